@@ -0,0 +1,186 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/agentplexus/go-elevenlabs/internal/api"
+)
+
+// AgentDefinition is the editable configuration of an agent: everything
+// needed to recreate it, without server-assigned fields such as its ID,
+// creation time, or phone numbers. Store it in version control to review
+// prompt changes and roll them back:
+//
+//	def, err := client.Agents().Export(ctx, agentID)
+//	data, err := def.ToJSON()
+//	os.WriteFile("agents/support.json", data, 0o644)
+//
+//	// Later, after review
+//	def, err = elevenlabs.AgentDefinitionFromJSON(data)
+//	err = client.Agents().Import(ctx, agentID, def)
+//
+// The configuration sections are kept as JSON, so settings this package
+// does not model survive the round trip.
+type AgentDefinition struct {
+	// Name is the agent's name.
+	Name string `json:"name"`
+
+	// Tags are labels for finding the agent.
+	Tags []string `json:"tags,omitempty"`
+
+	// ConversationConfig holds the prompt, LLM, voice, and conversation
+	// settings.
+	ConversationConfig json.RawMessage `json:"conversation_config,omitempty"`
+
+	// PlatformSettings holds the widget, evaluation, data collection, and
+	// privacy settings.
+	PlatformSettings json.RawMessage `json:"platform_settings,omitempty"`
+
+	// Workflow is the agent's workflow graph, if it has one.
+	Workflow json.RawMessage `json:"workflow,omitempty"`
+}
+
+// AgentDefinitionFromJSON decodes a definition written by ToJSON.
+func AgentDefinitionFromJSON(data []byte) (*AgentDefinition, error) {
+	var def AgentDefinition
+	if err := json.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("decoding agent definition: %w", err)
+	}
+	if def.Name == "" {
+		return nil, &ValidationError{Field: "name", Message: "cannot be empty"}
+	}
+	return &def, nil
+}
+
+// ToJSON encodes the definition as indented JSON with sorted keys, so
+// exports of the same configuration are identical and diffs show only
+// real changes.
+func (d *AgentDefinition) ToJSON() ([]byte, error) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	// Decode into generic values, whose maps encode with sorted keys.
+	// Numbers are kept as written.
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Export returns the definition of an agent.
+func (s *AgentsService) Export(ctx context.Context, agentID string) (*AgentDefinition, error) {
+	if agentID == "" {
+		return nil, &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+
+	var def AgentDefinition
+	if err := s.client.doJSON(ctx, "GET", agentPath(agentID), nil, &def); err != nil {
+		return nil, err
+	}
+	for _, raw := range []*json.RawMessage{&def.ConversationConfig, &def.PlatformSettings, &def.Workflow} {
+		if string(bytes.TrimSpace(*raw)) == "null" {
+			*raw = nil
+		}
+	}
+	return &def, nil
+}
+
+// Import replaces an agent's configuration with def, for example to roll
+// back to a reviewed version.
+func (s *AgentsService) Import(ctx context.Context, agentID string, def *AgentDefinition) error {
+	if agentID == "" {
+		return &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+	if err := validateAgentDefinition(def); err != nil {
+		return err
+	}
+	return s.client.doJSON(ctx, "PATCH", agentPath(agentID), def, nil)
+}
+
+// Create creates an agent from def, for example to deploy an exported
+// agent to another workspace. Returns the new agent's ID.
+func (s *AgentsService) Create(ctx context.Context, def *AgentDefinition) (string, error) {
+	if err := validateAgentDefinition(def); err != nil {
+		return "", err
+	}
+	if len(def.ConversationConfig) == 0 {
+		return "", &ValidationError{Field: "conversation_config", Message: "cannot be empty"}
+	}
+
+	var created struct {
+		AgentID string `json:"agent_id"`
+	}
+	if err := s.client.doJSON(ctx, "POST", "/v1/convai/agents/create", def, &created); err != nil {
+		return "", err
+	}
+	return created.AgentID, nil
+}
+
+// Duplicate copies an agent, including its configuration and knowledge
+// base, under a new name. An empty name lets the API choose one. Returns
+// the new agent's ID.
+func (s *AgentsService) Duplicate(ctx context.Context, agentID, name string) (string, error) {
+	if agentID == "" {
+		return "", &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+
+	var body api.OptBodyDuplicateAgentV1ConvaiAgentsAgentIDDuplicatePost
+	if name != "" {
+		body = api.NewOptBodyDuplicateAgentV1ConvaiAgentsAgentIDDuplicatePost(
+			api.BodyDuplicateAgentV1ConvaiAgentsAgentIDDuplicatePost{Name: api.NewOptNilString(name)},
+		)
+	}
+
+	resp, err := s.client.apiClient.DuplicateAgentRoute(ctx, body, api.DuplicateAgentRouteParams{
+		AgentID: agentID,
+	})
+	if err != nil {
+		return "", apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.CreateAgentResponseModel:
+		return r.AgentID, nil
+	case *api.HTTPValidationError:
+		return "", validationAPIError(r)
+	default:
+		return "", unexpectedResponse(resp)
+	}
+}
+
+func validateAgentDefinition(def *AgentDefinition) error {
+	if def == nil {
+		return &ValidationError{Field: "definition", Message: "cannot be nil"}
+	}
+	if def.Name == "" {
+		return &ValidationError{Field: "name", Message: "cannot be empty"}
+	}
+	sections := []struct {
+		field string
+		raw   json.RawMessage
+	}{
+		{"conversation_config", def.ConversationConfig},
+		{"platform_settings", def.PlatformSettings},
+		{"workflow", def.Workflow},
+	}
+	for _, sec := range sections {
+		if len(sec.raw) > 0 && !json.Valid(sec.raw) {
+			return &ValidationError{Field: sec.field, Message: "is not valid JSON"}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testAgentResponse = `{
+	"agent_id": "agent-1",
+	"name": "Support",
+	"tags": ["prod"],
+	"metadata": {"created_at_unix_secs": 1700000000},
+	"phone_numbers": [],
+	"access_info": {"is_creator": true},
+	"workflow": null,
+	"conversation_config": {"agent": {"prompt": {"prompt": "Be brief & kind.", "temperature": 0.30}, "first_message": "Hi {{name}}"}},
+	"platform_settings": {"privacy": {"record_voice": true}}
+}`
+
+func TestAgentsExportRoundTrip(t *testing.T) {
+	var patched []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/convai/agents/agent-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(testAgentResponse))
+		case http.MethodPatch:
+			patched, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	def, err := client.Agents().Export(ctx, "agent-1")
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if def.Name != "Support" || def.Workflow != nil {
+		t.Errorf("def = %+v", def)
+	}
+
+	data, err := def.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() error = %v", err)
+	}
+	out := string(data)
+	for _, field := range []string{"agent_id", "metadata", "phone_numbers", "access_info", "workflow"} {
+		if strings.Contains(out, `"`+field+`"`) {
+			t.Errorf("ToJSON() includes %s:\n%s", field, out)
+		}
+	}
+	// Keys are sorted, numbers and characters are kept as written
+	if strings.Index(out, `"first_message"`) > strings.Index(out, `"prompt"`) {
+		t.Errorf("ToJSON() keys are not sorted:\n%s", out)
+	}
+	if !strings.Contains(out, `0.30`) || !strings.Contains(out, `Be brief & kind.`) {
+		t.Errorf("ToJSON() changed values:\n%s", out)
+	}
+	again, _ := def.ToJSON()
+	if string(again) != out {
+		t.Error("ToJSON() is not deterministic")
+	}
+
+	restored, err := AgentDefinitionFromJSON(data)
+	if err != nil {
+		t.Fatalf("AgentDefinitionFromJSON() error = %v", err)
+	}
+	if err := client.Agents().Import(ctx, "agent-1", restored); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(patched, &body); err != nil {
+		t.Fatalf("decoding PATCH body: %v", err)
+	}
+	if _, ok := body["workflow"]; ok {
+		t.Errorf("PATCH body includes workflow: %s", patched)
+	}
+	if !jsonEqual(t, body["conversation_config"], def.ConversationConfig) {
+		t.Errorf("conversation_config = %s", body["conversation_config"])
+	}
+}
+
+func TestAgentsCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/convai/agents/create" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var def AgentDefinition
+		if err := json.NewDecoder(r.Body).Decode(&def); err != nil || def.Name != "Support copy" {
+			t.Errorf("body = %+v, %v", def, err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"agent_id": "agent-2"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	id, err := client.Agents().Create(context.Background(), &AgentDefinition{
+		Name:               "Support copy",
+		ConversationConfig: json.RawMessage(`{"agent": {"prompt": {"prompt": "Hi"}}}`),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if id != "agent-2" {
+		t.Errorf("Create() = %q, want agent-2", id)
+	}
+}
+
+func TestAgentsDuplicate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/convai/agents/agent-1/duplicate" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["name"] != "Support (staging)" {
+			t.Errorf("body = %v, %v", body, err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"agent_id": "agent-3"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	id, err := client.Agents().Duplicate(context.Background(), "agent-1", "Support (staging)")
+	if err != nil {
+		t.Fatalf("Duplicate() error = %v", err)
+	}
+	if id != "agent-3" {
+		t.Errorf("Duplicate() = %q, want agent-3", id)
+	}
+}
+
+func TestAgentDefinitionValidation(t *testing.T) {
+	client, _ := NewClient()
+	ctx := context.Background()
+	var valErr *ValidationError
+
+	if _, err := AgentDefinitionFromJSON([]byte(`{"conversation_config": {}}`)); !isValidationError(err, &valErr) {
+		t.Errorf("AgentDefinitionFromJSON() without name error = %v", err)
+	}
+	if err := client.Agents().Import(ctx, "agent-1", &AgentDefinition{Name: "x", PlatformSettings: json.RawMessage(`{`)}); !isValidationError(err, &valErr) || valErr.Field != "platform_settings" {
+		t.Errorf("Import() with invalid JSON error = %v", err)
+	}
+	if _, err := client.Agents().Create(ctx, &AgentDefinition{Name: "x"}); !isValidationError(err, &valErr) {
+		t.Errorf("Create() without conversation config error = %v", err)
+	}
+	if _, err := client.Agents().Duplicate(ctx, "", "x"); !isValidationError(err, &valErr) {
+		t.Errorf("Duplicate('') error = %v", err)
+	}
+}
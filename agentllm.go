@@ -0,0 +1,270 @@
+package elevenlabs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// LLMs for agents. The API accepts more models than listed here, including
+// dated versions such as "gpt-4o-2024-11-20".
+const (
+	LLMGPT4o             = "gpt-4o"
+	LLMGPT4oMini         = "gpt-4o-mini"
+	LLMGPT41             = "gpt-4.1"
+	LLMGPT41Mini         = "gpt-4.1-mini"
+	LLMGPT5              = "gpt-5"
+	LLMGPT5Mini          = "gpt-5-mini"
+	LLMClaudeSonnet45    = "claude-sonnet-4-5"
+	LLMClaudeSonnet4     = "claude-sonnet-4"
+	LLMClaudeHaiku45     = "claude-haiku-4-5"
+	LLMGemini25Flash     = "gemini-2.5-flash"
+	LLMGemini25FlashLite = "gemini-2.5-flash-lite"
+	LLMGemini20Flash     = "gemini-2.0-flash"
+	LLMCustom            = "custom-llm"
+)
+
+// Reasoning efforts for models that support them.
+const (
+	ReasoningEffortNone    = "none"
+	ReasoningEffortMinimal = "minimal"
+	ReasoningEffortLow     = "low"
+	ReasoningEffortMedium  = "medium"
+	ReasoningEffortHigh    = "high"
+)
+
+// Custom LLM API types.
+const (
+	CustomLLMChatCompletions = "chat_completions"
+	CustomLLMResponses       = "responses"
+)
+
+// CustomLLM is an OpenAI-compatible endpoint serving the agent's LLM.
+type CustomLLM struct {
+	// URL is the base URL of the Chat Completions or Responses endpoint.
+	URL string
+
+	// ModelID is the model to request, if the endpoint serves several.
+	ModelID string
+
+	// APIKeyRef is the ID of the secret in the workspace secret store
+	// holding the endpoint's API key. The key itself is never sent.
+	APIKeyRef string
+
+	// APIType is CustomLLMChatCompletions (default) or CustomLLMResponses.
+	APIType string
+
+	// APIVersion is the API version to request, for endpoints such as
+	// Azure OpenAI that require one.
+	APIVersion string
+
+	// Headers are extra request headers.
+	Headers map[string]string
+}
+
+// AgentLLMConfig is the LLM an agent uses to respond. Start from a
+// preset such as ClaudeLLM and adjust it:
+//
+//	cfg := elevenlabs.ClaudeLLM()
+//	cfg.Temperature = 0.3
+//	err := client.Agents().SetLLMConfig(ctx, agentID, cfg)
+type AgentLLMConfig struct {
+	// Model is one of the LLM constants, or LLMCustom with CustomLLM set.
+	Model string
+
+	// Temperature is the sampling temperature, from 0 to 2. Lower values
+	// give more consistent answers.
+	Temperature float64
+
+	// MaxTokens limits the length of each response. Zero means no limit.
+	MaxTokens int
+
+	// ReasoningEffort is one of the ReasoningEffort constants, for models
+	// that support reasoning. Empty uses the model default.
+	ReasoningEffort string
+
+	// ThinkingBudget is the maximum number of thinking tokens, for models
+	// that support it. Zero turns thinking off; nil uses the model
+	// default.
+	ThinkingBudget *int
+
+	// CustomLLM is the endpoint to use when Model is LLMCustom.
+	CustomLLM *CustomLLM
+}
+
+// GPT4oLLM returns a configuration for OpenAI GPT-4o.
+func GPT4oLLM() *AgentLLMConfig {
+	return &AgentLLMConfig{Model: LLMGPT4o, Temperature: 0.5}
+}
+
+// ClaudeLLM returns a configuration for Anthropic Claude Sonnet 4.5.
+func ClaudeLLM() *AgentLLMConfig {
+	return &AgentLLMConfig{Model: LLMClaudeSonnet45, Temperature: 0.5}
+}
+
+// GeminiLLM returns a configuration for Google Gemini 2.5 Flash, the
+// default model for new agents.
+func GeminiLLM() *AgentLLMConfig {
+	return &AgentLLMConfig{Model: LLMGemini25Flash, Temperature: 0.5}
+}
+
+// CustomLLMConfig returns a configuration for an OpenAI-compatible
+// endpoint, such as a self-hosted model or a proxy in front of one.
+func CustomLLMConfig(custom CustomLLM) *AgentLLMConfig {
+	return &AgentLLMConfig{Model: LLMCustom, Temperature: 0.5, CustomLLM: &custom}
+}
+
+// Validate checks the configuration for errors.
+func (c *AgentLLMConfig) Validate() error {
+	if c.Model == "" {
+		return &ValidationError{Field: "llm", Message: "cannot be empty"}
+	}
+	if c.Temperature < 0 || c.Temperature > 2 {
+		return &ValidationError{Field: "temperature", Message: "must be between 0 and 2"}
+	}
+	if c.MaxTokens < 0 {
+		return &ValidationError{Field: "max_tokens", Message: "cannot be negative"}
+	}
+	if c.ThinkingBudget != nil && *c.ThinkingBudget < 0 {
+		return &ValidationError{Field: "thinking_budget", Message: "cannot be negative"}
+	}
+	switch c.ReasoningEffort {
+	case "", ReasoningEffortNone, ReasoningEffortMinimal, ReasoningEffortLow, ReasoningEffortMedium, ReasoningEffortHigh:
+	default:
+		return &ValidationError{Field: "reasoning_effort", Message: fmt.Sprintf("unsupported value %q", c.ReasoningEffort)}
+	}
+
+	if c.Model != LLMCustom {
+		if c.CustomLLM != nil {
+			return &ValidationError{Field: "custom_llm", Message: "requires llm to be " + LLMCustom}
+		}
+		return nil
+	}
+	if c.CustomLLM == nil {
+		return &ValidationError{Field: "custom_llm", Message: "is required for " + LLMCustom}
+	}
+	u, err := url.Parse(c.CustomLLM.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return &ValidationError{Field: "custom_llm.url", Message: "must be an absolute http or https URL"}
+	}
+	switch c.CustomLLM.APIType {
+	case "", CustomLLMChatCompletions, CustomLLMResponses:
+	default:
+		return &ValidationError{Field: "custom_llm.api_type", Message: fmt.Sprintf("unsupported value %q", c.CustomLLM.APIType)}
+	}
+	return nil
+}
+
+type secretLocatorWire struct {
+	SecretID string `json:"secret_id"`
+}
+
+type customLLMWire struct {
+	URL            string             `json:"url"`
+	ModelID        string             `json:"model_id,omitempty"`
+	APIKey         *secretLocatorWire `json:"api_key,omitempty"`
+	APIType        string             `json:"api_type,omitempty"`
+	APIVersion     string             `json:"api_version,omitempty"`
+	RequestHeaders map[string]any     `json:"request_headers,omitempty"`
+}
+
+// agentLLMConfig is the agent config fragment holding the LLM settings.
+type agentLLMConfig struct {
+	ConversationConfig struct {
+		Agent struct {
+			Prompt struct {
+				LLM             string         `json:"llm"`
+				Temperature     float64        `json:"temperature"`
+				MaxTokens       int            `json:"max_tokens"`
+				ReasoningEffort string         `json:"reasoning_effort,omitempty"`
+				ThinkingBudget  *int           `json:"thinking_budget,omitempty"`
+				CustomLLM       *customLLMWire `json:"custom_llm"`
+			} `json:"prompt"`
+		} `json:"agent"`
+	} `json:"conversation_config"`
+}
+
+// GetLLMConfig returns the LLM configuration of an agent.
+func (s *AgentsService) GetLLMConfig(ctx context.Context, agentID string) (*AgentLLMConfig, error) {
+	if agentID == "" {
+		return nil, &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+
+	var agent agentLLMConfig
+	if err := s.client.doJSON(ctx, "GET", agentPath(agentID), nil, &agent); err != nil {
+		return nil, err
+	}
+
+	p := agent.ConversationConfig.Agent.Prompt
+	cfg := &AgentLLMConfig{
+		Model:           p.LLM,
+		Temperature:     p.Temperature,
+		MaxTokens:       max(p.MaxTokens, 0),
+		ReasoningEffort: p.ReasoningEffort,
+		ThinkingBudget:  p.ThinkingBudget,
+	}
+	if w := p.CustomLLM; w != nil {
+		cfg.CustomLLM = &CustomLLM{
+			URL:        w.URL,
+			ModelID:    w.ModelID,
+			APIType:    w.APIType,
+			APIVersion: w.APIVersion,
+		}
+		if w.APIKey != nil {
+			cfg.CustomLLM.APIKeyRef = w.APIKey.SecretID
+		}
+		for name, value := range w.RequestHeaders {
+			// Headers referencing secrets or dynamic variables are not
+			// plain strings and are left out
+			if v, ok := value.(string); ok {
+				if cfg.CustomLLM.Headers == nil {
+					cfg.CustomLLM.Headers = make(map[string]string)
+				}
+				cfg.CustomLLM.Headers[name] = v
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// SetLLMConfig replaces the LLM configuration of an agent.
+func (s *AgentsService) SetLLMConfig(ctx context.Context, agentID string, cfg *AgentLLMConfig) error {
+	if agentID == "" {
+		return &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+	if cfg == nil {
+		return &ValidationError{Field: "config", Message: "cannot be nil"}
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	var update agentLLMConfig
+	p := &update.ConversationConfig.Agent.Prompt
+	p.LLM = cfg.Model
+	p.Temperature = cfg.Temperature
+	p.MaxTokens = cfg.MaxTokens
+	if p.MaxTokens == 0 {
+		p.MaxTokens = -1
+	}
+	p.ReasoningEffort = cfg.ReasoningEffort
+	p.ThinkingBudget = cfg.ThinkingBudget
+	if c := cfg.CustomLLM; c != nil {
+		p.CustomLLM = &customLLMWire{
+			URL:        c.URL,
+			ModelID:    c.ModelID,
+			APIType:    c.APIType,
+			APIVersion: c.APIVersion,
+		}
+		if c.APIKeyRef != "" {
+			p.CustomLLM.APIKey = &secretLocatorWire{SecretID: c.APIKeyRef}
+		}
+		if len(c.Headers) > 0 {
+			p.CustomLLM.RequestHeaders = make(map[string]any, len(c.Headers))
+			for name, value := range c.Headers {
+				p.CustomLLM.RequestHeaders[name] = value
+			}
+		}
+	}
+	return s.client.doJSON(ctx, "PATCH", agentPath(agentID), &update, nil)
+}
@@ -0,0 +1,156 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAgentsGetLLMConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/convai/agents/agent-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"agent_id": "agent-1", "conversation_config": {"agent": {"prompt": {
+			"prompt": "You are helpful.",
+			"llm": "custom-llm",
+			"temperature": 0.2,
+			"max_tokens": -1,
+			"custom_llm": {
+				"url": "https://llm.example.com/v1",
+				"model_id": "llama-3-70b",
+				"api_key": {"secret_id": "secret-1"},
+				"api_type": "chat_completions",
+				"request_headers": {"X-Team": "voice", "Authorization": {"secret_id": "secret-2"}}
+			}
+		}}}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	cfg, err := client.Agents().GetLLMConfig(context.Background(), "agent-1")
+	if err != nil {
+		t.Fatalf("GetLLMConfig() error = %v", err)
+	}
+	if cfg.Model != LLMCustom || cfg.Temperature != 0.2 || cfg.MaxTokens != 0 {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	c := cfg.CustomLLM
+	if c == nil || c.URL != "https://llm.example.com/v1" || c.ModelID != "llama-3-70b" || c.APIKeyRef != "secret-1" {
+		t.Fatalf("CustomLLM = %+v", c)
+	}
+	if len(c.Headers) != 1 || c.Headers["X-Team"] != "voice" {
+		t.Errorf("Headers = %v", c.Headers)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestAgentsSetLLMConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *AgentLLMConfig
+		want string
+	}{
+		{
+			name: "preset",
+			cfg:  ClaudeLLM(),
+			want: `{"conversation_config":{"agent":{"prompt":{"llm":"claude-sonnet-4-5","temperature":0.5,"max_tokens":-1,"custom_llm":null}}}}`,
+		},
+		{
+			name: "custom",
+			cfg: &AgentLLMConfig{
+				Model:       LLMCustom,
+				MaxTokens:   300,
+				CustomLLM:   &CustomLLM{URL: "https://llm.example.com/v1", APIKeyRef: "secret-1"},
+				Temperature: 0,
+			},
+			want: `{"conversation_config":{"agent":{"prompt":{"llm":"custom-llm","temperature":0,"max_tokens":300,"custom_llm":{"url":"https://llm.example.com/v1","api_key":{"secret_id":"secret-1"}}}}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPatch || r.URL.Path != "/v1/convai/agents/agent-1" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				got, _ = io.ReadAll(r.Body)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			if err := client.Agents().SetLLMConfig(context.Background(), "agent-1", tt.cfg); err != nil {
+				t.Fatalf("SetLLMConfig() error = %v", err)
+			}
+			if !jsonEqual(t, got, []byte(tt.want)) {
+				t.Errorf("body = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func jsonEqual(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	var va, vb any
+	if err := json.Unmarshal(a, &va); err != nil {
+		t.Fatalf("decoding %s: %v", a, err)
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		t.Fatalf("decoding %s: %v", b, err)
+	}
+	ja, _ := json.Marshal(va)
+	jb, _ := json.Marshal(vb)
+	return string(ja) == string(jb)
+}
+
+func TestAgentLLMConfigValidate(t *testing.T) {
+	budget := -1
+	tests := []struct {
+		name  string
+		cfg   *AgentLLMConfig
+		field string
+	}{
+		{"presets", GPT4oLLM(), ""},
+		{"gemini", GeminiLLM(), ""},
+		{"custom preset", CustomLLMConfig(CustomLLM{URL: "https://llm.example.com/v1"}), ""},
+		{"empty model", &AgentLLMConfig{}, "llm"},
+		{"temperature", &AgentLLMConfig{Model: LLMGPT4o, Temperature: 2.5}, "temperature"},
+		{"max tokens", &AgentLLMConfig{Model: LLMGPT4o, MaxTokens: -5}, "max_tokens"},
+		{"thinking budget", &AgentLLMConfig{Model: LLMGemini25Flash, ThinkingBudget: &budget}, "thinking_budget"},
+		{"reasoning effort", &AgentLLMConfig{Model: LLMGPT5, ReasoningEffort: "extreme"}, "reasoning_effort"},
+		{"custom without endpoint", &AgentLLMConfig{Model: LLMCustom}, "custom_llm"},
+		{"endpoint without custom", &AgentLLMConfig{Model: LLMGPT4o, CustomLLM: &CustomLLM{URL: "https://x"}}, "custom_llm"},
+		{"relative url", CustomLLMConfig(CustomLLM{URL: "/v1"}), "custom_llm.url"},
+		{"api type", CustomLLMConfig(CustomLLM{URL: "https://x", APIType: "completions"}), "custom_llm.api_type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.field == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			var valErr *ValidationError
+			if !isValidationError(err, &valErr) || valErr.Field != tt.field {
+				t.Errorf("Validate() error = %v, want field %s", err, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/agentplexus/go-elevenlabs/internal/api"
+)
+
+// AgentsService handles conversational AI agent operations.
+type AgentsService struct {
+	client *Client
+}
+
+func agentPath(agentID string) string {
+	return "/v1/convai/agents/" + url.PathEscape(agentID)
+}
+
+// Evaluation criteria results.
+const (
+	EvaluationSuccess = "success"
+	EvaluationFailure = "failure"
+	EvaluationUnknown = "unknown"
+)
+
+// SimulationSpec describes a simulated conversation with an agent.
+type SimulationSpec struct {
+	// UserPrompt is the system prompt for the simulated user (required).
+	UserPrompt string
+
+	// UserFirstMessage is the simulated user's first message.
+	UserFirstMessage string
+
+	// UserLanguage is the simulated user's language (e.g., "en").
+	UserLanguage string
+
+	// DynamicVariables are values for {{variable}} placeholders in the
+	// agent prompt.
+	DynamicVariables map[string]any
+
+	// ToolMocks maps tool names to mocked results, so simulations do not
+	// call real tools.
+	ToolMocks map[string]SimulationToolMock
+
+	// History is a partial conversation to continue from.
+	History []SimulationMessage
+
+	// EvaluationCriteria are evaluated in addition to the agent's own criteria.
+	EvaluationCriteria []EvaluationCriterion
+
+	// NewTurnsLimit is the maximum number of new turns to simulate.
+	// Zero uses the API default.
+	NewTurnsLimit int
+}
+
+// SimulationToolMock is a mocked tool result used during simulation.
+type SimulationToolMock struct {
+	// ReturnValue is the result returned by the tool.
+	ReturnValue string `json:"default_return_value"`
+
+	// IsError reports the tool call as failed.
+	IsError bool `json:"default_is_error"`
+}
+
+// SimulationMessage is a turn in a simulated conversation.
+type SimulationMessage struct {
+	// Role is "user" or "agent".
+	Role string `json:"role"`
+
+	// Message is the text of the turn.
+	Message string `json:"message"`
+
+	// TimeInCallSecs is when the turn occurred, in seconds from the start.
+	TimeInCallSecs int `json:"time_in_call_secs"`
+}
+
+// EvaluationCriterion is a goal used to evaluate a conversation.
+type EvaluationCriterion struct {
+	// ID identifies the criterion in results.
+	ID string `json:"id"`
+
+	// Name is a human-readable name.
+	Name string `json:"name"`
+
+	// Prompt describes what a successful conversation achieves.
+	Prompt string `json:"conversation_goal_prompt"`
+}
+
+// EvaluationResult is the outcome of an evaluation criterion.
+type EvaluationResult struct {
+	// CriteriaID is the evaluated criterion.
+	CriteriaID string `json:"criteria_id"`
+
+	// Result is EvaluationSuccess, EvaluationFailure, or EvaluationUnknown.
+	Result string `json:"result"`
+
+	// Rationale explains the result.
+	Rationale string `json:"rationale"`
+}
+
+// DataCollectionResult is a value extracted from a conversation.
+type DataCollectionResult struct {
+	// DataCollectionID is the data collection field.
+	DataCollectionID string `json:"data_collection_id"`
+
+	// Value is the extracted value, or nil if none was found.
+	Value any `json:"value"`
+
+	// Rationale explains how the value was extracted.
+	Rationale string `json:"rationale"`
+}
+
+// ConversationAnalysis is the post-call analysis of a conversation.
+type ConversationAnalysis struct {
+	// CallSuccessful is EvaluationSuccess, EvaluationFailure, or EvaluationUnknown.
+	CallSuccessful string `json:"call_successful"`
+
+	// CallSummaryTitle is a short title for the conversation.
+	CallSummaryTitle string `json:"call_summary_title,omitempty"`
+
+	// TranscriptSummary summarizes the conversation.
+	TranscriptSummary string `json:"transcript_summary"`
+
+	// EvaluationCriteriaResults maps criterion IDs to results.
+	EvaluationCriteriaResults map[string]EvaluationResult `json:"evaluation_criteria_results"`
+
+	// DataCollectionResults maps data collection IDs to extracted values.
+	DataCollectionResults map[string]DataCollectionResult `json:"data_collection_results"`
+}
+
+// FailedCriteria returns the evaluation results that did not succeed,
+// sorted by criterion ID.
+func (a *ConversationAnalysis) FailedCriteria() []EvaluationResult {
+	var failed []EvaluationResult
+	for _, id := range sortedResultIDs(a.EvaluationCriteriaResults) {
+		if r := a.EvaluationCriteriaResults[id]; r.Result != EvaluationSuccess {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// Passed reports whether the call was successful and every evaluation
+// criterion succeeded.
+func (a *ConversationAnalysis) Passed() bool {
+	return a.CallSuccessful == EvaluationSuccess && len(a.FailedCriteria()) == 0
+}
+
+func sortedResultIDs(results map[string]EvaluationResult) []string {
+	ids := make([]string, 0, len(results))
+	for id := range results {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// SimulationResult is the result of a simulated conversation.
+type SimulationResult struct {
+	// Conversation is the simulated transcript, including any history.
+	Conversation []SimulationMessage `json:"simulated_conversation"`
+
+	// Analysis is the evaluation of the simulated conversation.
+	Analysis ConversationAnalysis `json:"analysis"`
+}
+
+// simulationRequest is the API request body for Simulate.
+type simulationRequest struct {
+	Specification struct {
+		SimulatedUserConfig struct {
+			Prompt       promptOverrideWire `json:"prompt"`
+			FirstMessage string             `json:"first_message,omitempty"`
+			Language     string             `json:"language,omitempty"`
+		} `json:"simulated_user_config"`
+		ToolMockConfig             map[string]SimulationToolMock `json:"tool_mock_config,omitempty"`
+		PartialConversationHistory []SimulationMessage           `json:"partial_conversation_history,omitempty"`
+		DynamicVariables           map[string]any                `json:"dynamic_variables,omitempty"`
+	} `json:"simulation_specification"`
+	ExtraEvaluationCriteria []simulationCriterion `json:"extra_evaluation_criteria,omitempty"`
+	NewTurnsLimit           int                   `json:"new_turns_limit,omitempty"`
+}
+
+type simulationCriterion struct {
+	EvaluationCriterion
+	Type string `json:"type"`
+}
+
+// Simulate runs a conversation between an agent and a simulated user and
+// returns the transcript and evaluation. Use it in CI to catch regressions
+// in agent prompts:
+//
+//	result, err := client.Agents().Simulate(ctx, agentID, &elevenlabs.SimulationSpec{
+//	    UserPrompt: "You want to cancel your subscription.",
+//	    EvaluationCriteria: []elevenlabs.EvaluationCriterion{
+//	        {ID: "offered_retention", Name: "Offered retention", Prompt: "The agent offers a discount before cancelling."},
+//	    },
+//	})
+//	if err == nil && !result.Analysis.Passed() {
+//	    // report result.Analysis.FailedCriteria()
+//	}
+func (s *AgentsService) Simulate(ctx context.Context, agentID string, spec *SimulationSpec) (*SimulationResult, error) {
+	if agentID == "" {
+		return nil, &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+	if spec == nil || spec.UserPrompt == "" {
+		return nil, &ValidationError{Field: "user_prompt", Message: "cannot be empty"}
+	}
+	if spec.NewTurnsLimit < 0 {
+		return nil, &ValidationError{Field: "new_turns_limit", Message: "cannot be negative"}
+	}
+	for i, m := range spec.History {
+		if m.Role != "user" && m.Role != "agent" {
+			return nil, &ValidationError{Field: "history", Message: fmt.Sprintf("message %d has invalid role %q", i, m.Role)}
+		}
+	}
+	for i, c := range spec.EvaluationCriteria {
+		if c.ID == "" || c.Prompt == "" {
+			return nil, &ValidationError{Field: "evaluation_criteria", Message: fmt.Sprintf("criterion %d requires an ID and prompt", i)}
+		}
+	}
+	if err := (&ConversationInitiationData{DynamicVariables: spec.DynamicVariables}).Validate(); err != nil {
+		return nil, err
+	}
+
+	var body simulationRequest
+	user := &body.Specification.SimulatedUserConfig
+	user.Prompt = promptOverrideWire{Prompt: spec.UserPrompt}
+	user.FirstMessage = spec.UserFirstMessage
+	user.Language = spec.UserLanguage
+	body.Specification.ToolMockConfig = spec.ToolMocks
+	body.Specification.PartialConversationHistory = spec.History
+	body.Specification.DynamicVariables = spec.DynamicVariables
+	for _, c := range spec.EvaluationCriteria {
+		body.ExtraEvaluationCriteria = append(body.ExtraEvaluationCriteria, simulationCriterion{EvaluationCriterion: c, Type: "prompt"})
+	}
+	body.NewTurnsLimit = spec.NewTurnsLimit
+
+	var result SimulationResult
+	path := agentPath(agentID) + "/simulate-conversation"
+	if err := s.client.doJSON(ctx, "POST", path, &body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Conversation feedback scores.
+const (
+	FeedbackLike    = "like"
+	FeedbackDislike = "dislike"
+)
+
+// ConversationFeedback is feedback on an agent conversation.
+type ConversationFeedback struct {
+	// Score is FeedbackLike (thumbs up) or FeedbackDislike (thumbs down).
+	Score string `json:"feedback"`
+
+	// Rating is an optional rating from 1 to 5.
+	Rating int `json:"rating,omitempty"`
+
+	// Comment is an optional free-text comment.
+	Comment string `json:"comment,omitempty"`
+}
+
+// SubmitFeedback records feedback on a conversation, e.g. from a call
+// center supervisor reviewing agent quality.
+func (s *AgentsService) SubmitFeedback(ctx context.Context, conversationID string, feedback *ConversationFeedback) error {
+	if conversationID == "" {
+		return &ValidationError{Field: "conversation_id", Message: "cannot be empty"}
+	}
+	if feedback == nil || (feedback.Score != FeedbackLike && feedback.Score != FeedbackDislike) {
+		return &ValidationError{Field: "feedback", Message: "must be \"like\" or \"dislike\""}
+	}
+	if feedback.Rating < 0 || feedback.Rating > 5 {
+		return &ValidationError{Field: "rating", Message: "must be between 1 and 5"}
+	}
+
+	path := "/v1/convai/conversations/" + url.PathEscape(conversationID) + "/feedback"
+	return s.client.doJSON(ctx, "POST", path, feedback, nil)
+}
+
+// GetConversationAnalysis returns the evaluation criteria results and data
+// collection fields of a conversation. The analysis is empty until the
+// conversation has ended and been analyzed.
+func (s *AgentsService) GetConversationAnalysis(ctx context.Context, conversationID string) (*ConversationAnalysis, error) {
+	if conversationID == "" {
+		return nil, &ValidationError{Field: "conversation_id", Message: "cannot be empty"}
+	}
+
+	resp, err := s.client.apiClient.GetConversationHistoryRoute(ctx, api.GetConversationHistoryRouteParams{
+		ConversationID: conversationID,
+	})
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.GetConversationResponseModel:
+		analysis := &ConversationAnalysis{}
+		if !r.Analysis.Set {
+			return analysis, nil
+		}
+		a := r.Analysis.Value
+
+		analysis.CallSuccessful = string(a.CallSuccessful)
+		analysis.TranscriptSummary = a.TranscriptSummary
+		if a.CallSummaryTitle.Set && !a.CallSummaryTitle.Null {
+			analysis.CallSummaryTitle = a.CallSummaryTitle.Value
+		}
+
+		if a.EvaluationCriteriaResults.Set {
+			analysis.EvaluationCriteriaResults = make(map[string]EvaluationResult, len(a.EvaluationCriteriaResults.Value))
+			for id, e := range a.EvaluationCriteriaResults.Value {
+				analysis.EvaluationCriteriaResults[id] = EvaluationResult{
+					CriteriaID: e.CriteriaID,
+					Result:     string(e.Result),
+					Rationale:  e.Rationale,
+				}
+			}
+		}
+
+		if a.DataCollectionResults.Set {
+			analysis.DataCollectionResults = make(map[string]DataCollectionResult, len(a.DataCollectionResults.Value))
+			for id, d := range a.DataCollectionResults.Value {
+				result := DataCollectionResult{
+					DataCollectionID: d.DataCollectionID,
+					Rationale:        d.Rationale,
+				}
+				if len(d.Value) > 0 {
+					if err := json.Unmarshal(d.Value, &result.Value); err != nil {
+						return nil, fmt.Errorf("failed to decode data collection %s: %w", id, err)
+					}
+				}
+				analysis.DataCollectionResults[id] = result
+			}
+		}
+
+		return analysis, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
+	default:
+		return nil, unexpectedResponse(resp)
+	}
+}
@@ -0,0 +1,210 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAgentsSimulate(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/convai/agents/agent-1/simulate-conversation" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"simulated_conversation": [
+				{"role": "agent", "message": "Hi, how can I help?", "time_in_call_secs": 0},
+				{"role": "user", "message": "Cancel my plan.", "time_in_call_secs": 3}
+			],
+			"analysis": {
+				"call_successful": "success",
+				"transcript_summary": "The user asked to cancel.",
+				"evaluation_criteria_results": {
+					"polite": {"criteria_id": "polite", "result": "success", "rationale": "Greeted the user."},
+					"offered_retention": {"criteria_id": "offered_retention", "result": "failure", "rationale": "No discount offered."}
+				},
+				"data_collection_results": {
+					"reason": {"data_collection_id": "reason", "value": "price", "rationale": "Stated by user."}
+				}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	result, err := client.Agents().Simulate(context.Background(), "agent-1", &SimulationSpec{
+		UserPrompt:    "You want to cancel your subscription.",
+		ToolMocks:     map[string]SimulationToolMock{"lookup_account": {ReturnValue: `{"plan":"pro"}`}},
+		NewTurnsLimit: 5,
+		EvaluationCriteria: []EvaluationCriterion{
+			{ID: "offered_retention", Name: "Offered retention", Prompt: "The agent offers a discount."},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Simulate() error = %v", err)
+	}
+
+	spec, _ := body["simulation_specification"].(map[string]any)
+	user, _ := spec["simulated_user_config"].(map[string]any)
+	if prompt, _ := user["prompt"].(map[string]any); prompt["prompt"] != "You want to cancel your subscription." {
+		t.Errorf("simulated_user_config = %v", user)
+	}
+	if _, ok := spec["tool_mock_config"].(map[string]any)["lookup_account"]; !ok {
+		t.Errorf("tool_mock_config = %v", spec["tool_mock_config"])
+	}
+	criteria, _ := body["extra_evaluation_criteria"].([]any)
+	if len(criteria) != 1 || criteria[0].(map[string]any)["type"] != "prompt" {
+		t.Errorf("extra_evaluation_criteria = %v", body["extra_evaluation_criteria"])
+	}
+	if body["new_turns_limit"] != float64(5) {
+		t.Errorf("new_turns_limit = %v", body["new_turns_limit"])
+	}
+
+	if len(result.Conversation) != 2 || result.Conversation[1].Role != "user" {
+		t.Errorf("Conversation = %+v", result.Conversation)
+	}
+	if result.Analysis.Passed() {
+		t.Error("Passed() = true with a failed criterion")
+	}
+	failed := result.Analysis.FailedCriteria()
+	if len(failed) != 1 || failed[0].CriteriaID != "offered_retention" {
+		t.Errorf("FailedCriteria() = %+v", failed)
+	}
+	if got := result.Analysis.DataCollectionResults["reason"].Value; got != "price" {
+		t.Errorf("data collection value = %v, want price", got)
+	}
+}
+
+func TestAgentsSimulateValidation(t *testing.T) {
+	client, err := NewClient(WithAPIKey("test-key"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		agentID string
+		spec    *SimulationSpec
+		field   string
+	}{
+		{"empty agent ID", "", &SimulationSpec{UserPrompt: "p"}, "agent_id"},
+		{"nil spec", "agent-1", nil, "user_prompt"},
+		{"invalid role", "agent-1", &SimulationSpec{UserPrompt: "p", History: []SimulationMessage{{Role: "bot"}}}, "history"},
+		{"criterion without prompt", "agent-1", &SimulationSpec{UserPrompt: "p", EvaluationCriteria: []EvaluationCriterion{{ID: "x"}}}, "evaluation_criteria"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Agents().Simulate(ctx, tt.agentID, tt.spec)
+			var valErr *ValidationError
+			if !isValidationError(err, &valErr) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if valErr.Field != tt.field {
+				t.Errorf("Field = %q, want %q", valErr.Field, tt.field)
+			}
+		})
+	}
+}
+
+func TestAgentsConversationFeedbackAndAnalysis(t *testing.T) {
+	var feedback map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/convai/conversations/conv-1/feedback":
+			if err := json.NewDecoder(r.Body).Decode(&feedback); err != nil {
+				t.Errorf("decoding body: %v", err)
+			}
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/convai/conversations/conv-1":
+			_, _ = w.Write([]byte(`{
+				"agent_id": "agent-1",
+				"conversation_id": "conv-1",
+				"status": "done",
+				"has_audio": false,
+				"has_user_audio": false,
+				"has_response_audio": false,
+				"transcript": [],
+				"metadata": {"start_time_unix_secs": 1700000000, "call_duration_secs": 42},
+				"analysis": {
+					"call_successful": "success",
+					"call_summary_title": "Billing question",
+					"transcript_summary": "The caller asked about an invoice.",
+					"evaluation_criteria_results": {
+						"resolved": {"criteria_id": "resolved", "result": "success", "rationale": "Answered."}
+					},
+					"data_collection_results": {
+						"invoice_total": {"data_collection_id": "invoice_total", "value": 129.5, "rationale": "Read from invoice."}
+					}
+				}
+			}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	err = client.Agents().SubmitFeedback(ctx, "conv-1", &ConversationFeedback{
+		Score:   FeedbackDislike,
+		Rating:  2,
+		Comment: "Agent interrupted the caller.",
+	})
+	if err != nil {
+		t.Fatalf("SubmitFeedback() error = %v", err)
+	}
+	if feedback["feedback"] != "dislike" || feedback["rating"] != float64(2) || feedback["comment"] != "Agent interrupted the caller." {
+		t.Errorf("feedback body = %v", feedback)
+	}
+
+	analysis, err := client.Agents().GetConversationAnalysis(ctx, "conv-1")
+	if err != nil {
+		t.Fatalf("GetConversationAnalysis() error = %v", err)
+	}
+	if !analysis.Passed() {
+		t.Errorf("Passed() = false, analysis = %+v", analysis)
+	}
+	if analysis.CallSummaryTitle != "Billing question" {
+		t.Errorf("CallSummaryTitle = %q", analysis.CallSummaryTitle)
+	}
+	if got := analysis.DataCollectionResults["invoice_total"].Value; got != 129.5 {
+		t.Errorf("invoice_total = %v, want 129.5", got)
+	}
+}
+
+func TestAgentsSubmitFeedbackValidation(t *testing.T) {
+	client, err := NewClient(WithAPIKey("test-key"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	var valErr *ValidationError
+	if err := client.Agents().SubmitFeedback(ctx, "", &ConversationFeedback{Score: FeedbackLike}); !isValidationError(err, &valErr) {
+		t.Errorf("expected ValidationError for empty conversation ID, got %v", err)
+	}
+	if err := client.Agents().SubmitFeedback(ctx, "conv-1", &ConversationFeedback{Score: "meh"}); !isValidationError(err, &valErr) {
+		t.Errorf("expected ValidationError for invalid score, got %v", err)
+	}
+	if err := client.Agents().SubmitFeedback(ctx, "conv-1", &ConversationFeedback{Score: FeedbackLike, Rating: 6}); !isValidationError(err, &valErr) {
+		t.Errorf("expected ValidationError for invalid rating, got %v", err)
+	}
+}
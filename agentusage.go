@@ -0,0 +1,316 @@
+package elevenlabs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MetricLLMTimeToFirstByte is the turn metric holding the time until the
+// LLM returned its first token.
+const MetricLLMTimeToFirstByte = "convai_llm_service_ttfb"
+
+// ConversationCharging holds the billing details of a conversation.
+type ConversationCharging struct {
+	// LLMPrice is the dollar cost of the LLM usage.
+	LLMPrice float64 `json:"llm_price"`
+
+	// LLMCharge is the number of credits charged for the LLM usage.
+	LLMCharge int `json:"llm_charge"`
+
+	// CallCharge is the number of credits charged for the call.
+	CallCharge int `json:"call_charge"`
+
+	// LLMUsage is the token usage by model. Nil if not reported.
+	LLMUsage *LLMUsage `json:"llm_usage,omitempty"`
+}
+
+// LLMUsage is the LLM token usage of a conversation.
+type LLMUsage struct {
+	// IrreversibleGeneration is the usage of generations that were
+	// completed and billed.
+	IrreversibleGeneration *LLMGenerationUsage `json:"irreversible_generation,omitempty"`
+
+	// InitiatedGeneration is the usage of all generations started,
+	// including those cut off by interruptions.
+	InitiatedGeneration *LLMGenerationUsage `json:"initiated_generation,omitempty"`
+}
+
+// LLMGenerationUsage is token usage by model.
+type LLMGenerationUsage struct {
+	// ModelUsage is the usage of each model, by model name.
+	ModelUsage map[string]LLMModelUsage `json:"model_usage"`
+}
+
+// LLMModelUsage is the token usage of one model.
+type LLMModelUsage struct {
+	Input           LLMTokenUsage `json:"input"`
+	InputCacheRead  LLMTokenUsage `json:"input_cache_read"`
+	InputCacheWrite LLMTokenUsage `json:"input_cache_write"`
+	OutputTotal     LLMTokenUsage `json:"output_total"`
+}
+
+// LLMTokenUsage is a number of tokens and their dollar price.
+type LLMTokenUsage struct {
+	Tokens int     `json:"tokens"`
+	Price  float64 `json:"price"`
+}
+
+// ConversationTurnMetrics holds the latencies of a turn.
+type ConversationTurnMetrics struct {
+	// Metrics are the latencies by metric name, such as
+	// MetricLLMTimeToFirstByte.
+	Metrics map[string]ConversationMetric `json:"metrics"`
+}
+
+// ConversationMetric is a latency measurement.
+type ConversationMetric struct {
+	// ElapsedTime is the latency in seconds.
+	ElapsedTime float64 `json:"elapsed_time"`
+}
+
+// Duration returns the latency as a duration.
+func (m ConversationMetric) Duration() time.Duration {
+	return time.Duration(m.ElapsedTime * float64(time.Second))
+}
+
+// LLMUsageTotals is LLM token usage and its cost.
+type LLMUsageTotals struct {
+	// InputTokens is the number of input tokens, excluding cache reads.
+	InputTokens int
+
+	// CachedInputTokens is the number of input tokens read from the cache.
+	CachedInputTokens int
+
+	// OutputTokens is the number of output tokens.
+	OutputTokens int
+
+	// Cost is the dollar cost.
+	Cost float64
+}
+
+func (t *LLMUsageTotals) add(o LLMUsageTotals) {
+	t.InputTokens += o.InputTokens
+	t.CachedInputTokens += o.CachedInputTokens
+	t.OutputTokens += o.OutputTokens
+	t.Cost += o.Cost
+}
+
+// ConversationLLMUsage is the LLM usage and latency of a conversation.
+type ConversationLLMUsage struct {
+	// Models is the usage of each model, by model name.
+	Models map[string]LLMUsageTotals
+
+	// Total is the usage of all models. Its Cost is the billed LLM price
+	// if the API reported one, else the sum of the token prices.
+	Total LLMUsageTotals
+
+	// Credits is the number of credits charged for the LLM usage.
+	Credits int
+
+	// TimeToFirstByte is the LLM latency of each agent turn that reported
+	// it, in order.
+	TimeToFirstByte []time.Duration
+}
+
+// LLMUsage returns the LLM usage and latency of the conversation from its
+// charging metadata and turn metrics. Usage counts the billed generations
+// (IrreversibleGeneration). Conversations from before the API reported
+// usage return zero totals.
+func (c *Conversation) LLMUsage() *ConversationLLMUsage {
+	usage := &ConversationLLMUsage{Models: make(map[string]LLMUsageTotals)}
+	if ch := c.Metadata.Charging; ch != nil {
+		usage.Credits = ch.LLMCharge
+		if ch.LLMUsage != nil && ch.LLMUsage.IrreversibleGeneration != nil {
+			for model, mu := range ch.LLMUsage.IrreversibleGeneration.ModelUsage {
+				totals := LLMUsageTotals{
+					InputTokens:       mu.Input.Tokens + mu.InputCacheWrite.Tokens,
+					CachedInputTokens: mu.InputCacheRead.Tokens,
+					OutputTokens:      mu.OutputTotal.Tokens,
+					Cost:              mu.Input.Price + mu.InputCacheRead.Price + mu.InputCacheWrite.Price + mu.OutputTotal.Price,
+				}
+				usage.Models[model] = totals
+				usage.Total.add(totals)
+			}
+		}
+		if ch.LLMPrice > 0 {
+			usage.Total.Cost = ch.LLMPrice
+		}
+	}
+	for _, turn := range c.Transcript {
+		if turn.Metrics == nil {
+			continue
+		}
+		if m, ok := turn.Metrics.Metrics[MetricLLMTimeToFirstByte]; ok {
+			usage.TimeToFirstByte = append(usage.TimeToFirstByte, m.Duration())
+		}
+	}
+	return usage
+}
+
+// AgentUsageLine is the LLM usage of one agent.
+type AgentUsageLine struct {
+	// AgentID is the agent.
+	AgentID string
+
+	// AgentName is the name of the agent.
+	AgentName string
+
+	// Conversations is the number of conversations.
+	Conversations int
+
+	// CallDuration is the total length of the conversations.
+	CallDuration time.Duration
+
+	// LLMUsageTotals is the LLM usage of all conversations.
+	LLMUsageTotals
+
+	// Credits is the number of credits charged for the LLM usage.
+	Credits int
+
+	// MedianTimeToFirstByte and P95TimeToFirstByte are the LLM latencies
+	// of the agent turns. Zero if no turn reported latency.
+	MedianTimeToFirstByte time.Duration
+	P95TimeToFirstByte    time.Duration
+}
+
+// LLMModelUsageLine is the usage of one LLM across agents.
+type LLMModelUsageLine struct {
+	// Model is the model name.
+	Model string
+
+	// LLMUsageTotals is the usage of the model.
+	LLMUsageTotals
+}
+
+// AgentUsageReport is the LLM usage of agent conversations over a period.
+// Lines are sorted by cost, highest first.
+type AgentUsageReport struct {
+	// Period is the reported period.
+	Period UsagePeriod
+
+	// ByAgent is the usage per agent.
+	ByAgent []AgentUsageLine
+
+	// ByModel is the usage per LLM.
+	ByModel []LLMModelUsageLine
+
+	// Conversations is the number of conversations.
+	Conversations int
+
+	// Total is the usage of all conversations.
+	Total LLMUsageTotals
+
+	// Credits is the number of credits charged for the LLM usage.
+	Credits int
+}
+
+// UsageReport returns the LLM token usage, cost, and latency of the
+// conversations started in period, per agent and per model, for budgeting
+// conversational deployments:
+//
+//	report, err := client.Agents().UsageReport(ctx, elevenlabs.UsageMonth(time.Now()))
+//	for _, line := range report.ByAgent {
+//	    fmt.Printf("%s: %d conversations, $%.2f, p95 %v\n",
+//	        line.AgentName, line.Conversations, line.Cost, line.P95TimeToFirstByte)
+//	}
+//
+// Usage is only reported in conversation details, so UsageReport reads
+// each conversation, one request per conversation.
+func (s *AgentsService) UsageReport(ctx context.Context, period UsagePeriod) (*AgentUsageReport, error) {
+	if err := period.validate(); err != nil {
+		return nil, err
+	}
+
+	report := &AgentUsageReport{Period: period}
+	agents := make(map[string]*AgentUsageLine)
+	latencies := make(map[string][]time.Duration)
+	models := make(map[string]*LLMModelUsageLine)
+
+	// The API filters by whole seconds, so widen the range and filter
+	// exactly here
+	opts := &ConversationListOptions{
+		StartedAfter:  period.Start.Add(-time.Second),
+		StartedBefore: period.End.Add(time.Second),
+		PageSize:      100,
+	}
+	for {
+		page, err := s.ListConversations(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range page.Conversations {
+			if summary.StartTime.Before(period.Start.Truncate(time.Second)) || summary.StartTime.After(period.End) {
+				continue
+			}
+			conv, err := s.GetConversation(ctx, summary.ConversationID)
+			if err != nil {
+				return nil, fmt.Errorf("reading conversation %s: %w", summary.ConversationID, err)
+			}
+			usage := conv.LLMUsage()
+
+			line := agents[summary.AgentID]
+			if line == nil {
+				line = &AgentUsageLine{AgentID: summary.AgentID, AgentName: summary.AgentName}
+				agents[summary.AgentID] = line
+			}
+			line.Conversations++
+			line.CallDuration += time.Duration(summary.CallDurationSecs) * time.Second
+			line.add(usage.Total)
+			line.Credits += usage.Credits
+			latencies[summary.AgentID] = append(latencies[summary.AgentID], usage.TimeToFirstByte...)
+
+			for model, totals := range usage.Models {
+				if models[model] == nil {
+					models[model] = &LLMModelUsageLine{Model: model}
+				}
+				models[model].add(totals)
+			}
+
+			report.Conversations++
+			report.Total.add(usage.Total)
+			report.Credits += usage.Credits
+		}
+		if !page.HasMore || page.NextCursor == "" {
+			break
+		}
+		opts.Cursor = page.NextCursor
+	}
+
+	for agentID, line := range agents {
+		line.MedianTimeToFirstByte = percentile(latencies[agentID], 50)
+		line.P95TimeToFirstByte = percentile(latencies[agentID], 95)
+		report.ByAgent = append(report.ByAgent, *line)
+	}
+	sort.Slice(report.ByAgent, func(i, j int) bool {
+		a, b := report.ByAgent[i], report.ByAgent[j]
+		if a.Cost != b.Cost {
+			return a.Cost > b.Cost
+		}
+		return a.AgentID < b.AgentID
+	})
+	for _, line := range models {
+		report.ByModel = append(report.ByModel, *line)
+	}
+	sort.Slice(report.ByModel, func(i, j int) bool {
+		a, b := report.ByModel[i], report.ByModel[j]
+		if a.Cost != b.Cost {
+			return a.Cost > b.Cost
+		}
+		return a.Model < b.Model
+	})
+	return report, nil
+}
+
+// percentile returns the p-th percentile of durations by the nearest-rank
+// method, or zero if there are none.
+func percentile(durations []time.Duration, p int) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
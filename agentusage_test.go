@@ -0,0 +1,179 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// conversationUsageJSON returns conversation details with LLM usage of one
+// model and a turn per latency, in milliseconds.
+func conversationUsageJSON(id, model string, input, cached, output int, price float64, latenciesMs ...int) string {
+	var turns []string
+	for _, ms := range latenciesMs {
+		turns = append(turns, fmt.Sprintf(`{"role": "agent", "message": "Hi", "time_in_call_secs": 1,
+			"conversation_turn_metrics": {"metrics": {"convai_llm_service_ttfb": {"elapsed_time": %g},
+			"convai_tts_service_ttfb": {"elapsed_time": 0.2}}}}`, float64(ms)/1000))
+	}
+	turns = append(turns, `{"role": "user", "message": "Hello", "time_in_call_secs": 0}`)
+	return fmt.Sprintf(`{"conversation_id": %q, "agent_id": "agent-1", "status": "done",
+		"transcript": [%s],
+		"metadata": {"start_time_unix_secs": 1700000000, "call_duration_secs": 60, "cost": 500,
+			"charging": {"llm_price": %g, "llm_charge": 40, "call_charge": 460, "llm_usage": {
+				"irreversible_generation": {"model_usage": {%q: {
+					"input": {"tokens": %d, "price": 0.01}, "input_cache_read": {"tokens": %d, "price": 0.001},
+					"output_total": {"tokens": %d, "price": 0.02}}}},
+				"initiated_generation": {"model_usage": {}}}}}}`,
+		id, strings.Join(turns, ", "), price, model, input, cached, output)
+}
+
+func TestConversationLLMUsage(t *testing.T) {
+	var conv Conversation
+	if err := json.Unmarshal([]byte(conversationUsageJSON("c-1", "gpt-4o", 1000, 200, 300, 0.05, 400, 600)), &conv); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if conv.Metadata.Cost != 500 || conv.Metadata.Charging == nil || conv.Metadata.Charging.CallCharge != 460 {
+		t.Errorf("Metadata = %+v", conv.Metadata)
+	}
+
+	usage := conv.LLMUsage()
+	want := LLMUsageTotals{InputTokens: 1000, CachedInputTokens: 200, OutputTokens: 300, Cost: 0.05}
+	if usage.Total != want {
+		t.Errorf("Total = %+v, want %+v", usage.Total, want)
+	}
+	if got := usage.Models["gpt-4o"]; got.InputTokens != 1000 || got.Cost < 0.0309 || got.Cost > 0.0311 {
+		t.Errorf("Models[gpt-4o] = %+v", got)
+	}
+	if usage.Credits != 40 {
+		t.Errorf("Credits = %d, want 40", usage.Credits)
+	}
+	if len(usage.TimeToFirstByte) != 2 || usage.TimeToFirstByte[1] != 600*time.Millisecond {
+		t.Errorf("TimeToFirstByte = %v", usage.TimeToFirstByte)
+	}
+
+	// Without a billed price, the token prices are summed
+	conv.Metadata.Charging.LLMPrice = 0
+	if got := conv.LLMUsage().Total.Cost; got < 0.0309 || got > 0.0311 {
+		t.Errorf("Total.Cost without llm_price = %v, want 0.031", got)
+	}
+
+	// Older conversations have no charging metadata
+	if got := (&Conversation{}).LLMUsage(); got.Total != (LLMUsageTotals{}) || len(got.Models) != 0 {
+		t.Errorf("LLMUsage() without charging = %+v", got)
+	}
+}
+
+func TestAgentsUsageReport(t *testing.T) {
+	period := UsagePeriod{Start: time.Unix(1700000000, 0), End: time.Unix(1700086399, 0)}
+	summary := func(id, agentID, agentName string, start int64) string {
+		return fmt.Sprintf(`{"agent_id": %q, "agent_name": %q, "conversation_id": %q, "status": "done",
+			"call_successful": "success", "start_time_unix_secs": %d, "call_duration_secs": 60, "message_count": 4}`,
+			agentID, agentName, id, start)
+	}
+	details := map[string]string{
+		"c-1": conversationUsageJSON("c-1", "gpt-4o", 1000, 0, 100, 0.10, 300, 500),
+		"c-2": conversationUsageJSON("c-2", "gemini-2.0-flash", 2000, 500, 200, 0.02, 900),
+		"c-3": conversationUsageJSON("c-3", "gpt-4o", 3000, 0, 300, 0.30, 100),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/convai/conversations":
+			if got := r.URL.Query().Get("call_start_after_unix"); got != "1699999999" {
+				t.Errorf("call_start_after_unix = %s", got)
+			}
+			if r.URL.Query().Get("cursor") == "" {
+				fmt.Fprintf(w, `{"conversations": [%s, %s], "has_more": true, "next_cursor": "page-2"}`,
+					summary("c-1", "agent-1", "Support", 1700000100), summary("c-2", "agent-2", "Sales", 1700000200))
+				return
+			}
+			// The API filters by whole seconds; c-out is outside the period
+			fmt.Fprintf(w, `{"conversations": [%s, %s], "has_more": false, "next_cursor": null}`,
+				summary("c-3", "agent-1", "Support", 1700000300), summary("c-out", "agent-1", "Support", 1700086400))
+		case strings.HasPrefix(r.URL.Path, "/v1/convai/conversations/"):
+			id := strings.TrimPrefix(r.URL.Path, "/v1/convai/conversations/")
+			body, ok := details[id]
+			if !ok {
+				t.Errorf("unexpected conversation %s", id)
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprint(w, body)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	report, err := client.Agents().UsageReport(context.Background(), period)
+	if err != nil {
+		t.Fatalf("UsageReport() error = %v", err)
+	}
+
+	if report.Conversations != 3 || report.Credits != 120 {
+		t.Errorf("Conversations = %d, Credits = %d, want 3 and 120", report.Conversations, report.Credits)
+	}
+	if report.Total.InputTokens != 6000 || report.Total.OutputTokens != 600 || report.Total.CachedInputTokens != 500 {
+		t.Errorf("Total = %+v", report.Total)
+	}
+	if len(report.ByAgent) != 2 {
+		t.Fatalf("ByAgent = %+v", report.ByAgent)
+	}
+	support := report.ByAgent[0]
+	if support.AgentID != "agent-1" || support.AgentName != "Support" || support.Conversations != 2 {
+		t.Errorf("ByAgent[0] = %+v, want agent-1 first", support)
+	}
+	if support.Cost < 0.399 || support.Cost > 0.401 || support.CallDuration != 2*time.Minute {
+		t.Errorf("Support cost = %v, duration = %v", support.Cost, support.CallDuration)
+	}
+	if support.MedianTimeToFirstByte != 300*time.Millisecond || support.P95TimeToFirstByte != 500*time.Millisecond {
+		t.Errorf("Support latency median %v, p95 %v", support.MedianTimeToFirstByte, support.P95TimeToFirstByte)
+	}
+	if len(report.ByModel) != 2 || report.ByModel[0].Model != "gpt-4o" || report.ByModel[0].InputTokens != 4000 {
+		t.Errorf("ByModel = %+v", report.ByModel)
+	}
+}
+
+func TestAgentsUsageReportValidation(t *testing.T) {
+	client, _ := NewClient(WithAPIKey("test-key"))
+	var valErr *ValidationError
+	_, err := client.Agents().UsageReport(context.Background(), UsagePeriod{Start: time.Now()})
+	if !isValidationError(err, &valErr) {
+		t.Errorf("error = %v, want ValidationError", err)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	ms := func(v ...int) []time.Duration {
+		var d []time.Duration
+		for _, n := range v {
+			d = append(d, time.Duration(n)*time.Millisecond)
+		}
+		return d
+	}
+	tests := []struct {
+		durations []time.Duration
+		p         int
+		want      time.Duration
+	}{
+		{nil, 50, 0},
+		{ms(100), 95, 100 * time.Millisecond},
+		{ms(400, 100, 300, 200), 50, 200 * time.Millisecond},
+		{ms(400, 100, 300, 200), 95, 400 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if got := percentile(tt.durations, tt.p); got != tt.want {
+			t.Errorf("percentile(%v, %d) = %v, want %v", tt.durations, tt.p, got, tt.want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 	"strconv"
 	"strings"
 )
@@ -101,3 +102,47 @@ func ParsePCMSampleRate(format string) (int, error) {
 	}
 	return rate, nil
 }
+
+// ComputePeaks returns the peak amplitude of 16-bit signed little-endian
+// mono PCM for each 1/bucketsPerSecond of audio, scaled to [0, 1]. The
+// result can be passed directly to web waveform renderers such as
+// wavesurfer.js:
+//
+//	peaks, _ := elevenlabs.ComputePeaks(pcm, 44100, 50)
+//	json.NewEncoder(w).Encode(peaks)
+func ComputePeaks(pcm []byte, sampleRate, bucketsPerSecond int) ([]float64, error) {
+	if bucketsPerSecond <= 0 || bucketsPerSecond > sampleRate {
+		return nil, fmt.Errorf("buckets per second must be between 1 and the sample rate, got %d", bucketsPerSecond)
+	}
+	samples, err := pcmSamples(pcm, sampleRate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bucket boundaries are rounded so the buckets cover the audio exactly
+	n := (len(samples)*bucketsPerSecond + sampleRate - 1) / sampleRate
+	peaks := make([]float64, n)
+	for i := range peaks {
+		start := i * sampleRate / bucketsPerSecond
+		end := min((i+1)*sampleRate/bucketsPerSecond, len(samples))
+		for _, s := range samples[start:end] {
+			peaks[i] = max(peaks[i], math.Abs(s))
+		}
+	}
+	return peaks, nil
+}
+
+// pcmSamples decodes 16-bit PCM into samples in [-1, 1).
+func pcmSamples(pcm []byte, sampleRate int) ([]float64, error) {
+	if sampleRate <= 0 {
+		return nil, fmt.Errorf("invalid sample rate: %d", sampleRate)
+	}
+	if len(pcm)%2 != 0 {
+		return nil, fmt.Errorf("PCM data has odd length %d", len(pcm))
+	}
+	samples := make([]float64, len(pcm)/2)
+	for i := range samples {
+		samples[i] = float64(int16(binary.LittleEndian.Uint16(pcm[2*i:]))) / 32768
+	}
+	return samples, nil
+}
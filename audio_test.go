@@ -2,6 +2,7 @@ package elevenlabs
 
 import (
 	"bytes"
+	"encoding/binary"
 	"testing"
 )
 
@@ -75,3 +76,38 @@ func TestParsePCMSampleRate(t *testing.T) {
 		})
 	}
 }
+
+func TestComputePeaks(t *testing.T) {
+	// 1 second at 100 Hz: half a second at half scale, then silence
+	pcm := make([]byte, 200)
+	for i := 0; i < 50; i++ {
+		v := int16(16384)
+		if i%2 == 1 {
+			v = -16384
+		}
+		binary.LittleEndian.PutUint16(pcm[2*i:], uint16(v))
+	}
+
+	peaks, err := ComputePeaks(pcm, 100, 4)
+	if err != nil {
+		t.Fatalf("ComputePeaks() error = %v", err)
+	}
+	want := []float64{0.5, 0.5, 0, 0}
+	if len(peaks) != len(want) {
+		t.Fatalf("len(peaks) = %d, want %d", len(peaks), len(want))
+	}
+	for i := range want {
+		if peaks[i] != want[i] {
+			t.Errorf("peaks[%d] = %v, want %v", i, peaks[i], want[i])
+		}
+	}
+
+	// A partial final bucket is included
+	if peaks, _ := ComputePeaks(pcm[:102], 100, 4); len(peaks) != 3 {
+		t.Errorf("len(peaks) = %d, want 3", len(peaks))
+	}
+
+	if _, err := ComputePeaks(pcm, 100, 0); err == nil {
+		t.Error("expected error for zero buckets per second")
+	}
+}
@@ -0,0 +1,54 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"io"
+)
+
+// audioSniffLen is how many bytes are read to detect an audio format.
+const audioSniffLen = 16
+
+// audioFormat is an audio container detected from its magic bytes.
+type audioFormat struct {
+	ext         string
+	contentType string
+}
+
+// detectAudioFormat returns the format of audio from its first bytes, and
+// false if it is not recognized.
+func detectAudioFormat(head []byte) (audioFormat, bool) {
+	switch {
+	case bytes.HasPrefix(head, []byte("ID3")):
+		return audioFormat{".mp3", "audio/mpeg"}, true
+	case len(head) >= 12 && bytes.HasPrefix(head, []byte("RIFF")) && bytes.Equal(head[8:12], []byte("WAVE")):
+		return audioFormat{".wav", "audio/wav"}, true
+	case bytes.HasPrefix(head, []byte("OggS")):
+		return audioFormat{".ogg", "audio/ogg"}, true
+	case bytes.HasPrefix(head, []byte("fLaC")):
+		return audioFormat{".flac", "audio/flac"}, true
+	case bytes.HasPrefix(head, []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		return audioFormat{".webm", "audio/webm"}, true
+	case len(head) >= 8 && bytes.Equal(head[4:8], []byte("ftyp")):
+		return audioFormat{".m4a", "audio/mp4"}, true
+	case len(head) >= 2 && head[0] == 0xFF && head[1]&0xF6 == 0xF0:
+		// ADTS AAC: sync word with layer bits 00
+		return audioFormat{".aac", "audio/aac"}, true
+	case len(head) >= 2 && head[0] == 0xFF && head[1]&0xE0 == 0xE0:
+		// MPEG audio frame sync
+		return audioFormat{".mp3", "audio/mpeg"}, true
+	}
+	return audioFormat{}, false
+}
+
+// sniffAudio detects the format of the audio in r. It returns a reader
+// with the full audio, since the detected bytes are consumed from r.
+func sniffAudio(r io.Reader) (audioFormat, bool, io.Reader, error) {
+	head := make([]byte, audioSniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return audioFormat{}, false, nil, err
+	}
+	head = head[:n]
+	format, ok := detectAudioFormat(head)
+	return format, ok, io.MultiReader(bytes.NewReader(head), r), nil
+}
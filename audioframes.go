@@ -0,0 +1,89 @@
+package elevenlabs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Silence bytes of 8-bit telephony encodings.
+const (
+	ulawSilence = 0xFF
+	alawSilence = 0xD5
+)
+
+// FrameEmitter re-buffers a stream of raw audio into frames of a fixed
+// duration, such as the 20ms packets WebRTC and telephony expect. It
+// supports the PCM, μ-law, and A-law output formats.
+type FrameEmitter struct {
+	r         io.Reader
+	frameSize int
+	silence   byte
+	done      bool
+}
+
+// NewFrameEmitter returns a FrameEmitter that reads audio in format (e.g.,
+// "pcm_16000" or "ulaw_8000") from r and emits frames of frameDuration.
+func NewFrameEmitter(r io.Reader, format string, frameDuration time.Duration) (*FrameEmitter, error) {
+	if frameDuration <= 0 {
+		return nil, &ValidationError{Field: "frame_duration", Message: "must be positive"}
+	}
+	encoding, rate, ok := strings.Cut(format, "_")
+	sampleRate, err := strconv.Atoi(rate)
+	if !ok || err != nil || sampleRate <= 0 {
+		return nil, &ValidationError{Field: "output_format", Message: fmt.Sprintf("unsupported format %q", format)}
+	}
+
+	e := &FrameEmitter{r: r}
+	bytesPerSample := 1
+	switch encoding {
+	case "pcm":
+		bytesPerSample = 2
+	case "ulaw":
+		e.silence = ulawSilence
+	case "alaw":
+		e.silence = alawSilence
+	default:
+		return nil, &ValidationError{Field: "output_format", Message: fmt.Sprintf("frames require raw audio, not %q", format)}
+	}
+
+	samples := int(int64(sampleRate) * int64(frameDuration) / int64(time.Second))
+	if samples == 0 {
+		return nil, &ValidationError{Field: "frame_duration", Message: "shorter than one sample"}
+	}
+	e.frameSize = samples * bytesPerSample
+	return e, nil
+}
+
+// FrameSize returns the size of each frame in bytes.
+func (e *FrameEmitter) FrameSize() int {
+	return e.frameSize
+}
+
+// Next returns the next frame. The last frame is padded with silence to
+// the full size. It returns io.EOF after the last frame.
+func (e *FrameEmitter) Next() ([]byte, error) {
+	if e.done {
+		return nil, io.EOF
+	}
+	frame := make([]byte, e.frameSize)
+	n, err := io.ReadFull(e.r, frame)
+	switch {
+	case err == nil:
+		return frame, nil
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		e.done = true
+		for i := n; i < len(frame); i++ {
+			frame[i] = e.silence
+		}
+		return frame, nil
+	case errors.Is(err, io.EOF):
+		e.done = true
+		return nil, io.EOF
+	default:
+		return nil, err
+	}
+}
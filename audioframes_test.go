@@ -0,0 +1,91 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestFrameEmitter(t *testing.T) {
+	// 50ms of 16kHz PCM, delivered in odd-sized reads
+	pcm := bytes.Repeat([]byte{1}, 1600)
+	e, err := NewFrameEmitter(iotest.HalfReader(bytes.NewReader(pcm)), "pcm_16000", 20*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewFrameEmitter() error = %v", err)
+	}
+	if e.FrameSize() != 640 {
+		t.Errorf("FrameSize() = %d, want 640", e.FrameSize())
+	}
+
+	var frames [][]byte
+	for {
+		frame, err := e.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		frames = append(frames, frame)
+	}
+	if len(frames) != 3 {
+		t.Fatalf("got %d frames, want 3", len(frames))
+	}
+	last := frames[2]
+	if len(last) != 640 || last[319] != 1 || last[320] != 0 {
+		t.Errorf("last frame not padded with silence: len %d", len(last))
+	}
+}
+
+func TestFrameEmitterFormats(t *testing.T) {
+	e, err := NewFrameEmitter(bytes.NewReader([]byte{0x10}), "ulaw_8000", 20*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewFrameEmitter() error = %v", err)
+	}
+	frame, err := e.Next()
+	if err != nil || len(frame) != 160 || frame[0] != 0x10 || frame[1] != ulawSilence {
+		t.Errorf("Next() = %d bytes, %v", len(frame), err)
+	}
+
+	var valErr *ValidationError
+	for _, format := range []string{"mp3_44100_128", "opus_48000_64", "pcm", ""} {
+		if _, err := NewFrameEmitter(nil, format, 20*time.Millisecond); !isValidationError(err, &valErr) {
+			t.Errorf("NewFrameEmitter(%q) error = %v", format, err)
+		}
+	}
+	if _, err := NewFrameEmitter(nil, "pcm_16000", 0); !isValidationError(err, &valErr) {
+		t.Errorf("NewFrameEmitter() with zero duration error = %v", err)
+	}
+}
+
+func TestWebSocketTTSReaderAndFrames(t *testing.T) {
+	client := newWebSocketTTSTestServer(t)
+
+	conn, err := client.WebSocketTTS().Connect(context.Background(), "voice-id", nil)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	frames, err := conn.Frames(time.Millisecond)
+	if err != nil {
+		t.Fatalf("Frames() error = %v", err)
+	}
+	if err := conn.SendText("hello"); err != nil {
+		t.Fatalf("SendText() error = %v", err)
+	}
+	go func() { _ = conn.CloseWithTimeout(5 * time.Second) }()
+
+	// The server sends 3 bytes, padded to one 1ms frame of 16kHz PCM
+	frame, err := frames.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if !bytes.Equal(frame[:4], []byte{0, 1, 2, 0}) || len(frame) != 32 {
+		t.Errorf("frame = %v", frame)
+	}
+	if _, err := frames.Next(); err != io.EOF {
+		t.Errorf("Next() after close error = %v, want io.EOF", err)
+	}
+}
@@ -39,14 +39,16 @@ func (s *AudioIsolationService) Isolate(ctx context.Context, req *AudioIsolation
 
 	resp, err := s.client.apiClient.AudioIsolation(ctx, body, api.AudioIsolationParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
 	case *api.AudioIsolationOK:
 		return r.Data, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -74,13 +76,15 @@ func (s *AudioIsolationService) IsolateStream(ctx context.Context, req *AudioIso
 
 	resp, err := s.client.apiClient.AudioIsolationStream(ctx, body, api.AudioIsolationStreamParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
 	case *api.AudioIsolationStreamOK:
 		return r.Data, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
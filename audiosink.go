@@ -0,0 +1,165 @@
+package elevenlabs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// RotatingFileSink writes audio to a sequence of files in a directory,
+// starting a new file when the current one reaches a size or age limit.
+// Use it as WebSocketSTTOptions.AudioSink to keep a recording of
+// everything sent for transcription:
+//
+//	sink, err := elevenlabs.NewRotatingFileSink("recordings", callID,
+//	    elevenlabs.WithSinkMaxDuration(15*time.Minute))
+//	defer sink.Close()
+//	opts.AudioSink = sink
+//
+// Each Write goes to a single file, so chunks are never split. Files
+// hold the raw audio as sent; wrap 16-bit PCM with PCMToWAV to play it.
+type RotatingFileSink struct {
+	dir      string
+	prefix   string
+	ext      string
+	maxBytes int64
+	maxAge   time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	file    *os.File
+	written int64
+	opened  time.Time
+	files   []string
+	closed  bool
+}
+
+// RotatingFileSinkOption configures a RotatingFileSink.
+type RotatingFileSinkOption func(*RotatingFileSink)
+
+// WithSinkMaxBytes starts a new file before a write would take the
+// current file over n bytes. Zero disables the size limit.
+func WithSinkMaxBytes(n int64) RotatingFileSinkOption {
+	return func(s *RotatingFileSink) {
+		s.maxBytes = n
+	}
+}
+
+// WithSinkMaxDuration starts a new file once the current one has been
+// open for d. Zero disables the age limit.
+func WithSinkMaxDuration(d time.Duration) RotatingFileSinkOption {
+	return func(s *RotatingFileSink) {
+		s.maxAge = d
+	}
+}
+
+// WithSinkExtension sets the file extension, including the dot. The
+// default is ".pcm".
+func WithSinkExtension(ext string) RotatingFileSinkOption {
+	return func(s *RotatingFileSink) {
+		s.ext = ext
+	}
+}
+
+// NewRotatingFileSink creates a sink that writes files named
+// "<prefix>-<UTC start time>-<sequence><ext>" in dir, creating dir if
+// needed. Without limits, all audio goes to one file.
+func NewRotatingFileSink(dir, prefix string, opts ...RotatingFileSinkOption) (*RotatingFileSink, error) {
+	if dir == "" {
+		return nil, &ValidationError{Field: "dir", Message: "cannot be empty"}
+	}
+	if prefix == "" {
+		return nil, &ValidationError{Field: "prefix", Message: "cannot be empty"}
+	}
+	s := &RotatingFileSink{dir: dir, prefix: prefix, ext: ".pcm", now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.maxBytes < 0 {
+		return nil, &ValidationError{Field: "max_bytes", Message: "cannot be negative"}
+	}
+	if s.maxAge < 0 {
+		return nil, &ValidationError{Field: "max_duration", Message: "cannot be negative"}
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("creating recording directory: %w", err)
+	}
+	return s, nil
+}
+
+// Write writes p to the current file, first starting a new file if p
+// would exceed the size limit or the file has reached the age limit.
+func (s *RotatingFileSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return 0, errors.New("write to closed sink")
+	}
+	if s.file != nil && s.written > 0 && s.shouldRotate(len(p)) {
+		if err := s.closeFile(); err != nil {
+			return 0, err
+		}
+	}
+	if s.file == nil {
+		if err := s.openFile(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := s.file.Write(p)
+	s.written += int64(n)
+	return n, err
+}
+
+func (s *RotatingFileSink) shouldRotate(size int) bool {
+	if s.maxBytes > 0 && s.written+int64(size) > s.maxBytes {
+		return true
+	}
+	return s.maxAge > 0 && s.now().Sub(s.opened) >= s.maxAge
+}
+
+func (s *RotatingFileSink) openFile() error {
+	s.opened = s.now()
+	name := fmt.Sprintf("%s-%s-%04d%s", s.prefix, s.opened.UTC().Format("20060102T150405Z"), len(s.files)+1, s.ext)
+	path := filepath.Join(s.dir, name)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("creating recording file: %w", err)
+	}
+	s.file = f
+	s.written = 0
+	s.files = append(s.files, path)
+	return nil
+}
+
+func (s *RotatingFileSink) closeFile() error {
+	err := s.file.Close()
+	s.file = nil
+	if err != nil {
+		return fmt.Errorf("closing recording file: %w", err)
+	}
+	return nil
+}
+
+// Files returns the paths of the files written so far, oldest first.
+func (s *RotatingFileSink) Files() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.files...)
+}
+
+// Close closes the current file. Later writes fail.
+func (s *RotatingFileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if s.file == nil {
+		return nil
+	}
+	return s.closeFile()
+}
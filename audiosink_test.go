@@ -0,0 +1,94 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileSinkMaxBytes(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "recordings")
+	sink, err := NewRotatingFileSink(dir, "call-1", WithSinkMaxBytes(4))
+	if err != nil {
+		t.Fatalf("NewRotatingFileSink() error = %v", err)
+	}
+	for _, chunk := range []string{"ab", "cd", "e", "fghij", "k"} {
+		if _, err := sink.Write([]byte(chunk)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Chunks are not split; an oversized chunk gets a file of its own
+	files := sink.Files()
+	var contents []string
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents = append(contents, string(data))
+	}
+	if got := strings.Join(contents, "|"); got != "abcd|e|fghij|k" {
+		t.Errorf("contents = %s", got)
+	}
+	if base := filepath.Base(files[1]); !strings.HasPrefix(base, "call-1-") || !strings.HasSuffix(base, "-0002.pcm") {
+		t.Errorf("file name = %s", base)
+	}
+
+	if _, err := sink.Write([]byte("x")); err == nil {
+		t.Error("Write() after Close(): expected error")
+	}
+}
+
+func TestRotatingFileSinkMaxDuration(t *testing.T) {
+	sink, err := NewRotatingFileSink(t.TempDir(), "call", WithSinkMaxDuration(time.Minute), WithSinkExtension(".raw"))
+	if err != nil {
+		t.Fatalf("NewRotatingFileSink() error = %v", err)
+	}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	sink.now = func() time.Time { return now }
+
+	write := func(s string) {
+		t.Helper()
+		if _, err := sink.Write([]byte(s)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	write("a")
+	now = now.Add(30 * time.Second)
+	write("b")
+	now = now.Add(30 * time.Second)
+	write("c")
+	sink.Close()
+
+	files := sink.Files()
+	if len(files) != 2 {
+		t.Fatalf("Files() = %v, want 2 files", files)
+	}
+	if base := filepath.Base(files[1]); base != "call-20240101T120100Z-0002.raw" {
+		t.Errorf("file name = %s", base)
+	}
+	data, _ := os.ReadFile(files[0])
+	if !bytes.Equal(data, []byte("ab")) {
+		t.Errorf("first file = %q", data)
+	}
+}
+
+func TestRotatingFileSinkValidation(t *testing.T) {
+	var valErr *ValidationError
+	if _, err := NewRotatingFileSink("", "call"); !isValidationError(err, &valErr) {
+		t.Errorf("empty dir: error = %v", err)
+	}
+	if _, err := NewRotatingFileSink(t.TempDir(), ""); !isValidationError(err, &valErr) {
+		t.Errorf("empty prefix: error = %v", err)
+	}
+	if _, err := NewRotatingFileSink(t.TempDir(), "call", WithSinkMaxBytes(-1)); !isValidationError(err, &valErr) {
+		t.Errorf("negative max bytes: error = %v", err)
+	}
+}
@@ -0,0 +1,383 @@
+package elevenlabs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Twilio call statuses that are retried by a CallScheduler.
+const (
+	CallStatusNoAnswer = "no-answer"
+	CallStatusBusy     = "busy"
+)
+
+// Defaults for CallScheduler retries.
+const (
+	DefaultCallMaxAttempts = 3
+	DefaultCallRetryDelay  = 15 * time.Minute
+)
+
+// ErrCallNotFound is returned when a scheduled call does not exist.
+var ErrCallNotFound = errors.New("elevenlabs: scheduled call not found")
+
+// ScheduledCall is an outbound call waiting to be placed.
+type ScheduledCall struct {
+	// ID identifies the call in the CallStore.
+	ID string `json:"id"`
+
+	// At is when the next attempt is placed.
+	At time.Time `json:"at"`
+
+	// Request is the outbound call to place.
+	Request *TwilioOutboundCallRequest `json:"request"`
+
+	// Attempts is the number of attempts made so far.
+	Attempts int `json:"attempts"`
+}
+
+// CallResult is the outcome of a scheduled call, reported once it is
+// answered, fails, or runs out of attempts.
+type CallResult struct {
+	// Call is the scheduled call.
+	Call *ScheduledCall
+
+	// Response is the response to the last attempt, if it was placed.
+	Response *TwilioOutboundCallResponse
+
+	// Status is the last call status, such as CallStatusNoAnswer.
+	Status string
+
+	// Err is the error from the last attempt, if any.
+	Err error
+}
+
+// CallStore persists scheduled calls so they survive restarts.
+// Implementations must be safe for concurrent use.
+type CallStore interface {
+	// Save creates or replaces a scheduled call.
+	Save(ctx context.Context, call *ScheduledCall) error
+
+	// Delete removes a scheduled call. Deleting a missing call is not an
+	// error.
+	Delete(ctx context.Context, id string) error
+
+	// List returns all scheduled calls.
+	List(ctx context.Context) ([]*ScheduledCall, error)
+}
+
+// MemoryCallStore is a CallStore that keeps calls in memory.
+type MemoryCallStore struct {
+	mu    sync.Mutex
+	calls map[string]*ScheduledCall
+}
+
+// NewMemoryCallStore creates an empty in-memory CallStore.
+func NewMemoryCallStore() *MemoryCallStore {
+	return &MemoryCallStore{calls: make(map[string]*ScheduledCall)}
+}
+
+// Save implements CallStore.
+func (m *MemoryCallStore) Save(_ context.Context, call *ScheduledCall) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := *call
+	m.calls[call.ID] = &c
+	return nil
+}
+
+// Delete implements CallStore.
+func (m *MemoryCallStore) Delete(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.calls, id)
+	return nil
+}
+
+// List implements CallStore.
+func (m *MemoryCallStore) List(_ context.Context) ([]*ScheduledCall, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	calls := make([]*ScheduledCall, 0, len(m.calls))
+	for _, call := range m.calls {
+		c := *call
+		calls = append(calls, &c)
+	}
+	return calls, nil
+}
+
+// CallStatusFunc reports the final status of a placed call, for example
+// by polling Twilio's Calls API with resp.CallSID until the call ends.
+type CallStatusFunc func(ctx context.Context, resp *TwilioOutboundCallResponse) (string, error)
+
+// CallSchedulerOption configures a CallScheduler.
+type CallSchedulerOption func(*CallScheduler)
+
+// WithCallStore persists scheduled calls in store. The default is a
+// MemoryCallStore.
+func WithCallStore(store CallStore) CallSchedulerOption {
+	return func(s *CallScheduler) {
+		s.store = store
+	}
+}
+
+// WithCallRetry sets how many times a call is attempted in total and how
+// long to wait between attempts when it is not answered.
+func WithCallRetry(maxAttempts int, delay time.Duration) CallSchedulerOption {
+	return func(s *CallScheduler) {
+		s.maxAttempts = maxAttempts
+		s.retryDelay = delay
+	}
+}
+
+// WithCallStatus sets how the final status of a placed call is found.
+// Without it, only the status returned by OutboundCall is checked.
+func WithCallStatus(fn CallStatusFunc) CallSchedulerOption {
+	return func(s *CallScheduler) {
+		s.callStatus = fn
+	}
+}
+
+// WithCallResultHandler sets the handler for calls scheduled without one,
+// including calls loaded from the store after a restart.
+func WithCallResultHandler(fn func(*CallResult)) CallSchedulerOption {
+	return func(s *CallScheduler) {
+		s.onResult = fn
+	}
+}
+
+// CallScheduler places outbound calls at scheduled times, retrying calls
+// that are not answered or busy. Use it for appointment reminders and
+// similar campaigns:
+//
+//	scheduler := client.Twilio().NewCallScheduler(
+//	    elevenlabs.WithCallStore(store),
+//	    elevenlabs.WithCallRetry(3, 30*time.Minute),
+//	)
+//	go scheduler.Run(ctx)
+//	_, err := scheduler.ScheduleCall(ctx, appointment.Add(-24*time.Hour), req, func(r *elevenlabs.CallResult) {
+//	    log.Printf("reminder %s: %s", r.Call.ID, r.Status)
+//	})
+type CallScheduler struct {
+	twilio      *TwilioService
+	store       CallStore
+	maxAttempts int
+	retryDelay  time.Duration
+	callStatus  CallStatusFunc
+	onResult    func(*CallResult)
+
+	mu       sync.Mutex
+	handlers map[string]func(*CallResult)
+	inFlight map[string]bool
+	wake     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewCallScheduler creates a scheduler that places calls with this
+// service. Calls are only placed while Run is running.
+func (s *TwilioService) NewCallScheduler(opts ...CallSchedulerOption) *CallScheduler {
+	cs := &CallScheduler{
+		twilio:      s,
+		store:       NewMemoryCallStore(),
+		maxAttempts: DefaultCallMaxAttempts,
+		retryDelay:  DefaultCallRetryDelay,
+		handlers:    make(map[string]func(*CallResult)),
+		inFlight:    make(map[string]bool),
+		wake:        make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(cs)
+	}
+	if cs.maxAttempts < 1 {
+		cs.maxAttempts = 1
+	}
+	return cs
+}
+
+// ScheduleCall stores a call to be placed at the given time. onResult is
+// called once with the final outcome; it is not persisted, so calls
+// resumed after a restart use the WithCallResultHandler handler.
+func (cs *CallScheduler) ScheduleCall(ctx context.Context, at time.Time, req *TwilioOutboundCallRequest, onResult func(*CallResult)) (*ScheduledCall, error) {
+	if req == nil {
+		return nil, &ValidationError{Field: "request", Message: "cannot be nil"}
+	}
+	if req.AgentID == "" || req.AgentPhoneNumberID == "" || req.ToNumber == "" {
+		return nil, &ValidationError{Field: "request", Message: "agent_id, agent_phone_number_id, and to_number are required"}
+	}
+
+	id, err := newCallID()
+	if err != nil {
+		return nil, err
+	}
+	call := &ScheduledCall{ID: id, At: at, Request: req}
+	if err := cs.store.Save(ctx, call); err != nil {
+		return nil, err
+	}
+	if onResult != nil {
+		cs.mu.Lock()
+		cs.handlers[id] = onResult
+		cs.mu.Unlock()
+	}
+	cs.notify()
+	return call, nil
+}
+
+// Cancel removes a scheduled call that has not been placed yet.
+func (cs *CallScheduler) Cancel(ctx context.Context, id string) error {
+	calls, err := cs.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, call := range calls {
+		if call.ID == id {
+			cs.mu.Lock()
+			delete(cs.handlers, id)
+			cs.mu.Unlock()
+			return cs.store.Delete(ctx, id)
+		}
+	}
+	return ErrCallNotFound
+}
+
+// Pending returns the calls waiting to be placed, earliest first.
+func (cs *CallScheduler) Pending(ctx context.Context) ([]*ScheduledCall, error) {
+	calls, err := cs.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(calls, func(i, j int) bool { return calls[i].At.Before(calls[j].At) })
+	return calls, nil
+}
+
+// Run places calls as they become due until ctx is done, then waits for
+// calls in progress to finish. Calls already in the store, such as those
+// saved before a restart, are resumed.
+func (cs *CallScheduler) Run(ctx context.Context) error {
+	defer cs.wg.Wait()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-cs.wake:
+		case <-timer.C:
+		}
+
+		next, err := cs.dispatchDue(ctx)
+		if err != nil {
+			return err
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		if !next.IsZero() {
+			timer.Reset(time.Until(next))
+		}
+	}
+}
+
+// dispatchDue starts the calls that are due and returns when the next
+// pending call is due, or the zero time if there is none.
+func (cs *CallScheduler) dispatchDue(ctx context.Context) (time.Time, error) {
+	calls, err := cs.store.List(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	now := time.Now()
+	var next time.Time
+	for _, call := range calls {
+		if cs.inFlight[call.ID] {
+			continue
+		}
+		if call.At.After(now) {
+			if next.IsZero() || call.At.Before(next) {
+				next = call.At
+			}
+			continue
+		}
+		cs.inFlight[call.ID] = true
+		cs.wg.Add(1)
+		go func() {
+			defer cs.wg.Done()
+			cs.place(ctx, call)
+		}()
+	}
+	return next, nil
+}
+
+// place makes one attempt and either reschedules the call or reports its
+// result.
+func (cs *CallScheduler) place(ctx context.Context, call *ScheduledCall) {
+	defer func() {
+		cs.mu.Lock()
+		delete(cs.inFlight, call.ID)
+		cs.mu.Unlock()
+		cs.notify()
+	}()
+
+	call.Attempts++
+	result := &CallResult{Call: call}
+	result.Response, result.Err = cs.twilio.OutboundCall(ctx, call.Request)
+	if result.Err == nil {
+		result.Status = result.Response.Status
+		if cs.callStatus != nil {
+			result.Status, result.Err = cs.callStatus(ctx, result.Response)
+		}
+	}
+	if ctx.Err() != nil {
+		// Leave the call in the store to be resumed
+		return
+	}
+
+	retry := result.Err == nil && (result.Status == CallStatusNoAnswer || result.Status == CallStatusBusy)
+	if retry && call.Attempts < cs.maxAttempts {
+		call.At = time.Now().Add(cs.retryDelay)
+		err := cs.store.Save(ctx, call)
+		if err == nil {
+			return
+		}
+		result.Err = err
+	} else if err := cs.store.Delete(ctx, call.ID); err != nil && result.Err == nil {
+		result.Err = err
+	}
+
+	cs.mu.Lock()
+	handler, ok := cs.handlers[call.ID]
+	delete(cs.handlers, call.ID)
+	cs.mu.Unlock()
+	if !ok {
+		handler = cs.onResult
+	}
+	if handler != nil {
+		handler(result)
+	}
+}
+
+// notify wakes Run to recheck the store.
+func (cs *CallScheduler) notify() {
+	select {
+	case cs.wake <- struct{}{}:
+	default:
+	}
+}
+
+func newCallID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
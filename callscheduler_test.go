@@ -0,0 +1,150 @@
+package elevenlabs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCallSchedulerRetriesNoAnswer(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/convai/twilio/outbound-call" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"call_sid":"CA1","conversation_id":"conv-1","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var statuses atomic.Int32
+	store := NewMemoryCallStore()
+	scheduler := client.Twilio().NewCallScheduler(
+		WithCallStore(store),
+		WithCallRetry(3, time.Millisecond),
+		WithCallStatus(func(ctx context.Context, resp *TwilioOutboundCallResponse) (string, error) {
+			if statuses.Add(1) < 3 {
+				return CallStatusNoAnswer, nil
+			}
+			return "completed", nil
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- scheduler.Run(ctx) }()
+
+	results := make(chan *CallResult, 1)
+	req := &TwilioOutboundCallRequest{AgentID: "agent-1", AgentPhoneNumberID: "phone-1", ToNumber: "+15551234567"}
+	call, err := scheduler.ScheduleCall(ctx, time.Now().Add(10*time.Millisecond), req, func(r *CallResult) {
+		results <- r
+	})
+	if err != nil {
+		t.Fatalf("ScheduleCall() error = %v", err)
+	}
+
+	select {
+	case r := <-results:
+		if r.Err != nil || r.Status != "completed" || r.Call.ID != call.ID || r.Call.Attempts != 3 {
+			t.Errorf("result = %+v", r)
+		}
+		if r.Response.ConversationID != "conv-1" {
+			t.Errorf("ConversationID = %q", r.Response.ConversationID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no result")
+	}
+	if calls.Load() != 3 {
+		t.Errorf("placed %d calls, want 3", calls.Load())
+	}
+	if pending, _ := scheduler.Pending(ctx); len(pending) != 0 {
+		t.Errorf("Pending() = %d calls, want 0", len(pending))
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v", err)
+	}
+}
+
+func TestCallSchedulerGivesUp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"call_sid":"CA1","conversation_id":"conv-1","status":"busy"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	results := make(chan *CallResult, 1)
+	scheduler := client.Twilio().NewCallScheduler(
+		WithCallRetry(2, time.Millisecond),
+		WithCallResultHandler(func(r *CallResult) { results <- r }),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = scheduler.Run(ctx) }()
+
+	req := &TwilioOutboundCallRequest{AgentID: "agent-1", AgentPhoneNumberID: "phone-1", ToNumber: "+15551234567"}
+	if _, err := scheduler.ScheduleCall(ctx, time.Now(), req, nil); err != nil {
+		t.Fatalf("ScheduleCall() error = %v", err)
+	}
+
+	select {
+	case r := <-results:
+		if r.Status != CallStatusBusy || r.Call.Attempts != 2 {
+			t.Errorf("result = %+v", r)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no result")
+	}
+}
+
+func TestCallSchedulerCancel(t *testing.T) {
+	client, err := NewClient(WithAPIKey("test-key"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	scheduler := client.Twilio().NewCallScheduler()
+	ctx := context.Background()
+
+	if _, err := scheduler.ScheduleCall(ctx, time.Now(), &TwilioOutboundCallRequest{AgentID: "agent-1"}, nil); err == nil {
+		t.Error("expected validation error for incomplete request")
+	}
+
+	req := &TwilioOutboundCallRequest{AgentID: "agent-1", AgentPhoneNumberID: "phone-1", ToNumber: "+15551234567"}
+	later, _ := scheduler.ScheduleCall(ctx, time.Now().Add(2*time.Hour), req, nil)
+	sooner, _ := scheduler.ScheduleCall(ctx, time.Now().Add(time.Hour), req, nil)
+
+	pending, err := scheduler.Pending(ctx)
+	if err != nil {
+		t.Fatalf("Pending() error = %v", err)
+	}
+	if len(pending) != 2 || pending[0].ID != sooner.ID || pending[1].ID != later.ID {
+		t.Errorf("Pending() = %+v", pending)
+	}
+
+	if err := scheduler.Cancel(ctx, later.ID); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if err := scheduler.Cancel(ctx, later.ID); !errors.Is(err, ErrCallNotFound) {
+		t.Errorf("Cancel() error = %v, want %v", err, ErrCallNotFound)
+	}
+	if pending, _ := scheduler.Pending(ctx); len(pending) != 1 {
+		t.Errorf("Pending() = %d calls, want 1", len(pending))
+	}
+}
@@ -0,0 +1,247 @@
+package elevenlabs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	ht "github.com/ogen-go/ogen/http"
+)
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through and counts failures.
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen fails requests with ErrCircuitOpen without sending them.
+	CircuitOpen
+
+	// CircuitHalfOpen lets one trial request through after the open
+	// duration. Its outcome closes or reopens the circuit.
+	CircuitHalfOpen
+)
+
+// String returns the state name.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Circuit breaker defaults.
+const (
+	// DefaultFailureThreshold is the number of consecutive failures that
+	// opens the circuit.
+	DefaultFailureThreshold = 5
+
+	// DefaultOpenDuration is how long the circuit stays open before a
+	// trial request is let through.
+	DefaultOpenDuration = 30 * time.Second
+)
+
+// CircuitBreaker stops sending requests while the API is failing, so
+// callers fail fast with ErrCircuitOpen and can fall back, for example to
+// cached audio, instead of waiting for timeouts during an outage.
+//
+// Network errors and 5xx responses count as failures; other responses,
+// including 4xx errors such as rate limits, count as successes because the
+// API answered. After the failure threshold of consecutive failures the
+// circuit opens. Once the open duration has passed, one trial request is sent: if
+// it succeeds the circuit closes, otherwise it opens again.
+//
+// Use it with WithCircuitBreaker:
+//
+//	breaker, err := elevenlabs.NewCircuitBreaker(
+//	    elevenlabs.WithCircuitStateChange(func(from, to elevenlabs.CircuitState) {
+//	        log.Printf("elevenlabs circuit %s -> %s", from, to)
+//	    }))
+//	client, err := elevenlabs.NewClient(elevenlabs.WithCircuitBreaker(breaker))
+//
+// A CircuitBreaker can be shared by several clients.
+type CircuitBreaker struct {
+	threshold     int
+	openDuration  time.Duration
+	onStateChange func(from, to CircuitState)
+	now           func() time.Time
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// CircuitBreakerOption configures a CircuitBreaker.
+type CircuitBreakerOption func(*CircuitBreaker)
+
+// WithFailureThreshold sets the number of consecutive failures that opens
+// the circuit. The default is DefaultFailureThreshold.
+func WithFailureThreshold(n int) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.threshold = n
+	}
+}
+
+// WithOpenDuration sets how long the circuit stays open before a trial
+// request. The default is DefaultOpenDuration.
+func WithOpenDuration(d time.Duration) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.openDuration = d
+	}
+}
+
+// WithCircuitStateChange sets a function called when the circuit changes
+// state. It is called without locks held, but must not block for long.
+func WithCircuitStateChange(fn func(from, to CircuitState)) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.onStateChange = fn
+	}
+}
+
+// NewCircuitBreaker creates a closed circuit breaker.
+func NewCircuitBreaker(opts ...CircuitBreakerOption) (*CircuitBreaker, error) {
+	b := &CircuitBreaker{
+		threshold:    DefaultFailureThreshold,
+		openDuration: DefaultOpenDuration,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.threshold < 1 {
+		return nil, &ValidationError{Field: "failure_threshold", Message: "must be at least 1"}
+	}
+	if b.openDuration <= 0 {
+		return nil, &ValidationError{Field: "open_duration", Message: "must be positive"}
+	}
+	return b, nil
+}
+
+// WithCircuitBreaker fails requests fast with ErrCircuitOpen while breaker
+// is open. It applies to HTTP requests and WebSocket connections; open
+// WebSocket connections are not affected.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(o *clientOptions) {
+		o.circuitBreaker = breaker
+	}
+}
+
+// State returns the current state. An open circuit whose open duration
+// has passed reports CircuitHalfOpen.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && !b.now().Before(b.openedAt.Add(b.openDuration)) {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// Reset closes the circuit and clears the failure count.
+func (b *CircuitBreaker) Reset() {
+	b.mu.Lock()
+	from := b.state
+	b.state = CircuitClosed
+	b.failures = 0
+	b.trial = false
+	b.mu.Unlock()
+	b.notify(from, CircuitClosed)
+}
+
+// allow reports whether a request may be sent, returning ErrCircuitOpen
+// if not. Every allowed request must be followed by a call to finish.
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	from := b.state
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Before(b.openedAt.Add(b.openDuration)) {
+			b.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		b.state = CircuitHalfOpen
+		b.trial = true
+	case CircuitHalfOpen:
+		// Only one trial request at a time
+		if b.trial {
+			b.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		b.trial = true
+	}
+	to := b.state
+	b.mu.Unlock()
+	b.notify(from, to)
+	return nil
+}
+
+// finish records the outcome of an allowed request. Requests canceled by
+// the caller say nothing about the API and are not counted.
+func (b *CircuitBreaker) finish(ctx context.Context, resp *http.Response, err error) {
+	b.mu.Lock()
+	from := b.state
+	if b.state == CircuitHalfOpen {
+		b.trial = false
+	}
+	failed := false
+	if resp != nil {
+		failed = resp.StatusCode >= 500
+	} else if err != nil {
+		if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+			b.mu.Unlock()
+			return
+		}
+		failed = true
+	}
+	switch {
+	case !failed:
+		b.state = CircuitClosed
+		b.failures = 0
+	case b.state == CircuitHalfOpen:
+		b.state = CircuitOpen
+		b.openedAt = b.now()
+	default:
+		b.failures++
+		if b.failures >= b.threshold {
+			b.state = CircuitOpen
+			b.openedAt = b.now()
+			b.failures = 0
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+	b.notify(from, to)
+}
+
+func (b *CircuitBreaker) notify(from, to CircuitState) {
+	if from != to && b.onStateChange != nil {
+		b.onStateChange(from, to)
+	}
+}
+
+// circuitBreakerHTTPClient fails requests fast while the circuit is open.
+type circuitBreakerHTTPClient struct {
+	next    ht.Client
+	breaker *CircuitBreaker
+}
+
+// Do implements ht.Client interface.
+func (c *circuitBreakerHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	resp, err := c.next.Do(req)
+	c.breaker.finish(req.Context(), resp, err)
+	return resp, err
+}
@@ -0,0 +1,158 @@
+package elevenlabs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	var status atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(int(status.Load()))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var transitions []string
+	breaker, err := NewCircuitBreaker(
+		WithFailureThreshold(2),
+		WithOpenDuration(time.Minute),
+		WithCircuitStateChange(func(from, to CircuitState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewCircuitBreaker() error = %v", err)
+	}
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithCircuitBreaker(breaker))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+	call := func() error {
+		return client.doJSON(ctx, "GET", "/v1/models", nil, nil)
+	}
+
+	// Two failures open the circuit
+	for range 2 {
+		if err := call(); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call() error = %v, want API error", err)
+		}
+	}
+	if breaker.State() != CircuitOpen {
+		t.Fatalf("State() = %s, want open", breaker.State())
+	}
+	if err := call(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("call() error = %v, want ErrCircuitOpen", err)
+	}
+	if _, err := client.Models().List(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Models().List() error = %v, want ErrCircuitOpen", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("requests = %d, want 2", n)
+	}
+
+	// A failed trial reopens the circuit
+	now = now.Add(time.Minute)
+	if breaker.State() != CircuitHalfOpen {
+		t.Errorf("State() = %s, want half-open", breaker.State())
+	}
+	if err := call(); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("trial call() error = %v, want API error", err)
+	}
+	if err := call(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("call() error = %v, want ErrCircuitOpen", err)
+	}
+
+	// A successful trial closes it
+	now = now.Add(time.Minute)
+	status.Store(http.StatusOK)
+	if err := call(); err != nil {
+		t.Errorf("trial call() error = %v", err)
+	}
+	if breaker.State() != CircuitClosed {
+		t.Errorf("State() = %s, want closed", breaker.State())
+	}
+
+	want := "closed->open,open->half-open,half-open->open,open->half-open,half-open->closed"
+	if got := strings.Join(transitions, ","); got != want {
+		t.Errorf("transitions = %s, want %s", got, want)
+	}
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	breaker, _ := NewCircuitBreaker(WithFailureThreshold(1))
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithCircuitBreaker(breaker))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	for range 3 {
+		if err := client.doJSON(context.Background(), "GET", "/v1/models", nil, nil); !IsRateLimitError(err) {
+			t.Fatalf("error = %v, want rate limit error", err)
+		}
+	}
+	if breaker.State() != CircuitClosed {
+		t.Errorf("State() = %s, want closed", breaker.State())
+	}
+
+	// Requests canceled by the caller are not counted either
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = client.doJSON(ctx, "GET", "/v1/models", nil, nil)
+	if breaker.State() != CircuitClosed {
+		t.Errorf("State() after cancel = %s, want closed", breaker.State())
+	}
+}
+
+func TestCircuitBreakerWebSocket(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	breaker, _ := NewCircuitBreaker(WithFailureThreshold(1))
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithCircuitBreaker(breaker))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+	if _, err := client.WebSocketSTT().Connect(ctx, nil); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Connect() error = %v, want dial error", err)
+	}
+	if _, err := client.WebSocketTTS().Connect(ctx, "voice-1", nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Connect() error = %v, want ErrCircuitOpen", err)
+	}
+
+	breaker.Reset()
+	if breaker.State() != CircuitClosed {
+		t.Errorf("State() after Reset() = %s, want closed", breaker.State())
+	}
+}
+
+func TestNewCircuitBreakerValidation(t *testing.T) {
+	var valErr *ValidationError
+	if _, err := NewCircuitBreaker(WithFailureThreshold(0)); !isValidationError(err, &valErr) {
+		t.Errorf("zero threshold: error = %v", err)
+	}
+	if _, err := NewCircuitBreaker(WithOpenDuration(0)); !isValidationError(err, &valErr) {
+		t.Errorf("zero open duration: error = %v", err)
+	}
+}
@@ -6,10 +6,19 @@
 package elevenlabs
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"os"
 	"time"
 
+	"github.com/gorilla/websocket"
+	ht "github.com/ogen-go/ogen/http"
+
 	"github.com/agentplexus/go-elevenlabs/internal/api"
 )
 
@@ -23,10 +32,24 @@ const DefaultBaseURL = "https://api.elevenlabs.io"
 const DefaultModelID = "eleven_multilingual_v2"
 
 // Client is the main ElevenLabs client for interacting with the API.
+//
+// A Client and its services are safe for concurrent use by multiple
+// goroutines. Create one Client and share it, so requests reuse
+// connections; see WithTransport to tune connection pooling.
 type Client struct {
-	apiClient *api.Client
-	apiKey    string
-	baseURL   string
+	apiClient  *api.Client
+	httpClient ht.Client
+	apiKey     string
+	baseURL    string
+	dryRun     bool
+	keyPool    *KeyPool
+	breaker    *CircuitBreaker
+	pricing    *Pricing
+	listCache  *listCache
+
+	// fetchClient downloads files from URLs outside the API, without
+	// authentication.
+	fetchClient *http.Client
 
 	// Service accessors
 	tts             *TextToSpeechService
@@ -44,6 +67,7 @@ type Client struct {
 	textToDialogue  *TextToDialogueService
 	voiceDesign     *VoiceDesignService
 	music           *MusicService
+	workspace       *WorkspaceService
 
 	// Real-time services
 	webSocketTTS   *WebSocketTTSService
@@ -51,6 +75,10 @@ type Client struct {
 	twilio         *TwilioService
 	phoneNumbers   *PhoneNumberService
 	speechToSpeech *SpeechToSpeechService
+	webSocketAgent *WebSocketAgentService
+	agents         *AgentsService
+	mcpServers     *MCPServersService
+	knowledgeBase  *KnowledgeBaseService
 }
 
 // NewClient creates a new ElevenLabs client with the given options.
@@ -65,33 +93,96 @@ func NewClient(opts ...Option) (*Client, error) {
 		options.apiKey = os.Getenv("ELEVENLABS_API_KEY")
 	}
 
+	if err := options.applyResidency(); err != nil {
+		return nil, err
+	}
+
+	if options.compression != nil {
+		if err := options.compression.validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create HTTP client with auth headers
 	httpClient := options.httpClient
 	if httpClient == nil {
 		httpClient = &http.Client{
-			Timeout: options.timeout,
+			Transport: newTransport(options.transport),
+			Timeout:   options.timeout,
 		}
 	}
 
+	// Record or replay requests below authentication and logging
+	rec, err := newRecorder(options)
+	if err != nil {
+		return nil, err
+	}
+	if rec != nil {
+		httpClient = rec.client(httpClient)
+	}
+
 	// Wrap with auth transport
-	authClient := &authHTTPClient{
-		client: httpClient,
-		apiKey: options.apiKey,
+	var doer ht.Client = &authHTTPClient{
+		client:      httpClient,
+		apiKey:      options.apiKey,
+		keys:        options.keyPool,
+		compression: options.compression,
+		log:         newRequestLogger(options),
+	}
+
+	// Fail fast while the API is degraded
+	if options.circuitBreaker != nil {
+		doer = &circuitBreakerHTTPClient{
+			next:    doer,
+			breaker: options.circuitBreaker,
+		}
+	}
+
+	// Serve list requests from memory
+	var cache *listCache
+	if options.listCacheTTL > 0 {
+		cache = newListCache(options.listCacheTTL)
+		doer = &listCacheHTTPClient{
+			next:   doer,
+			cache:  cache,
+			apiKey: options.apiKey,
+			pooled: options.keyPool != nil,
+		}
+	}
+
+	// Intercept mutating requests in dry-run mode
+	if options.dryRun {
+		logger := options.logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		doer = &dryRunHTTPClient{
+			next:    doer,
+			handler: options.dryRunHandler,
+			logger:  logger,
+		}
 	}
 
 	// Create the ogen client
 	apiClient, err := api.NewClient(
 		options.baseURL,
-		api.WithClient(authClient),
+		api.WithClient(doer),
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	c := &Client{
-		apiClient: apiClient,
-		apiKey:    options.apiKey,
-		baseURL:   options.baseURL,
+		apiClient:   apiClient,
+		httpClient:  doer,
+		fetchClient: httpClient,
+		apiKey:      options.apiKey,
+		baseURL:     options.baseURL,
+		dryRun:      options.dryRun,
+		keyPool:     options.keyPool,
+		breaker:     options.circuitBreaker,
+		pricing:     options.pricing,
+		listCache:   cache,
 	}
 
 	// Initialize services
@@ -110,6 +201,7 @@ func NewClient(opts ...Option) (*Client, error) {
 	c.textToDialogue = &TextToDialogueService{client: c}
 	c.voiceDesign = &VoiceDesignService{client: c}
 	c.music = &MusicService{client: c}
+	c.workspace = &WorkspaceService{client: c}
 
 	// Initialize real-time services
 	c.webSocketTTS = &WebSocketTTSService{client: c}
@@ -117,28 +209,163 @@ func NewClient(opts ...Option) (*Client, error) {
 	c.twilio = &TwilioService{client: c}
 	c.phoneNumbers = &PhoneNumberService{client: c}
 	c.speechToSpeech = &SpeechToSpeechService{client: c}
+	c.webSocketAgent = &WebSocketAgentService{client: c}
+	c.agents = &AgentsService{client: c}
+	c.mcpServers = &MCPServersService{client: c}
+	c.knowledgeBase = &KnowledgeBaseService{client: c}
 
 	return c, nil
 }
 
 // authHTTPClient wraps an http.Client to add authentication headers.
 type authHTTPClient struct {
-	client *http.Client
-	apiKey string
+	client      *http.Client
+	apiKey      string
+	keys        *KeyPool
+	compression *requestCompression
+	log         *requestLogger
 }
 
 // Do implements ht.Client interface.
 func (c *authHTTPClient) Do(req *http.Request) (*http.Response, error) {
-	// Add authentication header
-	if c.apiKey != "" {
-		req.Header.Set("xi-api-key", c.apiKey)
-	}
-
 	// Add SDK version headers
 	req.Header.Set("X-ElevenLabs-SDK-Version", Version)
 	req.Header.Set("X-ElevenLabs-SDK-Lang", "go")
 
-	return c.client.Do(req)
+	// Add authentication and per-request headers
+	ro := requestOptionsFrom(req.Context())
+	setRequestHeaders(req.Header, c.apiKey, ro)
+
+	if c.compression != nil && (ro == nil || !ro.noCompression) {
+		if err := c.compression.compress(req); err != nil {
+			return nil, err
+		}
+	}
+
+	var cancel context.CancelFunc
+	if ro != nil && ro.timeout > 0 {
+		var ctx context.Context
+		ctx, cancel = context.WithTimeout(req.Context(), ro.timeout)
+		req = req.WithContext(ctx)
+	}
+
+	send := c.client.Do
+	if c.log != nil {
+		send = c.log.wrap(send)
+	}
+
+	var resp *http.Response
+	var err error
+	if c.keys != nil && (ro == nil || ro.apiKey == "") {
+		resp, err = c.keys.do(req, send)
+	} else {
+		resp, err = send(req)
+	}
+	if err != nil {
+		if cancel != nil {
+			cancel()
+		}
+		return nil, err
+	}
+	if cancel != nil {
+		resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	}
+	if err := finishResponse(req, resp, ro); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// finishResponse applies the per-request response options, such as
+// WithDownloadProgress and WithMaxResponseSize, to resp and records its
+// headers for captureResponseHeaders. It also runs for responses served
+// without a request, such as from the list cache.
+func finishResponse(req *http.Request, resp *http.Response, ro *requestOptions) error {
+	if ro != nil {
+		if err := wrapResponseBody(resp, ro); err != nil {
+			return err
+		}
+	}
+	if h, ok := req.Context().Value(responseHeadersKey{}).(*http.Header); ok {
+		*h = resp.Header.Clone()
+	}
+	return nil
+}
+
+// responseHeadersKey is the context key for capturing response headers.
+type responseHeadersKey struct{}
+
+// captureResponseHeaders returns a context that records the headers of the
+// response to a request made with it. The generated client does not expose
+// response headers, so this is how wrappers read metadata from them.
+func captureResponseHeaders(ctx context.Context) (context.Context, *http.Header) {
+	h := new(http.Header)
+	return context.WithValue(ctx, responseHeadersKey{}, h), h
+}
+
+// doJSON sends a JSON request to an endpoint not covered by the generated
+// client and decodes the JSON response into result. Either req or result
+// may be nil. Any 2xx status is treated as success.
+func (c *Client) doJSON(ctx context.Context, method, path string, req any, result any) error {
+	var body io.Reader
+	if req != nil {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	if req != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(resp.Body)
+		apiErr := newAPIError(resp.StatusCode, respBody)
+		apiErr.setResponse(resp)
+		return apiErr
+	}
+
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// dialWebSocket opens a WebSocket connection to wsURL with the client's
+// authentication headers. The handshake is bounded by ctx.
+func (c *Client) dialWebSocket(ctx context.Context, wsURL string) (*websocket.Conn, error) {
+	if c.breaker != nil {
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	dialer := websocket.Dialer{
+		HandshakeTimeout: 0, // Use context timeout
+	}
+	conn, resp, err := dialer.DialContext(ctx, wsURL, c.connectHeaders(ctx))
+	if c.breaker != nil {
+		c.breaker.finish(ctx, resp, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("websocket dial failed: %w", err)
+	}
+	return conn, nil
 }
 
 // API returns the underlying ogen-generated API client for advanced usage.
@@ -223,6 +450,11 @@ func (c *Client) Music() *MusicService {
 	return c.music
 }
 
+// Workspace returns the workspace service for sharing resources.
+func (c *Client) Workspace() *WorkspaceService {
+	return c.workspace
+}
+
 // WebSocketTTS returns the WebSocket text-to-speech service for real-time streaming.
 func (c *Client) WebSocketTTS() *WebSocketTTSService {
 	return c.webSocketTTS
@@ -248,18 +480,56 @@ func (c *Client) SpeechToSpeech() *SpeechToSpeechService {
 	return c.speechToSpeech
 }
 
+// Agents returns the conversational AI agents service.
+func (c *Client) Agents() *AgentsService {
+	return c.agents
+}
+
+// MCPServers returns the MCP server management service for agent tools.
+func (c *Client) MCPServers() *MCPServersService {
+	return c.mcpServers
+}
+
+// KnowledgeBase returns the knowledge base service for agent documents.
+func (c *Client) KnowledgeBase() *KnowledgeBaseService {
+	return c.knowledgeBase
+}
+
+// WebSocketAgent returns the WebSocket service for real-time conversations
+// with conversational AI agents.
+func (c *Client) WebSocketAgent() *WebSocketAgentService {
+	return c.webSocketAgent
+}
+
 // clientOptions holds the options for creating a Client.
 type clientOptions struct {
-	apiKey     string
-	baseURL    string
-	httpClient *http.Client
-	timeout    time.Duration
+	apiKey        string
+	baseURL       string
+	httpClient    *http.Client
+	timeout       time.Duration
+	dryRun        bool
+	dryRunHandler DryRunHandler
+	keyPool       *KeyPool
+	residency     Residency
+	transport     TransportConfig
+	compression   *requestCompression
+	logger        *slog.Logger
+	logLevels     map[EndpointClass]slog.Level
+
+	circuitBreaker *CircuitBreaker
+	pricing        *Pricing
+	listCacheTTL   time.Duration
+
+	recorderPath    string
+	recorderMode    RecorderMode
+	recorderOptions RecorderOptions
 }
 
 func defaultClientOptions() *clientOptions {
 	return &clientOptions{
-		baseURL: DefaultBaseURL,
-		timeout: 120 * time.Second, // TTS can take a while
+		baseURL:   DefaultBaseURL,
+		timeout:   120 * time.Second, // TTS can take a while
+		transport: DefaultTransportConfig(),
 	}
 }
 
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	elevenlabs "github.com/agentplexus/go-elevenlabs"
+)
+
+func newDubbingCmd(global *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dubbing",
+		Short: "Manage dubbing projects",
+	}
+	cmd.AddCommand(newDubbingCreateCmd(global))
+	return cmd
+}
+
+func newDubbingCreateCmd(global *globalOptions) *cobra.Command {
+	req := &elevenlabs.DubbingRequest{}
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Create a dubbing project from a media URL",
+		Example: `  elevenlabs dubbing create --url https://example.com/video.mp4 --target es`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := global.newClient()
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Dubbing().CreateFromURL(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Dubbing ID: %s\n", resp.DubbingID)
+			fmt.Fprintf(cmd.OutOrStdout(), "Expected duration: %.0fs\n", resp.ExpectedDurationSeconds)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&req.SourceURL, "url", "", "source media URL (required)")
+	cmd.Flags().StringVar(&req.TargetLanguage, "target", "", "target language code (required)")
+	cmd.Flags().StringVar(&req.SourceLanguage, "source", "", "source language code (default: auto-detect)")
+	cmd.Flags().StringVar(&req.Name, "name", "", "project name")
+	cmd.Flags().IntVar(&req.NumSpeakers, "speakers", 0, "number of speakers (default: auto-detect)")
+	cmd.Flags().BoolVar(&req.Watermark, "watermark", false, "add a watermark")
+	cmd.Flags().BoolVar(&req.HighestResolution, "highest-resolution", false, "request the highest output resolution")
+	_ = cmd.MarkFlagRequired("url")
+	_ = cmd.MarkFlagRequired("target")
+
+	return cmd
+}
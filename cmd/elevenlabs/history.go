@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	elevenlabs "github.com/agentplexus/go-elevenlabs"
+)
+
+func newHistoryCmd(global *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Browse and download speech history",
+	}
+	cmd.AddCommand(newHistoryListCmd(global), newHistoryDownloadCmd(global), newHistoryExportCmd(global))
+	return cmd
+}
+
+func newHistoryListCmd(global *globalOptions) *cobra.Command {
+	var (
+		pageSize int
+		voiceID  string
+		asJSON   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List speech history items",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := global.newClient()
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.History().List(cmd.Context(), &elevenlabs.HistoryListOptions{
+				PageSize: pageSize,
+				VoiceID:  voiceID,
+			})
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				return printJSON(cmd.OutOrStdout(), resp)
+			}
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(tw, "HISTORY ITEM ID\tCREATED\tVOICE\tTEXT")
+			for _, item := range resp.Items {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+					item.HistoryItemID, item.CreatedAt.Format("2006-01-02 15:04"), item.VoiceName, truncate(item.Text, 40))
+			}
+			return tw.Flush()
+		},
+	}
+
+	cmd.Flags().IntVar(&pageSize, "page-size", 20, "number of items to list")
+	cmd.Flags().StringVar(&voiceID, "voice", "", "filter by voice ID")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print items as JSON")
+	return cmd
+}
+
+func newHistoryDownloadCmd(global *globalOptions) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "download <history-item-id>",
+		Short: "Download audio for a history item",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := global.newClient()
+			if err != nil {
+				return err
+			}
+
+			audio, err := client.History().GetAudio(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			if output == "" {
+				output = args[0] + ".mp3"
+			}
+			n, err := writeOutput(output, audio, cmd.OutOrStdout())
+			if err != nil {
+				return fmt.Errorf("writing audio: %w", err)
+			}
+			if output != "-" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Saved %s (%d bytes)\n", output, n)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", `output file (default "<history-item-id>.mp3", "-" for stdout)`)
+	return cmd
+}
+
+func newHistoryExportCmd(global *globalOptions) *cobra.Command {
+	var (
+		voiceID string
+		modelID string
+		limit   int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export <dir> [history-item-id...]",
+		Short: "Export history items as a dataset (audio files and JSONL manifest)",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := global.newClient()
+			if err != nil {
+				return err
+			}
+
+			opts := &elevenlabs.HistoryExportOptions{
+				ItemIDs: args[1:],
+				VoiceID: voiceID,
+				Limit:   limit,
+			}
+			if modelID != "" {
+				opts.Filter = func(item *elevenlabs.HistoryItem) bool {
+					return item.ModelID == modelID
+				}
+			}
+
+			result, err := client.History().Export(cmd.Context(), args[0], opts)
+			if result != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d items to %s (%d already exported)\n", result.Exported, args[0], result.Skipped)
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&voiceID, "voice", "", "export only items with this voice ID")
+	cmd.Flags().StringVar(&modelID, "model", "", "export only items with this model ID")
+	cmd.Flags().IntVar(&limit, "limit", 0, "maximum number of items (0 for all)")
+	return cmd
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
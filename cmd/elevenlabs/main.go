@@ -0,0 +1,34 @@
+// Command elevenlabs is a command-line interface for common ElevenLabs operations.
+//
+// Usage:
+//
+//	elevenlabs [command] [flags]
+//
+// Commands:
+//
+//	tts                  Convert text to speech
+//	stt                  Transcribe audio to text
+//	voices list          List available voices
+//	voices clone         Clone a voice from audio samples
+//	history list         List speech history items
+//	history download     Download audio for a history item
+//	dubbing create       Create a dubbing project
+//	script compile       Compile a ttsscript JSON file to SSML or text
+//	script synthesize    Generate audio for a ttsscript JSON file
+//
+// Run "elevenlabs [command] --help" for command flags.
+//
+// Environment:
+//
+//	ELEVENLABS_API_KEY    API key for ElevenLabs (or use --api-key)
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
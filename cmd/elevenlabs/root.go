@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	elevenlabs "github.com/agentplexus/go-elevenlabs"
+)
+
+// globalOptions are flags shared by all commands.
+type globalOptions struct {
+	apiKey  string
+	baseURL string
+	timeout time.Duration
+	dryRun  bool
+}
+
+func newRootCmd() *cobra.Command {
+	opts := &globalOptions{}
+
+	cmd := &cobra.Command{
+		Use:          "elevenlabs",
+		Short:        "Command-line interface for the ElevenLabs API",
+		SilenceUsage: true,
+	}
+
+	cmd.PersistentFlags().StringVar(&opts.apiKey, "api-key", "", "ElevenLabs API key (default $ELEVENLABS_API_KEY)")
+	cmd.PersistentFlags().StringVar(&opts.baseURL, "base-url", "", "API base URL")
+	cmd.PersistentFlags().DurationVar(&opts.timeout, "timeout", 5*time.Minute, "HTTP request timeout")
+	cmd.PersistentFlags().BoolVar(&opts.dryRun, "dry-run", false, "log mutating requests instead of sending them")
+
+	cmd.AddCommand(
+		newTTSCmd(opts),
+		newSTTCmd(opts),
+		newVoicesCmd(opts),
+		newHistoryCmd(opts),
+		newDubbingCmd(opts),
+		newScriptCmd(opts),
+	)
+
+	return cmd
+}
+
+// newClient creates an API client from the global options.
+func (o *globalOptions) newClient() (*elevenlabs.Client, error) {
+	apiKey := o.apiKey
+	if apiKey == "" {
+		apiKey = os.Getenv("ELEVENLABS_API_KEY")
+	}
+	if apiKey == "" {
+		return nil, errors.New("API key required: set ELEVENLABS_API_KEY or use --api-key")
+	}
+
+	clientOpts := []elevenlabs.Option{
+		elevenlabs.WithAPIKey(apiKey),
+		elevenlabs.WithTimeout(o.timeout),
+	}
+	if o.baseURL != "" {
+		clientOpts = append(clientOpts, elevenlabs.WithBaseURL(o.baseURL))
+	}
+	if o.dryRun {
+		clientOpts = append(clientOpts, elevenlabs.WithDryRun())
+	}
+	return elevenlabs.NewClient(clientOpts...)
+}
+
+// printJSON writes v as indented JSON.
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeOutput copies r to the file at path, or to stdout if path is "-".
+func writeOutput(path string, r io.Reader, stdout io.Writer) (int64, error) {
+	if path == "-" {
+		return io.Copy(stdout, r)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
+
+// readInput reads the file at path, or stdin if path is "-".
+func readInput(path string, stdin io.Reader) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(stdin)
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	elevenlabs "github.com/agentplexus/go-elevenlabs"
+	"github.com/agentplexus/go-elevenlabs/internal/scriptcmd"
+	"github.com/agentplexus/go-elevenlabs/ttsscript"
+)
+
+func newScriptCmd(global *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "script",
+		Short: "Compile and synthesize ttsscript JSON files",
+	}
+	cmd.AddCommand(newScriptCompileCmd(), newScriptSynthesizeCmd(global))
+	return cmd
+}
+
+// loadScript loads and validates a script.
+func loadScript(path string) (*ttsscript.Script, error) {
+	script, err := ttsscript.LoadScript(path)
+	if err != nil {
+		return nil, err
+	}
+	if issues := script.Validate(); len(issues) > 0 {
+		return nil, fmt.Errorf("script validation failed:\n  - %s", strings.Join(issues, "\n  - "))
+	}
+	return script, nil
+}
+
+func newScriptCompileCmd() *cobra.Command {
+	var (
+		lang   string
+		format string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "compile <script.json>",
+		Short: "Compile a script to SSML, ElevenLabs segments, or text",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			script, err := loadScript(args[0])
+			if err != nil {
+				return err
+			}
+
+			var result string
+			switch format {
+			case "ssml":
+				result, err = ttsscript.NewSSMLFormatter().FormatScript(script, lang)
+			case "elevenlabs":
+				var segments []ttsscript.ElevenLabsSegment
+				segments, err = ttsscript.NewElevenLabsFormatter().FormatScript(script, lang)
+				if err == nil {
+					var sb strings.Builder
+					err = printJSON(&sb, segments)
+					result = sb.String()
+				}
+			case "text":
+				var segments []ttsscript.ElevenLabsSegment
+				formatter := ttsscript.NewElevenLabsFormatter()
+				segments, err = formatter.FormatScript(script, lang)
+				result = formatter.CombineForSingleRequest(segments) + "\n"
+			default:
+				return fmt.Errorf("unknown format %q (use ssml, elevenlabs, or text)", format)
+			}
+			if err != nil {
+				return err
+			}
+
+			_, err = writeOutput(output, strings.NewReader(result), cmd.OutOrStdout())
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&lang, "lang", "en", "language code")
+	cmd.Flags().StringVar(&format, "format", "ssml", "output format: ssml, elevenlabs, or text")
+	cmd.Flags().StringVarP(&output, "output", "o", "-", `output file ("-" for stdout)`)
+	return cmd
+}
+
+func newScriptSynthesizeCmd(global *globalOptions) *cobra.Command {
+	var (
+		lang      string
+		outputDir string
+		modelID   string
+		format    string
+		force     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "synthesize <script.json>",
+		Short: "Generate audio for each script segment",
+		Long: `Generate audio for each script segment and write a JSON and CSV manifest.
+Segments unchanged since the last run are skipped unless --force is set.
+Segments and slides with their own output_format keep it; PCM audio is
+written as WAV.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := ttsscript.ValidateOutputFormat(format); err != nil {
+				return err
+			}
+			script, err := loadScript(args[0])
+			if err != nil {
+				return err
+			}
+
+			client, err := global.newClient()
+			if err != nil {
+				return err
+			}
+
+			// The script can set a model per language; --model covers the rest
+			langModel := script.Model(lang, modelID)
+			maxChars := ttsscript.CharacterLimit(langModel)
+
+			// Check that the model speaks the language, and chunk to its limit
+			models, err := client.Models().List(cmd.Context())
+			if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: could not check the model: %v\n", err)
+			} else if maxChars, err = scriptcmd.ModelLimit(models, langModel, lang); err != nil {
+				return err
+			}
+
+			compiler := ttsscript.NewCompiler()
+			compiler.MaxChars = maxChars
+			segments, err := compiler.Compile(script, lang)
+			if err != nil {
+				return err
+			}
+
+			formatter := ttsscript.NewElevenLabsFormatter()
+			formatter.UseAudioTags = strings.HasPrefix(langModel, "eleven_v3")
+			jobs := formatter.Format(segments)
+
+			if err := os.MkdirAll(outputDir, 0755); err != nil {
+				return fmt.Errorf("creating output directory: %w", err)
+			}
+
+			config := ttsscript.NewBatchConfig(outputDir)
+			config.ModelID = modelID
+			config.OutputFormat = format
+			entries, err := ttsscript.GenerateManifest(jobs, config, lang)
+			if err != nil {
+				return err
+			}
+
+			manifestPath := filepath.Join(outputDir, fmt.Sprintf("manifest_%s.json", lang))
+			previous := ttsscript.ManifestIndex{}
+			if !force {
+				if prev, err := ttsscript.LoadManifest(manifestPath); err == nil {
+					previous = ttsscript.NewManifestIndex(prev)
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			var failed, skipped int
+			for i, job := range jobs {
+				if prev, ok := previous.Unchanged(entries[i]); ok {
+					entries[i].DurationMs = prev.DurationMs
+					skipped++
+					continue
+				}
+
+				fmt.Fprintf(out, "[%d/%d] %s\n", i+1, len(jobs), entries[i].OutputFile)
+				if job.Audio != nil {
+					// Pre-recorded inserts are copied instead of generated
+					durationMs, err := scriptcmd.WriteAudioAsset(cmd.Context(), client, job.Audio, args[0], entries[i].OutputFile)
+					if err != nil {
+						fmt.Fprintf(cmd.ErrOrStderr(), "  ERROR: %v\n", err)
+						entries[i].MarkFailed()
+						failed++
+						continue
+					}
+					entries[i].DurationMs = durationMs
+					continue
+				}
+				outputFormat := entries[i].OutputFormat
+				if outputFormat == "" {
+					outputFormat = ttsscript.DefaultOutputFormat
+				}
+				resp, err := client.TextToSpeech().Generate(cmd.Context(), &elevenlabs.TTSRequest{
+					VoiceID:       job.VoiceID,
+					Text:          job.Text,
+					ModelID:       entries[i].ModelID,
+					OutputFormat:  outputFormat,
+					VoiceSettings: scriptcmd.VoiceSettings(job.Settings),
+					PreviousText:  job.PreviousText,
+					NextText:      job.NextText,
+				})
+				if err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "  ERROR: %v\n", err)
+					entries[i].MarkFailed()
+					failed++
+					continue
+				}
+
+				audio := resp.Audio
+				if rate, err := elevenlabs.ParsePCMSampleRate(outputFormat); err == nil {
+					wav, err := elevenlabs.PCMToWAV(audio, rate)
+					if err != nil {
+						fmt.Fprintf(cmd.ErrOrStderr(), "  ERROR: %v\n", err)
+						entries[i].MarkFailed()
+						failed++
+						continue
+					}
+					audio = bytes.NewReader(wav)
+				}
+				n, err := writeOutput(entries[i].OutputFile, audio, out)
+				if err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "  ERROR writing file: %v\n", err)
+					entries[i].MarkFailed()
+					failed++
+					continue
+				}
+				if strings.HasPrefix(outputFormat, "pcm_") {
+					n -= wavHeaderSize
+				}
+				entries[i].DurationMs = ttsscript.EstimateDurationMs(n, outputFormat)
+			}
+
+			writer := ttsscript.NewManifestWriter()
+			if err := writer.WriteJSONFile(manifestPath, entries); err != nil {
+				return err
+			}
+			csvPath := filepath.Join(outputDir, fmt.Sprintf("manifest_%s.csv", lang))
+			if err := writer.WriteCSVFile(csvPath, entries); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(out, "Done! Generated %d audio files (%d unchanged, %d failed).\n",
+				len(jobs)-skipped-failed, skipped, failed)
+			if failed > 0 {
+				return errors.New("some segments failed to generate")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&lang, "lang", "en", "language code")
+	cmd.Flags().StringVarP(&outputDir, "output", "o", "./output", "output directory")
+	cmd.Flags().StringVar(&modelID, "model", elevenlabs.DefaultModelID, "model ID for languages without a default model in the script")
+	cmd.Flags().StringVar(&format, "format", ttsscript.DefaultOutputFormat, "output format for segments without their own (e.g., mp3_44100_128, pcm_48000)")
+	cmd.Flags().BoolVar(&force, "force", false, "regenerate all segments, even if unchanged")
+	return cmd
+}
+
+// wavHeaderSize is the size of the WAV header written by elevenlabs.PCMToWAV.
+const wavHeaderSize = 44
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	elevenlabs "github.com/agentplexus/go-elevenlabs"
+)
+
+func newSTTCmd(global *globalOptions) *cobra.Command {
+	var (
+		language    string
+		modelID     string
+		diarize     bool
+		numSpeakers int
+		audioEvents bool
+		asJSON      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stt <file-or-url>",
+		Short: "Transcribe audio to text",
+		Example: `  elevenlabs stt recording.mp3
+  elevenlabs stt --diarize --json https://example.com/meeting.mp3`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &elevenlabs.TranscriptionRequest{
+				LanguageCode:   language,
+				ModelID:        modelID,
+				Diarize:        diarize,
+				NumSpeakers:    numSpeakers,
+				TagAudioEvents: audioEvents,
+			}
+
+			source := args[0]
+			switch {
+			case strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://"):
+				req.FileURL = source
+			case source == "-":
+				req.File = cmd.InOrStdin()
+			default:
+				f, err := os.Open(source)
+				if err != nil {
+					return fmt.Errorf("reading audio: %w", err)
+				}
+				defer f.Close()
+				req.File = f
+				req.Filename = filepath.Base(source)
+			}
+
+			client, err := global.newClient()
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.SpeechToText().Transcribe(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				return printJSON(cmd.OutOrStdout(), resp)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), resp.Text)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&language, "language", "", "language code (default: auto-detect)")
+	cmd.Flags().StringVar(&modelID, "model", "", "transcription model (default: scribe_v1)")
+	cmd.Flags().BoolVar(&diarize, "diarize", false, "identify speakers")
+	cmd.Flags().IntVar(&numSpeakers, "speakers", 0, "expected number of speakers")
+	cmd.Flags().BoolVar(&audioEvents, "audio-events", false, "tag audio events like laughter")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print the full result with word timings as JSON")
+
+	return cmd
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	elevenlabs "github.com/agentplexus/go-elevenlabs"
+)
+
+func newTTSCmd(global *globalOptions) *cobra.Command {
+	var (
+		voiceID   string
+		modelID   string
+		output    string
+		format    string
+		language  string
+		textFile  string
+		stability float64
+		boost     float64
+		style     float64
+		speed     float64
+	)
+
+	defaults := elevenlabs.DefaultVoiceSettings()
+
+	cmd := &cobra.Command{
+		Use:   "tts [text]",
+		Short: "Convert text to speech",
+		Example: `  elevenlabs tts --voice 21m00Tcm4TlvDq8ikWAM -o hello.mp3 "Hello, world"
+  cat script.txt | elevenlabs tts --voice 21m00Tcm4TlvDq8ikWAM --file - -o script.mp3`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			text := strings.Join(args, " ")
+			if textFile != "" {
+				data, err := readInput(textFile, cmd.InOrStdin())
+				if err != nil {
+					return fmt.Errorf("reading text: %w", err)
+				}
+				text = string(data)
+			}
+			if strings.TrimSpace(text) == "" {
+				return errors.New("text required: pass it as arguments or use --file")
+			}
+
+			client, err := global.newClient()
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.TextToSpeech().Generate(cmd.Context(), &elevenlabs.TTSRequest{
+				VoiceID:      voiceID,
+				Text:         text,
+				ModelID:      modelID,
+				OutputFormat: format,
+				LanguageCode: language,
+				VoiceSettings: &elevenlabs.VoiceSettings{
+					Stability:       stability,
+					SimilarityBoost: boost,
+					Style:           style,
+					Speed:           speed,
+					UseSpeakerBoost: defaults.UseSpeakerBoost,
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			n, err := writeOutput(output, resp.Audio, cmd.OutOrStdout())
+			if err != nil {
+				return fmt.Errorf("writing audio: %w", err)
+			}
+			if output != "-" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Saved %s (%d bytes)\n", output, n)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&voiceID, "voice", "", "voice ID (required)")
+	cmd.Flags().StringVar(&modelID, "model", elevenlabs.DefaultModelID, "model ID")
+	cmd.Flags().StringVarP(&output, "output", "o", "output.mp3", `output file ("-" for stdout)`)
+	cmd.Flags().StringVar(&format, "format", "", "output format (e.g., mp3_44100_128, pcm_16000)")
+	cmd.Flags().StringVar(&language, "language", "", "ISO 639-1 language code")
+	cmd.Flags().StringVar(&textFile, "file", "", `read text from file ("-" for stdin)`)
+	cmd.Flags().Float64Var(&stability, "stability", defaults.Stability, "voice stability (0.0 to 1.0)")
+	cmd.Flags().Float64Var(&boost, "similarity-boost", defaults.SimilarityBoost, "voice similarity boost (0.0 to 1.0)")
+	cmd.Flags().Float64Var(&style, "style", defaults.Style, "style exaggeration (0.0 to 1.0)")
+	cmd.Flags().Float64Var(&speed, "speed", defaults.Speed, "speaking speed (0.25 to 4.0)")
+	_ = cmd.MarkFlagRequired("voice")
+
+	return cmd
+}
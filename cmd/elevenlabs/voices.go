@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	elevenlabs "github.com/agentplexus/go-elevenlabs"
+)
+
+func newVoicesCmd(global *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "voices",
+		Short: "Manage voices",
+	}
+	cmd.AddCommand(newVoicesListCmd(global), newVoicesCloneCmd(global))
+	return cmd
+}
+
+func newVoicesListCmd(global *globalOptions) *cobra.Command {
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List available voices",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := global.newClient()
+			if err != nil {
+				return err
+			}
+
+			voices, err := client.Voices().List(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				return printJSON(cmd.OutOrStdout(), voices)
+			}
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(tw, "VOICE ID\tNAME\tCATEGORY")
+			for _, v := range voices {
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", v.VoiceID, v.Name, v.Category)
+			}
+			return tw.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print voices as JSON")
+	return cmd
+}
+
+func newVoicesCloneCmd(global *globalOptions) *cobra.Command {
+	var (
+		name        string
+		description string
+		labels      map[string]string
+		removeNoise bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "clone <sample>...",
+		Short:   "Clone a voice from audio samples",
+		Example: `  elevenlabs voices clone --name "Narrator" --label accent=british sample1.mp3 sample2.mp3`,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &elevenlabs.CloneVoiceRequest{
+				Name:                  name,
+				Description:           description,
+				Labels:                labels,
+				RemoveBackgroundNoise: removeNoise,
+			}
+
+			for _, path := range args {
+				f, err := os.Open(path)
+				if err != nil {
+					return fmt.Errorf("opening sample: %w", err)
+				}
+				defer f.Close()
+				req.Samples = append(req.Samples, elevenlabs.VoiceSample{
+					Filename: filepath.Base(path),
+					Audio:    f,
+				})
+			}
+
+			client, err := global.newClient()
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Voices().Clone(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Voice ID: %s\n", resp.VoiceID)
+			if resp.RequiresVerification {
+				fmt.Fprintln(cmd.OutOrStdout(), "The voice requires verification before use.")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "voice name (required)")
+	cmd.Flags().StringVar(&description, "description", "", "voice description")
+	cmd.Flags().StringToStringVar(&labels, "label", nil, "voice label as key=value (repeatable)")
+	cmd.Flags().BoolVar(&removeNoise, "remove-background-noise", false, "remove background noise from samples")
+	_ = cmd.MarkFlagRequired("name")
+
+	return cmd
+}
@@ -9,9 +9,16 @@
 //	-lang string      Language code to generate (default "en")
 //	-output string    Output directory (default "./output")
 //	-per-slide        Concatenate segments into per-slide audio files (requires ffmpeg)
-//	-manifest         Generate manifest JSON file (default true)
+//	-manifest         Generate manifest JSON and CSV files (default true)
 //	-dry-run          Show what would be generated without calling API
 //	-model string     ElevenLabs model ID (default "eleven_multilingual_v2")
+//	-format string    Output format for segments without their own, and for per-slide files (default "mp3_44100_128")
+//	-tier string      Subscription tier for cost estimates (default: the account's tier)
+//	-force            Regenerate all segments, even if unchanged since the last run
+//	-subtitles        Generate SRT and WebVTT subtitle files
+//	-diff string      Report segments changed since an older script version and exit
+//	-timeline         Generate timeline JSON, EDL, and ffmpeg concat files for video assembly
+//	-slide-images string  Slide image file pattern for the ffmpeg concat file (default "slide%02d.png")
 //
 // Environment:
 //
@@ -19,8 +26,8 @@
 package main
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
@@ -32,6 +39,7 @@ import (
 	"strings"
 
 	elevenlabs "github.com/agentplexus/go-elevenlabs"
+	"github.com/agentplexus/go-elevenlabs/internal/scriptcmd"
 	"github.com/agentplexus/go-elevenlabs/ttsscript"
 )
 
@@ -40,9 +48,16 @@ func main() {
 	lang := flag.String("lang", "en", "Language code to generate")
 	outputDir := flag.String("output", "./output", "Output directory")
 	perSlide := flag.Bool("per-slide", false, "Concatenate segments into per-slide audio files (requires ffmpeg)")
-	manifest := flag.Bool("manifest", true, "Generate manifest JSON file")
+	manifest := flag.Bool("manifest", true, "Generate manifest JSON and CSV files")
 	dryRun := flag.Bool("dry-run", false, "Show what would be generated without calling API")
-	modelID := flag.String("model", "eleven_multilingual_v2", "ElevenLabs model ID")
+	modelID := flag.String("model", "eleven_multilingual_v2", "ElevenLabs model ID for languages without a default model in the script")
+	format := flag.String("format", ttsscript.DefaultOutputFormat, "Output format for segments without their own, and for per-slide files")
+	tier := flag.String("tier", "", "Subscription tier for cost estimates (default: the account's tier)")
+	force := flag.Bool("force", false, "Regenerate all segments, even if unchanged since the last run")
+	subtitles := flag.Bool("subtitles", false, "Generate SRT and WebVTT subtitle files")
+	diffPath := flag.String("diff", "", "Report segments changed since an older script version and exit")
+	timeline := flag.Bool("timeline", false, "Generate timeline JSON, EDL, and ffmpeg concat files for video assembly")
+	slideImages := flag.String("slide-images", "slide%02d.png", "Slide image file pattern for the ffmpeg concat file")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: %s [flags] <script.json>\n\n", os.Args[0])
@@ -62,13 +77,20 @@ func main() {
 
 	scriptPath := flag.Arg(0)
 
-	// Check for API key (unless dry run)
-	if !*dryRun && os.Getenv("ELEVENLABS_API_KEY") == "" {
+	// Check for API key (unless dry run or diff)
+	if !*dryRun && *diffPath == "" && os.Getenv("ELEVENLABS_API_KEY") == "" {
 		log.Fatal("ELEVENLABS_API_KEY environment variable is required")
 	}
 
+	if err := ttsscript.ValidateOutputFormat(*format); err != nil {
+		log.Fatalf("Invalid -format: %v", err)
+	}
+
 	// Check for ffmpeg if per-slide mode
 	if *perSlide {
+		if !assemblable(*format) {
+			log.Fatalf("--per-slide supports mp3, opus, and pcm formats, not %s", *format)
+		}
 		if _, err := exec.LookPath("ffmpeg"); err != nil {
 			log.Fatal("ffmpeg is required for --per-slide mode but was not found in PATH")
 		}
@@ -89,15 +111,71 @@ func main() {
 	fmt.Printf("Language: %s\n", *lang)
 	fmt.Printf("Slides: %d, Segments: %d\n", script.SlideCount(), script.SegmentCount())
 
+	// The script can set a model per language; -model covers the rest
+	langModel := script.Model(*lang, *modelID)
+	maxChars := ttsscript.CharacterLimit(langModel)
+
+	// Dry runs and diffs work offline; generation needs a client
+	ctx := context.Background()
+	var client *elevenlabs.Client
+	var pricing *elevenlabs.Pricing
+	if !*dryRun && *diffPath == "" {
+		client, err = elevenlabs.NewClient()
+		if err != nil {
+			log.Fatalf("Failed to create ElevenLabs client: %v", err)
+		}
+
+		// Price generations for the run summary
+		if *tier != "" {
+			pricing, err = elevenlabs.NewPricing(*tier)
+		} else {
+			pricing, err = client.User().Pricing(ctx)
+		}
+		if err != nil {
+			log.Printf("Warning: cost estimates unavailable: %v", err)
+		} else {
+			client, err = elevenlabs.NewClient(elevenlabs.WithPricing(pricing))
+			if err != nil {
+				log.Fatalf("Failed to create ElevenLabs client: %v", err)
+			}
+		}
+
+		// Check that the model speaks the language, and chunk to its limit
+		models, err := client.Models().List(ctx)
+		if err != nil {
+			log.Printf("Warning: could not check the model: %v", err)
+		} else if maxChars, err = scriptcmd.ModelLimit(models, langModel, *lang); err != nil {
+			log.Fatalf("Model validation failed: %v", err)
+		}
+	}
+
 	// Compile script
 	compiler := ttsscript.NewCompiler()
+	compiler.MaxChars = maxChars
 	segments, err := compiler.Compile(script, *lang)
 	if err != nil {
 		log.Fatalf("Failed to compile script: %v", err)
 	}
 
+	// Report changes against an older version
+	if *diffPath != "" {
+		oldScript, err := ttsscript.LoadScript(*diffPath)
+		if err != nil {
+			log.Fatalf("Failed to load old script: %v", err)
+		}
+		diff, err := compiler.Diff(oldScript, script, *lang)
+		if err != nil {
+			log.Fatalf("Failed to diff scripts: %v", err)
+		}
+		fmt.Printf("\n%s", diff)
+		fmt.Printf("%d segments need synthesis\n", len(diff.NeedsSynthesis()))
+		return
+	}
+
 	// Format for ElevenLabs
 	formatter := ttsscript.NewElevenLabsFormatter()
+	// Audio tags are only understood by Eleven v3; other models would speak them
+	formatter.UseAudioTags = strings.HasPrefix(langModel, "eleven_v3")
 	jobs := formatter.Format(segments)
 
 	fmt.Printf("Generated %d TTS jobs\n\n", len(jobs))
@@ -110,9 +188,33 @@ func main() {
 	// Generate batch config
 	config := ttsscript.NewBatchConfig(*outputDir)
 	config.IncludeLanguageInFilename = true
+	config.ModelID = *modelID
+	config.OutputFormat = *format
 
 	// Generate manifest
-	manifestEntries := ttsscript.GenerateManifest(jobs, config, *lang)
+	manifestEntries, err := ttsscript.GenerateManifest(jobs, config, *lang)
+	if err != nil {
+		log.Fatalf("Failed to generate manifest: %v", err)
+	}
+
+	// Per-slide assembly decodes segments with ffmpeg, which cannot detect
+	// headerless μ-law and A-law audio
+	if *perSlide {
+		for _, entry := range manifestEntries {
+			if !assemblable(entry.OutputFormat) {
+				log.Fatalf("--per-slide supports mp3, opus, and pcm formats; slide %d uses %s", entry.SlideIndex+1, entry.OutputFormat)
+			}
+		}
+	}
+
+	// Load the previous manifest for incremental builds
+	manifestPath := filepath.Join(*outputDir, fmt.Sprintf("manifest_%s.json", *lang))
+	previous := ttsscript.ManifestIndex{}
+	if !*force {
+		if entries, err := ttsscript.LoadManifest(manifestPath); err == nil {
+			previous = ttsscript.NewManifestIndex(entries)
+		}
+	}
 
 	if *dryRun {
 		fmt.Println("Dry run - would generate:")
@@ -120,15 +222,24 @@ func main() {
 			segType := "segment"
 			if entry.IsTitleSegment {
 				segType = "title"
+			} else if entry.Audio != nil {
+				segType = "audio"
+			}
+			if _, ok := previous.Unchanged(entry); ok {
+				segType += ", unchanged"
 			}
 			fmt.Printf("  [%s] %s\n", segType, entry.OutputFile)
 			fmt.Printf("    Text: %s\n", truncate(entry.Text, 60))
 			fmt.Printf("    Voice: %s\n", entry.VoiceID)
+			if entry.OutputFormat != "" {
+				fmt.Printf("    Format: %s\n", entry.OutputFormat)
+			}
 		}
 
 		if *perSlide {
 			fmt.Println("\nPer-slide output:")
 			slideFiles := getSlideOutputFiles(manifestEntries, config, *lang)
+			fmt.Printf("  Format: %s\n", *format)
 			for slide, file := range slideFiles {
 				fmt.Printf("  Slide %d: %s\n", slide+1, file)
 			}
@@ -136,24 +247,40 @@ func main() {
 		return
 	}
 
-	// Create ElevenLabs client
-	client, err := elevenlabs.NewClient()
-	if err != nil {
-		log.Fatalf("Failed to create ElevenLabs client: %v", err)
-	}
-
-	ctx := context.Background()
-
 	// Generate audio for each segment
 	generatedFiles := make([]string, 0, len(jobs))
+	skipped := 0
+	var costs elevenlabs.CostSummary
 	for i, job := range jobs {
-		if job.VoiceID == "" {
+		if job.VoiceID == "" && job.Audio == nil {
 			log.Printf("Skipping segment %d: no voice ID configured", i+1)
+			manifestEntries[i].MarkFailed()
+			continue
+		}
+
+		if prev, ok := previous.Unchanged(manifestEntries[i]); ok {
+			manifestEntries[i].DurationMs = prev.DurationMs
+			skipped++
 			continue
 		}
 
 		outputFile := config.GenerateFilename(job, *lang)
 
+		// Pre-recorded inserts are copied instead of generated
+		if job.Audio != nil {
+			fmt.Printf("[%d/%d] Inserting audio: %s\n", i+1, len(jobs), scriptcmd.AudioSource(job.Audio))
+			durationMs, err := scriptcmd.WriteAudioAsset(ctx, client, job.Audio, scriptPath, outputFile)
+			if err != nil {
+				log.Printf("  ERROR: %v", err)
+				manifestEntries[i].MarkFailed()
+				continue
+			}
+			manifestEntries[i].DurationMs = durationMs
+			fmt.Printf("  Saved: %s\n", outputFile)
+			generatedFiles = append(generatedFiles, outputFile)
+			continue
+		}
+
 		segType := "segment"
 		if job.IsTitleSegment {
 			segType = "title"
@@ -161,30 +288,33 @@ func main() {
 
 		fmt.Printf("[%d/%d] Generating %s: %s\n", i+1, len(jobs), segType, truncate(job.Text, 50))
 
+		outputFormat := manifestEntries[i].OutputFormat
+		if outputFormat == "" {
+			outputFormat = ttsscript.DefaultOutputFormat
+		}
 		resp, err := client.TextToSpeech().Generate(ctx, &elevenlabs.TTSRequest{
 			VoiceID:       job.VoiceID,
 			Text:          job.Text,
-			ModelID:       *modelID,
-			VoiceSettings: elevenlabs.DefaultVoiceSettings(),
+			ModelID:       manifestEntries[i].ModelID,
+			OutputFormat:  outputFormat,
+			VoiceSettings: scriptcmd.VoiceSettings(job.Settings),
+			PreviousText:  job.PreviousText,
+			NextText:      job.NextText,
 		})
 		if err != nil {
 			log.Printf("  ERROR: %v", err)
+			manifestEntries[i].MarkFailed()
 			continue
 		}
-		audio := resp.Audio
+		costs.Add(resp)
 
-		f, err := os.Create(outputFile)
+		durationMs, err := saveAudio(outputFile, outputFormat, resp.Audio)
 		if err != nil {
-			log.Printf("  ERROR creating file: %v", err)
-			continue
-		}
-
-		_, err = io.Copy(f, audio)
-		f.Close()
-		if err != nil {
-			log.Printf("  ERROR writing file: %v", err)
+			log.Printf("  ERROR saving file: %v", err)
+			manifestEntries[i].MarkFailed()
 			continue
 		}
+		manifestEntries[i].DurationMs = durationMs
 
 		fmt.Printf("  Saved: %s\n", outputFile)
 		generatedFiles = append(generatedFiles, outputFile)
@@ -192,28 +322,116 @@ func main() {
 
 	// Write manifest
 	if *manifest {
-		manifestPath := filepath.Join(*outputDir, fmt.Sprintf("manifest_%s.json", *lang))
-		manifestData, err := json.MarshalIndent(manifestEntries, "", "  ")
-		if err != nil {
-			log.Printf("Failed to marshal manifest: %v", err)
-		} else if err := os.WriteFile(manifestPath, manifestData, 0600); err != nil {
+		writer := ttsscript.NewManifestWriter()
+		if err := writer.WriteJSONFile(manifestPath, manifestEntries); err != nil {
 			log.Printf("Failed to write manifest: %v", err)
 		} else {
 			fmt.Printf("\nManifest saved: %s\n", manifestPath)
 		}
+		csvPath := filepath.Join(*outputDir, fmt.Sprintf("manifest_%s.csv", *lang))
+		if err := writer.WriteCSVFile(csvPath, manifestEntries); err != nil {
+			log.Printf("Failed to write CSV manifest: %v", err)
+		} else {
+			fmt.Printf("Manifest saved: %s\n", csvPath)
+		}
+	}
+
+	// Write subtitles
+	if *subtitles {
+		writeSubtitles(segments, manifestEntries, *lang, *outputDir)
+	}
+
+	// Write timeline
+	if *timeline {
+		writeTimeline(manifestEntries, script.Title, *slideImages, *lang, *outputDir)
 	}
 
 	// Concatenate per-slide if requested
 	if *perSlide {
 		fmt.Println("\nConcatenating per-slide audio...")
-		concatenatePerSlide(manifestEntries, *lang, *outputDir)
+		concatenatePerSlide(manifestEntries, *lang, *outputDir, *format)
+	}
+
+	fmt.Printf("\nDone! Generated %d audio files (%d unchanged).\n", len(generatedFiles), skipped)
+	if pricing != nil {
+		fmt.Printf("Cost: %s (%s tier)\n", costs.String(), pricing.Tier)
+	}
+}
+
+// writeSubtitles writes SRT and WebVTT subtitles using the estimated segment durations.
+func writeSubtitles(segments []ttsscript.CompiledSegment, entries []ttsscript.ManifestEntry, language, outputDir string) {
+	durations := make([]int, len(entries))
+	for i, entry := range entries {
+		durations[i] = entry.DurationMs
+	}
+
+	formatter := ttsscript.NewSubtitleFormatter()
+	outputs := []struct {
+		ext    string
+		format func([]ttsscript.CompiledSegment, []int) (string, error)
+	}{
+		{"srt", formatter.FormatSRT},
+		{"vtt", formatter.FormatVTT},
+	}
+
+	for _, out := range outputs {
+		content, err := out.format(segments, durations)
+		if err != nil {
+			log.Printf("Failed to format %s subtitles: %v", out.ext, err)
+			continue
+		}
+		subtitlePath := filepath.Join(outputDir, fmt.Sprintf("subtitles_%s.%s", language, out.ext))
+		if err := os.WriteFile(subtitlePath, []byte(content), 0600); err != nil {
+			log.Printf("Failed to write subtitles: %v", err)
+			continue
+		}
+		fmt.Printf("Subtitles saved: %s\n", subtitlePath)
+	}
+}
+
+// writeTimeline writes the slide timeline as JSON, a CMX 3600 EDL, and an
+// ffmpeg concat script showing each slide image for its duration.
+func writeTimeline(entries []ttsscript.ManifestEntry, title, imagePattern, language, outputDir string) {
+	tl := ttsscript.NewTimeline(entries)
+	outputs := []struct {
+		ext   string
+		write func(io.Writer) error
+	}{
+		{"json", tl.WriteJSON},
+		{"edl", func(w io.Writer) error { return tl.WriteEDL(w, title, 30) }},
+		{"ffconcat", func(w io.Writer) error {
+			return tl.WriteFFmpegConcat(w, func(slide int) string {
+				return fmt.Sprintf(imagePattern, slide+1)
+			})
+		}},
 	}
 
-	fmt.Printf("\nDone! Generated %d audio files.\n", len(generatedFiles))
+	for _, out := range outputs {
+		timelinePath := filepath.Join(outputDir, fmt.Sprintf("timeline_%s.%s", language, out.ext))
+		f, err := os.Create(timelinePath)
+		if err != nil {
+			log.Printf("Failed to create timeline: %v", err)
+			continue
+		}
+		err = out.write(f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			log.Printf("Failed to write timeline: %v", err)
+			continue
+		}
+		fmt.Printf("Timeline saved: %s\n", timelinePath)
+	}
 }
 
-// concatenatePerSlide uses ffmpeg to concatenate segment audio files into per-slide files.
-func concatenatePerSlide(entries []ttsscript.ManifestEntry, language, outputDir string) {
+// concatenatePerSlide uses ffmpeg to concatenate segment audio files into
+// per-slide files in the target format. Segments in other formats and
+// pre-recorded inserts are converted first, so they can be joined without
+// re-encoding.
+func concatenatePerSlide(entries []ttsscript.ManifestEntry, language, outputDir, format string) {
+	ext := ttsscript.FileExtension(format)
+
 	// Group entries by slide
 	slideSegments := make(map[int][]ttsscript.ManifestEntry)
 	for _, entry := range entries {
@@ -229,6 +447,7 @@ func concatenatePerSlide(entries []ttsscript.ManifestEntry, language, outputDir
 
 	for _, slideIdx := range slideIndices {
 		segments := slideSegments[slideIdx]
+		slideOutput := filepath.Join(outputDir, fmt.Sprintf("slide%02d_%s%s", slideIdx+1, language, ext))
 
 		// Sort segments: title first (SegmentIndex -1), then by segment index
 		sort.Slice(segments, func(i, j int) bool {
@@ -237,9 +456,14 @@ func concatenatePerSlide(entries []ttsscript.ManifestEntry, language, outputDir
 
 		// Skip if only one segment (no need to concatenate)
 		if len(segments) == 1 {
-			// Just copy/rename to slide output
-			slideOutput := filepath.Join(outputDir, fmt.Sprintf("slide%02d_%s.mp3", slideIdx+1, language))
-			if err := copyFile(segments[0].OutputFile, slideOutput); err != nil {
+			// Just copy/rename to slide output, converting if needed
+			var err error
+			if segments[0].NeedsConversion(format) {
+				err = convertAudio(segments[0].OutputFile, slideOutput, format)
+			} else {
+				err = copyFile(segments[0].OutputFile, slideOutput)
+			}
+			if err != nil {
 				log.Printf("  Slide %d: failed to copy: %v", slideIdx+1, err)
 				continue
 			}
@@ -250,11 +474,12 @@ func concatenatePerSlide(entries []ttsscript.ManifestEntry, language, outputDir
 		// Create concat list file for ffmpeg
 		listFile := filepath.Join(outputDir, fmt.Sprintf(".concat_slide%02d.txt", slideIdx+1))
 		var listContent strings.Builder
+		failed := false
 
 		for i, seg := range segments {
 			// Add pause before (as silence) if needed
 			if seg.PauseBeforeMs > 0 && i > 0 {
-				silenceFile, err := generateSilence(outputDir, seg.PauseBeforeMs, slideIdx, i, "before")
+				silenceFile, err := generateSilence(outputDir, format, seg.PauseBeforeMs, slideIdx, i, "before")
 				if err != nil {
 					log.Printf("  Warning: failed to generate silence: %v", err)
 				} else {
@@ -262,12 +487,21 @@ func concatenatePerSlide(entries []ttsscript.ManifestEntry, language, outputDir
 				}
 			}
 
-			// Add the audio file
-			listContent.WriteString(fmt.Sprintf("file '%s'\n", filepath.Base(seg.OutputFile)))
+			// Add the audio file, converted to the slide format if needed
+			input := seg.OutputFile
+			if seg.NeedsConversion(format) {
+				input = filepath.Join(outputDir, fmt.Sprintf(".convert_s%02d_%02d%s", slideIdx, i, ext))
+				if err := convertAudio(seg.OutputFile, input, format); err != nil {
+					log.Printf("  Slide %d: %v", slideIdx+1, err)
+					failed = true
+					break
+				}
+			}
+			listContent.WriteString(fmt.Sprintf("file '%s'\n", filepath.Base(input)))
 
 			// Add pause after (as silence) if needed
 			if seg.PauseAfterMs > 0 {
-				silenceFile, err := generateSilence(outputDir, seg.PauseAfterMs, slideIdx, i, "after")
+				silenceFile, err := generateSilence(outputDir, format, seg.PauseAfterMs, slideIdx, i, "after")
 				if err != nil {
 					log.Printf("  Warning: failed to generate silence: %v", err)
 				} else {
@@ -275,6 +509,10 @@ func concatenatePerSlide(entries []ttsscript.ManifestEntry, language, outputDir
 				}
 			}
 		}
+		if failed {
+			cleanupTempFiles(outputDir, slideIdx)
+			continue
+		}
 
 		if err := os.WriteFile(listFile, []byte(listContent.String()), 0600); err != nil {
 			log.Printf("  Slide %d: failed to write concat list: %v", slideIdx+1, err)
@@ -282,7 +520,6 @@ func concatenatePerSlide(entries []ttsscript.ManifestEntry, language, outputDir
 		}
 
 		// Run ffmpeg to concatenate
-		slideOutput := filepath.Join(outputDir, fmt.Sprintf("slide%02d_%s.mp3", slideIdx+1, language))
 		cmd := exec.Command("ffmpeg", "-y", "-f", "concat", "-safe", "0", "-i", listFile, "-c", "copy", slideOutput)
 		cmd.Dir = outputDir
 		if output, err := cmd.CombinedOutput(); err != nil {
@@ -292,21 +529,78 @@ func concatenatePerSlide(entries []ttsscript.ManifestEntry, language, outputDir
 
 		// Clean up temp files
 		os.Remove(listFile)
-		cleanupSilenceFiles(outputDir, slideIdx)
+		cleanupTempFiles(outputDir, slideIdx)
 
 		fmt.Printf("  Slide %d: %s (%d segments)\n", slideIdx+1, slideOutput, len(segments))
 	}
 }
 
-// generateSilence creates a silent audio file of the specified duration.
-func generateSilence(outputDir string, durationMs, slideIdx, segIdx int, position string) (string, error) {
-	filename := filepath.Join(outputDir, fmt.Sprintf(".silence_s%02d_%02d_%s.mp3", slideIdx, segIdx, position))
+// convertAudio re-encodes an audio file in an ElevenLabs output format.
+func convertAudio(src, dst, format string) error {
+	args, err := ttsscript.FFmpegEncodeArgs(format)
+	if err != nil {
+		return err
+	}
+	args = append(append([]string{"-y", "-i", src}, args...), dst)
+
+	// #nosec G204 -- paths come from the manifest and the user-controlled outputDir flag, which is intentional for CLI tools
+	cmd := exec.Command("ffmpeg", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("ffmpeg conversion of %s failed: %v\n%s", src, err, string(output))
+	}
+	return nil
+}
+
+// assemblable reports whether ffmpeg can read audio in an output format
+// for per-slide assembly. Headerless μ-law and A-law audio cannot be.
+func assemblable(format string) bool {
+	ext := ttsscript.FileExtension(format)
+	return ext != ".ulaw" && ext != ".alaw"
+}
+
+// saveAudio writes generated audio to outputFile and returns its estimated
+// duration. PCM audio is wrapped in a WAV header, so it can be played and
+// assembled like the other formats.
+func saveAudio(outputFile, format string, audio io.Reader) (int, error) {
+	rate, err := elevenlabs.ParsePCMSampleRate(format)
+	if err != nil {
+		// Write atomically so an interrupted run never leaves a truncated file
+		saved, err := elevenlabs.SaveAudio(outputFile, audio)
+		if err != nil {
+			return 0, err
+		}
+		return ttsscript.EstimateDurationMs(saved.Size, format), nil
+	}
+
+	pcm, err := io.ReadAll(audio)
+	if err != nil {
+		return 0, err
+	}
+	wav, err := elevenlabs.PCMBytesToWAV(pcm, rate)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := elevenlabs.SaveAudio(outputFile, bytes.NewReader(wav)); err != nil {
+		return 0, err
+	}
+	return ttsscript.EstimateDurationMs(int64(len(pcm)), format), nil
+}
+
+// generateSilence creates a silent audio file of the specified duration in
+// an ElevenLabs output format.
+func generateSilence(outputDir, format string, durationMs, slideIdx, segIdx int, position string) (string, error) {
+	filename := filepath.Join(outputDir, fmt.Sprintf(".silence_s%02d_%02d_%s%s", slideIdx, segIdx, position, ttsscript.FileExtension(format)))
 	duration := float64(durationMs) / 1000.0
 
+	encode, err := ttsscript.FFmpegEncodeArgs(format)
+	if err != nil {
+		return "", err
+	}
+	args := []string{"-y", "-f", "lavfi", "-i", fmt.Sprintf("anullsrc=r=44100:cl=mono:d=%.3f", duration)}
+	args = append(append(args, encode...), filename)
+
 	// #nosec G204 -- filename is constructed from user-controlled outputDir flag, which is intentional for CLI tools
-	cmd := exec.Command("ffmpeg", "-y", "-f", "lavfi", "-i",
-		fmt.Sprintf("anullsrc=r=44100:cl=mono:d=%.3f", duration),
-		"-c:a", "libmp3lame", "-q:a", "9", filename)
+	cmd := exec.Command("ffmpeg", args...)
 
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return "", fmt.Errorf("ffmpeg silence generation failed: %v\n%s", err, string(output))
@@ -315,12 +609,14 @@ func generateSilence(outputDir string, durationMs, slideIdx, segIdx int, positio
 	return filename, nil
 }
 
-// cleanupSilenceFiles removes temporary silence files for a slide.
-func cleanupSilenceFiles(outputDir string, slideIdx int) {
-	pattern := filepath.Join(outputDir, fmt.Sprintf(".silence_s%02d_*.mp3", slideIdx))
-	files, _ := filepath.Glob(pattern)
-	for _, f := range files {
-		os.Remove(f)
+// cleanupTempFiles removes temporary silence and converted files for a slide.
+func cleanupTempFiles(outputDir string, slideIdx int) {
+	for _, prefix := range []string{".silence", ".convert"} {
+		pattern := filepath.Join(outputDir, fmt.Sprintf("%s_s%02d_*", prefix, slideIdx))
+		files, _ := filepath.Glob(pattern)
+		for _, f := range files {
+			os.Remove(f)
+		}
 	}
 }
 
@@ -347,7 +643,7 @@ func getSlideOutputFiles(entries []ttsscript.ManifestEntry, config *ttsscript.Ba
 	slides := make(map[int]string)
 	for _, entry := range entries {
 		if _, exists := slides[entry.SlideIndex]; !exists {
-			slides[entry.SlideIndex] = filepath.Join(config.OutputDir, fmt.Sprintf("slide%02d_%s.mp3", entry.SlideIndex+1, language))
+			slides[entry.SlideIndex] = filepath.Join(config.OutputDir, fmt.Sprintf("slide%02d_%s%s", entry.SlideIndex+1, language, ttsscript.FileExtension(config.OutputFormat)))
 		}
 	}
 	return slides
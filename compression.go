@@ -0,0 +1,103 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// Request body encodings for WithRequestCompression.
+const (
+	CompressionGzip    = "gzip"
+	CompressionDeflate = "deflate"
+)
+
+// DefaultCompressionThreshold is the smallest request body compressed when
+// WithRequestCompression is given no minimum size. Smaller bodies gain
+// little and cost CPU.
+const DefaultCompressionThreshold = 16 << 10
+
+// requestCompression compresses large JSON request bodies.
+type requestCompression struct {
+	encoding string
+	minSize  int64
+}
+
+// WithRequestCompression compresses JSON request bodies of at least minSize
+// bytes with encoding (CompressionGzip or CompressionDeflate), reducing
+// upload time for long-form text and large dictionaries on slow links.
+// A minSize of zero uses DefaultCompressionThreshold. Audio and other
+// multipart uploads are not compressed.
+//
+// Compression is opt-in rather than on by default: the API does not
+// document support for gzip or deflate request bodies, and an endpoint that
+// rejected them would fail every large request. Check that your endpoints
+// accept compressed bodies before enabling it. Use
+// WithoutRequestCompression to skip it for a call that does not.
+func WithRequestCompression(encoding string, minSize int64) Option {
+	return func(o *clientOptions) {
+		if minSize <= 0 {
+			minSize = DefaultCompressionThreshold
+		}
+		o.compression = &requestCompression{encoding: encoding, minSize: minSize}
+	}
+}
+
+// WithoutRequestCompression opts a call out of the compression enabled by
+// WithRequestCompression. It has no effect on clients without it, as
+// request bodies are not compressed by default.
+func WithoutRequestCompression() RequestOption {
+	return func(o *requestOptions) {
+		o.noCompression = true
+	}
+}
+
+func (rc *requestCompression) validate() error {
+	switch rc.encoding {
+	case CompressionGzip, CompressionDeflate:
+		return nil
+	}
+	return &ValidationError{Field: "compression", Message: "unsupported encoding " + rc.encoding}
+}
+
+// compress replaces a large, replayable JSON request body with its
+// compressed form. Other requests are left unchanged.
+func (rc *requestCompression) compress(req *http.Request) error {
+	if req.GetBody == nil || req.ContentLength < rc.minSize || req.Header.Get("Content-Encoding") != "" {
+		return nil
+	}
+	if mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type")); mediaType != "application/json" {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	var buf bytes.Buffer
+	var w io.WriteCloser = gzip.NewWriter(&buf)
+	if rc.encoding == CompressionDeflate {
+		// HTTP deflate is zlib-wrapped
+		w = zlib.NewWriter(&buf)
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	compressed := buf.Bytes()
+	req.Body = io.NopCloser(bytes.NewReader(compressed))
+	req.ContentLength = int64(len(compressed))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed)), nil
+	}
+	req.Header.Set("Content-Encoding", rc.encoding)
+	return nil
+}
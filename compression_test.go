@@ -0,0 +1,84 @@
+package elevenlabs
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestCompression(t *testing.T) {
+	var encoding, text string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding = r.Header.Get("Content-Encoding")
+		var body io.Reader = r.Body
+		switch encoding {
+		case CompressionGzip:
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("gzip.NewReader() error = %v", err)
+				return
+			}
+			body = zr
+		case CompressionDeflate:
+			zr, err := zlib.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("zlib.NewReader() error = %v", err)
+				return
+			}
+			body = zr
+		}
+		var req struct {
+			Text string `json:"text"`
+		}
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			t.Errorf("decode error = %v", err)
+		}
+		text = req.Text
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	long := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 100)
+	tests := []struct {
+		name     string
+		opts     []Option
+		ctx      context.Context
+		text     string
+		encoding string
+	}{
+		{"disabled by default", nil, context.Background(), long, ""},
+		{"gzip", []Option{WithRequestCompression(CompressionGzip, 1024)}, context.Background(), long, CompressionGzip},
+		{"deflate", []Option{WithRequestCompression(CompressionDeflate, 1024)}, context.Background(), long, CompressionDeflate},
+		{"below threshold", []Option{WithRequestCompression(CompressionGzip, 1024)}, context.Background(), "Hello", ""},
+		{"opt out", []Option{WithRequestCompression(CompressionGzip, 1024)},
+			WithRequestOptions(context.Background(), WithoutRequestCompression()), long, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(append([]Option{WithAPIKey("test-key"), WithBaseURL(server.URL)}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			if _, err := client.TextToSpeech().Generate(tt.ctx, &TTSRequest{VoiceID: "voice-1", Text: tt.text}); err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if encoding != tt.encoding {
+				t.Errorf("Content-Encoding = %q, want %q", encoding, tt.encoding)
+			}
+			if text != tt.text {
+				t.Error("server received different text")
+			}
+		})
+	}
+
+	if _, err := NewClient(WithRequestCompression("br", 0)); err == nil {
+		t.Error("expected error for unsupported encoding")
+	}
+}
@@ -0,0 +1,113 @@
+package elevenlabs
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ConversationInitiationData configures a conversational AI session at start.
+// It is sent when connecting with WebSocketAgent and with Twilio and SIP
+// calls, and overrides the agent's stored configuration for one conversation.
+// Overrides must be enabled in the agent's security settings.
+type ConversationInitiationData struct {
+	// DynamicVariables are values for {{variable}} placeholders in the agent
+	// prompt and first message. Values must be strings, numbers, or booleans.
+	DynamicVariables map[string]any
+
+	// Prompt overrides the agent's system prompt.
+	Prompt string
+
+	// FirstMessage overrides the agent's first message.
+	FirstMessage string
+
+	// Language overrides the agent's language (e.g., "en", "es").
+	Language string
+
+	// VoiceID overrides the agent's TTS voice.
+	VoiceID string
+
+	// CustomLLMExtraBody is additional data to pass to a custom LLM.
+	CustomLLMExtraBody map[string]any
+
+	// UserID identifies the end user participating in the conversation.
+	UserID string
+}
+
+// conversationInitiationWire is the API representation of ConversationInitiationData.
+type conversationInitiationWire struct {
+	Type                       string                          `json:"type,omitempty"`
+	ConversationConfigOverride *conversationConfigOverrideWire `json:"conversation_config_override,omitempty"`
+	CustomLLMExtraBody         map[string]any                  `json:"custom_llm_extra_body,omitempty"`
+	DynamicVariables           map[string]any                  `json:"dynamic_variables,omitempty"`
+	UserID                     string                          `json:"user_id,omitempty"`
+}
+
+type conversationConfigOverrideWire struct {
+	Agent *agentConfigOverrideWire `json:"agent,omitempty"`
+	TTS   *ttsConfigOverrideWire   `json:"tts,omitempty"`
+}
+
+type agentConfigOverrideWire struct {
+	Prompt       *promptOverrideWire `json:"prompt,omitempty"`
+	FirstMessage string              `json:"first_message,omitempty"`
+	Language     string              `json:"language,omitempty"`
+}
+
+type promptOverrideWire struct {
+	Prompt string `json:"prompt"`
+}
+
+type ttsConfigOverrideWire struct {
+	VoiceID string `json:"voice_id"`
+}
+
+// Validate checks that dynamic variable values have supported types.
+func (d *ConversationInitiationData) Validate() error {
+	for name, value := range d.DynamicVariables {
+		if name == "" {
+			return &ValidationError{Field: "dynamic_variables", Message: "variable name cannot be empty"}
+		}
+		switch value.(type) {
+		case nil, string, bool, int, int32, int64, float32, float64:
+		default:
+			return &ValidationError{
+				Field:   "dynamic_variables",
+				Message: fmt.Sprintf("variable %q must be a string, number, or boolean, got %T", name, value),
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler using the API's nested override format.
+func (d ConversationInitiationData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.wire(""))
+}
+
+func (d *ConversationInitiationData) wire(msgType string) *conversationInitiationWire {
+	w := &conversationInitiationWire{
+		Type:               msgType,
+		CustomLLMExtraBody: d.CustomLLMExtraBody,
+		DynamicVariables:   d.DynamicVariables,
+		UserID:             d.UserID,
+	}
+
+	override := &conversationConfigOverrideWire{}
+	if d.Prompt != "" || d.FirstMessage != "" || d.Language != "" {
+		override.Agent = &agentConfigOverrideWire{
+			FirstMessage: d.FirstMessage,
+			Language:     d.Language,
+		}
+		if d.Prompt != "" {
+			override.Agent.Prompt = &promptOverrideWire{Prompt: d.Prompt}
+		}
+	}
+	if d.VoiceID != "" {
+		override.TTS = &ttsConfigOverrideWire{VoiceID: d.VoiceID}
+	}
+	if override.Agent != nil || override.TTS != nil {
+		w.ConversationConfigOverride = override
+	}
+
+	return w
+}
@@ -0,0 +1,70 @@
+package elevenlabs
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConversationInitiationDataMarshal(t *testing.T) {
+	data := ConversationInitiationData{
+		DynamicVariables: map[string]any{"name": "Ada", "vip": true},
+		Prompt:           "You are helpful.",
+		FirstMessage:     "Hi {{name}}",
+		Language:         "en",
+		VoiceID:          "voice-123",
+		UserID:           "user-1",
+	}
+
+	got, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"conversation_config_override":{"agent":{"prompt":{"prompt":"You are helpful."},"first_message":"Hi {{name}}","language":"en"},"tts":{"voice_id":"voice-123"}},"dynamic_variables":{"name":"Ada","vip":true},"user_id":"user-1"}`
+	if string(got) != want {
+		t.Errorf("Marshal() =\n%s\nwant\n%s", got, want)
+	}
+
+	// No overrides omits conversation_config_override
+	got, err = json.Marshal(ConversationInitiationData{DynamicVariables: map[string]any{"n": 1}})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"dynamic_variables":{"n":1}}`; string(got) != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+}
+
+func TestConversationInitiationDataValidate(t *testing.T) {
+	valid := &ConversationInitiationData{DynamicVariables: map[string]any{
+		"s": "text", "i": 3, "f": 1.5, "b": false, "nil": nil,
+	}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	invalid := &ConversationInitiationData{DynamicVariables: map[string]any{
+		"list": []string{"a"},
+	}}
+	var valErr *ValidationError
+	if !isValidationError(invalid.Validate(), &valErr) {
+		t.Fatal("expected ValidationError for unsupported variable type")
+	}
+	if valErr.Field != "dynamic_variables" {
+		t.Errorf("Field = %q, want %q", valErr.Field, "dynamic_variables")
+	}
+}
+
+func TestTwilioRegisterCallInitiationData(t *testing.T) {
+	req := TwilioRegisterCallRequest{
+		AgentID:        "agent-1",
+		InitiationData: &ConversationInitiationData{VoiceID: "voice-123"},
+	}
+	got, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"agent_id":"agent-1","conversation_initiation_client_data":{"conversation_config_override":{"tts":{"voice_id":"voice-123"}}}}`
+	if string(got) != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+}
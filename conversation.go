@@ -0,0 +1,375 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/agentplexus/go-elevenlabs/internal/api"
+)
+
+// Conversation is a completed or ongoing agent conversation, as returned by
+// GetConversation and sent in post-call transcription webhooks.
+type Conversation struct {
+	// ConversationID identifies the conversation.
+	ConversationID string `json:"conversation_id"`
+
+	// AgentID is the agent that held the conversation.
+	AgentID string `json:"agent_id"`
+
+	// Status is the conversation status, such as "done" or "failed".
+	Status string `json:"status"`
+
+	// Transcript is the conversation, turn by turn.
+	Transcript []ConversationTurn `json:"transcript"`
+
+	// Metadata holds timing and call details.
+	Metadata ConversationMetadata `json:"metadata"`
+
+	// Analysis is the post-call analysis, or nil if it has not run.
+	Analysis *ConversationAnalysis `json:"analysis"`
+
+	// InitiationData holds the dynamic variables the conversation was
+	// started with.
+	InitiationData ConversationClientData `json:"conversation_initiation_client_data"`
+
+	// HasAudio reports whether a recording is available.
+	HasAudio bool `json:"has_audio"`
+}
+
+// ConversationTurn is a message in a conversation transcript.
+type ConversationTurn struct {
+	// Role is "user" or "agent".
+	Role string `json:"role"`
+
+	// Message is the text of the turn. It is empty for turns that only
+	// call tools.
+	Message string `json:"message"`
+
+	// TimeInCallSecs is when the turn occurred, in seconds from the start.
+	TimeInCallSecs int `json:"time_in_call_secs"`
+
+	// AgentMetadata identifies the agent that took the turn, which
+	// changes after a transfer to another agent. Nil if not reported.
+	AgentMetadata *ConversationAgentMetadata `json:"agent_metadata,omitempty"`
+
+	// ToolResults are the results of the tools called in the turn.
+	ToolResults []ConversationToolResult `json:"tool_results,omitempty"`
+
+	// Metrics are the latencies of the services that produced an agent
+	// turn. Nil if not reported.
+	Metrics *ConversationTurnMetrics `json:"conversation_turn_metrics,omitempty"`
+}
+
+// ConversationAgentMetadata identifies the agent that took a turn.
+type ConversationAgentMetadata struct {
+	// AgentID is the agent.
+	AgentID string `json:"agent_id"`
+}
+
+// ConversationToolResult is the result of a tool call in a conversation.
+type ConversationToolResult struct {
+	// ToolName is the name of the tool (e.g., "transfer_to_agent").
+	ToolName string `json:"tool_name"`
+
+	// Type is the tool type, such as "system", "client", or "webhook".
+	Type string `json:"type,omitempty"`
+
+	// ResultValue is the result as returned to the LLM.
+	ResultValue string `json:"result_value"`
+
+	// IsError reports whether the tool call failed.
+	IsError bool `json:"is_error"`
+
+	// Result is the structured result of system tools, or nil. Its
+	// result_type field identifies the kind, such as
+	// "transfer_to_agent_success".
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+// AgentTransferEvent is a transfer from one agent to another during a
+// conversation.
+type AgentTransferEvent struct {
+	// FromAgentID is the agent that transferred the conversation.
+	FromAgentID string
+
+	// ToAgentID is the agent that took over. Empty if the transfer failed
+	// before a target was chosen.
+	ToAgentID string
+
+	// Condition is the transfer condition that matched.
+	Condition string
+
+	// TransferMessage is what was spoken to the user before transferring.
+	TransferMessage string
+
+	// TimeInCallSecs is when the transfer occurred, in seconds from the
+	// start.
+	TimeInCallSecs int
+
+	// Error is why the transfer failed. Empty if it succeeded.
+	Error string
+}
+
+// Succeeded reports whether the transfer took place.
+func (e *AgentTransferEvent) Succeeded() bool {
+	return e.Error == ""
+}
+
+// agentTransferResult is the result of the transfer_to_agent system tool.
+type agentTransferResult struct {
+	ResultType      string `json:"result_type"`
+	FromAgent       string `json:"from_agent"`
+	ToAgent         string `json:"to_agent"`
+	Condition       string `json:"condition"`
+	TransferMessage string `json:"transfer_message"`
+	Error           string `json:"error"`
+}
+
+// AgentTransfers returns the agent-to-agent transfers in the transcript,
+// successful or not, in order. See AgentsService.SetTransferToAgentTool.
+func (c *Conversation) AgentTransfers() []AgentTransferEvent {
+	var events []AgentTransferEvent
+	for _, turn := range c.Transcript {
+		for _, tr := range turn.ToolResults {
+			if len(tr.Result) == 0 {
+				continue
+			}
+			var result agentTransferResult
+			if err := json.Unmarshal(tr.Result, &result); err != nil {
+				continue
+			}
+			switch result.ResultType {
+			case "transfer_to_agent_success", "transfer_to_agent_error":
+			default:
+				continue
+			}
+			event := AgentTransferEvent{
+				FromAgentID:     result.FromAgent,
+				ToAgentID:       result.ToAgent,
+				Condition:       result.Condition,
+				TransferMessage: result.TransferMessage,
+				TimeInCallSecs:  turn.TimeInCallSecs,
+				Error:           result.Error,
+			}
+			if result.ResultType == "transfer_to_agent_error" && event.Error == "" {
+				event.Error = "transfer failed"
+			}
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// ConversationMetadata holds timing and call details of a conversation.
+type ConversationMetadata struct {
+	// StartTimeUnixSecs is when the conversation started.
+	StartTimeUnixSecs int64 `json:"start_time_unix_secs"`
+
+	// CallDurationSecs is the length of the conversation.
+	CallDurationSecs int `json:"call_duration_secs"`
+
+	// PhoneCall holds call details for phone conversations.
+	PhoneCall *ConversationPhoneCall `json:"phone_call,omitempty"`
+
+	// Cost is the number of credits charged for the conversation.
+	Cost int `json:"cost"`
+
+	// Charging holds the billing details, including LLM usage. Nil if not
+	// reported.
+	Charging *ConversationCharging `json:"charging,omitempty"`
+}
+
+// StartTime returns when the conversation started.
+func (m *ConversationMetadata) StartTime() time.Time {
+	return time.Unix(m.StartTimeUnixSecs, 0)
+}
+
+// Duration returns the length of the conversation.
+func (m *ConversationMetadata) Duration() time.Duration {
+	return time.Duration(m.CallDurationSecs) * time.Second
+}
+
+// ConversationPhoneCall holds the details of a phone conversation.
+type ConversationPhoneCall struct {
+	// Direction is "inbound" or "outbound".
+	Direction string `json:"direction"`
+
+	// AgentNumber is the agent's phone number.
+	AgentNumber string `json:"agent_number"`
+
+	// ExternalNumber is the caller's or callee's phone number.
+	ExternalNumber string `json:"external_number"`
+
+	// CallSID is the Twilio call SID, for Twilio calls.
+	CallSID string `json:"call_sid,omitempty"`
+}
+
+// ConversationClientData is the data a conversation was started with.
+type ConversationClientData struct {
+	// DynamicVariables are the prompt variables, including system
+	// variables set by the platform.
+	DynamicVariables map[string]any `json:"dynamic_variables"`
+}
+
+// GetConversation returns a conversation with its transcript, metadata,
+// and analysis.
+func (s *AgentsService) GetConversation(ctx context.Context, conversationID string) (*Conversation, error) {
+	if conversationID == "" {
+		return nil, &ValidationError{Field: "conversation_id", Message: "cannot be empty"}
+	}
+
+	var conv Conversation
+	path := "/v1/convai/conversations/" + url.PathEscape(conversationID)
+	if err := s.client.doJSON(ctx, "GET", path, nil, &conv); err != nil {
+		return nil, err
+	}
+	return &conv, nil
+}
+
+// ConversationSummary is a conversation as listed by ListConversations.
+type ConversationSummary struct {
+	// ConversationID identifies the conversation.
+	ConversationID string
+
+	// AgentID is the agent that held the conversation.
+	AgentID string
+
+	// AgentName is the name of the agent.
+	AgentName string
+
+	// Status is the conversation status, such as "done" or "failed".
+	Status string
+
+	// StartTime is when the conversation started.
+	StartTime time.Time
+
+	// CallDurationSecs is the length of the conversation in seconds.
+	CallDurationSecs int
+
+	// MessageCount is the number of transcript turns.
+	MessageCount int
+}
+
+// ConversationListOptions filters the conversations listed by
+// ListConversations.
+type ConversationListOptions struct {
+	// AgentID limits the list to one agent's conversations.
+	AgentID string
+
+	// StartedBefore limits the list to conversations started before it.
+	StartedBefore time.Time
+
+	// StartedAfter limits the list to conversations started after it.
+	StartedAfter time.Time
+
+	// PageSize is the number of conversations per page, up to 100.
+	PageSize int
+
+	// Cursor is the NextCursor of the previous page.
+	Cursor string
+}
+
+// ConversationPage is a page of conversations, newest first.
+type ConversationPage struct {
+	// Conversations is the list of conversations.
+	Conversations []*ConversationSummary
+
+	// HasMore indicates if there are more conversations to fetch.
+	HasMore bool
+
+	// NextCursor is the cursor of the next page.
+	NextCursor string
+}
+
+// ListConversations returns a page of conversations, newest first.
+func (s *AgentsService) ListConversations(ctx context.Context, opts *ConversationListOptions) (*ConversationPage, error) {
+	params := api.GetConversationHistoriesRouteParams{}
+	if opts != nil {
+		if opts.AgentID != "" {
+			params.AgentID = api.NewOptNilString(opts.AgentID)
+		}
+		if !opts.StartedBefore.IsZero() {
+			params.CallStartBeforeUnix = api.NewOptNilInt(int(opts.StartedBefore.Unix()))
+		}
+		if !opts.StartedAfter.IsZero() {
+			params.CallStartAfterUnix = api.NewOptNilInt(int(opts.StartedAfter.Unix()))
+		}
+		if opts.PageSize > 0 {
+			params.PageSize = api.NewOptInt(opts.PageSize)
+		}
+		if opts.Cursor != "" {
+			params.Cursor = api.NewOptNilString(opts.Cursor)
+		}
+	}
+
+	resp, err := s.client.apiClient.GetConversationHistoriesRoute(ctx, params)
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.GetConversationsPageResponseModel:
+		page := &ConversationPage{HasMore: r.HasMore}
+		if r.NextCursor.Set && !r.NextCursor.Null {
+			page.NextCursor = r.NextCursor.Value
+		}
+		for _, c := range r.Conversations {
+			summary := &ConversationSummary{
+				ConversationID:   c.ConversationID,
+				AgentID:          c.AgentID,
+				Status:           string(c.Status),
+				StartTime:        time.Unix(int64(c.StartTimeUnixSecs), 0),
+				CallDurationSecs: c.CallDurationSecs,
+				MessageCount:     c.MessageCount,
+			}
+			if c.AgentName.Set && !c.AgentName.Null {
+				summary.AgentName = c.AgentName.Value
+			}
+			page.Conversations = append(page.Conversations, summary)
+		}
+		return page, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
+	default:
+		return nil, unexpectedResponse(resp)
+	}
+}
+
+// GetConversationRecording returns the audio recording of a conversation.
+// Close the reader if it implements io.Closer.
+func (s *AgentsService) GetConversationRecording(ctx context.Context, conversationID string) (io.Reader, error) {
+	if conversationID == "" {
+		return nil, &ValidationError{Field: "conversation_id", Message: "cannot be empty"}
+	}
+
+	resp, err := s.client.apiClient.GetConversationAudioRoute(ctx, api.GetConversationAudioRouteParams{
+		ConversationID: conversationID,
+	})
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.GetConversationAudioRouteOK:
+		return r.Data, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
+	default:
+		return nil, unexpectedResponse(resp)
+	}
+}
+
+// DeleteConversation deletes a conversation with its transcript and
+// recording. The API does not delete the recording alone.
+func (s *AgentsService) DeleteConversation(ctx context.Context, conversationID string) error {
+	if conversationID == "" {
+		return &ValidationError{Field: "conversation_id", Message: "cannot be empty"}
+	}
+
+	return checkResponse(s.client.apiClient.DeleteConversationRoute(ctx, api.DeleteConversationRouteParams{
+		ConversationID: conversationID,
+	}))
+}
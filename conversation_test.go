@@ -0,0 +1,139 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testConversationJSON = `{
+	"conversation_id": "conv-1",
+	"agent_id": "agent-1",
+	"status": "done",
+	"has_audio": true,
+	"transcript": [
+		{"role": "agent", "message": "Hi, how can I help?", "time_in_call_secs": 0},
+		{"role": "user", "message": "I'd like to book a demo.", "time_in_call_secs": 3},
+		{"role": "agent", "message": null, "time_in_call_secs": 5},
+		{"role": "agent", "message": "Booked for Tuesday.", "time_in_call_secs": 9}
+	],
+	"metadata": {
+		"start_time_unix_secs": 1700000000,
+		"call_duration_secs": 42,
+		"phone_call": {"direction": "inbound", "agent_number": "+15550000000", "external_number": "+15551234567", "call_sid": "CA123"}
+	},
+	"analysis": {
+		"call_successful": "success",
+		"call_summary_title": "Demo booking",
+		"transcript_summary": "The caller booked a demo for Tuesday.",
+		"evaluation_criteria_results": {},
+		"data_collection_results": {
+			"customer_email": {"data_collection_id": "customer_email", "value": "ada@example.com", "rationale": "Stated by caller."},
+			"sentiment": {"data_collection_id": "sentiment", "value": "positive", "rationale": ""},
+			"company_size": {"data_collection_id": "company_size", "value": null, "rationale": "Not mentioned."}
+		}
+	},
+	"conversation_initiation_client_data": {"dynamic_variables": {"contact_id": "003xx", "system__caller_id": "+15551234567"}}
+}`
+
+func TestAgentsGetConversation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/convai/conversations/conv-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(testConversationJSON))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	conv, err := client.Agents().GetConversation(context.Background(), "conv-1")
+	if err != nil {
+		t.Fatalf("GetConversation() error = %v", err)
+	}
+	if conv.AgentID != "agent-1" || len(conv.Transcript) != 4 || !conv.HasAudio {
+		t.Errorf("conv = %+v", conv)
+	}
+	if conv.Metadata.Duration() != 42*time.Second || conv.Metadata.PhoneCall == nil || conv.Metadata.PhoneCall.CallSID != "CA123" {
+		t.Errorf("Metadata = %+v", conv.Metadata)
+	}
+	if conv.Analysis == nil || conv.Analysis.DataCollectionResults["customer_email"].Value != "ada@example.com" {
+		t.Errorf("Analysis = %+v", conv.Analysis)
+	}
+
+	var valErr *ValidationError
+	if _, err := client.Agents().GetConversation(context.Background(), ""); !isValidationError(err, &valErr) {
+		t.Errorf("GetConversation('') error = %v", err)
+	}
+}
+
+func TestAgentsGetConversationRecording(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/convai/conversations/conv-1/audio" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write([]byte("ID3audio"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	audio, err := client.Agents().GetConversationRecording(context.Background(), "conv-1")
+	if err != nil {
+		t.Fatalf("GetConversationRecording() error = %v", err)
+	}
+	if data, _ := io.ReadAll(audio); string(data) != "ID3audio" {
+		t.Errorf("recording = %q", data)
+	}
+}
+
+func TestConversationAgentTransfers(t *testing.T) {
+	data := `{
+		"conversation_id": "conv-2",
+		"agent_id": "reception",
+		"transcript": [
+			{"role": "agent", "message": "Hi!", "time_in_call_secs": 0, "agent_metadata": {"agent_id": "reception"}},
+			{"role": "agent", "message": null, "time_in_call_secs": 4, "tool_results": [
+				{"tool_name": "lookup", "type": "webhook", "result_value": "{}", "is_error": false},
+				{"tool_name": "transfer_to_agent", "type": "system", "result_value": "ok", "is_error": false,
+				 "result": {"result_type": "transfer_to_agent_success", "status": "success", "from_agent": "reception", "to_agent": "billing",
+				            "condition": "Invoice questions", "transfer_message": "One moment.", "delay_ms": 0}}
+			]},
+			{"role": "agent", "message": "Billing here.", "time_in_call_secs": 6, "agent_metadata": {"agent_id": "billing"}},
+			{"role": "agent", "message": null, "time_in_call_secs": 20, "tool_results": [
+				{"tool_name": "transfer_to_agent", "type": "system", "result_value": "", "is_error": true,
+				 "result": {"result_type": "transfer_to_agent_error", "status": "error", "from_agent": "billing", "error": "agent not found"}}
+			]}
+		]
+	}`
+	var conv Conversation
+	if err := json.Unmarshal([]byte(data), &conv); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if conv.Transcript[2].AgentMetadata == nil || conv.Transcript[2].AgentMetadata.AgentID != "billing" {
+		t.Errorf("AgentMetadata = %+v", conv.Transcript[2].AgentMetadata)
+	}
+
+	events := conv.AgentTransfers()
+	if len(events) != 2 {
+		t.Fatalf("AgentTransfers() = %+v, want 2 events", events)
+	}
+	want := AgentTransferEvent{FromAgentID: "reception", ToAgentID: "billing", Condition: "Invoice questions", TransferMessage: "One moment.", TimeInCallSecs: 4}
+	if events[0] != want || !events[0].Succeeded() {
+		t.Errorf("events[0] = %+v, want %+v", events[0], want)
+	}
+	if events[1].Succeeded() || events[1].Error != "agent not found" || events[1].TimeInCallSecs != 20 {
+		t.Errorf("events[1] = %+v", events[1])
+	}
+}
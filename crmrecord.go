@@ -0,0 +1,190 @@
+package elevenlabs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CRMRecord is a conversation flattened for a CRM, such as a call log
+// entry, lead, or ticket.
+type CRMRecord struct {
+	// ConversationID identifies the conversation.
+	ConversationID string `json:"conversation_id"`
+
+	// AgentID is the agent that held the conversation.
+	AgentID string `json:"agent_id"`
+
+	// StartTime is when the conversation started.
+	StartTime time.Time `json:"start_time"`
+
+	// DurationSecs is the length of the conversation.
+	DurationSecs int `json:"duration_secs"`
+
+	// Direction is "inbound" or "outbound" for phone calls.
+	Direction string `json:"direction,omitempty"`
+
+	// PhoneNumber is the caller's or callee's number for phone calls.
+	PhoneNumber string `json:"phone_number,omitempty"`
+
+	// Title is a short title for the conversation.
+	Title string `json:"title,omitempty"`
+
+	// Summary summarizes the conversation.
+	Summary string `json:"summary"`
+
+	// Outcome is EvaluationSuccess, EvaluationFailure, or
+	// EvaluationUnknown.
+	Outcome string `json:"outcome"`
+
+	// Sentiment is the customer's sentiment. The API does not rate
+	// sentiment, so it is only set by a mapper such as MapSentiment.
+	Sentiment string `json:"sentiment,omitempty"`
+
+	// Fields are the collected values, keyed by data collection ID unless
+	// renamed by a mapper.
+	Fields map[string]any `json:"fields,omitempty"`
+
+	// Transcript is the conversation as text, one "Agent:" or "User:"
+	// line per turn.
+	Transcript string `json:"transcript"`
+
+	// RecordingURL links to the recording. It is only set by
+	// MapRecordingURL, as the API's audio endpoint requires an API key.
+	RecordingURL string `json:"recording_url,omitempty"`
+}
+
+// CRMFieldMapper adjusts a record built from a conversation, for example
+// to rename fields to match a CRM schema.
+type CRMFieldMapper func(conv *Conversation, record *CRMRecord) error
+
+// MapConversation converts a conversation into a CRM record, then applies
+// mappers in order:
+//
+//	record, err := elevenlabs.MapConversation(conv,
+//	    elevenlabs.MapSentiment("sentiment"),
+//	    elevenlabs.MapDataCollection("customer_email", "Email"),
+//	    elevenlabs.MapDynamicVariable("contact_id", "ContactId"),
+//	)
+func MapConversation(conv *Conversation, mappers ...CRMFieldMapper) (*CRMRecord, error) {
+	if conv == nil {
+		return nil, &ValidationError{Field: "conversation", Message: "cannot be nil"}
+	}
+
+	record := &CRMRecord{
+		ConversationID: conv.ConversationID,
+		AgentID:        conv.AgentID,
+		DurationSecs:   conv.Metadata.CallDurationSecs,
+		Outcome:        EvaluationUnknown,
+		Transcript:     formatTranscript(conv.Transcript),
+	}
+	if conv.Metadata.StartTimeUnixSecs > 0 {
+		record.StartTime = conv.Metadata.StartTime().UTC()
+	}
+	if call := conv.Metadata.PhoneCall; call != nil {
+		record.Direction = call.Direction
+		record.PhoneNumber = call.ExternalNumber
+	}
+	if a := conv.Analysis; a != nil {
+		record.Title = a.CallSummaryTitle
+		record.Summary = a.TranscriptSummary
+		if a.CallSuccessful != "" {
+			record.Outcome = a.CallSuccessful
+		}
+		for id, result := range a.DataCollectionResults {
+			if result.Value == nil {
+				continue
+			}
+			if record.Fields == nil {
+				record.Fields = make(map[string]any)
+			}
+			record.Fields[id] = result.Value
+		}
+	}
+
+	for _, mapper := range mappers {
+		if err := mapper(conv, record); err != nil {
+			return nil, err
+		}
+	}
+	return record, nil
+}
+
+// MapDataCollection stores the data collection value with the given ID
+// under field instead.
+func MapDataCollection(id, field string) CRMFieldMapper {
+	return func(_ *Conversation, record *CRMRecord) error {
+		value, ok := record.Fields[id]
+		if !ok {
+			return nil
+		}
+		delete(record.Fields, id)
+		record.Fields[field] = value
+		return nil
+	}
+}
+
+// MapSentiment sets Sentiment from a data collection field, such as one
+// defined to classify the customer as "positive", "neutral", or
+// "negative".
+func MapSentiment(dataCollectionID string) CRMFieldMapper {
+	return func(_ *Conversation, record *CRMRecord) error {
+		if value, ok := record.Fields[dataCollectionID]; ok {
+			record.Sentiment = fmt.Sprint(value)
+		}
+		return nil
+	}
+}
+
+// MapDynamicVariable copies a dynamic variable the conversation was
+// started with, such as a CRM contact ID, into field.
+func MapDynamicVariable(name, field string) CRMFieldMapper {
+	return func(conv *Conversation, record *CRMRecord) error {
+		value, ok := conv.InitiationData.DynamicVariables[name]
+		if !ok {
+			return nil
+		}
+		if record.Fields == nil {
+			record.Fields = make(map[string]any)
+		}
+		record.Fields[field] = value
+		return nil
+	}
+}
+
+// MapRecordingURL sets RecordingURL for conversations with audio, using fn
+// to return a URL the CRM can open, for example after copying the audio
+// to your own storage.
+func MapRecordingURL(fn func(conversationID string) (string, error)) CRMFieldMapper {
+	return func(conv *Conversation, record *CRMRecord) error {
+		if !conv.HasAudio {
+			return nil
+		}
+		u, err := fn(conv.ConversationID)
+		if err != nil {
+			return fmt.Errorf("recording URL for %s: %w", conv.ConversationID, err)
+		}
+		record.RecordingURL = u
+		return nil
+	}
+}
+
+// formatTranscript writes turns as "Agent:" and "User:" lines, skipping
+// turns without text.
+func formatTranscript(turns []ConversationTurn) string {
+	var b strings.Builder
+	for _, turn := range turns {
+		if turn.Message == "" {
+			continue
+		}
+		speaker := "User"
+		if turn.Role == "agent" {
+			speaker = "Agent"
+		}
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(speaker + ": " + turn.Message)
+	}
+	return b.String()
+}
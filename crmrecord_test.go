@@ -0,0 +1,81 @@
+package elevenlabs
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMapConversation(t *testing.T) {
+	var conv Conversation
+	if err := json.Unmarshal([]byte(testConversationJSON), &conv); err != nil {
+		t.Fatalf("decoding conversation: %v", err)
+	}
+
+	record, err := MapConversation(&conv,
+		MapSentiment("sentiment"),
+		MapDataCollection("customer_email", "Email"),
+		MapDynamicVariable("contact_id", "ContactId"),
+		MapRecordingURL(func(id string) (string, error) {
+			return "https://storage.example.com/calls/" + id + ".mp3", nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("MapConversation() error = %v", err)
+	}
+
+	if record.ConversationID != "conv-1" || record.AgentID != "agent-1" {
+		t.Errorf("IDs = %q, %q", record.ConversationID, record.AgentID)
+	}
+	if !record.StartTime.Equal(time.Unix(1700000000, 0)) || record.DurationSecs != 42 {
+		t.Errorf("StartTime = %v, DurationSecs = %d", record.StartTime, record.DurationSecs)
+	}
+	if record.Direction != "inbound" || record.PhoneNumber != "+15551234567" {
+		t.Errorf("Direction = %q, PhoneNumber = %q", record.Direction, record.PhoneNumber)
+	}
+	if record.Title != "Demo booking" || record.Summary != "The caller booked a demo for Tuesday." || record.Outcome != EvaluationSuccess {
+		t.Errorf("Title = %q, Summary = %q, Outcome = %q", record.Title, record.Summary, record.Outcome)
+	}
+	if record.Sentiment != "positive" {
+		t.Errorf("Sentiment = %q", record.Sentiment)
+	}
+	if record.Fields["Email"] != "ada@example.com" || record.Fields["ContactId"] != "003xx" {
+		t.Errorf("Fields = %v", record.Fields)
+	}
+	if _, ok := record.Fields["customer_email"]; ok {
+		t.Error("renamed field kept its data collection ID")
+	}
+	if _, ok := record.Fields["company_size"]; ok {
+		t.Error("Fields includes a value that was not collected")
+	}
+	if record.RecordingURL != "https://storage.example.com/calls/conv-1.mp3" {
+		t.Errorf("RecordingURL = %q", record.RecordingURL)
+	}
+	wantTranscript := "Agent: Hi, how can I help?\nUser: I'd like to book a demo.\nAgent: Booked for Tuesday."
+	if record.Transcript != wantTranscript {
+		t.Errorf("Transcript = %q, want %q", record.Transcript, wantTranscript)
+	}
+}
+
+func TestMapConversationWithoutAnalysis(t *testing.T) {
+	record, err := MapConversation(&Conversation{ConversationID: "conv-2"})
+	if err != nil {
+		t.Fatalf("MapConversation() error = %v", err)
+	}
+	if record.Outcome != EvaluationUnknown || record.Fields != nil || !record.StartTime.IsZero() {
+		t.Errorf("record = %+v", record)
+	}
+
+	errStorage := errors.New("storage unavailable")
+	_, err = MapConversation(&Conversation{ConversationID: "conv-2", HasAudio: true},
+		MapRecordingURL(func(string) (string, error) { return "", errStorage }))
+	if !errors.Is(err, errStorage) {
+		t.Errorf("MapConversation() error = %v, want %v", err, errStorage)
+	}
+
+	var valErr *ValidationError
+	if _, err := MapConversation(nil); !isValidationError(err, &valErr) {
+		t.Errorf("MapConversation(nil) error = %v", err)
+	}
+}
@@ -0,0 +1,205 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Data collection field types.
+const (
+	DataCollectionString  = "string"
+	DataCollectionBoolean = "boolean"
+	DataCollectionInteger = "integer"
+	DataCollectionNumber  = "number"
+)
+
+// DataCollectionField is a value the post-call analysis extracts from
+// each conversation, such as the caller's email or the number of seats
+// they asked about.
+type DataCollectionField struct {
+	// Type is DataCollectionString, DataCollectionBoolean,
+	// DataCollectionInteger, or DataCollectionNumber.
+	Type string `json:"type"`
+
+	// Description tells the LLM what to extract (e.g., "The email address
+	// the caller gave, if any.").
+	Description string `json:"description"`
+
+	// Enum limits string fields to these values.
+	Enum []string `json:"enum,omitempty"`
+}
+
+func (f *DataCollectionField) validate(id string) error {
+	switch f.Type {
+	case DataCollectionString, DataCollectionBoolean, DataCollectionInteger, DataCollectionNumber:
+	default:
+		return &ValidationError{Field: "data_collection", Message: fmt.Sprintf("field %s has unsupported type %q", id, f.Type)}
+	}
+	if f.Description == "" {
+		return &ValidationError{Field: "data_collection", Message: fmt.Sprintf("field %s description cannot be empty", id)}
+	}
+	if len(f.Enum) > 0 && f.Type != DataCollectionString {
+		return &ValidationError{Field: "data_collection", Message: fmt.Sprintf("field %s enum requires type string", id)}
+	}
+	return nil
+}
+
+// agentDataCollection is the agent config fragment holding the data
+// collection fields.
+type agentDataCollection struct {
+	PlatformSettings struct {
+		DataCollection map[string]DataCollectionField `json:"data_collection"`
+	} `json:"platform_settings"`
+}
+
+// GetDataCollection returns the agent's data collection fields by ID.
+func (s *AgentsService) GetDataCollection(ctx context.Context, agentID string) (map[string]DataCollectionField, error) {
+	if agentID == "" {
+		return nil, &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+
+	var agent agentDataCollection
+	if err := s.client.doJSON(ctx, "GET", agentPath(agentID), nil, &agent); err != nil {
+		return nil, err
+	}
+	if agent.PlatformSettings.DataCollection == nil {
+		return map[string]DataCollectionField{}, nil
+	}
+	return agent.PlatformSettings.DataCollection, nil
+}
+
+// SetDataCollection replaces the agent's data collection fields. The IDs
+// are the keys of ConversationAnalysis.DataCollectionResults. An empty map
+// removes all fields.
+func (s *AgentsService) SetDataCollection(ctx context.Context, agentID string, fields map[string]DataCollectionField) error {
+	if agentID == "" {
+		return &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+	for _, id := range sortedFieldIDs(fields) {
+		if id == "" {
+			return &ValidationError{Field: "data_collection", Message: "field ID cannot be empty"}
+		}
+		f := fields[id]
+		if err := f.validate(id); err != nil {
+			return err
+		}
+	}
+
+	var update agentDataCollection
+	update.PlatformSettings.DataCollection = fields
+	if fields == nil {
+		update.PlatformSettings.DataCollection = map[string]DataCollectionField{}
+	}
+	return s.client.doJSON(ctx, "PATCH", agentPath(agentID), &update, nil)
+}
+
+// DataCollectionSchema returns a JSON Schema (draft 2020-12) describing the
+// values collected by fields, as an object keyed by field ID. Fields are
+// not required, since a value is only collected when the conversation
+// contains it. Use it to validate DataCollectionValues before storing them
+// or to generate types for downstream systems.
+func DataCollectionSchema(fields map[string]DataCollectionField) ([]byte, error) {
+	type property struct {
+		Type        any      `json:"type"`
+		Description string   `json:"description,omitempty"`
+		Enum        []string `json:"enum,omitempty"`
+	}
+	properties := make(map[string]property, len(fields))
+	for _, id := range sortedFieldIDs(fields) {
+		f := fields[id]
+		if err := f.validate(id); err != nil {
+			return nil, err
+		}
+		properties[id] = property{
+			// Values that were not found are null
+			Type:        []string{f.Type, "null"},
+			Description: f.Description,
+			Enum:        f.Enum,
+		}
+	}
+	schema := map[string]any{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+func sortedFieldIDs(fields map[string]DataCollectionField) []string {
+	ids := make([]string, 0, len(fields))
+	for id := range fields {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// DataCollectionValues returns the collected values by field ID, without
+// the fields that were not found.
+func (a *ConversationAnalysis) DataCollectionValues() map[string]any {
+	values := make(map[string]any, len(a.DataCollectionResults))
+	for id, r := range a.DataCollectionResults {
+		if r.Value != nil {
+			values[id] = r.Value
+		}
+	}
+	return values
+}
+
+// DecodeDataCollection decodes the collected values into v, a pointer to
+// a struct whose json tags name the field IDs:
+//
+//	var lead struct {
+//	    Email string `json:"customer_email"`
+//	    Seats int    `json:"seats"`
+//	    Demo  bool   `json:"wants_demo"`
+//	}
+//	err := conv.Analysis.DecodeDataCollection(&lead)
+//
+// Fields that were not found keep their zero value; use pointer fields to
+// tell them apart.
+func (a *ConversationAnalysis) DecodeDataCollection(v any) error {
+	data, err := json.Marshal(a.DataCollectionValues())
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decoding data collection: %w", err)
+	}
+	return nil
+}
+
+// DataCollectionString returns a collected string value. ok is false if
+// the value was not found or is not a string.
+func (a *ConversationAnalysis) DataCollectionString(id string) (value string, ok bool) {
+	value, ok = a.DataCollectionResults[id].Value.(string)
+	return value, ok
+}
+
+// DataCollectionBool returns a collected boolean value. ok is false if the
+// value was not found or is not a boolean.
+func (a *ConversationAnalysis) DataCollectionBool(id string) (value bool, ok bool) {
+	value, ok = a.DataCollectionResults[id].Value.(bool)
+	return value, ok
+}
+
+// DataCollectionInt returns a collected integer value. ok is false if the
+// value was not found or is not a whole number.
+func (a *ConversationAnalysis) DataCollectionInt(id string) (value int64, ok bool) {
+	f, ok := a.DataCollectionResults[id].Value.(float64)
+	if !ok || f != math.Trunc(f) || math.Abs(f) > 1<<53 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// DataCollectionFloat returns a collected number value. ok is false if
+// the value was not found or is not a number.
+func (a *ConversationAnalysis) DataCollectionFloat(id string) (value float64, ok bool) {
+	value, ok = a.DataCollectionResults[id].Value.(float64)
+	return value, ok
+}
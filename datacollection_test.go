@@ -0,0 +1,185 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAgentsDataCollection(t *testing.T) {
+	var patched map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/convai/agents/agent-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"platform_settings": {"data_collection": {
+				"customer_email": {"type": "string", "description": "The caller's email"},
+				"seats": {"type": "integer", "description": "Number of seats"}
+			}}}`))
+		case http.MethodPatch:
+			patched = nil
+			if err := json.NewDecoder(r.Body).Decode(&patched); err != nil {
+				t.Errorf("decoding body: %v", err)
+			}
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	fields, err := client.Agents().GetDataCollection(ctx, "agent-1")
+	if err != nil {
+		t.Fatalf("GetDataCollection() error = %v", err)
+	}
+	if len(fields) != 2 || fields["seats"].Type != DataCollectionInteger || fields["customer_email"].Description != "The caller's email" {
+		t.Errorf("fields = %+v", fields)
+	}
+
+	err = client.Agents().SetDataCollection(ctx, "agent-1", map[string]DataCollectionField{
+		"sentiment": {Type: DataCollectionString, Description: "Customer sentiment", Enum: []string{"positive", "neutral", "negative"}},
+	})
+	if err != nil {
+		t.Fatalf("SetDataCollection() error = %v", err)
+	}
+	dc := patched["platform_settings"].(map[string]any)["data_collection"].(map[string]any)
+	sentiment := dc["sentiment"].(map[string]any)
+	if len(dc) != 1 || sentiment["type"] != "string" || len(sentiment["enum"].([]any)) != 3 {
+		t.Errorf("data_collection = %v", dc)
+	}
+
+	if err := client.Agents().SetDataCollection(ctx, "agent-1", nil); err != nil {
+		t.Fatalf("SetDataCollection(nil) error = %v", err)
+	}
+	if dc := patched["platform_settings"].(map[string]any)["data_collection"].(map[string]any); len(dc) != 0 {
+		t.Errorf("data_collection = %v, want empty", dc)
+	}
+}
+
+func TestDataCollectionFieldValidation(t *testing.T) {
+	client, err := NewClient(WithAPIKey("test-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		fields map[string]DataCollectionField
+	}{
+		{"empty ID", map[string]DataCollectionField{"": {Type: DataCollectionString, Description: "x"}}},
+		{"bad type", map[string]DataCollectionField{"a": {Type: "date", Description: "x"}}},
+		{"no description", map[string]DataCollectionField{"a": {Type: DataCollectionBoolean}}},
+		{"enum on number", map[string]DataCollectionField{"a": {Type: DataCollectionNumber, Description: "x", Enum: []string{"1"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var valErr *ValidationError
+			if err := client.Agents().SetDataCollection(context.Background(), "agent-1", tt.fields); !isValidationError(err, &valErr) {
+				t.Errorf("SetDataCollection() = %v, want ValidationError", err)
+			}
+		})
+	}
+}
+
+func TestDataCollectionSchema(t *testing.T) {
+	data, err := DataCollectionSchema(map[string]DataCollectionField{
+		"seats":     {Type: DataCollectionInteger, Description: "Number of seats"},
+		"sentiment": {Type: DataCollectionString, Description: "Sentiment", Enum: []string{"positive", "negative"}},
+	})
+	if err != nil {
+		t.Fatalf("DataCollectionSchema() error = %v", err)
+	}
+	var schema struct {
+		Type                 string `json:"type"`
+		AdditionalProperties bool   `json:"additionalProperties"`
+		Properties           map[string]struct {
+			Type        []string `json:"type"`
+			Description string   `json:"description"`
+			Enum        []string `json:"enum"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if schema.Type != "object" || schema.AdditionalProperties {
+		t.Errorf("schema = %s", data)
+	}
+	seats := schema.Properties["seats"]
+	if len(seats.Type) != 2 || seats.Type[0] != "integer" || seats.Type[1] != "null" || seats.Description != "Number of seats" {
+		t.Errorf("seats = %+v", seats)
+	}
+	if len(schema.Properties["sentiment"].Enum) != 2 {
+		t.Errorf("sentiment = %+v", schema.Properties["sentiment"])
+	}
+
+	if _, err := DataCollectionSchema(map[string]DataCollectionField{"a": {Type: "date", Description: "x"}}); err == nil {
+		t.Error("DataCollectionSchema() with an invalid field: expected error")
+	}
+}
+
+func TestConversationAnalysisDataCollectionValues(t *testing.T) {
+	var conv Conversation
+	if err := json.Unmarshal([]byte(`{"analysis": {"data_collection_results": {
+		"customer_email": {"data_collection_id": "customer_email", "value": "ada@example.com"},
+		"seats": {"data_collection_id": "seats", "value": 25},
+		"budget": {"data_collection_id": "budget", "value": 1250.5},
+		"wants_demo": {"data_collection_id": "wants_demo", "value": true},
+		"company": {"data_collection_id": "company", "value": null}
+	}}}`), &conv); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	a := conv.Analysis
+
+	if v, ok := a.DataCollectionString("customer_email"); !ok || v != "ada@example.com" {
+		t.Errorf("DataCollectionString() = %q, %v", v, ok)
+	}
+	if v, ok := a.DataCollectionInt("seats"); !ok || v != 25 {
+		t.Errorf("DataCollectionInt() = %d, %v", v, ok)
+	}
+	if _, ok := a.DataCollectionInt("budget"); ok {
+		t.Error("DataCollectionInt() of a fraction should not be ok")
+	}
+	if v, ok := a.DataCollectionFloat("budget"); !ok || v != 1250.5 {
+		t.Errorf("DataCollectionFloat() = %v, %v", v, ok)
+	}
+	if v, ok := a.DataCollectionBool("wants_demo"); !ok || !v {
+		t.Errorf("DataCollectionBool() = %v, %v", v, ok)
+	}
+	if _, ok := a.DataCollectionString("company"); ok {
+		t.Error("DataCollectionString() of a null value should not be ok")
+	}
+	if _, ok := a.DataCollectionString("missing"); ok {
+		t.Error("DataCollectionString() of a missing field should not be ok")
+	}
+	if values := a.DataCollectionValues(); len(values) != 4 {
+		t.Errorf("DataCollectionValues() = %v, want 4 values", values)
+	}
+
+	var lead struct {
+		Email   string  `json:"customer_email"`
+		Seats   int     `json:"seats"`
+		Demo    bool    `json:"wants_demo"`
+		Company *string `json:"company"`
+	}
+	if err := a.DecodeDataCollection(&lead); err != nil {
+		t.Fatalf("DecodeDataCollection() error = %v", err)
+	}
+	if lead.Email != "ada@example.com" || lead.Seats != 25 || !lead.Demo || lead.Company != nil {
+		t.Errorf("lead = %+v", lead)
+	}
+
+	var wrong struct {
+		Seats string `json:"seats"`
+	}
+	if err := a.DecodeDataCollection(&wrong); err == nil {
+		t.Error("DecodeDataCollection() into a mismatched type: expected error")
+	}
+}
@@ -0,0 +1,219 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	ht "github.com/ogen-go/ogen/http"
+)
+
+// DryRunRequest describes a request that was not sent because the client
+// is in dry-run mode.
+type DryRunRequest struct {
+	// Method is the HTTP method (e.g., "POST").
+	Method string
+
+	// Endpoint is the request path (e.g., "/v1/text-to-speech/{voice_id}").
+	Endpoint string
+
+	// ContentType is the request content type.
+	ContentType string
+
+	// BodySize is the size of the request body in bytes.
+	BodySize int
+
+	// BodySummary is the beginning of the request body. Multipart bodies
+	// are not summarized.
+	BodySummary string
+
+	// EstimatedCharacters is the number of characters that would be billed,
+	// counted from the "text" fields of JSON bodies.
+	EstimatedCharacters int
+
+	// Synthetic reports whether a synthetic response was returned. Requests
+	// without a synthetic response fail with ErrDryRun.
+	Synthetic bool
+}
+
+// DryRunHandler is called for each request intercepted in dry-run mode.
+type DryRunHandler func(req *DryRunRequest)
+
+// WithDryRun enables dry-run mode. Read-only (GET) requests are sent
+// normally. Mutating and generating requests are not sent: they are logged
+// and return a synthetic response. Endpoints that respond with audio return
+// empty audio; other mutating calls, including generations that respond
+// with JSON such as GenerateWithTimestamps, return ErrDryRun. WebSocket
+// connections fail with ErrDryRun. Intercepted requests are logged at info
+// level to the WithLogger logger, or slog.Default if none is set.
+//
+// Use this to verify batch scripts before spending quota.
+func WithDryRun() Option {
+	return func(o *clientOptions) {
+		o.dryRun = true
+	}
+}
+
+// WithDryRunHandler enables dry-run mode and calls handler for each
+// intercepted request instead of logging it.
+func WithDryRunHandler(handler DryRunHandler) Option {
+	return func(o *clientOptions) {
+		o.dryRun = true
+		o.dryRunHandler = handler
+	}
+}
+
+// DryRun reports whether the client is in dry-run mode.
+func (c *Client) DryRun() bool {
+	return c.dryRun
+}
+
+// dryRunMaxSummary is the maximum length of DryRunRequest.BodySummary.
+const dryRunMaxSummary = 200
+
+// audioRoutes are the endpoints that respond with audio, and so can be
+// answered with empty audio in dry-run mode. "*" matches one path segment.
+// Endpoints that respond with JSON, such as the with-timestamps variants
+// and music plans, fail with ErrDryRun instead.
+var audioRoutes = []string{
+	"/v1/text-to-speech/*",
+	"/v1/text-to-speech/*/stream",
+	"/v1/text-to-dialogue",
+	"/v1/text-to-dialogue/stream",
+	"/v1/speech-to-speech/*",
+	"/v1/speech-to-speech/*/stream",
+	"/v1/sound-generation",
+	"/v1/audio-isolation",
+	"/v1/audio-isolation/stream",
+	"/v1/music",
+	"/v1/music/stream",
+}
+
+// dryRunHTTPClient intercepts mutating requests.
+type dryRunHTTPClient struct {
+	next    ht.Client
+	handler DryRunHandler
+	logger  *slog.Logger
+}
+
+// Do implements ht.Client interface.
+func (c *dryRunHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return c.next.Do(req)
+	}
+
+	dr := &DryRunRequest{
+		Method:      req.Method,
+		Endpoint:    req.URL.Path,
+		ContentType: req.Header.Get("Content-Type"),
+	}
+
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		dr.BodySize = len(body)
+		if !strings.HasPrefix(dr.ContentType, "multipart/") {
+			dr.BodySummary = summarizeBody(body)
+		}
+		if strings.HasPrefix(dr.ContentType, "application/json") {
+			dr.EstimatedCharacters = countTextCharacters(body)
+		}
+	}
+
+	dr.Synthetic = returnsAudio(req.URL.Path)
+
+	if c.handler != nil {
+		c.handler(dr)
+	} else {
+		c.logger.LogAttrs(req.Context(), slog.LevelInfo, "elevenlabs: dry run",
+			slog.String("method", dr.Method),
+			slog.String("path", dr.Endpoint),
+			slog.Int("body_size", dr.BodySize),
+			slog.Int("estimated_characters", dr.EstimatedCharacters),
+		)
+	}
+
+	if !dr.Synthetic {
+		return nil, ErrDryRun
+	}
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"Content-Type":         []string{"audio/mpeg"},
+			"X-Elevenlabs-Dry-Run": []string{"true"},
+		},
+		Body:          io.NopCloser(bytes.NewReader(nil)),
+		ContentLength: 0,
+		Request:       req,
+	}, nil
+}
+
+// returnsAudio reports whether path is one of audioRoutes. The route is
+// matched against the end of the path, so base URLs with a path prefix,
+// such as a proxy, still match.
+func returnsAudio(path string) bool {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, route := range audioRoutes {
+		pattern := strings.Split(strings.Trim(route, "/"), "/")
+		if len(pattern) > len(segments) {
+			continue
+		}
+		tail := segments[len(segments)-len(pattern):]
+		matched := true
+		for i, p := range pattern {
+			if p != "*" && p != tail[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// summarizeBody returns the beginning of a request body.
+func summarizeBody(body []byte) string {
+	if len(body) <= dryRunMaxSummary {
+		return string(body)
+	}
+	summary := body[:dryRunMaxSummary]
+	for !utf8.Valid(summary) && len(summary) > 0 {
+		summary = summary[:len(summary)-1]
+	}
+	return string(summary) + "..."
+}
+
+// countTextCharacters counts the characters in "text" fields of a JSON
+// body, including those of dialogue inputs.
+func countTextCharacters(body []byte) int {
+	var payload struct {
+		Text   string `json:"text"`
+		Inputs []struct {
+			Text string `json:"text"`
+		} `json:"inputs"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return 0
+	}
+
+	count := utf8.RuneCountInString(payload.Text)
+	for _, input := range payload.Inputs {
+		count += utf8.RuneCountInString(input.Text)
+	}
+	return count
+}
@@ -0,0 +1,154 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	var posts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			posts.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"voices":[]}`))
+	}))
+	defer server.Close()
+
+	var intercepted []*DryRunRequest
+	client, err := NewClient(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithDryRunHandler(func(req *DryRunRequest) {
+			intercepted = append(intercepted, req)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if !client.DryRun() {
+		t.Fatal("DryRun() should be true")
+	}
+	ctx := context.Background()
+
+	// Read-only requests are sent
+	if _, err := client.Voices().List(ctx); err != nil {
+		t.Fatalf("Voices().List() error = %v", err)
+	}
+
+	// Audio generation returns empty audio
+	resp, err := client.TextToSpeech().Generate(ctx, &TTSRequest{VoiceID: "voice", Text: "Hello there"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	audio, _ := io.ReadAll(resp.Audio)
+	if len(audio) != 0 {
+		t.Errorf("expected empty audio, got %d bytes", len(audio))
+	}
+
+	// Other mutating requests fail with ErrDryRun
+	_, err = client.Dubbing().CreateFromURL(ctx, &DubbingRequest{SourceURL: "https://example.com/a.mp4", TargetLanguage: "es"})
+	if !errors.Is(err, ErrDryRun) {
+		t.Errorf("CreateFromURL() error = %v, want ErrDryRun", err)
+	}
+
+	// So do generations that respond with JSON instead of audio
+	dialogue := &DialogueRequest{Inputs: []DialogueInput{{Text: "Hi", VoiceID: "voice"}}}
+	if _, err := client.TextToDialogue().GenerateWithTimestamps(ctx, dialogue); !errors.Is(err, ErrDryRun) {
+		t.Errorf("TextToDialogue().GenerateWithTimestamps() error = %v, want ErrDryRun", err)
+	}
+	if _, err := client.Music().GeneratePlan(ctx, &CompositionPlanRequest{Prompt: "pop song", DurationMs: 10000}); !errors.Is(err, ErrDryRun) {
+		t.Errorf("Music().GeneratePlan() error = %v, want ErrDryRun", err)
+	}
+
+	// WebSocket connections are refused
+	if _, err := client.WebSocketTTS().Connect(ctx, "voice", nil); !errors.Is(err, ErrDryRun) {
+		t.Errorf("WebSocketTTS().Connect() error = %v, want ErrDryRun", err)
+	}
+
+	if posts.Load() != 0 {
+		t.Errorf("server received %d mutating requests in dry-run mode", posts.Load())
+	}
+
+	if len(intercepted) != 4 {
+		t.Fatalf("expected 4 intercepted requests, got %d", len(intercepted))
+	}
+	tts := intercepted[0]
+	if tts.Method != http.MethodPost || tts.Endpoint != "/v1/text-to-speech/voice" {
+		t.Errorf("unexpected endpoint: %s %s", tts.Method, tts.Endpoint)
+	}
+	if tts.EstimatedCharacters != len("Hello there") {
+		t.Errorf("EstimatedCharacters = %d, want %d", tts.EstimatedCharacters, len("Hello there"))
+	}
+	if !tts.Synthetic || intercepted[1].Synthetic || intercepted[2].Synthetic || intercepted[3].Synthetic {
+		t.Error("only the audio request should have a synthetic response")
+	}
+}
+
+func TestCountTextCharacters(t *testing.T) {
+	tests := []struct {
+		body string
+		want int
+	}{
+		{`{"text":"héllo"}`, 5},
+		{`{"inputs":[{"text":"ab"},{"text":"cde"}]}`, 5},
+		{`not json`, 0},
+	}
+	for _, tt := range tests {
+		if got := countTextCharacters([]byte(tt.body)); got != tt.want {
+			t.Errorf("countTextCharacters(%s) = %d, want %d", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestReturnsAudio(t *testing.T) {
+	tests := map[string]bool{
+		"/v1/text-to-speech/voice":                        true,
+		"/v1/text-to-speech/voice/stream":                 true,
+		"/proxy/v1/text-to-speech/voice":                  true,
+		"/v1/text-to-speech/voice/with-timestamps":        false,
+		"/v1/text-to-speech/voice/stream/with-timestamps": false,
+		"/v1/text-to-dialogue":                            true,
+		"/v1/text-to-dialogue/with-timestamps":            false,
+		"/v1/text-to-dialogue/stream/with-timestamps":     false,
+		"/v1/speech-to-speech/voice/stream":               true,
+		"/v1/sound-generation":                            true,
+		"/v1/audio-isolation":                             true,
+		"/v1/music":                                       true,
+		"/v1/music/stream":                                true,
+		"/v1/music/plan":                                  false,
+		"/v1/music/detailed":                              false,
+		"/v1/music/stem-separation":                       false,
+		"/v1/dubbing":                                     false,
+		"/v1/text-to-speech":                              false,
+	}
+	for path, want := range tests {
+		if got := returnsAudio(path); got != want {
+			t.Errorf("returnsAudio(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestDryRunLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL("http://127.0.0.1:0"), WithDryRun(), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := client.SoundEffects().Generate(context.Background(), &SoundEffectRequest{Text: "rain"}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "elevenlabs: dry run") || !strings.Contains(buf.String(), "/v1/sound-generation") {
+		t.Errorf("log = %q, want the intercepted request", buf.String())
+	}
+}
@@ -2,7 +2,11 @@ package elevenlabs
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/agentplexus/go-elevenlabs/internal/api"
@@ -66,7 +70,8 @@ type DubbingRequest struct {
 	// NumSpeakers is the number of speakers (0 for auto-detection).
 	NumSpeakers int
 
-	// Watermark enables watermark (for free tier).
+	// Watermark adds a watermark to the dubbed video. Plans without
+	// watermark-free dubbing require it.
 	Watermark bool
 
 	// StartTime is the start time in seconds for dubbing.
@@ -75,11 +80,29 @@ type DubbingRequest struct {
 	// EndTime is the end time in seconds for dubbing.
 	EndTime int
 
-	// HighestResolution requests highest resolution output.
+	// HighestResolution renders the dubbed video at the source resolution
+	// instead of the default reduced resolution.
 	HighestResolution bool
 
-	// DropBackgroundAudio removes background audio.
+	// DropBackgroundAudio removes background music and effects, leaving
+	// only the dubbed speech. Use it for speech-only sources such as
+	// lectures, where background separation can add artifacts.
 	DropBackgroundAudio bool
+
+	// DisableVoiceCloning uses similar voices from the voice library
+	// instead of cloning the speakers.
+	DisableVoiceCloning bool
+
+	// UseProfanityFilter censors profanity in the transcripts.
+	UseProfanityFilter bool
+
+	// TargetAccent is an accent for selecting library voices and for the
+	// translation dialect (e.g., "american"). Experimental.
+	TargetAccent string
+
+	// DubbingStudio creates an editable project, which is required for
+	// Render and for audio-only downloads of video dubs.
+	DubbingStudio bool
 }
 
 // CreateFromURL creates a dubbing project from a URL source.
@@ -120,10 +143,22 @@ func (s *DubbingService) CreateFromURL(ctx context.Context, req *DubbingRequest)
 	if req.DropBackgroundAudio {
 		body.DropBackgroundAudio = api.NewOptBool(true)
 	}
+	if req.DisableVoiceCloning {
+		body.DisableVoiceCloning = api.NewOptBool(true)
+	}
+	if req.UseProfanityFilter {
+		body.UseProfanityFilter = api.NewOptNilBool(true)
+	}
+	if req.TargetAccent != "" {
+		body.TargetAccent = api.NewOptNilString(req.TargetAccent)
+	}
+	if req.DubbingStudio {
+		body.DubbingStudio = api.NewOptBool(true)
+	}
 
 	resp, err := s.client.apiClient.CreateDubbing(ctx, api.NewOptBodyDubAVideoOrAnAudioFileV1DubbingPostMultipart(body), api.CreateDubbingParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	// Handle response type
@@ -133,8 +168,10 @@ func (s *DubbingService) CreateFromURL(ctx context.Context, req *DubbingRequest)
 			DubbingID:               r.DubbingID,
 			ExpectedDurationSeconds: r.ExpectedDurationSec,
 		}, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -148,7 +185,7 @@ func (s *DubbingService) Get(ctx context.Context, dubbingID string) (*DubbingPro
 		DubbingID: dubbingID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	// Handle response type
@@ -167,8 +204,10 @@ func (s *DubbingService) Get(ctx context.Context, dubbingID string) (*DubbingPro
 		}
 
 		return project, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -178,19 +217,61 @@ func (s *DubbingService) Delete(ctx context.Context, dubbingID string) error {
 		return &ValidationError{Field: "dubbing_id", Message: "cannot be empty"}
 	}
 
-	_, err := s.client.apiClient.DeleteDubbing(ctx, api.DeleteDubbingParams{
+	return checkResponse(s.client.apiClient.DeleteDubbing(ctx, api.DeleteDubbingParams{
 		DubbingID: dubbingID,
-	})
-	return err
+	}))
 }
 
 // GetDubbedFile returns the dubbed audio/video file for a specific language.
 func (s *DubbingService) GetDubbedFile(ctx context.Context, dubbingID, languageCode string) (io.Reader, error) {
+	data, _, err := s.dubbedFile(ctx, dubbingID, languageCode)
+	return data, err
+}
+
+// DownloadVideo returns the dubbed MP4 video for a language. It fails if
+// the source was audio. Close the reader if it implements io.Closer.
+func (s *DubbingService) DownloadVideo(ctx context.Context, dubbingID, languageCode string) (io.Reader, error) {
+	data, video, err := s.dubbedFile(ctx, dubbingID, languageCode)
+	if err != nil {
+		return nil, err
+	}
+	if !video {
+		closeReader(data)
+		return nil, fmt.Errorf("dubbing %s has no video: the source is audio", dubbingID)
+	}
+	return data, nil
+}
+
+// DownloadAudioOnly returns the dubbed audio track for a language as MP3,
+// without video. For audio sources this is the dubbed file. For video
+// sources, an MP3 is rendered first, which requires a project created
+// with DubbingStudio; rendering is polled like WaitForCompletion. Close
+// the reader if it implements io.Closer.
+func (s *DubbingService) DownloadAudioOnly(ctx context.Context, dubbingID, languageCode string) (io.Reader, error) {
+	data, video, err := s.dubbedFile(ctx, dubbingID, languageCode)
+	if err != nil {
+		return nil, err
+	}
+	if !video {
+		return data, nil
+	}
+	closeReader(data)
+
+	renderID, err := s.Render(ctx, dubbingID, languageCode, DubbingRenderMP3)
+	if err != nil {
+		return nil, err
+	}
+	return s.DownloadRender(ctx, dubbingID, renderID, 0)
+}
+
+// dubbedFile returns the dubbed file for a language and whether it is a
+// video.
+func (s *DubbingService) dubbedFile(ctx context.Context, dubbingID, languageCode string) (io.Reader, bool, error) {
 	if dubbingID == "" {
-		return nil, &ValidationError{Field: "dubbing_id", Message: "cannot be empty"}
+		return nil, false, &ValidationError{Field: "dubbing_id", Message: "cannot be empty"}
 	}
 	if languageCode == "" {
-		return nil, &ValidationError{Field: "language_code", Message: "cannot be empty"}
+		return nil, false, &ValidationError{Field: "language_code", Message: "cannot be empty"}
 	}
 
 	resp, err := s.client.apiClient.GetDubbedFile(ctx, api.GetDubbedFileParams{
@@ -198,20 +279,138 @@ func (s *DubbingService) GetDubbedFile(ctx context.Context, dubbingID, languageC
 		LanguageCode: languageCode,
 	})
 	if err != nil {
-		return nil, err
+		return nil, false, apiError(err)
 	}
 
 	// Handle response type - can be audio or video
 	switch r := resp.(type) {
 	case *api.GetDubbedFileOKAudioMpeg:
-		return r.Data, nil
+		return r.Data, false, nil
 	case *api.GetDubbedFileOKVideoMP4:
-		return r.Data, nil
+		return r.Data, true, nil
+	case *api.HTTPValidationError:
+		return nil, false, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, false, unexpectedResponse(resp)
+	}
+}
+
+// closeReader closes r if it is an io.Closer.
+func closeReader(r io.Reader) {
+	if c, ok := r.(io.Closer); ok {
+		c.Close()
+	}
+}
+
+// DubbingRenderType is the output of a dubbing render.
+type DubbingRenderType string
+
+// Dubbing render types.
+const (
+	DubbingRenderMP4       DubbingRenderType = "mp4"
+	DubbingRenderAAC       DubbingRenderType = "aac"
+	DubbingRenderMP3       DubbingRenderType = "mp3"
+	DubbingRenderWAV       DubbingRenderType = "wav"
+	DubbingRenderAAF       DubbingRenderType = "aaf"
+	DubbingRenderTracksZip DubbingRenderType = "tracks_zip"
+	DubbingRenderClipsZip  DubbingRenderType = "clips_zip"
+)
+
+// Render starts rendering a language of a dubbing project, such as an
+// audio-only track or per-speaker stems (DubbingRenderTracksZip), and
+// returns the render ID. The project must have been created with
+// DubbingStudio. Use DownloadRender to wait for and fetch the output.
+func (s *DubbingService) Render(ctx context.Context, dubbingID, languageCode string, renderType DubbingRenderType) (string, error) {
+	if dubbingID == "" {
+		return "", &ValidationError{Field: "dubbing_id", Message: "cannot be empty"}
+	}
+	if languageCode == "" {
+		return "", &ValidationError{Field: "language_code", Message: "cannot be empty"}
+	}
+	if renderType == "" {
+		return "", &ValidationError{Field: "render_type", Message: "cannot be empty"}
+	}
+
+	var result struct {
+		RenderID string `json:"render_id"`
+	}
+	path := "/v1/dubbing/resource/" + url.PathEscape(dubbingID) + "/render/" + url.PathEscape(languageCode)
+	if err := s.client.doJSON(ctx, http.MethodPost, path, map[string]string{"render_type": string(renderType)}, &result); err != nil {
+		return "", err
+	}
+	return result.RenderID, nil
+}
+
+// DownloadRender waits for a render started by Render to complete and
+// returns its output. Polling starts at pollInterval, or
+// DefaultDubbingPollInterval if zero, and backs off up to
+// MaxDubbingPollInterval. Close the reader if it implements io.Closer.
+func (s *DubbingService) DownloadRender(ctx context.Context, dubbingID, renderID string, pollInterval time.Duration) (io.Reader, error) {
+	if dubbingID == "" {
+		return nil, &ValidationError{Field: "dubbing_id", Message: "cannot be empty"}
+	}
+	if renderID == "" {
+		return nil, &ValidationError{Field: "render_id", Message: "cannot be empty"}
+	}
+	if pollInterval <= 0 {
+		pollInterval = DefaultDubbingPollInterval
+	}
+	maxInterval := max(pollInterval, MaxDubbingPollInterval)
+
+	for {
+		resp, err := s.client.apiClient.GetDubbingResource(ctx, api.GetDubbingResourceParams{
+			DubbingID: dubbingID,
+		})
+		if err != nil {
+			return nil, apiError(err)
+		}
+		var render api.Render
+		switch r := resp.(type) {
+		case *api.DubbingResource:
+			var ok bool
+			if render, ok = r.Renders[renderID]; !ok {
+				return nil, fmt.Errorf("render %s not found in dubbing %s", renderID, dubbingID)
+			}
+		case *api.HTTPValidationError:
+			return nil, validationAPIError(r)
+		default:
+			return nil, unexpectedResponse(resp)
+		}
+
+		switch render.Status {
+		case api.RenderStatusComplete:
+			return s.fetchMedia(ctx, render.MediaRef)
+		case api.RenderStatusFailed:
+			return nil, fmt.Errorf("%w: render %s failed", ErrDubbingFailed, renderID)
+		}
+		if err := waitBackoff(ctx, &pollInterval, maxInterval); err != nil {
+			return nil, err
+		}
 	}
 }
 
+// fetchMedia downloads rendered dubbing media from its signed URL.
+func (s *DubbingService) fetchMedia(ctx context.Context, ref api.DubbingMediaReference) (io.Reader, error) {
+	if ref.URL == "" {
+		return nil, fmt.Errorf("render has no download URL")
+	}
+	// The signed URL is not an ElevenLabs endpoint, so the authenticated
+	// client is not used
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, ref.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.fetchClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download render: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download render: HTTP %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
 // IsComplete checks if a dubbing project is complete.
 func (p *DubbingProject) IsComplete() bool {
 	return p.Status == "dubbed"
@@ -226,3 +425,124 @@ func (p *DubbingProject) IsFailed() bool {
 func (p *DubbingProject) IsProcessing() bool {
 	return p.Status == "dubbing" || p.Status == "cloning"
 }
+
+// Polling intervals for dubbing watchers.
+const (
+	// DefaultDubbingPollInterval is the first interval between status
+	// checks when none is given.
+	DefaultDubbingPollInterval = 5 * time.Second
+
+	// MaxDubbingPollInterval caps the interval between status checks,
+	// which grows while a long job is in progress.
+	MaxDubbingPollInterval = time.Minute
+)
+
+// DubbingUpdate is a status change reported by Watch.
+type DubbingUpdate struct {
+	// Project is the project with its new status. Nil if Err is set.
+	Project *DubbingProject
+
+	// Err is the error that ended watching, if any.
+	Err error
+}
+
+// WaitForCompletion polls a dubbing project until it is dubbed, and
+// returns it. Polling starts at pollInterval, or DefaultDubbingPollInterval
+// if zero, and backs off up to MaxDubbingPollInterval. If the project
+// fails, it is returned with an error wrapping ErrDubbingFailed.
+func (s *DubbingService) WaitForCompletion(ctx context.Context, dubbingID string, pollInterval time.Duration) (*DubbingProject, error) {
+	return s.poll(ctx, dubbingID, pollInterval, nil)
+}
+
+// Watch polls a dubbing project like WaitForCompletion and sends an update
+// each time its status changes, starting with the current status. The
+// channel is closed after the project is dubbed or fails, or after an
+// update with Err set, such as when ctx is canceled.
+//
+//	for update := range client.Dubbing().Watch(ctx, dubbingID, 0) {
+//	    if update.Err != nil {
+//	        return update.Err
+//	    }
+//	    log.Printf("dubbing %s", update.Project.Status)
+//	}
+func (s *DubbingService) Watch(ctx context.Context, dubbingID string, pollInterval time.Duration) <-chan DubbingUpdate {
+	updates := make(chan DubbingUpdate, 1)
+	go func() {
+		defer close(updates)
+		send := func(u DubbingUpdate) bool {
+			select {
+			case updates <- u:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		_, err := s.poll(ctx, dubbingID, pollInterval, func(p *DubbingProject) bool {
+			return send(DubbingUpdate{Project: p})
+		})
+		if err != nil && !errors.Is(err, ErrDubbingFailed) {
+			// The send fails if ctx is done, so deliver the error directly
+			select {
+			case updates <- DubbingUpdate{Err: err}:
+			default:
+				send(DubbingUpdate{Err: err})
+			}
+		}
+	}()
+	return updates
+}
+
+// poll checks a project's status until it is finished, calling onChange
+// when the status changes. Polling stops early if onChange returns false.
+func (s *DubbingService) poll(ctx context.Context, dubbingID string, interval time.Duration, onChange func(*DubbingProject) bool) (*DubbingProject, error) {
+	if dubbingID == "" {
+		return nil, &ValidationError{Field: "dubbing_id", Message: "cannot be empty"}
+	}
+	if interval <= 0 {
+		interval = DefaultDubbingPollInterval
+	}
+	maxInterval := max(interval, MaxDubbingPollInterval)
+
+	lastStatus := ""
+	for {
+		project, err := s.Get(ctx, dubbingID)
+		if err != nil {
+			return nil, err
+		}
+		if project.Status != lastStatus {
+			lastStatus = project.Status
+			if onChange != nil && !onChange(project) {
+				return project, ctx.Err()
+			}
+		}
+
+		switch {
+		case project.IsComplete():
+			return project, nil
+		case project.IsFailed():
+			if project.Error != "" {
+				return project, fmt.Errorf("%w: %s", ErrDubbingFailed, project.Error)
+			}
+			return project, ErrDubbingFailed
+		}
+
+		if err := waitBackoff(ctx, &interval, maxInterval); err != nil {
+			return project, err
+		}
+	}
+}
+
+// waitBackoff waits for interval, then grows it by half up to
+// maxInterval, for polling long-running jobs.
+func waitBackoff(ctx context.Context, interval *time.Duration, maxInterval time.Duration) error {
+	timer := time.NewTimer(*interval)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	*interval = min(*interval*3/2, maxInterval)
+	return nil
+}
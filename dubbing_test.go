@@ -0,0 +1,255 @@
+package elevenlabs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newDubbingStatusServer serves a dubbing project whose status advances
+// through statuses on each request, staying at the last one.
+func newDubbingStatusServer(t *testing.T, statuses ...string) *Client {
+	t.Helper()
+	var mu sync.Mutex
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/dubbing/dub-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		mu.Lock()
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		mu.Unlock()
+
+		errMsg := "null"
+		if status == "failed" {
+			errMsg = `"no speech detected"`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"dubbing_id": "dub-1", "name": "Demo", "status": %q, "target_languages": ["es"], "created_at": "2024-01-01T00:00:00Z", "error": %s}`, status, errMsg)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestDubbingWaitForCompletion(t *testing.T) {
+	client := newDubbingStatusServer(t, "cloning", "dubbing", "dubbing", "dubbed")
+
+	project, err := client.Dubbing().WaitForCompletion(context.Background(), "dub-1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForCompletion() error = %v", err)
+	}
+	if !project.IsComplete() {
+		t.Errorf("Status = %q, want dubbed", project.Status)
+	}
+}
+
+func TestDubbingWaitForCompletionFailed(t *testing.T) {
+	client := newDubbingStatusServer(t, "dubbing", "failed")
+
+	project, err := client.Dubbing().WaitForCompletion(context.Background(), "dub-1", time.Millisecond)
+	if !errors.Is(err, ErrDubbingFailed) {
+		t.Fatalf("WaitForCompletion() error = %v, want ErrDubbingFailed", err)
+	}
+	if project == nil || project.Error != "no speech detected" {
+		t.Errorf("project = %+v", project)
+	}
+}
+
+func TestDubbingWaitForCompletionCanceled(t *testing.T) {
+	client := newDubbingStatusServer(t, "dubbing")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Dubbing().WaitForCompletion(ctx, "dub-1", time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForCompletion() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestDubbingWatch(t *testing.T) {
+	client := newDubbingStatusServer(t, "cloning", "dubbing", "dubbing", "dubbed")
+
+	var statuses []string
+	for update := range client.Dubbing().Watch(context.Background(), "dub-1", time.Millisecond) {
+		if update.Err != nil {
+			t.Fatalf("update error = %v", update.Err)
+		}
+		statuses = append(statuses, update.Project.Status)
+	}
+	if want := []string{"cloning", "dubbing", "dubbed"}; !slices.Equal(statuses, want) {
+		t.Errorf("statuses = %v, want %v", statuses, want)
+	}
+
+	// Errors end the stream
+	var last DubbingUpdate
+	for update := range client.Dubbing().Watch(context.Background(), "", 0) {
+		last = update
+	}
+	var validationErr *ValidationError
+	if !errors.As(last.Err, &validationErr) {
+		t.Errorf("last update error = %v, want validation error", last.Err)
+	}
+}
+
+// dubbingMediaRef returns a dubbing media reference as JSON.
+func dubbingMediaRef(url string, isAudio bool) string {
+	return fmt.Sprintf(`{"src": "src", "content_type": "audio/mpeg", "bucket_name": "b", "random_path_slug": "s", "duration_secs": 1.5, "is_audio": %t, "url": %q}`, isAudio, url)
+}
+
+// newDubbingFileServer serves a dubbed file of the given content type and a
+// dubbing resource whose mp3 render completes after pending polls.
+func newDubbingFileServer(t *testing.T, contentType string, pending int) (*Client, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []string
+	polls := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/v1/dubbing/dub-1/audio/es":
+			w.Header().Set("Content-Type", contentType)
+			fmt.Fprint(w, "dubbed file")
+		case "/v1/dubbing/resource/dub-1/render/es":
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != `{"render_type":"mp3"}` {
+				t.Errorf("render body = %s", body)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"version": 2, "render_id": "render-1"}`)
+		case "/v1/dubbing/resource/dub-1":
+			status := "processing"
+			if polls >= pending {
+				status = "complete"
+			}
+			polls++
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"id": "dub-1", "version": 2, "source_language": "en", "target_languages": ["es"],
+				"input": %[1]s, "background": %[1]s, "foreground": %[1]s,
+				"speaker_tracks": {}, "speaker_segments": {},
+				"renders": {"render-1": {"id": "render-1", "version": 2, "language": "es", "type": "mp3", "status": %[2]q, "media_ref": %[3]s}}}`,
+				dubbingMediaRef("", true), status, dubbingMediaRef(server.URL+"/signed/render.mp3", true))
+		case "/signed/render.mp3":
+			if r.Header.Get("xi-api-key") != "" {
+				t.Error("API key sent to signed URL")
+			}
+			fmt.Fprint(w, "rendered audio")
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client, &requests
+}
+
+func TestDubbingDownloadVideo(t *testing.T) {
+	client, _ := newDubbingFileServer(t, "video/mp4", 0)
+
+	r, err := client.Dubbing().DownloadVideo(context.Background(), "dub-1", "es")
+	if err != nil {
+		t.Fatalf("DownloadVideo() error = %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	if string(data) != "dubbed file" {
+		t.Errorf("data = %q", data)
+	}
+
+	client, _ = newDubbingFileServer(t, "audio/mpeg", 0)
+	if _, err := client.Dubbing().DownloadVideo(context.Background(), "dub-1", "es"); err == nil {
+		t.Error("DownloadVideo() of an audio dub: expected error")
+	}
+}
+
+func TestDubbingDownloadAudioOnly(t *testing.T) {
+	t.Run("audio source", func(t *testing.T) {
+		client, requests := newDubbingFileServer(t, "audio/mpeg", 0)
+
+		r, err := client.Dubbing().DownloadAudioOnly(context.Background(), "dub-1", "es")
+		if err != nil {
+			t.Fatalf("DownloadAudioOnly() error = %v", err)
+		}
+		data, _ := io.ReadAll(r)
+		if string(data) != "dubbed file" {
+			t.Errorf("data = %q", data)
+		}
+		if len(*requests) != 1 {
+			t.Errorf("requests = %v, want only the dubbed file", *requests)
+		}
+	})
+
+	t.Run("video source", func(t *testing.T) {
+		client, requests := newDubbingFileServer(t, "video/mp4", 0)
+
+		r, err := client.Dubbing().DownloadAudioOnly(context.Background(), "dub-1", "es")
+		if err != nil {
+			t.Fatalf("DownloadAudioOnly() error = %v", err)
+		}
+		data, _ := io.ReadAll(r)
+		if string(data) != "rendered audio" {
+			t.Errorf("data = %q", data)
+		}
+		want := []string{
+			"GET /v1/dubbing/dub-1/audio/es",
+			"POST /v1/dubbing/resource/dub-1/render/es",
+			"GET /v1/dubbing/resource/dub-1",
+			"GET /signed/render.mp3",
+		}
+		if !slices.Equal(*requests, want) {
+			t.Errorf("requests = %v, want %v", *requests, want)
+		}
+	})
+}
+
+func TestDubbingDownloadRender(t *testing.T) {
+	client, requests := newDubbingFileServer(t, "video/mp4", 2)
+
+	r, err := client.Dubbing().DownloadRender(context.Background(), "dub-1", "render-1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("DownloadRender() error = %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	if string(data) != "rendered audio" {
+		t.Errorf("data = %q", data)
+	}
+	if len(*requests) != 4 {
+		t.Errorf("requests = %v, want 3 polls and a download", *requests)
+	}
+
+	if _, err := client.Dubbing().DownloadRender(context.Background(), "dub-1", "render-2", time.Millisecond); err == nil {
+		t.Error("DownloadRender() of an unknown render: expected error")
+	}
+}
+
+func TestDubbingRenderValidation(t *testing.T) {
+	client, err := NewClient(WithAPIKey("test-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var verr *ValidationError
+	if _, err := client.Dubbing().Render(context.Background(), "dub-1", "es", ""); !errors.As(err, &verr) || verr.Field != "render_type" {
+		t.Errorf("Render() error = %v, want render_type ValidationError", err)
+	}
+	if _, err := client.Dubbing().DownloadRender(context.Background(), "dub-1", "", 0); !errors.As(err, &verr) || verr.Field != "render_id" {
+		t.Errorf("DownloadRender() error = %v, want render_id ValidationError", err)
+	}
+}
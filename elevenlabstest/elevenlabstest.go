@@ -0,0 +1,43 @@
+// Package elevenlabstest provides fake ElevenLabs servers for testing code
+// that uses the elevenlabs package, without calling the API or spending
+// credits.
+//
+// TTSServer speaks the WebSocket text-to-speech protocol and answers with
+// synthetic audio and alignment, so streaming code can be unit tested and
+// load tested locally:
+//
+//	srv := elevenlabstest.NewTTSServer(elevenlabstest.WithLatency(50 * time.Millisecond))
+//	defer srv.Close()
+//
+//	client, err := srv.Client()
+//	conn, err := client.WebSocketTTS().Connect(ctx, "voice-id", nil)
+//
+// STTServer speaks the WebSocket speech-to-text protocol and answers with
+// scripted transcripts, so voice agent pipelines can be tested
+// deterministically.
+package elevenlabstest
+
+import (
+	"net/http"
+
+	elevenlabs "github.com/agentplexus/go-elevenlabs"
+)
+
+// newClient returns a client for a fake server at url. opts are applied
+// after the API key and base URL.
+func newClient(url string, opts []elevenlabs.Option) (*elevenlabs.Client, error) {
+	opts = append([]elevenlabs.Option{
+		elevenlabs.WithAPIKey("test-key"),
+		elevenlabs.WithBaseURL(url),
+	}, opts...)
+	return elevenlabs.NewClient(opts...)
+}
+
+// authorized reports whether r has an API key, responding 401 if not.
+func authorized(w http.ResponseWriter, r *http.Request) bool {
+	if r.Header.Get("xi-api-key") == "" {
+		http.Error(w, `{"detail": "missing api key"}`, http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
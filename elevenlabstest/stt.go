@@ -0,0 +1,414 @@
+package elevenlabstest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	elevenlabs "github.com/agentplexus/go-elevenlabs"
+)
+
+// Utterance is a scripted transcript of the audio between Start and End,
+// measured from the start of the stream.
+type Utterance struct {
+	// Text is the transcript.
+	Text string
+
+	// Start and End place the utterance in the audio.
+	Start time.Duration
+	End   time.Duration
+
+	// SpeakerID labels the words when the client enables diarization.
+	SpeakerID string
+
+	// Confidence is the reported confidence. Zero reports 1.
+	Confidence float64
+}
+
+// STTServer is a fake WebSocket speech-to-text server. It counts the audio
+// each connection sends and transcribes it from a script: once the audio
+// reaches the End of an utterance, the server sends its final transcript.
+// With partials enabled, it first sends partial transcripts of the words
+// spoken so far, spreading the words evenly between Start and End.
+//
+// On end_of_stream, utterances whose audio has started are finalized and
+// the connection is closed normally. The same script is used for every
+// connection.
+type STTServer struct {
+	// URL is the base URL of the server, for elevenlabs.WithBaseURL.
+	URL string
+
+	server       *httptest.Server
+	upgrader     websocket.Upgrader
+	script       []Utterance
+	latency      time.Duration
+	errs         []injectedError
+	disconnectAt time.Duration
+
+	mu    sync.Mutex
+	stats STTServerStats
+}
+
+// injectedError is an error message sent once the audio reaches at.
+type injectedError struct {
+	at      time.Duration
+	message string
+}
+
+// STTServerStats counts the traffic of an STTServer.
+type STTServerStats struct {
+	// Connections is the number of connections accepted.
+	Connections int
+
+	// AudioBytes is the number of audio bytes received.
+	AudioBytes int
+
+	// Audio is the duration of the audio received.
+	Audio time.Duration
+
+	// Partials and Finals are the numbers of transcripts sent.
+	Partials int
+	Finals   int
+
+	// Errors is the number of error messages sent.
+	Errors int
+}
+
+// STTServerOption configures an STTServer.
+type STTServerOption func(*STTServer)
+
+// WithScript sets the utterances to transcribe, in any order.
+func WithScript(utterances ...Utterance) STTServerOption {
+	return func(s *STTServer) {
+		s.script = append(s.script, utterances...)
+	}
+}
+
+// WithTranscriptLatency delays each transcript, simulating recognition
+// time.
+func WithTranscriptLatency(d time.Duration) STTServerOption {
+	return func(s *STTServer) {
+		s.latency = d
+	}
+}
+
+// WithErrorAt sends an error message once the audio of a connection
+// reaches at. The connection stays open, as with the API's recoverable
+// errors.
+func WithErrorAt(at time.Duration, message string) STTServerOption {
+	return func(s *STTServer) {
+		s.errs = append(s.errs, injectedError{at: at, message: message})
+	}
+}
+
+// WithDisconnectAt drops each connection without a close frame once its
+// audio reaches at, simulating a network failure.
+func WithDisconnectAt(at time.Duration) STTServerOption {
+	return func(s *STTServer) {
+		s.disconnectAt = at
+	}
+}
+
+// NewSTTServer starts a fake WebSocket STT server. Close it when done.
+func NewSTTServer(opts ...STTServerOption) *STTServer {
+	s := &STTServer{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	sort.SliceStable(s.script, func(i, j int) bool { return s.script[i].Start < s.script[j].Start })
+	sort.SliceStable(s.errs, func(i, j int) bool { return s.errs[i].at < s.errs[j].at })
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Client returns a client connected to the server. opts are applied after
+// the API key and base URL.
+func (s *STTServer) Client(opts ...elevenlabs.Option) (*elevenlabs.Client, error) {
+	return newClient(s.URL, opts)
+}
+
+// Close closes open connections and shuts the server down.
+func (s *STTServer) Close() {
+	s.server.CloseClientConnections()
+	s.server.Close()
+}
+
+// Stats returns the traffic counts so far.
+func (s *STTServer) Stats() STTServerStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// sttMessage is a client message: config, audio, or end_of_stream.
+type sttMessage struct {
+	Type                 string `json:"type"`
+	Audio                string `json:"audio"`
+	SampleRate           int    `json:"sample_rate"`
+	Encoding             string `json:"encoding"`
+	LanguageCode         string `json:"language_code"`
+	EnablePartials       bool   `json:"enable_partials"`
+	EnableWordTimestamps bool   `json:"enable_word_timestamps"`
+	Diarize              bool   `json:"diarize"`
+}
+
+type sttResponse struct {
+	Type         string               `json:"type"`
+	Text         string               `json:"text,omitempty"`
+	IsFinal      bool                 `json:"is_final,omitempty"`
+	Confidence   float64              `json:"confidence,omitempty"`
+	Words        []elevenlabs.STTWord `json:"words,omitempty"`
+	LanguageCode string               `json:"language_code,omitempty"`
+	StartTime    float64              `json:"start_time,omitempty"`
+	EndTime      float64              `json:"end_time,omitempty"`
+	SpeakerID    string               `json:"speaker_id,omitempty"`
+	Message      string               `json:"message,omitempty"`
+}
+
+func (s *STTServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/speech-to-text/realtime" {
+		http.NotFound(w, r)
+		return
+	}
+	if !authorized(w, r) {
+		return
+	}
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	s.mu.Lock()
+	s.stats.Connections++
+	s.mu.Unlock()
+
+	sess := &sttSession{
+		server:   s,
+		conn:     conn,
+		config:   sttMessage{SampleRate: 16000, Encoding: "pcm_s16le", LanguageCode: "en"},
+		errsLeft: s.errs,
+	}
+	sess.run()
+}
+
+// sttSession is the state of one connection.
+type sttSession struct {
+	server   *STTServer
+	conn     *websocket.Conn
+	config   sttMessage
+	received int
+	next     int // index of the next utterance to finalize
+	partial  int // words in the last partial of the next utterance
+	errsLeft []injectedError
+}
+
+func (sess *sttSession) run() {
+	for {
+		_, data, err := sess.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg sttMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			if sess.sendError("invalid message: "+err.Error()) != nil {
+				return
+			}
+			continue
+		}
+
+		switch msg.Type {
+		case "config":
+			sess.configure(msg)
+		case "audio":
+			audio, err := base64.StdEncoding.DecodeString(msg.Audio)
+			if err != nil {
+				if sess.sendError("invalid audio: "+err.Error()) != nil {
+					return
+				}
+				continue
+			}
+			if !sess.receive(audio) {
+				return
+			}
+		case "end_of_stream":
+			if sess.transcribe(true) != nil {
+				return
+			}
+			closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+			_ = sess.conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
+			return
+		default:
+			if sess.sendError("unknown message type: "+msg.Type) != nil {
+				return
+			}
+		}
+	}
+}
+
+// configure applies a config message, keeping defaults for unset fields.
+func (sess *sttSession) configure(msg sttMessage) {
+	if msg.SampleRate > 0 {
+		sess.config.SampleRate = msg.SampleRate
+	}
+	if msg.Encoding != "" {
+		sess.config.Encoding = msg.Encoding
+	}
+	if msg.LanguageCode != "" {
+		sess.config.LanguageCode = msg.LanguageCode
+	}
+	sess.config.EnablePartials = msg.EnablePartials
+	sess.config.EnableWordTimestamps = msg.EnableWordTimestamps
+	sess.config.Diarize = msg.Diarize
+}
+
+// receive counts audio and sends the transcripts and errors it triggers.
+// It reports false if the connection should end.
+func (sess *sttSession) receive(audio []byte) bool {
+	sess.received += len(audio)
+	s := sess.server
+	s.mu.Lock()
+	s.stats.AudioBytes += len(audio)
+	s.stats.Audio += sess.duration(len(audio))
+	s.mu.Unlock()
+
+	pos := sess.position()
+	for len(sess.errsLeft) > 0 && sess.errsLeft[0].at <= pos {
+		if sess.sendError(sess.errsLeft[0].message) != nil {
+			return false
+		}
+		sess.errsLeft = sess.errsLeft[1:]
+	}
+	if s.disconnectAt > 0 && pos >= s.disconnectAt {
+		return false
+	}
+	return sess.transcribe(false) == nil
+}
+
+// duration returns the length of n bytes of audio.
+func (sess *sttSession) duration(n int) time.Duration {
+	bytesPerSample := 2
+	if sess.config.Encoding == "pcm_mulaw" {
+		bytesPerSample = 1
+	}
+	return time.Duration(n) * time.Second / time.Duration(sess.config.SampleRate*bytesPerSample)
+}
+
+// position returns the length of the audio received.
+func (sess *sttSession) position() time.Duration {
+	return sess.duration(sess.received)
+}
+
+// transcribe sends the transcripts due at the current position. At the end
+// of the stream, utterances that have started are finalized.
+func (sess *sttSession) transcribe(eos bool) error {
+	pos := sess.position()
+	script := sess.server.script
+	for sess.next < len(script) {
+		u := script[sess.next]
+		words := strings.Fields(u.Text)
+		if pos >= u.End || (eos && pos > u.Start) {
+			sess.next++
+			sess.partial = 0
+			if err := sess.sendTranscript(u, words, true); err != nil {
+				return err
+			}
+			continue
+		}
+		if !eos && sess.config.EnablePartials && pos > u.Start {
+			if n := spokenWords(u, len(words), pos); n > sess.partial {
+				sess.partial = n
+				if err := sess.sendTranscript(u, words[:n], false); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// spokenWords returns the number of the n words of u that have started at
+// pos, with the words spread evenly over the utterance.
+func spokenWords(u Utterance, n int, pos time.Duration) int {
+	span := u.End - u.Start
+	spoken := 0
+	for i := range n {
+		if u.Start+span*time.Duration(i)/time.Duration(n) < pos {
+			spoken++
+		}
+	}
+	return spoken
+}
+
+// sendTranscript sends a transcript of the first len(words) words of u.
+func (sess *sttSession) sendTranscript(u Utterance, words []string, final bool) error {
+	s := sess.server
+	if s.latency > 0 {
+		time.Sleep(s.latency)
+	}
+
+	total := len(strings.Fields(u.Text))
+	span := u.End - u.Start
+	resp := sttResponse{
+		Type:         "transcript",
+		Text:         strings.Join(words, " "),
+		IsFinal:      final,
+		Confidence:   u.Confidence,
+		LanguageCode: sess.config.LanguageCode,
+		StartTime:    u.Start.Seconds(),
+		EndTime:      u.End.Seconds(),
+	}
+	if final {
+		resp.Text = u.Text
+	}
+	if resp.Confidence == 0 {
+		resp.Confidence = 1
+	}
+	if sess.config.Diarize {
+		resp.SpeakerID = u.SpeakerID
+	}
+	if sess.config.EnableWordTimestamps {
+		for i, word := range words {
+			w := elevenlabs.STTWord{
+				Word:       word,
+				Start:      (u.Start + span*time.Duration(i)/time.Duration(total)).Seconds(),
+				End:        (u.Start + span*time.Duration(i+1)/time.Duration(total)).Seconds(),
+				Confidence: resp.Confidence,
+			}
+			if sess.config.Diarize {
+				w.SpeakerID = u.SpeakerID
+			}
+			resp.Words = append(resp.Words, w)
+		}
+	}
+	if !final {
+		resp.EndTime = (u.Start + span*time.Duration(len(words))/time.Duration(total)).Seconds()
+	}
+
+	s.mu.Lock()
+	if final {
+		s.stats.Finals++
+	} else {
+		s.stats.Partials++
+	}
+	s.mu.Unlock()
+	return sess.conn.WriteJSON(resp)
+}
+
+// sendError sends an error message.
+func (sess *sttSession) sendError(message string) error {
+	s := sess.server
+	s.mu.Lock()
+	s.stats.Errors++
+	s.mu.Unlock()
+	return sess.conn.WriteJSON(sttResponse{Type: "error", Message: message})
+}
@@ -0,0 +1,221 @@
+package elevenlabstest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	elevenlabs "github.com/agentplexus/go-elevenlabs"
+)
+
+// pcm returns d of 16kHz 16-bit PCM audio.
+func pcm(d time.Duration) []byte {
+	return make([]byte, int(d.Seconds()*32000))
+}
+
+// transcribe streams audio in 100ms chunks, ends the stream, and returns
+// the transcripts and the first error received.
+func transcribe(t *testing.T, srv *STTServer, opts *elevenlabs.WebSocketSTTOptions, audio []byte) ([]*elevenlabs.STTTranscript, error) {
+	t.Helper()
+	client, err := srv.Client()
+	if err != nil {
+		t.Fatalf("Client() error = %v", err)
+	}
+	conn, err := client.WebSocketSTT().Connect(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer conn.Close()
+
+	for len(audio) > 0 {
+		n := min(len(audio), 3200)
+		if err := conn.SendAudio(audio[:n]); err != nil {
+			break
+		}
+		audio = audio[n:]
+	}
+	_ = conn.EndStream()
+
+	var transcripts []*elevenlabs.STTTranscript
+	var firstErr error
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case tr, ok := <-conn.Transcripts():
+			if !ok {
+				if firstErr == nil {
+					select {
+					case firstErr = <-conn.Errors():
+					default:
+					}
+				}
+				return transcripts, firstErr
+			}
+			transcripts = append(transcripts, tr)
+		case err := <-conn.Errors():
+			if firstErr == nil {
+				firstErr = err
+			}
+		case <-timeout:
+			t.Fatal("stream not closed")
+		}
+	}
+}
+
+func finals(transcripts []*elevenlabs.STTTranscript) []string {
+	var texts []string
+	for _, tr := range transcripts {
+		if tr.IsFinal {
+			texts = append(texts, tr.Text)
+		}
+	}
+	return texts
+}
+
+func TestSTTServer(t *testing.T) {
+	srv := NewSTTServer(WithScript(
+		Utterance{Text: "book a table", Start: 200 * time.Millisecond, End: 800 * time.Millisecond},
+		Utterance{Text: "for two", Start: time.Second, End: 1400 * time.Millisecond, Confidence: 0.8},
+	))
+	defer srv.Close()
+
+	transcripts, err := transcribe(t, srv, elevenlabs.DefaultWebSocketSTTOptions(), pcm(2*time.Second))
+	if err != nil {
+		t.Fatalf("transcribe() error = %v", err)
+	}
+	if got := finals(transcripts); strings.Join(got, "|") != "book a table|for two" {
+		t.Errorf("finals = %q", got)
+	}
+
+	// Partials grow word by word before each final
+	var partials []string
+	for _, tr := range transcripts {
+		if !tr.IsFinal && tr.StartTime == 0.2 {
+			partials = append(partials, tr.Text)
+		}
+	}
+	if strings.Join(partials, "|") != "book|book a|book a table" {
+		t.Errorf("partials = %q", partials)
+	}
+
+	last := transcripts[len(transcripts)-1]
+	if last.Confidence != 0.8 || last.LanguageCode != "en" || len(last.Words) != 2 {
+		t.Fatalf("last transcript = %+v", last)
+	}
+	if w := last.Words[1]; w.Word != "two" || w.Start != 1.2 || w.End != 1.4 {
+		t.Errorf("Words[1] = %+v", w)
+	}
+
+	stats := srv.Stats()
+	if stats.Connections != 1 || stats.Finals != 2 || stats.Audio != 2*time.Second || stats.AudioBytes != 64000 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestSTTServerEndOfStream(t *testing.T) {
+	srv := NewSTTServer(WithScript(
+		Utterance{Text: "cut off", Start: 0, End: time.Second},
+		Utterance{Text: "never heard", Start: 2 * time.Second, End: 3 * time.Second},
+	))
+	defer srv.Close()
+
+	opts := &elevenlabs.WebSocketSTTOptions{SampleRate: 16000}
+	transcripts, err := transcribe(t, srv, opts, pcm(500*time.Millisecond))
+	if err != nil {
+		t.Fatalf("transcribe() error = %v", err)
+	}
+	// The started utterance is finalized; the unheard one is dropped.
+	// Without partials, only finals are sent.
+	if len(transcripts) != 1 || transcripts[0].Text != "cut off" || !transcripts[0].IsFinal {
+		t.Errorf("transcripts = %+v", transcripts)
+	}
+	if len(transcripts) == 1 && len(transcripts[0].Words) != 0 {
+		t.Errorf("Words = %+v, want none without word timestamps", transcripts[0].Words)
+	}
+}
+
+func TestSTTServerDiarization(t *testing.T) {
+	srv := NewSTTServer(WithScript(
+		Utterance{Text: "hello", End: 500 * time.Millisecond, SpeakerID: "speaker_0"},
+		Utterance{Text: "hi there", Start: 500 * time.Millisecond, End: time.Second, SpeakerID: "speaker_1"},
+	))
+	defer srv.Close()
+
+	opts := &elevenlabs.WebSocketSTTOptions{SampleRate: 16000, EnableWordTimestamps: true, EnableDiarization: true}
+	transcripts, err := transcribe(t, srv, opts, pcm(time.Second))
+	if err != nil {
+		t.Fatalf("transcribe() error = %v", err)
+	}
+	if len(transcripts) != 2 {
+		t.Fatalf("transcripts = %+v", transcripts)
+	}
+	if tr := transcripts[1]; tr.SpeakerID != "speaker_1" || len(tr.Segments) != 1 || tr.Segments[0].Text != "hi there" {
+		t.Errorf("transcripts[1] = %+v", tr)
+	}
+}
+
+func TestSTTServerErrorInjection(t *testing.T) {
+	srv := NewSTTServer(
+		WithScript(Utterance{Text: "still works", End: time.Second}),
+		WithErrorAt(300*time.Millisecond, "quota exceeded"),
+	)
+	defer srv.Close()
+
+	transcripts, err := transcribe(t, srv, &elevenlabs.WebSocketSTTOptions{SampleRate: 16000}, pcm(time.Second))
+	if err == nil || !strings.Contains(err.Error(), "quota exceeded") {
+		t.Errorf("error = %v, want injected error", err)
+	}
+	if got := finals(transcripts); len(got) != 1 {
+		t.Errorf("finals = %q, want the stream to continue after the error", got)
+	}
+	if srv.Stats().Errors != 1 {
+		t.Errorf("Stats().Errors = %d, want 1", srv.Stats().Errors)
+	}
+}
+
+func TestSTTServerDisconnect(t *testing.T) {
+	srv := NewSTTServer(
+		WithScript(Utterance{Text: "lost", Start: 500 * time.Millisecond, End: time.Second}),
+		WithDisconnectAt(200*time.Millisecond),
+	)
+	defer srv.Close()
+
+	transcripts, err := transcribe(t, srv, &elevenlabs.WebSocketSTTOptions{SampleRate: 16000}, pcm(time.Second))
+	if err == nil {
+		t.Error("error = nil, want abnormal close")
+	}
+	if len(transcripts) != 0 {
+		t.Errorf("transcripts = %+v, want none", transcripts)
+	}
+}
+
+func TestSTTServerLatency(t *testing.T) {
+	srv := NewSTTServer(
+		WithScript(Utterance{Text: "slow", End: 100 * time.Millisecond}),
+		WithTranscriptLatency(150*time.Millisecond),
+	)
+	defer srv.Close()
+
+	start := time.Now()
+	if _, err := transcribe(t, srv, &elevenlabs.WebSocketSTTOptions{SampleRate: 16000}, pcm(100*time.Millisecond)); err != nil {
+		t.Fatalf("transcribe() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("elapsed = %v, want at least the latency", elapsed)
+	}
+}
+
+func TestSTTServerMulaw(t *testing.T) {
+	srv := NewSTTServer(WithScript(Utterance{Text: "phone call", End: time.Second}))
+	defer srv.Close()
+
+	opts := &elevenlabs.WebSocketSTTOptions{SampleRate: 8000, Encoding: "pcm_mulaw"}
+	transcripts, err := transcribe(t, srv, opts, make([]byte, 8000))
+	if err != nil {
+		t.Fatalf("transcribe() error = %v", err)
+	}
+	if got := finals(transcripts); len(got) != 1 || srv.Stats().Audio != time.Second {
+		t.Errorf("finals = %q, audio = %v", got, srv.Stats().Audio)
+	}
+}
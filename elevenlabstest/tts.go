@@ -0,0 +1,365 @@
+package elevenlabstest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	elevenlabs "github.com/agentplexus/go-elevenlabs"
+)
+
+// DefaultCharDuration is the synthetic speech duration of one character.
+const DefaultCharDuration = 60 * time.Millisecond
+
+// defaultChunkLengthSchedule is the API's default chunk_length_schedule.
+var defaultChunkLengthSchedule = []int{120, 160, 250, 290}
+
+// defaultInactivityTimeout is the API's default inactivity_timeout.
+const defaultInactivityTimeout = 20 * time.Second
+
+// TTSServer is a fake WebSocket text-to-speech server. Like the API, it
+// buffers text until the chunk length schedule is reached or a flush or
+// trigger is requested, then sends the audio and alignment of the buffered
+// text. It closes the connection, after a final message, on an empty text
+// message, close_connection, or the inactivity timeout.
+//
+// The audio is silence in the requested output format, WithCharDuration per
+// character, so its length matches what a player expects.
+type TTSServer struct {
+	// URL is the base URL of the server, for elevenlabs.WithBaseURL.
+	URL string
+
+	server       *httptest.Server
+	upgrader     websocket.Upgrader
+	latency      time.Duration
+	charDuration time.Duration
+	maxConns     int
+
+	mu    sync.Mutex
+	stats TTSServerStats
+	texts []string
+}
+
+// TTSServerStats counts the traffic of a TTSServer.
+type TTSServerStats struct {
+	// Connections is the number of connections accepted.
+	Connections int
+
+	// Rejected is the number of connections refused by WithMaxConnections.
+	Rejected int
+
+	// ActiveConnections is the number of open connections.
+	ActiveConnections int
+
+	// PeakConnections is the most connections open at once.
+	PeakConnections int
+
+	// Generations is the number of audio messages sent.
+	Generations int
+
+	// Characters is the number of characters synthesized.
+	Characters int
+
+	// AudioBytes is the number of audio bytes sent.
+	AudioBytes int
+}
+
+// TTSServerOption configures a TTSServer.
+type TTSServerOption func(*TTSServer)
+
+// WithLatency delays each audio message, simulating generation time.
+func WithLatency(d time.Duration) TTSServerOption {
+	return func(s *TTSServer) {
+		s.latency = d
+	}
+}
+
+// WithCharDuration sets the synthetic speech duration of one character.
+// The default is DefaultCharDuration.
+func WithCharDuration(d time.Duration) TTSServerOption {
+	return func(s *TTSServer) {
+		s.charDuration = d
+	}
+}
+
+// WithMaxConnections refuses connections beyond n open at once with 429
+// Too Many Requests, like the concurrency limit of a plan.
+func WithMaxConnections(n int) TTSServerOption {
+	return func(s *TTSServer) {
+		s.maxConns = n
+	}
+}
+
+// NewTTSServer starts a fake WebSocket TTS server. Close it when done.
+func NewTTSServer(opts ...TTSServerOption) *TTSServer {
+	s := &TTSServer{charDuration: DefaultCharDuration}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Client returns a client connected to the server. opts are applied after
+// the API key and base URL.
+func (s *TTSServer) Client(opts ...elevenlabs.Option) (*elevenlabs.Client, error) {
+	return newClient(s.URL, opts)
+}
+
+// Close closes open connections and shuts the server down.
+func (s *TTSServer) Close() {
+	s.server.CloseClientConnections()
+	s.server.Close()
+}
+
+// Stats returns the traffic counts so far.
+func (s *TTSServer) Stats() TTSServerStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// Texts returns the text of each generation, in the order generated.
+func (s *TTSServer) Texts() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.texts...)
+}
+
+// ttsMessage is a client message. Text is a pointer to tell an empty text,
+// which ends the stream, from no text.
+type ttsMessage struct {
+	Text                 *string `json:"text"`
+	TryTriggerGeneration bool    `json:"try_trigger_generation"`
+	Flush                bool    `json:"flush"`
+	CloseConnection      bool    `json:"close_connection"`
+	GenerationConfig     *struct {
+		ChunkLengthSchedule []int `json:"chunk_length_schedule"`
+	} `json:"generation_config"`
+}
+
+type ttsAlignment struct {
+	Characters     []string  `json:"characters"`
+	CharacterStart []float64 `json:"character_start_times_seconds"`
+	CharacterEnd   []float64 `json:"character_end_times_seconds"`
+}
+
+type ttsResponse struct {
+	Audio               string        `json:"audio,omitempty"`
+	IsFinal             bool          `json:"isFinal,omitempty"`
+	NormalizedAlignment *ttsAlignment `json:"normalizedAlignment,omitempty"`
+	Alignment           *ttsAlignment `json:"alignment,omitempty"`
+}
+
+func (s *TTSServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, "/v1/text-to-speech/") || !strings.HasSuffix(r.URL.Path, "/stream-input") {
+		http.NotFound(w, r)
+		return
+	}
+	if !authorized(w, r) {
+		return
+	}
+	if !s.connect() {
+		http.Error(w, `{"detail": "too many concurrent requests"}`, http.StatusTooManyRequests)
+		return
+	}
+	defer s.disconnect()
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	q := r.URL.Query()
+	sess := &ttsSession{
+		server:   s,
+		conn:     conn,
+		format:   q.Get("output_format"),
+		schedule: defaultChunkLengthSchedule,
+	}
+	timeout := defaultInactivityTimeout
+	if secs, err := strconv.Atoi(q.Get("inactivity_timeout")); err == nil && secs > 0 {
+		timeout = time.Duration(secs) * time.Second
+	}
+	sess.run(timeout)
+}
+
+// connect counts a new connection, or reports false if over the limit.
+func (s *TTSServer) connect() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.maxConns > 0 && s.stats.ActiveConnections >= s.maxConns {
+		s.stats.Rejected++
+		return false
+	}
+	s.stats.Connections++
+	s.stats.ActiveConnections++
+	s.stats.PeakConnections = max(s.stats.PeakConnections, s.stats.ActiveConnections)
+	return true
+}
+
+func (s *TTSServer) disconnect() {
+	s.mu.Lock()
+	s.stats.ActiveConnections--
+	s.mu.Unlock()
+}
+
+// ttsSession is the state of one connection.
+type ttsSession struct {
+	server      *TTSServer
+	conn        *websocket.Conn
+	format      string
+	schedule    []int
+	generations int
+	buf         strings.Builder
+	elapsed     time.Duration
+}
+
+func (sess *ttsSession) run(timeout time.Duration) {
+	for {
+		_ = sess.conn.SetReadDeadline(time.Now().Add(timeout))
+		_, data, err := sess.conn.ReadMessage()
+		if err != nil {
+			// Like the API, end the stream after the inactivity timeout
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				sess.finish()
+			}
+			return
+		}
+		var msg ttsMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			_ = sess.conn.WriteJSON(map[string]string{"message": "invalid message: " + err.Error()})
+			continue
+		}
+		if msg.GenerationConfig != nil && len(msg.GenerationConfig.ChunkLengthSchedule) > 0 {
+			sess.schedule = msg.GenerationConfig.ChunkLengthSchedule
+		}
+
+		if (msg.Text != nil && *msg.Text == "") || msg.CloseConnection {
+			sess.finish()
+			return
+		}
+		if msg.Text != nil {
+			sess.buf.WriteString(*msg.Text)
+		}
+		if msg.Flush || msg.TryTriggerGeneration || len(strings.TrimSpace(sess.buf.String())) >= sess.threshold() {
+			if err := sess.generate(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// threshold returns the buffered characters that trigger the next
+// generation.
+func (sess *ttsSession) threshold() int {
+	return sess.schedule[min(sess.generations, len(sess.schedule)-1)]
+}
+
+// generate sends the audio and alignment of the buffered text.
+func (sess *ttsSession) generate() error {
+	text := strings.TrimSpace(sess.buf.String())
+	sess.buf.Reset()
+	if text == "" {
+		return nil
+	}
+	sess.generations++
+	if sess.server.latency > 0 {
+		time.Sleep(sess.server.latency)
+	}
+
+	chars := strings.Split(text, "")
+	align := &ttsAlignment{Characters: chars}
+	for range chars {
+		align.CharacterStart = append(align.CharacterStart, sess.elapsed.Seconds())
+		sess.elapsed += sess.server.charDuration
+		align.CharacterEnd = append(align.CharacterEnd, sess.elapsed.Seconds())
+	}
+	audio := silence(sess.format, time.Duration(len(chars))*sess.server.charDuration)
+
+	s := sess.server
+	s.mu.Lock()
+	s.stats.Generations++
+	s.stats.Characters += len(chars)
+	s.stats.AudioBytes += len(audio)
+	s.texts = append(s.texts, text)
+	s.mu.Unlock()
+
+	return sess.conn.WriteJSON(ttsResponse{
+		Audio:               base64.StdEncoding.EncodeToString(audio),
+		Alignment:           align,
+		NormalizedAlignment: align,
+	})
+}
+
+// finish sends the remaining audio and a final message, then closes the
+// connection normally.
+func (sess *ttsSession) finish() {
+	if err := sess.generate(); err != nil {
+		return
+	}
+	if err := sess.conn.WriteJSON(ttsResponse{IsFinal: true}); err != nil {
+		return
+	}
+	closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	_ = sess.conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
+}
+
+// silence returns d of silence in an output format such as "pcm_16000".
+// Compressed formats get zero bytes at their bitrate, which is the right
+// size but not playable.
+func silence(format string, d time.Duration) []byte {
+	if format == "" {
+		format = elevenlabs.DefaultOutputFormat
+	}
+	parts := strings.Split(format, "_")
+	rate := 16000
+	if len(parts) > 1 {
+		if n, err := strconv.Atoi(parts[1]); err == nil {
+			rate = n
+		}
+	}
+
+	var bytesPerSec int
+	switch parts[0] {
+	case "pcm":
+		bytesPerSec = rate * 2
+	case "ulaw", "alaw":
+		bytesPerSec = rate
+	default:
+		kbps := 128
+		if len(parts) > 2 {
+			if n, err := strconv.Atoi(parts[2]); err == nil {
+				kbps = n
+			}
+		}
+		bytesPerSec = kbps * 1000 / 8
+	}
+	n := int(d.Seconds() * float64(bytesPerSec))
+	n -= n % 2 // whole 16-bit samples
+
+	audio := make([]byte, n)
+	switch parts[0] {
+	case "ulaw":
+		for i := range audio {
+			audio[i] = 0xFF
+		}
+	case "alaw":
+		for i := range audio {
+			audio[i] = 0xD5
+		}
+	}
+	return audio
+}
@@ -0,0 +1,181 @@
+package elevenlabstest
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	elevenlabs "github.com/agentplexus/go-elevenlabs"
+)
+
+// speak sends texts over a new connection, flushes, and returns the audio
+// received before the server closed the connection.
+func speak(srv *TTSServer, opts *elevenlabs.WebSocketTTSOptions, texts ...string) ([]byte, error) {
+	client, err := srv.Client()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := client.WebSocketTTS().Connect(context.Background(), "voice-1", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	audio := make(chan []byte, 1)
+	go func() {
+		data, _ := io.ReadAll(conn.Reader())
+		audio <- data
+	}()
+	for _, text := range texts {
+		if err := conn.SendText(text); err != nil {
+			return nil, err
+		}
+	}
+	if err := conn.CloseWithTimeout(5 * time.Second); err != nil {
+		return nil, err
+	}
+	return <-audio, nil
+}
+
+func TestTTSServer(t *testing.T) {
+	srv := NewTTSServer()
+	defer srv.Close()
+
+	audio, err := speak(srv, &elevenlabs.WebSocketTTSOptions{OutputFormat: "pcm_16000"}, "Hello ", "world.")
+	if err != nil {
+		t.Fatalf("speak() error = %v", err)
+	}
+
+	// 12 characters of 60ms at 16kHz, 16-bit
+	if want := 12 * 60 * 32; len(audio) != want {
+		t.Errorf("len(audio) = %d, want %d", len(audio), want)
+	}
+	if got := srv.Texts(); len(got) != 1 || got[0] != "Hello world." {
+		t.Errorf("Texts() = %q", got)
+	}
+	stats := srv.Stats()
+	if stats.Connections != 1 || stats.Generations != 1 || stats.Characters != 12 || stats.AudioBytes != len(audio) {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestTTSServerChunkSchedule(t *testing.T) {
+	srv := NewTTSServer()
+	defer srv.Close()
+
+	opts := &elevenlabs.WebSocketTTSOptions{OutputFormat: "pcm_16000", ChunkLengthSchedule: []int{50}}
+	if _, err := speak(srv, opts, strings.Repeat("a", 60), " ", strings.Repeat("b", 10)); err != nil {
+		t.Fatalf("speak() error = %v", err)
+	}
+
+	// The schedule triggers a generation at 50 characters, the flush
+	// generates the rest
+	got := srv.Texts()
+	if len(got) != 2 || len(got[0]) != 60 || got[1] != strings.Repeat("b", 10) {
+		t.Errorf("Texts() = %q", got)
+	}
+}
+
+func TestTTSServerInactivityTimeout(t *testing.T) {
+	srv := NewTTSServer()
+	defer srv.Close()
+
+	client, _ := srv.Client()
+	conn, err := client.WebSocketTTS().Connect(context.Background(), "voice-1", &elevenlabs.WebSocketTTSOptions{
+		InactivityTimeout: 1,
+	})
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer conn.Close()
+	_ = conn.SendText("Unflushed")
+
+	select {
+	case <-conn.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection not closed after the inactivity timeout")
+	}
+	// The buffered text is generated before closing
+	if got := srv.Texts(); len(got) != 1 || got[0] != "Unflushed" {
+		t.Errorf("Texts() = %q", got)
+	}
+}
+
+func TestTTSServerMaxConnections(t *testing.T) {
+	srv := NewTTSServer(WithMaxConnections(1))
+	defer srv.Close()
+
+	client, _ := srv.Client()
+	ctx := context.Background()
+	first, err := client.WebSocketTTS().Connect(ctx, "voice-1", nil)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	if _, err := client.WebSocketTTS().Connect(ctx, "voice-1", nil); err == nil {
+		t.Error("second Connect() error = nil, want rejection")
+	}
+	_ = first.CloseWithTimeout(5 * time.Second)
+
+	stats := srv.Stats()
+	if stats.Connections != 1 || stats.Rejected != 1 {
+		t.Errorf("Stats() = %+v, want 1 connection and 1 rejected", stats)
+	}
+}
+
+func TestTTSServerLoad(t *testing.T) {
+	srv := NewTTSServer(WithLatency(20*time.Millisecond), WithCharDuration(time.Millisecond))
+	defer srv.Close()
+
+	const n = 20
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			audio, err := speak(srv, &elevenlabs.WebSocketTTSOptions{OutputFormat: "ulaw_8000"}, "Load test.")
+			if err != nil {
+				t.Errorf("speak() error = %v", err)
+				return
+			}
+			if len(audio) != 80 {
+				t.Errorf("len(audio) = %d, want 80", len(audio))
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := srv.Stats()
+	if stats.Connections != n || stats.Generations != n {
+		t.Errorf("Stats() = %+v", stats)
+	}
+	if stats.PeakConnections < 2 {
+		t.Errorf("PeakConnections = %d, want concurrent connections", stats.PeakConnections)
+	}
+}
+
+func TestSilence(t *testing.T) {
+	tests := []struct {
+		format string
+		want   int
+		fill   byte
+	}{
+		{"pcm_16000", 32000, 0},
+		{"pcm_44100", 88200, 0},
+		{"ulaw_8000", 8000, 0xFF},
+		{"alaw_8000", 8000, 0xD5},
+		{"mp3_44100_128", 16000, 0},
+		{"", 16000, 0}, // DefaultOutputFormat
+	}
+	for _, tt := range tests {
+		audio := silence(tt.format, time.Second)
+		if len(audio) != tt.want {
+			t.Errorf("silence(%q) = %d bytes, want %d", tt.format, len(audio), tt.want)
+			continue
+		}
+		if audio[0] != tt.fill {
+			t.Errorf("silence(%q)[0] = %#x, want %#x", tt.format, audio[0], tt.fill)
+		}
+	}
+}
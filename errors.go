@@ -1,11 +1,20 @@
 package elevenlabs
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/agentplexus/ogen-tools/ogenerror"
+	"github.com/ogen-go/ogen/validate"
+
+	"github.com/agentplexus/go-elevenlabs/internal/api"
 )
 
 // Common errors
@@ -30,8 +39,64 @@ var (
 
 	// ErrInvalidSpeed is returned when speed is out of range.
 	ErrInvalidSpeed = errors.New("elevenlabs: speed must be between 0.25 and 4.0")
+
+	// ErrWebSocketClosed is returned when using a closed WebSocket connection,
+	// and is the close reason for connections closed normally.
+	ErrWebSocketClosed = errors.New("elevenlabs: websocket connection closed")
+
+	// ErrConversationNotFound is returned when controlling a conversation
+	// that is not open on this client.
+	ErrConversationNotFound = errors.New("elevenlabs: conversation not found")
+
+	// ErrResponseTooLarge is returned when a response body exceeds the size
+	// set with WithMaxResponseSize.
+	ErrResponseTooLarge = errors.New("elevenlabs: response body too large")
+
+	// ErrDubbingFailed is returned when waiting for a dubbing project that
+	// fails.
+	ErrDubbingFailed = errors.New("elevenlabs: dubbing failed")
+
+	// ErrProjectConversionFailed is returned when waiting for a Studio
+	// project whose chapters fail to convert.
+	ErrProjectConversionFailed = errors.New("elevenlabs: project conversion failed")
+
+	// ErrDryRun is returned for requests that were not sent because the
+	// client is in dry-run mode and no synthetic response is available.
+	ErrDryRun = errors.New("elevenlabs: request not sent in dry-run mode")
+
+	// ErrVoiceInUse is returned for voices that VoicesService.DeleteMany
+	// did not delete because they were used recently or by an agent.
+	ErrVoiceInUse = errors.New("elevenlabs: voice is in use")
+
+	// ErrCircuitOpen is returned for requests that were not sent because
+	// the client's circuit breaker is open.
+	ErrCircuitOpen = errors.New("elevenlabs: circuit breaker open")
 )
 
+// Account errors, matched with errors.Is against an *APIError whose status
+// reports them. They are not retryable.
+var (
+	// ErrUnusualActivity is returned when the API blocks a free-tier
+	// account for unusual activity, such as use through a VPN or proxy, or
+	// several free accounts. A paid plan lifts the restriction.
+	ErrUnusualActivity = errors.New("elevenlabs: unusual activity detected")
+
+	// ErrQuotaExceeded is returned when the account has no character
+	// quota left for the request.
+	ErrQuotaExceeded = errors.New("elevenlabs: quota exceeded")
+
+	// ErrVoiceLimitReached is returned when adding a voice to an account
+	// that has reached its plan's custom voice limit.
+	ErrVoiceLimitReached = errors.New("elevenlabs: voice limit reached")
+)
+
+// accountErrors maps API error statuses to account errors.
+var accountErrors = map[string]error{
+	"detected_unusual_activity": ErrUnusualActivity,
+	"quota_exceeded":            ErrQuotaExceeded,
+	"voice_limit_reached":       ErrVoiceLimitReached,
+}
+
 // ValidationError represents a validation error.
 type ValidationError struct {
 	Field   string
@@ -48,6 +113,60 @@ type APIError struct {
 	StatusCode int
 	Message    string
 	Detail     string
+
+	// RequestID is the ID of the failed request, for support tickets.
+	RequestID string
+
+	// Method and Endpoint are the HTTP method and path of the failed
+	// request (e.g., "POST", "/v1/text-to-speech/voice-id").
+	Method   string
+	Endpoint string
+
+	// RetryAfter is how long to wait before retrying, from the Retry-After
+	// header of 429 and 503 responses. Zero if not provided.
+	RetryAfter time.Duration
+
+	// FieldErrors are the problems with individual request fields, from
+	// 422 validation responses.
+	FieldErrors []FieldError
+
+	// err is the error from the generated client, if any.
+	err error
+}
+
+// FieldError is a problem with one field of a request, as reported by the
+// API in a 422 validation response.
+type FieldError struct {
+	// Location is the path to the field, starting with where it was sent
+	// (e.g., ["body", "voice_settings", "stability"]).
+	Location []string
+
+	// Message describes the problem (e.g., "Field required").
+	Message string
+
+	// Type is the kind of problem (e.g., "missing", "value_error").
+	Type string
+}
+
+// Field returns the dotted path to the field within the request body,
+// query, or path (e.g., "voice_settings.stability").
+func (e FieldError) Field() string {
+	loc := e.Location
+	if len(loc) > 1 {
+		switch loc[0] {
+		case "body", "query", "path", "header":
+			loc = loc[1:]
+		}
+	}
+	return strings.Join(loc, ".")
+}
+
+// String returns the field and message (e.g., "text: Field required").
+func (e FieldError) String() string {
+	if field := e.Field(); field != "" {
+		return field + ": " + e.Message
+	}
+	return e.Message
 }
 
 // Error implements the error interface.
@@ -58,6 +177,19 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("elevenlabs: API error (status %d): %s", e.StatusCode, e.Message)
 }
 
+// Unwrap returns the error from the generated client, such as
+// *validate.UnexpectedStatusCodeError, if the APIError was created from one.
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether the error's status matches an account error such as
+// ErrQuotaExceeded, so errors.Is can be used on API errors.
+func (e *APIError) Is(target error) bool {
+	sentinel, ok := accountErrors[e.Detail]
+	return ok && sentinel == target
+}
+
 // IsNotFoundError returns true if the error is a 404 Not Found error.
 func IsNotFoundError(err error) bool {
 	var apiErr *APIError
@@ -85,6 +217,42 @@ func IsRateLimitError(err error) bool {
 	return false
 }
 
+// IsPaymentRequiredError returns true if the error is a 402 Payment Required
+// error, returned when the subscription does not cover the request.
+func IsPaymentRequiredError(err error) bool {
+	if apiErr := ParseAPIError(err); apiErr != nil {
+		return apiErr.StatusCode == 402
+	}
+	return false
+}
+
+// IsQuotaExceededError returns true if the error reports that the account's
+// character quota is exhausted.
+func IsQuotaExceededError(err error) bool {
+	return isAccountError(err, ErrQuotaExceeded)
+}
+
+// IsUnusualActivityError returns true if the error reports that a free-tier
+// account was blocked for unusual activity.
+func IsUnusualActivityError(err error) bool {
+	return isAccountError(err, ErrUnusualActivity)
+}
+
+// IsVoiceLimitError returns true if the error reports that the account has
+// reached its custom voice limit.
+func IsVoiceLimitError(err error) bool {
+	return isAccountError(err, ErrVoiceLimitReached)
+}
+
+// isAccountError reports whether err, including an error from the
+// generated client, is the account error sentinel.
+func isAccountError(err error, sentinel error) bool {
+	if apiErr := ParseAPIError(err); apiErr != nil {
+		return errors.Is(apiErr, sentinel)
+	}
+	return false
+}
+
 // IsForbiddenError returns true if the error is a 403 Forbidden error.
 func IsForbiddenError(err error) bool {
 	var apiErr *APIError
@@ -124,34 +292,163 @@ func ParseAPIError(err error) *APIError {
 		return nil
 	}
 
+	apiErr := newAPIError(status.StatusCode, status.Body)
+
+	// Request metadata comes from the response itself
+	var statusErr *validate.UnexpectedStatusCodeError
+	if errors.As(err, &statusErr) && statusErr.Payload != nil {
+		apiErr.setResponse(statusErr.Payload)
+		// Restore the consumed body so the error can be parsed again
+		statusErr.Payload.Body = io.NopCloser(bytes.NewReader(status.Body))
+	}
+	apiErr.err = err
+
+	return apiErr
+}
+
+// apiError converts an error from the generated client into an *APIError,
+// so failed requests report their status and message. Other errors, such
+// as network failures, are returned unchanged.
+func apiError(err error) error {
+	var existing *APIError
+	if errors.As(err, &existing) {
+		return err
+	}
+	if apiErr := ParseAPIError(err); apiErr != nil {
+		return apiErr
+	}
+	return err
+}
+
+// checkResponse returns the error for a generated client call whose
+// success response has no content.
+func checkResponse(resp any, err error) error {
+	if err != nil {
+		return apiError(err)
+	}
+	if v, ok := resp.(*api.HTTPValidationError); ok {
+		return validationAPIError(v)
+	}
+	return nil
+}
+
+// unexpectedResponse is returned when the generated client decodes a
+// response type the SDK does not handle.
+func unexpectedResponse(resp any) *APIError {
+	return &APIError{Message: fmt.Sprintf("unexpected response type %T", resp)}
+}
+
+// newAPIError creates an APIError from an error response body.
+func newAPIError(statusCode int, body []byte) *APIError {
 	apiErr := &APIError{
-		StatusCode: status.StatusCode,
-		Message:    fmt.Sprintf("HTTP %d", status.StatusCode),
+		StatusCode: statusCode,
+		Message:    fmt.Sprintf("HTTP %d", statusCode),
+	}
+	if len(body) == 0 {
+		return apiErr
 	}
 
 	// Parse ElevenLabs-specific error format
-	if len(status.Body) > 0 {
-		var errResp struct {
-			Detail interface{} `json:"detail"`
-		}
-		if json.Unmarshal(status.Body, &errResp) == nil {
-			switch d := errResp.Detail.(type) {
-			case string:
-				apiErr.Detail = d
-			case map[string]interface{}:
-				if msg, ok := d["message"].(string); ok {
-					apiErr.Message = msg
-				}
-				if detail, ok := d["status"].(string); ok {
-					apiErr.Detail = detail
+	var errResp struct {
+		Detail interface{} `json:"detail"`
+	}
+	if json.Unmarshal(body, &errResp) == nil {
+		switch d := errResp.Detail.(type) {
+		case string:
+			apiErr.Detail = d
+		case map[string]interface{}:
+			if msg, ok := d["message"].(string); ok {
+				apiErr.Message = msg
+			}
+			if detail, ok := d["status"].(string); ok {
+				apiErr.Detail = detail
+			}
+		case []interface{}:
+			var validation struct {
+				Detail []struct {
+					Loc  []interface{} `json:"loc"`
+					Msg  string        `json:"msg"`
+					Type string        `json:"type"`
+				} `json:"detail"`
+			}
+			if json.Unmarshal(body, &validation) == nil {
+				for _, d := range validation.Detail {
+					fe := FieldError{Message: d.Msg, Type: d.Type}
+					for _, loc := range d.Loc {
+						fe.Location = append(fe.Location, fmt.Sprint(loc))
+					}
+					apiErr.FieldErrors = append(apiErr.FieldErrors, fe)
 				}
+				apiErr.setFieldErrorSummary()
 			}
 		}
-		// If parsing failed, use raw body as detail
-		if apiErr.Detail == "" && apiErr.Message == fmt.Sprintf("HTTP %d", status.StatusCode) {
-			apiErr.Detail = string(status.Body)
-		}
 	}
+	// If parsing failed, use raw body as detail
+	if apiErr.Detail == "" && apiErr.Message == fmt.Sprintf("HTTP %d", statusCode) {
+		apiErr.Detail = string(body)
+	}
+	return apiErr
+}
 
+// validationAPIError creates an APIError from a 422 response decoded by the
+// generated client.
+func validationAPIError(v *api.HTTPValidationError) *APIError {
+	apiErr := &APIError{StatusCode: http.StatusUnprocessableEntity}
+	for _, d := range v.Detail {
+		fe := FieldError{Message: d.Msg, Type: d.Type}
+		for _, loc := range d.Loc {
+			if loc.IsInt() {
+				fe.Location = append(fe.Location, strconv.Itoa(loc.Int))
+			} else {
+				fe.Location = append(fe.Location, loc.String)
+			}
+		}
+		apiErr.FieldErrors = append(apiErr.FieldErrors, fe)
+	}
+	apiErr.setFieldErrorSummary()
 	return apiErr
 }
+
+// setFieldErrorSummary sets the message and detail of a validation error
+// from its field errors.
+func (e *APIError) setFieldErrorSummary() {
+	e.Message = "validation failed"
+	parts := make([]string, len(e.FieldErrors))
+	for i, fe := range e.FieldErrors {
+		parts[i] = fe.String()
+	}
+	e.Detail = strings.Join(parts, "; ")
+}
+
+// setResponse fills the request and retry metadata of the error from the
+// failed response.
+func (e *APIError) setResponse(resp *http.Response) {
+	e.RequestID = resp.Header.Get(headerRequestID)
+	e.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+	if req := resp.Request; req != nil {
+		e.Method = req.Method
+		if req.URL != nil {
+			e.Endpoint = req.URL.Path
+		}
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an
+// HTTP date. Returns 0 if the header is empty or invalid.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
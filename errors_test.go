@@ -1,8 +1,15 @@
 package elevenlabs
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/ogen-go/ogen/validate"
 )
 
 func TestValidationError(t *testing.T) {
@@ -135,3 +142,222 @@ func TestIsRateLimitError(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAPIErrorResponseMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("request-id", "req-789")
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"detail":{"status":"too_many_concurrent_requests","message":"Too many requests"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.TextToSpeech().Generate(context.Background(), &TTSRequest{VoiceID: "voice-1", Text: "Hello"})
+	apiErr := ParseAPIError(err)
+	if apiErr == nil {
+		t.Fatalf("ParseAPIError(%v) = nil", err)
+	}
+	if apiErr.StatusCode != 429 || apiErr.Detail != "too_many_concurrent_requests" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+	if apiErr.RequestID != "req-789" {
+		t.Errorf("RequestID = %q, want req-789", apiErr.RequestID)
+	}
+	if apiErr.Method != http.MethodPost || apiErr.Endpoint != "/v1/text-to-speech/voice-1" {
+		t.Errorf("Method, Endpoint = %q, %q", apiErr.Method, apiErr.Endpoint)
+	}
+	if apiErr.RetryAfter != 7*time.Second {
+		t.Errorf("RetryAfter = %v, want 7s", apiErr.RetryAfter)
+	}
+
+	// The error can be parsed again.
+	if again := ParseAPIError(err); again.Detail != apiErr.Detail {
+		t.Errorf("second ParseAPIError() Detail = %q, want %q", again.Detail, apiErr.Detail)
+	}
+}
+
+func TestIsQuotaExceededAndPaymentRequiredError(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		quotaExceeded bool
+		payment       bool
+	}{
+		{"quota exceeded", &APIError{StatusCode: 401, Detail: "quota_exceeded"}, true, false},
+		{"payment required", &APIError{StatusCode: 402, Message: "Payment Required"}, false, true},
+		{"unauthorized", &APIError{StatusCode: 401, Detail: "invalid_api_key"}, false, false},
+		{"other error", errors.New("some error"), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsQuotaExceededError(tt.err); got != tt.quotaExceeded {
+				t.Errorf("IsQuotaExceededError() = %v, want %v", got, tt.quotaExceeded)
+			}
+			if got := IsPaymentRequiredError(tt.err); got != tt.payment {
+				t.Errorf("IsPaymentRequiredError() = %v, want %v", got, tt.payment)
+			}
+		})
+	}
+}
+
+func TestAccountErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		sentinel error
+		is       func(error) bool
+	}{
+		{"unusual activity", `{"detail": {"status": "detected_unusual_activity", "message": "Unusual activity detected. Free Tier usage disabled."}}`, ErrUnusualActivity, IsUnusualActivityError},
+		{"quota exceeded", `{"detail": {"status": "quota_exceeded", "message": "This request exceeds your quota."}}`, ErrQuotaExceeded, IsQuotaExceededError},
+		{"voice limit", `{"detail": {"status": "voice_limit_reached", "message": "You have reached your maximum amount of custom voices."}}`, ErrVoiceLimitReached, IsVoiceLimitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("generate: %w", newAPIError(http.StatusUnauthorized, []byte(tt.body)))
+			if !errors.Is(err, tt.sentinel) {
+				t.Errorf("errors.Is(%v) = false", tt.sentinel)
+			}
+			if !tt.is(err) {
+				t.Error("predicate = false")
+			}
+			for _, other := range []error{ErrUnusualActivity, ErrQuotaExceeded, ErrVoiceLimitReached} {
+				if other != tt.sentinel && errors.Is(err, other) {
+					t.Errorf("errors.Is(%v) = true", other)
+				}
+			}
+		})
+	}
+
+	if errors.Is(&APIError{StatusCode: 401, Detail: "invalid_api_key"}, ErrQuotaExceeded) {
+		t.Error("errors.Is() on another status = true")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("30"); got != 30*time.Second {
+		t.Errorf("parseRetryAfter(30) = %v", got)
+	}
+	if got := parseRetryAfter(""); got != 0 {
+		t.Errorf("parseRetryAfter(\"\") = %v", got)
+	}
+	if got := parseRetryAfter("soon"); got != 0 {
+		t.Errorf("parseRetryAfter(soon) = %v", got)
+	}
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(date); got <= 0 || got > time.Minute {
+		t.Errorf("parseRetryAfter(%s) = %v", date, got)
+	}
+}
+
+func TestAPIErrorFieldErrors(t *testing.T) {
+	const body = `{"detail":[
+		{"loc":["body","voice_settings","stability"],"msg":"Input should be less than or equal to 1","type":"less_than_equal"},
+		{"loc":["body","text"],"msg":"Field required","type":"missing"}
+	]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	check := func(t *testing.T, err error) {
+		t.Helper()
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("expected APIError, got %v", err)
+		}
+		if apiErr.StatusCode != 422 || len(apiErr.FieldErrors) != 2 {
+			t.Fatalf("APIError = %+v", apiErr)
+		}
+		fe := apiErr.FieldErrors[0]
+		if fe.Field() != "voice_settings.stability" || fe.Type != "less_than_equal" {
+			t.Errorf("FieldErrors[0] = %+v, Field() = %q", fe, fe.Field())
+		}
+		want := "elevenlabs: API error (status 422): validation failed - " +
+			"voice_settings.stability: Input should be less than or equal to 1; text: Field required"
+		if apiErr.Error() != want {
+			t.Errorf("Error() = %q, want %q", apiErr.Error(), want)
+		}
+	}
+
+	t.Run("generated client", func(t *testing.T) {
+		_, err := client.TextToSpeech().Generate(ctx, &TTSRequest{VoiceID: "voice-1", Text: "Hello"})
+		check(t, err)
+	})
+	t.Run("raw JSON endpoint", func(t *testing.T) {
+		check(t, client.doJSON(ctx, http.MethodPost, "/v1/anything", map[string]string{}, nil))
+	})
+	t.Run("no content response", func(t *testing.T) {
+		check(t, client.Voices().Delete(ctx, "voice-1"))
+	})
+}
+
+func TestServiceErrorsAreAPIErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("request-id", "req-401")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"detail":{"status":"invalid_api_key","message":"Invalid API key"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	_, listErr := client.Models().List(ctx)
+	errs := map[string]error{
+		"Models.List":   listErr,
+		"Voices.Delete": client.Voices().Delete(ctx, "voice-1"),
+	}
+	for name, err := range errs {
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Errorf("%s: expected *APIError, got %T: %v", name, err, err)
+			continue
+		}
+		if apiErr.Message != "Invalid API key" || apiErr.Detail != "invalid_api_key" || apiErr.RequestID != "req-401" {
+			t.Errorf("%s: APIError = %+v", name, apiErr)
+		}
+		if !IsUnauthorizedError(err) {
+			t.Errorf("%s: IsUnauthorizedError() = false", name)
+		}
+		// The generated client's error is still available
+		var statusErr *validate.UnexpectedStatusCodeError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusUnauthorized {
+			t.Errorf("%s: UnexpectedStatusCodeError not unwrapped", name)
+		}
+	}
+}
+
+func TestFieldErrorField(t *testing.T) {
+	tests := []struct {
+		loc  []string
+		want string
+	}{
+		{[]string{"body", "inputs", "0", "text"}, "inputs.0.text"},
+		{[]string{"query", "output_format"}, "output_format"},
+		{[]string{"body"}, "body"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := (FieldError{Location: tt.loc}).Field(); got != tt.want {
+			t.Errorf("Field(%v) = %q, want %q", tt.loc, got, tt.want)
+		}
+	}
+}
@@ -153,7 +153,10 @@ func compileForElevenLabs(script *ttsscript.Script, language string) {
 
 	// Generate manifest for batch processing
 	config := ttsscript.NewBatchConfig("./output")
-	manifest := ttsscript.GenerateManifest(jobs, config, language)
+	manifest, err := ttsscript.GenerateManifest(jobs, config, language)
+	if err != nil {
+		log.Fatalf("Failed to generate manifest: %v", err)
+	}
 
 	manifestJSON, _ := json.MarshalIndent(manifest, "", "  ")
 	fmt.Printf("\nManifest:\n%s\n", string(manifestJSON))
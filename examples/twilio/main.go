@@ -113,14 +113,10 @@ func handleIncomingCall(w http.ResponseWriter, r *http.Request) {
 	agentID := os.Getenv("ELEVENLABS_AGENT_ID")
 	if agentID == "" {
 		// Return error TwiML
-		w.Header().Set("Content-Type", "application/xml")
-		if _, err := w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
-<Response>
-    <Say>Sorry, no agent is configured.</Say>
-    <Hangup/>
-</Response>`)); err != nil {
-			logError(ctx, "Failed to write response", err)
-		}
+		elevenlabs.NewTwiMLResponse().
+			Say("Sorry, no agent is configured.").
+			Hangup().
+			ServeHTTP(w, r)
 		return
 	}
 
@@ -128,25 +124,23 @@ func handleIncomingCall(w http.ResponseWriter, r *http.Request) {
 	resp, err := client.Twilio().RegisterCall(ctx, &elevenlabs.TwilioRegisterCallRequest{
 		AgentID: agentID,
 
-		// Inject caller info as dynamic variables
-		DynamicVariables: map[string]string{
-			"caller_number": callerNumber,
-			"call_sid":      callSid,
-		},
+		InitiationData: &elevenlabs.ConversationInitiationData{
+			// Inject caller info as dynamic variables
+			DynamicVariables: map[string]any{
+				"caller_number": callerNumber,
+				"call_sid":      callSid,
+			},
 
-		// Optional: customize first message
-		// FirstMessage: fmt.Sprintf("Hello! I see you're calling from %s.", callerNumber),
+			// Optional: customize first message
+			// FirstMessage: fmt.Sprintf("Hello! I see you're calling from %s.", callerNumber),
+		},
 	})
 	if err != nil {
 		logError(ctx, "Failed to register call", err, "agent_id", agentID)
-		w.Header().Set("Content-Type", "application/xml")
-		if _, err := w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
-<Response>
-    <Say>Sorry, there was an error connecting your call.</Say>
-    <Hangup/>
-</Response>`)); err != nil {
-			logError(ctx, "Failed to write response", err)
-		}
+		elevenlabs.NewTwiMLResponse().
+			Say("Sorry, there was an error connecting your call.").
+			Hangup().
+			ServeHTTP(w, r)
 		return
 	}
 
@@ -170,11 +164,11 @@ func handleOutboundCall(w http.ResponseWriter, r *http.Request) {
 
 	// Parse request
 	var req struct {
-		ToNumber           string            `json:"to_number"`
-		AgentID            string            `json:"agent_id"`
-		AgentPhoneNumberID string            `json:"agent_phone_number_id"`
-		FirstMessage       string            `json:"first_message,omitempty"`
-		Variables          map[string]string `json:"variables,omitempty"`
+		ToNumber           string         `json:"to_number"`
+		AgentID            string         `json:"agent_id"`
+		AgentPhoneNumberID string         `json:"agent_phone_number_id"`
+		FirstMessage       string         `json:"first_message,omitempty"`
+		Variables          map[string]any `json:"variables,omitempty"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid JSON", http.StatusBadRequest)
@@ -192,8 +186,10 @@ func handleOutboundCall(w http.ResponseWriter, r *http.Request) {
 		AgentID:            req.AgentID,
 		AgentPhoneNumberID: req.AgentPhoneNumberID,
 		ToNumber:           req.ToNumber,
-		FirstMessage:       req.FirstMessage,
-		DynamicVariables:   req.Variables,
+		InitiationData: &elevenlabs.ConversationInitiationData{
+			FirstMessage:     req.FirstMessage,
+			DynamicVariables: req.Variables,
+		},
 	})
 	if err != nil {
 		logError(ctx, "Failed to make outbound call", err)
@@ -254,9 +250,11 @@ func sipOutboundExample(ctx context.Context, client *elevenlabs.Client) {
 		ToNumber:   "+1234567890",
 		FromNumber: "+0987654321", // Must be verified
 
-		DynamicVariables: map[string]string{
-			"customer_name": "John",
-			"order_id":      "12345",
+		InitiationData: &elevenlabs.ConversationInitiationData{
+			DynamicVariables: map[string]any{
+				"customer_name": "John",
+				"order_id":      "12345",
+			},
 		},
 	})
 	if err != nil {
@@ -85,7 +85,7 @@ func (s *ForcedAlignmentService) Align(ctx context.Context, req *ForcedAlignment
 
 	resp, err := s.client.apiClient.ForcedAlignment(ctx, body, api.ForcedAlignmentParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
@@ -114,8 +114,10 @@ func (s *ForcedAlignmentService) Align(ctx context.Context, req *ForcedAlignment
 		}
 
 		return result, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
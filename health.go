@@ -0,0 +1,113 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// DefaultPingTimeout is how long Ping and Health wait for the API before
+// reporting it unreachable.
+const DefaultPingTimeout = 5 * time.Second
+
+// Healthcheck is a snapshot of API reachability and quota, for readiness
+// probes in services that depend on the API.
+type Healthcheck struct {
+	// CheckedAt is when the check started.
+	CheckedAt time.Time
+
+	// Latency is the round-trip time of the check request.
+	Latency time.Duration
+
+	// Subscription is the account's subscription and character quota at
+	// the time of the check.
+	Subscription *Subscription
+}
+
+// CharactersRemaining returns the characters left in the current period.
+func (h *Healthcheck) CharactersRemaining() int {
+	if h.Subscription == nil {
+		return 0
+	}
+	return h.Subscription.CharactersRemaining()
+}
+
+// QuotaUsed returns the fraction of the character quota used in the
+// current period, from 0 to 1. It is 0 if the plan has no limit.
+func (h *Healthcheck) QuotaUsed() float64 {
+	if h.Subscription == nil || h.Subscription.CharacterLimit <= 0 {
+		return 0
+	}
+	return min(float64(h.Subscription.CharacterCount)/float64(h.Subscription.CharacterLimit), 1)
+}
+
+// Ping checks that the API is reachable and the API key is valid with a
+// cheap authenticated request. It fails after DefaultPingTimeout, or
+// sooner if ctx has an earlier deadline.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.Health(ctx)
+	return err
+}
+
+// Health checks the API like Ping and returns the request latency and a
+// snapshot of the character quota.
+func (c *Client) Health(ctx context.Context) (*Healthcheck, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultPingTimeout)
+	defer cancel()
+
+	start := time.Now()
+	user, err := c.User().GetInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Healthcheck{
+		CheckedAt:    start,
+		Latency:      time.Since(start),
+		Subscription: user.Subscription,
+	}, nil
+}
+
+// healthResponse is the JSON body written by HealthHandler.
+type healthResponse struct {
+	Status              string  `json:"status"`
+	Error               string  `json:"error,omitempty"`
+	LatencyMs           int64   `json:"latency_ms,omitempty"`
+	Tier                string  `json:"tier,omitempty"`
+	CharactersRemaining int     `json:"characters_remaining,omitempty"`
+	QuotaUsed           float64 `json:"quota_used,omitempty"`
+}
+
+// HealthHandler returns an http.Handler for readiness probes. It responds
+// 200 with the latency and quota as JSON when the API is reachable, and
+// 503 with the error otherwise. If minCharacters is positive, the check
+// also fails when fewer characters than that remain in the quota.
+func (c *Client) HealthHandler(minCharacters int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body healthResponse
+		status := http.StatusOK
+		h, err := c.Health(r.Context())
+		if err == nil {
+			body.LatencyMs = h.Latency.Milliseconds()
+			body.CharactersRemaining = h.CharactersRemaining()
+			body.QuotaUsed = h.QuotaUsed()
+			if h.Subscription != nil {
+				body.Tier = h.Subscription.Tier
+			}
+		}
+		switch {
+		case err != nil:
+			status = http.StatusServiceUnavailable
+			body.Status = "unavailable"
+			body.Error = err.Error()
+		case minCharacters > 0 && body.CharactersRemaining < minCharacters:
+			status = http.StatusServiceUnavailable
+			body.Status = "quota_low"
+		default:
+			body.Status = "ok"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	})
+}
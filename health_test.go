@@ -0,0 +1,104 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newUserInfoServer serves /v1/user with the given status code and a
+// subscription using 9,000 of 10,000 characters.
+func newUserInfoServer(t *testing.T, status int) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/user" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("xi-api-key") != "test-key" {
+			t.Errorf("missing API key")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status != http.StatusOK {
+			_, _ = w.Write([]byte(`{"detail": {"status": "invalid_api_key", "message": "Invalid API key"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"user_id": "user-1", "created_at": 1700000000,
+			"is_new_user": false, "can_use_delayed_payment_methods": false,
+			"is_onboarding_completed": true, "is_onboarding_checklist_completed": true,
+			"subscription": {"tier": "creator", "status": "active",
+				"character_count": 9000, "character_limit": 10000,
+				"can_extend_character_limit": false, "allowed_to_extend_character_limit": false,
+				"voice_slots_used": 1, "professional_voice_slots_used": 0, "voice_limit": 30,
+				"voice_add_edit_counter": 0, "max_character_limit_extension": null, "next_character_count_reset_unix": null, "max_voice_add_edits": null, "currency": null, "billing_period": null, "character_refresh_period": null, "professional_voice_limit": 1,
+				"can_extend_voice_limit": false, "can_use_instant_voice_cloning": true,
+				"can_use_professional_voice_cloning": true}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestClientHealth(t *testing.T) {
+	client := newUserInfoServer(t, http.StatusOK)
+
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	h, err := client.Health(context.Background())
+	if err != nil {
+		t.Fatalf("Health() error = %v", err)
+	}
+	if h.Latency <= 0 || h.CheckedAt.IsZero() {
+		t.Errorf("Latency = %v, CheckedAt = %v", h.Latency, h.CheckedAt)
+	}
+	if h.CharactersRemaining() != 1000 || h.QuotaUsed() != 0.9 || h.Subscription.Tier != "creator" {
+		t.Errorf("quota = %d remaining, %v used", h.CharactersRemaining(), h.QuotaUsed())
+	}
+}
+
+func TestClientPingUnauthorized(t *testing.T) {
+	client := newUserInfoServer(t, http.StatusUnauthorized)
+
+	if err := client.Ping(context.Background()); err == nil {
+		t.Error("Ping() with an invalid key: expected error")
+	}
+}
+
+func TestClientHealthHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		minCharacters int
+		wantCode      int
+		wantStatus    string
+	}{
+		{"ok", http.StatusOK, 0, http.StatusOK, "ok"},
+		{"quota low", http.StatusOK, 5000, http.StatusServiceUnavailable, "quota_low"},
+		{"unavailable", http.StatusUnauthorized, 0, http.StatusServiceUnavailable, "unavailable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newUserInfoServer(t, tt.status)
+			rec := httptest.NewRecorder()
+			client.HealthHandler(tt.minCharacters).ServeHTTP(rec, httptest.NewRequest("GET", "/readyz", nil))
+
+			var body healthResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if rec.Code != tt.wantCode || body.Status != tt.wantStatus {
+				t.Errorf("code = %d, body = %s", rec.Code, rec.Body)
+			}
+			if tt.status == http.StatusOK && body.CharactersRemaining != 1000 {
+				t.Errorf("characters_remaining = %d", body.CharactersRemaining)
+			}
+		})
+	}
+}
@@ -2,7 +2,10 @@ package elevenlabs
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"net/url"
 	"time"
 
 	"github.com/agentplexus/go-elevenlabs/internal/api"
@@ -47,6 +50,21 @@ type HistoryItem struct {
 
 	// CreatedAt is when the item was created.
 	CreatedAt time.Time
+
+	// RequestID is the ID of the generation request. Only set by Get.
+	RequestID string
+
+	// VoiceSettings are the voice settings the item was generated with,
+	// or nil if they were not recorded. Only set by Get.
+	VoiceSettings *VoiceSettings
+
+	// Settings are all generation settings as recorded by the API. Only
+	// set by Get.
+	Settings map[string]any
+
+	// Dialogue holds the turns of an item generated with text to
+	// dialogue, in which case Text and VoiceID are empty. Only set by Get.
+	Dialogue []DialogueInput
 }
 
 // HistoryListResponse contains the list of history items and pagination info.
@@ -91,7 +109,7 @@ func (s *HistoryService) List(ctx context.Context, opts *HistoryListOptions) (*H
 
 	resp, err := s.client.apiClient.GetSpeechHistory(ctx, params)
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	// Handle response type
@@ -138,58 +156,154 @@ func (s *HistoryService) List(ctx context.Context, opts *HistoryListOptions) (*H
 		}
 
 		return result, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
-// Get returns a specific history item by ID.
+// Get returns a specific history item by ID, with the settings it was
+// generated with.
 func (s *HistoryService) Get(ctx context.Context, historyItemID string) (*HistoryItem, error) {
 	if historyItemID == "" {
 		return nil, &ValidationError{Field: "history_item_id", Message: "cannot be empty"}
 	}
 
-	resp, err := s.client.apiClient.GetSpeechHistoryItemByID(ctx, api.GetSpeechHistoryItemByIDParams{
-		HistoryItemID: historyItemID,
-	})
-	if err != nil {
+	// The generated client drops the item settings, so decode them here.
+	var r historyItemJSON
+	if err := s.client.doJSON(ctx, "GET", "/v1/history/"+url.PathEscape(historyItemID), nil, &r); err != nil {
 		return nil, err
 	}
+	return r.item(), nil
+}
 
-	// Handle response type
-	switch r := resp.(type) {
-	case *api.SpeechHistoryItemResponseModel:
-		item := &HistoryItem{
-			HistoryItemID:  r.HistoryItemID,
-			State:          string(r.State),
-			ContentType:    r.ContentType,
-			CharactersUsed: r.CharacterCountChangeTo - r.CharacterCountChangeFrom,
-			CreatedAt:      time.Unix(int64(r.DateUnix), 0),
-		}
+// historyItemJSON is the API form of a history item.
+type historyItemJSON struct {
+	HistoryItemID            string            `json:"history_item_id"`
+	RequestID                string            `json:"request_id"`
+	VoiceID                  string            `json:"voice_id"`
+	VoiceName                string            `json:"voice_name"`
+	VoiceCategory            string            `json:"voice_category"`
+	ModelID                  string            `json:"model_id"`
+	Text                     string            `json:"text"`
+	State                    string            `json:"state"`
+	Source                   string            `json:"source"`
+	ContentType              string            `json:"content_type"`
+	CharacterCountChangeFrom int               `json:"character_count_change_from"`
+	CharacterCountChangeTo   int               `json:"character_count_change_to"`
+	DateUnix                 int64             `json:"date_unix"`
+	Settings                 map[string]any    `json:"settings"`
+	Dialogue                 []json.RawMessage `json:"dialogue"`
+}
 
-		if r.VoiceID.Set && !r.VoiceID.Null {
-			item.VoiceID = r.VoiceID.Value
-		}
-		if r.VoiceName.Set && !r.VoiceName.Null {
-			item.VoiceName = r.VoiceName.Value
-		}
-		if r.VoiceCategory.Set && !r.VoiceCategory.Null {
-			item.VoiceCategory = string(r.VoiceCategory.Value)
-		}
-		if r.ModelID.Set && !r.ModelID.Null {
-			item.ModelID = r.ModelID.Value
-		}
-		if r.Text.Set && !r.Text.Null {
-			item.Text = r.Text.Value
+func (r *historyItemJSON) item() *HistoryItem {
+	item := &HistoryItem{
+		HistoryItemID:  r.HistoryItemID,
+		RequestID:      r.RequestID,
+		VoiceID:        r.VoiceID,
+		VoiceName:      r.VoiceName,
+		VoiceCategory:  r.VoiceCategory,
+		ModelID:        r.ModelID,
+		Text:           r.Text,
+		State:          r.State,
+		Source:         r.Source,
+		ContentType:    r.ContentType,
+		CharactersUsed: r.CharacterCountChangeTo - r.CharacterCountChangeFrom,
+		CreatedAt:      time.Unix(r.DateUnix, 0),
+		Settings:       r.Settings,
+		VoiceSettings:  voiceSettingsFromHistory(r.Settings),
+	}
+	for _, raw := range r.Dialogue {
+		var turn struct {
+			Text    string `json:"text"`
+			VoiceID string `json:"voice_id"`
 		}
-		if r.Source.Set && !r.Source.Null {
-			item.Source = string(r.Source.Value)
+		if json.Unmarshal(raw, &turn) == nil {
+			item.Dialogue = append(item.Dialogue, DialogueInput{Text: turn.Text, VoiceID: turn.VoiceID})
 		}
+	}
+	return item
+}
 
-		return item, nil
-	default:
-		return nil, &APIError{Message: "unexpected response type"}
+// voiceSettingsFromHistory returns the voice settings stored in history
+// item settings, either at the top level or under voice_settings, or nil
+// if there are none.
+func voiceSettingsFromHistory(settings map[string]any) *VoiceSettings {
+	if nested, ok := settings["voice_settings"].(map[string]any); ok {
+		settings = nested
+	}
+	number := func(key string) (float64, bool) {
+		v, ok := settings[key].(float64)
+		return v, ok
+	}
+
+	vs := &VoiceSettings{}
+	found := false
+	if v, ok := number("stability"); ok {
+		vs.Stability, found = v, true
+	}
+	if v, ok := number("similarity_boost"); ok {
+		vs.SimilarityBoost, found = v, true
+	}
+	if v, ok := number("style"); ok {
+		vs.Style, found = v, true
+	}
+	if v, ok := number("speed"); ok {
+		vs.Speed, found = v, true
+	}
+	if v, ok := settings["use_speaker_boost"].(bool); ok {
+		vs.UseSpeakerBoost, found = v, true
+	}
+	if !found {
+		return nil
 	}
+	return vs
+}
+
+// Regenerate generates speech again with the voice, model, text, and
+// voice settings of a history item, for example to re-render audio after
+// a voice was updated. Non-zero fields of overrides replace the stored
+// values; VoiceSettings replaces the stored settings as a whole. overrides
+// may be nil.
+//
+// Dialogue items and items from other sources than text to speech cannot
+// be regenerated.
+func (s *HistoryService) Regenerate(ctx context.Context, historyItemID string, overrides *TTSRequest) (*TTSResponse, error) {
+	item, err := s.Get(ctx, historyItemID)
+	if err != nil {
+		return nil, err
+	}
+	if len(item.Dialogue) > 0 || (item.Source != "" && item.Source != "TTS") {
+		return nil, &ValidationError{Field: "history_item_id", Message: fmt.Sprintf("cannot regenerate a %s item", item.kind())}
+	}
+
+	req := &TTSRequest{}
+	if overrides != nil {
+		copied := *overrides
+		req = &copied
+	}
+	if req.VoiceID == "" {
+		req.VoiceID = item.VoiceID
+	}
+	if req.Text == "" {
+		req.Text = item.Text
+	}
+	if req.ModelID == "" {
+		req.ModelID = item.ModelID
+	}
+	if req.VoiceSettings == nil {
+		req.VoiceSettings = item.VoiceSettings
+	}
+	return s.client.TextToSpeech().Generate(ctx, req)
+}
+
+// kind describes the item for errors.
+func (h *HistoryItem) kind() string {
+	if len(h.Dialogue) > 0 {
+		return "dialogue"
+	}
+	return h.Source
 }
 
 // GetAudio returns the audio for a history item.
@@ -202,15 +316,17 @@ func (s *HistoryService) GetAudio(ctx context.Context, historyItemID string) (io
 		HistoryItemID: historyItemID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	// Handle response type
 	switch r := resp.(type) {
 	case *api.GetAudioFullFromSpeechHistoryItemOK:
 		return r.Data, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -220,8 +336,7 @@ func (s *HistoryService) Delete(ctx context.Context, historyItemID string) error
 		return &ValidationError{Field: "history_item_id", Message: "cannot be empty"}
 	}
 
-	_, err := s.client.apiClient.DeleteSpeechHistoryItem(ctx, api.DeleteSpeechHistoryItemParams{
+	return checkResponse(s.client.apiClient.DeleteSpeechHistoryItem(ctx, api.DeleteSpeechHistoryItemParams{
 		HistoryItemID: historyItemID,
-	})
-	return err
+	}))
 }
@@ -2,6 +2,11 @@ package elevenlabs
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -69,3 +74,109 @@ func TestHistoryGetValidation(t *testing.T) {
 		t.Error("Delete('') should return error")
 	}
 }
+
+const historyItemResponse = `{
+	"history_item_id": "item-1", "request_id": "req-1", "voice_id": "voice-1", "voice_name": "Rachel",
+	"voice_category": "premade", "model_id": "eleven_multilingual_v2", "text": "Hello there",
+	"state": "created", "source": "TTS", "content_type": "audio/mpeg",
+	"character_count_change_from": 100, "character_count_change_to": 111, "date_unix": 1700000000,
+	"settings": {"stability": 0.4, "similarity_boost": 0.8, "style": 0.1, "use_speaker_boost": true, "speed": 1.1}
+}`
+
+func TestHistoryGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/history/item-1" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, historyItemResponse)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	item, err := client.History().Get(context.Background(), "item-1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if item.VoiceID != "voice-1" || item.ModelID != "eleven_multilingual_v2" || item.Text != "Hello there" ||
+		item.RequestID != "req-1" || item.CharactersUsed != 11 || item.CreatedAt.Unix() != 1700000000 {
+		t.Errorf("item = %+v", item)
+	}
+	want := VoiceSettings{Stability: 0.4, SimilarityBoost: 0.8, Style: 0.1, Speed: 1.1, UseSpeakerBoost: true}
+	if item.VoiceSettings == nil || *item.VoiceSettings != want {
+		t.Errorf("VoiceSettings = %+v, want %+v", item.VoiceSettings, want)
+	}
+	if item.Settings["speed"] != 1.1 {
+		t.Errorf("Settings = %v", item.Settings)
+	}
+}
+
+func TestVoiceSettingsFromHistory(t *testing.T) {
+	if vs := voiceSettingsFromHistory(nil); vs != nil {
+		t.Errorf("no settings = %+v, want nil", vs)
+	}
+	nested := map[string]any{"voice_settings": map[string]any{"stability": 0.3}}
+	if vs := voiceSettingsFromHistory(nested); vs == nil || vs.Stability != 0.3 {
+		t.Errorf("nested settings = %+v", vs)
+	}
+}
+
+func TestHistoryRegenerate(t *testing.T) {
+	var ttsBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/history/item-1":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, historyItemResponse)
+		case "/v1/text-to-speech/voice-1":
+			data, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(data, &ttsBody); err != nil {
+				t.Errorf("Unmarshal() error = %v", err)
+			}
+			w.Header().Set("Content-Type", "audio/mpeg")
+			w.Write([]byte("audio"))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	resp, err := client.History().Regenerate(context.Background(), "item-1", &TTSRequest{Text: "Hello again"})
+	if err != nil {
+		t.Fatalf("Regenerate() error = %v", err)
+	}
+	if data, _ := io.ReadAll(resp.Audio); string(data) != "audio" {
+		t.Errorf("audio = %q", data)
+	}
+	if ttsBody["text"] != "Hello again" || ttsBody["model_id"] != "eleven_multilingual_v2" {
+		t.Errorf("request = %v, want the override text and stored model", ttsBody)
+	}
+	if settings, _ := ttsBody["voice_settings"].(map[string]any); settings["stability"] != 0.4 {
+		t.Errorf("voice_settings = %v, want the stored settings", ttsBody["voice_settings"])
+	}
+}
+
+func TestHistoryRegenerateDialogue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"history_item_id": "item-2", "source": "TTS", "dialogue": [
+			{"text": "Hi", "voice_id": "voice-1"}, {"text": "Hello", "voice_id": "voice-2"}]}`)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	item, err := client.History().Get(context.Background(), "item-2")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(item.Dialogue) != 2 || item.Dialogue[1] != (DialogueInput{Text: "Hello", VoiceID: "voice-2"}) {
+		t.Errorf("Dialogue = %+v", item.Dialogue)
+	}
+
+	var valErr *ValidationError
+	if _, err := client.History().Regenerate(context.Background(), "item-2", nil); !isValidationError(err, &valErr) {
+		t.Errorf("Regenerate() error = %v, want ValidationError", err)
+	}
+}
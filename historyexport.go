@@ -0,0 +1,242 @@
+package elevenlabs
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HistoryManifestFile is the name of the manifest written by
+// HistoryService.Export.
+const HistoryManifestFile = "manifest.jsonl"
+
+// HistoryExportOptions selects the history items to export.
+type HistoryExportOptions struct {
+	// ItemIDs are the items to export. If empty, the history is listed
+	// and items are selected with VoiceID, Filter, and Limit.
+	ItemIDs []string
+
+	// VoiceID exports only items generated with this voice.
+	VoiceID string
+
+	// Filter exports only items for which it returns true, for example
+	// to select a model or a date range.
+	Filter func(*HistoryItem) bool
+
+	// Limit is the maximum number of items selected, including items
+	// already in the manifest. Zero means no limit.
+	Limit int
+}
+
+// HistoryExportResult summarizes an export.
+type HistoryExportResult struct {
+	// Exported is the number of items written.
+	Exported int
+
+	// Skipped is the number of selected items already in the manifest.
+	Skipped int
+}
+
+// HistoryManifestEntry is a line of the export manifest.
+type HistoryManifestEntry struct {
+	// HistoryItemID is the ID of the history item.
+	HistoryItemID string `json:"history_item_id"`
+
+	// Audio is the path of the audio file, relative to the export
+	// directory.
+	Audio string `json:"audio"`
+
+	// Text is the text that was converted to speech.
+	Text string `json:"text"`
+
+	// VoiceID is the ID of the voice used.
+	VoiceID string `json:"voice_id,omitempty"`
+
+	// VoiceName is the name of the voice used.
+	VoiceName string `json:"voice_name,omitempty"`
+
+	// ModelID is the ID of the model used.
+	ModelID string `json:"model_id,omitempty"`
+
+	// ContentType is the content type of the audio.
+	ContentType string `json:"content_type,omitempty"`
+
+	// CreatedAt is when the item was generated.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Export writes history items to dir as a dataset: one audio file per
+// item under audio/, and a line per item in manifest.jsonl holding the
+// text and generation metadata. Use it to build evaluation sets from
+// production output:
+//
+//	result, err := client.History().Export(ctx, "eval-set", &elevenlabs.HistoryExportOptions{
+//	    VoiceID: voiceID,
+//	    Limit:   200,
+//	})
+//
+// Exports are incremental: items already in the manifest are skipped, so
+// an interrupted export can be run again to finish it.
+func (s *HistoryService) Export(ctx context.Context, dir string, opts *HistoryExportOptions) (*HistoryExportResult, error) {
+	if dir == "" {
+		return nil, &ValidationError{Field: "dir", Message: "cannot be empty"}
+	}
+	if opts == nil {
+		opts = &HistoryExportOptions{}
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "audio"), 0o755); err != nil {
+		return nil, fmt.Errorf("creating export directory: %w", err)
+	}
+
+	manifestPath := filepath.Join(dir, HistoryManifestFile)
+	exported, err := readManifestIDs(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := os.OpenFile(manifestPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("opening manifest: %w", err)
+	}
+	defer manifest.Close()
+
+	result := &HistoryExportResult{}
+	err = s.selectItems(ctx, opts, func(item *HistoryItem) (bool, error) {
+		if exported[item.HistoryItemID] {
+			result.Skipped++
+			return true, nil
+		}
+		if err := s.exportItem(ctx, dir, item, manifest); err != nil {
+			return false, fmt.Errorf("exporting %s: %w", item.HistoryItemID, err)
+		}
+		exported[item.HistoryItemID] = true
+		result.Exported++
+		return opts.Limit == 0 || result.Exported+result.Skipped < opts.Limit, nil
+	})
+	return result, err
+}
+
+// selectItems calls fn for each selected item until fn returns false.
+func (s *HistoryService) selectItems(ctx context.Context, opts *HistoryExportOptions, fn func(*HistoryItem) (bool, error)) error {
+	if len(opts.ItemIDs) > 0 {
+		for _, id := range opts.ItemIDs {
+			item, err := s.Get(ctx, id)
+			if err != nil {
+				return err
+			}
+			if more, err := fn(item); err != nil || !more {
+				return err
+			}
+		}
+		return nil
+	}
+
+	list := &HistoryListOptions{PageSize: 100, VoiceID: opts.VoiceID}
+	for {
+		page, err := s.List(ctx, list)
+		if err != nil {
+			return err
+		}
+		for _, item := range page.Items {
+			if item.State != "created" || (opts.Filter != nil && !opts.Filter(item)) {
+				continue
+			}
+			if more, err := fn(item); err != nil || !more {
+				return err
+			}
+		}
+		if !page.HasMore || page.LastHistoryItemID == "" {
+			return nil
+		}
+		list.StartAfterHistoryItemID = page.LastHistoryItemID
+	}
+}
+
+// exportItem writes an item's audio and appends it to the manifest. The
+// audio is written first, so the manifest only lists complete files.
+func (s *HistoryService) exportItem(ctx context.Context, dir string, item *HistoryItem, manifest io.Writer) error {
+	audio, err := s.GetAudio(ctx, item.HistoryItemID)
+	if err != nil {
+		return err
+	}
+	rel := "audio/" + item.HistoryItemID + audioExtension(item.ContentType)
+	f, err := os.Create(filepath.Join(dir, filepath.FromSlash(rel)))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, audio); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(&HistoryManifestEntry{
+		HistoryItemID: item.HistoryItemID,
+		Audio:         rel,
+		Text:          item.Text,
+		VoiceID:       item.VoiceID,
+		VoiceName:     item.VoiceName,
+		ModelID:       item.ModelID,
+		ContentType:   item.ContentType,
+		CreatedAt:     item.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = manifest.Write(append(line, '\n'))
+	return err
+}
+
+// readManifestIDs returns the IDs of the items in a manifest, if it exists.
+func readManifestIDs(path string) (map[string]bool, error) {
+	ids := make(map[string]bool)
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ids, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry HistoryManifestEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("reading manifest: %w", err)
+		}
+		ids[entry.HistoryItemID] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	return ids, nil
+}
+
+// audioExtension returns the file extension for an audio content type.
+func audioExtension(contentType string) string {
+	switch contentType {
+	case "audio/wav", "audio/x-wav", "audio/wave":
+		return ".wav"
+	case "audio/ogg", "audio/opus":
+		return ".ogg"
+	case "audio/pcm", "audio/L16":
+		return ".pcm"
+	case "audio/basic", "audio/x-mulaw":
+		return ".ulaw"
+	default:
+		return ".mp3"
+	}
+}
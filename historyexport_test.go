@@ -0,0 +1,166 @@
+package elevenlabs
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newHistoryExportServer serves a history of items in two pages, with one
+// item still processing. It records the IDs whose audio was downloaded.
+func newHistoryExportServer(t *testing.T) (*Client, func() []string) {
+	t.Helper()
+	pages := map[string]string{
+		"": `{"has_more": true, "last_history_item_id": "h2", "history": [
+			{"history_item_id": "h1", "date_unix": 1700000000, "character_count_change_from": 0, "character_count_change_to": 5, "content_type": "audio/mpeg", "state": "created", "text": "Hello", "voice_id": "v1", "voice_name": "Rachel", "model_id": "eleven_multilingual_v2"},
+			{"history_item_id": "h2", "date_unix": 1700000100, "character_count_change_from": 0, "character_count_change_to": 6, "content_type": "audio/mpeg", "state": "processing", "text": "Skipped", "voice_id": "v1"}
+		]}`,
+		"h2": `{"has_more": false, "last_history_item_id": "h3", "history": [
+			{"history_item_id": "h3", "date_unix": 1700000200, "character_count_change_from": 0, "character_count_change_to": 5, "content_type": "audio/wav", "state": "created", "text": "World", "voice_id": "v2", "model_id": "eleven_flash_v2_5"}
+		]}`,
+	}
+
+	var mu sync.Mutex
+	var downloads []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/history":
+			page, ok := pages[r.URL.Query().Get("start_after_history_item_id")]
+			if !ok {
+				t.Errorf("unexpected page %s", r.URL.RawQuery)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, page)
+		case strings.HasSuffix(r.URL.Path, "/audio"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/history/"), "/audio")
+			mu.Lock()
+			downloads = append(downloads, id)
+			mu.Unlock()
+			w.Header().Set("Content-Type", "audio/mpeg")
+			fmt.Fprint(w, "audio-"+id)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), downloads...)
+	}
+}
+
+func readTestManifest(t *testing.T, dir string) []HistoryManifestEntry {
+	t.Helper()
+	f, err := os.Open(filepath.Join(dir, HistoryManifestFile))
+	if err != nil {
+		t.Fatalf("opening manifest: %v", err)
+	}
+	defer f.Close()
+
+	var entries []HistoryManifestEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry HistoryManifestEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("decoding manifest line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestHistoryExport(t *testing.T) {
+	client, downloads := newHistoryExportServer(t)
+	dir := t.TempDir()
+
+	result, err := client.History().Export(context.Background(), dir, nil)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if result.Exported != 2 || result.Skipped != 0 {
+		t.Errorf("result = %+v, want 2 exported", result)
+	}
+
+	entries := readTestManifest(t, dir)
+	if len(entries) != 2 {
+		t.Fatalf("manifest has %d entries, want 2", len(entries))
+	}
+	first := entries[0]
+	if first.HistoryItemID != "h1" || first.Audio != "audio/h1.mp3" || first.Text != "Hello" ||
+		first.VoiceName != "Rachel" || first.ModelID != "eleven_multilingual_v2" || first.CreatedAt.Unix() != 1700000000 {
+		t.Errorf("entries[0] = %+v", first)
+	}
+	if entries[1].Audio != "audio/h3.wav" {
+		t.Errorf("entries[1].Audio = %q, want audio/h3.wav", entries[1].Audio)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "audio", "h3.wav"))
+	if err != nil {
+		t.Fatalf("reading audio: %v", err)
+	}
+	if string(data) != "audio-h3" {
+		t.Errorf("audio = %q, want audio-h3", data)
+	}
+
+	// A second export only downloads new items
+	result, err = client.History().Export(context.Background(), dir, nil)
+	if err != nil {
+		t.Fatalf("second Export() error = %v", err)
+	}
+	if result.Exported != 0 || result.Skipped != 2 {
+		t.Errorf("second result = %+v, want 2 skipped", result)
+	}
+	if got := downloads(); len(got) != 2 {
+		t.Errorf("downloads = %v, want h1 and h3 once", got)
+	}
+	if got := len(readTestManifest(t, dir)); got != 2 {
+		t.Errorf("manifest has %d entries after rerun, want 2", got)
+	}
+}
+
+func TestHistoryExportFilterAndLimit(t *testing.T) {
+	client, downloads := newHistoryExportServer(t)
+	dir := t.TempDir()
+
+	result, err := client.History().Export(context.Background(), dir, &HistoryExportOptions{
+		Filter: func(item *HistoryItem) bool { return item.ModelID == "eleven_flash_v2_5" },
+	})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if result.Exported != 1 || downloads()[0] != "h3" {
+		t.Errorf("result = %+v, downloads = %v, want only h3", result, downloads())
+	}
+
+	dir = t.TempDir()
+	if _, err := client.History().Export(context.Background(), dir, &HistoryExportOptions{Limit: 1}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	entries := readTestManifest(t, dir)
+	if len(entries) != 1 || entries[0].HistoryItemID != "h1" {
+		t.Errorf("manifest = %+v, want only h1", entries)
+	}
+}
+
+func TestHistoryExportValidation(t *testing.T) {
+	client, _ := NewClient()
+
+	if _, err := client.History().Export(context.Background(), "", nil); err == nil {
+		t.Error("Export('') should return error")
+	}
+}
@@ -0,0 +1,192 @@
+// Package scriptcmd contains the parts of generating audio from ttsscript
+// scripts that are shared by the ttsscript and elevenlabs commands.
+package scriptcmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	elevenlabs "github.com/agentplexus/go-elevenlabs"
+	"github.com/agentplexus/go-elevenlabs/ttsscript"
+)
+
+// VoiceSettings applies script overrides on top of the SDK default settings.
+func VoiceSettings(overrides *ttsscript.VoiceSettings) *elevenlabs.VoiceSettings {
+	vs := elevenlabs.DefaultVoiceSettings()
+	if overrides == nil {
+		return vs
+	}
+	if overrides.Stability != nil {
+		vs.Stability = *overrides.Stability
+	}
+	if overrides.SimilarityBoost != nil {
+		vs.SimilarityBoost = *overrides.SimilarityBoost
+	}
+	if overrides.Style != nil {
+		vs.Style = *overrides.Style
+	}
+	if overrides.Speed != nil {
+		vs.Speed = *overrides.Speed
+	}
+	if overrides.UseSpeakerBoost != nil {
+		vs.UseSpeakerBoost = *overrides.UseSpeakerBoost
+	}
+	return vs
+}
+
+// WriteAudioAsset writes the pre-recorded audio of an audio segment to
+// outputFile and returns its duration in milliseconds. Asset files are
+// relative to the script. The duration is measured from the audio (see
+// AudioDurationMs), falling back to an estimate for MP3 at 128 kbps.
+func WriteAudioAsset(ctx context.Context, client *elevenlabs.Client, asset *ttsscript.AudioAsset, scriptPath, outputFile string) (int, error) {
+	var audio io.Reader
+	if asset.HistoryItemID != "" {
+		r, err := client.History().GetAudio(ctx, asset.HistoryItemID)
+		if err != nil {
+			return 0, fmt.Errorf("downloading history item %s: %w", asset.HistoryItemID, err)
+		}
+		audio = r
+	} else {
+		f, err := os.Open(asset.ResolveFile(scriptPath))
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+		audio = f
+	}
+
+	// Write atomically so an interrupted run never leaves a truncated file
+	saved, err := elevenlabs.SaveAudio(outputFile, audio)
+	if err != nil {
+		return 0, err
+	}
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		return 0, err
+	}
+	if ms, err := AudioDurationMs(data); err == nil {
+		return ms, nil
+	}
+	return ttsscript.EstimateDurationMs(saved.Size, ""), nil
+}
+
+// AudioSource describes where an audio asset comes from.
+func AudioSource(asset *ttsscript.AudioAsset) string {
+	if asset.HistoryItemID != "" {
+		return "history item " + asset.HistoryItemID
+	}
+	return asset.File
+}
+
+// errUnknownAudio is returned by AudioDurationMs for audio it cannot read.
+var errUnknownAudio = errors.New("unrecognized audio format")
+
+// AudioDurationMs returns the duration of WAV or MP3 audio. WAV durations
+// are exact. MP3 durations are estimated from the bitrate of the first
+// frame, so they assume constant bitrate, like the generated audio.
+func AudioDurationMs(data []byte) (int, error) {
+	if len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WAVE" {
+		return wavDurationMs(data)
+	}
+	return mp3DurationMs(data)
+}
+
+// wavDurationMs returns the duration of WAV audio from its fmt and data
+// chunks.
+func wavDurationMs(data []byte) (int, error) {
+	var byteRate, dataSize uint32
+	for pos := 12; pos+8 <= len(data); {
+		id := string(data[pos : pos+4])
+		size := binary.LittleEndian.Uint32(data[pos+4 : pos+8])
+		body := pos + 8
+		switch id {
+		case "fmt ":
+			if body+12 > len(data) {
+				return 0, errUnknownAudio
+			}
+			byteRate = binary.LittleEndian.Uint32(data[body+8 : body+12])
+		case "data":
+			dataSize = size
+			// Streamed WAV files may not know the data size
+			if remaining := uint32(len(data) - body); dataSize == 0 || dataSize > remaining {
+				dataSize = remaining
+			}
+		}
+		if id == "data" {
+			break
+		}
+		// Chunks are padded to an even size
+		pos = body + int(size) + int(size&1)
+	}
+	if byteRate == 0 {
+		return 0, errUnknownAudio
+	}
+	return int(uint64(dataSize) * 1000 / uint64(byteRate)), nil
+}
+
+// mp3Bitrates are the MPEG audio bitrates in kbps by version group and
+// bitrate index, for Layer III.
+var mp3Bitrates = [2][16]int{
+	// MPEG-1
+	{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},
+	// MPEG-2 and 2.5
+	{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+}
+
+// mp3DurationMs estimates the duration of MP3 audio from the bitrate of
+// its first frame, skipping an ID3v2 tag.
+func mp3DurationMs(data []byte) (int, error) {
+	start := 0
+	if len(data) >= 10 && bytes.Equal(data[:3], []byte("ID3")) {
+		// The tag size is a 28-bit synchsafe integer
+		size := int(data[6]&0x7f)<<21 | int(data[7]&0x7f)<<14 | int(data[8]&0x7f)<<7 | int(data[9]&0x7f)
+		start = 10 + size
+	}
+	for i := start; i+4 <= len(data); i++ {
+		if data[i] != 0xff || data[i+1]&0xe0 != 0xe0 {
+			continue
+		}
+		version := (data[i+1] >> 3) & 0x03 // 3 is MPEG-1, 1 is reserved
+		layer := (data[i+1] >> 1) & 0x03   // 1 is Layer III
+		index := data[i+2] >> 4
+		if version == 1 || layer != 1 {
+			continue
+		}
+		group := 1
+		if version == 3 {
+			group = 0
+		}
+		kbps := mp3Bitrates[group][index]
+		if kbps == 0 {
+			continue
+		}
+		// kbps is bits per millisecond
+		return (len(data) - i) * 8 / kbps, nil
+	}
+	return 0, errUnknownAudio
+}
+
+// ModelLimit checks that modelID supports language, using the model list
+// from Models().List, and returns the model's per-request character limit.
+// Models missing from the list, or without a reported limit, use
+// ttsscript.CharacterLimit; the TTS request rejects unknown models.
+func ModelLimit(models []*elevenlabs.Model, modelID, language string) (int, error) {
+	for _, m := range models {
+		if m.ModelID != modelID {
+			continue
+		}
+		if !m.SupportsLanguage(language) {
+			return 0, fmt.Errorf("language %s is not supported by model %s", language, modelID)
+		}
+		if m.MaxTextLength > 0 {
+			return m.MaxTextLength, nil
+		}
+		break
+	}
+	return ttsscript.CharacterLimit(modelID), nil
+}
@@ -0,0 +1,117 @@
+package scriptcmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	elevenlabs "github.com/agentplexus/go-elevenlabs"
+	"github.com/agentplexus/go-elevenlabs/ttsscript"
+)
+
+// mp3Frames returns a fake MP3 stream of n bytes whose first frame header
+// is MPEG-1 Layer III at the given bitrate index.
+func mp3Frames(n int, bitrateIndex byte) []byte {
+	data := make([]byte, n)
+	copy(data, []byte{0xff, 0xfb, bitrateIndex<<4 | 0x04, 0xc4})
+	return data
+}
+
+func TestAudioDurationMs(t *testing.T) {
+	wav, err := elevenlabs.PCMBytesToWAV(make([]byte, 48000), 24000) // 1s of 16-bit mono
+	if err != nil {
+		t.Fatal(err)
+	}
+	id3 := append([]byte{'I', 'D', '3', 4, 0, 0, 0, 0, 1, 0}, make([]byte, 128)...)
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"wav", wav, 1000},
+		{"mp3 128kbps", mp3Frames(32000, 9), 2000},
+		{"mp3 64kbps", mp3Frames(32000, 5), 4000},
+		{"mp3 with id3 tag", append(id3, mp3Frames(16000, 9)...), 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AudioDurationMs(tt.data)
+			if err != nil {
+				t.Fatalf("AudioDurationMs() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AudioDurationMs() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := AudioDurationMs([]byte("not audio")); err == nil {
+		t.Error("AudioDurationMs() error = nil for unknown data")
+	}
+}
+
+func TestWriteAudioAsset(t *testing.T) {
+	dir := t.TempDir()
+	wav, err := elevenlabs.PCMBytesToWAV(make([]byte, 24000), 24000) // 500ms
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "intro.wav"), wav, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(dir, "out.wav")
+	asset := &ttsscript.AudioAsset{File: "intro.wav"}
+	got, err := WriteAudioAsset(context.Background(), nil, asset, filepath.Join(dir, "script.json"), out)
+	if err != nil {
+		t.Fatalf("WriteAudioAsset() error = %v", err)
+	}
+	if got != 500 {
+		t.Errorf("WriteAudioAsset() = %d, want 500", got)
+	}
+	written, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(written, wav) {
+		t.Error("written audio does not match the asset")
+	}
+}
+
+func TestVoiceSettings(t *testing.T) {
+	if got := VoiceSettings(nil); *got != *elevenlabs.DefaultVoiceSettings() {
+		t.Errorf("VoiceSettings(nil) = %+v, want defaults", got)
+	}
+
+	speed := 1.2
+	got := VoiceSettings(&ttsscript.VoiceSettings{Speed: &speed})
+	if got.Speed != 1.2 {
+		t.Errorf("Speed = %v, want 1.2", got.Speed)
+	}
+	if got.Stability != elevenlabs.DefaultVoiceSettings().Stability {
+		t.Errorf("Stability = %v, want default", got.Stability)
+	}
+}
+
+func TestModelLimit(t *testing.T) {
+	models := []*elevenlabs.Model{
+		{ModelID: "english", MaxTextLength: 5000, Languages: []*elevenlabs.Language{{LanguageID: "en"}}},
+		{ModelID: "unlimited", Languages: []*elevenlabs.Language{{LanguageID: "en"}}},
+	}
+
+	if got, err := ModelLimit(models, "english", "en-US"); err != nil || got != 5000 {
+		t.Errorf("ModelLimit(english) = %d, %v; want 5000", got, err)
+	}
+	if _, err := ModelLimit(models, "english", "de"); err == nil {
+		t.Error("ModelLimit() error = nil for an unsupported language")
+	}
+	if got, err := ModelLimit(models, "unlimited", "en"); err != nil || got != ttsscript.CharacterLimit("unlimited") {
+		t.Errorf("ModelLimit(unlimited) = %d, %v; want the built-in limit", got, err)
+	}
+	if got, err := ModelLimit(models, "eleven_v3", "de"); err != nil || got != ttsscript.CharacterLimit("eleven_v3") {
+		t.Errorf("ModelLimit(unknown) = %d, %v; want the built-in limit", got, err)
+	}
+}
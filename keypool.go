@@ -0,0 +1,272 @@
+package elevenlabs
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrNoAvailableKey is returned when every key in a KeyPool is disabled or
+// cooling down.
+var ErrNoAvailableKey = errors.New("elevenlabs: no API key available in key pool")
+
+// KeyPoolStrategy selects which key a KeyPool uses for a request.
+type KeyPoolStrategy int
+
+const (
+	// KeyPoolRoundRobin spreads requests evenly across healthy keys.
+	KeyPoolRoundRobin KeyPoolStrategy = iota
+
+	// KeyPoolPriority uses the first healthy key in the order given, so
+	// later keys are only used as fallbacks.
+	KeyPoolPriority
+)
+
+// DefaultKeyCooldown is how long a rate-limited key is skipped when the
+// response has no Retry-After header.
+const DefaultKeyCooldown = 30 * time.Second
+
+// KeyPool spreads requests across several API keys, for example to
+// isolate workloads or to combine the concurrency limits of several
+// accounts. Keys that are rate limited (429) cool down before being used
+// again; keys that are rejected (401) are disabled until Reset. Failed
+// requests are retried with the next available key.
+//
+// Use it with WithKeyPool:
+//
+//	pool, err := elevenlabs.NewKeyPool([]string{keyA, keyB})
+//	client, err := elevenlabs.NewClient(elevenlabs.WithKeyPool(pool))
+type KeyPool struct {
+	mu       sync.Mutex
+	keys     []*pooledKey
+	strategy KeyPoolStrategy
+	cooldown time.Duration
+	next     int
+}
+
+type pooledKey struct {
+	key       string
+	requests  int64
+	failures  int64
+	disabled  bool
+	coolUntil time.Time
+}
+
+// KeyPoolOption configures a KeyPool.
+type KeyPoolOption func(*KeyPool)
+
+// WithKeyPoolStrategy sets how keys are selected. The default is
+// KeyPoolRoundRobin.
+func WithKeyPoolStrategy(strategy KeyPoolStrategy) KeyPoolOption {
+	return func(p *KeyPool) {
+		p.strategy = strategy
+	}
+}
+
+// WithKeyCooldown sets how long a rate-limited key is skipped when the
+// response has no Retry-After header.
+func WithKeyCooldown(cooldown time.Duration) KeyPoolOption {
+	return func(p *KeyPool) {
+		p.cooldown = cooldown
+	}
+}
+
+// NewKeyPool creates a pool of API keys.
+func NewKeyPool(keys []string, opts ...KeyPoolOption) (*KeyPool, error) {
+	if len(keys) == 0 {
+		return nil, &ValidationError{Field: "keys", Message: "at least one API key is required"}
+	}
+	p := &KeyPool{cooldown: DefaultKeyCooldown}
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key == "" {
+			return nil, &ValidationError{Field: "keys", Message: "API key cannot be empty"}
+		}
+		if seen[key] {
+			return nil, &ValidationError{Field: "keys", Message: "duplicate API key"}
+		}
+		seen[key] = true
+		p.keys = append(p.keys, &pooledKey{key: key})
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p, nil
+}
+
+// WithKeyPool sends requests with keys from pool instead of a single API
+// key. A key set with WithRequestAPIKey still takes precedence.
+func WithKeyPool(pool *KeyPool) Option {
+	return func(o *clientOptions) {
+		o.keyPool = pool
+	}
+}
+
+// KeyStatus is the health of one key in a KeyPool.
+type KeyStatus struct {
+	// Key is the API key, masked to its last four characters.
+	Key string
+
+	// Available reports whether the key is used for new requests.
+	Available bool
+
+	// Disabled reports whether the key was rejected by the API.
+	Disabled bool
+
+	// CoolingUntil is when a rate-limited key becomes available again.
+	CoolingUntil time.Time
+
+	// Requests and Failures count requests sent with the key and those
+	// that failed with 401 or 429.
+	Requests int64
+	Failures int64
+}
+
+// Status returns the health of each key, in the order given to NewKeyPool.
+func (p *KeyPool) Status() []KeyStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	status := make([]KeyStatus, len(p.keys))
+	for i, k := range p.keys {
+		status[i] = KeyStatus{
+			Key:       maskKey(k.key),
+			Available: k.available(now),
+			Disabled:  k.disabled,
+			Requests:  k.requests,
+			Failures:  k.failures,
+		}
+		if now.Before(k.coolUntil) {
+			status[i].CoolingUntil = k.coolUntil
+		}
+	}
+	return status
+}
+
+// Reset makes all keys available again.
+func (p *KeyPool) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, k := range p.keys {
+		k.disabled = false
+		k.coolUntil = time.Time{}
+	}
+}
+
+func (k *pooledKey) available(now time.Time) bool {
+	return !k.disabled && !now.Before(k.coolUntil)
+}
+
+// pick returns the next available key, skipping those in tried.
+func (p *KeyPool) pick(tried map[*pooledKey]bool) *pooledKey {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	start := 0
+	if p.strategy == KeyPoolRoundRobin {
+		start = p.next
+	}
+	for i := range p.keys {
+		idx := (start + i) % len(p.keys)
+		k := p.keys[idx]
+		if tried[k] || !k.available(now) {
+			continue
+		}
+		if p.strategy == KeyPoolRoundRobin {
+			p.next = (idx + 1) % len(p.keys)
+		}
+		k.requests++
+		return k
+	}
+	return nil
+}
+
+// report records the outcome of a request and reports whether the key
+// failed in a way another key may not.
+func (p *KeyPool) report(k *pooledKey, resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusTooManyRequests:
+	default:
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	k.failures++
+	if resp.StatusCode == http.StatusUnauthorized {
+		k.disabled = true
+		return true
+	}
+	cooldown := parseRetryAfter(resp.Header.Get("Retry-After"))
+	if cooldown <= 0 {
+		cooldown = p.cooldown
+	}
+	k.coolUntil = time.Now().Add(cooldown)
+	return true
+}
+
+// do sends req with keys from the pool, failing over to the next
+// available key on 401 and 429 responses while the body can be replayed.
+func (p *KeyPool) do(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	tried := make(map[*pooledKey]bool)
+	var last *http.Response
+	for {
+		k := p.pick(tried)
+		if k == nil {
+			if last != nil {
+				return last, nil
+			}
+			return nil, ErrNoAvailableKey
+		}
+		tried[k] = true
+
+		if last != nil {
+			// Retry with a fresh body
+			_, _ = io.Copy(io.Discard, last.Body)
+			last.Body.Close()
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = body
+			}
+		}
+
+		req.Header.Set("xi-api-key", k.key)
+		resp, err := send(req)
+		if err != nil {
+			return nil, err
+		}
+		if !p.report(k, resp) || !replayable(req) {
+			return resp, nil
+		}
+		last = resp
+	}
+}
+
+// replayable reports whether the request body can be sent again.
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// connectKey returns a key for a connection made outside the HTTP client,
+// or "" if none is available.
+func (p *KeyPool) connectKey() string {
+	if k := p.pick(nil); k != nil {
+		return k.key
+	}
+	return ""
+}
+
+// maskKey hides all but the last four characters of an API key.
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
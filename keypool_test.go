@@ -0,0 +1,156 @@
+package elevenlabs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewKeyPoolValidation(t *testing.T) {
+	var valErr *ValidationError
+	for _, keys := range [][]string{nil, {"a", ""}, {"a", "a"}} {
+		if _, err := NewKeyPool(keys); !isValidationError(err, &valErr) {
+			t.Errorf("NewKeyPool(%q) error = %v, want ValidationError", keys, err)
+		}
+	}
+}
+
+func TestKeyPoolRoundRobin(t *testing.T) {
+	var mu sync.Mutex
+	used := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		used[r.Header.Get("xi-api-key")]++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	pool, err := NewKeyPool([]string{"key-a", "key-b", "key-c"})
+	if err != nil {
+		t.Fatalf("NewKeyPool() error = %v", err)
+	}
+	client, err := NewClient(WithKeyPool(pool), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	for i := 0; i < 6; i++ {
+		if err := client.doJSON(context.Background(), http.MethodGet, "/v1/test", nil, nil); err != nil {
+			t.Fatalf("doJSON() error = %v", err)
+		}
+	}
+	for _, key := range []string{"key-a", "key-b", "key-c"} {
+		if used[key] != 2 {
+			t.Errorf("%s used %d times, want 2 (%v)", key, used[key], used)
+		}
+	}
+
+	// A per-request key bypasses the pool.
+	ctx := WithRequestOptions(context.Background(), WithRequestAPIKey("tenant-key"))
+	if err := client.doJSON(ctx, http.MethodGet, "/v1/test", nil, nil); err != nil {
+		t.Fatalf("doJSON() error = %v", err)
+	}
+	if used["tenant-key"] != 1 {
+		t.Errorf("tenant-key used %d times, want 1", used["tenant-key"])
+	}
+}
+
+func TestKeyPoolFailover(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, r.Header.Get("xi-api-key")+":"+string(body))
+		mu.Unlock()
+		switch r.Header.Get("xi-api-key") {
+		case "revoked":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "busy":
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	pool, err := NewKeyPool([]string{"revoked", "busy", "key-good"}, WithKeyPoolStrategy(KeyPoolPriority))
+	if err != nil {
+		t.Fatalf("NewKeyPool() error = %v", err)
+	}
+	client, err := NewClient(WithKeyPool(pool), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	if err := client.doJSON(ctx, http.MethodPost, "/v1/test", map[string]string{"text": "hi"}, nil); err != nil {
+		t.Fatalf("doJSON() error = %v", err)
+	}
+	want := []string{`revoked:{"text":"hi"}`, `busy:{"text":"hi"}`, `key-good:{"text":"hi"}`}
+	if len(bodies) != len(want) {
+		t.Fatalf("requests = %q, want %q", bodies, want)
+	}
+	for i := range want {
+		if bodies[i] != want[i] {
+			t.Errorf("request %d = %q, want %q", i, bodies[i], want[i])
+		}
+	}
+
+	status := pool.Status()
+	if !status[0].Disabled || status[0].Available {
+		t.Errorf("revoked key status = %+v", status[0])
+	}
+	if status[1].Available || time.Until(status[1].CoolingUntil) < 50*time.Second {
+		t.Errorf("busy key status = %+v", status[1])
+	}
+	if !status[2].Available || status[2].Requests != 1 || status[2].Key != "****good" {
+		t.Errorf("good key status = %+v", status[2])
+	}
+
+	// Later requests go straight to the healthy key.
+	bodies = nil
+	if err := client.doJSON(ctx, http.MethodGet, "/v1/test", nil, nil); err != nil {
+		t.Fatalf("doJSON() error = %v", err)
+	}
+	if len(bodies) != 1 {
+		t.Errorf("requests = %q, want only the healthy key", bodies)
+	}
+
+	pool.Reset()
+	if !pool.Status()[0].Available {
+		t.Error("Reset() did not re-enable keys")
+	}
+}
+
+func TestKeyPoolExhausted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	pool, _ := NewKeyPool([]string{"key-a", "key-b"})
+	client, err := NewClient(WithKeyPool(pool), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	// Both keys fail: the last response is returned.
+	if err := client.doJSON(ctx, http.MethodGet, "/v1/test", nil, nil); !IsRateLimitError(err) {
+		t.Errorf("error = %v, want rate limit error", err)
+	}
+	// Both keys are cooling down.
+	if err := client.doJSON(ctx, http.MethodGet, "/v1/test", nil, nil); !errors.Is(err, ErrNoAvailableKey) {
+		t.Errorf("error = %v, want %v", err, ErrNoAvailableKey)
+	}
+}
@@ -0,0 +1,231 @@
+package elevenlabs
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"path"
+	"slices"
+
+	"github.com/agentplexus/go-elevenlabs/internal/api"
+	ht "github.com/ogen-go/ogen/http"
+)
+
+// KnowledgeBaseService manages the documents that conversational AI agents
+// use to answer questions.
+type KnowledgeBaseService struct {
+	client *Client
+}
+
+// Knowledge base document types.
+const (
+	KnowledgeBaseTypeFile   = "file"
+	KnowledgeBaseTypeURL    = "url"
+	KnowledgeBaseTypeText   = "text"
+	KnowledgeBaseTypeFolder = "folder"
+)
+
+// DefaultRAGModel is the embedding model used to index documents for
+// retrieval-augmented generation.
+const DefaultRAGModel = "e5_mistral_7b_instruct"
+
+// KnowledgeBaseDocument is a document in the knowledge base.
+type KnowledgeBaseDocument struct {
+	// ID is the document ID.
+	ID string `json:"id"`
+
+	// Name is the display name of the document.
+	Name string `json:"name"`
+
+	// Type is one of the KnowledgeBaseType constants.
+	Type string `json:"type"`
+}
+
+// knowledgeBaseLocator is an entry in an agent's knowledge base.
+type knowledgeBaseLocator struct {
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	ID        string `json:"id"`
+	UsageMode string `json:"usage_mode,omitempty"`
+}
+
+// agentKnowledgeBaseConfig is the part of an agent's configuration holding
+// knowledge base documents.
+type agentKnowledgeBaseConfig struct {
+	ConversationConfig struct {
+		Agent struct {
+			Prompt struct {
+				KnowledgeBase []knowledgeBaseLocator `json:"knowledge_base"`
+			} `json:"prompt"`
+		} `json:"agent"`
+	} `json:"conversation_config"`
+}
+
+// List returns all documents in the knowledge base.
+func (s *KnowledgeBaseService) List(ctx context.Context) ([]*KnowledgeBaseDocument, error) {
+	var docs []*KnowledgeBaseDocument
+	cursor := ""
+	for {
+		query := url.Values{"page_size": {"100"}}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		var page struct {
+			Documents  []*KnowledgeBaseDocument `json:"documents"`
+			HasMore    bool                     `json:"has_more"`
+			NextCursor string                   `json:"next_cursor"`
+		}
+		if err := s.client.doJSON(ctx, "GET", "/v1/convai/knowledge-base?"+query.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+		docs = append(docs, page.Documents...)
+		if !page.HasMore || page.NextCursor == "" {
+			return docs, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// UploadFile creates a document from a file. The document type is
+// detected from the extension of name, which is also the display name.
+// Supported types include PDF, TXT, DOCX, HTML, and EPUB.
+func (s *KnowledgeBaseService) UploadFile(ctx context.Context, name string, file io.Reader) (*KnowledgeBaseDocument, error) {
+	if name == "" {
+		return nil, &ValidationError{Field: "name", Message: "cannot be empty"}
+	}
+	if file == nil {
+		return nil, &ValidationError{Field: "file", Message: "cannot be nil"}
+	}
+
+	resp, err := s.client.apiClient.CreateFileDocumentRoute(ctx,
+		&api.BodyCreateFileDocumentV1ConvaiKnowledgeBaseFilePostMultipart{
+			File: ht.MultipartFile{Name: path.Base(name), File: file},
+			Name: api.NewOptNilString(name),
+		},
+		api.CreateFileDocumentRouteParams{},
+	)
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.AddKnowledgeBaseResponseModel:
+		return &KnowledgeBaseDocument{ID: r.ID, Name: r.Name, Type: KnowledgeBaseTypeFile}, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
+	default:
+		return nil, unexpectedResponse(resp)
+	}
+}
+
+// Delete removes a document. Documents used by agents can only be deleted
+// with force, which also removes them from the agents.
+func (s *KnowledgeBaseService) Delete(ctx context.Context, documentID string, force bool) error {
+	if documentID == "" {
+		return &ValidationError{Field: "documentation_id", Message: "cannot be empty"}
+	}
+
+	resp, err := s.client.apiClient.DeleteKnowledgeBaseDocument(ctx, api.DeleteKnowledgeBaseDocumentParams{
+		DocumentationID: documentID,
+		Force:           api.NewOptBool(force),
+	})
+	if err != nil {
+		return apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.DeleteKnowledgeBaseDocumentOKApplicationJSON:
+		return nil
+	case *api.HTTPValidationError:
+		return validationAPIError(r)
+	default:
+		return unexpectedResponse(resp)
+	}
+}
+
+// ComputeRAGIndex starts indexing a document with the given embedding
+// model, or DefaultRAGModel if empty, so agents can retrieve from it.
+// Returns the index status, such as "processing" or "succeeded".
+func (s *KnowledgeBaseService) ComputeRAGIndex(ctx context.Context, documentID, model string) (string, error) {
+	if documentID == "" {
+		return "", &ValidationError{Field: "documentation_id", Message: "cannot be empty"}
+	}
+	if model == "" {
+		model = DefaultRAGModel
+	}
+
+	resp, err := s.client.apiClient.RagIndexStatus(ctx,
+		&api.RAGIndexRequestModel{Model: api.EmbeddingModelEnum(model)},
+		api.RagIndexStatusParams{DocumentationID: documentID},
+	)
+	if err != nil {
+		return "", apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.RAGDocumentIndexResponseModel:
+		return string(r.Status), nil
+	case *api.HTTPValidationError:
+		return "", validationAPIError(r)
+	default:
+		return "", unexpectedResponse(resp)
+	}
+}
+
+// AttachToAgent adds documents to an agent's knowledge base, keeping
+// documents that are already attached.
+func (s *KnowledgeBaseService) AttachToAgent(ctx context.Context, agentID string, docs ...*KnowledgeBaseDocument) error {
+	if len(docs) == 0 {
+		return &ValidationError{Field: "documents", Message: "at least one document is required"}
+	}
+	return s.updateAgentDocuments(ctx, agentID, docs, nil)
+}
+
+// DetachFromAgent removes documents from an agent's knowledge base.
+func (s *KnowledgeBaseService) DetachFromAgent(ctx context.Context, agentID string, documentIDs ...string) error {
+	if len(documentIDs) == 0 {
+		return &ValidationError{Field: "documents", Message: "at least one document ID is required"}
+	}
+	return s.updateAgentDocuments(ctx, agentID, nil, documentIDs)
+}
+
+// updateAgentDocuments removes and adds documents in an agent's knowledge
+// base in a single update, so replaced documents are swapped atomically.
+func (s *KnowledgeBaseService) updateAgentDocuments(ctx context.Context, agentID string, add []*KnowledgeBaseDocument, remove []string) error {
+	if agentID == "" {
+		return &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+
+	path := agentPath(agentID)
+
+	var agent agentKnowledgeBaseConfig
+	if err := s.client.doJSON(ctx, "GET", path, nil, &agent); err != nil {
+		return err
+	}
+
+	entries := agent.ConversationConfig.Agent.Prompt.KnowledgeBase
+	entries = slices.DeleteFunc(entries, func(e knowledgeBaseLocator) bool {
+		return slices.Contains(remove, e.ID)
+	})
+	for _, doc := range add {
+		if doc == nil || doc.ID == "" {
+			return &ValidationError{Field: "documents", Message: "cannot contain documents without IDs"}
+		}
+		if slices.ContainsFunc(entries, func(e knowledgeBaseLocator) bool { return e.ID == doc.ID }) {
+			continue
+		}
+		docType := doc.Type
+		if docType == "" {
+			docType = KnowledgeBaseTypeFile
+		}
+		entries = append(entries, knowledgeBaseLocator{Type: docType, Name: doc.Name, ID: doc.ID, UsageMode: "auto"})
+	}
+	if entries == nil {
+		entries = []knowledgeBaseLocator{}
+	}
+
+	var update agentKnowledgeBaseConfig
+	update.ConversationConfig.Agent.Prompt.KnowledgeBase = entries
+	return s.client.doJSON(ctx, "PATCH", path, &update, nil)
+}
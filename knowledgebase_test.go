@@ -0,0 +1,205 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeKnowledgeBase is an in-memory knowledge base and agent served over
+// HTTP, paging document lists two at a time.
+type fakeKnowledgeBase struct {
+	mu       sync.Mutex
+	nextID   int
+	docs     map[string]*KnowledgeBaseDocument
+	contents map[string]string
+	indexed  []string
+	agentKB  []knowledgeBaseLocator
+}
+
+func newFakeKnowledgeBase(t *testing.T) (*fakeKnowledgeBase, *Client) {
+	t.Helper()
+	kb := &fakeKnowledgeBase{docs: make(map[string]*KnowledgeBaseDocument), contents: make(map[string]string)}
+	server := httptest.NewServer(http.HandlerFunc(kb.serveHTTP))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return kb, client
+}
+
+func (kb *fakeKnowledgeBase) add(name, content string) *KnowledgeBaseDocument {
+	kb.nextID++
+	doc := &KnowledgeBaseDocument{ID: fmt.Sprintf("doc-%d", kb.nextID), Name: name, Type: KnowledgeBaseTypeFile}
+	kb.docs[doc.ID] = doc
+	kb.contents[doc.ID] = content
+	return doc
+}
+
+// names returns the document names and contents, sorted.
+func (kb *fakeKnowledgeBase) names() []string {
+	kb.mu.Lock()
+	defer kb.mu.Unlock()
+	var names []string
+	for id, doc := range kb.docs {
+		names = append(names, doc.Name+"="+kb.contents[id])
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (kb *fakeKnowledgeBase) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	kb.mu.Lock()
+	defer kb.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/convai/")
+	switch {
+	case r.Method == http.MethodGet && path == "knowledge-base":
+		ids := make([]string, 0, len(kb.docs))
+		for id := range kb.docs {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		start := 0
+		if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+			start, _ = strconv.Atoi(cursor)
+		}
+		end := min(start+2, len(ids))
+		page := map[string]any{"documents": []any{}, "has_more": end < len(ids)}
+		if end < len(ids) {
+			page["next_cursor"] = fmt.Sprint(end)
+		}
+		var docs []any
+		for _, id := range ids[start:end] {
+			docs = append(docs, kb.docs[id])
+		}
+		if docs != nil {
+			page["documents"] = docs
+		}
+		_ = json.NewEncoder(w).Encode(page)
+
+	case r.Method == http.MethodPost && path == "knowledge-base/file":
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		name := r.FormValue("name")
+		if name == "" {
+			name = header.Filename
+		}
+		doc := kb.add(name, string(data))
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": doc.ID, "name": doc.Name})
+
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/rag-index"):
+		id := strings.TrimSuffix(strings.TrimPrefix(path, "knowledge-base/"), "/rag-index")
+		kb.indexed = append(kb.indexed, id)
+		_, _ = w.Write([]byte(`{"id": "index-1", "model": "e5_mistral_7b_instruct", "status": "processing", "progress_percentage": 0, "document_model_index_usage": {"used_bytes": 0}}`))
+
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "knowledge-base/"):
+		id := strings.TrimPrefix(path, "knowledge-base/")
+		if kb.docs[id] == nil {
+			http.Error(w, `{"detail": "not found"}`, http.StatusNotFound)
+			return
+		}
+		delete(kb.docs, id)
+		_, _ = w.Write([]byte(`{}`))
+
+	case path == "agents/agent-1":
+		var agent agentKnowledgeBaseConfig
+		if r.Method == http.MethodPatch {
+			_ = json.NewDecoder(r.Body).Decode(&agent)
+			kb.agentKB = agent.ConversationConfig.Agent.Prompt.KnowledgeBase
+		}
+		agent.ConversationConfig.Agent.Prompt.KnowledgeBase = kb.agentKB
+		_ = json.NewEncoder(w).Encode(agent)
+
+	default:
+		http.Error(w, "unexpected request "+r.Method+" "+r.URL.Path, http.StatusNotFound)
+	}
+}
+
+func TestKnowledgeBaseList(t *testing.T) {
+	kb, client := newFakeKnowledgeBase(t)
+	for _, name := range []string{"a.md", "b.md", "c.md"} {
+		kb.add(name, "")
+	}
+
+	docs, err := client.KnowledgeBase().List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	var names []string
+	for _, doc := range docs {
+		names = append(names, doc.Name)
+	}
+	if !slices.Equal(names, []string{"a.md", "b.md", "c.md"}) {
+		t.Errorf("List() names = %v, want all pages", names)
+	}
+}
+
+func TestKnowledgeBaseUploadAndIndex(t *testing.T) {
+	kb, client := newFakeKnowledgeBase(t)
+	ctx := context.Background()
+
+	doc, err := client.KnowledgeBase().UploadFile(ctx, "guides/setup.md", strings.NewReader("# Setup"))
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+	if doc.Name != "guides/setup.md" || doc.Type != KnowledgeBaseTypeFile {
+		t.Errorf("UploadFile() = %+v", doc)
+	}
+
+	status, err := client.KnowledgeBase().ComputeRAGIndex(ctx, doc.ID, "")
+	if err != nil {
+		t.Fatalf("ComputeRAGIndex() error = %v", err)
+	}
+	if status != "processing" || !slices.Equal(kb.indexed, []string{doc.ID}) {
+		t.Errorf("ComputeRAGIndex() = %q, indexed %v", status, kb.indexed)
+	}
+
+	if err := client.KnowledgeBase().Delete(ctx, doc.ID, false); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if len(kb.names()) != 0 {
+		t.Errorf("documents after Delete() = %v", kb.names())
+	}
+}
+
+func TestKnowledgeBaseAttachToAgent(t *testing.T) {
+	kb, client := newFakeKnowledgeBase(t)
+	kb.agentKB = []knowledgeBaseLocator{{Type: KnowledgeBaseTypeURL, Name: "Site", ID: "doc-url", UsageMode: "prompt"}}
+	ctx := context.Background()
+
+	doc := &KnowledgeBaseDocument{ID: "doc-1", Name: "faq.md"}
+	if err := client.KnowledgeBase().AttachToAgent(ctx, "agent-1", doc, doc); err != nil {
+		t.Fatalf("AttachToAgent() error = %v", err)
+	}
+	want := []knowledgeBaseLocator{
+		{Type: KnowledgeBaseTypeURL, Name: "Site", ID: "doc-url", UsageMode: "prompt"},
+		{Type: KnowledgeBaseTypeFile, Name: "faq.md", ID: "doc-1", UsageMode: "auto"},
+	}
+	if !slices.Equal(kb.agentKB, want) {
+		t.Errorf("agent knowledge base = %+v, want %+v", kb.agentKB, want)
+	}
+
+	if err := client.KnowledgeBase().DetachFromAgent(ctx, "agent-1", "doc-url", "doc-1"); err != nil {
+		t.Fatalf("DetachFromAgent() error = %v", err)
+	}
+	if len(kb.agentKB) != 0 {
+		t.Errorf("agent knowledge base = %+v, want empty", kb.agentKB)
+	}
+}
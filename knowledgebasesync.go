@@ -0,0 +1,354 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultKnowledgeBaseExtensions are the file extensions synced by a
+// KnowledgeBaseSyncer.
+var DefaultKnowledgeBaseExtensions = []string{".md", ".markdown", ".html", ".htm", ".pdf", ".txt"}
+
+// KnowledgeBaseSyncResult lists the documents changed by a sync, by name.
+type KnowledgeBaseSyncResult struct {
+	// Added are documents uploaded for new files.
+	Added []string
+
+	// Updated are documents replaced because their files changed.
+	Updated []string
+
+	// Deleted are documents removed because their files were deleted.
+	Deleted []string
+
+	// Unchanged are documents whose files have not changed.
+	Unchanged []string
+}
+
+// KnowledgeBaseSyncOption configures a KnowledgeBaseSyncer.
+type KnowledgeBaseSyncOption func(*KnowledgeBaseSyncer)
+
+// WithKnowledgeBaseAgent keeps the documents attached to an agent:
+// uploaded documents are added to its knowledge base and replaced ones
+// are swapped out.
+func WithKnowledgeBaseAgent(agentID string) KnowledgeBaseSyncOption {
+	return func(s *KnowledgeBaseSyncer) {
+		s.agentID = agentID
+	}
+}
+
+// WithKnowledgeBasePrefix prefixes document names with prefix, such as
+// "docs/". With a prefix, every document whose name starts with it is
+// treated as synced, so documents without a local file are deleted even
+// if they were not uploaded by this syncer.
+func WithKnowledgeBasePrefix(prefix string) KnowledgeBaseSyncOption {
+	return func(s *KnowledgeBaseSyncer) {
+		s.prefix = prefix
+	}
+}
+
+// WithKnowledgeBaseExtensions sets the file extensions to sync. The
+// default is DefaultKnowledgeBaseExtensions.
+func WithKnowledgeBaseExtensions(extensions ...string) KnowledgeBaseSyncOption {
+	return func(s *KnowledgeBaseSyncer) {
+		s.extensions = extensions
+	}
+}
+
+// WithKnowledgeBaseRAGModel sets the embedding model used to index
+// uploaded documents. The default is DefaultRAGModel.
+func WithKnowledgeBaseRAGModel(model string) KnowledgeBaseSyncOption {
+	return func(s *KnowledgeBaseSyncer) {
+		s.ragModel = model
+	}
+}
+
+// WithKnowledgeBaseStateFile stores the IDs and content hashes of synced
+// documents in a JSON file, so unchanged files are not uploaded again by
+// later runs. Without it, the state is kept in memory and the first sync
+// replaces every document.
+func WithKnowledgeBaseStateFile(path string) KnowledgeBaseSyncOption {
+	return func(s *KnowledgeBaseSyncer) {
+		s.stateFile = path
+	}
+}
+
+// KnowledgeBaseSyncer keeps the knowledge base in sync with a local
+// directory, such as the docs folder of a git repository. Each Sync
+// uploads new and changed files, indexes them for retrieval, and deletes
+// the documents of removed files. Files are named by their path relative
+// to the directory and tracked by content hash.
+//
+//	syncer := client.KnowledgeBase().NewSyncer("./docs",
+//	    elevenlabs.WithKnowledgeBaseAgent(agentID),
+//	    elevenlabs.WithKnowledgeBaseStateFile(".kb-sync.json"),
+//	)
+//	result, err := syncer.Sync(ctx)
+type KnowledgeBaseSyncer struct {
+	kb         *KnowledgeBaseService
+	dir        string
+	agentID    string
+	prefix     string
+	extensions []string
+	ragModel   string
+	stateFile  string
+
+	mu    sync.Mutex
+	state map[string]syncedDocument
+}
+
+// syncedDocument is the synced state of one file.
+type syncedDocument struct {
+	DocumentID string `json:"document_id"`
+	Hash       string `json:"hash"`
+}
+
+// localDocument is a file to sync.
+type localDocument struct {
+	path string
+	name string
+	data []byte
+	hash string
+}
+
+// NewSyncer creates a syncer for the files in dir. Hidden files and
+// directories, such as .git, are skipped.
+func (s *KnowledgeBaseService) NewSyncer(dir string, opts ...KnowledgeBaseSyncOption) *KnowledgeBaseSyncer {
+	syncer := &KnowledgeBaseSyncer{
+		kb:         s,
+		dir:        dir,
+		extensions: DefaultKnowledgeBaseExtensions,
+	}
+	for _, opt := range opts {
+		opt(syncer)
+	}
+	return syncer
+}
+
+// Sync brings the knowledge base up to date with the directory. If it
+// fails partway, the result lists the changes made so far, and the next
+// Sync continues from there.
+func (ks *KnowledgeBaseSyncer) Sync(ctx context.Context) (result *KnowledgeBaseSyncResult, err error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if ks.state == nil {
+		if ks.state, err = ks.loadState(); err != nil {
+			return nil, err
+		}
+	}
+	defer func() {
+		if saveErr := ks.saveState(); saveErr != nil {
+			err = errors.Join(err, saveErr)
+		}
+	}()
+
+	local, err := ks.localDocuments()
+	if err != nil {
+		return nil, err
+	}
+	remote, err := ks.kb.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	remoteIDs := make(map[string]bool, len(remote))
+	for _, doc := range remote {
+		remoteIDs[doc.ID] = true
+	}
+
+	result = &KnowledgeBaseSyncResult{}
+	localNames := make(map[string]bool, len(local))
+	for _, file := range local {
+		localNames[file.name] = true
+		prev, tracked := ks.state[file.path]
+		if tracked && prev.Hash == file.hash && remoteIDs[prev.DocumentID] {
+			result.Unchanged = append(result.Unchanged, file.name)
+			continue
+		}
+
+		// Replace earlier uploads of the file, whether tracked or found by name
+		var oldIDs []string
+		if tracked && remoteIDs[prev.DocumentID] {
+			oldIDs = append(oldIDs, prev.DocumentID)
+		}
+		for _, doc := range remote {
+			if doc.Name == file.name && doc.Type == KnowledgeBaseTypeFile && !slices.Contains(oldIDs, doc.ID) {
+				oldIDs = append(oldIDs, doc.ID)
+			}
+		}
+
+		if err := ks.upload(ctx, file, oldIDs); err != nil {
+			return result, fmt.Errorf("syncing %s: %w", file.path, err)
+		}
+		for _, id := range oldIDs {
+			remoteIDs[id] = false
+		}
+		if len(oldIDs) > 0 {
+			result.Updated = append(result.Updated, file.name)
+		} else {
+			result.Added = append(result.Added, file.name)
+		}
+	}
+
+	// Delete documents whose files are gone
+	type staleDocument struct{ id, name, path string }
+	var stale []staleDocument
+	paths := make([]string, 0, len(ks.state))
+	for path := range ks.state {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if localNames[ks.prefix+path] {
+			continue
+		}
+		if id := ks.state[path].DocumentID; remoteIDs[id] {
+			stale = append(stale, staleDocument{id, ks.prefix + path, path})
+			remoteIDs[id] = false
+		} else {
+			delete(ks.state, path)
+		}
+	}
+	if ks.prefix != "" {
+		for _, doc := range remote {
+			if remoteIDs[doc.ID] && doc.Type == KnowledgeBaseTypeFile && !localNames[doc.Name] && strings.HasPrefix(doc.Name, ks.prefix) {
+				stale = append(stale, staleDocument{doc.ID, doc.Name, ""})
+			}
+		}
+	}
+	if len(stale) == 0 {
+		return result, nil
+	}
+
+	if ks.agentID != "" {
+		ids := make([]string, len(stale))
+		for i, doc := range stale {
+			ids[i] = doc.id
+		}
+		if err := ks.kb.updateAgentDocuments(ctx, ks.agentID, nil, ids); err != nil {
+			return result, fmt.Errorf("updating agent: %w", err)
+		}
+	}
+	for _, doc := range stale {
+		if err := ks.kb.Delete(ctx, doc.id, true); err != nil {
+			return result, fmt.Errorf("deleting %s: %w", doc.name, err)
+		}
+		delete(ks.state, doc.path)
+		result.Deleted = append(result.Deleted, doc.name)
+	}
+	return result, nil
+}
+
+// upload uploads a file, indexes it, and swaps it for the documents it
+// replaces.
+func (ks *KnowledgeBaseSyncer) upload(ctx context.Context, file localDocument, oldIDs []string) error {
+	doc, err := ks.kb.UploadFile(ctx, file.name, bytes.NewReader(file.data))
+	if err != nil {
+		return err
+	}
+	if _, err := ks.kb.ComputeRAGIndex(ctx, doc.ID, ks.ragModel); err != nil {
+		return fmt.Errorf("indexing: %w", err)
+	}
+	if ks.agentID != "" {
+		if err := ks.kb.updateAgentDocuments(ctx, ks.agentID, []*KnowledgeBaseDocument{doc}, oldIDs); err != nil {
+			return fmt.Errorf("updating agent: %w", err)
+		}
+	}
+	for _, id := range oldIDs {
+		if err := ks.kb.Delete(ctx, id, true); err != nil {
+			return fmt.Errorf("deleting previous version: %w", err)
+		}
+	}
+	ks.state[file.path] = syncedDocument{DocumentID: doc.ID, Hash: file.hash}
+	return nil
+}
+
+// localDocuments returns the files to sync, sorted by path.
+func (ks *KnowledgeBaseSyncer) localDocuments() ([]localDocument, error) {
+	var docs []localDocument
+	err := filepath.WalkDir(ks.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != ks.dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !slices.Contains(ks.extensions, strings.ToLower(filepath.Ext(path))) {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(ks.dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		sum := sha256.Sum256(data)
+		docs = append(docs, localDocument{
+			path: rel,
+			name: ks.prefix + rel,
+			data: data,
+			hash: hex.EncodeToString(sum[:]),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", ks.dir, err)
+	}
+	return docs, nil
+}
+
+func (ks *KnowledgeBaseSyncer) loadState() (map[string]syncedDocument, error) {
+	state := make(map[string]syncedDocument)
+	if ks.stateFile == "" {
+		return state, nil
+	}
+	data, err := os.ReadFile(ks.stateFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading sync state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("decoding sync state %s: %w", ks.stateFile, err)
+	}
+	return state, nil
+}
+
+func (ks *KnowledgeBaseSyncer) saveState() error {
+	if ks.stateFile == "" || ks.state == nil {
+		return nil
+	}
+	data, err := json.MarshalIndent(ks.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first so an interrupted save keeps the
+	// previous state
+	tmp := ks.stateFile + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing sync state: %w", err)
+	}
+	if err := os.Rename(tmp, ks.stateFile); err != nil {
+		return fmt.Errorf("writing sync state: %w", err)
+	}
+	return nil
+}
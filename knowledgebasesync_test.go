@@ -0,0 +1,111 @@
+package elevenlabs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestKnowledgeBaseSyncer(t *testing.T) {
+	kb, client := newFakeKnowledgeBase(t)
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	writeTestFile(t, filepath.Join(dir, "faq.md"), "v1")
+	writeTestFile(t, filepath.Join(dir, "guides", "setup.html"), "setup")
+	writeTestFile(t, filepath.Join(dir, "notes.go"), "skipped")
+	writeTestFile(t, filepath.Join(dir, ".git", "HEAD.txt"), "skipped")
+
+	newSyncer := func() *KnowledgeBaseSyncer {
+		return client.KnowledgeBase().NewSyncer(dir,
+			WithKnowledgeBaseAgent("agent-1"),
+			WithKnowledgeBaseStateFile(stateFile),
+		)
+	}
+
+	result, err := newSyncer().Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if !slices.Equal(result.Added, []string{"faq.md", "guides/setup.html"}) {
+		t.Errorf("Added = %v", result.Added)
+	}
+	if len(kb.indexed) != 2 || len(kb.agentKB) != 2 {
+		t.Errorf("indexed %v, agent knowledge base %+v", kb.indexed, kb.agentKB)
+	}
+
+	// A new syncer reads the state file, so only changes are synced
+	writeTestFile(t, filepath.Join(dir, "faq.md"), "v2")
+	if err := os.Remove(filepath.Join(dir, "guides", "setup.html")); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(dir, "pricing.pdf"), "pdf")
+
+	result, err = newSyncer().Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if !slices.Equal(result.Added, []string{"pricing.pdf"}) ||
+		!slices.Equal(result.Updated, []string{"faq.md"}) ||
+		!slices.Equal(result.Deleted, []string{"guides/setup.html"}) ||
+		len(result.Unchanged) != 0 {
+		t.Errorf("Sync() = %+v", result)
+	}
+	if want := []string{"faq.md=v2", "pricing.pdf=pdf"}; !slices.Equal(kb.names(), want) {
+		t.Errorf("documents = %v, want %v", kb.names(), want)
+	}
+
+	// The agent references the current documents only
+	var agentNames []string
+	for _, e := range kb.agentKB {
+		if kb.docs[e.ID] == nil {
+			t.Errorf("agent references deleted document %s", e.ID)
+		}
+		agentNames = append(agentNames, e.Name)
+	}
+	if !slices.Equal(agentNames, []string{"faq.md", "pricing.pdf"}) {
+		t.Errorf("agent documents = %v", agentNames)
+	}
+
+	result, err = newSyncer().Sync(ctx)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if len(result.Added)+len(result.Updated)+len(result.Deleted) != 0 || len(result.Unchanged) != 2 {
+		t.Errorf("Sync() without changes = %+v", result)
+	}
+}
+
+func TestKnowledgeBaseSyncerPrefix(t *testing.T) {
+	kb, client := newFakeKnowledgeBase(t)
+	kb.add("docs/old.md", "stale")
+	kb.add("docs/faq.md", "old")
+	kb.add("manual.md", "kept")
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "faq.md"), "new")
+
+	result, err := client.KnowledgeBase().NewSyncer(dir, WithKnowledgeBasePrefix("docs/")).Sync(context.Background())
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if !slices.Equal(result.Updated, []string{"docs/faq.md"}) || !slices.Equal(result.Deleted, []string{"docs/old.md"}) {
+		t.Errorf("Sync() = %+v", result)
+	}
+	if want := []string{"docs/faq.md=new", "manual.md=kept"}; !slices.Equal(kb.names(), want) {
+		t.Errorf("documents = %v, want %v", kb.names(), want)
+	}
+}
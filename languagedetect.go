@@ -0,0 +1,226 @@
+package elevenlabs
+
+import (
+	"context"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// DetectedLanguage is the language identified in audio or text.
+type DetectedLanguage struct {
+	// Code is the ISO 639-1 code, such as "en", or the ISO 639-3 code for
+	// languages without one. Empty if no language was identified.
+	Code string
+
+	// Confidence is the probability that Code is right, from 0 to 1.
+	Confidence float64
+}
+
+// DetectLanguage identifies the spoken language of audio with speech to
+// text, for example to route a caller to an agent or voice that speaks
+// it. The audio is transcribed, so pass a short clip, such as the first
+// seconds of a call, to limit cost and latency. filename is used to detect
+// the audio format.
+func (s *SpeechToTextService) DetectLanguage(ctx context.Context, audio io.Reader, filename string) (*DetectedLanguage, error) {
+	if audio == nil {
+		return nil, &ValidationError{Field: "audio", Message: "cannot be nil"}
+	}
+	resp, err := s.Transcribe(ctx, &TranscriptionRequest{File: audio, Filename: filename})
+	if err != nil {
+		return nil, err
+	}
+	return &DetectedLanguage{
+		Code:       normalizeLanguageCode(resp.LanguageCode),
+		Confidence: resp.LanguageProbability,
+	}, nil
+}
+
+// iso6393To6391 maps the ISO 639-3 codes reported by speech to text to
+// ISO 639-1 codes, as used by models and agents.
+var iso6393To6391 = map[string]string{
+	"afr": "af", "ara": "ar", "bel": "be", "ben": "bn", "bul": "bg", "cat": "ca",
+	"ces": "cs", "cmn": "zh", "cym": "cy", "dan": "da", "deu": "de", "ell": "el",
+	"eng": "en", "est": "et", "fas": "fa", "fin": "fi", "fra": "fr", "gle": "ga",
+	"glg": "gl", "guj": "gu", "heb": "he", "hin": "hi", "hrv": "hr", "hun": "hu",
+	"hye": "hy", "ind": "id", "isl": "is", "ita": "it", "jpn": "ja", "kan": "kn",
+	"kat": "ka", "kaz": "kk", "kor": "ko", "lav": "lv", "lit": "lt", "mal": "ml",
+	"mar": "mr", "mkd": "mk", "msa": "ms", "nld": "nl", "nor": "no", "pan": "pa",
+	"pol": "pl", "por": "pt", "ron": "ro", "rus": "ru", "slk": "sk", "slv": "sl",
+	"spa": "es", "srp": "sr", "swa": "sw", "swe": "sv", "tam": "ta", "tel": "te",
+	"tgl": "tl", "tha": "th", "tur": "tr", "ukr": "uk", "urd": "ur", "vie": "vi",
+	"zho": "zh", "yue": "zh",
+}
+
+// normalizeLanguageCode returns the ISO 639-1 code of an ISO 639-3 code,
+// or code lowercased if it has none.
+func normalizeLanguageCode(code string) string {
+	code = strings.ToLower(code)
+	if short, ok := iso6393To6391[code]; ok {
+		return short
+	}
+	return code
+}
+
+// stopWords are frequent short words of languages written in the Latin
+// script, used to tell them apart.
+var stopWords = map[string][]string{
+	"en": {"the", "and", "is", "are", "you", "to", "of", "it", "that", "this", "my", "have", "with", "for", "what"},
+	"es": {"el", "la", "los", "las", "y", "es", "que", "de", "en", "por", "un", "una", "mi", "con", "para", "está"},
+	"fr": {"le", "la", "les", "et", "est", "que", "de", "des", "un", "une", "je", "vous", "pour", "avec", "pas", "mon"},
+	"de": {"der", "die", "das", "und", "ist", "ich", "nicht", "ein", "eine", "mit", "für", "sie", "mein", "zu", "auf"},
+	"it": {"il", "la", "gli", "e", "è", "che", "di", "un", "una", "per", "non", "sono", "mio", "con", "della"},
+	"pt": {"o", "a", "os", "as", "e", "é", "que", "de", "um", "uma", "não", "para", "com", "meu", "você", "está"},
+	"nl": {"de", "het", "een", "en", "is", "ik", "niet", "van", "dat", "met", "voor", "mijn", "zijn", "u"},
+	"pl": {"i", "w", "nie", "jest", "się", "na", "że", "to", "z", "do", "mój", "jak", "dla", "czy"},
+	"sv": {"och", "är", "att", "det", "en", "ett", "jag", "inte", "på", "med", "för", "min", "som", "har"},
+	"tr": {"ve", "bir", "bu", "ne", "için", "ben", "değil", "mi", "da", "de", "çok", "var", "benim"},
+}
+
+// distinctiveLetters are letters that suggest a language.
+var distinctiveLetters = map[rune]string{
+	'ñ': "es", '¿': "es", '¡': "es",
+	'ß': "de",
+	'ã': "pt", 'õ': "pt",
+	'ł': "pl", 'ą': "pl", 'ę': "pl", 'ż': "pl", 'ś': "pl", 'ź': "pl",
+	'ı': "tr", 'ş': "tr", 'ğ': "tr",
+	'å': "sv",
+	'œ': "fr", 'û': "fr",
+}
+
+// scriptLanguages are the languages identified by their script alone.
+var scriptLanguages = []struct {
+	table *unicode.RangeTable
+	code  string
+}{
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Han, "zh"},
+	{unicode.Arabic, "ar"},
+	{unicode.Devanagari, "hi"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Greek, "el"},
+	{unicode.Hebrew, "he"},
+	{unicode.Thai, "th"},
+	{unicode.Tamil, "ta"},
+}
+
+// DetectTextLanguage identifies the language of text locally, without an
+// API call, for example to pick a voice for a customer's message. It
+// recognizes languages with their own script, such as Japanese, Korean,
+// Chinese, Arabic, Hindi, Russian, Ukrainian, and Greek, and tells common
+// languages written in the Latin script apart by their frequent words:
+// English, Spanish, French, German, Italian, Portuguese, Dutch, Polish,
+// Swedish, and Turkish.
+//
+// If candidates are given, only those languages are considered, for
+// example the languages of a model:
+//
+//	lang := elevenlabs.DetectTextLanguage(text, model.LanguageIDs()...)
+//
+// Short texts give low confidence. The Code is empty if the text gives no
+// evidence for any candidate.
+func DetectTextLanguage(text string, candidates ...string) DetectedLanguage {
+	allowed := func(code string) bool {
+		if len(candidates) == 0 {
+			return true
+		}
+		for _, c := range candidates {
+			base, _, _ := strings.Cut(strings.ToLower(strings.ReplaceAll(c, "_", "-")), "-")
+			if base == code {
+				return true
+			}
+		}
+		return false
+	}
+
+	scores := make(map[string]float64)
+	var letters, latin int
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.Is(unicode.Latin, r) {
+			latin++
+			continue
+		}
+		for _, sl := range scriptLanguages {
+			if unicode.Is(sl.table, r) {
+				scores[sl.code]++
+				break
+			}
+		}
+	}
+	if letters == 0 {
+		return DetectedLanguage{}
+	}
+
+	// Kana is only used in Japanese, which also uses Han characters
+	if scores["ja"] > 0 {
+		scores["ja"] += scores["zh"]
+		delete(scores, "zh")
+	}
+	// Cyrillic letters only used in Ukrainian
+	if scores["ru"] > 0 && strings.ContainsAny(strings.ToLower(text), "іїєґ") {
+		scores["uk"] = scores["ru"]
+		delete(scores, "ru")
+	}
+
+	if latin > 0 {
+		// Weigh Latin evidence by the share of Latin letters
+		latinScores, total := latinLanguageScores(text)
+		for code, score := range latinScores {
+			scores[code] += float64(latin) * score / total
+		}
+	}
+
+	var best string
+	var bestScore, total float64
+	for code, score := range scores {
+		if !allowed(code) {
+			continue
+		}
+		total += score
+		if score > bestScore || (score == bestScore && code < best) {
+			best, bestScore = code, score
+		}
+	}
+	if best == "" {
+		return DetectedLanguage{}
+	}
+	return DetectedLanguage{Code: best, Confidence: bestScore / total}
+}
+
+// latinLanguageScores counts the stop words and distinctive letters of
+// each Latin-script language in text, and returns the counts and their
+// total. The total is at least 1.
+func latinLanguageScores(text string) (map[string]float64, float64) {
+	scores := make(map[string]float64)
+	lower := strings.ToLower(text)
+	words := strings.FieldsFunc(lower, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	for _, word := range words {
+		for code, list := range stopWords {
+			for _, sw := range list {
+				if word == sw {
+					scores[code]++
+					break
+				}
+			}
+		}
+	}
+	for _, r := range lower {
+		if code, ok := distinctiveLetters[r]; ok {
+			scores[code] += 2
+		}
+	}
+
+	total := 1.0
+	for _, score := range scores {
+		total += score
+	}
+	return scores, total
+}
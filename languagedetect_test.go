@@ -0,0 +1,92 @@
+package elevenlabs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSpeechToTextDetectLanguage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/speech-to-text" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"language_code": "spa", "language_probability": 0.93, "text": "Hola", "words": []}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	lang, err := client.SpeechToText().DetectLanguage(context.Background(), strings.NewReader("audio"), "call.wav")
+	if err != nil {
+		t.Fatalf("DetectLanguage() error = %v", err)
+	}
+	if lang.Code != "es" || lang.Confidence != 0.93 {
+		t.Errorf("DetectLanguage() = %+v, want es with 0.93", lang)
+	}
+
+	var valErr *ValidationError
+	if _, err := client.SpeechToText().DetectLanguage(context.Background(), nil, "call.wav"); !isValidationError(err, &valErr) {
+		t.Errorf("nil audio error = %v, want ValidationError", err)
+	}
+}
+
+func TestNormalizeLanguageCode(t *testing.T) {
+	tests := map[string]string{"eng": "en", "CMN": "zh", "en": "en", "fil": "fil", "": ""}
+	for code, want := range tests {
+		if got := normalizeLanguageCode(code); got != want {
+			t.Errorf("normalizeLanguageCode(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestDetectTextLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Hello, I would like to check the status of my order", "en"},
+		{"Hola, quiero saber el estado de mi pedido, por favor", "es"},
+		{"Bonjour, je voudrais savoir où est ma commande", "fr"},
+		{"Hallo, ich möchte wissen, wo meine Bestellung ist und warum sie nicht kommt", "de"},
+		{"Ciao, vorrei sapere lo stato del mio ordine, per favore", "it"},
+		{"Olá, não recebi o meu pedido e gostaria de saber o que aconteceu", "pt"},
+		{"Goedendag, ik heb mijn bestelling niet ontvangen", "nl"},
+		{"Dzień dobry, nie otrzymałem jeszcze mojego zamówienia", "pl"},
+		{"Hej, jag har inte fått min beställning", "sv"},
+		{"Merhaba, siparişim henüz gelmedi, bu çok geç", "tr"},
+		{"こんにちは、注文の状況を確認したいです", "ja"},
+		{"주문 상태를 확인하고 싶습니다", "ko"},
+		{"你好，我想查询我的订单", "zh"},
+		{"مرحبا، أريد التحقق من طلبي", "ar"},
+		{"नमस्ते, मैं अपना ऑर्डर देखना चाहता हूँ", "hi"},
+		{"Здравствуйте, я хочу узнать статус заказа", "ru"},
+		{"Добрий день, я хочу дізнатися про своє замовлення", "uk"},
+		{"Γεια σας, θέλω να ελέγξω την παραγγελία μου", "el"},
+	}
+	for _, tt := range tests {
+		got := DetectTextLanguage(tt.text)
+		if got.Code != tt.want {
+			t.Errorf("DetectTextLanguage(%q) = %+v, want %s", tt.text, got, tt.want)
+		}
+		if got.Confidence <= 0 || got.Confidence > 1 {
+			t.Errorf("DetectTextLanguage(%q) confidence = %v", tt.text, got.Confidence)
+		}
+	}
+}
+
+func TestDetectTextLanguageCandidates(t *testing.T) {
+	// Spanish and Portuguese share many words; candidates decide
+	text := "que de para"
+	if got := DetectTextLanguage(text, "pt-BR", "en"); got.Code != "pt" {
+		t.Errorf("DetectTextLanguage(pt-BR, en) = %+v, want pt", got)
+	}
+	if got := DetectTextLanguage("你好", "en", "es"); got.Code != "" || got.Confidence != 0 {
+		t.Errorf("DetectTextLanguage() outside candidates = %+v, want none", got)
+	}
+	if got := DetectTextLanguage("1234 !?"); got.Code != "" {
+		t.Errorf("DetectTextLanguage(no letters) = %+v, want none", got)
+	}
+}
@@ -0,0 +1,207 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	ht "github.com/ogen-go/ogen/http"
+)
+
+// listCachePaths are the list endpoints cached by WithListCache. Their
+// responses rarely change but are fetched often, for example to resolve
+// voice names or check model capabilities.
+var listCachePaths = map[string]bool{
+	"/v1/voices": true,
+	"/v2/voices": true,
+	"/v1/models": true,
+}
+
+// WithListCache caches the responses of list endpoints that rarely change,
+// such as Voices().List, Voices().Search, and Models().List, in memory for
+// ttl. After ttl, a cached response is revalidated with If-None-Match if
+// the API sent an ETag, so unchanged lists are not downloaded again.
+//
+// Successful changes made through the client, such as adding or deleting
+// a voice, clear the cached lists of that resource. Changes made
+// elsewhere, such as in the web app, are seen after ttl; use
+// ClearListCache to see them sooner.
+//
+// Cached responses are served like responses from the API: request
+// options such as WithDownloadProgress and WithMaxResponseSize apply to
+// them.
+//
+// Lists are cached per API key. With WithKeyPool, the key is chosen as the
+// request is sent, so lists are only cached for requests that set their
+// own key with WithRequestAPIKey.
+func WithListCache(ttl time.Duration) Option {
+	return func(o *clientOptions) {
+		o.listCacheTTL = ttl
+	}
+}
+
+// ClearListCache removes all responses cached by WithListCache.
+func (c *Client) ClearListCache() {
+	if c.listCache != nil {
+		c.listCache.clear()
+	}
+}
+
+// listCache holds cached list responses by API key and URL.
+type listCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*listCacheEntry
+}
+
+type listCacheEntry struct {
+	resource string
+	header   http.Header
+	body     []byte
+	etag     string
+	fetched  time.Time
+}
+
+func newListCache(ttl time.Duration) *listCache {
+	return &listCache{ttl: ttl, now: time.Now, entries: make(map[string]*listCacheEntry)}
+}
+
+func (c *listCache) clear() {
+	c.mu.Lock()
+	c.entries = make(map[string]*listCacheEntry)
+	c.mu.Unlock()
+}
+
+// invalidate removes the cached lists of a resource.
+func (c *listCache) invalidate(resource string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, e := range c.entries {
+		if e.resource == resource {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// listResource returns the resource of an API path, such as "voices" for
+// "/v1/voices/add".
+func listResource(path string) string {
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	if len(parts) < 2 {
+		return path
+	}
+	return parts[1]
+}
+
+// listCacheHTTPClient serves list requests from a listCache.
+type listCacheHTTPClient struct {
+	next   ht.Client
+	cache  *listCache
+	apiKey string
+	pooled bool // the API key comes from a KeyPool
+}
+
+// Do implements ht.Client interface.
+func (c *listCacheHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := c.next.Do(req)
+		if err == nil && resp.StatusCode < 300 {
+			c.cache.invalidate(listResource(req.URL.Path))
+		}
+		return resp, err
+	}
+	if !listCachePaths[req.URL.Path] {
+		return c.next.Do(req)
+	}
+
+	// Lists depend on the account, so cache them per API key. Pooled keys
+	// may belong to different accounts and are not known until the
+	// request is sent, so those requests are not cached.
+	apiKey := c.apiKey
+	if ro := requestOptionsFrom(req.Context()); ro != nil && ro.apiKey != "" {
+		apiKey = ro.apiKey
+	} else if c.pooled {
+		return c.next.Do(req)
+	}
+	key := apiKey + "\x00" + req.URL.String()
+
+	// Entries are replaced rather than modified, so they can be read
+	// without holding the lock
+	c.cache.mu.Lock()
+	entry := c.cache.entries[key]
+	fresh := entry != nil && c.cache.now().Sub(entry.fetched) < c.cache.ttl
+	c.cache.mu.Unlock()
+	if fresh {
+		return entry.response(req)
+	}
+
+	if entry != nil && entry.etag != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+	resp, err := c.next.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		resp.Body.Close()
+		// A 304 updates the stored headers, such as the request ID
+		revalidated := *entry
+		revalidated.header = entry.header.Clone()
+		for name, values := range resp.Header {
+			if name != "Content-Length" {
+				revalidated.header[name] = values
+			}
+		}
+		c.cache.mu.Lock()
+		revalidated.fetched = c.cache.now()
+		c.cache.entries[key] = &revalidated
+		c.cache.mu.Unlock()
+		return revalidated.response(req)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	c.cache.mu.Lock()
+	c.cache.entries[key] = &listCacheEntry{
+		resource: listResource(req.URL.Path),
+		header:   resp.Header.Clone(),
+		body:     body,
+		etag:     resp.Header.Get("ETag"),
+		fetched:  c.cache.now(),
+	}
+	c.cache.mu.Unlock()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// response returns a copy of the cached response for req, with the
+// request options applied as for a response from the API.
+func (e *listCacheEntry) response(req *http.Request) (*http.Response, error) {
+	resp := &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+	if err := finishResponse(req, resp, requestOptionsFrom(req.Context())); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
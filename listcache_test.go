@@ -0,0 +1,277 @@
+package elevenlabs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestListCache(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	notModified := 0
+	version := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests[r.Method+" "+r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/voices":
+			etag := fmt.Sprintf(`"v%d"`, version)
+			if r.Header.Get("If-None-Match") == etag {
+				notModified++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", etag)
+			voices := voiceMigrationJSON("a", "cloned", 0)
+			if version > 1 {
+				voices += ", " + voiceMigrationJSON("b", "cloned", 0)
+			}
+			fmt.Fprintf(w, `{"voices": [%s]}`, voices)
+		case r.Method == http.MethodDelete:
+			version++
+			fmt.Fprint(w, `{"status": "ok"}`)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithListCache(time.Minute))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	now := time.Now()
+	client.listCache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	list := func(want int) {
+		t.Helper()
+		voices, err := client.Voices().List(ctx)
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if len(voices) != want {
+			t.Errorf("List() = %d voices, want %d", len(voices), want)
+		}
+	}
+	count := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests["GET /v1/voices"]
+	}
+
+	list(1)
+	list(1)
+	if n := count(); n != 1 {
+		t.Errorf("requests = %d, want 1 within the TTL", n)
+	}
+
+	// After the TTL, an unchanged list is revalidated with its ETag
+	now = now.Add(2 * time.Minute)
+	list(1)
+	list(1)
+	if n := count(); n != 2 || notModified != 1 {
+		t.Errorf("requests = %d, 304s = %d, want 2 and 1", n, notModified)
+	}
+
+	// Changing a voice clears the cached voice lists
+	if err := client.Voices().Delete(ctx, "x"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	list(2)
+	if n := count(); n != 3 {
+		t.Errorf("requests = %d, want 3 after invalidation", n)
+	}
+
+	client.ClearListCache()
+	list(2)
+	if n := count(); n != 4 {
+		t.Errorf("requests = %d, want 4 after ClearListCache", n)
+	}
+
+	// Other API keys have their own cache entries
+	keyCtx := WithRequestOptions(ctx, WithRequestAPIKey("other-key"))
+	if _, err := client.Voices().List(keyCtx); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if n := count(); n != 5 {
+		t.Errorf("requests = %d, want 5 for another API key", n)
+	}
+}
+
+func TestListCacheDisabled(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"voices": []}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	for range 2 {
+		if _, err := client.Voices().List(context.Background()); err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2 without a cache", requests)
+	}
+	client.ClearListCache()
+}
+
+func TestListCacheKeyPool(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		// Each account has its own voice
+		fmt.Fprintf(w, `{"voices": [%s]}`, voiceMigrationJSON(r.Header.Get("xi-api-key"), "cloned", 0))
+	}))
+	defer server.Close()
+
+	pool, err := NewKeyPool([]string{"key-a", "key-b"})
+	if err != nil {
+		t.Fatalf("NewKeyPool() error = %v", err)
+	}
+	client, err := NewClient(WithKeyPool(pool), WithBaseURL(server.URL), WithListCache(time.Minute))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	// Round robin alternates accounts, so no list is shared between them
+	seen := map[string]bool{}
+	for range 2 {
+		voices, err := client.Voices().List(ctx)
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if len(voices) != 1 {
+			t.Fatalf("List() = %d voices, want 1", len(voices))
+		}
+		seen[voices[0].VoiceID] = true
+	}
+	if !seen["key-a"] || !seen["key-b"] || requests != 2 {
+		t.Errorf("voices = %v, requests = %d, want each account's voice from 2 requests", seen, requests)
+	}
+
+	// Requests with their own key are still cached
+	keyCtx := WithRequestOptions(ctx, WithRequestAPIKey("key-c"))
+	for range 2 {
+		if _, err := client.Voices().List(keyCtx); err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3 with a cached per-request key", requests)
+	}
+}
+
+func TestListCacheConcurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"voices": []}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithListCache(time.Nanosecond))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	// Every request revalidates the expired entry; run with -race
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 5 {
+				if _, err := client.Voices().List(context.Background()); err != nil {
+					t.Errorf("List() error = %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestListCacheRequestOptions(t *testing.T) {
+	body := `{"voices": [` + voiceMigrationJSON("a", "cloned", 0) + `]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set(headerRequestID, "req-"+r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithListCache(time.Minute))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	now := time.Now()
+	client.listCache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, err := client.Voices().List(ctx); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	check := func(name string) {
+		t.Helper()
+		var read int64
+		progressCtx := WithRequestOptions(ctx, WithDownloadProgress(func(n, _ int64) { read = n }))
+		if _, err := client.Voices().List(progressCtx); err != nil {
+			t.Fatalf("%s: List() error = %v", name, err)
+		}
+		if read != int64(len(body)) {
+			t.Errorf("%s: progress = %d bytes, want %d", name, read, len(body))
+		}
+
+		limitCtx := WithRequestOptions(ctx, WithMaxResponseSize(10))
+		if _, err := client.Voices().List(limitCtx); !errors.Is(err, ErrResponseTooLarge) {
+			t.Errorf("%s: List() error = %v, want %v", name, err, ErrResponseTooLarge)
+		}
+	}
+
+	// Cache hits within the TTL
+	check("cached")
+
+	// Revalidated responses, which carry the headers of the 304
+	now = now.Add(2 * time.Minute)
+	headerCtx, header := captureResponseHeaders(ctx)
+	if _, err := client.Voices().List(headerCtx); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if got := header.Get(headerRequestID); got != `req-"v1"` {
+		t.Errorf("request ID = %q, want the one from the 304", got)
+	}
+	if got := header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want the cached one", got)
+	}
+	now = now.Add(2 * time.Minute)
+	check("revalidated")
+}
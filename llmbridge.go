@@ -0,0 +1,140 @@
+package elevenlabs
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// maxSSELineSize bounds the length of one line in an SSE stream.
+const maxSSELineSize = 1 << 20
+
+// Writer returns a writer that speaks the text written to it. Text is sent
+// at sentence boundaries, as by SentenceSplitter, so writes may split
+// words or multi-byte characters. Close sends the remaining text and
+// flushes the connection; it does not close the connection.
+//
+//	w := conn.Writer()
+//	io.Copy(w, llmTextStream)
+//	w.Close()
+func (wsc *WebSocketTTSConnection) Writer(opts ...SentenceSplitterOption) io.WriteCloser {
+	return &ttsWriter{conn: wsc, splitter: wsc.SentenceSplitter(opts...)}
+}
+
+type ttsWriter struct {
+	mu       sync.Mutex
+	conn     *WebSocketTTSConnection
+	splitter *SentenceSplitter
+	partial  []byte
+	closed   bool
+}
+
+func (w *ttsWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, ErrWebSocketClosed
+	}
+
+	// Hold back a trailing incomplete UTF-8 sequence until the next write
+	data := append(w.partial, p...)
+	cut := len(data)
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				cut = i
+			}
+			break
+		}
+	}
+	w.partial = append([]byte(nil), data[cut:]...)
+
+	if err := w.splitter.Add(string(data[:cut])); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *ttsWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if err := w.splitter.Add(string(w.partial)); err != nil {
+		return err
+	}
+	w.partial = nil
+	if err := w.splitter.Flush(); err != nil {
+		return err
+	}
+	return w.conn.Flush()
+}
+
+// sseChunk is a chunk of an OpenAI-style chat completion stream.
+type sseChunk struct {
+	Choices []struct {
+		Index int `json:"index"`
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+// BridgeSSE speaks an OpenAI-style chat completion stream, read from r as
+// server-sent events, until the "[DONE]" event or the end of r. It then
+// flushes the connection. Use it with the body of a streaming response
+// from OpenAI or any compatible API:
+//
+//	resp, err := http.DefaultClient.Do(chatCompletionRequest) // "stream": true
+//	defer resp.Body.Close()
+//	err = conn.BridgeSSE(ctx, resp.Body)
+//
+// BridgeSSE returns when ctx is canceled, but a blocked read from r only
+// returns when r is closed, so r should be tied to ctx as response bodies
+// are.
+func (wsc *WebSocketTTSConnection) BridgeSSE(ctx context.Context, r io.Reader, opts ...SentenceSplitterOption) error {
+	w := wsc.Writer(opts...)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSSELineSize)
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			// Event names, ids, comments, and blank separator lines
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk sseChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("decoding stream event: %w", err)
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Index != 0 {
+				// Only the first choice is spoken
+				continue
+			}
+			if _, err := io.WriteString(w, choice.Delta.Content); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading stream: %w", err)
+	}
+	return w.Close()
+}
@@ -0,0 +1,127 @@
+package elevenlabs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// newWebSocketTTSRecordingServer starts a server that records the text of
+// each message until a flush, then sends the recorded texts on the
+// returned channel.
+func newWebSocketTTSRecordingServer(t *testing.T) (*Client, <-chan []string) {
+	t.Helper()
+	flushed := make(chan []string, 1)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Skip the initial message
+		var msg ttsWSMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		var texts []string
+		for {
+			var msg ttsWSMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg.Flush {
+				flushed <- texts
+				return
+			}
+			texts = append(texts, msg.Text)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client, flushed
+}
+
+func TestWebSocketTTSWriter(t *testing.T) {
+	client, flushed := newWebSocketTTSRecordingServer(t)
+	conn, err := client.WebSocketTTS().Connect(context.Background(), "voice-id", nil)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer conn.Close()
+
+	w := conn.Writer(WithMinChunkSize(1))
+	// "é" is split across writes
+	text := []byte("Café open. Come in")
+	for _, part := range [][]byte{text[:4], text[4:8], text[8:]} {
+		if _, err := w.Write(part); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := w.Write([]byte("more")); err == nil {
+		t.Error("Write() after Close error = nil")
+	}
+
+	want := []string{"Café open. ", "Come in"}
+	if got := <-flushed; !reflect.DeepEqual(got, want) {
+		t.Errorf("sent %q, want %q", got, want)
+	}
+}
+
+func TestWebSocketTTSBridgeSSE(t *testing.T) {
+	client, flushed := newWebSocketTTSRecordingServer(t)
+	conn, err := client.WebSocketTTS().Connect(context.Background(), "voice-id", nil)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer conn.Close()
+
+	stream := strings.Join([]string{
+		`: keep-alive`,
+		``,
+		`data: {"choices":[{"index":0,"delta":{"role":"assistant"}}]}`,
+		``,
+		`data: {"choices":[{"index":0,"delta":{"content":"Hello the"}}]}`,
+		``,
+		`data: {"choices":[{"index":0,"delta":{"content":"re. How are"}},{"index":1,"delta":{"content":"ignored"}}]}`,
+		``,
+		`data: {"choices":[{"index":0,"delta":{"content":" you?"}}]}`,
+		``,
+		`data: [DONE]`,
+		``,
+	}, "\n")
+	if err := conn.BridgeSSE(context.Background(), strings.NewReader(stream), WithMinChunkSize(1)); err != nil {
+		t.Fatalf("BridgeSSE() error = %v", err)
+	}
+
+	want := []string{"Hello there. ", "How are you?"}
+	if got := <-flushed; !reflect.DeepEqual(got, want) {
+		t.Errorf("sent %q, want %q", got, want)
+	}
+}
+
+func TestWebSocketTTSBridgeSSEInvalidEvent(t *testing.T) {
+	client, _ := newWebSocketTTSRecordingServer(t)
+	conn, err := client.WebSocketTTS().Connect(context.Background(), "voice-id", nil)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.BridgeSSE(context.Background(), strings.NewReader("data: {not json\n")); err == nil {
+		t.Error("BridgeSSE() error = nil, want decode error")
+	}
+}
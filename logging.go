@@ -0,0 +1,285 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// EndpointClass groups endpoints for logging, so noisy classes can be
+// logged at a different level than the rest.
+type EndpointClass string
+
+// Endpoint classes.
+const (
+	// EndpointClassRead is GET and HEAD requests that do not return
+	// audio, such as listing voices.
+	EndpointClassRead EndpointClass = "read"
+
+	// EndpointClassWrite is requests that change state, such as creating
+	// an agent.
+	EndpointClassWrite EndpointClass = "write"
+
+	// EndpointClassAudio is requests that generate, transcribe, or
+	// download audio.
+	EndpointClassAudio EndpointClass = "audio"
+)
+
+// LogLevelOff disables logging for an endpoint class when passed to
+// WithLogLevel.
+const LogLevelOff = slog.Level(math.MaxInt32)
+
+// logBodyLimit is how much of a text request or response body is logged.
+const logBodyLimit = 512
+
+// redacted replaces secrets in logs.
+const redacted = "[REDACTED]"
+
+// WithLogger logs each request and response to logger, at debug level by
+// default. The API key is redacted and audio and other binary bodies are
+// elided; text bodies are truncated. Failed requests are logged at warn
+// level or higher.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+// WithLogLevel sets the level requests of an endpoint class are logged at,
+// for example slog.LevelInfo to see audio generations without debug
+// output, or LogLevelOff to skip polling reads.
+func WithLogLevel(class EndpointClass, level slog.Level) Option {
+	return func(o *clientOptions) {
+		if o.logLevels == nil {
+			o.logLevels = make(map[EndpointClass]slog.Level)
+		}
+		o.logLevels[class] = level
+	}
+}
+
+// requestLogger logs HTTP requests.
+type requestLogger struct {
+	logger  *slog.Logger
+	levels  map[EndpointClass]slog.Level
+	secrets []string
+}
+
+// newRequestLogger returns a logger for the options, or nil if logging is
+// not enabled.
+func newRequestLogger(o *clientOptions) *requestLogger {
+	if o.logger == nil {
+		return nil
+	}
+	l := &requestLogger{logger: o.logger, levels: o.logLevels}
+	if o.apiKey != "" {
+		l.secrets = append(l.secrets, o.apiKey)
+	}
+	if o.keyPool != nil {
+		for _, k := range o.keyPool.keys {
+			l.secrets = append(l.secrets, k.key)
+		}
+	}
+	return l
+}
+
+// wrap returns send with each request logged.
+func (l *requestLogger) wrap(send func(*http.Request) (*http.Response, error)) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		class := endpointClass(req)
+		level, ok := l.levels[class]
+		if !ok {
+			level = slog.LevelDebug
+		}
+		if level == LogLevelOff {
+			return send(req)
+		}
+		ctx := req.Context()
+
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("path", req.URL.Path),
+			slog.String("class", string(class)),
+		}
+		if req.URL.RawQuery != "" {
+			attrs = append(attrs, slog.String("query", l.redact(req.URL.RawQuery)))
+		}
+		attrs = append(attrs, slog.Any("request_headers", l.headers(req.Header)))
+		if l.logger.Enabled(ctx, level) {
+			attrs = append(attrs, slog.String("request_body", l.requestBody(req)))
+		}
+
+		start := time.Now()
+		resp, err := send(req)
+		attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+		if err != nil {
+			attrs = append(attrs, slog.String("error", l.redact(err.Error())))
+			l.logger.LogAttrs(ctx, max(level, slog.LevelWarn), "elevenlabs: request failed", attrs...)
+			return nil, err
+		}
+
+		if resp.StatusCode >= 400 {
+			level = max(level, slog.LevelWarn)
+		}
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if id := resp.Header.Get(headerRequestID); id != "" {
+			attrs = append(attrs, slog.String("request_id", id))
+		}
+		if l.logger.Enabled(ctx, level) {
+			attrs = append(attrs, slog.String("response_body", l.responseBody(resp)))
+		}
+		l.logger.LogAttrs(ctx, level, "elevenlabs: request", attrs...)
+		return resp, nil
+	}
+}
+
+// endpointClass returns the class of a request.
+func endpointClass(req *http.Request) EndpointClass {
+	path := req.URL.Path
+	if isAudioEndpoint(path) || strings.Contains(path, "/speech-to-text") || strings.HasSuffix(path, "/audio") {
+		return EndpointClassAudio
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return EndpointClassRead
+	}
+	return EndpointClassWrite
+}
+
+// headers returns the request headers with credentials redacted.
+func (l *requestLogger) headers(h http.Header) slog.Value {
+	attrs := make([]slog.Attr, 0, len(h))
+	for name, values := range h {
+		value := strings.Join(values, ", ")
+		switch http.CanonicalHeaderKey(name) {
+		case "Xi-Api-Key", "Authorization", "Cookie":
+			value = redacted
+		default:
+			value = l.redact(value)
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// requestBody summarizes a request body without consuming it. Bodies that
+// cannot be re-read, such as streamed uploads, are not read.
+func (l *requestLogger) requestBody(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody {
+		return ""
+	}
+	if elided, ok := elideBody(req.Header, req.ContentLength); ok {
+		return elided
+	}
+	if req.GetBody == nil {
+		return "<streamed>"
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "<unavailable>"
+	}
+	defer body.Close()
+	return l.summarize(body)
+}
+
+// responseBody summarizes a text response body, leaving it readable by the
+// caller.
+func (l *requestLogger) responseBody(resp *http.Response) string {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return ""
+	}
+	if elided, ok := elideBody(resp.Header, resp.ContentLength); ok {
+		return elided
+	}
+	head, err := io.ReadAll(io.LimitReader(resp.Body, logBodyLimit+1))
+	resp.Body = &prefixedBody{Reader: io.MultiReader(bytes.NewReader(head), resp.Body), Closer: resp.Body}
+	if err != nil {
+		return "<unavailable>"
+	}
+	return l.summarize(bytes.NewReader(head))
+}
+
+// summarize returns the beginning of a text body with secrets redacted.
+func (l *requestLogger) summarize(r io.Reader) string {
+	head, _ := io.ReadAll(io.LimitReader(r, logBodyLimit+1))
+	if len(head) <= logBodyLimit {
+		return l.redact(string(head))
+	}
+	head = head[:logBodyLimit]
+	for !utf8.Valid(head) && len(head) > 0 {
+		head = head[:len(head)-1]
+	}
+	return l.redact(string(head)) + "..."
+}
+
+// redact replaces the client's API keys in s.
+func (l *requestLogger) redact(s string) string {
+	for _, secret := range l.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return s
+}
+
+// elideBody returns a placeholder for bodies that are not logged: audio,
+// multipart uploads, and other binary or compressed content.
+func elideBody(h http.Header, length int64) (string, bool) {
+	contentType := h.Get("Content-Type")
+	if isTextMediaType(contentMediaType(h)) && h.Get("Content-Encoding") == "" {
+		return "", false
+	}
+	if contentType == "" {
+		contentType = "unknown"
+	}
+	if enc := h.Get("Content-Encoding"); enc != "" {
+		contentType += "; " + enc
+	}
+	if length >= 0 {
+		return "<" + contentType + ", " + strconv.FormatInt(length, 10) + " bytes>", true
+	}
+	return "<" + contentType + ">", true
+}
+
+// contentMediaType returns the media type of the Content-Type header.
+func contentMediaType(h http.Header) string {
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	return mediaType
+}
+
+// isTextMediaType reports whether bodies of mediaType are stored as text.
+func isTextMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasPrefix(mediaType, "text/") ||
+		mediaType == "application/x-www-form-urlencoded"
+}
+
+// prefixedBody is a response body whose beginning was read for logging.
+type prefixedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// audioPathFragments are path fragments of endpoints that generate or
+// process audio, including those that respond with audio in JSON.
+var audioPathFragments = []string{
+	"/text-to-speech/",
+	"/text-to-dialogue",
+	"/speech-to-speech/",
+	"/sound-generation",
+	"/audio-isolation",
+	"/music",
+}
+
+// isAudioEndpoint reports whether path is an audio generation endpoint.
+func isAudioEndpoint(path string) bool {
+	for _, fragment := range audioPathFragments {
+		if strings.Contains(path, fragment) {
+			return true
+		}
+	}
+	return false
+}
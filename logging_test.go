@@ -0,0 +1,159 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithLogger(t *testing.T) {
+	audio := bytes.Repeat([]byte{0xff, 0xfb}, 512)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v1/text-to-speech/") {
+			w.Header().Set("Content-Type", "audio/mpeg")
+			_, _ = w.Write(audio)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"voices":[]}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, err := NewClient(WithAPIKey("sk_secret"), WithBaseURL(server.URL), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := client.Voices().List(ctx); err != nil {
+		t.Fatalf("Voices().List() error = %v", err)
+	}
+	resp, err := client.TextToSpeech().Generate(ctx, &TTSRequest{VoiceID: "voice", Text: "Hello there"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	got, _ := io.ReadAll(resp.Audio)
+	if !bytes.Equal(got, audio) {
+		t.Errorf("audio = %d bytes, want %d", len(got), len(audio))
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "sk_secret") {
+		t.Errorf("log contains the API key:\n%s", output)
+	}
+	records := decodeLogRecords(t, output)
+	if len(records) != 2 {
+		t.Fatalf("logged %d records, want 2:\n%s", len(records), output)
+	}
+
+	list := records[0]
+	if list["class"] != string(EndpointClassRead) || list["response_body"] != `{"voices":[]}` {
+		t.Errorf("list record = %v", list)
+	}
+	if headers, _ := list["request_headers"].(map[string]any); headers["Xi-Api-Key"] != redacted {
+		t.Errorf("request_headers = %v", list["request_headers"])
+	}
+
+	tts := records[1]
+	if tts["class"] != string(EndpointClassAudio) || tts["level"] != "DEBUG" {
+		t.Errorf("TTS record = %v", tts)
+	}
+	if body, _ := tts["request_body"].(string); !strings.Contains(body, "Hello there") {
+		t.Errorf("request_body = %q", body)
+	}
+	if body, _ := tts["response_body"].(string); !strings.HasPrefix(body, "<audio/mpeg") {
+		t.Errorf("response_body = %q, want elided audio", body)
+	}
+}
+
+func TestWithLogLevel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/models" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"detail": {"status": "invalid_api_key", "message": "Invalid API key"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"voices":[]}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	client, err := NewClient(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithLogger(logger),
+		WithLogLevel(EndpointClassRead, LogLevelOff),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	// Disabled classes are not logged
+	if _, err := client.Voices().List(ctx); err != nil {
+		t.Fatalf("Voices().List() error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("logged a disabled class:\n%s", buf.String())
+	}
+
+	client, err = NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	// Successful requests are below the handler level, failures are warnings
+	_, _ = client.Voices().List(ctx)
+	_, _ = client.Models().List(ctx)
+	records := decodeLogRecords(t, buf.String())
+	if len(records) != 1 || records[0]["level"] != "WARN" || records[0]["status"] != float64(http.StatusUnauthorized) {
+		t.Errorf("records = %v", records)
+	}
+}
+
+func TestEndpointClass(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   EndpointClass
+	}{
+		{http.MethodGet, "/v1/voices", EndpointClassRead},
+		{http.MethodPost, "/v1/convai/agents/create", EndpointClassWrite},
+		{http.MethodDelete, "/v1/voices/abc", EndpointClassWrite},
+		{http.MethodPost, "/v1/text-to-speech/abc/stream", EndpointClassAudio},
+		{http.MethodPost, "/v1/speech-to-text", EndpointClassAudio},
+		{http.MethodGet, "/v1/history/abc/audio", EndpointClassAudio},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if got := endpointClass(req); got != tt.want {
+			t.Errorf("endpointClass(%s %s) = %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func decodeLogRecords(t *testing.T, output string) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("decode log record: %v", err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
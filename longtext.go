@@ -0,0 +1,281 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// DefaultLongTextMaxChars is the chunk size GenerateLong uses when the
+// model does not report a maximum text length.
+const DefaultLongTextMaxChars = 5000
+
+// maxStitchingRequestIDs is the most previous request IDs the API accepts.
+const maxStitchingRequestIDs = 3
+
+// LongTextOptions configures GenerateLong.
+type LongTextOptions struct {
+	// MaxChars is the longest chunk in characters. Default: the model's
+	// MaxTextLength from Models().List.
+	MaxChars int
+
+	// Concurrency is the number of chunks generated at once. Zero or one
+	// generates chunks in order, stitching each to the request IDs of the
+	// chunks before it. Higher values are faster but stitch with the
+	// neighbouring text only, since request IDs are not known yet.
+	Concurrency int
+}
+
+// LongTTSResponse is the audio generated by GenerateLong.
+type LongTTSResponse struct {
+	// Audio is the audio of all chunks, in order.
+	Audio io.Reader
+
+	// Chunks are the responses of the chunks, in order. Each chunk's
+	// Audio can be read independently of Audio.
+	Chunks []*TTSResponse
+
+	// Texts are the texts of the chunks, in order.
+	Texts []string
+
+	// CharacterCount is the total number of characters billed.
+	CharacterCount int
+
+	// Credits is the total credits of the chunks. Set only if the client
+	// has pricing.
+	Credits float64
+
+	// EstimatedCost is the total estimated dollar cost of the chunks. Set
+	// only if the client has pricing.
+	EstimatedCost float64
+
+	// ContentType is the MIME type of the audio (e.g., "audio/mpeg").
+	ContentType string
+
+	// OutputFormat is the format of the audio (e.g., "mp3_44100_128").
+	OutputFormat string
+}
+
+// GenerateLong generates speech from text longer than a single request
+// allows. The text is split on sentence boundaries into chunks of at most
+// opts.MaxChars characters, each chunk is generated with the text around
+// it as PreviousText and NextText so the prosody carries across, and the
+// audio is joined into one stream:
+//
+//	resp, err := client.TextToSpeech().GenerateLong(ctx, &elevenlabs.TTSRequest{
+//	    VoiceID: voiceID,
+//	    Text:    chapter,
+//	}, nil)
+//	if err != nil {
+//	    return err
+//	}
+//	saved, err := elevenlabs.SaveAudio("chapter1.mp3", resp.Audio)
+//
+// Chunk audio is joined byte for byte, which suits MP3, PCM, μ-law, and
+// A-law output but not Opus. PreviousText and NextText in req are used
+// for the first and last chunk. If a chunk fails, GenerateLong returns its
+// error and no audio.
+func (s *TextToSpeechService) GenerateLong(ctx context.Context, req *TTSRequest, opts *LongTextOptions) (*LongTTSResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &LongTextOptions{}
+	}
+	if opts.MaxChars < 0 {
+		return nil, &ValidationError{Field: "MaxChars", Message: "cannot be negative"}
+	}
+
+	maxChars := opts.MaxChars
+	if maxChars == 0 {
+		var err error
+		if maxChars, err = s.maxTextLength(ctx, req.ModelID); err != nil {
+			return nil, fmt.Errorf("looking up model limit: %w", err)
+		}
+	}
+
+	texts := splitLongText(req.text(), maxChars)
+	if len(texts) == 0 {
+		return nil, ErrEmptyText
+	}
+	reqs := make([]*TTSRequest, len(texts))
+	for i, text := range texts {
+		chunk := *req
+		chunk.Text = text
+		chunk.Normalize = false
+		chunk.Pronunciations = nil
+		if i > 0 {
+			chunk.PreviousText = texts[i-1]
+		}
+		if i < len(texts)-1 {
+			chunk.NextText = texts[i+1]
+		}
+		reqs[i] = &chunk
+	}
+
+	var chunks []*TTSResponse
+	var err error
+	if opts.Concurrency <= 1 {
+		chunks, err = s.generateSequential(ctx, reqs)
+	} else {
+		chunks, err = s.generateParallel(ctx, reqs, opts.Concurrency)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := &LongTTSResponse{Chunks: chunks, Texts: texts}
+	readers := make([]io.Reader, len(chunks))
+	for i, chunk := range chunks {
+		data := chunk.Audio.(*bytes.Reader)
+		readers[i] = io.NewSectionReader(data, 0, data.Size())
+		out.CharacterCount += chunk.CharacterCount
+		out.Credits += chunk.Credits
+		out.EstimatedCost += chunk.EstimatedCost
+	}
+	out.Audio = io.MultiReader(readers...)
+	out.ContentType = chunks[0].ContentType
+	out.OutputFormat = chunks[0].OutputFormat
+	return out, nil
+}
+
+// maxTextLength returns the maximum text length of a model, or
+// DefaultLongTextMaxChars if the model is not listed or does not report
+// one.
+func (s *TextToSpeechService) maxTextLength(ctx context.Context, modelID string) (int, error) {
+	if modelID == "" {
+		modelID = DefaultModelID
+	}
+	models, err := s.client.Models().List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, m := range models {
+		if m.ModelID == modelID && m.MaxTextLength > 0 {
+			return m.MaxTextLength, nil
+		}
+	}
+	return DefaultLongTextMaxChars, nil
+}
+
+// generateSequential generates chunks in order, stitching each to the
+// request IDs of the chunks before it.
+func (s *TextToSpeechService) generateSequential(ctx context.Context, reqs []*TTSRequest) ([]*TTSResponse, error) {
+	chunks := make([]*TTSResponse, len(reqs))
+	var requestIDs []string
+	for i, req := range reqs {
+		req.PreviousRequestIDs = requestIDs[max(0, len(requestIDs)-maxStitchingRequestIDs):]
+		resp, err := s.generateChunk(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("chunk %d of %d: %w", i+1, len(reqs), err)
+		}
+		chunks[i] = resp
+		if resp.RequestID != "" {
+			requestIDs = append(requestIDs, resp.RequestID)
+		}
+	}
+	return chunks, nil
+}
+
+// generateParallel generates chunks with at most concurrency at once and
+// returns them in order. The first failure cancels the rest.
+func (s *TextToSpeechService) generateParallel(ctx context.Context, reqs []*TTSRequest, concurrency int) ([]*TTSResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunks := make([]*TTSResponse, len(reqs))
+	errs := make([]error, len(reqs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			chunks[i], errs[i] = s.generateChunk(ctx, req)
+			if errs[i] != nil {
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Report the failure that caused the cancellation, not the chunks it
+	// canceled
+	var first error
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		err = fmt.Errorf("chunk %d of %d: %w", i+1, len(reqs), err)
+		if first == nil {
+			first = err
+		}
+		if !errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+	}
+	if first != nil {
+		return nil, first
+	}
+	return chunks, nil
+}
+
+// generateChunk generates one chunk and reads its audio into memory.
+func (s *TextToSpeechService) generateChunk(ctx context.Context, req *TTSRequest) (*TTSResponse, error) {
+	resp, err := s.Generate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Audio)
+	if err != nil {
+		return nil, fmt.Errorf("reading audio: %w", err)
+	}
+	resp.Audio = bytes.NewReader(data)
+	return resp, nil
+}
+
+// splitLongText splits text into chunks of at most limit characters,
+// preferring sentence boundaries, then clause breaks, then spaces.
+// Whitespace around chunks is trimmed.
+func splitLongText(text string, limit int) []string {
+	var chunks []string
+	rest := strings.TrimSpace(text)
+	for rest != "" {
+		if utf8.RuneCountInString(rest) <= limit {
+			chunks = append(chunks, rest)
+			break
+		}
+
+		// Byte offset of the first limit characters
+		head := 0
+		for range limit {
+			_, size := utf8.DecodeRuneInString(rest[head:])
+			head += size
+		}
+
+		cut := 0
+		for i := 1; i < head; i++ {
+			if end := sentenceEnd(rest, i); end > 0 {
+				cut = end
+			}
+		}
+		if cut == 0 {
+			cut = splitPoint(rest, head)
+		}
+		chunks = append(chunks, strings.TrimSpace(rest[:cut]))
+		rest = strings.TrimSpace(rest[cut:])
+	}
+	return chunks
+}
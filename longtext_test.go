@@ -0,0 +1,247 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitLongText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{
+			name:  "fits",
+			text:  "  One sentence.  ",
+			limit: 100,
+			want:  []string{"One sentence."},
+		},
+		{
+			name:  "sentence boundaries",
+			text:  "First one. Second one. Third one here.",
+			limit: 25,
+			want:  []string{"First one. Second one.", "Third one here."},
+		},
+		{
+			name:  "abbreviations",
+			text:  "Ask Dr. Smith today. Then leave.",
+			limit: 25,
+			want:  []string{"Ask Dr. Smith today.", "Then leave."},
+		},
+		{
+			name:  "clause break",
+			text:  "no sentence ends here, so split at the comma",
+			limit: 30,
+			want:  []string{"no sentence ends here,", "so split at the comma"},
+		},
+		{
+			name:  "characters not bytes",
+			text:  "Größe ändern. Übung macht.",
+			limit: 14,
+			want:  []string{"Größe ändern.", "Übung macht."},
+		},
+		{
+			name:  "empty",
+			text:  "   ",
+			limit: 10,
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitLongText(tt.text, tt.limit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitLongText() = %q, want %q", got, tt.want)
+			}
+			for _, chunk := range got {
+				if n := utf8.RuneCountInString(chunk); n > tt.limit {
+					t.Errorf("chunk %q has %d characters, limit %d", chunk, n, tt.limit)
+				}
+			}
+		})
+	}
+}
+
+// longTextBody is the part of a TTS request body checked by the
+// GenerateLong tests.
+type longTextBody struct {
+	Text               string   `json:"text"`
+	PreviousText       *string  `json:"previous_text"`
+	NextText           *string  `json:"next_text"`
+	PreviousRequestIDs []string `json:"previous_request_ids"`
+}
+
+// newLongTextServer returns a client whose TTS endpoint answers each chunk
+// with "[text]" and a request ID derived from the text, and records the
+// request bodies. Chunks containing "fail" get a 500.
+func newLongTextServer(t *testing.T) (*Client, func() []longTextBody) {
+	t.Helper()
+	var mu sync.Mutex
+	var bodies []longTextBody
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body longTextBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+		if strings.Contains(body.Text, "fail") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Header().Set("request-id", "req-"+strings.Fields(body.Text)[0])
+		w.Header().Set("x-character-count", fmt.Sprint(len(body.Text)))
+		fmt.Fprintf(w, "[%s]", body.Text)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client, func() []longTextBody {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]longTextBody(nil), bodies...)
+	}
+}
+
+const longTestText = "Alpha one. Bravo two. Charlie three. Delta four. Echo five."
+
+func TestTextToSpeechGenerateLong(t *testing.T) {
+	client, bodies := newLongTextServer(t)
+
+	resp, err := client.TextToSpeech().GenerateLong(context.Background(),
+		&TTSRequest{VoiceID: "voice-1", Text: longTestText}, &LongTextOptions{MaxChars: 12})
+	if err != nil {
+		t.Fatalf("GenerateLong() error = %v", err)
+	}
+
+	wantTexts := []string{"Alpha one.", "Bravo two.", "Charlie", "three.", "Delta four.", "Echo five."}
+	if !reflect.DeepEqual(resp.Texts, wantTexts) {
+		t.Fatalf("Texts = %q, want %q", resp.Texts, wantTexts)
+	}
+	audio, _ := io.ReadAll(resp.Audio)
+	if want := "[Alpha one.][Bravo two.][Charlie][three.][Delta four.][Echo five.]"; string(audio) != want {
+		t.Errorf("Audio = %q, want %q", audio, want)
+	}
+	chunkAudio, _ := io.ReadAll(resp.Chunks[1].Audio)
+	if string(chunkAudio) != "[Bravo two.]" {
+		t.Errorf("Chunks[1].Audio = %q", chunkAudio)
+	}
+	if want := len(strings.Join(wantTexts, "")); resp.CharacterCount != want {
+		t.Errorf("CharacterCount = %d, want %d", resp.CharacterCount, want)
+	}
+	if resp.OutputFormat != DefaultOutputFormat {
+		t.Errorf("OutputFormat = %q", resp.OutputFormat)
+	}
+
+	sent := bodies()
+	if len(sent) != len(wantTexts) {
+		t.Fatalf("sent %d requests, want %d", len(sent), len(wantTexts))
+	}
+	if sent[0].PreviousText != nil || len(sent[0].PreviousRequestIDs) != 0 {
+		t.Errorf("first chunk has previous context: %+v", sent[0])
+	}
+	if sent[0].NextText == nil || *sent[0].NextText != "Bravo two." {
+		t.Errorf("first chunk next_text = %v", sent[0].NextText)
+	}
+	if sent[2].PreviousText == nil || *sent[2].PreviousText != "Bravo two." {
+		t.Errorf("third chunk previous_text = %v", sent[2].PreviousText)
+	}
+	if want := []string{"req-Charlie", "req-three.", "req-Delta"}; !reflect.DeepEqual(sent[5].PreviousRequestIDs, want) {
+		t.Errorf("last chunk previous_request_ids = %q, want %q", sent[5].PreviousRequestIDs, want)
+	}
+	if sent[5].NextText != nil {
+		t.Errorf("last chunk next_text = %q", *sent[5].NextText)
+	}
+}
+
+func TestTextToSpeechGenerateLongParallel(t *testing.T) {
+	client, bodies := newLongTextServer(t)
+
+	resp, err := client.TextToSpeech().GenerateLong(context.Background(),
+		&TTSRequest{VoiceID: "voice-1", Text: longTestText}, &LongTextOptions{MaxChars: 12, Concurrency: 3})
+	if err != nil {
+		t.Fatalf("GenerateLong() error = %v", err)
+	}
+	audio, _ := io.ReadAll(resp.Audio)
+	if want := "[Alpha one.][Bravo two.][Charlie][three.][Delta four.][Echo five.]"; string(audio) != want {
+		t.Errorf("Audio = %q, want %q", audio, want)
+	}
+	for _, body := range bodies() {
+		if len(body.PreviousRequestIDs) != 0 {
+			t.Errorf("parallel chunk %q sent previous_request_ids", body.Text)
+		}
+	}
+}
+
+func TestTextToSpeechGenerateLongErrors(t *testing.T) {
+	client, _ := newLongTextServer(t)
+	ctx := context.Background()
+	text := "Alpha one. Bravo fail. Carl six."
+
+	for _, concurrency := range []int{0, 2} {
+		_, err := client.TextToSpeech().GenerateLong(ctx,
+			&TTSRequest{VoiceID: "voice-1", Text: text}, &LongTextOptions{MaxChars: 12, Concurrency: concurrency})
+		if err == nil || !strings.Contains(err.Error(), "chunk 2 of 3") {
+			t.Errorf("concurrency %d: error = %v, want chunk 2 failure", concurrency, err)
+		}
+	}
+
+	var valErr *ValidationError
+	_, err := client.TextToSpeech().GenerateLong(ctx, &TTSRequest{VoiceID: "voice-1", Text: text}, &LongTextOptions{MaxChars: -1})
+	if !isValidationError(err, &valErr) {
+		t.Errorf("negative MaxChars error = %v, want ValidationError", err)
+	}
+	if _, err := client.TextToSpeech().GenerateLong(ctx, &TTSRequest{VoiceID: "voice-1"}, nil); err != ErrEmptyText {
+		t.Errorf("empty text error = %v, want ErrEmptyText", err)
+	}
+}
+
+func TestTextToSpeechGenerateLongModelLimit(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/models" {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `[{"model_id": "tiny_model", "name": "Tiny", "description": "", "can_be_finetuned": false,
+				"can_do_text_to_speech": true, "can_do_voice_conversion": false, "can_use_style": true,
+				"can_use_speaker_boost": true, "serves_pro_voices": false, "token_cost_factor": 1,
+				"requires_alpha_access": false, "max_characters_request_free_user": 2500,
+				"max_characters_request_subscribed_user": 5000, "maximum_text_length_per_request": 12,
+				"concurrency_group": "standard", "model_rates": {"character_cost_multiplier": 1},
+				"languages": []}]`)
+			return
+		}
+		requests++
+		w.Header().Set("Content-Type", "audio/mpeg")
+		fmt.Fprint(w, "x")
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	resp, err := client.TextToSpeech().GenerateLong(context.Background(),
+		&TTSRequest{VoiceID: "voice-1", ModelID: "tiny_model", Text: longTestText}, nil)
+	if err != nil {
+		t.Fatalf("GenerateLong() error = %v", err)
+	}
+	if len(resp.Texts) != 6 || requests != 6 {
+		t.Errorf("got %d chunks in %d requests, want 6 with the model's 12-character limit", len(resp.Texts), requests)
+	}
+}
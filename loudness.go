@@ -0,0 +1,173 @@
+package elevenlabs
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Common loudness targets in LUFS.
+const (
+	// LoudnessBroadcast is the EBU R 128 target for broadcast.
+	LoudnessBroadcast = -23.0
+
+	// LoudnessPodcast is a common target for podcasts and spoken audio.
+	LoudnessPodcast = -16.0
+
+	// LoudnessStreaming is a common target for music streaming services.
+	LoudnessStreaming = -14.0
+)
+
+// MeasureLoudness returns the integrated loudness of 16-bit signed
+// little-endian mono PCM in LUFS, as defined by ITU-R BS.1770 with EBU R 128
+// gating. Silence returns negative infinity.
+func MeasureLoudness(pcm []byte, sampleRate int) (float64, error) {
+	samples, err := pcmSamples(pcm, sampleRate)
+	if err != nil {
+		return 0, err
+	}
+	return integratedLoudness(samples, sampleRate), nil
+}
+
+// NormalizeLoudness returns a copy of 16-bit signed little-endian mono PCM
+// with its gain adjusted to targetLUFS, so segments synthesized with
+// different voices or settings play at the same loudness when assembled.
+// Samples that would clip are limited to full scale. Silence is returned
+// unchanged.
+//
+//	resp, _ := client.TextToSpeech().Generate(ctx, &elevenlabs.TTSRequest{
+//	    VoiceID:      voiceID,
+//	    Text:         text,
+//	    OutputFormat: "pcm_44100",
+//	})
+//	pcm, _ := io.ReadAll(resp.Audio)
+//	pcm, err := elevenlabs.NormalizeLoudness(pcm, 44100, elevenlabs.LoudnessBroadcast)
+func NormalizeLoudness(pcm []byte, sampleRate int, targetLUFS float64) ([]byte, error) {
+	samples, err := pcmSamples(pcm, sampleRate)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, len(pcm))
+	copy(out, pcm)
+	loudness := integratedLoudness(samples, sampleRate)
+	if math.IsInf(loudness, -1) {
+		return out, nil
+	}
+
+	gain := math.Pow(10, (targetLUFS-loudness)/20)
+	for i, s := range samples {
+		v := math.Round(s * gain * 32768)
+		v = math.Max(math.MinInt16, math.Min(math.MaxInt16, v))
+		binary.LittleEndian.PutUint16(out[2*i:], uint16(int16(v)))
+	}
+	return out, nil
+}
+
+// biquad is a second-order IIR filter.
+type biquad struct {
+	b0, b1, b2, a1, a2 float64
+	x1, x2, y1, y2     float64
+}
+
+func (f *biquad) process(x float64) float64 {
+	y := f.b0*x + f.b1*f.x1 + f.b2*f.x2 - f.a1*f.y1 - f.a2*f.y2
+	f.x2, f.x1 = f.x1, x
+	f.y2, f.y1 = f.y1, y
+	return y
+}
+
+// kWeighting returns the BS.1770 K-weighting filters (a high shelf
+// modelling the head, then a high pass) for the sample rate.
+func kWeighting(sampleRate int) (shelf, highPass *biquad) {
+	fs := float64(sampleRate)
+
+	// High shelf: +4 dB above 1.5 kHz
+	a := math.Pow(10, 4.0/40)
+	w0 := 2 * math.Pi * 1500 / fs
+	alpha := math.Sin(w0) / (2 / math.Sqrt2)
+	cos := math.Cos(w0)
+	a0 := (a + 1) - (a-1)*cos + 2*math.Sqrt(a)*alpha
+	shelf = &biquad{
+		b0: a * ((a + 1) + (a-1)*cos + 2*math.Sqrt(a)*alpha) / a0,
+		b1: -2 * a * ((a - 1) + (a+1)*cos) / a0,
+		b2: a * ((a + 1) + (a-1)*cos - 2*math.Sqrt(a)*alpha) / a0,
+		a1: 2 * ((a - 1) - (a+1)*cos) / a0,
+		a2: ((a + 1) - (a-1)*cos - 2*math.Sqrt(a)*alpha) / a0,
+	}
+
+	// High pass at 38 Hz
+	w0 = 2 * math.Pi * 38 / fs
+	alpha = math.Sin(w0) / (2 * 0.5)
+	cos = math.Cos(w0)
+	a0 = 1 + alpha
+	highPass = &biquad{
+		b0: (1 + cos) / 2 / a0,
+		b1: -(1 + cos) / a0,
+		b2: (1 + cos) / 2 / a0,
+		a1: -2 * cos / a0,
+		a2: (1 - alpha) / a0,
+	}
+	return shelf, highPass
+}
+
+// integratedLoudness measures gated loudness over 400 ms blocks with 75%
+// overlap. Audio shorter than one block is measured as a single block.
+func integratedLoudness(samples []float64, sampleRate int) float64 {
+	const (
+		absoluteGate = -70.0
+		relativeGate = -10.0
+	)
+
+	shelf, highPass := kWeighting(sampleRate)
+	squared := make([]float64, len(samples))
+	for i, s := range samples {
+		y := highPass.process(shelf.process(s))
+		squared[i] = y * y
+	}
+
+	blockSize := sampleRate * 4 / 10
+	step := blockSize / 4
+	if blockSize > len(squared) || step == 0 {
+		blockSize, step = len(squared), len(squared)
+	}
+	if blockSize == 0 {
+		return math.Inf(-1)
+	}
+
+	// Mean square of each block
+	var powers []float64
+	for start := 0; start+blockSize <= len(squared); start += step {
+		var sum float64
+		for _, v := range squared[start : start+blockSize] {
+			sum += v
+		}
+		powers = append(powers, sum/float64(blockSize))
+	}
+
+	gated := func(threshold float64) (float64, int) {
+		var total float64
+		var n int
+		for _, p := range powers {
+			if blockLoudness(p) > threshold {
+				total += p
+				n++
+			}
+		}
+		return total, n
+	}
+
+	total, n := gated(absoluteGate)
+	if n == 0 {
+		return math.Inf(-1)
+	}
+	total, n = gated(math.Max(absoluteGate, blockLoudness(total/float64(n))+relativeGate))
+	if n == 0 {
+		return math.Inf(-1)
+	}
+	return blockLoudness(total / float64(n))
+}
+
+// blockLoudness converts a K-weighted mean square to LUFS.
+func blockLoudness(meanSquare float64) float64 {
+	return -0.691 + 10*math.Log10(meanSquare)
+}
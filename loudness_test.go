@@ -0,0 +1,82 @@
+package elevenlabs
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// sinePCM returns 16-bit PCM of a sine wave with the given peak amplitude.
+func sinePCM(freq, amplitude float64, sampleRate int, seconds float64) []byte {
+	n := int(float64(sampleRate) * seconds)
+	pcm := make([]byte, 2*n)
+	for i := 0; i < n; i++ {
+		v := amplitude * math.Sin(2*math.Pi*freq*float64(i)/float64(sampleRate)) * 32767
+		binary.LittleEndian.PutUint16(pcm[2*i:], uint16(int16(v)))
+	}
+	return pcm
+}
+
+func TestMeasureLoudness(t *testing.T) {
+	// A full-scale 997 Hz sine measures -3.01 LUFS in BS.1770
+	for _, rate := range []int{16000, 44100, 48000} {
+		got, err := MeasureLoudness(sinePCM(997, 1, rate, 2), rate)
+		if err != nil {
+			t.Fatalf("MeasureLoudness() error = %v", err)
+		}
+		if math.Abs(got-(-3.01)) > 0.1 {
+			t.Errorf("MeasureLoudness() at %d Hz = %.2f, want -3.01", rate, got)
+		}
+	}
+
+	got, err := MeasureLoudness(make([]byte, 44100), 22050)
+	if err != nil {
+		t.Fatalf("MeasureLoudness() error = %v", err)
+	}
+	if !math.IsInf(got, -1) {
+		t.Errorf("MeasureLoudness(silence) = %v, want -Inf", got)
+	}
+
+	if _, err := MeasureLoudness([]byte{1, 2, 3}, 44100); err == nil {
+		t.Error("expected error for odd-length PCM")
+	}
+	if _, err := MeasureLoudness(nil, 0); err == nil {
+		t.Error("expected error for invalid sample rate")
+	}
+}
+
+func TestNormalizeLoudness(t *testing.T) {
+	const rate = 44100
+	quiet := sinePCM(440, 0.05, rate, 2)
+	loud := sinePCM(2000, 0.8, rate, 2)
+
+	for name, pcm := range map[string][]byte{"quiet": quiet, "loud": loud} {
+		out, err := NormalizeLoudness(pcm, rate, LoudnessBroadcast)
+		if err != nil {
+			t.Fatalf("NormalizeLoudness() error = %v", err)
+		}
+		if len(out) != len(pcm) {
+			t.Fatalf("len = %d, want %d", len(out), len(pcm))
+		}
+		got, _ := MeasureLoudness(out, rate)
+		if math.Abs(got-LoudnessBroadcast) > 0.1 {
+			t.Errorf("%s: normalized loudness = %.2f, want %.1f", name, got, LoudnessBroadcast)
+		}
+	}
+
+	// Gain that would clip is limited to full scale
+	out, _ := NormalizeLoudness(quiet, rate, 0)
+	var peak int16
+	for i := 0; i < len(out); i += 2 {
+		peak = max(peak, int16(binary.LittleEndian.Uint16(out[i:])))
+	}
+	if peak != math.MaxInt16 {
+		t.Errorf("peak = %d, want %d", peak, math.MaxInt16)
+	}
+
+	silence := make([]byte, 1000)
+	out, err := NormalizeLoudness(silence, rate, LoudnessBroadcast)
+	if err != nil || len(out) != len(silence) {
+		t.Errorf("NormalizeLoudness(silence) = %d bytes, %v", len(out), err)
+	}
+}
@@ -0,0 +1,421 @@
+package elevenlabs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/agentplexus/go-elevenlabs/internal/api"
+)
+
+// MCPServersService manages Model Context Protocol (MCP) servers that
+// conversational AI agents can use as tool providers.
+type MCPServersService struct {
+	client *Client
+}
+
+// MCP server approval policies control whether tool calls need approval.
+const (
+	MCPApprovalAutoApproveAll   = "auto_approve_all"
+	MCPApprovalRequireAll       = "require_approval_all"
+	MCPApprovalRequirePerTool   = "require_approval_per_tool"
+	MCPToolApprovalAutoApproved = "auto_approved"
+	MCPToolApprovalRequired     = "requires_approval"
+)
+
+// MCP server transports.
+const (
+	MCPTransportSSE            = "SSE"
+	MCPTransportStreamableHTTP = "STREAMABLE_HTTP"
+)
+
+// MCPServerConfig is the configuration of an MCP server.
+type MCPServerConfig struct {
+	// Name is the display name of the server (required).
+	Name string `json:"name"`
+
+	// Description describes the tools the server provides.
+	Description string `json:"description,omitempty"`
+
+	// URL is the server endpoint (required).
+	URL string `json:"url"`
+
+	// Transport is MCPTransportSSE (default) or MCPTransportStreamableHTTP.
+	Transport string `json:"transport,omitempty"`
+
+	// ApprovalPolicy is one of the MCPApproval policies
+	// (default: MCPApprovalRequireAll).
+	ApprovalPolicy string `json:"approval_policy,omitempty"`
+
+	// RequestHeaders are sent with every request to the server.
+	RequestHeaders map[string]string `json:"request_headers,omitempty"`
+
+	// SecretID references a workspace secret sent as the server's auth token.
+	SecretID string `json:"-"`
+
+	// ToolApprovals are the per-tool approvals, used with
+	// MCPApprovalRequirePerTool. Read-only; use ApproveTool to change them.
+	ToolApprovals []MCPToolApproval `json:"-"`
+}
+
+// MCPToolApproval is the approval policy of a single MCP tool.
+type MCPToolApproval struct {
+	// ToolName is the name of the tool (required).
+	ToolName string `json:"tool_name"`
+
+	// ToolDescription is the description of the tool.
+	ToolDescription string `json:"tool_description,omitempty"`
+
+	// ApprovalPolicy is MCPToolApprovalAutoApproved or MCPToolApprovalRequired.
+	ApprovalPolicy string `json:"approval_policy,omitempty"`
+}
+
+// MCPServer is a registered MCP server.
+type MCPServer struct {
+	// ID is the MCP server ID.
+	ID string
+
+	// Config is the server configuration.
+	Config MCPServerConfig
+
+	// DependentAgentIDs are the agents using the server.
+	DependentAgentIDs []string
+}
+
+// MCPTool is a tool provided by an MCP server.
+type MCPTool struct {
+	// Name is the tool name.
+	Name string
+
+	// Title is the human-readable title.
+	Title string
+
+	// Description describes what the tool does.
+	Description string
+}
+
+// mcpServerConfigWire is the API representation of MCPServerConfig.
+type mcpServerConfigWire struct {
+	MCPServerConfig
+	SecretToken   *mcpSecretWire    `json:"secret_token,omitempty"`
+	ToolApprovals []MCPToolApproval `json:"tool_approval_hashes,omitempty"`
+}
+
+type mcpSecretWire struct {
+	SecretID string `json:"secret_id"`
+}
+
+// mcpServerWire is the API representation of MCPServer.
+type mcpServerWire struct {
+	ID              string              `json:"id"`
+	Config          mcpServerConfigWire `json:"config"`
+	DependentAgents []struct {
+		ID string `json:"id"`
+	} `json:"dependent_agents"`
+}
+
+func (w *mcpServerWire) server() *MCPServer {
+	server := &MCPServer{
+		ID:     w.ID,
+		Config: w.Config.MCPServerConfig,
+	}
+	if w.Config.SecretToken != nil {
+		server.Config.SecretID = w.Config.SecretToken.SecretID
+	}
+	server.Config.ToolApprovals = w.Config.ToolApprovals
+	for _, a := range w.DependentAgents {
+		server.DependentAgentIDs = append(server.DependentAgentIDs, a.ID)
+	}
+	return server
+}
+
+func (c *MCPServerConfig) wire() *mcpServerConfigWire {
+	w := &mcpServerConfigWire{MCPServerConfig: *c}
+	if c.SecretID != "" {
+		w.SecretToken = &mcpSecretWire{SecretID: c.SecretID}
+	}
+	return w
+}
+
+func (c *MCPServerConfig) validate() error {
+	if c.Name == "" {
+		return &ValidationError{Field: "name", Message: "cannot be empty"}
+	}
+	if c.URL == "" {
+		return &ValidationError{Field: "url", Message: "cannot be empty"}
+	}
+	if c.Transport != "" && c.Transport != MCPTransportSSE && c.Transport != MCPTransportStreamableHTTP {
+		return &ValidationError{Field: "transport", Message: fmt.Sprintf("unsupported transport %q", c.Transport)}
+	}
+	if c.ApprovalPolicy != "" {
+		return validateMCPApprovalPolicy(c.ApprovalPolicy)
+	}
+	return nil
+}
+
+func validateMCPApprovalPolicy(policy string) error {
+	switch policy {
+	case MCPApprovalAutoApproveAll, MCPApprovalRequireAll, MCPApprovalRequirePerTool:
+		return nil
+	default:
+		return &ValidationError{Field: "approval_policy", Message: fmt.Sprintf("unsupported policy %q", policy)}
+	}
+}
+
+func mcpServerPath(serverID string) string {
+	return "/v1/convai/mcp-servers/" + url.PathEscape(serverID)
+}
+
+// Create registers a new MCP server.
+func (s *MCPServersService) Create(ctx context.Context, config *MCPServerConfig) (*MCPServer, error) {
+	if config == nil {
+		return nil, &ValidationError{Field: "config", Message: "cannot be nil"}
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	body := struct {
+		Config *mcpServerConfigWire `json:"config"`
+	}{Config: config.wire()}
+
+	var result mcpServerWire
+	if err := s.client.doJSON(ctx, "POST", "/v1/convai/mcp-servers", &body, &result); err != nil {
+		return nil, err
+	}
+	return result.server(), nil
+}
+
+// List returns the MCP servers in the workspace.
+func (s *MCPServersService) List(ctx context.Context) ([]*MCPServer, error) {
+	var result struct {
+		MCPServers []mcpServerWire `json:"mcp_servers"`
+	}
+	if err := s.client.doJSON(ctx, "GET", "/v1/convai/mcp-servers", nil, &result); err != nil {
+		return nil, err
+	}
+
+	servers := make([]*MCPServer, 0, len(result.MCPServers))
+	for i := range result.MCPServers {
+		servers = append(servers, result.MCPServers[i].server())
+	}
+	return servers, nil
+}
+
+// Get returns an MCP server by ID.
+func (s *MCPServersService) Get(ctx context.Context, serverID string) (*MCPServer, error) {
+	if serverID == "" {
+		return nil, &ValidationError{Field: "mcp_server_id", Message: "cannot be empty"}
+	}
+
+	var result mcpServerWire
+	if err := s.client.doJSON(ctx, "GET", mcpServerPath(serverID), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.server(), nil
+}
+
+// Update replaces the configuration of an MCP server.
+func (s *MCPServersService) Update(ctx context.Context, serverID string, config *MCPServerConfig) (*MCPServer, error) {
+	if serverID == "" {
+		return nil, &ValidationError{Field: "mcp_server_id", Message: "cannot be empty"}
+	}
+	if config == nil {
+		return nil, &ValidationError{Field: "config", Message: "cannot be nil"}
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	var result mcpServerWire
+	if err := s.client.doJSON(ctx, "PATCH", mcpServerPath(serverID), config.wire(), &result); err != nil {
+		return nil, err
+	}
+	return result.server(), nil
+}
+
+// SetApprovalPolicy changes the approval policy of an MCP server.
+func (s *MCPServersService) SetApprovalPolicy(ctx context.Context, serverID, policy string) (*MCPServer, error) {
+	if serverID == "" {
+		return nil, &ValidationError{Field: "mcp_server_id", Message: "cannot be empty"}
+	}
+	if err := validateMCPApprovalPolicy(policy); err != nil {
+		return nil, err
+	}
+
+	body := struct {
+		ApprovalPolicy string `json:"approval_policy"`
+	}{ApprovalPolicy: policy}
+
+	var result mcpServerWire
+	if err := s.client.doJSON(ctx, "PATCH", mcpServerPath(serverID)+"/approval-policy", &body, &result); err != nil {
+		return nil, err
+	}
+	return result.server(), nil
+}
+
+// ApproveTool sets the approval policy of a single tool. It applies when the
+// server uses MCPApprovalRequirePerTool.
+func (s *MCPServersService) ApproveTool(ctx context.Context, serverID string, approval *MCPToolApproval) (*MCPServer, error) {
+	if serverID == "" {
+		return nil, &ValidationError{Field: "mcp_server_id", Message: "cannot be empty"}
+	}
+	if approval == nil || approval.ToolName == "" {
+		return nil, &ValidationError{Field: "tool_name", Message: "cannot be empty"}
+	}
+	if p := approval.ApprovalPolicy; p != "" && p != MCPToolApprovalAutoApproved && p != MCPToolApprovalRequired {
+		return nil, &ValidationError{Field: "approval_policy", Message: fmt.Sprintf("unsupported tool policy %q", p)}
+	}
+
+	var result mcpServerWire
+	if err := s.client.doJSON(ctx, "POST", mcpServerPath(serverID)+"/tool-approvals", approval, &result); err != nil {
+		return nil, err
+	}
+	return result.server(), nil
+}
+
+// RemoveToolApproval removes the approval of a single tool.
+func (s *MCPServersService) RemoveToolApproval(ctx context.Context, serverID, toolName string) (*MCPServer, error) {
+	if serverID == "" {
+		return nil, &ValidationError{Field: "mcp_server_id", Message: "cannot be empty"}
+	}
+	if toolName == "" {
+		return nil, &ValidationError{Field: "tool_name", Message: "cannot be empty"}
+	}
+
+	var result mcpServerWire
+	path := mcpServerPath(serverID) + "/tool-approvals/" + url.PathEscape(toolName)
+	if err := s.client.doJSON(ctx, "DELETE", path, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.server(), nil
+}
+
+// Delete removes an MCP server.
+func (s *MCPServersService) Delete(ctx context.Context, serverID string) error {
+	if serverID == "" {
+		return &ValidationError{Field: "mcp_server_id", Message: "cannot be empty"}
+	}
+
+	resp, err := s.client.apiClient.DeleteMcpServerRoute(ctx, api.DeleteMcpServerRouteParams{
+		McpServerID: serverID,
+	})
+	if err != nil {
+		return apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.DeleteMcpServerRouteOKApplicationJSON:
+		return nil
+	case *api.HTTPValidationError:
+		return validationAPIError(r)
+	default:
+		return unexpectedResponse(resp)
+	}
+}
+
+// ListTools returns the tools provided by an MCP server.
+func (s *MCPServersService) ListTools(ctx context.Context, serverID string) ([]MCPTool, error) {
+	if serverID == "" {
+		return nil, &ValidationError{Field: "mcp_server_id", Message: "cannot be empty"}
+	}
+
+	resp, err := s.client.apiClient.ListMcpServerToolsRoute(ctx, api.ListMcpServerToolsRouteParams{
+		McpServerID: serverID,
+	})
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.ListMCPToolsResponseModel:
+		if !r.Success {
+			msg := "listing MCP tools failed"
+			if r.ErrorMessage.Set && !r.ErrorMessage.Null {
+				msg = r.ErrorMessage.Value
+			}
+			return nil, &APIError{Message: msg}
+		}
+		tools := make([]MCPTool, 0, len(r.Tools))
+		for _, t := range r.Tools {
+			tool := MCPTool{Name: t.Name}
+			if t.Title.Set && !t.Title.Null {
+				tool.Title = t.Title.Value
+			}
+			if t.Description.Set && !t.Description.Null {
+				tool.Description = t.Description.Value
+			}
+			tools = append(tools, tool)
+		}
+		return tools, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
+	default:
+		return nil, unexpectedResponse(resp)
+	}
+}
+
+// agentMCPConfig is the part of an agent's configuration holding MCP servers.
+type agentMCPConfig struct {
+	ConversationConfig struct {
+		Agent struct {
+			Prompt struct {
+				MCPServerIDs []string `json:"mcp_server_ids"`
+			} `json:"prompt"`
+		} `json:"agent"`
+	} `json:"conversation_config"`
+}
+
+// AttachToAgent adds MCP servers to an agent's tools, keeping servers that
+// are already attached. Returns the agent's MCP server IDs.
+func (s *MCPServersService) AttachToAgent(ctx context.Context, agentID string, serverIDs ...string) ([]string, error) {
+	return s.updateAgentServers(ctx, agentID, serverIDs, func(ids []string, id string) []string {
+		if slices.Contains(ids, id) {
+			return ids
+		}
+		return append(ids, id)
+	})
+}
+
+// DetachFromAgent removes MCP servers from an agent's tools.
+// Returns the agent's remaining MCP server IDs.
+func (s *MCPServersService) DetachFromAgent(ctx context.Context, agentID string, serverIDs ...string) ([]string, error) {
+	return s.updateAgentServers(ctx, agentID, serverIDs, func(ids []string, id string) []string {
+		return slices.DeleteFunc(ids, func(existing string) bool { return existing == id })
+	})
+}
+
+func (s *MCPServersService) updateAgentServers(ctx context.Context, agentID string, serverIDs []string, apply func([]string, string) []string) ([]string, error) {
+	if agentID == "" {
+		return nil, &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+	if len(serverIDs) == 0 {
+		return nil, &ValidationError{Field: "mcp_server_ids", Message: "at least one MCP server ID is required"}
+	}
+
+	path := agentPath(agentID)
+
+	var agent agentMCPConfig
+	if err := s.client.doJSON(ctx, "GET", path, nil, &agent); err != nil {
+		return nil, err
+	}
+
+	ids := agent.ConversationConfig.Agent.Prompt.MCPServerIDs
+	for _, id := range serverIDs {
+		if id == "" {
+			return nil, &ValidationError{Field: "mcp_server_ids", Message: "cannot contain empty IDs"}
+		}
+		ids = apply(ids, id)
+	}
+	if ids == nil {
+		ids = []string{}
+	}
+
+	var update agentMCPConfig
+	update.ConversationConfig.Agent.Prompt.MCPServerIDs = ids
+	if err := s.client.doJSON(ctx, "PATCH", path, &update, nil); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,162 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestMCPServersCreate(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/convai/mcp-servers" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"id": "mcp-1",
+			"config": {
+				"name": "CRM",
+				"url": "https://mcp.example.com/sse",
+				"approval_policy": "require_approval_per_tool",
+				"secret_token": {"secret_id": "secret-1"},
+				"tool_approval_hashes": [{"tool_name": "lookup", "approval_policy": "auto_approved"}]
+			},
+			"dependent_agents": [{"id": "agent-1"}]
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	created, err := client.MCPServers().Create(context.Background(), &MCPServerConfig{
+		Name:           "CRM",
+		URL:            "https://mcp.example.com/sse",
+		ApprovalPolicy: MCPApprovalRequirePerTool,
+		SecretID:       "secret-1",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	config, _ := body["config"].(map[string]any)
+	if config["name"] != "CRM" || config["approval_policy"] != MCPApprovalRequirePerTool {
+		t.Errorf("config = %v", config)
+	}
+	if secret, _ := config["secret_token"].(map[string]any); secret["secret_id"] != "secret-1" {
+		t.Errorf("secret_token = %v", config["secret_token"])
+	}
+
+	if created.ID != "mcp-1" || created.Config.SecretID != "secret-1" {
+		t.Errorf("created = %+v", created)
+	}
+	if len(created.Config.ToolApprovals) != 1 || created.Config.ToolApprovals[0].ApprovalPolicy != MCPToolApprovalAutoApproved {
+		t.Errorf("ToolApprovals = %+v", created.Config.ToolApprovals)
+	}
+	if !slices.Equal(created.DependentAgentIDs, []string{"agent-1"}) {
+		t.Errorf("DependentAgentIDs = %v", created.DependentAgentIDs)
+	}
+}
+
+func TestMCPServersAttachToAgent(t *testing.T) {
+	var patch string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/convai/agents/agent-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"agent_id": "agent-1", "conversation_config": {"agent": {"prompt": {"mcp_server_ids": ["mcp-1"]}}}}`))
+		case http.MethodPatch:
+			data, _ := io.ReadAll(r.Body)
+			patch = string(data)
+			_, _ = w.Write([]byte(`{"agent_id": "agent-1"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	ids, err := client.MCPServers().AttachToAgent(ctx, "agent-1", "mcp-1", "mcp-2")
+	if err != nil {
+		t.Fatalf("AttachToAgent() error = %v", err)
+	}
+	if !slices.Equal(ids, []string{"mcp-1", "mcp-2"}) {
+		t.Errorf("AttachToAgent() = %v", ids)
+	}
+	if want := `{"conversation_config":{"agent":{"prompt":{"mcp_server_ids":["mcp-1","mcp-2"]}}}}`; patch != want {
+		t.Errorf("PATCH body = %s, want %s", patch, want)
+	}
+
+	ids, err = client.MCPServers().DetachFromAgent(ctx, "agent-1", "mcp-1")
+	if err != nil {
+		t.Fatalf("DetachFromAgent() error = %v", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("DetachFromAgent() = %v, want empty", ids)
+	}
+	if want := `{"conversation_config":{"agent":{"prompt":{"mcp_server_ids":[]}}}}`; patch != want {
+		t.Errorf("PATCH body = %s, want %s", patch, want)
+	}
+}
+
+func TestMCPServersValidation(t *testing.T) {
+	client, err := NewClient(WithAPIKey("test-key"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		call  func() error
+		field string
+	}{
+		{"create without URL", func() error {
+			_, err := client.MCPServers().Create(ctx, &MCPServerConfig{Name: "CRM"})
+			return err
+		}, "url"},
+		{"create with bad transport", func() error {
+			_, err := client.MCPServers().Create(ctx, &MCPServerConfig{Name: "CRM", URL: "https://x", Transport: "grpc"})
+			return err
+		}, "transport"},
+		{"bad approval policy", func() error {
+			_, err := client.MCPServers().SetApprovalPolicy(ctx, "mcp-1", "sometimes")
+			return err
+		}, "approval_policy"},
+		{"approve tool without name", func() error {
+			_, err := client.MCPServers().ApproveTool(ctx, "mcp-1", &MCPToolApproval{})
+			return err
+		}, "tool_name"},
+		{"attach without servers", func() error {
+			_, err := client.MCPServers().AttachToAgent(ctx, "agent-1")
+			return err
+		}, "mcp_server_ids"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var valErr *ValidationError
+			if err := tt.call(); !isValidationError(err, &valErr) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if valErr.Field != tt.field {
+				t.Errorf("Field = %q, want %q", valErr.Field, tt.field)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package elevenlabs
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/agentplexus/go-elevenlabs/internal/api"
 )
@@ -55,6 +57,10 @@ type Model struct {
 	// MaxCharactersSubscribedUser is the max characters for subscribed users.
 	MaxCharactersSubscribedUser int
 
+	// MaxTextLength is the maximum text length of a single request. The
+	// API reports limits per model; they apply to every language.
+	MaxTextLength int
+
 	// TokenCostFactor is the cost factor for the model.
 	TokenCostFactor float64
 }
@@ -63,7 +69,7 @@ type Model struct {
 func (s *ModelsService) List(ctx context.Context) ([]*Model, error) {
 	resp, err := s.client.apiClient.GetModels(ctx, api.GetModelsParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	// Handle response type
@@ -82,6 +88,7 @@ func (s *ModelsService) List(ctx context.Context) ([]*Model, error) {
 				CanUseSpeakerBoost:          m.CanUseSpeakerBoost,
 				MaxCharactersFreeUser:       m.MaxCharactersRequestFreeUser,
 				MaxCharactersSubscribedUser: m.MaxCharactersRequestSubscribedUser,
+				MaxTextLength:               m.MaximumTextLengthPerRequest,
 				TokenCostFactor:             m.TokenCostFactor,
 				Languages:                   make([]*Language, 0, len(m.Languages)),
 			}
@@ -94,8 +101,10 @@ func (s *ModelsService) List(ctx context.Context) ([]*Model, error) {
 			models = append(models, model)
 		}
 		return models, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -114,3 +123,55 @@ func (s *ModelsService) ListTTSModels(ctx context.Context) ([]*Model, error) {
 	}
 	return ttsModels, nil
 }
+
+// Get returns the model with the given ID.
+func (s *ModelsService) Get(ctx context.Context, modelID string) (*Model, error) {
+	if modelID == "" {
+		return nil, &ValidationError{Field: "model_id", Message: "cannot be empty"}
+	}
+	models, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range models {
+		if m.ModelID == modelID {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("model %q not found", modelID)
+}
+
+// SupportsLanguage reports whether a model supports a language. See
+// Model.SupportsLanguage for how language codes are matched. To check many
+// pairs, List the models once and use Model.SupportsLanguage.
+func (s *ModelsService) SupportsLanguage(ctx context.Context, modelID, languageCode string) (bool, error) {
+	model, err := s.Get(ctx, modelID)
+	if err != nil {
+		return false, err
+	}
+	return model.SupportsLanguage(languageCode), nil
+}
+
+// LanguageIDs returns the codes of the languages the model supports.
+func (m *Model) LanguageIDs() []string {
+	ids := make([]string, len(m.Languages))
+	for i, lang := range m.Languages {
+		ids[i] = lang.LanguageID
+	}
+	return ids
+}
+
+// SupportsLanguage reports whether the model supports a language. Codes
+// match case-insensitively, and a regional code such as "pt-BR" matches
+// its base language "pt".
+func (m *Model) SupportsLanguage(languageCode string) bool {
+	code := strings.ToLower(strings.ReplaceAll(languageCode, "_", "-"))
+	base, _, _ := strings.Cut(code, "-")
+	for _, lang := range m.Languages {
+		id := strings.ToLower(lang.LanguageID)
+		if id == code || id == base {
+			return true
+		}
+	}
+	return false
+}
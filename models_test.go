@@ -2,6 +2,10 @@ package elevenlabs
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
 	"testing"
 )
 
@@ -81,3 +85,83 @@ func TestModelsContainsDefaultModel_Live(t *testing.T) {
 		t.Errorf("Default model %s not found in models list", DefaultModelID)
 	}
 }
+
+// newModelsServer serves a model list with one multilingual and one
+// English-only model.
+func newModelsServer(t *testing.T) *Client {
+	t.Helper()
+	model := func(id string, maxLen int, langs ...string) string {
+		list := ""
+		for i, lang := range langs {
+			if i > 0 {
+				list += ", "
+			}
+			list += fmt.Sprintf(`{"language_id": %q, "name": %q}`, lang, lang)
+		}
+		return fmt.Sprintf(`{"model_id": %q, "name": %q, "description": "", "can_be_finetuned": false,
+			"can_do_text_to_speech": true, "can_do_voice_conversion": false, "can_use_style": true,
+			"can_use_speaker_boost": true, "serves_pro_voices": false, "token_cost_factor": 1,
+			"requires_alpha_access": false, "max_characters_request_free_user": 2500,
+			"max_characters_request_subscribed_user": 5000, "maximum_text_length_per_request": %d,
+			"concurrency_group": "standard", "model_rates": {"character_cost_multiplier": 1},
+			"languages": [%s]}`, id, id, maxLen, list)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, "[%s, %s]", model("eleven_multilingual_v2", 10000, "en", "de", "pt"), model("eleven_monolingual_v1", 5000, "en"))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestModelsGet(t *testing.T) {
+	client := newModelsServer(t)
+
+	model, err := client.Models().Get(context.Background(), "eleven_multilingual_v2")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if model.MaxTextLength != 10000 {
+		t.Errorf("MaxTextLength = %d, want 10000", model.MaxTextLength)
+	}
+	if got, want := model.LanguageIDs(), []string{"en", "de", "pt"}; !slices.Equal(got, want) {
+		t.Errorf("LanguageIDs() = %v, want %v", got, want)
+	}
+
+	if _, err := client.Models().Get(context.Background(), "eleven_unknown"); err == nil {
+		t.Error("Get() of an unknown model: expected error")
+	}
+}
+
+func TestModelsSupportsLanguage(t *testing.T) {
+	client := newModelsServer(t)
+
+	tests := []struct {
+		model, lang string
+		want        bool
+	}{
+		{"eleven_multilingual_v2", "de", true},
+		{"eleven_multilingual_v2", "pt-BR", true},
+		{"eleven_multilingual_v2", "DE", true},
+		{"eleven_multilingual_v2", "ja", false},
+		{"eleven_monolingual_v1", "en_US", true},
+		{"eleven_monolingual_v1", "de", false},
+	}
+	for _, tt := range tests {
+		got, err := client.Models().SupportsLanguage(context.Background(), tt.model, tt.lang)
+		if err != nil {
+			t.Fatalf("SupportsLanguage(%s, %s) error = %v", tt.model, tt.lang, err)
+		}
+		if got != tt.want {
+			t.Errorf("SupportsLanguage(%s, %s) = %v, want %v", tt.model, tt.lang, got, tt.want)
+		}
+	}
+}
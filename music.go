@@ -64,7 +64,7 @@ func (s *MusicService) Generate(ctx context.Context, req *MusicRequest) (*MusicR
 
 	resp, err := s.client.apiClient.Generate(ctx, api.NewOptBodyComposeMusicV1MusicPost(*body), api.GenerateParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
@@ -73,8 +73,10 @@ func (s *MusicService) Generate(ctx context.Context, req *MusicRequest) (*MusicR
 			Audio:  r.Response.Data,
 			SongID: r.SongID.Value,
 		}, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -102,7 +104,7 @@ func (s *MusicService) GenerateStream(ctx context.Context, req *MusicRequest) (*
 
 	resp, err := s.client.apiClient.StreamCompose(ctx, api.NewOptBodyStreamComposedMusicV1MusicStreamPost(*body), api.StreamComposeParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
@@ -111,8 +113,10 @@ func (s *MusicService) GenerateStream(ctx context.Context, req *MusicRequest) (*
 			Audio:  r.Response.Data,
 			SongID: r.SongID.Value,
 		}, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -216,14 +220,16 @@ func (s *MusicService) GeneratePlan(ctx context.Context, req *CompositionPlanReq
 
 	resp, err := s.client.apiClient.ComposePlan(ctx, body, api.ComposePlanParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
 	case *api.MusicPrompt:
 		return compositionPlanFromAPI(r), nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -309,7 +315,7 @@ func (s *MusicService) GenerateDetailed(ctx context.Context, req *MusicDetailedR
 		api.NewOptBodyComposeMusicWithADetailedResponseV1MusicDetailedPost(*body),
 		api.ComposeDetailedParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
@@ -318,8 +324,10 @@ func (s *MusicService) GenerateDetailed(ctx context.Context, req *MusicDetailedR
 			Audio:  r.Response.Data,
 			SongID: r.SongID.Value,
 		}, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -370,14 +378,16 @@ func (s *MusicService) SeparateStems(ctx context.Context, req *StemSeparationReq
 
 	resp, err := s.client.apiClient.SeparateSongStems(ctx, body, api.SeparateSongStemsParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
 	case *api.SeparateSongStemsOKHeaders:
 		return r.Response.Data, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
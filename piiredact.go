@@ -0,0 +1,315 @@
+package elevenlabs
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PII types detected by the built-in detectors.
+const (
+	PIIEmail      = "email"
+	PIIPhone      = "phone"
+	PIICreditCard = "credit_card"
+)
+
+// PIIMatch is personal data found in a text.
+type PIIMatch struct {
+	// Type is the kind of data, such as PIIEmail.
+	Type string
+
+	// Start and End are the byte offsets of the data in the text.
+	Start int
+	End   int
+}
+
+// PIIDetector finds personal data in text. Implement it to add detectors,
+// for example for account numbers or names from an entity recognition
+// service.
+type PIIDetector interface {
+	Detect(text string) []PIIMatch
+}
+
+// PatternDetector detects personal data with a regular expression.
+type PatternDetector struct {
+	// Type is the PII type of matches.
+	Type string
+
+	// Pattern matches candidate data.
+	Pattern *regexp.Regexp
+
+	// Valid optionally rejects candidates, such as numbers that fail a
+	// checksum.
+	Valid func(match string) bool
+}
+
+// Detect implements PIIDetector.
+func (d *PatternDetector) Detect(text string) []PIIMatch {
+	var matches []PIIMatch
+	for _, loc := range d.Pattern.FindAllStringIndex(text, -1) {
+		if d.Valid != nil && !d.Valid(text[loc[0]:loc[1]]) {
+			continue
+		}
+		matches = append(matches, PIIMatch{Type: d.Type, Start: loc[0], End: loc[1]})
+	}
+	return matches
+}
+
+// Email addresses are matched with the text normalizer's emailPattern.
+var (
+	phonePattern      = regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?)?(?:\(\d{1,4}\)[\s.-]?)?\d(?:[\s.-]?\d)+`)
+	creditCardPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+)
+
+// EmailDetector returns a detector for email addresses.
+func EmailDetector() PIIDetector {
+	return &PatternDetector{Type: PIIEmail, Pattern: emailPattern}
+}
+
+// PhoneDetector returns a detector for phone numbers of 7 to 15 digits,
+// with or without a country code and separators.
+func PhoneDetector() PIIDetector {
+	return &PatternDetector{Type: PIIPhone, Pattern: phonePattern, Valid: func(s string) bool {
+		n := countDigits(s)
+		return n >= 7 && n <= 15
+	}}
+}
+
+// CreditCardDetector returns a detector for payment card numbers of 13 to
+// 19 digits that pass the Luhn checksum.
+func CreditCardDetector() PIIDetector {
+	return &PatternDetector{Type: PIICreditCard, Pattern: creditCardPattern, Valid: luhnValid}
+}
+
+// DefaultPIIDetectors returns the built-in detectors for credit card
+// numbers, email addresses, and phone numbers.
+func DefaultPIIDetectors() []PIIDetector {
+	return []PIIDetector{CreditCardDetector(), EmailDetector(), PhoneDetector()}
+}
+
+// TranscriptRedactor replaces personal data in transcripts, so they can be
+// stored or sent to analytics without it. It is safe for concurrent use
+// if its detectors are.
+type TranscriptRedactor struct {
+	detectors []PIIDetector
+	mask      func(PIIMatch) string
+}
+
+// RedactorOption configures a TranscriptRedactor.
+type RedactorOption func(*TranscriptRedactor)
+
+// WithPIIDetectors replaces the default detectors. Include
+// DefaultPIIDetectors to extend them instead.
+func WithPIIDetectors(detectors ...PIIDetector) RedactorOption {
+	return func(r *TranscriptRedactor) {
+		r.detectors = detectors
+	}
+}
+
+// WithRedactionMask sets the replacement text for matches. The default
+// replaces data with its type in brackets, such as "[EMAIL]".
+func WithRedactionMask(fn func(PIIMatch) string) RedactorOption {
+	return func(r *TranscriptRedactor) {
+		r.mask = fn
+	}
+}
+
+// NewTranscriptRedactor returns a redactor using the default detectors
+// unless WithPIIDetectors is given.
+func NewTranscriptRedactor(opts ...RedactorOption) *TranscriptRedactor {
+	r := &TranscriptRedactor{
+		detectors: DefaultPIIDetectors(),
+		mask: func(m PIIMatch) string {
+			return "[" + strings.ToUpper(m.Type) + "]"
+		},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Detect returns the personal data in text, sorted by position. Where
+// matches overlap, the earliest and then longest is kept.
+func (r *TranscriptRedactor) Detect(text string) []PIIMatch {
+	var all []PIIMatch
+	for _, d := range r.detectors {
+		all = append(all, d.Detect(text)...)
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].Start != all[j].Start {
+			return all[i].Start < all[j].Start
+		}
+		return all[i].End > all[j].End
+	})
+
+	var matches []PIIMatch
+	end := 0
+	for _, m := range all {
+		if m.Start < end || m.End <= m.Start || m.End > len(text) {
+			continue
+		}
+		matches = append(matches, m)
+		end = m.End
+	}
+	return matches
+}
+
+// RedactText returns text with personal data replaced.
+func (r *TranscriptRedactor) RedactText(text string) string {
+	matches := r.Detect(text)
+	if len(matches) == 0 {
+		return text
+	}
+	var b strings.Builder
+	prev := 0
+	for _, m := range matches {
+		b.WriteString(text[prev:m.Start])
+		b.WriteString(r.mask(m))
+		prev = m.End
+	}
+	b.WriteString(text[prev:])
+	return b.String()
+}
+
+// RedactConversation returns a copy of conv with personal data replaced in
+// the transcript and in the analysis summary, rationales, and collected
+// text values. Dynamic variables are copied unchanged.
+func (r *TranscriptRedactor) RedactConversation(conv *Conversation) *Conversation {
+	if conv == nil {
+		return nil
+	}
+	out := *conv
+	out.Transcript = make([]ConversationTurn, len(conv.Transcript))
+	for i, turn := range conv.Transcript {
+		turn.Message = r.RedactText(turn.Message)
+		out.Transcript[i] = turn
+	}
+
+	if conv.Analysis != nil {
+		a := *conv.Analysis
+		a.CallSummaryTitle = r.RedactText(a.CallSummaryTitle)
+		a.TranscriptSummary = r.RedactText(a.TranscriptSummary)
+		if a.EvaluationCriteriaResults != nil {
+			a.EvaluationCriteriaResults = make(map[string]EvaluationResult, len(conv.Analysis.EvaluationCriteriaResults))
+			for id, e := range conv.Analysis.EvaluationCriteriaResults {
+				e.Rationale = r.RedactText(e.Rationale)
+				a.EvaluationCriteriaResults[id] = e
+			}
+		}
+		if a.DataCollectionResults != nil {
+			a.DataCollectionResults = make(map[string]DataCollectionResult, len(conv.Analysis.DataCollectionResults))
+			for id, d := range conv.Analysis.DataCollectionResults {
+				if s, ok := d.Value.(string); ok {
+					d.Value = r.RedactText(s)
+				}
+				d.Rationale = r.RedactText(d.Rationale)
+				a.DataCollectionResults[id] = d
+			}
+		}
+		out.Analysis = &a
+	}
+	return &out
+}
+
+// RedactTranscription returns a copy of t with personal data replaced in
+// the text, utterances, and words. Personal data spoken as several words,
+// such as a phone number read in groups, becomes one masked word spanning
+// their time.
+func (r *TranscriptRedactor) RedactTranscription(t *TranscriptionResponse) *TranscriptionResponse {
+	if t == nil {
+		return nil
+	}
+	out := *t
+	out.Text = r.RedactText(t.Text)
+	out.Utterances = make([]TranscriptionUtterance, len(t.Utterances))
+	for i, u := range t.Utterances {
+		u.Text = r.RedactText(u.Text)
+		out.Utterances[i] = u
+	}
+	out.Words = r.redactWords(t.Words)
+	return &out
+}
+
+// redactWords detects personal data in the joined words and replaces the
+// words it covers.
+func (r *TranscriptRedactor) redactWords(words []TranscriptionWord) []TranscriptionWord {
+	// Words include spacing entries unless disabled; otherwise words are
+	// separated by spaces
+	sep := " "
+	for _, w := range words {
+		if w.Type == "spacing" {
+			sep = ""
+			break
+		}
+	}
+
+	var b strings.Builder
+	starts := make([]int, len(words))
+	for i, w := range words {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		starts[i] = b.Len()
+		b.WriteString(w.Text)
+	}
+
+	out := make([]TranscriptionWord, 0, len(words))
+	matches := r.Detect(b.String())
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+		end := starts[i] + len(w.Text)
+		for len(matches) > 0 && matches[0].End <= starts[i] {
+			matches = matches[1:]
+		}
+		if len(matches) == 0 || end <= matches[0].Start {
+			out = append(out, w)
+			continue
+		}
+
+		// Merge the words covered by the match
+		m := matches[0]
+		w.Text = r.mask(m)
+		w.Type = "word"
+		for i+1 < len(words) && starts[i+1] < m.End {
+			i++
+			w.End = words[i].End
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+func countDigits(s string) int {
+	n := 0
+	for _, c := range s {
+		if c >= '0' && c <= '9' {
+			n++
+		}
+	}
+	return n
+}
+
+// luhnValid reports whether the digits of s pass the Luhn checksum.
+func luhnValid(s string) bool {
+	sum := 0
+	double := false
+	digits := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+		digits++
+	}
+	return digits >= 13 && digits <= 19 && sum%10 == 0
+}
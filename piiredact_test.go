@@ -0,0 +1,109 @@
+package elevenlabs
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestTranscriptRedactorRedactText(t *testing.T) {
+	r := NewTranscriptRedactor()
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Email me at jane.doe@example.co.uk please", "Email me at [EMAIL] please"},
+		{"My number is +1 (415) 555-0132.", "My number is [PHONE]."},
+		{"Call 020 7946 0958 tomorrow", "Call [PHONE] tomorrow"},
+		{"Card 4111 1111 1111 1111 exp 12/27", "Card [CREDIT_CARD] exp 12/27"},
+		// Fails the Luhn check and is too long for a phone number
+		{"Order 4111 1111 1111 1112", "Order 4111 1111 1111 1112"},
+		{"I have 3 dogs and 12 cats", "I have 3 dogs and 12 cats"},
+	}
+	for _, tt := range tests {
+		if got := r.RedactText(tt.in); got != tt.want {
+			t.Errorf("RedactText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTranscriptRedactorCustomDetector(t *testing.T) {
+	accounts := &PatternDetector{Type: "account", Pattern: regexp.MustCompile(`ACC-\d{6}`)}
+	r := NewTranscriptRedactor(
+		WithPIIDetectors(append(DefaultPIIDetectors(), accounts)...),
+		WithRedactionMask(func(m PIIMatch) string { return "***" }),
+	)
+	got := r.RedactText("Account ACC-123456, email a@b.io")
+	if want := "Account ***, email ***"; got != want {
+		t.Errorf("RedactText() = %q, want %q", got, want)
+	}
+}
+
+func TestTranscriptRedactorRedactConversation(t *testing.T) {
+	conv := &Conversation{
+		ConversationID: "conv-1",
+		Transcript: []ConversationTurn{
+			{Role: "agent", Message: "What is your email?"},
+			{Role: "user", Message: "It's sam@example.com"},
+		},
+		Analysis: &ConversationAnalysis{
+			TranscriptSummary: "The user gave sam@example.com.",
+			DataCollectionResults: map[string]DataCollectionResult{
+				"email": {DataCollectionID: "email", Value: "sam@example.com", Rationale: "Stated as sam@example.com"},
+			},
+		},
+	}
+	got := NewTranscriptRedactor().RedactConversation(conv)
+
+	if got.Transcript[1].Message != "It's [EMAIL]" || got.Transcript[0].Message != "What is your email?" {
+		t.Errorf("Transcript = %+v", got.Transcript)
+	}
+	if got.Analysis.TranscriptSummary != "The user gave [EMAIL]." {
+		t.Errorf("TranscriptSummary = %q", got.Analysis.TranscriptSummary)
+	}
+	if d := got.Analysis.DataCollectionResults["email"]; d.Value != "[EMAIL]" || d.Rationale != "Stated as [EMAIL]" {
+		t.Errorf("DataCollectionResults = %+v", d)
+	}
+	// The original is unchanged
+	if conv.Transcript[1].Message != "It's sam@example.com" || conv.Analysis.DataCollectionResults["email"].Value != "sam@example.com" {
+		t.Error("RedactConversation() modified its input")
+	}
+}
+
+func TestTranscriptRedactorRedactTranscription(t *testing.T) {
+	words := func(texts ...string) []TranscriptionWord {
+		var out []TranscriptionWord
+		for i, text := range texts {
+			typ := "word"
+			if text == " " {
+				typ = "spacing"
+			}
+			out = append(out, TranscriptionWord{Text: text, Start: float64(i), End: float64(i) + 0.5, Type: typ, Speaker: "speaker_0"})
+		}
+		return out
+	}
+	resp := &TranscriptionResponse{
+		Text:       "call 415 555 0132 now",
+		Words:      words("call", " ", "415", " ", "555", " ", "0132", " ", "now"),
+		Utterances: []TranscriptionUtterance{{Text: "call 415 555 0132 now", Speaker: "speaker_0"}},
+	}
+	got := NewTranscriptRedactor().RedactTranscription(resp)
+
+	if got.Text != "call [PHONE] now" || got.Utterances[0].Text != "call [PHONE] now" {
+		t.Errorf("Text = %q, utterance = %q", got.Text, got.Utterances[0].Text)
+	}
+	if len(got.Words) != 5 {
+		t.Fatalf("Words = %+v, want 5", got.Words)
+	}
+	if w := got.Words[2]; w.Text != "[PHONE]" || w.Start != 2 || w.End != 6.5 || w.Speaker != "speaker_0" {
+		t.Errorf("masked word = %+v", w)
+	}
+	if got.Words[4].Text != "now" || resp.Words[2].Text != "415" {
+		t.Errorf("Words = %+v", got.Words)
+	}
+
+	// Words without spacing entries are joined with spaces
+	resp.Words = words("mail", "kim@example.org")
+	if got := NewTranscriptRedactor().RedactTranscription(resp); got.Words[1].Text != "[EMAIL]" {
+		t.Errorf("Words = %+v", got.Words)
+	}
+}
@@ -0,0 +1,168 @@
+package elevenlabs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// TierPricing lists approximate usage-based prices per 1,000 credits by
+// subscription tier. Prices change; check your plan for current rates and
+// override USDPer1000Credits if they differ.
+var TierPricing = map[string]float64{
+	"creator":  0.30,
+	"pro":      0.24,
+	"scale":    0.18,
+	"business": 0.12,
+}
+
+// ModelCreditsPerCharacter lists models that bill other than one credit
+// per character, such as the Flash and Turbo models at half price.
+var ModelCreditsPerCharacter = map[string]float64{
+	"eleven_flash_v2_5": 0.5,
+	"eleven_flash_v2":   0.5,
+	"eleven_turbo_v2_5": 0.5,
+	"eleven_turbo_v2":   0.5,
+}
+
+// Pricing converts billed characters into credits and dollar estimates.
+// Estimates ignore plan allowances: characters within the monthly quota
+// are priced at the same rate as overage.
+type Pricing struct {
+	// Tier is the subscription tier name (informational).
+	Tier string
+
+	// USDPer1000Credits is the dollar cost of 1,000 credits.
+	USDPer1000Credits float64
+
+	// ModelCredits overrides the credits per character of models. Models
+	// not listed use ModelCreditsPerCharacter, then one credit per
+	// character.
+	ModelCredits map[string]float64
+}
+
+// NewPricing returns the pricing for a subscription tier from TierPricing.
+func NewPricing(tier string) (*Pricing, error) {
+	tier = strings.ToLower(tier)
+	price, ok := TierPricing[tier]
+	if !ok {
+		return nil, &ValidationError{Field: "tier", Message: fmt.Sprintf("no pricing for tier %q", tier)}
+	}
+	return &Pricing{Tier: tier, USDPer1000Credits: price}, nil
+}
+
+// Pricing returns the pricing for the account's subscription tier.
+func (s *UserService) Pricing(ctx context.Context) (*Pricing, error) {
+	sub, err := s.GetSubscription(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewPricing(sub.Tier)
+}
+
+// WithPricing sets the pricing used to fill TTSResponse.Credits and
+// TTSResponse.EstimatedCost.
+func WithPricing(pricing *Pricing) Option {
+	return func(o *clientOptions) {
+		o.pricing = pricing
+	}
+}
+
+// CreditsPerCharacter returns the credits billed per character by a model.
+// An empty modelID means DefaultModelID.
+func (p *Pricing) CreditsPerCharacter(modelID string) float64 {
+	if modelID == "" {
+		modelID = DefaultModelID
+	}
+	if credits, ok := p.ModelCredits[modelID]; ok {
+		return credits
+	}
+	if credits, ok := ModelCreditsPerCharacter[modelID]; ok {
+		return credits
+	}
+	return 1
+}
+
+// Credits returns the credits billed for synthesizing characters with a
+// model.
+func (p *Pricing) Credits(characters int, modelID string) float64 {
+	return float64(characters) * p.CreditsPerCharacter(modelID)
+}
+
+// Cost returns the estimated dollar cost of synthesizing characters with
+// a model.
+func (p *Pricing) Cost(characters int, modelID string) float64 {
+	return p.Credits(characters, modelID) * p.USDPer1000Credits / 1000
+}
+
+// CostSummary totals the cost of a batch of generations. It is safe for
+// concurrent use.
+type CostSummary struct {
+	mu         sync.Mutex
+	requests   int
+	characters int
+	credits    float64
+	cost       float64
+}
+
+// Add adds a response to the summary. Responses without a reported
+// CharacterCount add the characters sent, as for their credits.
+func (s *CostSummary) Add(resp *TTSResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	s.characters += resp.billedCharacters()
+	s.credits += resp.Credits
+	s.cost += resp.EstimatedCost
+}
+
+// Requests returns the number of responses added.
+func (s *CostSummary) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// Characters returns the total billed characters.
+func (s *CostSummary) Characters() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.characters
+}
+
+// Credits returns the total credits.
+func (s *CostSummary) Credits() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.credits
+}
+
+// EstimatedCost returns the total estimated dollar cost.
+func (s *CostSummary) EstimatedCost() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cost
+}
+
+// String returns a one-line summary, e.g. "12 requests, 3,400 characters,
+// 1,700 credits, est. $0.51".
+func (s *CostSummary) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fmt.Sprintf("%d requests, %s characters, %s credits, est. $%.2f",
+		s.requests, groupThousands(int64(s.characters)), groupThousands(int64(s.credits+0.5)), s.cost)
+}
+
+// groupThousands formats n with comma separators.
+func groupThousands(n int64) string {
+	s := fmt.Sprint(n)
+	start := 0
+	if n < 0 {
+		start = 1
+	}
+	for i := len(s) - 3; i > start; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
@@ -0,0 +1,108 @@
+package elevenlabs
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestPricing(t *testing.T) {
+	p, err := NewPricing("Creator")
+	if err != nil {
+		t.Fatalf("NewPricing() error = %v", err)
+	}
+	if p.Tier != "creator" || p.USDPer1000Credits != 0.30 {
+		t.Errorf("pricing = %+v", p)
+	}
+	if got := p.Cost(10000, ""); !approxEqual(got, 3.00) {
+		t.Errorf("Cost(default model) = %v, want 3.00", got)
+	}
+	if got := p.Credits(10000, "eleven_flash_v2_5"); got != 5000 {
+		t.Errorf("Credits(flash) = %v, want 5000", got)
+	}
+	if got := p.Cost(10000, "eleven_turbo_v2_5"); !approxEqual(got, 1.50) {
+		t.Errorf("Cost(turbo) = %v, want 1.50", got)
+	}
+
+	p.ModelCredits = map[string]float64{"eleven_v3": 2}
+	if got := p.Credits(100, "eleven_v3"); got != 200 {
+		t.Errorf("Credits(override) = %v, want 200", got)
+	}
+
+	var valErr *ValidationError
+	if _, err := NewPricing("free"); !isValidationError(err, &valErr) {
+		t.Errorf("NewPricing(free) error = %v", err)
+	}
+}
+
+func TestTextToSpeechGenerateCost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		if r.URL.Path == "/v1/text-to-speech/voice-1" {
+			w.Header().Set("x-character-count", "2000")
+		}
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	pricing := &Pricing{Tier: "pro", USDPer1000Credits: 0.24}
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithPricing(pricing))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	var summary CostSummary
+	resp, err := client.TextToSpeech().Generate(ctx, &TTSRequest{VoiceID: "voice-1", Text: "Hello", ModelID: "eleven_flash_v2_5"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if resp.Credits != 1000 || !approxEqual(resp.EstimatedCost, 0.24) {
+		t.Errorf("Credits = %v, EstimatedCost = %v", resp.Credits, resp.EstimatedCost)
+	}
+	summary.Add(resp)
+
+	// Without a reported character count, the characters sent are counted
+	resp, err = client.TextToSpeech().Generate(ctx, &TTSRequest{VoiceID: "voice-2", Text: "Héllo"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if resp.Credits != 5 {
+		t.Errorf("Credits = %v, want 5", resp.Credits)
+	}
+	summary.Add(resp)
+
+	if summary.Requests() != 2 || summary.Characters() != 2005 || summary.Credits() != 1005 {
+		t.Errorf("summary = %s", summary.String())
+	}
+	if got := summary.String(); got != "2 requests, 2,005 characters, 1,005 credits, est. $0.24" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestTextToSpeechGenerateWithoutPricing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Header().Set("x-character-count", "10")
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	resp, err := client.TextToSpeech().Generate(context.Background(), &TTSRequest{VoiceID: "voice-1", Text: "Hello"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if resp.Credits != 0 || resp.EstimatedCost != 0 {
+		t.Errorf("Credits = %v, EstimatedCost = %v, want 0 without pricing", resp.Credits, resp.EstimatedCost)
+	}
+}
@@ -0,0 +1,122 @@
+package elevenlabs
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// ProgressFunc reports how many bytes have been read and the total size,
+// or -1 if the size is unknown. It is called from the goroutine reading
+// the data.
+type ProgressFunc func(read, total int64)
+
+// NewProgressReader wraps r to report reading progress to fn. total is the
+// expected size, or -1 if unknown. Use it for audio readers returned by
+// the SDK, such as dubbed files and studio snapshots:
+//
+//	audio, err := client.Dubbing().GetDubbedFile(ctx, dubbingID, "es")
+//	audio = elevenlabs.NewProgressReader(audio, -1, func(read, total int64) {
+//	    fmt.Printf("\rdownloaded %d bytes", read)
+//	})
+func NewProgressReader(r io.Reader, total int64, fn ProgressFunc) io.Reader {
+	return &progressReader{r: r, total: total, fn: fn}
+}
+
+type progressReader struct {
+	r     io.Reader
+	read  int64
+	total int64
+	fn    ProgressFunc
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.read += int64(n)
+		p.fn(p.read, p.total)
+	}
+	return n, err
+}
+
+// NewMaxSizeReader wraps r to fail with ErrResponseTooLarge once more than
+// limit bytes are read.
+func NewMaxSizeReader(r io.Reader, limit int64) io.Reader {
+	return &maxSizeReader{r: r, remaining: limit}
+}
+
+type maxSizeReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (m *maxSizeReader) Read(b []byte) (int, error) {
+	if m.remaining < 0 {
+		return 0, ErrResponseTooLarge
+	}
+	// Read one byte past the limit to detect oversized bodies
+	if int64(len(b)) > m.remaining+1 {
+		b = b[:m.remaining+1]
+	}
+	n, err := m.r.Read(b)
+	m.remaining -= int64(n)
+	if m.remaining < 0 {
+		return n + int(m.remaining), ErrResponseTooLarge
+	}
+	return n, err
+}
+
+// readCloser pairs a wrapped reader with the original body's Close.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// wrapResponseBody applies the download progress and size limit options
+// to a response body.
+func wrapResponseBody(resp *http.Response, ro *requestOptions) error {
+	if ro.maxResponseSize > 0 {
+		if resp.ContentLength > ro.maxResponseSize {
+			resp.Body.Close()
+			return ErrResponseTooLarge
+		}
+		resp.Body = &readCloser{Reader: NewMaxSizeReader(resp.Body, ro.maxResponseSize), Closer: resp.Body}
+	}
+	if ro.progress != nil && resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		resp.Body = &readCloser{Reader: NewProgressReader(resp.Body, resp.ContentLength, ro.progress), Closer: resp.Body}
+	}
+	return nil
+}
+
+// uploadReader wraps an uploaded file to report its progress, if requested
+// with WithUploadProgress.
+func uploadReader(ctx context.Context, r io.Reader) io.Reader {
+	ro := requestOptionsFrom(ctx)
+	if ro == nil || ro.uploadProgress == nil {
+		return r
+	}
+	return NewProgressReader(r, readerSize(r), ro.uploadProgress)
+}
+
+// readerSize returns the number of bytes left in r, or -1 if it cannot be
+// determined without reading.
+func readerSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len())
+	case io.Seeker:
+		cur, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if err != nil {
+			return -1
+		}
+		if _, err := v.Seek(cur, io.SeekStart); err != nil {
+			return -1
+		}
+		return end - cur
+	}
+	return -1
+}
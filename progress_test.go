@@ -0,0 +1,151 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestProgressReader(t *testing.T) {
+	var reads []int64
+	r := NewProgressReader(strings.NewReader("hello world"), 11, func(read, total int64) {
+		if total != 11 {
+			t.Errorf("total = %d, want 11", total)
+		}
+		reads = append(reads, read)
+	})
+	var out []byte
+	buf := make([]byte, 4)
+	for {
+		n, err := r.Read(buf)
+		out = append(out, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+	}
+	if string(out) != "hello world" {
+		t.Errorf("read %q", out)
+	}
+	if len(reads) != 3 || reads[2] != 11 {
+		t.Errorf("progress = %v, want 3 calls ending at 11", reads)
+	}
+}
+
+func TestMaxSizeReader(t *testing.T) {
+	b, err := io.ReadAll(NewMaxSizeReader(strings.NewReader("hello"), 5))
+	if err != nil || string(b) != "hello" {
+		t.Errorf("ReadAll() = %q, %v; want hello at the limit", b, err)
+	}
+
+	b, err = io.ReadAll(NewMaxSizeReader(strings.NewReader("hello world"), 5))
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("error = %v, want %v", err, ErrResponseTooLarge)
+	}
+	if string(b) != "hello" {
+		t.Errorf("read %q before failing, want hello", b)
+	}
+}
+
+func TestDownloadProgressAndMaxSize(t *testing.T) {
+	audio := bytes.Repeat([]byte{0xff}, 2048)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		if strings.HasSuffix(r.URL.Path, "/chunked") {
+			// Without Content-Length
+			_, _ = w.Write(audio[:1024])
+			w.(http.Flusher).Flush()
+			_, _ = w.Write(audio[1024:])
+			return
+		}
+		_, _ = w.Write(audio)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	req := &TTSRequest{VoiceID: "voice-1", Text: "Hello"}
+
+	var read, total int64
+	ctx := WithRequestOptions(context.Background(), WithDownloadProgress(func(r, t int64) {
+		read, total = r, t
+	}))
+	if _, err := client.TextToSpeech().Generate(ctx, req); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if read != 2048 || total != 2048 {
+		t.Errorf("progress = %d/%d, want 2048/2048", read, total)
+	}
+
+	ctx = WithRequestOptions(context.Background(), WithMaxResponseSize(1024))
+	if _, err := client.TextToSpeech().Generate(ctx, req); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("Generate() error = %v, want %v", err, ErrResponseTooLarge)
+	}
+
+	chunked := &TTSRequest{VoiceID: "chunked", Text: "Hello"}
+	if _, err := client.TextToSpeech().Generate(ctx, chunked); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("Generate() without Content-Length error = %v, want %v", err, ErrResponseTooLarge)
+	}
+}
+
+func TestUploadProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != -1 {
+			t.Errorf("ContentLength = %d, want a streamed body", r.ContentLength)
+		}
+		if _, _, err := r.FormFile("audio"); err != nil {
+			t.Errorf("FormFile() error = %v", err)
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write([]byte("converted"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	audio := bytes.Repeat([]byte{0xff, 0xfb, 0x90, 0x64}, 64*1024)
+	var read, total int64
+	ctx := WithRequestOptions(context.Background(), WithUploadProgress(func(r, t int64) {
+		read, total = r, t
+	}))
+	if _, err := client.SpeechToSpeech().Convert(ctx, &SpeechToSpeechRequest{VoiceID: "voice", Audio: bytes.NewReader(audio)}); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if read != int64(len(audio)) || total != int64(len(audio)) {
+		t.Errorf("progress = %d/%d, want %d/%d", read, total, len(audio), len(audio))
+	}
+}
+
+func TestReaderSize(t *testing.T) {
+	seeker := io.NewSectionReader(strings.NewReader("0123456789"), 0, 10)
+	_, _ = seeker.Seek(4, io.SeekStart)
+	tests := []struct {
+		name string
+		r    io.Reader
+		want int64
+	}{
+		{"Len", bytes.NewReader([]byte("abc")), 3},
+		{"Seeker", seeker, 6},
+		{"unknown", io.MultiReader(strings.NewReader("abc")), -1},
+	}
+	for _, tt := range tests {
+		if got := readerSize(tt.r); got != tt.want {
+			t.Errorf("readerSize(%s) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+	if pos, _ := seeker.Seek(0, io.SeekCurrent); pos != 4 {
+		t.Errorf("readerSize moved the seeker to %d", pos)
+	}
+}
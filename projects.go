@@ -1,8 +1,17 @@
 package elevenlabs
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/agentplexus/go-elevenlabs/internal/api"
@@ -56,6 +65,10 @@ type Project struct {
 
 	// AccessLevel is the access level of the project.
 	AccessLevel string
+
+	// State is the conversion state: "creating", "default", "converting",
+	// or "in_queue".
+	State string
 }
 
 // Chapter represents a chapter within a project.
@@ -178,7 +191,7 @@ type UpdateProjectRequest struct {
 func (s *ProjectsService) List(ctx context.Context) ([]*Project, error) {
 	resp, err := s.client.apiClient.GetProjects(ctx, api.GetProjectsParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
@@ -189,8 +202,10 @@ func (s *ProjectsService) List(ctx context.Context) ([]*Project, error) {
 			projects = append(projects, proj)
 		}
 		return projects, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -240,14 +255,16 @@ func (s *ProjectsService) Create(ctx context.Context, req *CreateProjectRequest)
 
 	resp, err := s.client.apiClient.AddProject(ctx, body, api.AddProjectParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
 	case *api.AddProjectResponseModel:
 		return projectFromAPI(&r.Project), nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -280,10 +297,9 @@ func (s *ProjectsService) Update(ctx context.Context, projectID string, req *Upd
 		body.Title = api.NewOptNilString(req.Title)
 	}
 
-	_, err := s.client.apiClient.EditProject(ctx, body, api.EditProjectParams{
+	return checkResponse(s.client.apiClient.EditProject(ctx, body, api.EditProjectParams{
 		ProjectID: projectID,
-	})
-	return err
+	}))
 }
 
 // Delete deletes a project.
@@ -292,10 +308,9 @@ func (s *ProjectsService) Delete(ctx context.Context, projectID string) error {
 		return &ValidationError{Field: "project_id", Message: "cannot be empty"}
 	}
 
-	_, err := s.client.apiClient.DeleteProject(ctx, api.DeleteProjectParams{
+	return checkResponse(s.client.apiClient.DeleteProject(ctx, api.DeleteProjectParams{
 		ProjectID: projectID,
-	})
-	return err
+	}))
 }
 
 // Convert initiates conversion of a project to audio.
@@ -304,10 +319,9 @@ func (s *ProjectsService) Convert(ctx context.Context, projectID string) error {
 		return &ValidationError{Field: "project_id", Message: "cannot be empty"}
 	}
 
-	_, err := s.client.apiClient.ConvertProjectEndpoint(ctx, api.ConvertProjectEndpointParams{
+	return checkResponse(s.client.apiClient.ConvertProjectEndpoint(ctx, api.ConvertProjectEndpointParams{
 		ProjectID: projectID,
-	})
-	return err
+	}))
 }
 
 // ListChapters returns all chapters in a project.
@@ -320,7 +334,7 @@ func (s *ProjectsService) ListChapters(ctx context.Context, projectID string) ([
 		ProjectID: projectID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
@@ -341,8 +355,10 @@ func (s *ProjectsService) ListChapters(ctx context.Context, projectID string) ([
 			chapters = append(chapters, ch)
 		}
 		return chapters, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -355,11 +371,10 @@ func (s *ProjectsService) ConvertChapter(ctx context.Context, projectID, chapter
 		return &ValidationError{Field: "chapter_id", Message: "cannot be empty"}
 	}
 
-	_, err := s.client.apiClient.ConvertChapterEndpoint(ctx, api.ConvertChapterEndpointParams{
+	return checkResponse(s.client.apiClient.ConvertChapterEndpoint(ctx, api.ConvertChapterEndpointParams{
 		ProjectID: projectID,
 		ChapterID: chapterID,
-	})
-	return err
+	}))
 }
 
 // DeleteChapter deletes a chapter from a project.
@@ -371,11 +386,10 @@ func (s *ProjectsService) DeleteChapter(ctx context.Context, projectID, chapterI
 		return &ValidationError{Field: "chapter_id", Message: "cannot be empty"}
 	}
 
-	_, err := s.client.apiClient.DeleteChapterEndpoint(ctx, api.DeleteChapterEndpointParams{
+	return checkResponse(s.client.apiClient.DeleteChapterEndpoint(ctx, api.DeleteChapterEndpointParams{
 		ProjectID: projectID,
 		ChapterID: chapterID,
-	})
-	return err
+	}))
 }
 
 // ListSnapshots returns all snapshots for a project.
@@ -388,7 +402,7 @@ func (s *ProjectsService) ListSnapshots(ctx context.Context, projectID string) (
 		ProjectID: projectID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
@@ -403,8 +417,10 @@ func (s *ProjectsService) ListSnapshots(ctx context.Context, projectID string) (
 			})
 		}
 		return snapshots, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -423,14 +439,16 @@ func (s *ProjectsService) DownloadSnapshotArchive(ctx context.Context, projectID
 			ProjectSnapshotID: snapshotID,
 		})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
 	case *api.StreamProjectSnapshotArchiveEndpointOK:
 		return r.Data, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -448,7 +466,7 @@ func (s *ProjectsService) ListChapterSnapshots(ctx context.Context, projectID, c
 		ChapterID: chapterID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
@@ -464,8 +482,10 @@ func (s *ProjectsService) ListChapterSnapshots(ctx context.Context, projectID, c
 			})
 		}
 		return snapshots, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -489,15 +509,191 @@ func (s *ProjectsService) StreamChapterAudio(ctx context.Context, projectID, cha
 			ChapterSnapshotID: snapshotID,
 		})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
 	case *api.StreamChapterSnapshotAudioOK:
 		return r.Data, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
+	}
+}
+
+// Polling intervals for WaitForConversion.
+const (
+	// DefaultConversionPollInterval is the first interval between status
+	// checks when none is given.
+	DefaultConversionPollInterval = 10 * time.Second
+
+	// MaxConversionPollInterval caps the interval between status checks.
+	MaxConversionPollInterval = time.Minute
+)
+
+func projectPath(projectID string) string {
+	return "/v1/studio/projects/" + url.PathEscape(projectID)
+}
+
+// WaitForConversion polls a project after Convert until it and all of its
+// chapters have finished converting, and returns the newest snapshot, or
+// nil if the project has none. Polling starts at pollInterval, or
+// DefaultConversionPollInterval if zero, and backs off up to
+// MaxConversionPollInterval. If a chapter fails to convert, the error wraps
+// ErrProjectConversionFailed.
+func (s *ProjectsService) WaitForConversion(ctx context.Context, projectID string, pollInterval time.Duration) (*ProjectSnapshot, error) {
+	if projectID == "" {
+		return nil, &ValidationError{Field: "project_id", Message: "cannot be empty"}
+	}
+	if pollInterval <= 0 {
+		pollInterval = DefaultConversionPollInterval
+	}
+	maxInterval := max(pollInterval, MaxConversionPollInterval)
+
+	for {
+		done, err := s.conversionDone(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			break
+		}
+		if err := waitBackoff(ctx, &pollInterval, maxInterval); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshots, err := s.ListSnapshots(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	var newest *ProjectSnapshot
+	for _, snap := range snapshots {
+		if newest == nil || snap.CreatedAt.After(newest.CreatedAt) {
+			newest = snap
+		}
+	}
+	return newest, nil
+}
+
+// conversionDone reports whether a project and its chapters have finished
+// converting.
+func (s *ProjectsService) conversionDone(ctx context.Context, projectID string) (bool, error) {
+	// The generated client has no endpoint for a single project
+	var project struct {
+		State string `json:"state"`
+	}
+	if err := s.client.doJSON(ctx, "GET", projectPath(projectID), nil, &project); err != nil {
+		return false, err
+	}
+	if project.State != "default" {
+		return false, nil
+	}
+
+	chapters, err := s.ListChapters(ctx, projectID)
+	if err != nil {
+		return false, err
+	}
+	for _, ch := range chapters {
+		if ch.State == "converting" {
+			return false, nil
+		}
+	}
+	for _, ch := range chapters {
+		if ch.LastConversionError != "" {
+			return false, fmt.Errorf("%w: chapter %q: %s", ErrProjectConversionFailed, ch.Name, ch.LastConversionError)
+		}
+	}
+	return true, nil
+}
+
+// StreamSnapshotAudio writes the audio of a project snapshot, with all of
+// its chapters, to w as a single MP3 file. Returns the number of bytes
+// written. The generated client discards this endpoint's body, so it is
+// requested directly; responses split into one part per chapter are
+// joined, dropping the ID3 tags of all but the first part.
+func (s *ProjectsService) StreamSnapshotAudio(ctx context.Context, projectID, snapshotID string, w io.Writer) (int64, error) {
+	if projectID == "" {
+		return 0, &ValidationError{Field: "project_id", Message: "cannot be empty"}
+	}
+	if snapshotID == "" {
+		return 0, &ValidationError{Field: "snapshot_id", Message: "cannot be empty"}
+	}
+
+	body, err := json.Marshal(map[string]bool{"convert_to_mpeg": true})
+	if err != nil {
+		return 0, err
+	}
+	path := projectPath(projectID) + "/snapshots/" + url.PathEscape(snapshotID) + "/stream"
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", s.client.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		apiErr := newAPIError(resp.StatusCode, respBody)
+		apiErr.setResponse(resp)
+		return 0, apiErr
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return io.Copy(w, resp.Body)
+	}
+
+	var written int64
+	parts := multipart.NewReader(resp.Body, params["boundary"])
+	for i := 0; ; i++ {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			return written, nil
+		}
+		if err != nil {
+			return written, fmt.Errorf("reading audio part: %w", err)
+		}
+
+		var r io.Reader = part
+		if i > 0 {
+			if r, err = skipID3(part); err != nil {
+				return written, fmt.Errorf("reading audio part: %w", err)
+			}
+		}
+		n, err := io.Copy(w, r)
+		written += n
+		if err != nil {
+			return written, err
+		}
+	}
+}
+
+// skipID3 skips an ID3v2 tag at the start of r, so MP3 parts can be
+// joined into one file.
+func skipID3(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(10)
+	if err != nil || string(header[:3]) != "ID3" {
+		// Parts shorter than a tag header are passed through
+		return br, nil
+	}
+	size := int64(header[6]&0x7f)<<21 | int64(header[7]&0x7f)<<14 | int64(header[8]&0x7f)<<7 | int64(header[9]&0x7f)
+	size += 10
+	if header[5]&0x10 != 0 {
+		// Footer present
+		size += 10
+	}
+	if _, err := io.CopyN(io.Discard, br, size); err != nil {
+		return nil, err
 	}
+	return br, nil
 }
 
 // projectFromAPI converts an API ProjectResponseModel to our Project type.
@@ -511,6 +707,7 @@ func projectFromAPI(p *api.ProjectResponseModel) *Project {
 		CreatedAt:               time.Unix(int64(p.CreateDateUnix), 0),
 		CanBeDownloaded:         p.CanBeDownloaded,
 		AccessLevel:             string(p.AccessLevel),
+		State:                   string(p.State),
 	}
 
 	if p.Description.Set && !p.Description.Null {
@@ -1,9 +1,18 @@
 package elevenlabs
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
 	"os"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestCreateProjectRequestValidate(t *testing.T) {
@@ -192,3 +201,116 @@ func TestProjectsService(t *testing.T) {
 		}
 	})
 }
+
+// newProjectConversionServer serves a project that is converting for the
+// first polls, then finished with chapter in the given state.
+func newProjectConversionServer(t *testing.T, chapterError string) *Client {
+	t.Helper()
+	var mu sync.Mutex
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/studio/projects/proj-1":
+			polls++
+			state := "default"
+			if polls == 1 {
+				state = "in_queue"
+			}
+			fmt.Fprintf(w, `{"project_id": "proj-1", "state": %q}`, state)
+		case "/v1/studio/projects/proj-1/chapters":
+			state := "default"
+			if polls == 2 {
+				state = "converting"
+			}
+			errMsg := "null"
+			if chapterError != "" {
+				errMsg = fmt.Sprintf("%q", chapterError)
+			}
+			fmt.Fprintf(w, `{"chapters": [{"chapter_id": "ch-1", "name": "Intro", "state": %q, "can_be_downloaded": true, "last_conversion_error": %s}]}`, state, errMsg)
+		case "/v1/studio/projects/proj-1/snapshots":
+			_, _ = w.Write([]byte(`{"snapshots": [
+				{"project_snapshot_id": "snap-old", "project_id": "proj-1", "name": "v1", "created_at_unix": 100},
+				{"project_snapshot_id": "snap-new", "project_id": "proj-1", "name": "v2", "created_at_unix": 200}
+			]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestProjectsWaitForConversion(t *testing.T) {
+	client := newProjectConversionServer(t, "")
+
+	snapshot, err := client.Projects().WaitForConversion(context.Background(), "proj-1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForConversion() error = %v", err)
+	}
+	if snapshot == nil || snapshot.ProjectSnapshotID != "snap-new" {
+		t.Errorf("WaitForConversion() = %+v, want newest snapshot", snapshot)
+	}
+}
+
+func TestProjectsWaitForConversionFailed(t *testing.T) {
+	client := newProjectConversionServer(t, "quota exceeded")
+
+	_, err := client.Projects().WaitForConversion(context.Background(), "proj-1", time.Millisecond)
+	if !errors.Is(err, ErrProjectConversionFailed) {
+		t.Errorf("WaitForConversion() error = %v, want ErrProjectConversionFailed", err)
+	}
+}
+
+func TestProjectsStreamSnapshotAudio(t *testing.T) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	// The second part starts with an ID3 tag holding 3 bytes of frames
+	parts := [][]byte{
+		[]byte("ID3\x04\x00\x00\x00\x00\x00\x01Xchapter-1"),
+		[]byte("ID3\x04\x00\x00\x00\x00\x00\x03abcchapter-2"),
+	}
+	for _, p := range parts {
+		pw, _ := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"audio/mpeg"}})
+		_, _ = pw.Write(p)
+	}
+	_ = mw.Close()
+
+	var requestBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/studio/projects/proj-1/snapshots/snap-1/stream" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var buf bytes.Buffer
+		_, _ = buf.ReadFrom(r.Body)
+		requestBody = buf.String()
+		w.Header().Set("Content-Type", mw.FormDataContentType())
+		_, _ = w.Write(body.Bytes())
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	n, err := client.Projects().StreamSnapshotAudio(context.Background(), "proj-1", "snap-1", &out)
+	if err != nil {
+		t.Fatalf("StreamSnapshotAudio() error = %v", err)
+	}
+	want := "ID3\x04\x00\x00\x00\x00\x00\x01Xchapter-1chapter-2"
+	if out.String() != want || n != int64(len(want)) {
+		t.Errorf("StreamSnapshotAudio() wrote %q (%d bytes), want %q", out.String(), n, want)
+	}
+	if requestBody != `{"convert_to_mpeg":true}` {
+		t.Errorf("request body = %s", requestBody)
+	}
+}
@@ -0,0 +1,141 @@
+package elevenlabs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// SystemVariablePrefix marks dynamic variables, such as
+// "system__caller_id", that the platform fills in for every conversation.
+const SystemVariablePrefix = "system__"
+
+// promptVariablePattern matches {{name}} placeholders, allowing spaces
+// inside the braces.
+var promptVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+// MissingVariablesError is returned when dynamic variables do not cover
+// every placeholder in an agent's prompt or first message.
+type MissingVariablesError struct {
+	// Missing are the names of the variables without values, sorted.
+	Missing []string
+}
+
+// Error implements the error interface.
+func (e *MissingVariablesError) Error() string {
+	return "elevenlabs: missing dynamic variables: " + strings.Join(e.Missing, ", ")
+}
+
+// PromptVariables returns the names of the {{variable}} placeholders in
+// templates, in order of first appearance. System variables are left out,
+// as the platform provides them.
+func PromptVariables(templates ...string) []string {
+	var names []string
+	for _, tmpl := range templates {
+		for _, m := range promptVariablePattern.FindAllStringSubmatch(tmpl, -1) {
+			name := m[1]
+			if strings.HasPrefix(name, SystemVariablePrefix) || slices.Contains(names, name) {
+				continue
+			}
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// CheckPromptVariables checks that vars has a value for every placeholder
+// in templates. Returns a *MissingVariablesError listing the variables
+// without values.
+func CheckPromptVariables(vars map[string]any, templates ...string) error {
+	var missing []string
+	for _, name := range PromptVariables(templates...) {
+		if _, ok := vars[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return &MissingVariablesError{Missing: missing}
+	}
+	return nil
+}
+
+// RenderPrompt replaces the placeholders in tmpl with values from vars,
+// as the agent would see them, for previews and tests. System variables
+// are left in place. Returns a *MissingVariablesError if a value is
+// missing.
+func RenderPrompt(tmpl string, vars map[string]any) (string, error) {
+	if err := CheckPromptVariables(vars, tmpl); err != nil {
+		return "", err
+	}
+	return promptVariablePattern.ReplaceAllStringFunc(tmpl, func(placeholder string) string {
+		name := promptVariablePattern.FindStringSubmatch(placeholder)[1]
+		value, ok := vars[name]
+		if !ok {
+			return placeholder
+		}
+		if value == nil {
+			return ""
+		}
+		return fmt.Sprint(value)
+	}), nil
+}
+
+// agentPromptConfig is the agent config fragment holding the prompt, the
+// first message, and default variable values.
+type agentPromptConfig struct {
+	ConversationConfig struct {
+		Agent struct {
+			FirstMessage string `json:"first_message"`
+			Prompt       struct {
+				Prompt string `json:"prompt"`
+			} `json:"prompt"`
+			DynamicVariables struct {
+				Placeholders map[string]any `json:"dynamic_variable_placeholders"`
+			} `json:"dynamic_variables"`
+		} `json:"agent"`
+	} `json:"conversation_config"`
+}
+
+// CheckDynamicVariables checks that data covers the placeholders in an
+// agent's prompt and first message before starting a conversation, such as
+// with RegisterCall or OutboundCall. Prompt and first message overrides in
+// data are checked instead of the stored ones, and variables with default
+// values in the agent are not required. Returns a *MissingVariablesError
+// listing the variables without values.
+func (s *AgentsService) CheckDynamicVariables(ctx context.Context, agentID string, data *ConversationInitiationData) error {
+	if agentID == "" {
+		return &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+	if data == nil {
+		data = &ConversationInitiationData{}
+	}
+	if err := data.Validate(); err != nil {
+		return err
+	}
+
+	var agent agentPromptConfig
+	if err := s.client.doJSON(ctx, "GET", agentPath(agentID), nil, &agent); err != nil {
+		return err
+	}
+
+	cfg := agent.ConversationConfig.Agent
+	prompt, firstMessage := cfg.Prompt.Prompt, cfg.FirstMessage
+	if data.Prompt != "" {
+		prompt = data.Prompt
+	}
+	if data.FirstMessage != "" {
+		firstMessage = data.FirstMessage
+	}
+
+	vars := make(map[string]any, len(cfg.DynamicVariables.Placeholders)+len(data.DynamicVariables))
+	for name, value := range cfg.DynamicVariables.Placeholders {
+		vars[name] = value
+	}
+	for name, value := range data.DynamicVariables {
+		vars[name] = value
+	}
+	return CheckPromptVariables(vars, prompt, firstMessage)
+}
@@ -0,0 +1,104 @@
+package elevenlabs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestPromptVariables(t *testing.T) {
+	got := PromptVariables(
+		"Hello {{name}}, your order {{ order_id }} ships {{date}}. Caller: {{system__caller_id}}",
+		"Hi {{name}}! {{greeting}}",
+	)
+	want := []string{"name", "order_id", "date", "greeting"}
+	if !slices.Equal(got, want) {
+		t.Errorf("PromptVariables() = %v, want %v", got, want)
+	}
+
+	if got := PromptVariables("no placeholders, {single} or {{ }}"); len(got) != 0 {
+		t.Errorf("PromptVariables() = %v, want none", got)
+	}
+}
+
+func TestCheckPromptVariables(t *testing.T) {
+	err := CheckPromptVariables(map[string]any{"name": "Ada"}, "{{name}} {{plan}} {{account}}")
+	var missing *MissingVariablesError
+	if !errors.As(err, &missing) {
+		t.Fatalf("CheckPromptVariables() error = %v, want *MissingVariablesError", err)
+	}
+	if !slices.Equal(missing.Missing, []string{"account", "plan"}) {
+		t.Errorf("Missing = %v", missing.Missing)
+	}
+	if got := err.Error(); got != "elevenlabs: missing dynamic variables: account, plan" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	if err := CheckPromptVariables(map[string]any{"name": "Ada"}, "{{name}} {{system__time}}"); err != nil {
+		t.Errorf("CheckPromptVariables() error = %v", err)
+	}
+}
+
+func TestRenderPrompt(t *testing.T) {
+	got, err := RenderPrompt("Hi {{ name }}, you have {{count}} messages. VIP: {{vip}}. {{system__time}}",
+		map[string]any{"name": "Ada", "count": 3, "vip": true})
+	if err != nil {
+		t.Fatalf("RenderPrompt() error = %v", err)
+	}
+	if want := "Hi Ada, you have 3 messages. VIP: true. {{system__time}}"; got != want {
+		t.Errorf("RenderPrompt() = %q, want %q", got, want)
+	}
+
+	if _, err := RenderPrompt("Hi {{name}}", nil); err == nil {
+		t.Error("RenderPrompt() with missing variable should return error")
+	}
+}
+
+func TestAgentsCheckDynamicVariables(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/convai/agents/agent-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"conversation_config": {"agent": {
+			"first_message": "Hi {{customer_name}}!",
+			"prompt": {"prompt": "Help {{customer_name}} with order {{order_id}} in {{region}}."},
+			"dynamic_variables": {"dynamic_variable_placeholders": {"region": "EU"}}
+		}}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+	agents := client.Agents()
+
+	err = agents.CheckDynamicVariables(ctx, "agent-1", &ConversationInitiationData{
+		DynamicVariables: map[string]any{"customer_name": "Ada"},
+	})
+	var missing *MissingVariablesError
+	if !errors.As(err, &missing) || !slices.Equal(missing.Missing, []string{"order_id"}) {
+		t.Errorf("CheckDynamicVariables() error = %v, want order_id missing", err)
+	}
+
+	err = agents.CheckDynamicVariables(ctx, "agent-1", &ConversationInitiationData{
+		DynamicVariables: map[string]any{"customer_name": "Ada", "order_id": "A-1"},
+	})
+	if err != nil {
+		t.Errorf("CheckDynamicVariables() error = %v", err)
+	}
+
+	// Overrides replace the stored prompt
+	err = agents.CheckDynamicVariables(ctx, "agent-1", &ConversationInitiationData{
+		Prompt:           "Help {{customer_name}}.",
+		DynamicVariables: map[string]any{"customer_name": "Ada"},
+	})
+	if err != nil {
+		t.Errorf("CheckDynamicVariables() with override error = %v", err)
+	}
+}
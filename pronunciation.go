@@ -75,7 +75,7 @@ func (s *PronunciationService) List(ctx context.Context, opts *PronunciationDict
 
 	resp, err := s.client.apiClient.GetPronunciationDictionariesMetadata(ctx, params)
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	// Handle response type
@@ -106,8 +106,10 @@ func (s *PronunciationService) List(ctx context.Context, opts *PronunciationDict
 		}
 
 		return result, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -121,7 +123,7 @@ func (s *PronunciationService) Get(ctx context.Context, dictionaryID string) (*P
 		PronunciationDictionaryID: dictionaryID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	// Handle response type
@@ -139,8 +141,10 @@ func (s *PronunciationService) Get(ctx context.Context, dictionaryID string) (*P
 			dict.Description = r.Description.Value
 		}
 		return dict, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -219,7 +223,7 @@ func (s *PronunciationService) Create(ctx context.Context, req *CreatePronunciat
 
 	resp, err := s.client.apiClient.AddFromFile(ctx, body, api.AddFromFileParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	// Handle response type
@@ -237,8 +241,10 @@ func (s *PronunciationService) Create(ctx context.Context, req *CreatePronunciat
 			dict.Description = r.Description.Value
 		}
 		return dict, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -293,10 +299,9 @@ func (s *PronunciationService) RemoveRules(ctx context.Context, dictionaryID str
 		RuleStrings: ruleStrings,
 	}
 
-	_, err := s.client.apiClient.RemoveRules(ctx, body, api.RemoveRulesParams{
+	return checkResponse(s.client.apiClient.RemoveRules(ctx, body, api.RemoveRulesParams{
 		PronunciationDictionaryID: dictionaryID,
-	})
-	return err
+	}))
 }
 
 // Rename renames a pronunciation dictionary.
@@ -312,12 +317,11 @@ func (s *PronunciationService) Rename(ctx context.Context, dictionaryID, newName
 		Name: api.NewOptString(newName),
 	}
 
-	_, err := s.client.apiClient.PatchPronunciationDictionary(ctx,
+	return checkResponse(s.client.apiClient.PatchPronunciationDictionary(ctx,
 		api.NewOptBodyUpdatePronunciationDictionaryV1PronunciationDictionariesPronunciationDictionaryIDPatch(body),
 		api.PatchPronunciationDictionaryParams{
 			PronunciationDictionaryID: dictionaryID,
-		})
-	return err
+		}))
 }
 
 // Archive archives a pronunciation dictionary.
@@ -330,12 +334,11 @@ func (s *PronunciationService) Archive(ctx context.Context, dictionaryID string)
 		Archived: api.NewOptBool(true),
 	}
 
-	_, err := s.client.apiClient.PatchPronunciationDictionary(ctx,
+	return checkResponse(s.client.apiClient.PatchPronunciationDictionary(ctx,
 		api.NewOptBodyUpdatePronunciationDictionaryV1PronunciationDictionariesPronunciationDictionaryIDPatch(body),
 		api.PatchPronunciationDictionaryParams{
 			PronunciationDictionaryID: dictionaryID,
-		})
-	return err
+		}))
 }
 
 // GetVersionPLS returns the PLS (Pronunciation Lexicon Specification) XML file
@@ -366,14 +369,16 @@ func (s *PronunciationService) GetVersionPLS(ctx context.Context, dictionaryID,
 		VersionID:    versionID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
 	case *api.GetPronunciationDictionaryVersionPlsOKHeaders:
 		return r.Response.Data, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -5,7 +5,10 @@ import (
 	"encoding/xml"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // PronunciationRule defines how a word or phrase should be pronounced.
@@ -193,6 +196,124 @@ func (rules PronunciationRules) String() string {
 	return sb.String()
 }
 
+// PhonemeModels lists the models that read SSML phoneme tags and phoneme
+// rules in pronunciation dictionaries. Other models only apply aliases.
+var PhonemeModels = map[string]bool{
+	"eleven_flash_v2":       true,
+	"eleven_turbo_v2":       true,
+	"eleven_monolingual_v1": true,
+}
+
+// SupportsPhonemes reports whether a model reads phoneme pronunciations.
+// An empty modelID means DefaultModelID.
+func SupportsPhonemes(modelID string) bool {
+	if modelID == "" {
+		modelID = DefaultModelID
+	}
+	return PhonemeModels[modelID]
+}
+
+// Apply rewrites text with the rules in the way modelID supports, so the
+// same rules work with every model: on models that read phonemes (see
+// SupportsPhonemes), phoneme rules wrap the word in an SSML phoneme tag;
+// elsewhere, and for alias rules, the word is replaced with its alias.
+// Give a grapheme both a phoneme rule and an alias rule to get the
+// phoneme where supported and the alias as the fallback:
+//
+//	rules := elevenlabs.PronunciationRules{
+//	    {Grapheme: "nginx", Phoneme: "ˈɛndʒɪnˈɛks"},
+//	    {Grapheme: "nginx", Alias: "engine X"},
+//	}
+//	text, skipped := rules.Apply("Restart nginx.", req.ModelID)
+//
+// Graphemes match whole words, case-sensitively, longest first. Apply
+// returns the rules it could not use: phoneme rules without an alias on
+// models that do not read phonemes. Invalid rules are ignored.
+func (rules PronunciationRules) Apply(text, modelID string) (string, PronunciationRules) {
+	phonemes := SupportsPhonemes(modelID)
+
+	aliases := make(map[string]string)
+	ipa := make(map[string]string)
+	for _, rule := range rules {
+		if rule.Validate() != nil {
+			continue
+		}
+		if rule.Alias != "" {
+			aliases[rule.Grapheme] = rule.Alias
+		} else {
+			ipa[rule.Grapheme] = rule.Phoneme
+		}
+	}
+
+	// Prefer the phoneme where supported, else the alias
+	replacements := aliases
+	var skipped PronunciationRules
+	for grapheme, ph := range ipa {
+		switch {
+		case phonemes:
+			replacements[grapheme] = fmt.Sprintf(`<phoneme alphabet="ipa" ph="%s">%s</phoneme>`, escapeAttr(ph), escapeAttr(grapheme))
+		case aliases[grapheme] == "":
+			skipped = append(skipped, PronunciationRule{Grapheme: grapheme, Phoneme: ph})
+		}
+	}
+	sort.Slice(skipped, func(i, j int) bool { return skipped[i].Grapheme < skipped[j].Grapheme })
+	if len(replacements) == 0 {
+		return text, skipped
+	}
+
+	graphemes := make([]string, 0, len(replacements))
+	for g := range replacements {
+		graphemes = append(graphemes, g)
+	}
+	sort.Slice(graphemes, func(i, j int) bool {
+		if len(graphemes[i]) != len(graphemes[j]) {
+			return len(graphemes[i]) > len(graphemes[j])
+		}
+		return graphemes[i] < graphemes[j]
+	})
+
+	var sb strings.Builder
+	for i := 0; i < len(text); {
+		matched := ""
+		if i == 0 || !isWordRune(lastRune(text[:i])) {
+			for _, g := range graphemes {
+				if strings.HasPrefix(text[i:], g) && !startsWithWordRune(text[i+len(g):]) {
+					matched = g
+					break
+				}
+			}
+		}
+		if matched != "" {
+			sb.WriteString(replacements[matched])
+			i += len(matched)
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		sb.WriteString(text[i : i+size])
+		i += size
+	}
+	return sb.String(), skipped
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+func lastRune(s string) rune {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return r
+}
+
+func startsWithWordRune(s string) bool {
+	r, size := utf8.DecodeRuneInString(s)
+	return size > 0 && isWordRune(r)
+}
+
+// escapeAttr escapes s for use in a double-quoted XML attribute.
+func escapeAttr(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(s)
+}
+
 // PLS XML structures (internal)
 
 type plsLexicon struct {
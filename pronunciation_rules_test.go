@@ -249,3 +249,64 @@ func TestParseRulesFromJSONInvalid(t *testing.T) {
 		})
 	}
 }
+
+func TestPronunciationRulesApply(t *testing.T) {
+	rules := PronunciationRules{
+		{Grapheme: "nginx", Phoneme: "ˈɛndʒɪnˈɛks"},
+		{Grapheme: "nginx", Alias: "engine X"},
+		{Grapheme: "API", Alias: "A P I"},
+		{Grapheme: "API key", Alias: "A P I key"},
+		{Grapheme: "Kubernetes", Phoneme: "ˌkuːbərˈnɛtiːz"},
+		{Grapheme: "AT&T", Phoneme: `eɪ"tiː`},
+	}
+	text := "Restart nginx, rotate the API key, call the API. Kubernetes runs AT&T's APIs."
+
+	tests := []struct {
+		name        string
+		modelID     string
+		want        string
+		wantSkipped []string
+	}{
+		{
+			name:    "phoneme model",
+			modelID: "eleven_flash_v2",
+			want: `Restart <phoneme alphabet="ipa" ph="ˈɛndʒɪnˈɛks">nginx</phoneme>, rotate the A P I key, call the A P I. ` +
+				`<phoneme alphabet="ipa" ph="ˌkuːbərˈnɛtiːz">Kubernetes</phoneme> runs ` +
+				`<phoneme alphabet="ipa" ph="eɪ&quot;tiː">AT&amp;T</phoneme>'s APIs.`,
+		},
+		{
+			name:        "alias model",
+			modelID:     "eleven_multilingual_v2",
+			want:        "Restart engine X, rotate the A P I key, call the A P I. Kubernetes runs AT&T's APIs.",
+			wantSkipped: []string{"AT&T", "Kubernetes"},
+		},
+		{
+			name:        "default model",
+			want:        "Restart engine X, rotate the A P I key, call the A P I. Kubernetes runs AT&T's APIs.",
+			wantSkipped: []string{"AT&T", "Kubernetes"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, skipped := rules.Apply(text, tt.modelID)
+			if got != tt.want {
+				t.Errorf("Apply() =\n%s\nwant\n%s", got, tt.want)
+			}
+			if graphemes := skipped.Graphemes(); strings.Join(graphemes, ",") != strings.Join(tt.wantSkipped, ",") {
+				t.Errorf("skipped = %v, want %v", graphemes, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestTTSRequestPronunciations(t *testing.T) {
+	req := &TTSRequest{
+		Text:           "Deploy with kubectl on Dr. Ops day.",
+		ModelID:        "eleven_turbo_v2_5",
+		Pronunciations: PronunciationRules{{Grapheme: "kubectl", Alias: "kube control"}},
+		Normalize:      true,
+	}
+	if got, want := req.text(), "Deploy with kube control on Doctor Ops day."; got != want {
+		t.Errorf("text() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,351 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RecorderMode selects whether WithRecorder sends requests or replays them.
+type RecorderMode int
+
+const (
+	// RecorderReplay answers requests from the cassette only. Requests
+	// that were not recorded fail with ErrNotRecorded, so tests never
+	// reach the API.
+	RecorderReplay RecorderMode = iota
+
+	// RecorderRecord sends every request to the API and records the
+	// interactions, replacing the cassette.
+	RecorderRecord
+
+	// RecorderReplayOrRecord replays recorded requests and sends and
+	// records new ones, adding them to the cassette.
+	RecorderReplayOrRecord
+)
+
+// ErrNotRecorded is returned in replay mode for requests that are not in
+// the cassette.
+var ErrNotRecorded = errors.New("elevenlabs: request not recorded")
+
+// RecorderOptions configures WithRecorder.
+type RecorderOptions struct {
+	// Redact lists secrets to remove from recordings, in addition to the
+	// client's API keys, such as webhook secrets or customer data.
+	Redact []string
+
+	// MaxAudioBytes truncates audio and other binary response bodies to
+	// keep cassettes small. Zero keeps whole bodies. Replayed bodies are
+	// the truncated ones.
+	MaxAudioBytes int
+}
+
+// WithRecorder records HTTP interactions with the API to a cassette file
+// at path, or replays them from it, so tests that exercise real API
+// responses run in CI without an API key:
+//
+//	mode := elevenlabs.RecorderReplay
+//	if os.Getenv("RECORD") != "" {
+//	    mode = elevenlabs.RecorderRecord
+//	}
+//	client, err := elevenlabs.NewClient(elevenlabs.WithRecorder("testdata/voices.json", mode))
+//
+// Requests are matched by method, path, query, and body; multipart bodies
+// are not compared. Requests made several times are replayed in the order
+// they were recorded, then the last response is repeated. API keys are
+// redacted from recordings.
+//
+// Response bodies are read fully before they are returned while recording.
+// WebSocket connections are not recorded.
+func WithRecorder(path string, mode RecorderMode) Option {
+	return func(o *clientOptions) {
+		o.recorderPath = path
+		o.recorderMode = mode
+	}
+}
+
+// WithRecorderOptions sets the redaction and truncation of WithRecorder.
+func WithRecorderOptions(opts RecorderOptions) Option {
+	return func(o *clientOptions) {
+		o.recorderOptions = opts
+	}
+}
+
+// cassette is the file format of a recording.
+type cassette struct {
+	Interactions []*interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+
+	// Body is a text body; BodyBytes a binary one.
+	Body      string `json:"body,omitempty"`
+	BodyBytes []byte `json:"body_bytes,omitempty"`
+
+	// Size is the length of a truncated body before truncation.
+	Size      int  `json:"size,omitempty"`
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// recorder is an http.RoundTripper that records or replays requests.
+type recorder struct {
+	path    string
+	mode    RecorderMode
+	next    http.RoundTripper
+	secrets []string
+	maxBody int
+
+	mu       sync.Mutex
+	cassette cassette
+	used     []bool
+}
+
+// newRecorder returns the recorder for the options, or nil if recording is
+// not enabled. In replay mode the cassette must exist.
+func newRecorder(o *clientOptions) (*recorder, error) {
+	if o.recorderPath == "" {
+		return nil, nil
+	}
+	r := &recorder{
+		path:    o.recorderPath,
+		mode:    o.recorderMode,
+		maxBody: o.recorderOptions.MaxAudioBytes,
+	}
+	if o.apiKey != "" {
+		r.secrets = append(r.secrets, o.apiKey)
+	}
+	if o.keyPool != nil {
+		for _, k := range o.keyPool.keys {
+			r.secrets = append(r.secrets, k.key)
+		}
+	}
+	for _, s := range o.recorderOptions.Redact {
+		if s != "" {
+			r.secrets = append(r.secrets, s)
+		}
+	}
+
+	if r.mode != RecorderRecord {
+		data, err := os.ReadFile(r.path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(data, &r.cassette); err != nil {
+				return nil, fmt.Errorf("reading cassette %s: %w", r.path, err)
+			}
+		case errors.Is(err, os.ErrNotExist) && r.mode == RecorderReplayOrRecord:
+		default:
+			return nil, fmt.Errorf("reading cassette: %w", err)
+		}
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// client returns a copy of c that records or replays its requests.
+func (r *recorder) client(c *http.Client) *http.Client {
+	wrapped := *c
+	r.next = c.Transport
+	if r.next == nil {
+		r.next = http.DefaultTransport
+	}
+	wrapped.Transport = r
+	return &wrapped
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	rec := r.recordRequest(req, body)
+
+	if r.mode != RecorderRecord {
+		if resp, ok := r.replay(rec, req); ok {
+			return resp, nil
+		}
+		if r.mode == RecorderReplay {
+			return nil, fmt.Errorf("%w: %s %s", ErrNotRecorded, rec.Method, rec.URL)
+		}
+	}
+
+	out := req.Clone(req.Context())
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+		out.ContentLength = int64(len(body))
+	}
+	resp, err := r.next.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	if err := r.record(&interaction{Request: rec, Response: r.recordResponse(resp, respBody)}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// recordRequest returns the redacted form of a request, used both to
+// record it and to look it up.
+func (r *recorder) recordRequest(req *http.Request, body []byte) recordedRequest {
+	rec := recordedRequest{
+		Method: req.Method,
+		URL:    r.redact(req.URL.RequestURI()),
+		Header: r.redactHeader(req.Header),
+	}
+	if mediaType := contentMediaType(req.Header); !strings.HasPrefix(mediaType, "multipart/") && len(body) > 0 {
+		if isTextMediaType(mediaType) && req.Header.Get("Content-Encoding") == "" {
+			rec.Body = r.redact(string(body))
+		} else {
+			rec.Body = fmt.Sprintf("<%d bytes>", len(body))
+		}
+	}
+	return rec
+}
+
+// recordResponse returns the redacted, possibly truncated, form of a
+// response.
+func (r *recorder) recordResponse(resp *http.Response, body []byte) recordedResponse {
+	rec := recordedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     r.redactHeader(resp.Header),
+	}
+	if isTextMediaType(contentMediaType(resp.Header)) && resp.Header.Get("Content-Encoding") == "" {
+		rec.Body = r.redact(string(body))
+		return rec
+	}
+	if r.maxBody > 0 && len(body) > r.maxBody {
+		rec.Size = len(body)
+		rec.Truncated = true
+		body = body[:r.maxBody]
+	}
+	rec.BodyBytes = body
+	return rec
+}
+
+// replay returns the recorded response to rec: the first unused match, or
+// the last match if all were used.
+func (r *recorder) replay(rec recordedRequest, req *http.Request) (*http.Response, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, in := range r.cassette.Interactions {
+		if in.Request.Method != rec.Method || in.Request.URL != rec.URL || in.Request.Body != rec.Body {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, false
+	}
+	r.used[match] = true
+
+	recorded := r.cassette.Interactions[match].Response
+	body := recorded.BodyBytes
+	if recorded.Body != "" {
+		body = []byte(recorded.Body)
+	}
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	if header.Get("Content-Length") != "" {
+		header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, true
+}
+
+// record adds an interaction and saves the cassette.
+func (r *recorder) record(in *interaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, in)
+	r.used = append(r.used, true)
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("writing cassette: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing cassette: %w", err)
+	}
+	return nil
+}
+
+// redact replaces secrets in s.
+func (r *recorder) redact(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return s
+}
+
+// redactHeader returns a copy of h with credentials and secrets redacted.
+func (r *recorder) redactHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(http.Header, len(h))
+	for name, values := range h {
+		switch http.CanonicalHeaderKey(name) {
+		case "Xi-Api-Key", "Authorization", "Cookie", "Set-Cookie":
+			out[name] = []string{redacted}
+		default:
+			for _, v := range values {
+				out[name] = append(out[name], r.redact(v))
+			}
+		}
+	}
+	return out
+}
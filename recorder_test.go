@@ -0,0 +1,164 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		switch r.URL.Path {
+		case "/v1/thing":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"n": %d, "key": %q, "webhook": "whsec-1"}`, n, r.Header.Get("xi-api-key"))
+		case "/v1/text-to-speech/voice-1":
+			w.Header().Set("Content-Type", "audio/mpeg")
+			w.Write(bytes.Repeat([]byte{0xFF}, 100))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+	ctx := context.Background()
+	var thing struct {
+		N   int    `json:"n"`
+		Key string `json:"key"`
+	}
+
+	client, err := NewClient(
+		WithAPIKey("secret-key-123"),
+		WithBaseURL(server.URL),
+		WithRecorder(path, RecorderRecord),
+		WithRecorderOptions(RecorderOptions{Redact: []string{"whsec-1"}, MaxAudioBytes: 10}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	for want := 1; want <= 2; want++ {
+		if err := client.doJSON(ctx, http.MethodGet, "/v1/thing", nil, &thing); err != nil {
+			t.Fatalf("doJSON() error = %v", err)
+		}
+		if thing.N != want || thing.Key != "secret-key-123" {
+			t.Errorf("recording: thing = %+v, want n %d with the real response", thing, want)
+		}
+	}
+	audio, err := client.TextToSpeech().Simple(ctx, "voice-1", "Hello")
+	if err != nil {
+		t.Fatalf("Simple() error = %v", err)
+	}
+	if data, _ := io.ReadAll(audio); len(data) != 100 {
+		t.Errorf("recording: len(audio) = %d, want the whole body", len(data))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	for _, secret := range []string{"secret-key-123", "whsec-1"} {
+		if bytes.Contains(data, []byte(secret)) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+	if !bytes.Contains(data, []byte(`"truncated": true`)) {
+		t.Errorf("cassette audio not truncated:\n%s", data)
+	}
+
+	// Replay without an API key or a server
+	server.Close()
+	recorded := hits.Load()
+	client, err = NewClient(WithAPIKey(""), WithBaseURL(server.URL), WithRecorder(path, RecorderReplay))
+	if err != nil {
+		t.Fatalf("NewClient() replay error = %v", err)
+	}
+	for _, want := range []int{1, 2, 2} { // then the last response repeats
+		if err := client.doJSON(ctx, http.MethodGet, "/v1/thing", nil, &thing); err != nil {
+			t.Fatalf("replay doJSON() error = %v", err)
+		}
+		if thing.N != want || thing.Key != redacted {
+			t.Errorf("replay: thing = %+v, want n %d", thing, want)
+		}
+	}
+	audio, err = client.TextToSpeech().Simple(ctx, "voice-1", "Hello")
+	if err != nil {
+		t.Fatalf("replay Simple() error = %v", err)
+	}
+	if data, _ := io.ReadAll(audio); len(data) != 10 {
+		t.Errorf("replay: len(audio) = %d, want the truncated 10 bytes", len(data))
+	}
+	if hits.Load() != recorded {
+		t.Errorf("replay reached the server")
+	}
+
+	// The body is part of the match
+	_, err = client.TextToSpeech().Simple(ctx, "voice-1", "Goodbye")
+	if !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("unrecorded request error = %v, want ErrNotRecorded", err)
+	}
+}
+
+func TestRecorderReplayOrRecord(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"path": %q}`, r.URL.RequestURI())
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "test.json")
+	ctx := context.Background()
+	newClient := func() *Client {
+		client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithRecorder(path, RecorderReplayOrRecord))
+		if err != nil {
+			t.Fatalf("NewClient() error = %v", err)
+		}
+		return client
+	}
+
+	// A missing cassette is created
+	var out struct{ Path string }
+	if err := newClient().doJSON(ctx, http.MethodGet, "/v1/a?x=1", nil, &out); err != nil {
+		t.Fatalf("doJSON() error = %v", err)
+	}
+
+	client := newClient()
+	for _, p := range []string{"/v1/a?x=1", "/v1/b"} {
+		if err := client.doJSON(ctx, http.MethodGet, p, nil, &out); err != nil {
+			t.Fatalf("doJSON(%s) error = %v", p, err)
+		}
+		if out.Path != p {
+			t.Errorf("Path = %s, want %s", out.Path, p)
+		}
+	}
+	if hits.Load() != 2 {
+		t.Errorf("hits = %d, want the recorded request replayed", hits.Load())
+	}
+
+	var c cassette
+	data, _ := os.ReadFile(path)
+	if err := json.Unmarshal(data, &c); err != nil || len(c.Interactions) != 2 {
+		t.Errorf("cassette = %d interactions, %v; want 2", len(c.Interactions), err)
+	}
+}
+
+func TestRecorderReplayMissingCassette(t *testing.T) {
+	_, err := NewClient(WithRecorder(filepath.Join(t.TempDir(), "missing.json"), RecorderReplay))
+	if err == nil || !strings.Contains(err.Error(), "cassette") {
+		t.Errorf("NewClient() error = %v, want missing cassette", err)
+	}
+}
@@ -0,0 +1,157 @@
+package elevenlabs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RequestOption customizes the API calls made with a context returned by
+// WithRequestOptions.
+type RequestOption func(*requestOptions)
+
+// requestOptions holds per-request overrides carried in a context.
+type requestOptions struct {
+	apiKey          string
+	headers         http.Header
+	timeout         time.Duration
+	progress        ProgressFunc
+	uploadProgress  ProgressFunc
+	maxResponseSize int64
+	noCompression   bool
+}
+
+// requestOptionsKey is the context key for per-request options.
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a context that applies opts to every API call
+// made with it, without creating a new Client. Options already set on ctx
+// are kept unless overridden.
+//
+// Use this in multi-tenant servers to call the API with each tenant's key:
+//
+//	ctx = elevenlabs.WithRequestOptions(ctx,
+//	    elevenlabs.WithRequestAPIKey(tenant.APIKey),
+//	    elevenlabs.WithIdempotencyKey(jobID),
+//	)
+//	resp, err := client.TextToSpeech().Generate(ctx, req)
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	ro := &requestOptions{headers: http.Header{}}
+	if parent := requestOptionsFrom(ctx); parent != nil {
+		ro.apiKey = parent.apiKey
+		ro.headers = parent.headers.Clone()
+		ro.timeout = parent.timeout
+		ro.progress = parent.progress
+		ro.uploadProgress = parent.uploadProgress
+		ro.maxResponseSize = parent.maxResponseSize
+		ro.noCompression = parent.noCompression
+	}
+	for _, opt := range opts {
+		opt(ro)
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, ro)
+}
+
+// WithRequestAPIKey uses apiKey instead of the client's API key.
+func WithRequestAPIKey(apiKey string) RequestOption {
+	return func(o *requestOptions) {
+		o.apiKey = apiKey
+	}
+}
+
+// WithRequestHeader sets a header on each request, replacing any value set
+// by the SDK.
+func WithRequestHeader(name, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.headers.Set(name, value)
+	}
+}
+
+// WithIdempotencyKey sets the Idempotency-Key header, so a retried request
+// is not applied twice.
+func WithIdempotencyKey(key string) RequestOption {
+	return WithRequestHeader("Idempotency-Key", key)
+}
+
+// WithRequestTimeout limits each request, including reading the response
+// body, to timeout. It does not extend the client's timeout.
+func WithRequestTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithDownloadProgress reports the progress of reading each response body
+// to fn, with the total taken from Content-Length. Use it to show the state
+// of large downloads such as dubbed videos or studio snapshots.
+func WithDownloadProgress(fn ProgressFunc) RequestOption {
+	return func(o *requestOptions) {
+		o.progress = fn
+	}
+}
+
+// WithUploadProgress reports the progress of reading each uploaded file to
+// fn, with the total taken from the file's size when it can be determined.
+// It applies to speech-to-text and speech-to-speech uploads, which stream
+// the file as it is read.
+func WithUploadProgress(fn ProgressFunc) RequestOption {
+	return func(o *requestOptions) {
+		o.uploadProgress = fn
+	}
+}
+
+// WithMaxResponseSize fails requests whose response body is larger than
+// limit bytes with ErrResponseTooLarge, so an unexpectedly large download
+// cannot exhaust memory.
+func WithMaxResponseSize(limit int64) RequestOption {
+	return func(o *requestOptions) {
+		o.maxResponseSize = limit
+	}
+}
+
+func requestOptionsFrom(ctx context.Context) *requestOptions {
+	ro, _ := ctx.Value(requestOptionsKey{}).(*requestOptions)
+	return ro
+}
+
+// setRequestHeaders sets the authentication and per-request headers.
+func setRequestHeaders(h http.Header, apiKey string, ro *requestOptions) {
+	if ro != nil && ro.apiKey != "" {
+		apiKey = ro.apiKey
+	}
+	if apiKey != "" {
+		h.Set("xi-api-key", apiKey)
+	}
+	if ro != nil {
+		for name, values := range ro.headers {
+			h[name] = append([]string(nil), values...)
+		}
+	}
+}
+
+// connectHeaders returns the headers for connections made outside the HTTP
+// client, such as WebSockets.
+func (c *Client) connectHeaders(ctx context.Context) http.Header {
+	apiKey := c.apiKey
+	if c.keyPool != nil {
+		if key := c.keyPool.connectKey(); key != "" {
+			apiKey = key
+		}
+	}
+	h := http.Header{}
+	setRequestHeaders(h, apiKey, requestOptionsFrom(ctx))
+	return h
+}
+
+// cancelOnClose cancels a request timeout once its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,83 @@
+package elevenlabs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithRequestOptions(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("client-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	// Without options, the client's key is used.
+	if err := client.doJSON(context.Background(), http.MethodGet, "/v1/test", nil, nil); err != nil {
+		t.Fatalf("doJSON() error = %v", err)
+	}
+	if got.Get("xi-api-key") != "client-key" {
+		t.Errorf("xi-api-key = %q, want client-key", got.Get("xi-api-key"))
+	}
+
+	ctx := WithRequestOptions(context.Background(),
+		WithRequestAPIKey("tenant-key"),
+		WithIdempotencyKey("job-1"),
+	)
+	ctx = WithRequestOptions(ctx, WithRequestHeader("X-Trace", "abc"))
+	if err := client.doJSON(ctx, http.MethodPost, "/v1/test", map[string]string{}, nil); err != nil {
+		t.Fatalf("doJSON() error = %v", err)
+	}
+	if got.Get("xi-api-key") != "tenant-key" {
+		t.Errorf("xi-api-key = %q, want tenant-key", got.Get("xi-api-key"))
+	}
+	if got.Get("Idempotency-Key") != "job-1" {
+		t.Errorf("Idempotency-Key = %q, want job-1", got.Get("Idempotency-Key"))
+	}
+	if got.Get("X-Trace") != "abc" {
+		t.Errorf("X-Trace = %q, want abc", got.Get("X-Trace"))
+	}
+	if got.Get("X-ElevenLabs-SDK-Lang") != "go" {
+		t.Error("SDK headers missing")
+	}
+
+	if h := client.connectHeaders(ctx); h.Get("xi-api-key") != "tenant-key" || h.Get("X-Trace") != "abc" {
+		t.Errorf("connectHeaders() = %v", h)
+	}
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx := WithRequestOptions(context.Background(), WithRequestTimeout(20*time.Millisecond))
+	start := time.Now()
+	if err := client.doJSON(ctx, http.MethodGet, "/v1/slow", nil, nil); err == nil {
+		t.Fatal("doJSON() should time out")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("request took %v, want the timeout to apply", elapsed)
+	}
+}
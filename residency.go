@@ -0,0 +1,84 @@
+package elevenlabs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Residency is a region that keeps API data within its borders. Workspaces
+// with data residency have their own API keys and endpoints.
+type Residency string
+
+// Data residency regions.
+const (
+	// ResidencyUS is the isolated United States region.
+	ResidencyUS Residency = "us"
+
+	// ResidencyEU is the European Union region.
+	ResidencyEU Residency = "eu"
+
+	// ResidencyIndia is the India region.
+	ResidencyIndia Residency = "in"
+)
+
+// residencyBaseURLs are the API base URLs of the residency regions.
+var residencyBaseURLs = map[Residency]string{
+	ResidencyUS:    "https://api.us.elevenlabs.io",
+	ResidencyEU:    "https://api.eu.residency.elevenlabs.io",
+	ResidencyIndia: "https://api.in.residency.elevenlabs.io",
+}
+
+// ErrResidencyKeyMismatch is returned by CheckResidency when the API key is
+// not accepted in the client's region.
+var ErrResidencyKeyMismatch = errors.New("elevenlabs: API key is not valid for this region")
+
+// BaseURL returns the API base URL of the region.
+func (r Residency) BaseURL() (string, error) {
+	u, ok := residencyBaseURLs[r]
+	if !ok {
+		return "", &ValidationError{Field: "residency", Message: fmt.Sprintf("unknown region %q", r)}
+	}
+	return u, nil
+}
+
+// WithResidency sends requests, including WebSocket connections, to the
+// endpoint of a data residency region. Use the API key of a workspace in
+// that region; see CheckResidency. It cannot be combined with WithBaseURL.
+func WithResidency(r Residency) Option {
+	return func(o *clientOptions) {
+		o.residency = r
+	}
+}
+
+// applyResidency sets the base URL of the residency region, if one is set.
+func (o *clientOptions) applyResidency() error {
+	if o.residency == "" {
+		return nil
+	}
+	baseURL, err := o.residency.BaseURL()
+	if err != nil {
+		return err
+	}
+	if o.baseURL != DefaultBaseURL && o.baseURL != baseURL {
+		return &ValidationError{Field: "residency", Message: "cannot be combined with a custom base URL"}
+	}
+	o.baseURL = baseURL
+	return nil
+}
+
+// CheckResidency verifies that the API key is accepted by the client's
+// endpoint. Keys are issued per region, so a key from the default region
+// is rejected by a residency endpoint and vice versa. Call it at startup
+// to fail fast on misconfiguration; the error wraps
+// ErrResidencyKeyMismatch and the underlying *APIError.
+func (c *Client) CheckResidency(ctx context.Context) error {
+	if c.apiKey == "" && c.keyPool == nil {
+		return ErrNoAPIKey
+	}
+	_, err := c.Models().List(ctx)
+	if IsUnauthorizedError(err) {
+		return fmt.Errorf("%w (%s): %w", ErrResidencyKeyMismatch, c.baseURL, err)
+	}
+	return err
+}
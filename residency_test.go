@@ -0,0 +1,80 @@
+package elevenlabs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithResidency(t *testing.T) {
+	client, err := NewClient(WithAPIKey("test-key"), WithResidency(ResidencyEU))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.baseURL != "https://api.eu.residency.elevenlabs.io" {
+		t.Errorf("baseURL = %s", client.baseURL)
+	}
+	wsURL, err := client.WebSocketTTS().buildWebSocketURL("voice-1", &WebSocketTTSOptions{})
+	if err != nil {
+		t.Fatalf("buildWebSocketURL() error = %v", err)
+	}
+	if !strings.HasPrefix(wsURL, "wss://api.eu.residency.elevenlabs.io/") {
+		t.Errorf("WebSocket URL = %s", wsURL)
+	}
+
+	// The region's own URL is not a conflict
+	if _, err := NewClient(WithResidency(ResidencyIndia), WithBaseURL("https://api.in.residency.elevenlabs.io")); err != nil {
+		t.Errorf("NewClient() with matching base URL error = %v", err)
+	}
+
+	var valErr *ValidationError
+	if _, err := NewClient(WithResidency(ResidencyUS), WithBaseURL("https://proxy.example.com")); !isValidationError(err, &valErr) {
+		t.Errorf("NewClient() with conflicting base URL error = %v", err)
+	}
+	if _, err := NewClient(WithResidency("mars")); !isValidationError(err, &valErr) {
+		t.Errorf("NewClient() with unknown region error = %v", err)
+	}
+}
+
+func TestCheckResidency(t *testing.T) {
+	var status int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status == http.StatusUnauthorized {
+			_, _ = w.Write([]byte(`{"detail": {"status": "invalid_api_key", "message": "Invalid API key"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	status = http.StatusOK
+	if err := client.CheckResidency(ctx); err != nil {
+		t.Errorf("CheckResidency() error = %v", err)
+	}
+
+	status = http.StatusUnauthorized
+	err = client.CheckResidency(ctx)
+	if !errors.Is(err, ErrResidencyKeyMismatch) || !IsUnauthorizedError(err) {
+		t.Errorf("CheckResidency() error = %v, want ErrResidencyKeyMismatch", err)
+	}
+
+	noKey, _ := NewClient(WithBaseURL(server.URL))
+	noKey.apiKey = ""
+	if err := noKey.CheckResidency(ctx); !errors.Is(err, ErrNoAPIKey) {
+		t.Errorf("CheckResidency() without key error = %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package elevenlabs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultRetentionInterval is how often a RetentionSweeper runs by default.
+const DefaultRetentionInterval = 24 * time.Hour
+
+// RetentionSweeper deletes agent conversations, with their transcripts and
+// recordings, once they are older than a retention period. Use it to
+// enforce a data retention policy, such as for GDPR.
+type RetentionSweeper struct {
+	agents    *AgentsService
+	maxAge    time.Duration
+	agentIDs  []string
+	interval  time.Duration
+	onDeleted func(*ConversationSummary)
+	onError   func(error)
+}
+
+// RetentionOption configures a RetentionSweeper.
+type RetentionOption func(*RetentionSweeper)
+
+// WithRetentionAgents limits the sweeper to the conversations of the given
+// agents. By default, conversations of all agents are deleted.
+func WithRetentionAgents(agentIDs ...string) RetentionOption {
+	return func(s *RetentionSweeper) {
+		s.agentIDs = agentIDs
+	}
+}
+
+// WithRetentionInterval sets how often Run sweeps. Defaults to
+// DefaultRetentionInterval.
+func WithRetentionInterval(d time.Duration) RetentionOption {
+	return func(s *RetentionSweeper) {
+		s.interval = d
+	}
+}
+
+// WithRetentionDeleted calls fn for each deleted conversation, for an
+// audit log of deletions.
+func WithRetentionDeleted(fn func(*ConversationSummary)) RetentionOption {
+	return func(s *RetentionSweeper) {
+		s.onDeleted = fn
+	}
+}
+
+// WithRetentionError calls fn when a sweep started by Run fails. Run
+// continues with the next sweep.
+func WithRetentionError(fn func(error)) RetentionOption {
+	return func(s *RetentionSweeper) {
+		s.onError = fn
+	}
+}
+
+// NewRetentionSweeper returns a sweeper that deletes conversations that
+// started more than maxAge ago.
+func (s *AgentsService) NewRetentionSweeper(maxAge time.Duration, opts ...RetentionOption) (*RetentionSweeper, error) {
+	if maxAge <= 0 {
+		return nil, &ValidationError{Field: "max_age", Message: "must be positive"}
+	}
+	sweeper := &RetentionSweeper{
+		agents:   s,
+		maxAge:   maxAge,
+		interval: DefaultRetentionInterval,
+	}
+	for _, opt := range opts {
+		opt(sweeper)
+	}
+	if sweeper.interval <= 0 {
+		return nil, &ValidationError{Field: "interval", Message: "must be positive"}
+	}
+	return sweeper, nil
+}
+
+// Sweep deletes the conversations that are older than the retention period
+// and returns how many were deleted. Conversations without a start time
+// before the cutoff are never deleted, even if the API lists them. Conversations that fail to delete are
+// skipped and reported in the error, so they are retried on the next sweep.
+func (s *RetentionSweeper) Sweep(ctx context.Context) (int, error) {
+	cutoff := time.Now().Add(-s.maxAge)
+	agentIDs := s.agentIDs
+	if len(agentIDs) == 0 {
+		agentIDs = []string{""}
+	}
+
+	// List everything first, since deleting while paging would shift the
+	// cursor
+	var expired []*ConversationSummary
+	for _, agentID := range agentIDs {
+		opts := &ConversationListOptions{AgentID: agentID, StartedBefore: cutoff, PageSize: 100}
+		for {
+			page, err := s.agents.ListConversations(ctx, opts)
+			if err != nil {
+				return 0, err
+			}
+			// Check the start times too, so a server or proxy that ignores
+			// the filter cannot cause recent conversations to be deleted
+			for _, conv := range page.Conversations {
+				if !conv.StartTime.IsZero() && conv.StartTime.Before(cutoff) {
+					expired = append(expired, conv)
+				}
+			}
+			if !page.HasMore || page.NextCursor == "" {
+				break
+			}
+			opts.Cursor = page.NextCursor
+		}
+	}
+
+	deleted := 0
+	var errs []error
+	for _, conv := range expired {
+		err := s.agents.DeleteConversation(ctx, conv.ConversationID)
+		if ctx.Err() != nil {
+			return deleted, ctx.Err()
+		}
+		if err != nil && !IsNotFoundError(err) {
+			errs = append(errs, fmt.Errorf("deleting conversation %s: %w", conv.ConversationID, err))
+			continue
+		}
+		deleted++
+		if s.onDeleted != nil {
+			s.onDeleted(conv)
+		}
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// Run sweeps now and then at every interval until ctx is done. Failed
+// sweeps are reported to the WithRetentionError function.
+func (s *RetentionSweeper) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		_, err := s.Sweep(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && s.onError != nil {
+			s.onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package elevenlabs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func conversationSummaryJSON(id string, start time.Time) string {
+	return fmt.Sprintf(`{"agent_id": "agent-1", "conversation_id": %q, "status": "done", "call_successful": "success",
+		"start_time_unix_secs": %d, "call_duration_secs": 30, "message_count": 4}`, id, start.Unix())
+}
+
+func TestRetentionSweeper(t *testing.T) {
+	now := time.Now()
+	var mu sync.Mutex
+	var cutoff int64
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/convai/conversations":
+			cutoff, _ = strconv.ParseInt(r.URL.Query().Get("call_start_before_unix"), 10, 64)
+			if r.URL.Query().Get("cursor") == "" {
+				_, _ = fmt.Fprintf(w, `{"conversations": [%s, %s], "has_more": true, "next_cursor": "page-2"}`,
+					conversationSummaryJSON("old-1", now.AddDate(0, 0, -40)),
+					conversationSummaryJSON("old-2", now.AddDate(0, 0, -45)))
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"conversations": [%s], "has_more": false, "next_cursor": null}`,
+				conversationSummaryJSON("gone", now.AddDate(0, 0, -50)))
+		case r.Method == http.MethodDelete:
+			id := strings.TrimPrefix(r.URL.Path, "/v1/convai/conversations/")
+			if id == "gone" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"detail": "not found"}`))
+				return
+			}
+			if id == "old-2" && len(deleted) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"detail": "try again"}`))
+				return
+			}
+			deleted = append(deleted, id)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var audit []string
+	sweeper, err := client.Agents().NewRetentionSweeper(30*24*time.Hour, WithRetentionDeleted(func(c *ConversationSummary) {
+		audit = append(audit, c.ConversationID)
+	}))
+	if err != nil {
+		t.Fatalf("NewRetentionSweeper() error = %v", err)
+	}
+
+	// A failed deletion is reported and retried on the next sweep; already
+	// deleted conversations are not errors
+	n, err := sweeper.Sweep(context.Background())
+	if n != 2 || err == nil || !strings.Contains(err.Error(), "old-2") {
+		t.Errorf("Sweep() = %d, %v; want 2 and an error for old-2", n, err)
+	}
+	if want := now.Add(-30 * 24 * time.Hour).Unix(); cutoff < want-5 || cutoff > want+5 {
+		t.Errorf("call_start_before_unix = %d, want about %d", cutoff, want)
+	}
+	if n, err := sweeper.Sweep(context.Background()); n != 3 || err != nil {
+		t.Errorf("second Sweep() = %d, %v", n, err)
+	}
+	if strings.Join(deleted, ",") != "old-1,old-1,old-2" {
+		t.Errorf("deleted = %v", deleted)
+	}
+	if len(audit) != 5 {
+		t.Errorf("audit = %v", audit)
+	}
+
+	var valErr *ValidationError
+	if _, err := client.Agents().NewRetentionSweeper(0); !isValidationError(err, &valErr) {
+		t.Errorf("NewRetentionSweeper(0) error = %v", err)
+	}
+}
+
+func TestRetentionSweeperIgnoredFilter(t *testing.T) {
+	now := time.Now()
+	var mu sync.Mutex
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			// The start time filter is ignored, as by a misbehaving proxy
+			_, _ = fmt.Fprintf(w, `{"conversations": [%s, %s], "has_more": false}`,
+				conversationSummaryJSON("recent", now.Add(-time.Hour)),
+				conversationSummaryJSON("old", now.AddDate(0, 0, -40)))
+		case http.MethodDelete:
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/v1/convai/conversations/"))
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	sweeper, err := client.Agents().NewRetentionSweeper(30 * 24 * time.Hour)
+	if err != nil {
+		t.Fatalf("NewRetentionSweeper() error = %v", err)
+	}
+	n, err := sweeper.Sweep(context.Background())
+	if err != nil || n != 1 {
+		t.Errorf("Sweep() = %d, %v; want 1", n, err)
+	}
+	if len(deleted) != 1 || deleted[0] != "old" {
+		t.Errorf("deleted = %v, want only the old conversation", deleted)
+	}
+}
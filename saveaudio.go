@@ -0,0 +1,83 @@
+package elevenlabs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// SavedFile describes a file written by SaveAudio.
+type SavedFile struct {
+	// Path is the path of the file.
+	Path string
+
+	// Size is the size of the file in bytes.
+	Size int64
+
+	// SHA256 is the hex-encoded SHA-256 checksum of the contents.
+	SHA256 string
+}
+
+// SaveAudio writes r to path atomically: the audio is written to a
+// temporary file in the same directory, synced to disk, and renamed over
+// path. A crash or a failed download leaves either the previous file or
+// none, never a truncated one.
+//
+//	resp, err := client.TextToSpeech().Generate(ctx, req)
+//	if err != nil {
+//	    return err
+//	}
+//	saved, err := elevenlabs.SaveAudio("out/intro.mp3", resp.Audio)
+//
+// The file is created with mode 0600. The directory must exist.
+func SaveAudio(path string, r io.Reader) (*SavedFile, error) {
+	if path == "" {
+		return nil, &ValidationError{Field: "path", Message: "cannot be empty"}
+	}
+	dir := filepath.Dir(path)
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary file: %w", err)
+	}
+	// Remove the temporary file unless it was renamed
+	renamed := false
+	defer func() {
+		if !renamed {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, h), r)
+	if err != nil {
+		return nil, fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return nil, fmt.Errorf("syncing %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("closing %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("renaming %s: %w", path, err)
+	}
+	renamed = true
+
+	// Sync the directory so the rename survives a crash. Not all platforms
+	// support this, so errors are ignored.
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		d.Close()
+	}
+
+	return &SavedFile{
+		Path:   path,
+		Size:   size,
+		SHA256: hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
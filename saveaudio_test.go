@@ -0,0 +1,64 @@
+package elevenlabs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestSaveAudio(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "intro.mp3")
+
+	saved, err := SaveAudio(path, strings.NewReader("audio data"))
+	if err != nil {
+		t.Fatalf("SaveAudio() error = %v", err)
+	}
+	sum := sha256.Sum256([]byte("audio data"))
+	if saved.Path != path || saved.Size != 10 || saved.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("saved = %+v", saved)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "audio data" {
+		t.Errorf("file = %q", data)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the saved file", len(entries))
+	}
+}
+
+func TestSaveAudioFailedRead(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "intro.mp3")
+	if err := os.WriteFile(path, []byte("previous"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// A download that breaks off keeps the previous file
+	r := io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(errors.New("connection reset")))
+	if _, err := SaveAudio(path, r); err == nil {
+		t.Fatal("SaveAudio() expected error")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "previous" {
+		t.Errorf("file = %q, want previous contents", data)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, temporary file not removed", len(entries))
+	}
+
+	var valErr *ValidationError
+	if _, err := SaveAudio("", strings.NewReader("x")); !isValidationError(err, &valErr) {
+		t.Errorf("SaveAudio(\"\") error = %v", err)
+	}
+	if _, err := SaveAudio(filepath.Join(dir, "missing", "a.mp3"), strings.NewReader("x")); err == nil {
+		t.Error("SaveAudio() into a missing directory: expected error")
+	}
+}
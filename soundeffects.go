@@ -83,15 +83,17 @@ func (s *SoundEffectsService) Generate(ctx context.Context, req *SoundEffectRequ
 
 	resp, err := s.client.apiClient.SoundGeneration(ctx, body, params)
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	// Handle response type
 	switch r := resp.(type) {
 	case *api.SoundGenerationOKHeaders:
 		return &SoundEffectResponse{Audio: r.Response.Data}, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -1,12 +1,15 @@
 package elevenlabs
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"net/url"
+	"path"
+	"strings"
 )
 
 // SpeechToSpeechService handles voice conversion operations.
@@ -22,7 +25,14 @@ type SpeechToSpeechRequest struct {
 	// Audio is the source audio data to convert.
 	Audio io.Reader
 
-	// AudioFilename is the filename for the audio (optional, helps with format detection).
+	// AudioURL is the HTTP or HTTPS URL of the source audio, downloaded by
+	// the SDK since the API only accepts uploads. The API key is not sent
+	// to it. Set one of Audio or AudioURL.
+	AudioURL string
+
+	// AudioFilename is the filename for the audio (optional). The format
+	// is detected from the audio itself, so it is only needed for formats
+	// that cannot be detected.
 	AudioFilename string
 
 	// ModelID is the model to use. Defaults to "eleven_english_sts_v2".
@@ -50,8 +60,14 @@ func (r *SpeechToSpeechRequest) Validate() error {
 	if r.VoiceID == "" {
 		return ErrEmptyVoiceID
 	}
-	if r.Audio == nil {
-		return &APIError{Message: "audio is required"}
+	if (r.Audio == nil) == (r.AudioURL == "") {
+		return &ValidationError{Field: "audio", Message: "exactly one of audio or audio_url must be provided"}
+	}
+	if r.AudioURL != "" {
+		u, err := url.Parse(r.AudioURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return &ValidationError{Field: "audio_url", Message: "must be an HTTP or HTTPS URL"}
+		}
 	}
 	if r.VoiceSettings != nil {
 		if err := r.VoiceSettings.Validate(); err != nil {
@@ -67,100 +83,51 @@ type SpeechToSpeechResponse struct {
 	Audio io.Reader
 }
 
-// Convert converts speech from one voice to another.
+// Convert converts speech from one voice to another. The audio is
+// streamed to the API, so memory use does not grow with its length.
 func (s *SpeechToSpeechService) Convert(ctx context.Context, req *SpeechToSpeechRequest) (*SpeechToSpeechResponse, error) {
+	return s.convert(ctx, req, "")
+}
+
+// ConvertStream converts speech with streaming response.
+func (s *SpeechToSpeechService) ConvertStream(ctx context.Context, req *SpeechToSpeechRequest) (*SpeechToSpeechResponse, error) {
+	return s.convert(ctx, req, "/stream")
+}
+
+// convert uploads req to the conversion endpoint with the suffix. The
+// multipart form is written through a pipe as the request is sent.
+func (s *SpeechToSpeechService) convert(ctx context.Context, req *SpeechToSpeechRequest, suffix string) (*SpeechToSpeechResponse, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	// Build multipart form
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
-
-	// Add audio file
-	audioFilename := req.AudioFilename
-	if audioFilename == "" {
-		audioFilename = "audio.mp3"
-	}
-	audioWriter, err := writer.CreateFormFile("audio", audioFilename)
+	audio, audioFilename, closeAudio, err := s.sourceAudio(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create audio form field: %w", err)
-	}
-	if _, err := io.Copy(audioWriter, req.Audio); err != nil {
-		return nil, fmt.Errorf("failed to write audio: %w", err)
-	}
-
-	// Add model ID
-	modelID := req.ModelID
-	if modelID == "" {
-		modelID = "eleven_english_sts_v2"
-	}
-	if err := writer.WriteField("model_id", modelID); err != nil {
-		return nil, fmt.Errorf("failed to write model_id: %w", err)
-	}
-
-	// Add voice settings if provided
-	if req.VoiceSettings != nil {
-		if err := writer.WriteField("stability", fmt.Sprintf("%.2f", req.VoiceSettings.Stability)); err != nil {
-			return nil, err
-		}
-		if err := writer.WriteField("similarity_boost", fmt.Sprintf("%.2f", req.VoiceSettings.SimilarityBoost)); err != nil {
-			return nil, err
-		}
-		if req.VoiceSettings.Style > 0 {
-			if err := writer.WriteField("style", fmt.Sprintf("%.2f", req.VoiceSettings.Style)); err != nil {
-				return nil, err
-			}
-		}
-		if req.VoiceSettings.UseSpeakerBoost {
-			if err := writer.WriteField("use_speaker_boost", "true"); err != nil {
-				return nil, err
-			}
-		}
-	}
-
-	// Add remove background noise option
-	if req.RemoveBackgroundNoise {
-		if err := writer.WriteField("remove_background_noise", "true"); err != nil {
-			return nil, err
-		}
+		return nil, err
 	}
+	defer closeAudio()
 
-	// Add seed audio if provided
-	if req.SeedAudio != nil {
-		seedFilename := req.SeedAudioFilename
-		if seedFilename == "" {
-			seedFilename = "seed.mp3"
-		}
-		seedWriter, err := writer.CreateFormFile("seed_audio", seedFilename)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create seed_audio form field: %w", err)
-		}
-		if _, err := io.Copy(seedWriter, req.SeedAudio); err != nil {
-			return nil, fmt.Errorf("failed to write seed audio: %w", err)
-		}
-	}
-
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
-	}
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeSpeechToSpeechForm(ctx, writer, req, audio, audioFilename))
+	}()
+	defer pr.Close()
 
 	// Build URL
-	url := fmt.Sprintf("%s/v1/speech-to-speech/%s", s.client.baseURL, req.VoiceID)
+	endpoint := fmt.Sprintf("%s/v1/speech-to-speech/%s%s", s.client.baseURL, req.VoiceID, suffix)
 	if req.OutputFormat != "" {
-		url += "?output_format=" + req.OutputFormat
+		endpoint += "?output_format=" + req.OutputFormat
 	}
 
 	// Make request
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, &buf)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", endpoint, pr)
 	if err != nil {
 		return nil, err
 	}
 
 	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
-	httpReq.Header.Set("xi-api-key", s.client.apiKey)
-
-	resp, err := http.DefaultClient.Do(httpReq)
+	resp, err := s.client.httpClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -168,36 +135,20 @@ func (s *SpeechToSpeechService) Convert(ctx context.Context, req *SpeechToSpeech
 	if resp.StatusCode != http.StatusOK {
 		defer resp.Body.Close()
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(respBody),
-		}
+		apiErr := newAPIError(resp.StatusCode, respBody)
+		apiErr.setResponse(resp)
+		return nil, apiErr
 	}
 
 	return &SpeechToSpeechResponse{Audio: resp.Body}, nil
 }
 
-// ConvertStream converts speech with streaming response.
-func (s *SpeechToSpeechService) ConvertStream(ctx context.Context, req *SpeechToSpeechRequest) (*SpeechToSpeechResponse, error) {
-	if err := req.Validate(); err != nil {
-		return nil, err
-	}
-
-	// Build multipart form
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
-
+// writeSpeechToSpeechForm writes the multipart form of req, with audio as
+// the source audio.
+func writeSpeechToSpeechForm(ctx context.Context, writer *multipart.Writer, req *SpeechToSpeechRequest, audio io.Reader, audioFilename string) error {
 	// Add audio file
-	audioFilename := req.AudioFilename
-	if audioFilename == "" {
-		audioFilename = "audio.mp3"
-	}
-	audioWriter, err := writer.CreateFormFile("audio", audioFilename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create audio form field: %w", err)
-	}
-	if _, err := io.Copy(audioWriter, req.Audio); err != nil {
-		return nil, fmt.Errorf("failed to write audio: %w", err)
+	if err := writeAudioPart(writer, "audio", audioFilename, uploadReader(ctx, audio)); err != nil {
+		return err
 	}
 
 	// Add model ID
@@ -206,25 +157,25 @@ func (s *SpeechToSpeechService) ConvertStream(ctx context.Context, req *SpeechTo
 		modelID = "eleven_english_sts_v2"
 	}
 	if err := writer.WriteField("model_id", modelID); err != nil {
-		return nil, fmt.Errorf("failed to write model_id: %w", err)
+		return fmt.Errorf("failed to write model_id: %w", err)
 	}
 
 	// Add voice settings if provided
 	if req.VoiceSettings != nil {
 		if err := writer.WriteField("stability", fmt.Sprintf("%.2f", req.VoiceSettings.Stability)); err != nil {
-			return nil, err
+			return err
 		}
 		if err := writer.WriteField("similarity_boost", fmt.Sprintf("%.2f", req.VoiceSettings.SimilarityBoost)); err != nil {
-			return nil, err
+			return err
 		}
 		if req.VoiceSettings.Style > 0 {
 			if err := writer.WriteField("style", fmt.Sprintf("%.2f", req.VoiceSettings.Style)); err != nil {
-				return nil, err
+				return err
 			}
 		}
 		if req.VoiceSettings.UseSpeakerBoost {
 			if err := writer.WriteField("use_speaker_boost", "true"); err != nil {
-				return nil, err
+				return err
 			}
 		}
 	}
@@ -232,46 +183,99 @@ func (s *SpeechToSpeechService) ConvertStream(ctx context.Context, req *SpeechTo
 	// Add remove background noise option
 	if req.RemoveBackgroundNoise {
 		if err := writer.WriteField("remove_background_noise", "true"); err != nil {
-			return nil, err
+			return err
+		}
+	}
+
+	// Add seed audio if provided
+	if req.SeedAudio != nil {
+		seedFilename := req.SeedAudioFilename
+		if seedFilename == "" {
+			seedFilename = "seed"
+		}
+		if err := writeAudioPart(writer, "seed_audio", seedFilename, uploadReader(ctx, req.SeedAudio)); err != nil {
+			return err
 		}
 	}
 
 	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+		return fmt.Errorf("failed to close multipart writer: %w", err)
 	}
+	return nil
+}
 
-	// Build URL for streaming endpoint
-	url := fmt.Sprintf("%s/v1/speech-to-speech/%s/stream", s.client.baseURL, req.VoiceID)
-	if req.OutputFormat != "" {
-		url += "?output_format=" + req.OutputFormat
+// sourceAudio returns the audio of req and its filename, downloading it
+// if req.AudioURL is set. The returned function releases the download.
+func (s *SpeechToSpeechService) sourceAudio(ctx context.Context, req *SpeechToSpeechRequest) (io.Reader, string, func(), error) {
+	if req.AudioURL == "" {
+		return req.Audio, req.AudioFilename, func() {}, nil
 	}
 
-	// Make request
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, &buf)
+	// The URL is not an ElevenLabs endpoint, so the authenticated client
+	// is not used
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, req.AudioURL, nil)
 	if err != nil {
-		return nil, err
+		return nil, "", nil, err
+	}
+	resp, err := s.client.fetchClient.Do(httpReq)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to download audio: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, "", nil, fmt.Errorf("failed to download audio: %s returned HTTP %d", req.AudioURL, resp.StatusCode)
 	}
 
-	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
-	httpReq.Header.Set("xi-api-key", s.client.apiKey)
+	filename := req.AudioFilename
+	if filename == "" {
+		if u, err := url.Parse(req.AudioURL); err == nil && path.Ext(u.Path) != "" {
+			filename = path.Base(u.Path)
+		}
+	}
+	return resp.Body, filename, func() { resp.Body.Close() }, nil
+}
 
-	resp, err := http.DefaultClient.Do(httpReq)
+// writeAudioPart writes audio as a file field of a multipart form. The
+// content type is detected from the audio, and so is the extension of a
+// filename without one. The filename defaults to the field name.
+func writeAudioPart(writer *multipart.Writer, field, filename string, audio io.Reader) error {
+	format, detected, audio, err := sniffAudio(audio)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return fmt.Errorf("failed to read %s: %w", field, err)
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		defer resp.Body.Close()
-		respBody, _ := io.ReadAll(resp.Body)
-		return nil, &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(respBody),
+	contentType := "application/octet-stream"
+	if detected {
+		contentType = format.contentType
+	}
+	if filename == "" {
+		filename = field
+	}
+	if path.Ext(filename) == "" {
+		if detected {
+			filename += format.ext
+		} else {
+			filename += ".mp3"
 		}
 	}
 
-	return &SpeechToSpeechResponse{Audio: resp.Body}, nil
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		formQuoteEscaper.Replace(field), formQuoteEscaper.Replace(filename)))
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("failed to create %s form field: %w", field, err)
+	}
+	if _, err := io.Copy(part, audio); err != nil {
+		return fmt.Errorf("failed to write %s: %w", field, err)
+	}
+	return nil
 }
 
+// formQuoteEscaper escapes quoted multipart parameters, as
+// multipart.Writer.CreateFormFile does.
+var formQuoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
 // Simple is a convenience method for basic voice conversion.
 func (s *SpeechToSpeechService) Simple(ctx context.Context, voiceID string, audio io.Reader) (io.Reader, error) {
 	resp, err := s.Convert(ctx, &SpeechToSpeechRequest{
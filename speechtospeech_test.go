@@ -0,0 +1,113 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var testWAV = []byte("RIFF\x24\x00\x00\x00WAVEfmt \x10\x00\x00\x00")
+
+func TestSpeechToSpeechAudioURL(t *testing.T) {
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("xi-api-key") != "" {
+			t.Error("API key sent to the audio URL")
+		}
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(testWAV)
+	}))
+	defer source.Close()
+
+	var filename, contentType string
+	var audio []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("audio")
+		if err != nil {
+			t.Errorf("FormFile() error = %v", err)
+			return
+		}
+		filename = header.Filename
+		contentType = header.Header.Get("Content-Type")
+		audio, _ = io.ReadAll(file)
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write([]byte("converted"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	resp, err := client.SpeechToSpeech().Convert(ctx, &SpeechToSpeechRequest{VoiceID: "voice", AudioURL: source.URL + "/recording"})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	converted, _ := io.ReadAll(resp.Audio)
+	if string(converted) != "converted" {
+		t.Errorf("Audio = %q", converted)
+	}
+	if !bytes.Equal(audio, testWAV) {
+		t.Errorf("uploaded audio = %q", audio)
+	}
+	if filename != "audio.wav" || contentType != "audio/wav" {
+		t.Errorf("filename, content type = %q, %q; want detected WAV", filename, contentType)
+	}
+
+	_, err = client.SpeechToSpeech().Convert(ctx, &SpeechToSpeechRequest{VoiceID: "voice", AudioURL: source.URL + "/missing"})
+	if err == nil || !strings.Contains(err.Error(), "HTTP 404") {
+		t.Errorf("Convert() with missing audio error = %v", err)
+	}
+}
+
+func TestSpeechToSpeechRequestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  SpeechToSpeechRequest
+	}{
+		{"no audio", SpeechToSpeechRequest{VoiceID: "voice"}},
+		{"audio and URL", SpeechToSpeechRequest{VoiceID: "voice", Audio: strings.NewReader("a"), AudioURL: "https://example.com/a.mp3"}},
+		{"file URL", SpeechToSpeechRequest{VoiceID: "voice", AudioURL: "file:///etc/passwd"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var valErr *ValidationError
+			if err := tt.req.Validate(); !isValidationError(err, &valErr) {
+				t.Errorf("Validate() error = %v, want ValidationError", err)
+			}
+		})
+	}
+}
+
+func TestDetectAudioFormat(t *testing.T) {
+	tests := []struct {
+		head []byte
+		ext  string
+	}{
+		{[]byte("ID3\x04\x00"), ".mp3"},
+		{[]byte{0xFF, 0xFB, 0x90, 0x64}, ".mp3"},
+		{testWAV, ".wav"},
+		{[]byte("OggS\x00\x02"), ".ogg"},
+		{[]byte("fLaC\x00\x00"), ".flac"},
+		{[]byte("\x00\x00\x00\x20ftypM4A "), ".m4a"},
+		{[]byte{0x1A, 0x45, 0xDF, 0xA3}, ".webm"},
+		{[]byte{0xFF, 0xF1, 0x50, 0x80}, ".aac"},
+	}
+	for _, tt := range tests {
+		format, ok := detectAudioFormat(tt.head)
+		if !ok || format.ext != tt.ext {
+			t.Errorf("detectAudioFormat(%q) = %v, %v; want %s", tt.head, format, ok, tt.ext)
+		}
+	}
+	if _, ok := detectAudioFormat([]byte("hello")); ok {
+		t.Error("detectAudioFormat(text) = true")
+	}
+}
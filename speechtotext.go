@@ -2,10 +2,25 @@ package elevenlabs
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/agentplexus/go-elevenlabs/internal/api"
 )
 
+// DefaultTranscriptionModel is the model used when
+// TranscriptionRequest.ModelID is empty.
+const DefaultTranscriptionModel = "scribe_v1"
+
+// maxUploadAttempts is how many times a seekable file is uploaded before
+// giving up on connection errors.
+const maxUploadAttempts = 3
+
 // SpeechToTextService handles speech-to-text transcription.
 type SpeechToTextService struct {
 	client *Client
@@ -13,12 +28,25 @@ type SpeechToTextService struct {
 
 // TranscriptionRequest contains options for transcription.
 type TranscriptionRequest struct {
-	// FileURL is the HTTPS URL of the file to transcribe.
-	// Either FileURL or FileContent must be provided.
+	// FileURL is the HTTPS URL of the file to transcribe, such as a
+	// pre-signed S3, Google Cloud Storage, or R2 URL. The file is fetched
+	// by the API, so large recordings need not pass through this client.
+	// Files must be smaller than 2GB. Set one of FileURL, File, or
+	// FileContent.
 	FileURL string
 
+	// File is the audio or video to upload. It is streamed, so files of
+	// any size up to the API limit of 3GB can be sent without holding
+	// them in memory. If File is also an io.Seeker, such as an *os.File,
+	// the upload is restarted from the beginning if the connection fails.
+	File io.Reader
+
+	// Filename is the name of File, used to detect its format.
+	Filename string
+
 	// FileContent is the base64-encoded file content.
-	// Either FileURL or FileContent must be provided.
+	//
+	// Deprecated: Use File, which streams the upload.
 	FileContent string
 
 	// LanguageCode is an ISO-639-1 or ISO-639-3 language code.
@@ -31,7 +59,8 @@ type TranscriptionRequest struct {
 	// NumSpeakers is the expected number of speakers (for diarization).
 	NumSpeakers int
 
-	// TagAudioEvents tags audio events like laughter, applause, etc.
+	// TagAudioEvents tags audio events like laughter, applause, etc. They
+	// are returned in TranscriptionResponse.AudioEvents.
 	TagAudioEvents bool
 
 	// ModelID is the transcription model to use (default: "scribe_v1").
@@ -46,11 +75,73 @@ type TranscriptionResponse struct {
 	// LanguageCode is the detected language.
 	LanguageCode string
 
+	// LanguageProbability is the confidence in LanguageCode, from 0 to 1.
+	LanguageProbability float64
+
 	// Words contains word-level details with timestamps.
 	Words []TranscriptionWord
 
 	// Utterances contains speaker-labeled segments (when diarization is enabled).
 	Utterances []TranscriptionUtterance
+
+	// AudioEvents are the non-speech sounds tagged in the audio, in order
+	// (when TagAudioEvents is enabled). They also appear in Words with
+	// type WordTypeAudioEvent.
+	AudioEvents []AudioEvent
+}
+
+// AudioEventsOfType returns the audio events of one type, such as
+// AudioEventLaughter.
+func (t *TranscriptionResponse) AudioEventsOfType(eventType string) []AudioEvent {
+	var events []AudioEvent
+	for _, e := range t.AudioEvents {
+		if e.Type == eventType {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// Word types of TranscriptionWord.
+const (
+	WordTypeWord       = "word"
+	WordTypeSpacing    = "spacing"
+	WordTypeAudioEvent = "audio_event"
+)
+
+// Common audio event types. The model describes events freely, so other
+// types occur too.
+const (
+	AudioEventLaughter = "laughter"
+	AudioEventApplause = "applause"
+	AudioEventMusic    = "music"
+)
+
+// AudioEvent is a non-speech sound tagged in a transcription.
+type AudioEvent struct {
+	// Type is the event, such as "laughter" or "applause": the tag
+	// lowercased, without its parentheses.
+	Type string
+
+	// Text is the tag as it appears in the transcript, e.g. "(laughter)".
+	Text string
+
+	// Start is the start time in seconds.
+	Start float64
+
+	// End is the end time in seconds.
+	End float64
+
+	// Speaker is the speaker ID (when diarization is enabled).
+	Speaker string
+}
+
+// audioEventType returns the event type of a tag such as "(Laughter)".
+func audioEventType(tag string) string {
+	tag = strings.TrimSpace(tag)
+	tag = strings.TrimPrefix(strings.TrimPrefix(tag, "("), "[")
+	tag = strings.TrimSuffix(strings.TrimSuffix(tag, ")"), "]")
+	return strings.ToLower(strings.TrimSpace(tag))
 }
 
 // TranscriptionWord represents a single word with timing.
@@ -70,7 +161,8 @@ type TranscriptionWord struct {
 	// Speaker is the speaker ID (when diarization is enabled).
 	Speaker string
 
-	// Type is the word type (e.g., "word", "punctuation").
+	// Type is the word type: WordTypeWord, WordTypeSpacing, or
+	// WordTypeAudioEvent.
 	Type string
 }
 
@@ -91,8 +183,11 @@ type TranscriptionUtterance struct {
 
 // Transcribe transcribes audio to text.
 func (s *SpeechToTextService) Transcribe(ctx context.Context, req *TranscriptionRequest) (*TranscriptionResponse, error) {
-	if req.FileURL == "" && req.FileContent == "" {
-		return nil, &ValidationError{Field: "file", Message: "either file_url or file_content must be provided"}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	if req.File != nil {
+		return s.transcribeUpload(ctx, req)
 	}
 
 	body := &api.BodySpeechToTextV1SpeechToTextPostMultipart{}
@@ -121,7 +216,7 @@ func (s *SpeechToTextService) Transcribe(ctx context.Context, req *Transcription
 
 	resp, err := s.client.apiClient.SpeechToText(ctx, body, api.SpeechToTextParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
@@ -130,35 +225,177 @@ func (s *SpeechToTextService) Transcribe(ctx context.Context, req *Transcription
 		if !r.IsSpeechToTextChunkResponseModel() {
 			return nil, &APIError{Message: "unexpected response format"}
 		}
-		chunk := r.SpeechToTextChunkResponseModel
+		return transcriptionFromAPI(&r.SpeechToTextChunkResponseModel), nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
+	default:
+		return nil, unexpectedResponse(resp)
+	}
+}
 
-		result := &TranscriptionResponse{
-			Text:         chunk.Text,
-			LanguageCode: chunk.LanguageCode,
+func (req *TranscriptionRequest) validate() error {
+	sources := 0
+	for _, set := range []bool{req.FileURL != "", req.File != nil, req.FileContent != ""} {
+		if set {
+			sources++
 		}
+	}
+	if sources != 1 {
+		return &ValidationError{Field: "file", Message: "exactly one of file_url, file, or file_content must be provided"}
+	}
+	if req.FileURL != "" && !strings.HasPrefix(req.FileURL, "https://") {
+		return &ValidationError{Field: "file_url", Message: "must be an HTTPS URL"}
+	}
+	return nil
+}
 
-		// Convert words
-		for _, w := range chunk.Words {
-			word := TranscriptionWord{
-				Text: w.Text,
-				Type: string(w.Type),
-			}
-			if w.Start.Set && !w.Start.Null {
-				word.Start = w.Start.Value
-			}
-			if w.End.Set && !w.End.Null {
-				word.End = w.End.Value
-			}
-			if w.SpeakerID.Set && !w.SpeakerID.Null {
-				word.Speaker = w.SpeakerID.Value
-			}
-			result.Words = append(result.Words, word)
+// transcribeUpload streams req.File to the API. The generated client
+// sends the file as a string field, so the multipart body is written
+// directly.
+func (s *SpeechToTextService) transcribeUpload(ctx context.Context, req *TranscriptionRequest) (*TranscriptionResponse, error) {
+	seeker, _ := req.File.(io.Seeker)
+	var start int64
+	if seeker != nil {
+		var err error
+		if start, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			seeker = nil
 		}
+	}
 
-		return result, nil
-	default:
-		return nil, &APIError{Message: "unexpected response type"}
+	for attempt := 1; ; attempt++ {
+		resp, err := s.upload(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		var apiErr *APIError
+		if seeker == nil || attempt == maxUploadAttempts || ctx.Err() != nil || errors.As(err, &apiErr) {
+			return nil, err
+		}
+		if _, serr := seeker.Seek(start, io.SeekStart); serr != nil {
+			return nil, errors.Join(err, fmt.Errorf("rewinding file: %w", serr))
+		}
+	}
+}
+
+func (s *SpeechToTextService) upload(ctx context.Context, req *TranscriptionRequest) (*TranscriptionResponse, error) {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pw.CloseWithError(writeTranscriptionForm(ctx, writer, req))
+	}()
+	defer func() {
+		pr.Close()
+		// Wait for the writer to stop reading before a retry rewinds the
+		// file. Other readers may block indefinitely, so are not waited for.
+		if _, ok := req.File.(io.Seeker); ok {
+			<-done
+		}
+	}()
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", s.client.baseURL+"/v1/speech-to-text", pr)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := s.client.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := newAPIError(resp.StatusCode, body)
+		apiErr.setResponse(resp)
+		return nil, apiErr
+	}
+
+	var chunk api.SpeechToTextChunkResponseModel
+	if err := chunk.UnmarshalJSON(body); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return transcriptionFromAPI(&chunk), nil
+}
+
+func writeTranscriptionForm(ctx context.Context, writer *multipart.Writer, req *TranscriptionRequest) error {
+	modelID := req.ModelID
+	if modelID == "" {
+		modelID = DefaultTranscriptionModel
+	}
+	fields := [][2]string{{"model_id", modelID}}
+	if req.LanguageCode != "" {
+		fields = append(fields, [2]string{"language_code", req.LanguageCode})
+	}
+	if req.Diarize {
+		fields = append(fields, [2]string{"diarize", "true"})
+	}
+	if req.NumSpeakers > 0 {
+		fields = append(fields, [2]string{"num_speakers", strconv.Itoa(req.NumSpeakers)})
+	}
+	if req.TagAudioEvents {
+		fields = append(fields, [2]string{"tag_audio_events", "true"})
+	}
+	for _, f := range fields {
+		if err := writer.WriteField(f[0], f[1]); err != nil {
+			return err
+		}
+	}
+
+	filename := req.Filename
+	if filename == "" {
+		filename = "audio"
+	}
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, uploadReader(ctx, req.File)); err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+	return writer.Close()
+}
+
+func transcriptionFromAPI(chunk *api.SpeechToTextChunkResponseModel) *TranscriptionResponse {
+	result := &TranscriptionResponse{
+		Text:                chunk.Text,
+		LanguageCode:        chunk.LanguageCode,
+		LanguageProbability: chunk.LanguageProbability,
+	}
+
+	// Convert words
+	for _, w := range chunk.Words {
+		word := TranscriptionWord{
+			Text: w.Text,
+			Type: string(w.Type),
+		}
+		if w.Start.Set && !w.Start.Null {
+			word.Start = w.Start.Value
+		}
+		if w.End.Set && !w.End.Null {
+			word.End = w.End.Value
+		}
+		if w.SpeakerID.Set && !w.SpeakerID.Null {
+			word.Speaker = w.SpeakerID.Value
+		}
+		result.Words = append(result.Words, word)
+
+		if word.Type == WordTypeAudioEvent {
+			result.AudioEvents = append(result.AudioEvents, AudioEvent{
+				Type:    audioEventType(word.Text),
+				Text:    word.Text,
+				Start:   word.Start,
+				End:     word.End,
+				Speaker: word.Speaker,
+			})
+		}
 	}
+	return result
 }
 
 // TranscribeURL transcribes audio from a URL.
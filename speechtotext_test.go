@@ -1,7 +1,13 @@
 package elevenlabs
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -65,3 +71,185 @@ func isValidationError(err error, valErr **ValidationError) bool {
 	}
 	return ok
 }
+
+const testTranscriptionJSON = `{"language_code": "en", "language_probability": 0.98, "text": "Hello world",
+	"words": [{"text": "Hello", "start": 0.0, "end": 0.5, "type": "word", "logprob": -0.1}, {"text": "world", "start": 0.6, "end": 1.0, "type": "word", "logprob": -0.2}]}`
+
+func TestTranscribeFileUpload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/speech-to-text" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.ContentLength != -1 {
+			t.Errorf("ContentLength = %d, want a streamed body", r.ContentLength)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm() error = %v", err)
+		}
+		if got := r.FormValue("model_id"); got != DefaultTranscriptionModel {
+			t.Errorf("model_id = %q", got)
+		}
+		if r.FormValue("diarize") != "true" || r.FormValue("num_speakers") != "2" || r.FormValue("language_code") != "en" {
+			t.Errorf("form = %v", r.MultipartForm.Value)
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("FormFile() error = %v", err)
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		if header.Filename != "meeting.mp3" || len(data) != 256<<10 {
+			t.Errorf("file = %s, %d bytes", header.Filename, len(data))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(testTranscriptionJSON))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	// A plain reader cannot be rewound, so it is sent once without buffering
+	audio := io.LimitReader(strings.NewReader(strings.Repeat("a", 256<<10)), 256<<10)
+	resp, err := client.SpeechToText().Transcribe(context.Background(), &TranscriptionRequest{
+		File:         audio,
+		Filename:     "meeting.mp3",
+		LanguageCode: "en",
+		Diarize:      true,
+		NumSpeakers:  2,
+	})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if resp.Text != "Hello world" || len(resp.Words) != 2 || resp.Words[1].End != 1.0 {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestTranscribeFileUploadRetry(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		n := attempts
+		mu.Unlock()
+		if n == 1 {
+			// Drop the connection partway through the upload
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatalf("Hijack() error = %v", err)
+			}
+			conn.Close()
+			return
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("FormFile() error = %v", err)
+		}
+		defer file.Close()
+		if data, _ := io.ReadAll(file); string(data) != "0123456789" {
+			t.Errorf("retried file = %q, want the whole file", data)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(testTranscriptionJSON))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.SpeechToText().Transcribe(context.Background(), &TranscriptionRequest{
+		File:     bytes.NewReader([]byte("0123456789")),
+		Filename: "short.wav",
+	})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+}
+
+func TestTranscriptionRequestSources(t *testing.T) {
+	client, _ := NewClient()
+	ctx := context.Background()
+	var valErr *ValidationError
+
+	tests := []struct {
+		name string
+		req  *TranscriptionRequest
+	}{
+		{"http url", &TranscriptionRequest{FileURL: "http://example.com/a.mp3"}},
+		{"two sources", &TranscriptionRequest{FileURL: "https://example.com/a.mp3", File: strings.NewReader("a")}},
+	}
+	for _, tt := range tests {
+		if _, err := client.SpeechToText().Transcribe(ctx, tt.req); !isValidationError(err, &valErr) {
+			t.Errorf("%s: expected ValidationError, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestTranscribeAudioEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm() error = %v", err)
+		}
+		if r.FormValue("tag_audio_events") != "true" {
+			t.Errorf("tag_audio_events = %q", r.FormValue("tag_audio_events"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"language_code": "en", "language_probability": 0.98, "text": "Welcome (applause) thanks (Laughter)",
+			"words": [
+				{"text": "Welcome", "start": 0.0, "end": 0.5, "type": "word", "logprob": 0},
+				{"text": " ", "start": 0.5, "end": 0.6, "type": "spacing", "logprob": 0},
+				{"text": "(applause)", "start": 0.6, "end": 3.2, "type": "audio_event", "speaker_id": "speaker_0", "logprob": 0},
+				{"text": " ", "start": 3.2, "end": 3.3, "type": "spacing", "logprob": 0},
+				{"text": "thanks", "start": 3.3, "end": 3.7, "type": "word", "logprob": 0},
+				{"text": " ", "start": 3.7, "end": 3.8, "type": "spacing", "logprob": 0},
+				{"text": "(Laughter)", "start": 3.8, "end": 5.0, "type": "audio_event", "logprob": 0}]}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	resp, err := client.SpeechToText().Transcribe(context.Background(), &TranscriptionRequest{
+		File:           strings.NewReader("audio"),
+		Filename:       "show.mp3",
+		TagAudioEvents: true,
+	})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if len(resp.AudioEvents) != 2 {
+		t.Fatalf("AudioEvents = %+v", resp.AudioEvents)
+	}
+	want := AudioEvent{Type: AudioEventApplause, Text: "(applause)", Start: 0.6, End: 3.2, Speaker: "speaker_0"}
+	if resp.AudioEvents[0] != want {
+		t.Errorf("AudioEvents[0] = %+v, want %+v", resp.AudioEvents[0], want)
+	}
+	if got := resp.AudioEventsOfType(AudioEventLaughter); len(got) != 1 || got[0].Start != 3.8 {
+		t.Errorf("AudioEventsOfType(laughter) = %+v", got)
+	}
+	if resp.Words[2].Type != WordTypeAudioEvent {
+		t.Errorf("Words[2].Type = %q", resp.Words[2].Type)
+	}
+}
+
+func TestAudioEventType(t *testing.T) {
+	tests := map[string]string{
+		"(laughter)":         "laughter",
+		"(Applause)":         "applause",
+		" [music] ":          "music",
+		"(footsteps)":        "footsteps",
+		"(background noise)": "background noise",
+	}
+	for tag, want := range tests {
+		if got := audioEventType(tag); got != want {
+			t.Errorf("audioEventType(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
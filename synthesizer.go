@@ -0,0 +1,107 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Synthesizer generates speech from text. TextToSpeechService implements
+// it; implement it for other TTS providers to use them as a fallback with
+// FallbackSynthesizer.
+type Synthesizer interface {
+	Generate(ctx context.Context, req *TTSRequest) (*TTSResponse, error)
+}
+
+// SynthesizerFunc adapts a function to the Synthesizer interface.
+type SynthesizerFunc func(ctx context.Context, req *TTSRequest) (*TTSResponse, error)
+
+// Generate implements Synthesizer.
+func (f SynthesizerFunc) Generate(ctx context.Context, req *TTSRequest) (*TTSResponse, error) {
+	return f(ctx, req)
+}
+
+// FallbackSynthesizer generates speech with Primary and, if that fails,
+// with Fallback, so prompts that must play, such as IVR menus, still
+// render during an outage or when the quota runs out:
+//
+//	tts := &elevenlabs.FallbackSynthesizer{
+//	    Primary:  client.TextToSpeech(),
+//	    Fallback: elevenlabs.SynthesizerFunc(pollySynthesize),
+//	}
+//	resp, err := tts.Generate(ctx, req)
+//
+// The primary's audio is read into memory before Generate returns, so a
+// stream that breaks off also falls back. Fallback receives the same
+// request and is responsible for mapping VoiceID and OutputFormat to its
+// own voices and formats.
+type FallbackSynthesizer struct {
+	// Primary is the preferred synthesizer, usually client.TextToSpeech().
+	Primary Synthesizer
+
+	// Fallback is used when Primary fails.
+	Fallback Synthesizer
+
+	// ShouldFallback reports whether an error from Primary should be
+	// retried with Fallback. If nil, every error falls back unless ctx is
+	// done.
+	ShouldFallback func(err error) bool
+
+	// OnFallback is called with the primary's error before Fallback is
+	// tried, for logging and metrics.
+	OnFallback func(req *TTSRequest, err error)
+}
+
+// Generate implements Synthesizer. If both synthesizers fail, the error
+// wraps both errors.
+func (s *FallbackSynthesizer) Generate(ctx context.Context, req *TTSRequest) (*TTSResponse, error) {
+	if s.Primary == nil || s.Fallback == nil {
+		return nil, &ValidationError{Field: "synthesizer", Message: "Primary and Fallback are required"}
+	}
+
+	resp, err := s.generatePrimary(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+	if s.ShouldFallback != nil {
+		if !s.ShouldFallback(err) {
+			return nil, err
+		}
+	} else if ctx.Err() != nil {
+		return nil, err
+	}
+	if s.OnFallback != nil {
+		s.OnFallback(req, err)
+	}
+
+	resp, fallbackErr := s.Fallback.Generate(ctx, req)
+	if fallbackErr != nil {
+		return nil, errors.Join(
+			fmt.Errorf("primary synthesizer: %w", err),
+			fmt.Errorf("fallback synthesizer: %w", fallbackErr),
+		)
+	}
+	return resp, nil
+}
+
+// generatePrimary generates speech with Primary and buffers the audio.
+func (s *FallbackSynthesizer) generatePrimary(ctx context.Context, req *TTSRequest) (*TTSResponse, error) {
+	resp, err := s.Primary.Generate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Audio == nil {
+		return resp, nil
+	}
+	data, err := io.ReadAll(resp.Audio)
+	if c, ok := resp.Audio.(io.Closer); ok {
+		c.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading audio: %w", err)
+	}
+	resp.Audio = bytes.NewReader(data)
+	return resp, nil
+}
@@ -0,0 +1,130 @@
+package elevenlabs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Ensure TextToSpeechService can be used as a Synthesizer.
+var _ Synthesizer = (*TextToSpeechService)(nil)
+
+// brokenReader fails after returning part of the audio.
+type brokenReader struct{ read bool }
+
+func (r *brokenReader) Read(p []byte) (int, error) {
+	if r.read {
+		return 0, errors.New("connection reset")
+	}
+	r.read = true
+	return copy(p, "partial"), nil
+}
+
+func staticSynthesizer(audio string) SynthesizerFunc {
+	return func(ctx context.Context, req *TTSRequest) (*TTSResponse, error) {
+		return &TTSResponse{Audio: strings.NewReader(audio + ":" + req.Text), ContentType: "audio/wav"}, nil
+	}
+}
+
+func failingSynthesizer(err error) SynthesizerFunc {
+	return func(ctx context.Context, req *TTSRequest) (*TTSResponse, error) {
+		return nil, err
+	}
+}
+
+func TestFallbackSynthesizer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"detail": {"status": "service_unavailable", "message": "down"}}`))
+	}))
+	defer server.Close()
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var fallbacks []error
+	tts := &FallbackSynthesizer{
+		Primary:  client.TextToSpeech(),
+		Fallback: staticSynthesizer("backup"),
+		OnFallback: func(req *TTSRequest, err error) {
+			fallbacks = append(fallbacks, err)
+		},
+	}
+	resp, err := tts.Generate(context.Background(), &TTSRequest{VoiceID: "voice-1", Text: "Press one"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	audio, _ := io.ReadAll(resp.Audio)
+	if string(audio) != "backup:Press one" {
+		t.Errorf("audio = %q", audio)
+	}
+	if len(fallbacks) != 1 || ParseAPIError(fallbacks[0]) == nil {
+		t.Errorf("OnFallback errors = %v", fallbacks)
+	}
+}
+
+func TestFallbackSynthesizerPrimary(t *testing.T) {
+	tts := &FallbackSynthesizer{
+		Primary:  staticSynthesizer("primary"),
+		Fallback: failingSynthesizer(errors.New("should not be called")),
+	}
+	resp, err := tts.Generate(context.Background(), &TTSRequest{Text: "hi"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if audio, _ := io.ReadAll(resp.Audio); string(audio) != "primary:hi" {
+		t.Errorf("audio = %q", audio)
+	}
+}
+
+func TestFallbackSynthesizerBrokenStream(t *testing.T) {
+	tts := &FallbackSynthesizer{
+		Primary: SynthesizerFunc(func(ctx context.Context, req *TTSRequest) (*TTSResponse, error) {
+			return &TTSResponse{Audio: &brokenReader{}}, nil
+		}),
+		Fallback: staticSynthesizer("backup"),
+	}
+	resp, err := tts.Generate(context.Background(), &TTSRequest{Text: "hi"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if audio, _ := io.ReadAll(resp.Audio); string(audio) != "backup:hi" {
+		t.Errorf("audio = %q", audio)
+	}
+}
+
+func TestFallbackSynthesizerErrors(t *testing.T) {
+	primaryErr := errors.New("primary down")
+	backupErr := errors.New("backup down")
+
+	tts := &FallbackSynthesizer{Primary: failingSynthesizer(primaryErr), Fallback: failingSynthesizer(backupErr)}
+	_, err := tts.Generate(context.Background(), &TTSRequest{Text: "hi"})
+	if !errors.Is(err, primaryErr) || !errors.Is(err, backupErr) {
+		t.Errorf("Generate() error = %v, want both errors", err)
+	}
+
+	// ShouldFallback can keep errors from falling back
+	tts.ShouldFallback = func(err error) bool { return !errors.Is(err, primaryErr) }
+	if _, err := tts.Generate(context.Background(), &TTSRequest{Text: "hi"}); err != primaryErr {
+		t.Errorf("Generate() error = %v, want primary error", err)
+	}
+
+	// A canceled request does not fall back by default
+	tts.ShouldFallback = nil
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := tts.Generate(ctx, &TTSRequest{Text: "hi"}); err != primaryErr {
+		t.Errorf("Generate() error = %v, want primary error", err)
+	}
+
+	var valErr *ValidationError
+	if _, err := (&FallbackSynthesizer{}).Generate(context.Background(), &TTSRequest{}); !isValidationError(err, &valErr) {
+		t.Errorf("Generate() without synthesizers: error = %v", err)
+	}
+}
@@ -0,0 +1,390 @@
+package elevenlabs
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// NormalizeRule rewrites text so it is read aloud as intended, for example
+// "$5" as "five dollars". lang is the ISO 639-1 language code of the text,
+// or "" if unknown.
+type NormalizeRule interface {
+	Apply(text, lang string) string
+}
+
+// NormalizeRuleFunc adapts a function to a NormalizeRule.
+type NormalizeRuleFunc func(text, lang string) string
+
+// Apply implements NormalizeRule.
+func (f NormalizeRuleFunc) Apply(text, lang string) string {
+	return f(text, lang)
+}
+
+// ReplaceRule returns a rule that replaces matches of pattern with
+// replacement, which may refer to submatches as in regexp.ReplaceAllString.
+// Use it for custom abbreviations, product names, and jargon:
+//
+//	rule := elevenlabs.ReplaceRule(regexp.MustCompile(`\bSKU-(\d+)`), "S K U $1")
+func ReplaceRule(pattern *regexp.Regexp, replacement string) NormalizeRule {
+	return NormalizeRuleFunc(func(text, _ string) string {
+		return pattern.ReplaceAllString(text, replacement)
+	})
+}
+
+// Built-in normalization rules. They read English and leave text in other
+// languages unchanged, since the API normalizes those itself.
+var (
+	// NormalizeEmails reads email addresses ("ann@example.com" as
+	// "ann at example dot com").
+	NormalizeEmails NormalizeRule = englishRule(normalizeEmails)
+
+	// NormalizeURLs reads web addresses without the scheme
+	// ("https://example.com/docs" as "example dot com slash docs").
+	NormalizeURLs NormalizeRule = englishRule(normalizeURLs)
+
+	// NormalizeCurrency reads amounts in dollars, euros, and pounds
+	// ("$4.50" as "four dollars and fifty cents").
+	NormalizeCurrency NormalizeRule = englishRule(normalizeCurrency)
+
+	// NormalizeDates reads ISO (2024-03-15) and US (3/15/2024) dates
+	// ("March fifteenth, twenty twenty-four").
+	NormalizeDates NormalizeRule = englishRule(normalizeDates)
+
+	// NormalizeAbbreviations expands common abbreviations ("Dr." as
+	// "Doctor", "e.g." as "for example").
+	NormalizeAbbreviations NormalizeRule = englishRule(normalizeAbbreviations)
+
+	// NormalizeNumbers reads numbers, ordinals, and percentages as words
+	// ("1,250" as "one thousand two hundred fifty", "3rd" as "third").
+	NormalizeNumbers NormalizeRule = englishRule(normalizeNumbers)
+)
+
+// DefaultNormalizeRules returns the built-in rules in the order they must
+// run: addresses, currency, and dates are read before the numbers in them.
+func DefaultNormalizeRules() []NormalizeRule {
+	return []NormalizeRule{
+		NormalizeEmails,
+		NormalizeURLs,
+		NormalizeCurrency,
+		NormalizeDates,
+		NormalizeAbbreviations,
+		NormalizeNumbers,
+	}
+}
+
+// Normalize applies rules to text in order. If rules is nil,
+// DefaultNormalizeRules is used. To add custom rules to the defaults,
+// put them first so they see the original text:
+//
+//	rules := append([]elevenlabs.NormalizeRule{myRule}, elevenlabs.DefaultNormalizeRules()...)
+//	text = elevenlabs.Normalize(text, "en", rules)
+func Normalize(text, lang string, rules []NormalizeRule) string {
+	if rules == nil {
+		rules = DefaultNormalizeRules()
+	}
+	for _, rule := range rules {
+		text = rule.Apply(text, lang)
+	}
+	return text
+}
+
+// englishRule applies fn only to English or unlabeled text.
+func englishRule(fn func(string) string) NormalizeRule {
+	return NormalizeRuleFunc(func(text, lang string) string {
+		if lang != "" && lang != "en" && !strings.HasPrefix(lang, "en-") && !strings.HasPrefix(lang, "en_") {
+			return text
+		}
+		return fn(text)
+	})
+}
+
+var (
+	emailPattern    = regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`)
+	urlPattern      = regexp.MustCompile(`\b(?:https?://|www\.)[^\s<>"]*[^\s<>".,;:!?)]`)
+	currencyPattern = regexp.MustCompile(`([$€£])(\d{1,3}(?:,\d{3})+|\d+)(?:\.(\d{1,2}))?(?:\s?(million|billion|thousand))?\b`)
+	isoDatePattern  = regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`)
+	usDatePattern   = regexp.MustCompile(`\b(\d{1,2})/(\d{1,2})/(\d{4})\b`)
+	ordinalPattern  = regexp.MustCompile(`\b(\d+)(?:st|nd|rd|th)\b`)
+	percentPattern  = regexp.MustCompile(`(-?\d+(?:\.\d+)?)\s?%`)
+	numberPattern   = regexp.MustCompile(`(^|[^\w.])(-?)(\d{1,3}(?:,\d{3})+|\d+)(?:\.(\d+))?\b`)
+)
+
+var abbreviations = []struct {
+	pattern *regexp.Regexp
+	words   string
+}{
+	{regexp.MustCompile(`\bDr\.`), "Doctor"},
+	{regexp.MustCompile(`\bMr\.`), "Mister"},
+	{regexp.MustCompile(`\bMrs\.`), "Missus"},
+	{regexp.MustCompile(`\bMs\.`), "Miz"},
+	{regexp.MustCompile(`\bProf\.`), "Professor"},
+	{regexp.MustCompile(`\bJr\.`), "Junior"},
+	{regexp.MustCompile(`\bSr\.`), "Senior"},
+	{regexp.MustCompile(`\bvs\.`), "versus"},
+	{regexp.MustCompile(`\betc\.`), "et cetera"},
+	{regexp.MustCompile(`\be\.g\.`), "for example"},
+	{regexp.MustCompile(`\bi\.e\.`), "that is"},
+	{regexp.MustCompile(`\bapprox\.`), "approximately"},
+}
+
+func normalizeEmails(text string) string {
+	return emailPattern.ReplaceAllStringFunc(text, func(email string) string {
+		user, domain, _ := strings.Cut(email, "@")
+		return spellAddress(user) + " at " + spellAddress(domain)
+	})
+}
+
+func normalizeURLs(text string) string {
+	return urlPattern.ReplaceAllStringFunc(text, func(url string) string {
+		url = strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+		url = strings.TrimPrefix(url, "www.")
+		return spellAddress(strings.TrimSuffix(url, "/"))
+	})
+}
+
+// addressReplacer reads the punctuation in email and web addresses.
+var addressReplacer = strings.NewReplacer(
+	".", " dot ",
+	"/", " slash ",
+	"-", " dash ",
+	"_", " underscore ",
+	"?", " question mark ",
+	"=", " equals ",
+	"&", " and ",
+	"#", " hash ",
+)
+
+// spellAddress reads an email or web address.
+func spellAddress(s string) string {
+	return strings.Join(strings.Fields(addressReplacer.Replace(s)), " ")
+}
+
+var currencyNames = map[string][2]string{
+	"$": {"dollar", "cent"},
+	"€": {"euro", "cent"},
+	"£": {"pound", "penny"},
+}
+
+func normalizeCurrency(text string) string {
+	return currencyPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := currencyPattern.FindStringSubmatch(match)
+		names := currencyNames[m[1]]
+		whole, err := strconv.ParseInt(strings.ReplaceAll(m[2], ",", ""), 10, 64)
+		if err != nil {
+			return match
+		}
+
+		if m[4] != "" {
+			// "$2.5 million" is "two point five million dollars"
+			amount := numberToWords(whole)
+			if m[3] != "" {
+				amount += " point " + digitsToWords(m[3])
+			}
+			return amount + " " + m[4] + " " + names[0] + "s"
+		}
+
+		words := numberToWords(whole) + " " + plural(names[0], whole)
+		cents := m[3]
+		if len(cents) == 1 {
+			cents += "0"
+		}
+		if n, _ := strconv.ParseInt(cents, 10, 64); n > 0 {
+			minor := plural(names[1], n)
+			if names[1] == "penny" && n != 1 {
+				minor = "pence"
+			}
+			minor = numberToWords(n) + " " + minor
+			if whole == 0 {
+				return minor
+			}
+			words += " and " + minor
+		}
+		return words
+	})
+}
+
+func plural(word string, n int64) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
+
+var monthNames = []string{"", "January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December"}
+
+func normalizeDates(text string) string {
+	date := func(year, month, day string) (string, bool) {
+		y, _ := strconv.ParseInt(year, 10, 64)
+		m, _ := strconv.Atoi(month)
+		d, _ := strconv.ParseInt(day, 10, 64)
+		if m < 1 || m > 12 || d < 1 || d > 31 {
+			return "", false
+		}
+		return monthNames[m] + " " + ordinalToWords(d) + ", " + yearToWords(y), true
+	}
+	text = isoDatePattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := isoDatePattern.FindStringSubmatch(match)
+		if s, ok := date(m[1], m[2], m[3]); ok {
+			return s
+		}
+		return match
+	})
+	return usDatePattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := usDatePattern.FindStringSubmatch(match)
+		if s, ok := date(m[3], m[1], m[2]); ok {
+			return s
+		}
+		return match
+	})
+}
+
+func normalizeAbbreviations(text string) string {
+	for _, a := range abbreviations {
+		text = a.pattern.ReplaceAllString(text, a.words)
+	}
+	return text
+}
+
+func normalizeNumbers(text string) string {
+	text = ordinalPattern.ReplaceAllStringFunc(text, func(match string) string {
+		n, err := strconv.ParseInt(ordinalPattern.FindStringSubmatch(match)[1], 10, 64)
+		if err != nil {
+			return match
+		}
+		return ordinalToWords(n)
+	})
+	text = percentPattern.ReplaceAllStringFunc(text, func(match string) string {
+		return decimalToWords(percentPattern.FindStringSubmatch(match)[1]) + " percent"
+	})
+	return numberPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := numberPattern.FindStringSubmatch(match)
+		number := m[2] + strings.ReplaceAll(m[3], ",", "")
+		if m[4] != "" {
+			number += "." + m[4]
+		}
+		return m[1] + decimalToWords(number)
+	})
+}
+
+var (
+	smallNumbers = []string{"zero", "one", "two", "three", "four", "five", "six",
+		"seven", "eight", "nine", "ten", "eleven", "twelve", "thirteen", "fourteen",
+		"fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	tens   = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	scales = []string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion"}
+)
+
+// numberToWords reads an integer in English ("one hundred twenty-three").
+func numberToWords(n int64) string {
+	if n < 0 {
+		// -(n+1) cannot overflow, unlike -n for math.MinInt64
+		return "minus " + uintToWords(uint64(-(n+1))+1)
+	}
+	return uintToWords(uint64(n))
+}
+
+// uintToWords reads a non-negative integer in English.
+func uintToWords(n uint64) string {
+	if n < 20 {
+		return smallNumbers[n]
+	}
+
+	var groups []string
+	for scale := 0; n > 0; scale++ {
+		if group := n % 1000; group > 0 {
+			words := hundredsToWords(int64(group))
+			if scales[scale] != "" {
+				words += " " + scales[scale]
+			}
+			groups = append([]string{words}, groups...)
+		}
+		n /= 1000
+	}
+	return strings.Join(groups, " ")
+}
+
+// hundredsToWords reads 1 to 999.
+func hundredsToWords(n int64) string {
+	var parts []string
+	if n >= 100 {
+		parts = append(parts, smallNumbers[n/100]+" hundred")
+		n %= 100
+	}
+	switch {
+	case n == 0:
+	case n < 20:
+		parts = append(parts, smallNumbers[n])
+	case n%10 == 0:
+		parts = append(parts, tens[n/10])
+	default:
+		parts = append(parts, tens[n/10]+"-"+smallNumbers[n%10])
+	}
+	return strings.Join(parts, " ")
+}
+
+var irregularOrdinals = map[string]string{
+	"one": "first", "two": "second", "three": "third", "five": "fifth",
+	"eight": "eighth", "nine": "ninth", "twelve": "twelfth",
+}
+
+// ordinalToWords reads an ordinal ("twenty-first").
+func ordinalToWords(n int64) string {
+	words := numberToWords(n)
+	cut := strings.LastIndexAny(words, " -") + 1
+	head, last := words[:cut], words[cut:]
+	switch {
+	case irregularOrdinals[last] != "":
+		last = irregularOrdinals[last]
+	case strings.HasSuffix(last, "y"):
+		last = strings.TrimSuffix(last, "y") + "ieth"
+	default:
+		last += "th"
+	}
+	return head + last
+}
+
+// yearToWords reads a year the way it is spoken ("nineteen ninety-nine",
+// "two thousand five", "twenty twenty-four").
+func yearToWords(y int64) string {
+	if y < 1000 || y >= 10000 || (y >= 2000 && y < 2010) || y%1000 == 0 {
+		return numberToWords(y)
+	}
+	high, low := y/100, y%100
+	switch {
+	case low == 0:
+		return numberToWords(high) + " hundred"
+	case low < 10:
+		return numberToWords(high) + " oh " + numberToWords(low)
+	default:
+		return numberToWords(high) + " " + numberToWords(low)
+	}
+}
+
+// decimalToWords reads a decimal number string ("3.14" as "three point
+// one four"). Numbers too large to read are returned unchanged.
+func decimalToWords(s string) string {
+	whole, frac, _ := strings.Cut(s, ".")
+	n, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return s
+	}
+	words := numberToWords(n)
+	if whole == "-0" {
+		words = "minus zero"
+	}
+	if frac != "" {
+		words += " point " + digitsToWords(frac)
+	}
+	return words
+}
+
+// digitsToWords reads each digit ("14" as "one four").
+func digitsToWords(digits string) string {
+	words := make([]string, 0, len(digits))
+	for _, d := range digits {
+		words = append(words, smallNumbers[d-'0'])
+	}
+	return strings.Join(words, " ")
+}
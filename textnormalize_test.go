@@ -0,0 +1,126 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"I have 3 cats.", "I have three cats."},
+		{"Population: 1,250,000", "Population: one million two hundred fifty thousand"},
+		{"Pi is 3.14", "Pi is three point one four"},
+		{"It fell to -12 degrees", "It fell to minus twelve degrees"},
+		{"the 21st and 3rd place", "the twenty-first and third place"},
+		{"Up 45% today", "Up forty-five percent today"},
+		{"It costs $4.50", "It costs four dollars and fifty cents"},
+		{"Only $1 or £2.01", "Only one dollar or two pounds and one penny"},
+		{"Just $0.99", "Just ninety-nine cents"},
+		{"Raised €2.5 million", "Raised two point five million euros"},
+		{"Due 2024-03-15.", "Due March fifteenth, twenty twenty-four."},
+		{"Born 7/4/1999", "Born July fourth, nineteen ninety-nine"},
+		{"Since 2005-01-02", "Since January second, two thousand five"},
+		{"Email ann.lee@example.com now", "Email ann dot lee at example dot com now"},
+		{"See https://www.example.com/docs/ for more.", "See example dot com slash docs for more."},
+		{"Ask Dr. Smith, e.g. today", "Ask Doctor Smith, for example today"},
+		{"Use mp3 format", "Use mp3 format"},
+		{"balance -9223372036854775808 units", "balance minus nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred eight units"},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.in, "en", nil); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	// Built-in rules leave other languages to the API
+	if got := Normalize("Ich habe 3 Katzen", "de", nil); got != "Ich habe 3 Katzen" {
+		t.Errorf("Normalize(de) = %q", got)
+	}
+
+	// Custom rules run with the defaults
+	rules := append([]NormalizeRule{ReplaceRule(regexp.MustCompile(`\bSKU-(\d+)`), "S K U $1")}, DefaultNormalizeRules()...)
+	if got := Normalize("Order SKU-42", "en", rules); got != "Order S K U forty-two" {
+		t.Errorf("Normalize() with custom rule = %q", got)
+	}
+}
+
+func TestNumberToWords(t *testing.T) {
+	tests := map[int64]string{
+		0:          "zero",
+		15:         "fifteen",
+		40:         "forty",
+		99:         "ninety-nine",
+		100:        "one hundred",
+		101:        "one hundred one",
+		1000:       "one thousand",
+		1000001:    "one million one",
+		2000000000: "two billion",
+		-1:         "minus one",
+		math.MaxInt64: "nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion " +
+			"eight hundred fifty-four million seven hundred seventy-five thousand eight hundred seven",
+		math.MinInt64: "minus nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion " +
+			"eight hundred fifty-four million seven hundred seventy-five thousand eight hundred eight",
+	}
+	for n, want := range tests {
+		if got := numberToWords(n); got != want {
+			t.Errorf("numberToWords(%d) = %q, want %q", n, got, want)
+		}
+	}
+
+	ordinals := map[int64]string{1: "first", 12: "twelfth", 20: "twentieth", 42: "forty-second", 100: "one hundredth"}
+	for n, want := range ordinals {
+		if got := ordinalToWords(n); got != want {
+			t.Errorf("ordinalToWords(%d) = %q, want %q", n, got, want)
+		}
+	}
+
+	years := map[int64]string{1905: "nineteen oh five", 1900: "nineteen hundred", 2000: "two thousand", 2010: "twenty ten"}
+	for y, want := range years {
+		if got := yearToWords(y); got != want {
+			t.Errorf("yearToWords(%d) = %q, want %q", y, got, want)
+		}
+	}
+}
+
+func TestGenerateNormalize(t *testing.T) {
+	var text string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Text string `json:"text"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		text = body.Text
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	req := &TTSRequest{VoiceID: "voice-1", Text: "Pay $5"}
+	if _, err := client.TextToSpeech().Generate(context.Background(), req); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if text != "Pay $5" {
+		t.Errorf("text = %q, want it unchanged without Normalize", text)
+	}
+
+	req.Normalize = true
+	if _, err := client.TextToSpeech().Generate(context.Background(), req); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if text != "Pay five dollars" {
+		t.Errorf("text = %q, want normalized", text)
+	}
+}
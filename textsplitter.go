@@ -0,0 +1,238 @@
+package elevenlabs
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Default chunk sizes for a SentenceSplitter, in bytes.
+const (
+	// DefaultMinChunkSize is the shortest chunk emitted at a sentence
+	// boundary. Shorter sentences are joined with the next one.
+	DefaultMinChunkSize = 20
+
+	// DefaultMaxChunkSize is the longest chunk buffered before text is
+	// emitted without waiting for a sentence boundary.
+	DefaultMaxChunkSize = 250
+)
+
+// SentenceSplitter buffers streamed text, such as LLM token deltas, and
+// emits it at sentence boundaries. Sending whole sentences to WebSocket
+// TTS gives more natural prosody than sending token fragments, which the
+// model reads without knowing how the sentence ends.
+//
+// A chunk is emitted once it ends with a sentence boundary and is at least
+// the minimum chunk size. If no boundary arrives before the maximum chunk
+// size, the text is split at the last clause break or space instead.
+//
+//	splitter := conn.SentenceSplitter()
+//	for delta := range llmDeltas {
+//	    if err := splitter.Add(delta); err != nil {
+//	        return err
+//	    }
+//	}
+//	if err := splitter.Flush(); err != nil {
+//	    return err
+//	}
+//	return conn.Flush()
+//
+// A SentenceSplitter is safe for concurrent use.
+type SentenceSplitter struct {
+	mu      sync.Mutex
+	buf     strings.Builder
+	send    func(text string) error
+	minSize int
+	maxSize int
+}
+
+// SentenceSplitterOption configures a SentenceSplitter.
+type SentenceSplitterOption func(*SentenceSplitter)
+
+// WithMinChunkSize sets the shortest chunk emitted at a sentence boundary.
+// The default is DefaultMinChunkSize.
+func WithMinChunkSize(size int) SentenceSplitterOption {
+	return func(s *SentenceSplitter) {
+		s.minSize = size
+	}
+}
+
+// WithMaxChunkSize sets the longest chunk buffered before text is emitted
+// without a sentence boundary. The default is DefaultMaxChunkSize.
+func WithMaxChunkSize(size int) SentenceSplitterOption {
+	return func(s *SentenceSplitter) {
+		s.maxSize = size
+	}
+}
+
+// NewSentenceSplitter creates a splitter that passes each chunk to send.
+func NewSentenceSplitter(send func(text string) error, opts ...SentenceSplitterOption) *SentenceSplitter {
+	s := &SentenceSplitter{
+		send:    send,
+		minSize: DefaultMinChunkSize,
+		maxSize: DefaultMaxChunkSize,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.maxSize < s.minSize {
+		s.maxSize = s.minSize
+	}
+	return s
+}
+
+// SentenceSplitter returns a splitter that sends chunks with SendText.
+func (wsc *WebSocketTTSConnection) SentenceSplitter(opts ...SentenceSplitterOption) *SentenceSplitter {
+	return NewSentenceSplitter(wsc.SendText, opts...)
+}
+
+// Add buffers text and sends any complete chunks. If sending fails, the
+// chunk stays buffered and the error is returned.
+func (s *SentenceSplitter) Add(text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buf.WriteString(text)
+	buffered := s.buf.String()
+	for {
+		n := s.nextChunk(buffered)
+		if n == 0 {
+			break
+		}
+		chunk := buffered[:n]
+		buffered = buffered[n:]
+		if strings.TrimSpace(chunk) == "" {
+			continue
+		}
+		if err := s.send(chunk); err != nil {
+			s.reset(chunk + buffered)
+			return err
+		}
+	}
+	s.reset(buffered)
+	return nil
+}
+
+// Flush sends any buffered text, even if it is not a complete sentence.
+// Call it when the text stream ends.
+func (s *SentenceSplitter) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buffered := s.buf.String()
+	s.buf.Reset()
+	if strings.TrimSpace(buffered) == "" {
+		return nil
+	}
+	return s.send(buffered)
+}
+
+func (s *SentenceSplitter) reset(text string) {
+	s.buf.Reset()
+	s.buf.WriteString(text)
+}
+
+// nextChunk returns the length of the next chunk to emit from text, or 0
+// if more text is needed.
+func (s *SentenceSplitter) nextChunk(text string) int {
+	for i := max(s.minSize, 1); i < len(text); i++ {
+		if end := sentenceEnd(text, i); end > 0 {
+			return end
+		}
+	}
+	if len(text) < s.maxSize {
+		return 0
+	}
+	return splitPoint(text, s.maxSize)
+}
+
+// sentenceEnd reports whether a sentence ends just before byte i of text,
+// and if so returns the index after the whitespace that follows it. A
+// boundary is only known once that whitespace arrives, so "3.14" and
+// "example.com" are not split.
+func sentenceEnd(text string, i int) int {
+	if text[i] == '\n' {
+		return skipSpace(text, i)
+	}
+	if !isSpace(text[i]) {
+		return 0
+	}
+
+	// Step back over closing quotes and brackets, then the punctuation
+	// run ("Really?!")
+	j := i
+	for j > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:j])
+		if !strings.ContainsRune(`"')”’`, r) {
+			break
+		}
+		j -= size
+	}
+	end := j
+	for j > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:j])
+		if !strings.ContainsRune(".!?…", r) {
+			break
+		}
+		j -= size
+	}
+	switch {
+	case j == end:
+		return 0
+	case text[j:end] == "." && isAbbreviation(text[:j]):
+		return 0
+	}
+	return skipSpace(text, i)
+}
+
+// nonTerminalAbbreviations are abbreviations that rarely end a sentence.
+var nonTerminalAbbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "sr": true,
+	"jr": true, "st": true, "vs": true, "e.g": true, "i.e": true,
+}
+
+// isAbbreviation reports whether the word ending text is an abbreviation
+// or an initial rather than the last word of a sentence.
+func isAbbreviation(text string) bool {
+	word := text[strings.LastIndexFunc(text, unicode.IsSpace)+1:]
+	word = strings.TrimLeft(word, `"'(“‘`)
+	if r, size := utf8.DecodeRuneInString(word); size == len(word) && unicode.IsUpper(r) {
+		return true
+	}
+	return nonTerminalAbbreviations[strings.ToLower(word)]
+}
+
+// splitPoint returns where to split text within its first limit bytes
+// when there is no sentence boundary: after the last clause break, else
+// after the last space, else at the last whole rune.
+func splitPoint(text string, limit int) int {
+	head := text[:limit]
+	if i := strings.LastIndexAny(head, ",;:"); i > 0 && i+1 < len(text) && isSpace(text[i+1]) {
+		return skipSpace(text, i+1)
+	}
+	if i := strings.LastIndexFunc(head, unicode.IsSpace); i > 0 {
+		return skipSpace(text, i)
+	}
+	n := limit
+	for n > 0 && n < len(text) && !utf8.RuneStart(text[n]) {
+		n--
+	}
+	if n == 0 {
+		// A single rune longer than limit
+		_, n = utf8.DecodeRuneInString(text)
+	}
+	return n
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+// skipSpace returns the index of the first non-space byte at or after i.
+func skipSpace(text string, i int) int {
+	for i < len(text) && isSpace(text[i]) {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,134 @@
+package elevenlabs
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func splitAll(t *testing.T, deltas []string, opts ...SentenceSplitterOption) []string {
+	t.Helper()
+	var chunks []string
+	s := NewSentenceSplitter(func(text string) error {
+		chunks = append(chunks, text)
+		return nil
+	}, opts...)
+	for _, d := range deltas {
+		if err := s.Add(d); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	if err := s.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	return chunks
+}
+
+func TestSentenceSplitter(t *testing.T) {
+	tests := []struct {
+		name   string
+		deltas []string
+		opts   []SentenceSplitterOption
+		want   []string
+	}{
+		{
+			name:   "token deltas",
+			deltas: []string{"Hel", "lo there", ", friend.", " How are", " you today?", " Fine"},
+			want:   []string{"Hello there, friend. ", "How are you today? Fine"},
+		},
+		{
+			name:   "short sentences joined",
+			deltas: []string{"Hi. Yes. This is a longer sentence. End"},
+			opts:   []SentenceSplitterOption{WithMinChunkSize(10)},
+			want:   []string{"Hi. Yes. This is a longer sentence. ", "End"},
+		},
+		{
+			name:   "abbreviations and decimals",
+			deltas: []string{"Dr. Smith paid 3.14 dollars at example.com today! ", "Then left."},
+			opts:   []SentenceSplitterOption{WithMinChunkSize(1)},
+			want:   []string{"Dr. Smith paid 3.14 dollars at example.com today! ", "Then left."},
+		},
+		{
+			name:   "closing quotes",
+			deltas: []string{`She said "Stop!" and "Wait?!" Then ran.`},
+			opts:   []SentenceSplitterOption{WithMinChunkSize(1)},
+			want:   []string{`She said "Stop!" `, `and "Wait?!" `, "Then ran."},
+		},
+		{
+			name:   "newlines",
+			deltas: []string{"First line\n\nSecond line"},
+			opts:   []SentenceSplitterOption{WithMinChunkSize(1)},
+			want:   []string{"First line\n\n", "Second line"},
+		},
+		{
+			name:   "max size at clause break",
+			deltas: []string{"one two three, four five six seven eight"},
+			opts:   []SentenceSplitterOption{WithMinChunkSize(5), WithMaxChunkSize(20)},
+			want:   []string{"one two three, ", "four five six seven ", "eight"},
+		},
+		{
+			name:   "max size without spaces",
+			deltas: []string{"ééééé"},
+			opts:   []SentenceSplitterOption{WithMinChunkSize(1), WithMaxChunkSize(3)},
+			want:   []string{"é", "é", "é", "é", "é"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitAll(t, tt.deltas, tt.opts...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chunks = %q, want %q", got, tt.want)
+			}
+			if joined := strings.Join(got, ""); joined != strings.Join(tt.deltas, "") {
+				t.Errorf("joined chunks = %q, want input text", joined)
+			}
+		})
+	}
+}
+
+func TestSentenceSplitterSendError(t *testing.T) {
+	fail := true
+	var sent []string
+	s := NewSentenceSplitter(func(text string) error {
+		if fail {
+			return errors.New("send failed")
+		}
+		sent = append(sent, text)
+		return nil
+	}, WithMinChunkSize(1))
+
+	if err := s.Add("Hello. "); err == nil {
+		t.Fatal("Add() error = nil, want send error")
+	}
+	fail = false
+	if err := s.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if !reflect.DeepEqual(sent, []string{"Hello. "}) {
+		t.Errorf("sent = %q, want the failed chunk retried", sent)
+	}
+}
+
+func TestWebSocketTTSSentenceSplitter(t *testing.T) {
+	client := newWebSocketTTSTestServer(t)
+
+	conn, err := client.WebSocketTTS().Connect(context.Background(), "voice-id", nil)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer conn.Close()
+
+	splitter := conn.SentenceSplitter()
+	if err := splitter.Add("Hello there, this is a test. And more"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := splitter.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if err := conn.CloseWithTimeout(5 * time.Second); err != nil {
+		t.Errorf("CloseWithTimeout() error = %v", err)
+	}
+}
@@ -91,14 +91,16 @@ func (s *TextToDialogueService) Generate(ctx context.Context, req *DialogueReque
 
 	resp, err := s.client.apiClient.TextToDialogue(ctx, body, api.TextToDialogueParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
 	case *api.TextToDialogueOK:
 		return r.Data, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -133,7 +135,7 @@ func (s *TextToDialogueService) GenerateWithTimestamps(ctx context.Context, req
 
 	resp, err := s.client.apiClient.TextToDialogueFullWithTimestamps(ctx, body, api.TextToDialogueFullWithTimestampsParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
@@ -152,8 +154,10 @@ func (s *TextToDialogueService) GenerateWithTimestamps(ctx context.Context, req
 		}
 
 		return result, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -190,14 +194,16 @@ func (s *TextToDialogueService) GenerateStream(ctx context.Context, req *Dialogu
 
 	resp, err := s.client.apiClient.TextToDialogueStream(ctx, body, api.TextToDialogueStreamParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
 	case *api.TextToDialogueStreamOK:
 		return r.Data, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -1,8 +1,16 @@
 package elevenlabs
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"unicode/utf8"
 
 	"github.com/agentplexus/go-elevenlabs/internal/api"
 )
@@ -83,6 +91,53 @@ type TTSRequest struct {
 
 	// LanguageCode is the ISO 639-1 language code for text normalization.
 	LanguageCode string
+
+	// PreviousText is the text that comes before this request's text.
+	// Used to improve continuity when splitting long text across requests.
+	PreviousText string
+
+	// NextText is the text that comes after this request's text.
+	// Used to improve continuity when splitting long text across requests.
+	NextText string
+
+	// PreviousRequestIDs are the request IDs of up to three generations
+	// that come before this one, for smoother stitching than PreviousText.
+	// When set, the API ignores PreviousText.
+	PreviousRequestIDs []string
+
+	// Seed for deterministic generation (optional). Requests with the same
+	// seed and parameters should produce the same audio, though determinism
+	// is not guaranteed. Zero sends no seed; GenerateVariants sends seed 0
+	// when asked for it.
+	Seed int
+
+	// Normalize rewrites Text with NormalizeRules before sending it, so
+	// numbers, dates, currencies, and addresses are read as intended.
+	Normalize bool
+
+	// NormalizeRules are the rules applied when Normalize is set. If nil,
+	// DefaultNormalizeRules is used.
+	NormalizeRules []NormalizeRule
+
+	// Pronunciations are applied to Text before normalization, as phoneme
+	// tags or aliases depending on the model. See PronunciationRules.Apply.
+	Pronunciations PronunciationRules
+
+	// seedSet sends Seed even when it is zero.
+	seedSet bool
+}
+
+// text returns the text to send, with pronunciations applied and
+// normalized if requested.
+func (r *TTSRequest) text() string {
+	text := r.Text
+	if len(r.Pronunciations) > 0 {
+		text, _ = r.Pronunciations.Apply(text, r.ModelID)
+	}
+	if !r.Normalize {
+		return text
+	}
+	return Normalize(text, r.LanguageCode, r.NormalizeRules)
 }
 
 // ValidOutputFormats lists the valid audio output formats.
@@ -128,6 +183,12 @@ func (r *TTSRequest) Validate() error {
 			return err
 		}
 	}
+	if r.Seed < 0 || int64(r.Seed) > MaxSeed {
+		return &ValidationError{
+			Field:   "Seed",
+			Message: fmt.Sprintf("must be between 0 and %d", MaxSeed),
+		}
+	}
 	if r.OutputFormat != "" && !ValidOutputFormats[r.OutputFormat] {
 		return &ValidationError{
 			Field:   "OutputFormat",
@@ -137,10 +198,75 @@ func (r *TTSRequest) Validate() error {
 	return nil
 }
 
+// DefaultOutputFormat is the output format used when none is requested.
+const DefaultOutputFormat = "mp3_44100_128"
+
+// Response headers carrying generation metadata.
+const (
+	headerRequestID      = "request-id"
+	headerCharacterCount = "x-character-count"
+	headerHistoryItemID  = "history-item-id"
+)
+
 // TTSResponse contains the generated audio from text-to-speech.
 type TTSResponse struct {
 	// Audio is the generated audio data.
 	Audio io.Reader
+
+	// RequestID identifies the request, for support and billing attribution.
+	RequestID string
+
+	// CharacterCount is the number of characters billed for the request.
+	// Zero if the API did not report it.
+	CharacterCount int
+
+	// HistoryItemID is the ID of the history item created for the
+	// generation, for later lookups with History().Get. Empty if history
+	// is disabled for the request.
+	HistoryItemID string
+
+	// ContentType is the MIME type of the audio (e.g., "audio/mpeg").
+	ContentType string
+
+	// OutputFormat is the format of the audio (e.g., "mp3_44100_128").
+	OutputFormat string
+
+	// Credits is the number of credits billed, from CharacterCount and the
+	// model's credits per character. Set only if the client has pricing
+	// (WithPricing); if the API did not report CharacterCount, the
+	// characters sent are counted.
+	Credits float64
+
+	// EstimatedCost is the estimated dollar cost of Credits. Set only if
+	// the client has pricing.
+	EstimatedCost float64
+
+	// sentCharacters is the number of characters sent, for estimates when
+	// the API did not report CharacterCount.
+	sentCharacters int
+}
+
+// billedCharacters returns CharacterCount, or the characters sent if the
+// API did not report it.
+func (r *TTSResponse) billedCharacters() int {
+	if r.CharacterCount == 0 {
+		return r.sentCharacters
+	}
+	return r.CharacterCount
+}
+
+// setMetadata fills the response metadata from the response headers.
+func (r *TTSResponse) setMetadata(h http.Header, outputFormat string) {
+	r.RequestID = h.Get(headerRequestID)
+	r.HistoryItemID = h.Get(headerHistoryItemID)
+	r.ContentType = h.Get("Content-Type")
+	if n, err := strconv.Atoi(h.Get(headerCharacterCount)); err == nil {
+		r.CharacterCount = n
+	}
+	r.OutputFormat = outputFormat
+	if r.OutputFormat == "" {
+		r.OutputFormat = DefaultOutputFormat
+	}
 }
 
 // Generate generates speech from text.
@@ -148,62 +274,92 @@ func (s *TextToSpeechService) Generate(ctx context.Context, req *TTSRequest) (*T
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
+	body, modelID := req.body()
+
+	// Build params
+	params := api.TextToSpeechFullParams{
+		VoiceID: req.VoiceID,
+	}
+
+	// Set output format if provided
+	if req.OutputFormat != "" {
+		params.OutputFormat = api.NewOptTextToSpeechFullOutputFormat(
+			api.TextToSpeechFullOutputFormat(req.OutputFormat),
+		)
+	}
 
-	// Build request body
+	// Make the API call
+	ctx, header := captureResponseHeaders(ctx)
+	resp, err := s.client.apiClient.TextToSpeechFull(ctx, body, params)
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	// Handle response type
+	switch r := resp.(type) {
+	case *api.TextToSpeechFullOK:
+		out := &TTSResponse{Audio: r.Data, sentCharacters: utf8.RuneCountInString(body.Text)}
+		out.setMetadata(*header, req.OutputFormat)
+		if p := s.client.pricing; p != nil {
+			characters := out.billedCharacters()
+			out.Credits = p.Credits(characters, modelID)
+			out.EstimatedCost = p.Cost(characters, modelID)
+		}
+		return out, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
+	default:
+		return nil, unexpectedResponse(resp)
+	}
+}
+
+// body returns the request body for the TTS endpoints and the model ID.
+func (r *TTSRequest) body() (*api.BodyTextToSpeechFull, string) {
 	body := &api.BodyTextToSpeechFull{
-		Text: req.Text,
+		Text: r.text(),
 	}
 
 	// Set model ID
-	modelID := req.ModelID
+	modelID := r.ModelID
 	if modelID == "" {
 		modelID = DefaultModelID
 	}
 	body.ModelID = api.NewOptString(modelID)
 
 	// Set voice settings if provided
-	if req.VoiceSettings != nil {
+	if r.VoiceSettings != nil {
 		vs := api.VoiceSettingsResponseModel{
-			Stability:       api.NewOptNilFloat64(req.VoiceSettings.Stability),
-			SimilarityBoost: api.NewOptNilFloat64(req.VoiceSettings.SimilarityBoost),
-			Style:           api.NewOptNilFloat64(req.VoiceSettings.Style),
+			Stability:       api.NewOptNilFloat64(r.VoiceSettings.Stability),
+			SimilarityBoost: api.NewOptNilFloat64(r.VoiceSettings.SimilarityBoost),
+			Style:           api.NewOptNilFloat64(r.VoiceSettings.Style),
 		}
-		if req.VoiceSettings.Speed != 0 {
-			vs.Speed = api.NewOptNilFloat64(req.VoiceSettings.Speed)
+		if r.VoiceSettings.Speed != 0 {
+			vs.Speed = api.NewOptNilFloat64(r.VoiceSettings.Speed)
 		}
 		body.VoiceSettings = api.NewOptVoiceSettingsResponseModel(vs)
 	}
 
 	// Set language code if provided
-	if req.LanguageCode != "" {
-		body.LanguageCode = api.NewOptNilString(req.LanguageCode)
+	if r.LanguageCode != "" {
+		body.LanguageCode = api.NewOptNilString(r.LanguageCode)
 	}
 
-	// Build params
-	params := api.TextToSpeechFullParams{
-		VoiceID: req.VoiceID,
+	// Set stitching context if provided
+	if r.PreviousText != "" {
+		body.PreviousText = api.NewOptNilString(r.PreviousText)
 	}
-
-	// Set output format if provided
-	if req.OutputFormat != "" {
-		params.OutputFormat = api.NewOptTextToSpeechFullOutputFormat(
-			api.TextToSpeechFullOutputFormat(req.OutputFormat),
-		)
+	if r.NextText != "" {
+		body.NextText = api.NewOptNilString(r.NextText)
 	}
-
-	// Make the API call
-	resp, err := s.client.apiClient.TextToSpeechFull(ctx, body, params)
-	if err != nil {
-		return nil, err
+	if len(r.PreviousRequestIDs) > 0 {
+		body.PreviousRequestIds = api.NewOptNilStringArray(r.PreviousRequestIDs)
 	}
 
-	// Handle response type
-	switch r := resp.(type) {
-	case *api.TextToSpeechFullOK:
-		return &TTSResponse{Audio: r.Data}, nil
-	default:
-		return nil, &APIError{Message: "unexpected response type"}
+	if r.Seed > 0 || r.seedSet {
+		body.Seed = api.NewOptNilInt(r.Seed)
 	}
+
+	return body, modelID
 }
 
 // GenerateToWriter generates speech and writes it to a writer.
@@ -216,6 +372,58 @@ func (s *TextToSpeechService) GenerateToWriter(ctx context.Context, req *TTSRequ
 	return err
 }
 
+// GeneratePipe generates speech with the streaming endpoint and returns
+// the audio as it arrives. The reader reads straight from the connection
+// without buffering, so a slow reader slows the download instead of
+// filling memory; use it to relay audio, for example from an HTTP
+// handler:
+//
+//	audio, err := client.TextToSpeech().GeneratePipe(r.Context(), req)
+//	if err != nil {
+//	    http.Error(w, err.Error(), http.StatusBadGateway)
+//	    return
+//	}
+//	defer audio.Close()
+//	w.Header().Set("Content-Type", "audio/mpeg")
+//	io.Copy(w, audio)
+//
+// GeneratePipe returns once the response headers arrive, so API errors
+// are returned from it rather than from Read. The caller must close the
+// reader; closing it early stops the generation.
+func (s *TextToSpeechService) GeneratePipe(ctx context.Context, req *TTSRequest) (io.ReadCloser, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	body, _ := req.body()
+	data, err := body.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/text-to-speech/%s/stream", s.client.baseURL, url.PathEscape(req.VoiceID))
+	if req.OutputFormat != "" {
+		endpoint += "?output_format=" + url.QueryEscape(req.OutputFormat)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		apiErr := newAPIError(resp.StatusCode, respBody)
+		apiErr.setResponse(resp)
+		return nil, apiErr
+	}
+	return resp.Body, nil
+}
+
 // Simple is a convenience method that generates speech with minimal parameters.
 func (s *TextToSpeechService) Simple(ctx context.Context, voiceID, text string) (io.Reader, error) {
 	resp, err := s.Generate(ctx, &TTSRequest{
@@ -228,3 +436,86 @@ func (s *TextToSpeechService) Simple(ctx context.Context, voiceID, text string)
 	}
 	return resp.Audio, nil
 }
+
+// MaxSeed is the largest seed accepted for generation.
+const MaxSeed = 4294967295
+
+// DefaultVariantConcurrency is the number of variants GenerateVariants
+// generates at once, to stay within the concurrency limits of most plans.
+const DefaultVariantConcurrency = 4
+
+// TTSVariant is one take generated by GenerateVariants.
+type TTSVariant struct {
+	// Seed is the seed used for this take.
+	Seed int
+
+	// Audio is the generated audio, fully buffered. Nil if Err is set.
+	Audio io.Reader
+
+	// Err is the error generating this take, if any.
+	Err error
+}
+
+// GenerateVariants generates a take of req for each seed, so the best read
+// can be picked. Takes are generated in parallel, at most
+// DefaultVariantConcurrency at a time. The seed in req is ignored.
+//
+// Variants are returned in the order of seeds. If some takes fail, the
+// variants are still returned with Err set, along with an error joining
+// the failures.
+func (s *TextToSpeechService) GenerateVariants(ctx context.Context, req *TTSRequest, seeds []int) ([]*TTSVariant, error) {
+	if len(seeds) == 0 {
+		return nil, &ValidationError{Field: "seeds", Message: "at least one seed is required"}
+	}
+	for _, seed := range seeds {
+		take := *req
+		take.Seed = seed
+		if err := take.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	variants := make([]*TTSVariant, len(seeds))
+	sem := make(chan struct{}, DefaultVariantConcurrency)
+	var wg sync.WaitGroup
+	for i, seed := range seeds {
+		variants[i] = &TTSVariant{Seed: seed}
+		wg.Add(1)
+		go func(v *TTSVariant) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				v.Err = ctx.Err()
+				return
+			}
+			take := *req
+			take.Seed = v.Seed
+			take.seedSet = true
+			v.Audio, v.Err = s.generateBuffered(ctx, &take)
+		}(variants[i])
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, v := range variants {
+		if v.Err != nil {
+			errs = append(errs, fmt.Errorf("seed %d: %w", v.Seed, v.Err))
+		}
+	}
+	return variants, errors.Join(errs...)
+}
+
+// generateBuffered generates speech and reads the audio into memory.
+func (s *TextToSpeechService) generateBuffered(ctx context.Context, req *TTSRequest) (io.Reader, error) {
+	resp, err := s.Generate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Audio)
+	if err != nil {
+		return nil, fmt.Errorf("reading audio: %w", err)
+	}
+	return bytes.NewReader(data), nil
+}
@@ -2,7 +2,12 @@ package elevenlabs
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -260,3 +265,194 @@ func TestTextToSpeechSimple_Live(t *testing.T) {
 		t.Error("Audio.Read() returned 0 bytes")
 	}
 }
+
+func TestTextToSpeechGenerateVariants(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		var body struct {
+			Seed *int `json:"seed"`
+		}
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+		if body.Seed != nil && *body.Seed == 0 && !strings.Contains(string(data), `"seed":0`) {
+			t.Errorf("body = %s, want \"seed\":0", data)
+		}
+		if body.Seed == nil {
+			t.Error("seed not sent")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if *body.Seed == 13 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		fmt.Fprintf(w, "take-%d", *body.Seed)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	req := &TTSRequest{VoiceID: "voice-1", Text: "Welcome back.", Seed: 99}
+	seeds := []int{0, 2, 13, 4, 5, 6}
+	variants, err := client.TextToSpeech().GenerateVariants(context.Background(), req, seeds)
+	if err == nil {
+		t.Error("GenerateVariants() should report the failed take")
+	}
+	if len(variants) != len(seeds) {
+		t.Fatalf("got %d variants, want %d", len(variants), len(seeds))
+	}
+	for i, v := range variants {
+		if v.Seed != seeds[i] {
+			t.Errorf("variant %d seed = %d, want %d", i, v.Seed, seeds[i])
+		}
+		if v.Seed == 13 {
+			if v.Err == nil {
+				t.Error("seed 13 should have failed")
+			}
+			continue
+		}
+		if v.Err != nil {
+			t.Fatalf("seed %d error = %v", v.Seed, v.Err)
+		}
+		data, _ := io.ReadAll(v.Audio)
+		if want := fmt.Sprintf("take-%d", v.Seed); string(data) != want {
+			t.Errorf("seed %d audio = %q, want %q", v.Seed, data, want)
+		}
+	}
+	if req.Seed != 99 {
+		t.Errorf("request seed modified to %d", req.Seed)
+	}
+}
+
+func TestTextToSpeechGenerateVariantsValidation(t *testing.T) {
+	client, _ := NewClient(WithAPIKey("test-key"))
+	ctx := context.Background()
+	req := &TTSRequest{VoiceID: "voice-1", Text: "Hello"}
+
+	var valErr *ValidationError
+	if _, err := client.TextToSpeech().GenerateVariants(ctx, req, nil); !isValidationError(err, &valErr) {
+		t.Errorf("expected ValidationError for no seeds, got %v", err)
+	}
+	if _, err := client.TextToSpeech().GenerateVariants(ctx, req, []int{1, -1}); !isValidationError(err, &valErr) || valErr.Field != "Seed" {
+		t.Errorf("expected Seed ValidationError, got %v", err)
+	}
+	if _, err := client.TextToSpeech().GenerateVariants(ctx, &TTSRequest{VoiceID: "voice-1"}, []int{1}); err != ErrEmptyText {
+		t.Errorf("error = %v, want %v", err, ErrEmptyText)
+	}
+}
+
+func TestTextToSpeechGenerateMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Header().Set("request-id", "req-123")
+		w.Header().Set("x-character-count", "42")
+		w.Header().Set("history-item-id", "hist-456")
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	resp, err := client.TextToSpeech().Generate(context.Background(), &TTSRequest{
+		VoiceID: "voice-1",
+		Text:    "Hello",
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if resp.RequestID != "req-123" {
+		t.Errorf("RequestID = %q, want req-123", resp.RequestID)
+	}
+	if resp.CharacterCount != 42 {
+		t.Errorf("CharacterCount = %d, want 42", resp.CharacterCount)
+	}
+	if resp.HistoryItemID != "hist-456" {
+		t.Errorf("HistoryItemID = %q, want hist-456", resp.HistoryItemID)
+	}
+	if resp.ContentType != "audio/mpeg" {
+		t.Errorf("ContentType = %q, want audio/mpeg", resp.ContentType)
+	}
+	if resp.OutputFormat != DefaultOutputFormat {
+		t.Errorf("OutputFormat = %q, want %q", resp.OutputFormat, DefaultOutputFormat)
+	}
+}
+
+func TestTextToSpeechGeneratePipe(t *testing.T) {
+	release := make(chan struct{})
+	serverDone := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(serverDone)
+		if r.URL.Path != "/v1/text-to-speech/voice-1/stream" || r.URL.Query().Get("output_format") != "mp3_22050_32" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		var body struct {
+			Text    string `json:"text"`
+			ModelID string `json:"model_id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Text != "Hello" || body.ModelID != DefaultModelID {
+			t.Errorf("body = %+v", body)
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write([]byte("first"))
+		w.(http.Flusher).Flush()
+		// The rest of the audio is only sent after the client read the
+		// first chunk
+		<-release
+		_, _ = w.Write([]byte("second"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	audio, err := client.TextToSpeech().GeneratePipe(context.Background(), &TTSRequest{
+		VoiceID:      "voice-1",
+		Text:         "Hello",
+		OutputFormat: "mp3_22050_32",
+	})
+	if err != nil {
+		t.Fatalf("GeneratePipe() error = %v", err)
+	}
+	defer audio.Close()
+
+	buf := make([]byte, 5)
+	if _, err := io.ReadFull(audio, buf); err != nil || string(buf) != "first" {
+		t.Fatalf("first chunk = %q, %v", buf, err)
+	}
+	close(release)
+	rest, err := io.ReadAll(audio)
+	if err != nil || string(rest) != "second" {
+		t.Errorf("rest = %q, %v", rest, err)
+	}
+	<-serverDone
+}
+
+func TestTextToSpeechGeneratePipeErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"detail": {"status": "invalid_api_key", "message": "Invalid API key"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := client.TextToSpeech().GeneratePipe(context.Background(), &TTSRequest{VoiceID: "voice-1", Text: "Hello"}); !IsUnauthorizedError(err) {
+		t.Errorf("GeneratePipe() error = %v, want unauthorized", err)
+	}
+	if _, err := client.TextToSpeech().GeneratePipe(context.Background(), &TTSRequest{VoiceID: "voice-1"}); err != ErrEmptyText {
+		t.Errorf("GeneratePipe() error = %v, want %v", err, ErrEmptyText)
+	}
+}
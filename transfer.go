@@ -0,0 +1,353 @@
+package elevenlabs
+
+import (
+	"context"
+	"fmt"
+)
+
+// Transfer types for PhoneTransfer.
+const (
+	// TransferTypeConference is a warm transfer: the caller, agent, and
+	// human are bridged so the agent can hand over before leaving.
+	TransferTypeConference = "conference"
+
+	// TransferTypeBlind is a cold transfer: the caller is connected
+	// directly to the destination.
+	TransferTypeBlind = "blind"
+
+	// TransferTypeSIPRefer transfers a SIP trunk call with a SIP REFER.
+	TransferTypeSIPRefer = "sip_refer"
+)
+
+// PhoneTransfer is a destination the agent may transfer calls to.
+type PhoneTransfer struct {
+	// PhoneNumber is the destination in E.164 format. Set either
+	// PhoneNumber or SIPURI.
+	PhoneNumber string
+
+	// SIPURI is the destination SIP URI (e.g., "sip:support@example.com").
+	SIPURI string
+
+	// Condition describes when the agent should transfer to this
+	// destination (e.g., "The caller asks to speak to billing.").
+	Condition string
+
+	// TransferType is TransferTypeConference (default), TransferTypeBlind,
+	// or TransferTypeSIPRefer.
+	TransferType string
+}
+
+// TransferToNumberTool configures the agent's built-in tool for
+// transferring calls to human operators.
+type TransferToNumberTool struct {
+	// Transfers are the destinations the agent may choose from.
+	Transfers []PhoneTransfer
+
+	// EnableClientMessage lets the agent tell the caller that they are
+	// being transferred.
+	EnableClientMessage bool
+}
+
+func (t *TransferToNumberTool) validate() error {
+	if len(t.Transfers) == 0 {
+		return &ValidationError{Field: "transfers", Message: "at least one transfer destination is required"}
+	}
+	for i, tr := range t.Transfers {
+		if (tr.PhoneNumber == "") == (tr.SIPURI == "") {
+			return &ValidationError{Field: "transfers", Message: fmt.Sprintf("transfer %d must set exactly one of phone number or SIP URI", i)}
+		}
+		if tr.Condition == "" {
+			return &ValidationError{Field: "transfers", Message: fmt.Sprintf("transfer %d condition cannot be empty", i)}
+		}
+		switch tr.TransferType {
+		case "", TransferTypeConference, TransferTypeBlind, TransferTypeSIPRefer:
+		default:
+			return &ValidationError{Field: "transfers", Message: fmt.Sprintf("transfer %d has unsupported type %q", i, tr.TransferType)}
+		}
+	}
+	return nil
+}
+
+// transferDestinationWire is a transfer destination in the agent config.
+type transferDestinationWire struct {
+	Type        string `json:"type"`
+	PhoneNumber string `json:"phone_number,omitempty"`
+	SIPURI      string `json:"sip_uri,omitempty"`
+}
+
+type phoneTransferWire struct {
+	Destination  transferDestinationWire `json:"transfer_destination"`
+	Condition    string                  `json:"condition"`
+	TransferType string                  `json:"transfer_type,omitempty"`
+}
+
+// transferToNumberWire is the transfer_to_number system tool.
+type transferToNumberWire struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	Params      struct {
+		SystemToolType      string              `json:"system_tool_type"`
+		Transfers           []phoneTransferWire `json:"transfers"`
+		EnableClientMessage bool                `json:"enable_client_message"`
+	} `json:"params"`
+}
+
+// agentTransferConfig is the agent config fragment holding the transfer
+// tool. A nil tool removes it.
+type agentTransferConfig struct {
+	ConversationConfig struct {
+		Agent struct {
+			Prompt struct {
+				BuiltInTools struct {
+					TransferToNumber *transferToNumberWire `json:"transfer_to_number"`
+				} `json:"built_in_tools"`
+			} `json:"prompt"`
+		} `json:"agent"`
+	} `json:"conversation_config"`
+}
+
+// GetTransferToNumberTool returns the agent's transfer tool, or nil if the
+// agent cannot transfer calls.
+func (s *AgentsService) GetTransferToNumberTool(ctx context.Context, agentID string) (*TransferToNumberTool, error) {
+	if agentID == "" {
+		return nil, &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+
+	var agent agentTransferConfig
+	if err := s.client.doJSON(ctx, "GET", agentPath(agentID), nil, &agent); err != nil {
+		return nil, err
+	}
+
+	w := agent.ConversationConfig.Agent.Prompt.BuiltInTools.TransferToNumber
+	if w == nil {
+		return nil, nil
+	}
+	tool := &TransferToNumberTool{EnableClientMessage: w.Params.EnableClientMessage}
+	for _, tr := range w.Params.Transfers {
+		tool.Transfers = append(tool.Transfers, PhoneTransfer{
+			PhoneNumber:  tr.Destination.PhoneNumber,
+			SIPURI:       tr.Destination.SIPURI,
+			Condition:    tr.Condition,
+			TransferType: tr.TransferType,
+		})
+	}
+	return tool, nil
+}
+
+// SetTransferToNumberTool enables call transfers to human operators, for
+// warm (conference) or cold (blind) handoffs. A nil tool disables
+// transfers.
+func (s *AgentsService) SetTransferToNumberTool(ctx context.Context, agentID string, tool *TransferToNumberTool) error {
+	if agentID == "" {
+		return &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+
+	var update agentTransferConfig
+	if tool != nil {
+		if err := tool.validate(); err != nil {
+			return err
+		}
+		w := &transferToNumberWire{
+			Name:        "transfer_to_number",
+			Description: "Transfer the call to a human operator.",
+			Type:        "system",
+		}
+		w.Params.SystemToolType = "transfer_to_number"
+		w.Params.EnableClientMessage = tool.EnableClientMessage
+		w.Params.Transfers = []phoneTransferWire{}
+		for _, tr := range tool.Transfers {
+			dest := transferDestinationWire{Type: "phone", PhoneNumber: tr.PhoneNumber}
+			if tr.SIPURI != "" {
+				dest = transferDestinationWire{Type: "sip_uri", SIPURI: tr.SIPURI}
+			}
+			w.Params.Transfers = append(w.Params.Transfers, phoneTransferWire{
+				Destination:  dest,
+				Condition:    tr.Condition,
+				TransferType: tr.TransferType,
+			})
+		}
+		update.ConversationConfig.Agent.Prompt.BuiltInTools.TransferToNumber = w
+	}
+	return s.client.doJSON(ctx, "PATCH", agentPath(agentID), &update, nil)
+}
+
+// AgentTransfer is an agent the agent may hand the conversation to.
+type AgentTransfer struct {
+	// AgentID is the agent that takes over the conversation.
+	AgentID string
+
+	// Condition describes when the agent should transfer to this agent
+	// (e.g., "The user asks about an invoice.").
+	Condition string
+
+	// DelayMs is how long to wait before transferring, in milliseconds.
+	DelayMs int
+
+	// TransferMessage is spoken to the user before transferring. Empty
+	// transfers silently.
+	TransferMessage string
+
+	// EnableFirstMessage lets the new agent greet the user with its first
+	// message. Disable it for seamless handoffs that continue the
+	// conversation.
+	EnableFirstMessage bool
+}
+
+// TransferToAgentTool configures the agent's built-in tool for handing
+// conversations to specialized agents, such as from a receptionist to
+// billing support.
+type TransferToAgentTool struct {
+	// Transfers are the agents the agent may choose from.
+	Transfers []AgentTransfer
+}
+
+func (t *TransferToAgentTool) validate() error {
+	if len(t.Transfers) == 0 {
+		return &ValidationError{Field: "transfers", Message: "at least one transfer target is required"}
+	}
+	for i, tr := range t.Transfers {
+		if tr.AgentID == "" {
+			return &ValidationError{Field: "transfers", Message: fmt.Sprintf("transfer %d agent ID cannot be empty", i)}
+		}
+		if tr.Condition == "" {
+			return &ValidationError{Field: "transfers", Message: fmt.Sprintf("transfer %d condition cannot be empty", i)}
+		}
+		if tr.DelayMs < 0 {
+			return &ValidationError{Field: "transfers", Message: fmt.Sprintf("transfer %d delay cannot be negative", i)}
+		}
+	}
+	return nil
+}
+
+type agentTransferWire struct {
+	AgentID            string `json:"agent_id"`
+	Condition          string `json:"condition"`
+	DelayMs            int    `json:"delay_ms"`
+	TransferMessage    string `json:"transfer_message,omitempty"`
+	EnableFirstMessage bool   `json:"enable_transferred_agent_first_message"`
+}
+
+// transferToAgentWire is the transfer_to_agent system tool.
+type transferToAgentWire struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	Params      struct {
+		SystemToolType string              `json:"system_tool_type"`
+		Transfers      []agentTransferWire `json:"transfers"`
+	} `json:"params"`
+}
+
+// agentTransferToAgentConfig is the agent config fragment holding the
+// agent transfer tool. It is separate from agentTransferConfig so that
+// updating one tool does not remove the other. A nil tool removes it.
+type agentTransferToAgentConfig struct {
+	ConversationConfig struct {
+		Agent struct {
+			Prompt struct {
+				BuiltInTools struct {
+					TransferToAgent *transferToAgentWire `json:"transfer_to_agent"`
+				} `json:"built_in_tools"`
+			} `json:"prompt"`
+		} `json:"agent"`
+	} `json:"conversation_config"`
+}
+
+// GetTransferToAgentTool returns the agent's agent transfer tool, or nil
+// if the agent cannot hand conversations to other agents.
+func (s *AgentsService) GetTransferToAgentTool(ctx context.Context, agentID string) (*TransferToAgentTool, error) {
+	if agentID == "" {
+		return nil, &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+
+	var agent agentTransferToAgentConfig
+	if err := s.client.doJSON(ctx, "GET", agentPath(agentID), nil, &agent); err != nil {
+		return nil, err
+	}
+
+	w := agent.ConversationConfig.Agent.Prompt.BuiltInTools.TransferToAgent
+	if w == nil {
+		return nil, nil
+	}
+	tool := &TransferToAgentTool{}
+	for _, tr := range w.Params.Transfers {
+		tool.Transfers = append(tool.Transfers, AgentTransfer(tr))
+	}
+	return tool, nil
+}
+
+// SetTransferToAgentTool lets the agent hand conversations to other
+// agents when a transfer condition is met. The conversation continues
+// with the target agent's prompt, voice, and tools. A nil tool disables
+// agent transfers.
+func (s *AgentsService) SetTransferToAgentTool(ctx context.Context, agentID string, tool *TransferToAgentTool) error {
+	if agentID == "" {
+		return &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+
+	var update agentTransferToAgentConfig
+	if tool != nil {
+		if err := tool.validate(); err != nil {
+			return err
+		}
+		w := &transferToAgentWire{
+			Name:        "transfer_to_agent",
+			Description: "Transfer the conversation to another agent.",
+			Type:        "system",
+		}
+		w.Params.SystemToolType = "transfer_to_agent"
+		w.Params.Transfers = make([]agentTransferWire, 0, len(tool.Transfers))
+		for _, tr := range tool.Transfers {
+			w.Params.Transfers = append(w.Params.Transfers, agentTransferWire(tr))
+		}
+		update.ConversationConfig.Agent.Prompt.BuiltInTools.TransferToAgent = w
+	}
+	return s.client.doJSON(ctx, "PATCH", agentPath(agentID), &update, nil)
+}
+
+// TransferTarget is where TransferTwiML sends a call.
+type TransferTarget struct {
+	// PhoneNumber or SIPURI is the destination. Ignored if Conference is set.
+	PhoneNumber string
+	SIPURI      string
+
+	// Conference joins the call to a named conference room instead, for
+	// warm handoffs where an operator dials into the same room.
+	Conference string
+
+	// CallerID is the number shown to the destination.
+	CallerID string
+
+	// Announcement is spoken to the caller before transferring.
+	Announcement string
+}
+
+// TransferTwiML returns the TwiML that transfers a Twilio call away from
+// the agent. ElevenLabs has no endpoint to transfer a live conversation
+// on the server's behalf; to hand off a call from a supervisor dashboard,
+// update the Twilio call with this TwiML through Twilio's Calls API. For
+// agent-initiated transfers, use SetTransferToNumberTool.
+func TransferTwiML(target *TransferTarget) (*TwiMLResponse, error) {
+	if target == nil {
+		return nil, &ValidationError{Field: "target", Message: "cannot be nil"}
+	}
+
+	dial := &TwiMLDial{CallerID: target.CallerID}
+	switch {
+	case target.Conference != "":
+		dial.Targets = []TwiMLVerb{&TwiMLConference{Name: target.Conference}}
+	case target.PhoneNumber != "":
+		dial.Targets = []TwiMLVerb{&TwiMLNumber{Number: target.PhoneNumber}}
+	case target.SIPURI != "":
+		dial.Targets = []TwiMLVerb{&TwiMLSip{URI: target.SIPURI}}
+	default:
+		return nil, &ValidationError{Field: "target", Message: "phone number, SIP URI, or conference is required"}
+	}
+
+	twiml := NewTwiMLResponse()
+	if target.Announcement != "" {
+		twiml.Say(target.Announcement)
+	}
+	return twiml.Append(dial), nil
+}
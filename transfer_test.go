@@ -0,0 +1,213 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAgentsTransferToNumberTool(t *testing.T) {
+	var patched map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/convai/agents/agent-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"conversation_config":{"agent":{"prompt":{"built_in_tools":{"transfer_to_number":{
+				"name":"transfer_to_number","type":"system",
+				"params":{"system_tool_type":"transfer_to_number","enable_client_message":true,"transfers":[
+					{"transfer_destination":{"type":"phone","phone_number":"+15551230000"},"condition":"Billing questions","transfer_type":"blind"},
+					{"transfer_destination":{"type":"sip_uri","sip_uri":"sip:support@example.com"},"condition":"Technical issues"}
+				]}}}}}}}`))
+		case http.MethodPatch:
+			patched = nil
+			if err := json.NewDecoder(r.Body).Decode(&patched); err != nil {
+				t.Errorf("decoding body: %v", err)
+			}
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	tool, err := client.Agents().GetTransferToNumberTool(ctx, "agent-1")
+	if err != nil {
+		t.Fatalf("GetTransferToNumberTool() error = %v", err)
+	}
+	if !tool.EnableClientMessage || len(tool.Transfers) != 2 {
+		t.Fatalf("tool = %+v", tool)
+	}
+	if tool.Transfers[0].PhoneNumber != "+15551230000" || tool.Transfers[0].TransferType != TransferTypeBlind {
+		t.Errorf("Transfers[0] = %+v", tool.Transfers[0])
+	}
+	if tool.Transfers[1].SIPURI != "sip:support@example.com" {
+		t.Errorf("Transfers[1] = %+v", tool.Transfers[1])
+	}
+
+	err = client.Agents().SetTransferToNumberTool(ctx, "agent-1", &TransferToNumberTool{
+		Transfers: []PhoneTransfer{{PhoneNumber: "+15559870000", Condition: "Caller asks for a human", TransferType: TransferTypeConference}},
+	})
+	if err != nil {
+		t.Fatalf("SetTransferToNumberTool() error = %v", err)
+	}
+	builtIn := patched["conversation_config"].(map[string]any)["agent"].(map[string]any)["prompt"].(map[string]any)["built_in_tools"].(map[string]any)
+	params := builtIn["transfer_to_number"].(map[string]any)["params"].(map[string]any)
+	transfer := params["transfers"].([]any)[0].(map[string]any)
+	if params["system_tool_type"] != "transfer_to_number" || transfer["transfer_type"] != "conference" {
+		t.Errorf("params = %v", params)
+	}
+	if dest := transfer["transfer_destination"].(map[string]any); dest["type"] != "phone" || dest["phone_number"] != "+15559870000" {
+		t.Errorf("transfer_destination = %v", dest)
+	}
+
+	if err := client.Agents().SetTransferToNumberTool(ctx, "agent-1", nil); err != nil {
+		t.Fatalf("SetTransferToNumberTool(nil) error = %v", err)
+	}
+	builtIn = patched["conversation_config"].(map[string]any)["agent"].(map[string]any)["prompt"].(map[string]any)["built_in_tools"].(map[string]any)
+	if v, ok := builtIn["transfer_to_number"]; !ok || v != nil {
+		t.Errorf("transfer_to_number = %v, want null", v)
+	}
+}
+
+func TestTransferToNumberToolValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		tool *TransferToNumberTool
+	}{
+		{"no transfers", &TransferToNumberTool{}},
+		{"no destination", &TransferToNumberTool{Transfers: []PhoneTransfer{{Condition: "x"}}}},
+		{"both destinations", &TransferToNumberTool{Transfers: []PhoneTransfer{{PhoneNumber: "+1", SIPURI: "sip:a", Condition: "x"}}}},
+		{"no condition", &TransferToNumberTool{Transfers: []PhoneTransfer{{PhoneNumber: "+1"}}}},
+		{"bad type", &TransferToNumberTool{Transfers: []PhoneTransfer{{PhoneNumber: "+1", Condition: "x", TransferType: "warm"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var valErr *ValidationError
+			if err := tt.tool.validate(); !isValidationError(err, &valErr) {
+				t.Errorf("validate() = %v, want ValidationError", err)
+			}
+		})
+	}
+}
+
+func TestAgentsTransferToAgentTool(t *testing.T) {
+	var patched map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/convai/agents/agent-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"conversation_config":{"agent":{"prompt":{"built_in_tools":{"transfer_to_agent":{
+				"name":"transfer_to_agent","type":"system",
+				"params":{"system_tool_type":"transfer_to_agent","transfers":[
+					{"agent_id":"billing","condition":"Invoice questions","delay_ms":500,"transfer_message":"Connecting you to billing.","enable_transferred_agent_first_message":true}
+				]}}}}}}}`))
+		case http.MethodPatch:
+			patched = nil
+			if err := json.NewDecoder(r.Body).Decode(&patched); err != nil {
+				t.Errorf("decoding body: %v", err)
+			}
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	tool, err := client.Agents().GetTransferToAgentTool(ctx, "agent-1")
+	if err != nil {
+		t.Fatalf("GetTransferToAgentTool() error = %v", err)
+	}
+	want := AgentTransfer{AgentID: "billing", Condition: "Invoice questions", DelayMs: 500, TransferMessage: "Connecting you to billing.", EnableFirstMessage: true}
+	if tool == nil || len(tool.Transfers) != 1 || tool.Transfers[0] != want {
+		t.Fatalf("tool = %+v", tool)
+	}
+
+	err = client.Agents().SetTransferToAgentTool(ctx, "agent-1", &TransferToAgentTool{
+		Transfers: []AgentTransfer{{AgentID: "tech", Condition: "Technical issues"}},
+	})
+	if err != nil {
+		t.Fatalf("SetTransferToAgentTool() error = %v", err)
+	}
+	builtIn := patched["conversation_config"].(map[string]any)["agent"].(map[string]any)["prompt"].(map[string]any)["built_in_tools"].(map[string]any)
+	if _, ok := builtIn["transfer_to_number"]; ok {
+		t.Error("SetTransferToAgentTool() should not change the transfer_to_number tool")
+	}
+	params := builtIn["transfer_to_agent"].(map[string]any)["params"].(map[string]any)
+	transfer := params["transfers"].([]any)[0].(map[string]any)
+	if params["system_tool_type"] != "transfer_to_agent" || transfer["agent_id"] != "tech" || transfer["condition"] != "Technical issues" {
+		t.Errorf("params = %v", params)
+	}
+	if _, ok := transfer["transfer_message"]; ok {
+		t.Errorf("transfer_message should be omitted when empty: %v", transfer)
+	}
+
+	if err := client.Agents().SetTransferToAgentTool(ctx, "agent-1", nil); err != nil {
+		t.Fatalf("SetTransferToAgentTool(nil) error = %v", err)
+	}
+	builtIn = patched["conversation_config"].(map[string]any)["agent"].(map[string]any)["prompt"].(map[string]any)["built_in_tools"].(map[string]any)
+	if v, ok := builtIn["transfer_to_agent"]; !ok || v != nil {
+		t.Errorf("transfer_to_agent = %v, want null", v)
+	}
+}
+
+func TestTransferToAgentToolValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		tool *TransferToAgentTool
+	}{
+		{"no transfers", &TransferToAgentTool{}},
+		{"no agent", &TransferToAgentTool{Transfers: []AgentTransfer{{Condition: "x"}}}},
+		{"no condition", &TransferToAgentTool{Transfers: []AgentTransfer{{AgentID: "a"}}}},
+		{"negative delay", &TransferToAgentTool{Transfers: []AgentTransfer{{AgentID: "a", Condition: "x", DelayMs: -1}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var valErr *ValidationError
+			if err := tt.tool.validate(); !isValidationError(err, &valErr) {
+				t.Errorf("validate() = %v, want ValidationError", err)
+			}
+		})
+	}
+}
+
+func TestTransferTwiML(t *testing.T) {
+	twiml, err := TransferTwiML(&TransferTarget{
+		PhoneNumber:  "+15551230000",
+		CallerID:     "+15550000000",
+		Announcement: "Connecting you to an operator.",
+	})
+	if err != nil {
+		t.Fatalf("TransferTwiML() error = %v", err)
+	}
+	want := `<Response><Say>Connecting you to an operator.</Say><Dial callerId="+15550000000"><Number>+15551230000</Number></Dial></Response>`
+	if got := twiml.String(); !strings.HasSuffix(got, want) {
+		t.Errorf("TwiML = %s", got)
+	}
+
+	twiml, _ = TransferTwiML(&TransferTarget{Conference: "handoff-42", PhoneNumber: "+1"})
+	if got := twiml.String(); !strings.Contains(got, "<Conference>handoff-42</Conference>") {
+		t.Errorf("TwiML = %s", got)
+	}
+
+	var valErr *ValidationError
+	if _, err := TransferTwiML(&TransferTarget{}); !isValidationError(err, &valErr) {
+		t.Errorf("expected ValidationError, got %v", err)
+	}
+}
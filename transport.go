@@ -0,0 +1,78 @@
+package elevenlabs
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// Transport defaults, tuned for parallel batch workloads such as
+// GenerateVariants and Voices().SettingsPreview. net/http keeps only two
+// idle connections per host, so bursts of concurrent requests would
+// otherwise open and discard connections; see BenchmarkParallelGenerate.
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 32
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// TransportConfig tunes the HTTP transport of a Client.
+type TransportConfig struct {
+	// MaxIdleConns limits idle connections across all hosts.
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost is how many idle connections are kept for reuse.
+	// Set it to at least the number of requests made in parallel.
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost limits connections, including active ones. Zero
+	// means no limit.
+	MaxConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept.
+	IdleConnTimeout time.Duration
+
+	// DisableHTTP2 uses HTTP/1.1 only. HTTP/2 multiplexes concurrent
+	// requests over one connection, which some proxies do not support.
+	DisableHTTP2 bool
+
+	// TLSConfig is the TLS configuration, for example to trust a private
+	// CA on a corporate proxy. Nil uses the system defaults.
+	TLSConfig *tls.Config
+}
+
+// DefaultTransportConfig returns the transport settings used when no
+// HTTP client is given.
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		MaxIdleConns:        DefaultMaxIdleConns,
+		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:     DefaultIdleConnTimeout,
+	}
+}
+
+// WithTransport tunes the HTTP transport. It has no effect when a client
+// is set with WithHTTPClient.
+func WithTransport(config TransportConfig) Option {
+	return func(o *clientOptions) {
+		o.transport = config
+	}
+}
+
+// newTransport creates an HTTP transport from config, keeping the proxy,
+// dialer, and timeout settings of http.DefaultTransport.
+func newTransport(config TransportConfig) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = config.MaxIdleConns
+	t.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	t.MaxConnsPerHost = config.MaxConnsPerHost
+	t.IdleConnTimeout = config.IdleConnTimeout
+	if config.TLSConfig != nil {
+		t.TLSClientConfig = config.TLSConfig.Clone()
+	}
+	if config.DisableHTTP2 {
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return t
+}
@@ -0,0 +1,113 @@
+package elevenlabs
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewTransport(t *testing.T) {
+	tr := newTransport(DefaultTransportConfig())
+	if tr.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost || tr.MaxIdleConns != DefaultMaxIdleConns {
+		t.Errorf("idle conns = %d/%d", tr.MaxIdleConnsPerHost, tr.MaxIdleConns)
+	}
+	if !tr.ForceAttemptHTTP2 || tr.Proxy == nil {
+		t.Error("defaults of http.DefaultTransport not kept")
+	}
+
+	tlsConfig := &tls.Config{ServerName: "proxy.internal"}
+	tr = newTransport(TransportConfig{MaxConnsPerHost: 4, DisableHTTP2: true, TLSConfig: tlsConfig})
+	if tr.MaxConnsPerHost != 4 {
+		t.Errorf("MaxConnsPerHost = %d, want 4", tr.MaxConnsPerHost)
+	}
+	if tr.ForceAttemptHTTP2 || tr.TLSNextProto == nil {
+		t.Error("HTTP/2 not disabled")
+	}
+	if tr.TLSClientConfig == nil || tr.TLSClientConfig.ServerName != "proxy.internal" || tr.TLSClientConfig == tlsConfig {
+		t.Error("TLS config not cloned")
+	}
+}
+
+// newConnCountingServer returns a TTS server and a counter of the
+// connections opened to it.
+func newConnCountingServer(tb testing.TB) (*httptest.Server, *atomic.Int64) {
+	var conns atomic.Int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Millisecond)
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write(make([]byte, 8192))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Start()
+	tb.Cleanup(server.Close)
+	return server, &conns
+}
+
+func TestClientConcurrentUse(t *testing.T) {
+	server, conns := newConnCountingServer(t)
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	const workers = 16
+	for round := 0; round < 3; round++ {
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := client.TextToSpeech().Generate(context.Background(), &TTSRequest{VoiceID: "voice-1", Text: "Hello"}); err != nil {
+					t.Errorf("Generate() error = %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	// Connections from the first round are reused by later rounds
+	if n := conns.Load(); n > workers {
+		t.Errorf("opened %d connections for %d parallel requests", n, workers)
+	}
+}
+
+// BenchmarkParallelGenerate compares net/http's default of two idle
+// connections per host with DefaultTransportConfig for parallel TTS
+// requests. Run with: go test -bench ParallelGenerate -cpu 16
+func BenchmarkParallelGenerate(b *testing.B) {
+	configs := map[string]TransportConfig{
+		"net-http-default": {MaxIdleConns: 100, MaxIdleConnsPerHost: http.DefaultMaxIdleConnsPerHost, IdleConnTimeout: DefaultIdleConnTimeout},
+		"default":          DefaultTransportConfig(),
+	}
+	for name, config := range configs {
+		b.Run(name, func(b *testing.B) {
+			server, conns := newConnCountingServer(b)
+			client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL), WithTransport(config))
+			if err != nil {
+				b.Fatalf("NewClient() error = %v", err)
+			}
+			req := &TTSRequest{VoiceID: "voice-1", Text: "Hello"}
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := client.TextToSpeech().Generate(context.Background(), req); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+			b.ReportMetric(float64(conns.Load()), "conns")
+		})
+	}
+}
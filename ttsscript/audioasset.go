@@ -0,0 +1,64 @@
+package ttsscript
+
+import "path/filepath"
+
+// Segment types.
+const (
+	// SegmentTypeText is a segment spoken with TTS. It is the default.
+	SegmentTypeText = "text"
+
+	// SegmentTypeAudio is a pre-recorded insert, such as a jingle or a
+	// human-recorded disclaimer, played from Segment.Audio instead of
+	// generated.
+	SegmentTypeAudio = "audio"
+)
+
+// AllLanguages is the Segment.Audio key for audio used in every language
+// without its own entry, such as a jingle.
+const AllLanguages = "*"
+
+// AudioAsset is pre-recorded audio for an audio segment. Set exactly one
+// of File and HistoryItemID. The audio is copied as is and converted to
+// the batch output format when assembled.
+type AudioAsset struct {
+	// File is the path of an audio file. Relative paths are relative to
+	// the script file; see ResolveFile.
+	File string `json:"file,omitempty"`
+
+	// HistoryItemID is an ElevenLabs history item whose audio is used.
+	HistoryItemID string `json:"history_item_id,omitempty"`
+}
+
+// ResolveFile returns the asset file path, resolving relative paths
+// against the directory of scriptPath.
+func (a *AudioAsset) ResolveFile(scriptPath string) string {
+	if a.File == "" || filepath.IsAbs(a.File) {
+		return a.File
+	}
+	return filepath.Join(filepath.Dir(scriptPath), a.File)
+}
+
+// validate returns a problem with the asset, or "".
+func (a *AudioAsset) validate() string {
+	switch {
+	case a == nil || (a.File == "" && a.HistoryItemID == ""):
+		return "has no file or history item"
+	case a.File != "" && a.HistoryItemID != "":
+		return "has both a file and a history item"
+	}
+	return ""
+}
+
+// IsAudio reports whether the segment is a pre-recorded audio insert.
+func (s *Segment) IsAudio() bool {
+	return s.Type == SegmentTypeAudio
+}
+
+// AudioFor returns the audio asset of an audio segment for a language,
+// falling back to the AllLanguages entry. Nil if there is none.
+func (s *Segment) AudioFor(language string) *AudioAsset {
+	if a, ok := s.Audio[language]; ok {
+		return a
+	}
+	return s.Audio[AllLanguages]
+}
@@ -0,0 +1,99 @@
+package ttsscript
+
+import "strings"
+
+// SupportedAudioTags lists the audio tags understood by Eleven v3.
+// Tags are written inline as "[tag]" and steer emotion, delivery,
+// and non-verbal reactions.
+var SupportedAudioTags = map[string]bool{
+	// Emotions
+	"happy":         true,
+	"sad":           true,
+	"angry":         true,
+	"annoyed":       true,
+	"excited":       true,
+	"curious":       true,
+	"surprised":     true,
+	"sarcastic":     true,
+	"nervous":       true,
+	"calm":          true,
+	"serious":       true,
+	"thoughtful":    true,
+	"mischievously": true,
+	"appalled":      true,
+	"crying":        true,
+
+	// Delivery
+	"whispers": true,
+	"shouts":   true,
+	"softly":   true,
+	"slowly":   true,
+	"quickly":  true,
+
+	// Non-verbal reactions
+	"laughs":          true,
+	"laughs harder":   true,
+	"starts laughing": true,
+	"chuckles":        true,
+	"giggles":         true,
+	"wheezing":        true,
+	"sighs":           true,
+	"exhales":         true,
+	"gasps":           true,
+	"gulps":           true,
+	"snorts":          true,
+	"clears throat":   true,
+	"pauses":          true,
+}
+
+// NormalizeAudioTag lowercases a tag and strips surrounding whitespace
+// and brackets, so "[Whispers]" and "whispers" are equivalent.
+func NormalizeAudioTag(tag string) string {
+	tag = strings.TrimSpace(tag)
+	tag = strings.TrimPrefix(tag, "[")
+	tag = strings.TrimSuffix(tag, "]")
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// IsSupportedAudioTag reports whether tag is a known Eleven v3 audio tag.
+func IsSupportedAudioTag(tag string) bool {
+	return SupportedAudioTags[NormalizeAudioTag(tag)]
+}
+
+// AudioTags returns the normalized audio tags for the segment,
+// with the emotion (if any) first followed by the explicit tags.
+// Empty and duplicate tags are removed.
+func (s *Segment) AudioTags() []string {
+	var tags []string
+	seen := make(map[string]bool)
+	add := func(tag string) {
+		tag = NormalizeAudioTag(tag)
+		if tag == "" || seen[tag] {
+			return
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	add(s.Emotion)
+	for _, tag := range s.Tags {
+		add(tag)
+	}
+	return tags
+}
+
+// ApplyAudioTags prefixes text with the given tags in "[tag]" form.
+func ApplyAudioTags(text string, tags []string) string {
+	if len(tags) == 0 {
+		return text
+	}
+
+	var sb strings.Builder
+	for _, tag := range tags {
+		sb.WriteString("[")
+		sb.WriteString(tag)
+		sb.WriteString("] ")
+	}
+	sb.WriteString(text)
+	return sb.String()
+}
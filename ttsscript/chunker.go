@@ -0,0 +1,193 @@
+package ttsscript
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ModelCharacterLimits lists the per-request character limits of ElevenLabs
+// TTS models, as documented at the time of writing. Prefer
+// elevenlabs.Model.MaxTextLength from the model list when it is available.
+var ModelCharacterLimits = map[string]int{
+	"eleven_v3":              5000,
+	"eleven_multilingual_v2": 10000,
+	"eleven_flash_v2_5":      40000,
+	"eleven_turbo_v2_5":      40000,
+	"eleven_flash_v2":        30000,
+	"eleven_turbo_v2":        30000,
+	"eleven_multilingual_v1": 10000,
+	"eleven_monolingual_v1":  10000,
+}
+
+// DefaultCharacterLimit is used for models not in ModelCharacterLimits.
+const DefaultCharacterLimit = 5000
+
+// CharacterLimit returns the per-request character limit for a model.
+func CharacterLimit(modelID string) int {
+	if limit, ok := ModelCharacterLimits[modelID]; ok {
+		return limit
+	}
+	return DefaultCharacterLimit
+}
+
+// sentenceEndPattern matches sentence-ending punctuation, optional closing
+// quotes or brackets, and the following whitespace.
+var sentenceEndPattern = regexp.MustCompile(`[.!?…。！？]+["'”’)\]]*\s+`)
+
+// Chunker splits text that exceeds a character limit into chunks.
+// Text is split on sentence boundaries where possible, then on word
+// boundaries for sentences that are too long on their own.
+type Chunker struct {
+	// MaxChars is the maximum number of characters per chunk.
+	MaxChars int
+}
+
+// NewChunker creates a chunker with the given character limit.
+func NewChunker(maxChars int) *Chunker {
+	return &Chunker{MaxChars: maxChars}
+}
+
+// Split splits text into chunks of at most MaxChars characters.
+// Text within the limit is returned as a single chunk.
+func (c *Chunker) Split(text string) []string {
+	return c.split(text, utf8.RuneCountInString)
+}
+
+// split splits text so that measure(chunk) <= MaxChars for each chunk.
+// measure lets the compiler count characters after pronunciation
+// substitutions while splitting the original text.
+func (c *Chunker) split(text string, measure func(string) int) []string {
+	text = strings.TrimSpace(text)
+	if c.MaxChars <= 0 || measure(text) <= c.MaxChars {
+		return []string{text}
+	}
+
+	// Break into pieces that each fit within the limit
+	var pieces []string
+	for _, sentence := range SplitSentences(text) {
+		if measure(sentence) <= c.MaxChars {
+			pieces = append(pieces, sentence)
+			continue
+		}
+		pieces = append(pieces, c.splitWords(sentence, measure)...)
+	}
+
+	// Greedily combine pieces into chunks
+	var chunks []string
+	current := ""
+	for _, piece := range pieces {
+		if current == "" {
+			current = piece
+			continue
+		}
+		candidate := current + " " + piece
+		if measure(candidate) > c.MaxChars {
+			chunks = append(chunks, current)
+			current = piece
+			continue
+		}
+		current = candidate
+	}
+	if current != "" {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// splitWords splits a sentence on word boundaries. Words longer than the
+// limit are cut at the limit.
+func (c *Chunker) splitWords(sentence string, measure func(string) int) []string {
+	var chunks []string
+	current := ""
+	for _, word := range strings.Fields(sentence) {
+		for measure(word) > c.MaxChars {
+			if current != "" {
+				chunks = append(chunks, current)
+				current = ""
+			}
+			runes := []rune(word)
+			cut := c.MaxChars
+			if cut > len(runes) {
+				cut = len(runes)
+			}
+			chunks = append(chunks, string(runes[:cut]))
+			word = string(runes[cut:])
+		}
+		if word == "" {
+			continue
+		}
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if current != "" && measure(candidate) > c.MaxChars {
+			chunks = append(chunks, current)
+			current = word
+			continue
+		}
+		current = candidate
+	}
+	if current != "" {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// SplitSentences splits text into sentences on terminal punctuation.
+func SplitSentences(text string) []string {
+	var sentences []string
+	start := 0
+	for _, m := range sentenceEndPattern.FindAllStringIndex(text, -1) {
+		if sentence := strings.TrimSpace(text[start:m[1]]); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = m[1]
+	}
+	if rest := strings.TrimSpace(text[start:]); rest != "" {
+		sentences = append(sentences, rest)
+	}
+	return sentences
+}
+
+// chunkSegment splits a compiled segment whose text exceeds maxChars.
+// The original text is split and apply re-applies pronunciations to each
+// chunk, so chunk limits hold for the substituted text. Pauses before and
+// after are kept on the first and last chunk, and each chunk records the
+// text of its neighbors for stitching.
+func chunkSegment(seg CompiledSegment, maxChars int, apply func(string) (string, []Phoneme)) []CompiledSegment {
+	chunker := NewChunker(maxChars)
+	parts := chunker.split(seg.OriginalText, func(s string) int {
+		text, _ := apply(s)
+		return utf8.RuneCountInString(text)
+	})
+	if len(parts) <= 1 {
+		return []CompiledSegment{seg}
+	}
+
+	chunks := make([]CompiledSegment, len(parts))
+	for i, part := range parts {
+		chunk := seg
+		chunk.Text, chunk.Phonemes = apply(part)
+		chunk.OriginalText = part
+		chunk.ChunkIndex = i
+		chunk.ChunkCount = len(parts)
+		if i > 0 {
+			chunk.PauseBeforeMs = 0
+		}
+		if i < len(parts)-1 {
+			chunk.PauseAfterMs = 0
+		}
+		chunks[i] = chunk
+	}
+
+	for i := range chunks {
+		if i > 0 {
+			chunks[i].PreviousText = chunks[i-1].Text
+		}
+		if i < len(chunks)-1 {
+			chunks[i].NextText = chunks[i+1].Text
+		}
+	}
+	return chunks
+}
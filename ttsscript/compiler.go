@@ -3,8 +3,10 @@ package ttsscript
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // Compiler compiles scripts to various output formats.
@@ -17,6 +19,15 @@ type Compiler struct {
 
 	// DefaultPauseAfterSegment is the pause after each segment if not specified.
 	DefaultPauseAfterSegment string
+
+	// MaxChars splits segments longer than this many characters into chunks
+	// on sentence boundaries (see CharacterLimit). Zero disables chunking.
+	MaxChars int
+
+	// Variables are values for {{name}} placeholders and segment
+	// conditions, overriding Script.Variables. Use them to compile one
+	// script into several product-variant narrations.
+	Variables map[string]string
 }
 
 // NewCompiler creates a new script compiler with default settings.
@@ -40,6 +51,11 @@ type CompiledSegment struct {
 	// SlideTitle is the slide title (if any).
 	SlideTitle string
 
+	// SlideID and SegmentID are the IDs of the source slide and segment,
+	// if set. SegmentID is empty for title segments.
+	SlideID   string
+	SegmentID string
+
 	// IsTitleSegment indicates this segment was generated from a slide title.
 	IsTitleSegment bool
 
@@ -49,7 +65,8 @@ type CompiledSegment struct {
 	// Text is the processed text with pronunciations applied.
 	Text string
 
-	// OriginalText is the text before pronunciation substitutions.
+	// OriginalText is the text before pronunciation substitutions, with
+	// variables substituted.
 	OriginalText string
 
 	// VoiceID is the voice to use for this segment.
@@ -72,28 +89,95 @@ type CompiledSegment struct {
 
 	// Pitch is the pitch adjustment.
 	Pitch string
+
+	// Phonemes are phonetic pronunciations for terms in Text, from
+	// pronunciation entries written as "ipa:..." or "cmu:...".
+	// The terms are left unchanged in Text.
+	Phonemes []Phoneme
+
+	// AudioTags are the normalized Eleven v3 audio tags for this segment,
+	// from the segment emotion and tags. Not included in Text.
+	AudioTags []string
+
+	// Settings are the merged script, language, slide, and segment voice
+	// settings. Nil if no level sets any voice settings.
+	Settings *VoiceSettings
+
+	// ModelID is the TTS model for the language from Script.DefaultModels.
+	// Empty to use the model chosen at generation time.
+	ModelID string
+
+	// ChunkIndex is the 0-based chunk index when a long segment was split.
+	ChunkIndex int
+
+	// ChunkCount is the number of chunks the segment was split into.
+	// Zero if the segment was not split.
+	ChunkCount int
+
+	// PreviousText is the text of the preceding chunk, for stitching.
+	PreviousText string
+
+	// NextText is the text of the following chunk, for stitching.
+	NextText string
+
+	// Audio is the pre-recorded audio of an audio segment, which is used
+	// instead of generating speech. Text is then the transcript, if any.
+	// Nil for text segments.
+	Audio *AudioAsset
+
+	// OutputFormat is the output format from the segment or slide. Empty
+	// to use the format chosen at generation time.
+	OutputFormat string
 }
 
 // Compile compiles the script for the specified language.
 // Returns a slice of compiled segments ready for TTS processing.
+// Segments whose condition is false are skipped. Compile fails if text or
+// a condition uses an undefined variable.
 func (c *Compiler) Compile(script *Script, language string) ([]CompiledSegment, error) {
 	var segments []CompiledSegment
+	values := variableValues(script.Variables, c.Variables)
+
+	languageSettings := script.VoiceSettings.Merge(script.DefaultSettings[language])
+	modelID := script.DefaultModels[language]
 
 	for slideIdx, slide := range script.Slides {
+		slideSettings := languageSettings.Merge(slide.VoiceSettings)
+
+		// Select the segments for this variant
+		var included []int
+		for segIdx, seg := range slide.Segments {
+			ok, err := EvaluateCondition(seg.Condition, values)
+			if err != nil {
+				return nil, fmt.Errorf("slide %d, segment %d: %w", slideIdx+1, segIdx+1, err)
+			}
+			if ok {
+				included = append(included, segIdx)
+			}
+		}
+		if len(slide.Segments) > 0 && len(included) == 0 {
+			continue // Skip slides without segments for this variant
+		}
+
+		slideTitle, err := SubstituteVariables(slide.Title, values)
+		if err != nil {
+			return nil, fmt.Errorf("slide %d title: %w", slideIdx+1, err)
+		}
+
 		// Check if we should speak the title
-		if slide.ShouldSpeakTitle() && slide.Title != "" {
-			titleText := slide.Title
+		if slide.ShouldSpeakTitle() && slideTitle != "" {
+			titleText := slideTitle
 
 			// Apply pronunciations to title
-			titleText = c.applyPronunciations(titleText, language, script.Pronunciations, nil)
+			titleText, titlePhonemes := c.applyPronunciations(titleText, language, script.Pronunciations, nil)
 
 			// Determine voice for title
 			voiceID := ""
 			if v, ok := slide.TitleVoice[language]; ok {
 				voiceID = v
-			} else if len(slide.Segments) > 0 {
+			} else if len(included) > 0 {
 				// Fall back to first segment's voice
-				if v, ok := slide.Segments[0].Voice[language]; ok {
+				if v, ok := slide.Segments[included[0]].Voice[language]; ok {
 					voiceID = v
 				}
 			}
@@ -123,37 +207,61 @@ func (c *Compiler) Compile(script *Script, language string) ([]CompiledSegment,
 			segments = append(segments, CompiledSegment{
 				SlideIndex:      slideIdx,
 				SegmentIndex:    -1, // Title segments use -1
-				SlideTitle:      slide.Title,
+				SlideID:         slide.ID,
+				SlideTitle:      slideTitle,
 				IsTitleSegment:  true,
 				IsSectionHeader: slide.IsSectionHeader,
 				Text:            titleText,
-				OriginalText:    slide.Title,
+				OriginalText:    slideTitle,
 				VoiceID:         voiceID,
 				Language:        language,
 				PauseBeforeMs:   pauseBefore,
 				PauseAfterMs:    titlePauseAfter,
+				Phonemes:        titlePhonemes,
+				Settings:        slideSettings,
+				ModelID:         modelID,
+				OutputFormat:    slide.OutputFormat,
 			})
 		}
 
-		for segIdx, seg := range slide.Segments {
+		for i, segIdx := range included {
+			seg := slide.Segments[segIdx]
+			var audio *AudioAsset
+			if seg.IsAudio() {
+				if audio = seg.AudioFor(language); audio == nil {
+					continue // Skip audio segments without this language
+				}
+			}
 			text, ok := seg.Text[language]
-			if !ok {
+			if !ok && audio == nil {
 				continue // Skip segments without this language
 			}
 
+			text, err := SubstituteVariables(text, values)
+			if err != nil {
+				return nil, fmt.Errorf("slide %d, segment %d: %w", slideIdx+1, segIdx+1, err)
+			}
 			originalText := text
 
 			// Apply pronunciations
-			text = c.applyPronunciations(text, language, script.Pronunciations, seg.Pronunciations)
+			var phonemes []Phoneme
+			if audio == nil {
+				text, phonemes = c.applyPronunciations(text, language, script.Pronunciations, seg.Pronunciations)
+			}
 
 			// Determine voice
 			voiceID := ""
 			if v, ok := seg.Voice[language]; ok {
 				voiceID = v
-			} else if v, ok := script.DefaultVoices[language]; ok {
+			} else if v, ok := script.DefaultVoices[language]; ok && audio == nil {
 				voiceID = v
 			}
 
+			outputFormat := seg.OutputFormat
+			if outputFormat == "" {
+				outputFormat = slide.OutputFormat
+			}
+
 			// Parse pauses
 			pauseBefore := ParseDuration(seg.PauseBefore)
 			pauseAfter := ParseDuration(seg.PauseAfter)
@@ -164,17 +272,19 @@ func (c *Compiler) Compile(script *Script, language string) ([]CompiledSegment,
 			}
 
 			// Add default slide pause after last segment
-			if segIdx == len(slide.Segments)-1 && c.DefaultPauseAfterSlide != "" {
+			if i == len(included)-1 && c.DefaultPauseAfterSlide != "" {
 				slidePause := ParseDuration(c.DefaultPauseAfterSlide)
 				if slidePause > pauseAfter {
 					pauseAfter = slidePause
 				}
 			}
 
-			segments = append(segments, CompiledSegment{
+			compiled := CompiledSegment{
 				SlideIndex:      slideIdx,
 				SegmentIndex:    segIdx,
-				SlideTitle:      slide.Title,
+				SlideID:         slide.ID,
+				SegmentID:       seg.ID,
+				SlideTitle:      slideTitle,
 				IsSectionHeader: slide.IsSectionHeader,
 				Text:            text,
 				OriginalText:    originalText,
@@ -185,7 +295,33 @@ func (c *Compiler) Compile(script *Script, language string) ([]CompiledSegment,
 				Emphasis:        seg.Emphasis,
 				Rate:            seg.Rate,
 				Pitch:           seg.Pitch,
-			})
+				Phonemes:        phonemes,
+				AudioTags:       seg.AudioTags(),
+				Settings:        slideSettings.Merge(seg.VoiceSettings),
+				ModelID:         modelID,
+				OutputFormat:    outputFormat,
+			}
+			if audio != nil {
+				// Pre-recorded audio is inserted as is
+				compiled.AudioTags = nil
+				compiled.Settings = nil
+				compiled.ModelID = ""
+				compiled.OutputFormat = ""
+				compiled.Audio = audio
+				segments = append(segments, compiled)
+				continue
+			}
+
+			// Split segments that exceed the character limit
+			if c.MaxChars > 0 && utf8.RuneCountInString(text) > c.MaxChars {
+				apply := func(s string) (string, []Phoneme) {
+					return c.applyPronunciations(s, language, script.Pronunciations, seg.Pronunciations)
+				}
+				segments = append(segments, chunkSegment(compiled, c.MaxChars, apply)...)
+				continue
+			}
+
+			segments = append(segments, compiled)
 		}
 	}
 
@@ -193,7 +329,9 @@ func (c *Compiler) Compile(script *Script, language string) ([]CompiledSegment,
 }
 
 // applyPronunciations applies pronunciation substitutions to the text.
-func (c *Compiler) applyPronunciations(text, language string, scriptProns, segmentProns map[string]map[string]string) string {
+// Phonetic pronunciations (see ParsePhoneme) are not substituted; they are
+// returned for formatters that support phoneme markup.
+func (c *Compiler) applyPronunciations(text, language string, scriptProns, segmentProns map[string]map[string]string) (string, []Phoneme) {
 	// Build combined pronunciation map
 	// Priority: additional > segment > script
 	prons := make(map[string]string)
@@ -221,12 +359,29 @@ func (c *Compiler) applyPronunciations(text, language string, scriptProns, segme
 
 	// Apply substitutions (case-insensitive word boundary matching)
 	result := text
+	var phonemes []Phoneme
 	for term, replacement := range prons {
-		pattern := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(term) + `\b`)
+		pattern := termPattern(term)
+		if alphabet, ph, ok := ParsePhoneme(replacement); ok {
+			if pattern.MatchString(result) {
+				phonemes = append(phonemes, Phoneme{Term: term, Alphabet: alphabet, Ph: ph})
+			}
+			continue
+		}
 		result = pattern.ReplaceAllString(result, replacement)
 	}
 
-	return result
+	// Sort for deterministic output
+	sort.Slice(phonemes, func(i, j int) bool {
+		return phonemes[i].Term < phonemes[j].Term
+	})
+
+	return result, phonemes
+}
+
+// termPattern matches a pronunciation term case-insensitively on word boundaries.
+func termPattern(term string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(term) + `\b`)
 }
 
 // AddPronunciation adds a pronunciation rule.
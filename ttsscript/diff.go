@@ -0,0 +1,261 @@
+package ttsscript
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ChangeKind is the kind of change to a segment between script versions.
+type ChangeKind string
+
+const (
+	// ChangeAdded is a segment only in the new script.
+	ChangeAdded ChangeKind = "added"
+
+	// ChangeRemoved is a segment only in the old script.
+	ChangeRemoved ChangeKind = "removed"
+
+	// ChangeModified is a segment whose audio changes.
+	ChangeModified ChangeKind = "changed"
+
+	// ChangeMoved is a segment with the same audio at a new position.
+	ChangeMoved ChangeKind = "moved"
+)
+
+// Changed fields reported in SegmentChange.Fields.
+const (
+	FieldText     = "text"
+	FieldVoice    = "voice"
+	FieldSettings = "settings"
+	FieldModel    = "model"
+	FieldContext  = "context"
+	FieldAudio    = "audio"
+	FieldFormat   = "format"
+)
+
+// SegmentChange is a change to one segment between script versions.
+type SegmentChange struct {
+	// Kind is the kind of change.
+	Kind ChangeKind
+
+	// Old is the segment in the old script. Nil for added segments.
+	Old *ElevenLabsSegment
+
+	// New is the segment in the new script. Nil for removed segments.
+	New *ElevenLabsSegment
+
+	// Fields lists what changed for modified segments (e.g., FieldText).
+	Fields []string
+}
+
+// NeedsSynthesis reports whether the change requires generating audio.
+func (c SegmentChange) NeedsSynthesis() bool {
+	return c.Kind == ChangeAdded || c.Kind == ChangeModified
+}
+
+// String formats the change as one line of a change report.
+func (c SegmentChange) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %q", segmentLabel(c.New), truncateText(c.New.Text, 60))
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %q", segmentLabel(c.Old), truncateText(c.Old.Text, 60))
+	case ChangeMoved:
+		return fmt.Sprintf("> %s: moved from %s", segmentLabel(c.New), segmentLabel(c.Old))
+	default:
+		line := fmt.Sprintf("~ %s (%s)", segmentLabel(c.New), strings.Join(c.Fields, ", "))
+		if c.Old.Text != c.New.Text {
+			line += fmt.Sprintf(": %q -> %q", truncateText(c.Old.Text, 40), truncateText(c.New.Text, 40))
+		}
+		return line
+	}
+}
+
+// ScriptDiff is the difference between two versions of a script in one
+// language.
+type ScriptDiff struct {
+	// Language is the compared language.
+	Language string
+
+	// Changes are the changed segments in new script order, followed by
+	// removed segments in old script order.
+	Changes []SegmentChange
+
+	// Unchanged is the number of segments with identical audio at the same
+	// position.
+	Unchanged int
+}
+
+// HasChanges reports whether any segment changed.
+func (d *ScriptDiff) HasChanges() bool {
+	return len(d.Changes) > 0
+}
+
+// NeedsSynthesis returns the segments of the new script whose audio must
+// be generated: added and changed segments.
+func (d *ScriptDiff) NeedsSynthesis() []ElevenLabsSegment {
+	var segments []ElevenLabsSegment
+	for _, c := range d.Changes {
+		if c.NeedsSynthesis() {
+			segments = append(segments, *c.New)
+		}
+	}
+	return segments
+}
+
+// String formats the diff as a human-readable change report, one line per
+// change.
+func (d *ScriptDiff) String() string {
+	var sb strings.Builder
+	counts := make(map[ChangeKind]int)
+	for _, c := range d.Changes {
+		counts[c.Kind]++
+	}
+	fmt.Fprintf(&sb, "%s: %d added, %d changed, %d removed, %d moved, %d unchanged\n",
+		d.Language, counts[ChangeAdded], counts[ChangeModified], counts[ChangeRemoved], counts[ChangeMoved], d.Unchanged)
+	for _, c := range d.Changes {
+		sb.WriteString(c.String())
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// DiffScripts compares two versions of a script in a language, using the
+// default compiler and ElevenLabs formatter. See Compiler.Diff.
+func DiffScripts(oldScript, newScript *Script, language string) (*ScriptDiff, error) {
+	return NewCompiler().Diff(oldScript, newScript, language)
+}
+
+// Diff compiles two versions of a script in a language and compares the
+// segments, reporting those whose text, voice, settings, model, stitching
+// context, or pre-recorded audio changed. Use the compiler settings of the
+// batch run, such as MaxChars, so chunks match the generated files.
+//
+// Segments are matched by ID where they have one (see RenumberIndexes),
+// otherwise by slide and segment position. Title segments are matched by
+// slide.
+func (c *Compiler) Diff(oldScript, newScript *Script, language string) (*ScriptDiff, error) {
+	// Include audio tags, so changed emotions and tags are reported
+	formatter := NewElevenLabsFormatter()
+	formatter.UseAudioTags = true
+	compile := func(script *Script) ([]ElevenLabsSegment, []string, error) {
+		compiled, err := c.Compile(script, language)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys := make([]string, len(compiled))
+		for i, seg := range compiled {
+			keys[i] = diffKey(seg)
+		}
+		return formatter.Format(compiled), keys, nil
+	}
+
+	oldSegs, oldKeys, err := compile(oldScript)
+	if err != nil {
+		return nil, fmt.Errorf("compiling old script: %w", err)
+	}
+	newSegs, newKeys, err := compile(newScript)
+	if err != nil {
+		return nil, fmt.Errorf("compiling new script: %w", err)
+	}
+
+	oldByKey := make(map[string]int, len(oldKeys))
+	for i, key := range oldKeys {
+		oldByKey[key] = i
+	}
+
+	diff := &ScriptDiff{Language: language}
+	matched := make([]bool, len(oldSegs))
+	for i := range newSegs {
+		newSeg := &newSegs[i]
+		j, ok := oldByKey[newKeys[i]]
+		if !ok {
+			diff.Changes = append(diff.Changes, SegmentChange{Kind: ChangeAdded, New: newSeg})
+			continue
+		}
+		matched[j] = true
+		oldSeg := &oldSegs[j]
+
+		fields := changedFields(oldSeg, newSeg)
+		switch {
+		case len(fields) > 0:
+			diff.Changes = append(diff.Changes, SegmentChange{Kind: ChangeModified, Old: oldSeg, New: newSeg, Fields: fields})
+		case oldSeg.SlideIndex != newSeg.SlideIndex || oldSeg.SegmentIndex != newSeg.SegmentIndex:
+			diff.Changes = append(diff.Changes, SegmentChange{Kind: ChangeMoved, Old: oldSeg, New: newSeg})
+		default:
+			diff.Unchanged++
+		}
+	}
+	for j := range oldSegs {
+		if !matched[j] {
+			diff.Changes = append(diff.Changes, SegmentChange{Kind: ChangeRemoved, Old: &oldSegs[j]})
+		}
+	}
+	return diff, nil
+}
+
+// diffKey identifies a compiled segment across script versions.
+func diffKey(seg CompiledSegment) string {
+	slide := seg.SlideID
+	if slide == "" {
+		slide = fmt.Sprintf("#%d", seg.SlideIndex)
+	}
+	if seg.IsTitleSegment {
+		return "title/" + slide
+	}
+	segment := seg.SegmentID
+	if segment == "" {
+		segment = fmt.Sprintf("%s/#%d", slide, seg.SegmentIndex)
+	}
+	return fmt.Sprintf("%s/%d", segment, seg.ChunkIndex)
+}
+
+// changedFields lists the fields that affect the audio and differ.
+func changedFields(a, b *ElevenLabsSegment) []string {
+	var fields []string
+	// The text of an audio segment is a transcript and does not affect the audio
+	if a.Text != b.Text && (a.Audio == nil || b.Audio == nil) {
+		fields = append(fields, FieldText)
+	}
+	if a.VoiceID != b.VoiceID {
+		fields = append(fields, FieldVoice)
+	}
+	if !reflect.DeepEqual(a.Settings, b.Settings) {
+		fields = append(fields, FieldSettings)
+	}
+	if a.ModelID != b.ModelID {
+		fields = append(fields, FieldModel)
+	}
+	if a.PreviousText != b.PreviousText || a.NextText != b.NextText {
+		fields = append(fields, FieldContext)
+	}
+	if !reflect.DeepEqual(a.Audio, b.Audio) {
+		fields = append(fields, FieldAudio)
+	}
+	if a.OutputFormat != b.OutputFormat {
+		fields = append(fields, FieldFormat)
+	}
+	return fields
+}
+
+// segmentLabel describes a segment's position for change reports.
+func segmentLabel(seg *ElevenLabsSegment) string {
+	label := fmt.Sprintf("slide %d, segment %d", seg.SlideIndex+1, seg.SegmentIndex+1)
+	if seg.IsTitleSegment {
+		label = fmt.Sprintf("slide %d, title", seg.SlideIndex+1)
+	}
+	if seg.ChunkCount > 1 {
+		label += fmt.Sprintf(", part %d", seg.ChunkIndex+1)
+	}
+	return label
+}
+
+// truncateText shortens text to at most n runes, adding an ellipsis.
+func truncateText(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n-1]) + "…"
+}
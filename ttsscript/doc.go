@@ -73,6 +73,11 @@
 //   - Pause before/after
 //   - Prosody settings (rate, pitch, emphasis)
 //   - Segment-specific pronunciations
+//   - Eleven v3 audio tags (emotion, tags)
+//   - Output format override (also settable per slide)
+//
+// Segments of type SegmentTypeAudio insert pre-recorded audio, such as a
+// jingle or disclaimer, from a file or history item instead of text.
 //
 // # Compilation Process
 //
@@ -85,6 +90,7 @@
 //
 // SSMLFormatter: Outputs W3C SSML compatible with Google, Amazon, Azure
 // ElevenLabsFormatter: Outputs segments ready for ElevenLabs TTS API
+// SubtitleFormatter: Outputs SRT/WebVTT captions from segment durations
 //
 // # Pronunciation Handling
 //
@@ -95,4 +101,16 @@
 //
 // This allows overrides at any level. Terms are matched case-insensitively
 // with word boundaries.
+//
+// Pronunciations written as phonetic transcriptions ("ipa:...", "cmu:...",
+// or "x-sampa:...") are not substituted. The SSMLFormatter emits them as
+// phoneme tags instead.
+//
+// # Variables and Conditions
+//
+// Segment text and slide titles may contain {{name}} placeholders, and
+// segments may have a condition such as `variant == "pro"`. Values come
+// from Script.Variables, overridden by Compiler.Variables, so one script
+// can be compiled into several product-variant narrations. See
+// EvaluateCondition for the condition syntax.
 package ttsscript
@@ -0,0 +1,244 @@
+package ttsscript
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// FindSlide returns the index of the slide with the given ID.
+func (s *Script) FindSlide(id string) (int, bool) {
+	for i := range s.Slides {
+		if s.Slides[i].ID == id && id != "" {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// FindSegment returns the slide and segment indexes of the segment with
+// the given ID.
+func (s *Script) FindSegment(id string) (slide, segment int, ok bool) {
+	if id == "" {
+		return -1, -1, false
+	}
+	for i := range s.Slides {
+		for j := range s.Slides[i].Segments {
+			if s.Slides[i].Segments[j].ID == id {
+				return i, j, true
+			}
+		}
+	}
+	return -1, -1, false
+}
+
+// RenumberIndexes gives every slide and segment a unique ID, assigning new
+// IDs to those without one and to later duplicates. Existing unique IDs are
+// kept. The editing methods address slides and segments by ID, so
+// references held by an editor stay valid as indexes change; call
+// RenumberIndexes after loading a script written by hand or by another tool.
+func (s *Script) RenumberIndexes() {
+	seen := make(map[string]bool)
+	for i := range s.Slides {
+		slide := &s.Slides[i]
+		if slide.ID == "" || seen[slide.ID] {
+			slide.ID = newID("slide", seen)
+		}
+		seen[slide.ID] = true
+		for j := range slide.Segments {
+			seg := &slide.Segments[j]
+			if seg.ID == "" || seen[seg.ID] {
+				seg.ID = newID("seg", seen)
+			}
+			seen[seg.ID] = true
+		}
+	}
+}
+
+// InsertSlide inserts slide at index, from 0 to SlideCount, and returns
+// its ID. The slide and its segments are given IDs if they have none.
+func (s *Script) InsertSlide(index int, slide Slide) (string, error) {
+	if index < 0 || index > len(s.Slides) {
+		return "", fmt.Errorf("slide index %d out of range [0, %d]", index, len(s.Slides))
+	}
+	ids := s.ids()
+	if slide.ID != "" && ids[slide.ID] {
+		return "", fmt.Errorf("duplicate ID %q", slide.ID)
+	}
+	if slide.ID == "" {
+		slide.ID = newID("slide", ids)
+	}
+	ids[slide.ID] = true
+
+	// Copy the segments so the caller's slice is not shared
+	slide.Segments = append([]Segment(nil), slide.Segments...)
+	for j := range slide.Segments {
+		seg := &slide.Segments[j]
+		if seg.ID != "" && ids[seg.ID] {
+			return "", fmt.Errorf("duplicate ID %q", seg.ID)
+		}
+		if seg.ID == "" {
+			seg.ID = newID("seg", ids)
+		}
+		ids[seg.ID] = true
+	}
+
+	s.Slides = append(s.Slides, Slide{})
+	copy(s.Slides[index+1:], s.Slides[index:])
+	s.Slides[index] = slide
+	return slide.ID, nil
+}
+
+// MoveSlide moves the slide with the given ID to index, counted after the
+// slide is removed from its current position.
+func (s *Script) MoveSlide(id string, index int) error {
+	from, ok := s.FindSlide(id)
+	if !ok {
+		return fmt.Errorf("slide %q not found", id)
+	}
+	if index < 0 || index >= len(s.Slides) {
+		return fmt.Errorf("slide index %d out of range [0, %d)", index, len(s.Slides))
+	}
+	slide := s.Slides[from]
+	s.Slides = append(s.Slides[:from], s.Slides[from+1:]...)
+	s.Slides = append(s.Slides, Slide{})
+	copy(s.Slides[index+1:], s.Slides[index:])
+	s.Slides[index] = slide
+	return nil
+}
+
+// SplitSegment splits the segment with the given ID in two and returns the
+// ID of the new second segment. offsets gives the byte offset to split the
+// text of each language at; whitespace around the split is trimmed.
+// Languages without an offset keep their whole text in the first segment.
+//
+// The new segment inherits the voice, prosody, and settings of the
+// original. The pause before stays with the first segment and the pause
+// after moves to the second.
+func (s *Script) SplitSegment(id string, offsets map[string]int) (string, error) {
+	si, gi, ok := s.FindSegment(id)
+	if !ok {
+		return "", fmt.Errorf("segment %q not found", id)
+	}
+	first := s.Slides[si].Segments[gi]
+	if first.IsAudio() {
+		return "", fmt.Errorf("segment %q is an audio segment and cannot be split", id)
+	}
+	for lang, off := range offsets {
+		text, ok := first.Text[lang]
+		if !ok {
+			return "", fmt.Errorf("segment %q has no %s text", id, lang)
+		}
+		if off <= 0 || off >= len(text) || !utf8.RuneStart(text[off]) {
+			return "", fmt.Errorf("invalid split offset %d for %s text of length %d", off, lang, len(text))
+		}
+	}
+
+	second := first
+	second.ID = newID("seg", s.ids())
+	second.PauseBefore = ""
+	first.PauseAfter = ""
+	first.Text = make(map[string]string, len(first.Text))
+	second.Text = make(map[string]string, len(offsets))
+	for lang, text := range s.Slides[si].Segments[gi].Text {
+		off, ok := offsets[lang]
+		if !ok {
+			first.Text[lang] = text
+			continue
+		}
+		first.Text[lang] = strings.TrimSpace(text[:off])
+		second.Text[lang] = strings.TrimSpace(text[off:])
+	}
+
+	segments := s.Slides[si].Segments
+	segments = append(segments[:gi+1], append([]Segment{second}, segments[gi+1:]...)...)
+	segments[gi] = first
+	s.Slides[si].Segments = segments
+	return second.ID, nil
+}
+
+// MergeSegments merges the segment secondID into firstID, which must come
+// directly before it on the same slide. Texts are joined with a space per
+// language. The merged segment keeps the first segment's ID, voice,
+// prosody, and settings, and the second segment's pause after.
+// Pronunciations of both are kept, the first's taking precedence.
+func (s *Script) MergeSegments(firstID, secondID string) error {
+	si, gi, ok := s.FindSegment(firstID)
+	if !ok {
+		return fmt.Errorf("segment %q not found", firstID)
+	}
+	sj, gj, ok := s.FindSegment(secondID)
+	if !ok {
+		return fmt.Errorf("segment %q not found", secondID)
+	}
+	if si != sj || gj != gi+1 {
+		return fmt.Errorf("segment %q does not directly follow %q", secondID, firstID)
+	}
+
+	segments := s.Slides[si].Segments
+	first, second := segments[gi], segments[gj]
+	if first.IsAudio() || second.IsAudio() {
+		return fmt.Errorf("audio segments cannot be merged")
+	}
+	merged := first
+	merged.Text = make(map[string]string, len(first.Text)+len(second.Text))
+	for lang, text := range first.Text {
+		merged.Text[lang] = text
+	}
+	for lang, text := range second.Text {
+		if prev, ok := merged.Text[lang]; ok && prev != "" && text != "" {
+			text = prev + " " + text
+		} else if ok && text == "" {
+			text = prev
+		}
+		merged.Text[lang] = text
+	}
+	merged.PauseAfter = second.PauseAfter
+	if len(second.Pronunciations) > 0 {
+		merged.Pronunciations = make(map[string]map[string]string)
+		for _, prons := range []map[string]map[string]string{second.Pronunciations, first.Pronunciations} {
+			for term, langs := range prons {
+				if merged.Pronunciations[term] == nil {
+					merged.Pronunciations[term] = make(map[string]string)
+				}
+				for lang, replacement := range langs {
+					merged.Pronunciations[term][lang] = replacement
+				}
+			}
+		}
+	}
+
+	segments[gi] = merged
+	s.Slides[si].Segments = append(segments[:gj], segments[gj+1:]...)
+	return nil
+}
+
+// ids returns the set of slide and segment IDs in use.
+func (s *Script) ids() map[string]bool {
+	ids := make(map[string]bool)
+	for _, slide := range s.Slides {
+		if slide.ID != "" {
+			ids[slide.ID] = true
+		}
+		for _, seg := range slide.Segments {
+			if seg.ID != "" {
+				ids[seg.ID] = true
+			}
+		}
+	}
+	return ids
+}
+
+// newID returns a random ID with the given prefix that is not in used.
+func newID(prefix string, used map[string]bool) string {
+	for {
+		var b [4]byte
+		_, _ = rand.Read(b[:])
+		id := prefix + "-" + hex.EncodeToString(b[:])
+		if !used[id] {
+			return id
+		}
+	}
+}
@@ -13,6 +13,11 @@ type ElevenLabsFormatter struct {
 
 	// PauseMarkerFormat is the format for pause markers (default: "[pause:%s]").
 	PauseMarkerFormat string
+
+	// UseAudioTags prefixes text with the segment's audio tags (e.g., "[whispers]").
+	// Audio tags require the Eleven v3 model; other models read them aloud,
+	// so it is off by default. Enable it when generating with Eleven v3.
+	UseAudioTags bool
 }
 
 // NewElevenLabsFormatter creates a new ElevenLabs formatter.
@@ -20,6 +25,7 @@ func NewElevenLabsFormatter() *ElevenLabsFormatter {
 	return &ElevenLabsFormatter{
 		UsePauseMarkers:   false,
 		PauseMarkerFormat: "[pause:%s]",
+		UseAudioTags:      false,
 	}
 }
 
@@ -52,7 +58,37 @@ type ElevenLabsSegment struct {
 	// PauseAfterMs is silence to add after this segment.
 	PauseAfterMs int
 
-	// SuggestedFilename is a suggested output filename.
+	// Settings are the voice settings overrides for this segment (may be nil).
+	Settings *VoiceSettings
+
+	// ModelID is the TTS model for this segment's language. Empty to use
+	// the model chosen at generation time.
+	ModelID string
+
+	// ChunkIndex is the 0-based chunk index when a long segment was split.
+	ChunkIndex int
+
+	// ChunkCount is the number of chunks the segment was split into.
+	// Zero if the segment was not split.
+	ChunkCount int
+
+	// PreviousText is the text of the preceding chunk, passed to TTS for stitching.
+	PreviousText string
+
+	// NextText is the text of the following chunk, passed to TTS for stitching.
+	NextText string
+
+	// Audio is the pre-recorded audio of an audio segment. Write it to the
+	// output file instead of generating speech; Text is its transcript.
+	// Nil for segments to generate.
+	Audio *AudioAsset
+
+	// OutputFormat is the output format for this segment. Empty to use
+	// the format chosen at generation time.
+	OutputFormat string
+
+	// SuggestedFilename is a suggested output filename, with the extension
+	// of the output format (see FileExtension).
 	SuggestedFilename string
 }
 
@@ -63,8 +99,13 @@ func (f *ElevenLabsFormatter) Format(segments []CompiledSegment) []ElevenLabsSeg
 	for i, seg := range segments {
 		text := seg.Text
 
+		// Add audio tags if enabled
+		if f.UseAudioTags && seg.Audio == nil {
+			text = ApplyAudioTags(text, seg.AudioTags)
+		}
+
 		// Add pause markers if enabled
-		if f.UsePauseMarkers {
+		if f.UsePauseMarkers && seg.Audio == nil {
 			if seg.PauseBeforeMs > 0 {
 				marker := fmt.Sprintf(f.PauseMarkerFormat, FormatDuration(seg.PauseBeforeMs))
 				text = marker + " " + text
@@ -78,9 +119,14 @@ func (f *ElevenLabsFormatter) Format(segments []CompiledSegment) []ElevenLabsSeg
 		// Generate appropriate filename
 		var filename string
 		if seg.IsTitleSegment {
-			filename = fmt.Sprintf("slide%02d_title.mp3", seg.SlideIndex+1)
+			filename = fmt.Sprintf("slide%02d_title", seg.SlideIndex+1)
 		} else {
-			filename = fmt.Sprintf("slide%02d_seg%02d.mp3", seg.SlideIndex+1, seg.SegmentIndex+1)
+			filename = fmt.Sprintf("slide%02d_seg%02d%s", seg.SlideIndex+1, seg.SegmentIndex+1, chunkSuffix(seg.ChunkIndex, seg.ChunkCount))
+		}
+		if seg.Audio != nil {
+			filename += audioFileExtension(seg.Audio)
+		} else {
+			filename += FileExtension(seg.OutputFormat)
 		}
 
 		result[i] = ElevenLabsSegment{
@@ -93,6 +139,14 @@ func (f *ElevenLabsFormatter) Format(segments []CompiledSegment) []ElevenLabsSeg
 			IsSectionHeader:   seg.IsSectionHeader,
 			PauseBeforeMs:     seg.PauseBeforeMs,
 			PauseAfterMs:      seg.PauseAfterMs,
+			Settings:          seg.Settings,
+			ModelID:           seg.ModelID,
+			ChunkIndex:        seg.ChunkIndex,
+			ChunkCount:        seg.ChunkCount,
+			PreviousText:      seg.PreviousText,
+			NextText:          seg.NextText,
+			Audio:             seg.Audio,
+			OutputFormat:      seg.OutputFormat,
 			SuggestedFilename: filename,
 		}
 	}
@@ -133,24 +187,34 @@ func (f *ElevenLabsFormatter) GroupByVoice(segments []ElevenLabsSegment) map[str
 // TTSRequest represents a request to the ElevenLabs TTS API.
 // This is a simplified version for use with ttsscript.
 type TTSRequest struct {
-	VoiceID  string
-	Text     string
-	ModelID  string
-	Segment  ElevenLabsSegment
-	Language string
+	VoiceID      string
+	Text         string
+	ModelID      string
+	OutputFormat string
+	Settings     *VoiceSettings
+	Segment      ElevenLabsSegment
+	Language     string
 }
 
 // GenerateTTSRequests creates TTS requests from formatted segments.
+// modelID is used for segments without a per-language model. OutputFormat
+// is empty for segments without their own format. Audio segments are
+// skipped, as they are not generated.
 func GenerateTTSRequests(segments []ElevenLabsSegment, modelID, language string) []TTSRequest {
-	requests := make([]TTSRequest, len(segments))
-	for i, seg := range segments {
-		requests[i] = TTSRequest{
-			VoiceID:  seg.VoiceID,
-			Text:     seg.Text,
-			ModelID:  modelID,
-			Segment:  seg,
-			Language: language,
+	requests := make([]TTSRequest, 0, len(segments))
+	for _, seg := range segments {
+		if seg.Audio != nil {
+			continue
 		}
+		requests = append(requests, TTSRequest{
+			VoiceID:      seg.VoiceID,
+			Text:         seg.Text,
+			ModelID:      seg.model(modelID),
+			OutputFormat: seg.OutputFormat,
+			Settings:     seg.Settings,
+			Segment:      seg,
+			Language:     language,
+		})
 	}
 	return requests
 }
@@ -168,6 +232,16 @@ type BatchConfig struct {
 
 	// IncludeLanguageInFilename adds language code to filename.
 	IncludeLanguageInFilename bool
+
+	// ModelID is the TTS model used for generation, for segments without a
+	// per-language model. It is part of each segment's content hash, so
+	// changing the model regenerates all audio.
+	ModelID string
+
+	// OutputFormat is the output format for segments without their own,
+	// and the format that per-slide audio is assembled in. Empty for
+	// DefaultOutputFormat.
+	OutputFormat string
 }
 
 // NewBatchConfig creates a batch config with defaults.
@@ -180,13 +254,15 @@ func NewBatchConfig(outputDir string) *BatchConfig {
 	}
 }
 
-// GenerateFilename generates an output filename for a segment.
+// GenerateFilename generates an output filename for a segment. The
+// extension is that of the segment's output format (see FileExtension), or
+// of the asset file for audio segments.
 func (c *BatchConfig) GenerateFilename(seg ElevenLabsSegment, language string) string {
 	var name string
 	if seg.IsTitleSegment {
 		name = fmt.Sprintf("slide%02d_title", seg.SlideIndex+1)
 	} else {
-		name = fmt.Sprintf("slide%02d_seg%02d", seg.SlideIndex+1, seg.SegmentIndex+1)
+		name = fmt.Sprintf("slide%02d_seg%02d%s", seg.SlideIndex+1, seg.SegmentIndex+1, chunkSuffix(seg.ChunkIndex, seg.ChunkCount))
 	}
 
 	if c.FilePrefix != "" {
@@ -201,41 +277,93 @@ func (c *BatchConfig) GenerateFilename(seg ElevenLabsSegment, language string) s
 		name = name + "_" + c.FileSuffix
 	}
 
-	return fmt.Sprintf("%s/%s.mp3", c.OutputDir, name)
+	return fmt.Sprintf("%s/%s%s", c.OutputDir, name, c.extension(seg))
+}
+
+// extension returns the output file extension for a segment.
+func (c *BatchConfig) extension(seg ElevenLabsSegment) string {
+	if seg.Audio != nil {
+		return audioFileExtension(seg.Audio)
+	}
+	return FileExtension(seg.format(c.OutputFormat))
+}
+
+// chunkSuffix returns the filename suffix for a chunk of a split segment.
+func chunkSuffix(chunkIndex, chunkCount int) string {
+	if chunkCount <= 1 {
+		return ""
+	}
+	return fmt.Sprintf("_part%02d", chunkIndex+1)
 }
 
 // ManifestEntry represents an entry in a generation manifest.
 type ManifestEntry struct {
 	SlideIndex      int    `json:"slide_index"`
 	SegmentIndex    int    `json:"segment_index"`
+	ChunkIndex      int    `json:"chunk_index,omitempty"`
 	SlideTitle      string `json:"slide_title,omitempty"`
 	IsTitleSegment  bool   `json:"is_title_segment,omitempty"`
 	IsSectionHeader bool   `json:"is_section_header,omitempty"`
 	Text            string `json:"text"`
 	VoiceID         string `json:"voice_id"`
+	ModelID         string `json:"model_id,omitempty"`
 	Language        string `json:"language"`
 	OutputFile      string `json:"output_file"`
 	PauseBeforeMs   int    `json:"pause_before_ms,omitempty"`
 	PauseAfterMs    int    `json:"pause_after_ms,omitempty"`
+
+	// Audio is the pre-recorded audio of an audio segment, copied to
+	// OutputFile instead of generated.
+	Audio *AudioAsset `json:"audio,omitempty"`
+
+	// OutputFormat is the format of the generated audio in OutputFile.
+	// Empty for audio segments and for DefaultOutputFormat.
+	OutputFormat string `json:"output_format,omitempty"`
+
+	// TextHash is the SHA-256 hash of Text, for detecting content changes.
+	TextHash string `json:"text_hash,omitempty"`
+
+	// ContentHash identifies everything that affects the generated audio
+	// (text, voice, settings, stitching context, model, output format).
+	// See ElevenLabsSegment.ContentHash.
+	ContentHash string `json:"content_hash,omitempty"`
+
+	// DurationMs is the measured audio duration. Zero until the audio is generated.
+	DurationMs int `json:"duration_ms"`
+
+	// StartMs is the start of this segment's speech within the assembled
+	// track, including all preceding audio and pauses. See ComputeTimings.
+	StartMs int `json:"start_ms"`
 }
 
-// GenerateManifest creates a manifest of all segments for tracking.
-func GenerateManifest(segments []ElevenLabsSegment, config *BatchConfig, language string) []ManifestEntry {
+// GenerateManifest creates a manifest of all segments for tracking. It
+// fails if a segment cannot be hashed; see ElevenLabsSegment.ContentHash.
+func GenerateManifest(segments []ElevenLabsSegment, config *BatchConfig, language string) ([]ManifestEntry, error) {
 	entries := make([]ManifestEntry, len(segments))
 	for i, seg := range segments {
+		hash, err := seg.ContentHash(config.ModelID)
+		if err != nil {
+			return nil, fmt.Errorf("slide %d, segment %d: %w", seg.SlideIndex+1, seg.SegmentIndex+1, err)
+		}
 		entries[i] = ManifestEntry{
 			SlideIndex:      seg.SlideIndex,
 			SegmentIndex:    seg.SegmentIndex,
+			ChunkIndex:      seg.ChunkIndex,
 			SlideTitle:      seg.SlideTitle,
 			IsTitleSegment:  seg.IsTitleSegment,
 			IsSectionHeader: seg.IsSectionHeader,
 			Text:            seg.Text,
 			VoiceID:         seg.VoiceID,
+			ModelID:         seg.model(config.ModelID),
 			Language:        language,
 			OutputFile:      config.GenerateFilename(seg, language),
 			PauseBeforeMs:   seg.PauseBeforeMs,
 			PauseAfterMs:    seg.PauseAfterMs,
+			Audio:           seg.Audio,
+			OutputFormat:    seg.format(config.OutputFormat),
+			TextHash:        TextHash(seg.Text),
+			ContentHash:     hash,
 		}
 	}
-	return entries
+	return entries, nil
 }
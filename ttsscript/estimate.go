@@ -0,0 +1,170 @@
+package ttsscript
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	elevenlabs "github.com/agentplexus/go-elevenlabs"
+)
+
+// Pricing describes the cost of synthesis for budgeting.
+// ElevenLabs bills TTS in credits, one per character for most models.
+type Pricing struct {
+	// Tier is the subscription tier name (informational).
+	Tier string
+
+	// USDPer1000Credits is the dollar cost of 1,000 credits.
+	USDPer1000Credits float64
+
+	// CreditsPerCharacter is the number of credits billed per character
+	// (default: 1). Flash and Turbo models bill 0.5 credits per character.
+	CreditsPerCharacter float64
+}
+
+// PricingForTier returns the pricing for a subscription tier and model,
+// from elevenlabs.TierPricing and elevenlabs.ModelCreditsPerCharacter.
+func PricingForTier(tier, modelID string) (*Pricing, error) {
+	p, err := elevenlabs.NewPricing(tier)
+	if err != nil {
+		return nil, fmt.Errorf("unknown pricing tier: %s", tier)
+	}
+	return &Pricing{
+		Tier:                p.Tier,
+		USDPer1000Credits:   p.USDPer1000Credits,
+		CreditsPerCharacter: p.CreditsPerCharacter(modelID),
+	}, nil
+}
+
+// Cost returns the dollar cost of synthesizing the given number of characters.
+func (p *Pricing) Cost(characters int) float64 {
+	if p == nil {
+		return 0
+	}
+	creditsPerChar := p.CreditsPerCharacter
+	if creditsPerChar == 0 {
+		creditsPerChar = 1
+	}
+	return float64(characters) * creditsPerChar / 1000 * p.USDPer1000Credits
+}
+
+// SlideEstimate is the estimate for a single slide.
+type SlideEstimate struct {
+	// SlideIndex is the 0-based slide index.
+	SlideIndex int
+
+	// Title is the slide title.
+	Title string
+
+	// Characters is the number of billed characters, after pronunciations.
+	Characters int
+
+	// Words is the number of spoken words.
+	Words int
+
+	// SpeechMs is the estimated speaking time.
+	SpeechMs int
+
+	// PauseMs is the total of pauses before and after segments.
+	PauseMs int
+
+	// DurationMs is SpeechMs plus PauseMs.
+	DurationMs int
+
+	// CostUSD is the estimated cost. Zero if no pricing was given.
+	CostUSD float64
+}
+
+// Estimate is an estimated duration and cost report for a script.
+type Estimate struct {
+	// Language is the estimated language.
+	Language string
+
+	// WordsPerMinute is the speaking rate used for duration estimates.
+	WordsPerMinute int
+
+	// Pricing is the pricing used for cost estimates (may be nil).
+	Pricing *Pricing
+
+	// Slides contains per-slide estimates.
+	Slides []SlideEstimate
+
+	// Characters is the total number of billed characters.
+	Characters int
+
+	// Words is the total number of spoken words.
+	Words int
+
+	// SpeechMs is the total estimated speaking time.
+	SpeechMs int
+
+	// PauseMs is the total of all pauses.
+	PauseMs int
+
+	// DurationMs is the total estimated duration.
+	DurationMs int
+
+	// CostUSD is the total estimated cost. Zero if no pricing was given.
+	CostUSD float64
+}
+
+// Estimate estimates speech duration, character counts, and cost for a
+// language before synthesis. Characters are counted on compiled text,
+// including spoken titles and pronunciation substitutions, as billed.
+// Audio segments are not billed; their speech time is estimated from
+// their transcript.
+// wpm is the speaking rate (default: 150). pricing may be nil to skip
+// cost estimates.
+func (s *Script) Estimate(language string, wpm int, pricing *Pricing) (*Estimate, error) {
+	if wpm <= 0 {
+		wpm = 150
+	}
+
+	segments, err := NewCompiler().Compile(s, language)
+	if err != nil {
+		return nil, err
+	}
+
+	est := &Estimate{
+		Language:       language,
+		WordsPerMinute: wpm,
+		Pricing:        pricing,
+	}
+
+	slideIdx := make(map[int]int)
+	for _, seg := range segments {
+		i, ok := slideIdx[seg.SlideIndex]
+		if !ok {
+			i = len(est.Slides)
+			slideIdx[seg.SlideIndex] = i
+			est.Slides = append(est.Slides, SlideEstimate{
+				SlideIndex: seg.SlideIndex,
+				Title:      seg.SlideTitle,
+			})
+		}
+
+		slide := &est.Slides[i]
+		if seg.Audio == nil {
+			// Pre-recorded audio is not billed; its transcript approximates its length
+			slide.Characters += utf8.RuneCountInString(seg.Text)
+			slide.Words += len(strings.Fields(seg.Text))
+		}
+		slide.SpeechMs += estimateSpeechMs(seg.Text, wpm)
+		slide.PauseMs += seg.PauseBeforeMs + seg.PauseAfterMs
+	}
+
+	for i := range est.Slides {
+		slide := &est.Slides[i]
+		slide.DurationMs = slide.SpeechMs + slide.PauseMs
+		slide.CostUSD = pricing.Cost(slide.Characters)
+
+		est.Characters += slide.Characters
+		est.Words += slide.Words
+		est.SpeechMs += slide.SpeechMs
+		est.PauseMs += slide.PauseMs
+	}
+	est.DurationMs = est.SpeechMs + est.PauseMs
+	est.CostUSD = pricing.Cost(est.Characters)
+
+	return est, nil
+}
@@ -0,0 +1,129 @@
+package ttsscript
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ContentHash returns a hash of everything that affects the generated audio
+// for this segment: the text, the voice, the voice settings, the stitching
+// context, the model, and the segment's own output format. Two segments
+// with the same content hash produce equivalent audio, so regeneration can
+// be skipped. The segment's own ModelID, if set, takes precedence over
+// modelID.
+//
+// The hash of an audio segment covers only its asset reference, so
+// replacing an asset file under the same name is not detected.
+//
+// ContentHash fails if the voice settings are not finite numbers; see
+// VoiceSettings.Validate.
+func (s ElevenLabsSegment) ContentHash(modelID string) (string, error) {
+	if s.Audio != nil {
+		data, err := json.Marshal(struct {
+			Audio *AudioAsset `json:"audio"`
+		}{s.Audio})
+		if err != nil {
+			return "", fmt.Errorf("hashing segment content: %w", err)
+		}
+		return TextHash(string(data)), nil
+	}
+
+	data, err := json.Marshal(struct {
+		Text         string         `json:"text"`
+		VoiceID      string         `json:"voice_id"`
+		Settings     *VoiceSettings `json:"settings,omitempty"`
+		PreviousText string         `json:"previous_text,omitempty"`
+		NextText     string         `json:"next_text,omitempty"`
+		ModelID      string         `json:"model_id"`
+		OutputFormat string         `json:"output_format,omitempty"`
+	}{
+		Text:         s.Text,
+		VoiceID:      s.VoiceID,
+		Settings:     s.Settings,
+		PreviousText: s.PreviousText,
+		NextText:     s.NextText,
+		ModelID:      s.model(modelID),
+		OutputFormat: s.OutputFormat,
+	})
+	if err != nil {
+		return "", fmt.Errorf("hashing segment content: %w", err)
+	}
+	return TextHash(string(data)), nil
+}
+
+// model returns the segment's model, or defaultModel if it has none.
+func (s ElevenLabsSegment) model(defaultModel string) string {
+	if s.ModelID != "" {
+		return s.ModelID
+	}
+	return defaultModel
+}
+
+// format returns the segment's output format, or defaultFormat if it has
+// none. Audio segments have no output format.
+func (s ElevenLabsSegment) format(defaultFormat string) string {
+	if s.Audio != nil {
+		return ""
+	}
+	if s.OutputFormat != "" {
+		return s.OutputFormat
+	}
+	if defaultFormat == DefaultOutputFormat {
+		return ""
+	}
+	return defaultFormat
+}
+
+// LoadManifest loads a JSON manifest written by ManifestWriter or the
+// ttsscript command.
+func LoadManifest(filePath string) ([]ManifestEntry, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest file: %w", err)
+	}
+	var entries []ManifestEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing manifest JSON: %w", err)
+	}
+	return entries, nil
+}
+
+// ManifestIndex indexes the entries of a previous manifest by output file,
+// for incremental builds that skip segments whose audio is up to date.
+type ManifestIndex map[string]ManifestEntry
+
+// NewManifestIndex creates an index from previous manifest entries.
+func NewManifestIndex(entries []ManifestEntry) ManifestIndex {
+	idx := make(ManifestIndex, len(entries))
+	for _, e := range entries {
+		idx[e.OutputFile] = e
+	}
+	return idx
+}
+
+// MarkFailed clears the content hash and duration of an entry whose audio
+// could not be generated. Without this, a manifest written after the
+// failure would record the new content hash, and the next run would skip
+// the segment and keep the stale audio from an earlier run at OutputFile.
+func (e *ManifestEntry) MarkFailed() {
+	e.ContentHash = ""
+	e.DurationMs = 0
+}
+
+// Unchanged reports whether the audio for entry can be reused: the previous
+// manifest has an entry for the same output file with an identical content
+// hash and output format, and the output file still exists. The previous
+// entry is returned so measured values such as DurationMs can be carried
+// over.
+func (idx ManifestIndex) Unchanged(entry ManifestEntry) (ManifestEntry, bool) {
+	prev, ok := idx[entry.OutputFile]
+	if !ok || entry.ContentHash == "" || prev.ContentHash != entry.ContentHash || prev.OutputFormat != entry.OutputFormat {
+		return ManifestEntry{}, false
+	}
+	info, err := os.Stat(entry.OutputFile)
+	if err != nil || info.Size() == 0 {
+		return ManifestEntry{}, false
+	}
+	return prev, true
+}
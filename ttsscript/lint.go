@@ -0,0 +1,304 @@
+package ttsscript
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LintSeverity is the severity of a lint issue.
+type LintSeverity int
+
+const (
+	// SeverityInfo is an observation that may not need action.
+	SeverityInfo LintSeverity = iota
+
+	// SeverityWarning is a likely problem that does not prevent generation.
+	SeverityWarning
+
+	// SeverityError is a problem that causes generation to fail or produce wrong output.
+	SeverityError
+)
+
+// String returns the severity name.
+func (s LintSeverity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// Lint issue codes.
+const (
+	LintInvalid              = "invalid"
+	LintMissingTranslation   = "missing-translation"
+	LintMissingVoice         = "missing-voice"
+	LintUnusedPronunciation  = "unused-pronunciation"
+	LintUnusedVoice          = "unused-voice"
+	LintInvalidPause         = "invalid-pause"
+	LintPauseOutOfRange      = "pause-out-of-range"
+	LintDuplicateSlide       = "duplicate-slide"
+	LintSlideExceedsDuration = "slide-exceeds-duration"
+)
+
+// LintIssue is a single issue found by Lint.
+type LintIssue struct {
+	// Severity is the issue severity.
+	Severity LintSeverity
+
+	// Code identifies the kind of issue (e.g., LintMissingTranslation).
+	Code string
+
+	// Slide is the 1-based slide number, or 0 for script-level issues.
+	Slide int
+
+	// Segment is the 1-based segment number, or 0 for slide- or script-level issues.
+	Segment int
+
+	// Language is the language the issue applies to, if any.
+	Language string
+
+	// Message describes the issue.
+	Message string
+}
+
+// String formats the issue as "severity [code] message".
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s [%s] %s", i.Severity, i.Code, i.Message)
+}
+
+// LintOptions configures Lint.
+type LintOptions struct {
+	// Languages are the languages the script must support.
+	// Defaults to all languages used in the script.
+	Languages []string
+
+	// MaxPauseMs is the longest expected pause (default: 5000).
+	MaxPauseMs int
+
+	// WordsPerMinute is the speaking rate for duration estimates (default: 150).
+	WordsPerMinute int
+
+	// MaxSlideDurationMs flags slides whose estimated duration exceeds this
+	// value in the default language. Zero disables the check.
+	MaxSlideDurationMs int
+}
+
+// DefaultLintOptions returns lint options with default settings.
+func DefaultLintOptions() *LintOptions {
+	return &LintOptions{
+		MaxPauseMs:     5000,
+		WordsPerMinute: 150,
+	}
+}
+
+// Lint checks the script for problems beyond Validate, with severity levels.
+// Validate issues are reported as errors. If opts is nil, DefaultLintOptions
+// is used. Issues are ordered by slide, then segment.
+func (s *Script) Lint(opts *LintOptions) []LintIssue {
+	if opts == nil {
+		opts = DefaultLintOptions()
+	}
+	maxPause := opts.MaxPauseMs
+	if maxPause <= 0 {
+		maxPause = 5000
+	}
+	wpm := opts.WordsPerMinute
+	if wpm <= 0 {
+		wpm = 150
+	}
+	languages := append([]string(nil), opts.Languages...)
+	if len(languages) == 0 {
+		languages = s.Languages()
+	}
+	sort.Strings(languages)
+
+	var issues []LintIssue
+	add := func(severity LintSeverity, code string, slide, segment int, lang, format string, args ...any) {
+		issues = append(issues, LintIssue{
+			Severity: severity,
+			Code:     code,
+			Slide:    slide,
+			Segment:  segment,
+			Language: lang,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	for _, issue := range s.Validate() {
+		add(SeverityError, LintInvalid, 0, 0, "", "%s", issue)
+	}
+
+	// Voices for languages without any text
+	used := make(map[string]bool)
+	for _, lang := range s.Languages() {
+		used[lang] = true
+	}
+	for _, lang := range sortedKeys(s.DefaultVoices) {
+		if !used[lang] {
+			add(SeverityInfo, LintUnusedVoice, 0, 0, lang, "default voice for %s is not used by any segment", lang)
+		}
+	}
+
+	checkPause := func(slide, segment int, name, value string) {
+		if value == "" {
+			return
+		}
+		ms, ok := parsePause(value)
+		switch {
+		case !ok:
+			add(SeverityError, LintInvalidPause, slide, segment, "", "%s %q is not a valid duration (use e.g. \"500ms\" or \"1s\")", name, value)
+		case ms > maxPause:
+			add(SeverityWarning, LintPauseOutOfRange, slide, segment, "", "%s %s exceeds %s", name, value, FormatDuration(maxPause))
+		}
+	}
+
+	// Pronunciation terms that appear in any text, to detect unused entries
+	pronUsed := make(map[string]bool)
+	checkPronunciations := func(text string) {
+		for term := range s.Pronunciations {
+			if !pronUsed[term] && termPattern(term).MatchString(text) {
+				pronUsed[term] = true
+			}
+		}
+	}
+
+	slidesByContent := make(map[string]int)
+	for i, slide := range s.Slides {
+		slideNum := i + 1
+		checkPause(slideNum, 0, "title_pause_after", slide.TitlePauseAfter)
+		if slide.ShouldSpeakTitle() {
+			checkPronunciations(slide.Title)
+		}
+
+		var content []string
+		for j, seg := range slide.Segments {
+			segNum := j + 1
+			checkPause(slideNum, segNum, "pause_before", seg.PauseBefore)
+			checkPause(slideNum, segNum, "pause_after", seg.PauseAfter)
+
+			for _, lang := range languages {
+				if seg.IsAudio() {
+					if seg.AudioFor(lang) == nil {
+						add(SeverityWarning, LintMissingTranslation, slideNum, segNum, lang, "slide %d, segment %d has no %s audio", slideNum, segNum, lang)
+					}
+					continue
+				}
+				text, ok := seg.Text[lang]
+				if !ok || strings.TrimSpace(text) == "" {
+					add(SeverityWarning, LintMissingTranslation, slideNum, segNum, lang, "slide %d, segment %d has no %s text", slideNum, segNum, lang)
+					continue
+				}
+				if seg.Voice[lang] == "" && s.DefaultVoices[lang] == "" {
+					add(SeverityError, LintMissingVoice, slideNum, segNum, lang, "slide %d, segment %d has no %s voice and there is no default", slideNum, segNum, lang)
+				}
+			}
+
+			for _, text := range seg.Text {
+				checkPronunciations(text)
+			}
+			content = append(content, seg.Text[s.lintLanguage(languages)])
+		}
+
+		// Duplicate slides have identical text in the primary language
+		key := strings.Join(content, "\x00")
+		if strings.TrimSpace(strings.Join(content, "")) != "" {
+			if first, ok := slidesByContent[key]; ok {
+				add(SeverityWarning, LintDuplicateSlide, slideNum, 0, "", "slide %d duplicates slide %d", slideNum, first)
+			} else {
+				slidesByContent[key] = slideNum
+			}
+		}
+
+		if opts.MaxSlideDurationMs > 0 {
+			lang := s.lintLanguage(languages)
+			if est := estimateSlideMs(slide, lang, wpm); est > opts.MaxSlideDurationMs {
+				add(SeverityWarning, LintSlideExceedsDuration, slideNum, 0, lang, "slide %d is an estimated %s, exceeding %s", slideNum, FormatDuration(est), FormatDuration(opts.MaxSlideDurationMs))
+			}
+		}
+	}
+
+	for _, term := range sortedKeys(s.Pronunciations) {
+		if !pronUsed[term] {
+			add(SeverityInfo, LintUnusedPronunciation, 0, 0, "", "pronunciation for %q is never used", term)
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Slide != issues[j].Slide {
+			return issues[i].Slide < issues[j].Slide
+		}
+		return issues[i].Segment < issues[j].Segment
+	})
+
+	return issues
+}
+
+// lintLanguage returns the language used for content comparisons and
+// duration estimates: the default language if set, else the first language.
+func (s *Script) lintLanguage(languages []string) string {
+	if s.DefaultLanguage != "" {
+		return s.DefaultLanguage
+	}
+	if len(languages) > 0 {
+		return languages[0]
+	}
+	return ""
+}
+
+// estimateSpeechMs estimates the speaking duration of text at the given
+// words per minute.
+func estimateSpeechMs(text string, wpm int) int {
+	words := len(strings.Fields(text))
+	return words * 60000 / wpm
+}
+
+// estimateSlideMs estimates the duration of a slide, including the spoken
+// title and explicit pauses.
+func estimateSlideMs(slide Slide, language string, wpm int) int {
+	total := 0
+	if slide.ShouldSpeakTitle() {
+		total += estimateSpeechMs(slide.Title, wpm) + ParseDuration(slide.TitlePauseAfter)
+	}
+	for _, seg := range slide.Segments {
+		total += ParseDuration(seg.PauseBefore)
+		total += estimateSpeechMs(seg.Text[language], wpm)
+		total += ParseDuration(seg.PauseAfter)
+	}
+	return total
+}
+
+// parsePause parses a pause value, reporting whether it is a valid duration.
+func parsePause(value string) (int, bool) {
+	v := strings.TrimSpace(strings.ToLower(value))
+	switch {
+	case strings.HasSuffix(v, "ms"):
+		if ms, err := strconv.Atoi(strings.TrimSuffix(v, "ms")); err != nil || ms < 0 {
+			return 0, false
+		}
+	case strings.HasSuffix(v, "s"):
+		if sec, err := strconv.ParseFloat(strings.TrimSuffix(v, "s"), 64); err != nil || sec < 0 {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	return ParseDuration(value), true
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
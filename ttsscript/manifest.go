@@ -0,0 +1,186 @@
+package ttsscript
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ManifestWriter writes generation manifests as JSON or CSV.
+// Manifests map each slide/segment to its output file, voice, duration,
+// and start time within the assembled track, for video editors and QA.
+type ManifestWriter struct {
+	// Indent is the JSON indentation (default: two spaces).
+	Indent string
+}
+
+// NewManifestWriter creates a manifest writer with default settings.
+func NewManifestWriter() *ManifestWriter {
+	return &ManifestWriter{
+		Indent: "  ",
+	}
+}
+
+// manifestCSVHeader is the column order used by WriteCSV.
+var manifestCSVHeader = []string{
+	"slide_index",
+	"segment_index",
+	"chunk_index",
+	"slide_title",
+	"is_title_segment",
+	"is_section_header",
+	"language",
+	"voice_id",
+	"output_file",
+	"start_ms",
+	"duration_ms",
+	"pause_before_ms",
+	"pause_after_ms",
+	"text_hash",
+	"text",
+}
+
+// WriteJSON writes the manifest as an indented JSON array.
+// Start times are computed from durations and pauses before writing;
+// the passed entries are not modified.
+func (w *ManifestWriter) WriteJSON(out io.Writer, entries []ManifestEntry) error {
+	timed := withTimings(entries)
+	data, err := json.MarshalIndent(timed, "", w.Indent)
+	if err != nil {
+		return fmt.Errorf("marshaling manifest: %w", err)
+	}
+	if _, err := out.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV writes the manifest as CSV with a header row.
+// Start times are computed from durations and pauses before writing;
+// the passed entries are not modified.
+func (w *ManifestWriter) WriteCSV(out io.Writer, entries []ManifestEntry) error {
+	cw := csv.NewWriter(out)
+	if err := cw.Write(manifestCSVHeader); err != nil {
+		return fmt.Errorf("writing manifest header: %w", err)
+	}
+
+	for _, e := range withTimings(entries) {
+		record := []string{
+			strconv.Itoa(e.SlideIndex),
+			strconv.Itoa(e.SegmentIndex),
+			strconv.Itoa(e.ChunkIndex),
+			e.SlideTitle,
+			strconv.FormatBool(e.IsTitleSegment),
+			strconv.FormatBool(e.IsSectionHeader),
+			e.Language,
+			e.VoiceID,
+			e.OutputFile,
+			strconv.Itoa(e.StartMs),
+			strconv.Itoa(e.DurationMs),
+			strconv.Itoa(e.PauseBeforeMs),
+			strconv.Itoa(e.PauseAfterMs),
+			e.TextHash,
+			e.Text,
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("writing manifest row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSONFile writes the manifest as JSON to the given file path.
+func (w *ManifestWriter) WriteJSONFile(filePath string, entries []ManifestEntry) error {
+	return writeManifestFile(filePath, entries, w.WriteJSON)
+}
+
+// WriteCSVFile writes the manifest as CSV to the given file path.
+func (w *ManifestWriter) WriteCSVFile(filePath string, entries []ManifestEntry) error {
+	return writeManifestFile(filePath, entries, w.WriteCSV)
+}
+
+func writeManifestFile(filePath string, entries []ManifestEntry, write func(io.Writer, []ManifestEntry) error) error {
+	f, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("creating manifest file: %w", err)
+	}
+	if err := write(f, entries); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ComputeTimings sets StartMs on each entry from the durations and pauses of
+// the entries before it, assuming the audio is assembled in order.
+// Returns the total duration of the assembled track in milliseconds.
+func ComputeTimings(entries []ManifestEntry) int {
+	elapsed := 0
+	for i := range entries {
+		elapsed += entries[i].PauseBeforeMs
+		entries[i].StartMs = elapsed
+		elapsed += entries[i].DurationMs + entries[i].PauseAfterMs
+	}
+	return elapsed
+}
+
+// withTimings returns a copy of entries with start times computed.
+func withTimings(entries []ManifestEntry) []ManifestEntry {
+	timed := make([]ManifestEntry, len(entries))
+	copy(timed, entries)
+	ComputeTimings(timed)
+	return timed
+}
+
+// TextHash returns the hex-encoded SHA-256 hash of text.
+func TextHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// EstimateDurationMs estimates the audio duration of a generated file from
+// its size and ElevenLabs output format (e.g., "mp3_44100_128", "pcm_16000").
+// MP3 and Opus estimates assume constant bitrate. An empty format is treated
+// as the ElevenLabs default "mp3_44100_128". Returns 0 for unknown formats.
+func EstimateDurationMs(sizeBytes int64, outputFormat string) int {
+	if outputFormat == "" {
+		outputFormat = "mp3_44100_128"
+	}
+
+	parts := strings.Split(outputFormat, "_")
+	switch parts[0] {
+	case "mp3", "opus":
+		if len(parts) != 3 {
+			return 0
+		}
+		kbps, err := strconv.Atoi(parts[2])
+		if err != nil || kbps <= 0 {
+			return 0
+		}
+		// kbps is bits per millisecond
+		return int(sizeBytes * 8 / int64(kbps))
+	case "pcm", "ulaw", "alaw":
+		if len(parts) != 2 {
+			return 0
+		}
+		rate, err := strconv.Atoi(parts[1])
+		if err != nil || rate <= 0 {
+			return 0
+		}
+		bytesPerSample := int64(1)
+		if parts[0] == "pcm" {
+			bytesPerSample = 2 // 16-bit mono
+		}
+		return int(sizeBytes * 1000 / (bytesPerSample * int64(rate)))
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,135 @@
+package ttsscript
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultOutputFormat is the ElevenLabs output format used when neither the
+// batch nor the segment sets one.
+const DefaultOutputFormat = "mp3_44100_128"
+
+// outputFormat is a parsed ElevenLabs output format, such as
+// "mp3_44100_128" or "pcm_48000".
+type outputFormat struct {
+	codec   string
+	rate    int
+	bitrate int // kbps; zero for uncompressed codecs
+}
+
+// parseOutputFormat parses an ElevenLabs output format.
+func parseOutputFormat(format string) (outputFormat, error) {
+	parts := strings.Split(format, "_")
+	var f outputFormat
+	switch f.codec = parts[0]; f.codec {
+	case "mp3", "opus":
+		if len(parts) != 3 {
+			return f, fmt.Errorf("output format %q: want %s_<rate>_<kbps>", format, f.codec)
+		}
+		kbps, err := strconv.Atoi(parts[2])
+		if err != nil || kbps <= 0 {
+			return f, fmt.Errorf("output format %q: invalid bitrate", format)
+		}
+		f.bitrate = kbps
+	case "pcm", "ulaw", "alaw":
+		if len(parts) != 2 {
+			return f, fmt.Errorf("output format %q: want %s_<rate>", format, f.codec)
+		}
+	default:
+		return f, fmt.Errorf("output format %q: unknown codec %q", format, f.codec)
+	}
+	rate, err := strconv.Atoi(parts[1])
+	if err != nil || rate <= 0 {
+		return f, fmt.Errorf("output format %q: invalid sample rate", format)
+	}
+	f.rate = rate
+	return f, nil
+}
+
+// ValidateOutputFormat checks that format is an ElevenLabs output format,
+// such as "mp3_44100_128", "pcm_48000", or "ulaw_8000".
+func ValidateOutputFormat(format string) error {
+	_, err := parseOutputFormat(format)
+	return err
+}
+
+// FileExtension returns the file extension for audio in an ElevenLabs
+// output format. PCM audio is stored as WAV, so it gets ".wav"; wrap it
+// with elevenlabs.PCMToWAV before writing. An empty format is
+// DefaultOutputFormat.
+func FileExtension(format string) string {
+	if format == "" {
+		format = DefaultOutputFormat
+	}
+	switch codec, _, _ := strings.Cut(format, "_"); codec {
+	case "pcm":
+		return ".wav"
+	case "opus":
+		return ".opus"
+	case "ulaw":
+		return ".ulaw"
+	case "alaw":
+		return ".alaw"
+	default:
+		return ".mp3"
+	}
+}
+
+// FFmpegEncodeArgs returns the ffmpeg output arguments that encode mono
+// audio in an ElevenLabs output format, for converting segments to a
+// single format before assembly:
+//
+//	args, _ := ttsscript.FFmpegEncodeArgs("mp3_44100_128")
+//	// ffmpeg -i slide01_seg01_en.wav <args...> converted.mp3
+func FFmpegEncodeArgs(format string) ([]string, error) {
+	if format == "" {
+		format = DefaultOutputFormat
+	}
+	f, err := parseOutputFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"-ar", strconv.Itoa(f.rate), "-ac", "1"}
+	switch f.codec {
+	case "mp3":
+		args = append(args, "-c:a", "libmp3lame", "-b:a", fmt.Sprintf("%dk", f.bitrate))
+	case "opus":
+		args = append(args, "-c:a", "libopus", "-b:a", fmt.Sprintf("%dk", f.bitrate))
+	case "pcm":
+		args = append(args, "-c:a", "pcm_s16le", "-f", "wav")
+	case "ulaw":
+		args = append(args, "-c:a", "pcm_mulaw", "-f", "mulaw")
+	case "alaw":
+		args = append(args, "-c:a", "pcm_alaw", "-f", "alaw")
+	}
+	return args, nil
+}
+
+// NeedsConversion reports whether the audio of a manifest entry must be
+// converted to the target output format before it is assembled with
+// other entries. Pre-recorded audio is always converted, as its format is
+// not known.
+func (e *ManifestEntry) NeedsConversion(target string) bool {
+	if target == "" {
+		target = DefaultOutputFormat
+	}
+	if e.Audio != nil {
+		return true
+	}
+	format := e.OutputFormat
+	if format == "" {
+		format = DefaultOutputFormat
+	}
+	return format != target
+}
+
+// audioFileExtension returns the extension of an audio asset's file, or
+// ".mp3" for history items, whose audio is MP3.
+func audioFileExtension(a *AudioAsset) string {
+	if ext := filepath.Ext(a.File); ext != "" {
+		return strings.ToLower(ext)
+	}
+	return ".mp3"
+}
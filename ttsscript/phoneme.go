@@ -0,0 +1,42 @@
+package ttsscript
+
+import "strings"
+
+// Phoneme is a phonetic pronunciation for a term.
+type Phoneme struct {
+	// Term is the word or phrase the pronunciation applies to.
+	Term string
+
+	// Alphabet is the phonetic alphabet ("ipa", "cmu-arpabet", or "x-sampa").
+	Alphabet string
+
+	// Ph is the phonetic transcription.
+	Ph string
+}
+
+// phonemePrefixes maps pronunciation value prefixes to SSML phonetic alphabets.
+var phonemePrefixes = map[string]string{
+	"ipa":         "ipa",
+	"cmu":         "cmu-arpabet",
+	"cmu-arpabet": "cmu-arpabet",
+	"x-sampa":     "x-sampa",
+}
+
+// ParsePhoneme parses a pronunciation value written as a phonetic
+// transcription, such as "ipa:ˈtoʊmɑːtoʊ" or "cmu:T AH0 M EY1 T OW2".
+// Returns ok=false for plain text substitutions.
+func ParsePhoneme(value string) (alphabet, ph string, ok bool) {
+	prefix, rest, found := strings.Cut(value, ":")
+	if !found {
+		return "", "", false
+	}
+	alphabet, ok = phonemePrefixes[strings.ToLower(strings.TrimSpace(prefix))]
+	if !ok {
+		return "", "", false
+	}
+	ph = strings.TrimSpace(rest)
+	if ph == "" {
+		return "", "", false
+	}
+	return alphabet, ph, true
+}
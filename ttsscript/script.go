@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 )
 
 // Script represents a multilingual TTS script with slides/segments.
@@ -26,16 +27,36 @@ type Script struct {
 	// DefaultVoices maps language codes to default voice IDs.
 	DefaultVoices map[string]string `json:"default_voices,omitempty"`
 
+	// DefaultModels maps language codes to TTS model IDs, for languages
+	// that need a different model than the one used for generation.
+	DefaultModels map[string]string `json:"default_models,omitempty"`
+
+	// DefaultSettings maps language codes to voice settings, merged over
+	// VoiceSettings and under slide and segment settings. Multilingual
+	// voices often need different stability per language.
+	DefaultSettings map[string]*VoiceSettings `json:"default_settings,omitempty"`
+
 	// Pronunciations maps terms to their pronunciation by language.
 	// Example: {"ADK": {"en": "A D K", "es": "A D K"}}
 	Pronunciations map[string]map[string]string `json:"pronunciations,omitempty"`
 
+	// VoiceSettings are the default voice settings for all slides.
+	VoiceSettings *VoiceSettings `json:"voice_settings,omitempty"`
+
+	// Variables are default values for {{name}} placeholders and segment
+	// conditions. Compiler.Variables overrides them.
+	// Example: {"product": "Acme", "variant": "basic"}
+	Variables map[string]string `json:"variables,omitempty"`
+
 	// Slides contains the ordered list of slides/sections.
 	Slides []Slide `json:"slides"`
 }
 
 // Slide represents a slide or section of the script.
 type Slide struct {
+	// ID identifies the slide across edits (optional). See RenumberIndexes.
+	ID string `json:"id,omitempty"`
+
 	// Title is the slide title (optional).
 	Title string `json:"title,omitempty"`
 
@@ -58,16 +79,38 @@ type Slide struct {
 	// Defaults to "500ms" for section headers, "300ms" for regular slides.
 	TitlePauseAfter string `json:"title_pause_after,omitempty"`
 
+	// VoiceSettings overrides the script voice settings for this slide,
+	// including its spoken title.
+	VoiceSettings *VoiceSettings `json:"voice_settings,omitempty"`
+
+	// OutputFormat overrides the batch output format for this slide,
+	// including its spoken title (e.g., "pcm_48000" for a slide to be
+	// mastered). See ValidateOutputFormat.
+	OutputFormat string `json:"output_format,omitempty"`
+
 	// Segments are the audio segments for this slide.
 	Segments []Segment `json:"segments"`
 }
 
 // Segment represents a single audio segment within a slide.
 type Segment struct {
+	// ID identifies the segment across edits (optional). See RenumberIndexes.
+	ID string `json:"id,omitempty"`
+
+	// Type is SegmentTypeText (the default) or SegmentTypeAudio.
+	Type string `json:"type,omitempty"`
+
 	// Text contains the text content by language code.
 	// Example: {"en": "Hello world", "es": "Hola mundo"}
+	// For audio segments, Text is an optional transcript used for
+	// subtitles and manifests; it is not synthesized.
 	Text map[string]string `json:"text"`
 
+	// Audio is the pre-recorded audio of an audio segment by language
+	// code. The AllLanguages key applies to languages without their own
+	// entry. Example: {"*": {"file": "jingle.mp3"}}
+	Audio map[string]*AudioAsset `json:"audio,omitempty"`
+
 	// Voice overrides the default voice for this segment by language.
 	// Example: {"en": "voice-id-1", "es": "voice-id-2"}
 	Voice map[string]string `json:"voice,omitempty"`
@@ -89,6 +132,26 @@ type Segment struct {
 
 	// Pronunciations are segment-specific pronunciation overrides.
 	Pronunciations map[string]map[string]string `json:"pronunciations,omitempty"`
+
+	// VoiceSettings overrides the slide voice settings for this segment.
+	VoiceSettings *VoiceSettings `json:"voice_settings,omitempty"`
+
+	// Emotion is the emotion to deliver this segment with (e.g., "excited").
+	// Compiled to an Eleven v3 audio tag.
+	Emotion string `json:"emotion,omitempty"`
+
+	// Tags are additional Eleven v3 audio tags (e.g., ["whispers", "laughs"]).
+	// See SupportedAudioTags.
+	Tags []string `json:"tags,omitempty"`
+
+	// OutputFormat overrides the slide output format for this segment.
+	// Generated audio in different formats is converted to one format
+	// when assembled. Ignored for audio segments.
+	OutputFormat string `json:"output_format,omitempty"`
+
+	// Condition includes the segment only when it evaluates to true for
+	// the compile variables (e.g., `variant == "pro"`). See EvaluateCondition.
+	Condition string `json:"condition,omitempty"`
 }
 
 // LoadScript loads a script from a JSON file.
@@ -129,6 +192,11 @@ func (s *Script) Languages() []string {
 			for lang := range seg.Text {
 				langs[lang] = true
 			}
+			for lang := range seg.Audio {
+				if lang != AllLanguages {
+					langs[lang] = true
+				}
+			}
 		}
 	}
 	result := make([]string, 0, len(langs))
@@ -171,16 +239,110 @@ func (s *Script) Validate() []string {
 		issues = append(issues, "script has no slides")
 	}
 
+	for _, issue := range s.VoiceSettings.Validate() {
+		issues = append(issues, "script voice settings: "+issue)
+	}
+	langs := make([]string, 0, len(s.DefaultSettings))
+	for lang := range s.DefaultSettings {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	for _, lang := range langs {
+		for _, issue := range s.DefaultSettings[lang].Validate() {
+			issues = append(issues, fmt.Sprintf("default settings for %s: %s", lang, issue))
+		}
+	}
+
+	ids := make(map[string]bool)
+	checkID := func(id, where string) {
+		if id != "" && ids[id] {
+			issues = append(issues, fmt.Sprintf("%s has duplicate ID %q", where, id))
+		}
+		ids[id] = true
+	}
+
 	for i, slide := range s.Slides {
+		checkID(slide.ID, fmt.Sprintf("slide %d", i+1))
 		if len(slide.Segments) == 0 {
 			issues = append(issues, fmt.Sprintf("slide %d has no segments", i+1))
 		}
+		for _, issue := range slide.VoiceSettings.Validate() {
+			issues = append(issues, fmt.Sprintf("slide %d voice settings: %s", i+1, issue))
+		}
+		if slide.OutputFormat != "" {
+			if err := ValidateOutputFormat(slide.OutputFormat); err != nil {
+				issues = append(issues, fmt.Sprintf("slide %d has invalid %v", i+1, err))
+			}
+		}
 		for j, seg := range slide.Segments {
-			if len(seg.Text) == 0 {
-				issues = append(issues, fmt.Sprintf("slide %d, segment %d has no text", i+1, j+1))
+			checkID(seg.ID, fmt.Sprintf("slide %d, segment %d", i+1, j+1))
+			switch seg.Type {
+			case "", SegmentTypeText:
+				if len(seg.Text) == 0 {
+					issues = append(issues, fmt.Sprintf("slide %d, segment %d has no text", i+1, j+1))
+				}
+			case SegmentTypeAudio:
+				if len(seg.Audio) == 0 {
+					issues = append(issues, fmt.Sprintf("slide %d, segment %d has no audio", i+1, j+1))
+				}
+				audioLangs := make([]string, 0, len(seg.Audio))
+				for lang := range seg.Audio {
+					audioLangs = append(audioLangs, lang)
+				}
+				sort.Strings(audioLangs)
+				for _, lang := range audioLangs {
+					if issue := seg.Audio[lang].validate(); issue != "" {
+						issues = append(issues, fmt.Sprintf("slide %d, segment %d %s audio %s", i+1, j+1, lang, issue))
+					}
+				}
+			default:
+				issues = append(issues, fmt.Sprintf("slide %d, segment %d has unknown type %q", i+1, j+1, seg.Type))
+			}
+			for _, issue := range seg.VoiceSettings.Validate() {
+				issues = append(issues, fmt.Sprintf("slide %d, segment %d voice settings: %s", i+1, j+1, issue))
+			}
+			if seg.OutputFormat != "" {
+				if err := ValidateOutputFormat(seg.OutputFormat); err != nil {
+					issues = append(issues, fmt.Sprintf("slide %d, segment %d has invalid %v", i+1, j+1, err))
+				}
+			}
+			if _, err := parseCondition(seg.Condition); err != nil {
+				issues = append(issues, fmt.Sprintf("slide %d, segment %d has invalid condition: %v", i+1, j+1, err))
+			}
+			for _, tag := range seg.AudioTags() {
+				if !IsSupportedAudioTag(tag) {
+					issues = append(issues, fmt.Sprintf("slide %d, segment %d has unsupported audio tag %q", i+1, j+1, tag))
+				}
 			}
 		}
 	}
 
 	return issues
 }
+
+// Model returns the TTS model of a language: its entry in DefaultModels,
+// or defaultModel.
+func (s *Script) Model(language, defaultModel string) string {
+	if modelID := s.DefaultModels[language]; modelID != "" {
+		return modelID
+	}
+	return defaultModel
+}
+
+// ValidateModelLanguages checks that the model of each script language
+// supports it. The model of a language is its entry in DefaultModels, or
+// defaultModel (see Model). supports reports whether a model supports a
+// language; with the elevenlabs package, list the models once and look up
+// Model.SupportsLanguage.
+func (s *Script) ValidateModelLanguages(defaultModel string, supports func(modelID, language string) bool) []string {
+	langs := s.Languages()
+	sort.Strings(langs)
+	var issues []string
+	for _, lang := range langs {
+		modelID := s.Model(lang, defaultModel)
+		if !supports(modelID, lang) {
+			issues = append(issues, fmt.Sprintf("language %s is not supported by model %s", lang, modelID))
+		}
+	}
+	return issues
+}
@@ -2,6 +2,9 @@ package ttsscript
 
 import (
 	"fmt"
+	"math"
+	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -16,6 +19,14 @@ type SSMLFormatter struct {
 
 	// IndentSpaces is the number of spaces for indentation.
 	IndentSpaces int
+
+	// UsePhonemes wraps terms with phonetic pronunciations in phoneme tags.
+	// Off by default.
+	UsePhonemes bool
+
+	// AutoSayAs wraps whole numbers and ISO dates (YYYY-MM-DD) in say-as
+	// tags. Decimals are left as text. Off by default.
+	AutoSayAs bool
 }
 
 // NewSSMLFormatter creates a new SSML formatter with default settings.
@@ -27,6 +38,14 @@ func NewSSMLFormatter() *SSMLFormatter {
 	}
 }
 
+var (
+	// isoDatePattern matches ISO 8601 calendar dates.
+	isoDatePattern = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b`)
+
+	// numberPattern matches integers and decimals, with optional thousands separators.
+	numberPattern = regexp.MustCompile(`\b(?:\d{1,3}(?:,\d{3})+|\d+)(?:\.\d+)?\b`)
+)
+
 // Format formats compiled segments as SSML.
 func (f *SSMLFormatter) Format(segments []CompiledSegment, language string) string {
 	var sb strings.Builder
@@ -73,8 +92,23 @@ func (f *SSMLFormatter) Format(segments []CompiledSegment, language string) stri
 }
 
 // writeSegmentContent writes the segment content with prosody/emphasis wrappers.
+// Audio segments with a file are written as audio elements, with the
+// transcript as fallback text; other audio segments speak their transcript.
 func (f *SSMLFormatter) writeSegmentContent(sb *strings.Builder, seg CompiledSegment, indent string) {
-	hasProsody := seg.Rate != "" || seg.Pitch != ""
+	if seg.Audio != nil && seg.Audio.File != "" {
+		sb.WriteString(indent)
+		sb.WriteString(SSMLAudio(EscapeSSML(seg.Audio.File), EscapeSSML(seg.Text)))
+		sb.WriteString("\n")
+		return
+	}
+
+	rate := seg.Rate
+	if rate == "" && seg.Settings != nil && seg.Settings.Speed != nil && *seg.Settings.Speed != 1 {
+		// Express the voice speed as a relative rate
+		rate = fmt.Sprintf("%d%%", int(math.Round(*seg.Settings.Speed*100)))
+	}
+
+	hasProsody := rate != "" || seg.Pitch != ""
 	hasEmphasis := seg.Emphasis != ""
 
 	sb.WriteString(indent)
@@ -82,8 +116,8 @@ func (f *SSMLFormatter) writeSegmentContent(sb *strings.Builder, seg CompiledSeg
 	// Open prosody tag
 	if hasProsody {
 		sb.WriteString("<prosody")
-		if seg.Rate != "" {
-			sb.WriteString(fmt.Sprintf(` rate="%s"`, seg.Rate))
+		if rate != "" {
+			sb.WriteString(fmt.Sprintf(` rate="%s"`, rate))
 		}
 		if seg.Pitch != "" {
 			sb.WriteString(fmt.Sprintf(` pitch="%s"`, seg.Pitch))
@@ -97,7 +131,7 @@ func (f *SSMLFormatter) writeSegmentContent(sb *strings.Builder, seg CompiledSeg
 	}
 
 	// Write text content
-	sb.WriteString(EscapeSSML(seg.Text))
+	sb.WriteString(f.formatText(seg))
 
 	// Close emphasis tag
 	if hasEmphasis {
@@ -112,6 +146,74 @@ func (f *SSMLFormatter) writeSegmentContent(sb *strings.Builder, seg CompiledSeg
 	sb.WriteString("\n")
 }
 
+// ssmlSpan is a range of segment text replaced with SSML markup.
+type ssmlSpan struct {
+	start, end int
+	markup     string
+}
+
+// formatText escapes the segment text and adds phoneme and say-as markup.
+// Phonemes take precedence over dates, and dates over numbers.
+func (f *SSMLFormatter) formatText(seg CompiledSegment) string {
+	text := seg.Text
+	var spans []ssmlSpan
+
+	// addSpans records matches that do not overlap an existing span.
+	addSpans := func(matches [][]int, markup func(match string) string) {
+		for _, m := range matches {
+			overlaps := false
+			for _, s := range spans {
+				if m[0] < s.end && s.start < m[1] {
+					overlaps = true
+					break
+				}
+			}
+			if !overlaps {
+				spans = append(spans, ssmlSpan{start: m[0], end: m[1], markup: markup(text[m[0]:m[1]])})
+			}
+		}
+	}
+
+	if f.UsePhonemes {
+		for _, p := range seg.Phonemes {
+			addSpans(termPattern(p.Term).FindAllStringIndex(text, -1), func(match string) string {
+				return SSMLPhoneme(EscapeSSML(match), p.Alphabet, EscapeSSML(p.Ph))
+			})
+		}
+	}
+
+	if f.AutoSayAs {
+		addSpans(isoDatePattern.FindAllStringIndex(text, -1), func(match string) string {
+			return SSMLSayAs(match, "date", "ymd")
+		})
+		addSpans(numberPattern.FindAllStringIndex(text, -1), func(match string) string {
+			// Engines read "cardinal" decimals inconsistently
+			if strings.Contains(match, ".") {
+				return match
+			}
+			return SSMLSayAs(match, "cardinal", "")
+		})
+	}
+
+	if len(spans) == 0 {
+		return EscapeSSML(text)
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start
+	})
+
+	var sb strings.Builder
+	pos := 0
+	for _, s := range spans {
+		sb.WriteString(EscapeSSML(text[pos:s.start]))
+		sb.WriteString(s.markup)
+		pos = s.end
+	}
+	sb.WriteString(EscapeSSML(text[pos:]))
+	return sb.String()
+}
+
 // FormatScript compiles and formats a script as SSML.
 func (f *SSMLFormatter) FormatScript(script *Script, language string) (string, error) {
 	compiler := NewCompiler()
@@ -173,6 +275,15 @@ func SSMLPhoneme(text, alphabet, ph string) string {
 	return fmt.Sprintf(`<phoneme alphabet="%s" ph="%s">%s</phoneme>`, alphabet, ph, text)
 }
 
+// SSMLAudio inserts a recording, with fallback text spoken if it cannot
+// be played.
+func SSMLAudio(src, fallback string) string {
+	if fallback == "" {
+		return fmt.Sprintf(`<audio src="%s"/>`, src)
+	}
+	return fmt.Sprintf(`<audio src="%s">%s</audio>`, src, fallback)
+}
+
 // SSMLSub provides an alias for a word.
 func SSMLSub(text, alias string) string {
 	return fmt.Sprintf(`<sub alias="%s">%s</sub>`, alias, text)
@@ -0,0 +1,157 @@
+package ttsscript
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SubtitleFormatter formats compiled segments as SRT or WebVTT subtitles
+// aligned to the assembled audio track.
+type SubtitleFormatter struct {
+	// MaxCharsPerCue splits long segments into multiple cues at word
+	// boundaries, dividing the segment duration by character count.
+	// Zero disables splitting.
+	MaxCharsPerCue int
+}
+
+// NewSubtitleFormatter creates a subtitle formatter with default settings.
+func NewSubtitleFormatter() *SubtitleFormatter {
+	return &SubtitleFormatter{
+		MaxCharsPerCue: 84, // two lines of 42 characters
+	}
+}
+
+// SubtitleCue is a single timed caption.
+type SubtitleCue struct {
+	// Index is the 1-based cue number.
+	Index int
+
+	// StartMs is the cue start time in the assembled track.
+	StartMs int
+
+	// EndMs is the cue end time in the assembled track.
+	EndMs int
+
+	// Text is the caption text.
+	Text string
+}
+
+// Cues computes subtitle cues from compiled segments and their measured audio
+// durations in milliseconds. durationsMs must have one entry per segment.
+// Timing follows the assembled track: pauses before and after each segment
+// advance the clock but produce no cue. Captions use the original text,
+// before pronunciation substitutions. Segments with no duration are skipped.
+func (f *SubtitleFormatter) Cues(segments []CompiledSegment, durationsMs []int) ([]SubtitleCue, error) {
+	if len(segments) != len(durationsMs) {
+		return nil, fmt.Errorf("got %d durations for %d segments", len(durationsMs), len(segments))
+	}
+
+	var cues []SubtitleCue
+	elapsed := 0
+	for i, seg := range segments {
+		elapsed += seg.PauseBeforeMs
+		duration := durationsMs[i]
+
+		text := strings.Join(strings.Fields(seg.OriginalText), " ")
+		if text == "" {
+			text = strings.Join(strings.Fields(seg.Text), " ")
+		}
+
+		if duration > 0 && text != "" {
+			chunks := splitCueText(text, f.MaxCharsPerCue)
+			total := 0
+			for _, chunk := range chunks {
+				total += len(chunk)
+			}
+
+			start := elapsed
+			chars := 0
+			for j, chunk := range chunks {
+				chars += len(chunk)
+				end := elapsed + duration*chars/total
+				if j == len(chunks)-1 {
+					end = elapsed + duration
+				}
+				cues = append(cues, SubtitleCue{
+					Index:   len(cues) + 1,
+					StartMs: start,
+					EndMs:   end,
+					Text:    chunk,
+				})
+				start = end
+			}
+		}
+
+		elapsed += duration + seg.PauseAfterMs
+	}
+
+	return cues, nil
+}
+
+// FormatSRT formats segments as SubRip (SRT) subtitles.
+func (f *SubtitleFormatter) FormatSRT(segments []CompiledSegment, durationsMs []int) (string, error) {
+	cues, err := f.Cues(segments, durationsMs)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for _, cue := range cues {
+		sb.WriteString(fmt.Sprintf("%d\n%s --> %s\n%s\n\n",
+			cue.Index, formatTimestamp(cue.StartMs, ","), formatTimestamp(cue.EndMs, ","), cue.Text))
+	}
+	return sb.String(), nil
+}
+
+// FormatVTT formats segments as WebVTT subtitles.
+func (f *SubtitleFormatter) FormatVTT(segments []CompiledSegment, durationsMs []int) (string, error) {
+	cues, err := f.Cues(segments, durationsMs)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("WEBVTT\n\n")
+	for _, cue := range cues {
+		sb.WriteString(fmt.Sprintf("%d\n%s --> %s\n%s\n\n",
+			cue.Index, formatTimestamp(cue.StartMs, "."), formatTimestamp(cue.EndMs, "."), cue.Text))
+	}
+	return sb.String(), nil
+}
+
+// formatTimestamp formats milliseconds as HH:MM:SS followed by the
+// millisecond separator ("," for SRT, "." for WebVTT) and milliseconds.
+func formatTimestamp(ms int, sep string) string {
+	hours := ms / 3600000
+	ms %= 3600000
+	minutes := ms / 60000
+	ms %= 60000
+	seconds := ms / 1000
+	ms %= 1000
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", hours, minutes, seconds, sep, ms)
+}
+
+// splitCueText splits text into chunks of at most maxChars at word
+// boundaries. Words longer than maxChars get their own chunk.
+func splitCueText(text string, maxChars int) []string {
+	if maxChars <= 0 || len(text) <= maxChars {
+		return []string{text}
+	}
+
+	var chunks []string
+	var current strings.Builder
+	for _, word := range strings.Fields(text) {
+		if current.Len() > 0 && current.Len()+1+len(word) > maxChars {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		if current.Len() > 0 {
+			current.WriteString(" ")
+		}
+		current.WriteString(word)
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
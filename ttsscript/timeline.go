@@ -0,0 +1,192 @@
+package ttsscript
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Timeline places slides and their narration clips on the assembled audio
+// track, for muxing narration with slide images in a video editor or
+// ffmpeg.
+type Timeline struct {
+	// Language is the narration language.
+	Language string `json:"language,omitempty"`
+
+	// DurationMs is the total track duration, including pauses.
+	DurationMs int `json:"duration_ms"`
+
+	// Slides are the slides in track order.
+	Slides []TimelineSlide `json:"slides"`
+}
+
+// TimelineSlide is a slide's span on the track. A slide starts with the
+// pause before its first clip and lasts until the next slide starts.
+type TimelineSlide struct {
+	// SlideIndex is the 0-based slide index in the script.
+	SlideIndex int `json:"slide_index"`
+
+	// Title is the slide title.
+	Title string `json:"title,omitempty"`
+
+	// StartMs is when the slide appears.
+	StartMs int `json:"start_ms"`
+
+	// EndMs is when the next slide appears, or the end of the track.
+	EndMs int `json:"end_ms"`
+
+	// Clips are the narration clips of the slide.
+	Clips []TimelineClip `json:"clips"`
+}
+
+// DurationMs returns how long the slide is shown.
+func (s TimelineSlide) DurationMs() int {
+	return s.EndMs - s.StartMs
+}
+
+// TimelineClip is one generated audio file on the track.
+type TimelineClip struct {
+	// File is the audio file.
+	File string `json:"file"`
+
+	// SegmentIndex is the 0-based segment index, or -1 for a title.
+	SegmentIndex int `json:"segment_index"`
+
+	// ChunkIndex is the 0-based chunk index of a split segment.
+	ChunkIndex int `json:"chunk_index,omitempty"`
+
+	// StartMs is when the speech starts.
+	StartMs int `json:"start_ms"`
+
+	// DurationMs is the audio duration.
+	DurationMs int `json:"duration_ms"`
+
+	// Text is the spoken text.
+	Text string `json:"text,omitempty"`
+}
+
+// NewTimeline builds a timeline from manifest entries in track order.
+// Durations should be measured, either from the generated files or from
+// the end time of the last character in the TTS alignment; entries
+// without a duration occupy only their pauses.
+func NewTimeline(entries []ManifestEntry) *Timeline {
+	timed := withTimings(entries)
+	t := &Timeline{}
+	elapsed := 0
+	for _, e := range timed {
+		if t.Language == "" {
+			t.Language = e.Language
+		}
+		if n := len(t.Slides); n == 0 || t.Slides[n-1].SlideIndex != e.SlideIndex {
+			if n > 0 {
+				t.Slides[n-1].EndMs = elapsed
+			}
+			t.Slides = append(t.Slides, TimelineSlide{
+				SlideIndex: e.SlideIndex,
+				Title:      e.SlideTitle,
+				StartMs:    elapsed,
+			})
+		}
+		slide := &t.Slides[len(t.Slides)-1]
+		slide.Clips = append(slide.Clips, TimelineClip{
+			File:         e.OutputFile,
+			SegmentIndex: e.SegmentIndex,
+			ChunkIndex:   e.ChunkIndex,
+			StartMs:      e.StartMs,
+			DurationMs:   e.DurationMs,
+			Text:         e.Text,
+		})
+		elapsed = e.StartMs + e.DurationMs + e.PauseAfterMs
+	}
+	if n := len(t.Slides); n > 0 {
+		t.Slides[n-1].EndMs = elapsed
+	}
+	t.DurationMs = elapsed
+	return t
+}
+
+// WriteJSON writes the timeline as indented JSON.
+func (t *Timeline) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling timeline: %w", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("writing timeline: %w", err)
+	}
+	return nil
+}
+
+// WriteEDL writes the narration clips as a CMX 3600 edit decision list at
+// fps frames per second (default: 30), with a comment marking each slide.
+// Editors that import EDLs place each clip at its start time on an audio
+// track.
+func (t *Timeline) WriteEDL(w io.Writer, title string, fps int) error {
+	if fps <= 0 {
+		fps = 30
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "TITLE: %s\nFCM: NON-DROP FRAME\n\n", title)
+	event := 0
+	for _, slide := range t.Slides {
+		marked := false
+		for _, clip := range slide.Clips {
+			if clip.DurationMs <= 0 {
+				continue
+			}
+			event++
+			if event > 999 {
+				return fmt.Errorf("timeline has more than 999 clips")
+			}
+			fmt.Fprintf(&sb, "%03d  AX       AA       C        %s %s %s %s\n", event,
+				timecode(0, fps), timecode(clip.DurationMs, fps),
+				timecode(clip.StartMs, fps), timecode(clip.StartMs+clip.DurationMs, fps))
+			fmt.Fprintf(&sb, "* FROM CLIP NAME: %s\n", filepath.Base(clip.File))
+			if !marked {
+				fmt.Fprintf(&sb, "* COMMENT: SLIDE %d %s\n", slide.SlideIndex+1, slide.Title)
+				marked = true
+			}
+			sb.WriteByte('\n')
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteFFmpegConcat writes an ffmpeg concat demuxer script that shows one
+// image per slide for the slide's duration. image returns the image file
+// for a 0-based slide index. Mux it with the assembled narration:
+//
+//	ffmpeg -f concat -safe 0 -i slides.ffconcat -i narration.mp3 \
+//	    -c:v libx264 -pix_fmt yuv420p -r 30 -c:a aac -shortest video.mp4
+func (t *Timeline) WriteFFmpegConcat(w io.Writer, image func(slideIndex int) string) error {
+	var sb strings.Builder
+	sb.WriteString("ffconcat version 1.0\n")
+	last := ""
+	for _, slide := range t.Slides {
+		last = image(slide.SlideIndex)
+		fmt.Fprintf(&sb, "file '%s'\nduration %.3f\n", escapeConcatPath(last), float64(slide.DurationMs())/1000)
+	}
+	// The concat demuxer ignores the duration of the last entry unless the
+	// file is repeated
+	if last != "" {
+		fmt.Fprintf(&sb, "file '%s'\n", escapeConcatPath(last))
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// escapeConcatPath escapes single quotes for ffmpeg concat scripts.
+func escapeConcatPath(path string) string {
+	return strings.ReplaceAll(path, "'", `'\''`)
+}
+
+// timecode formats milliseconds as an HH:MM:SS:FF timecode.
+func timecode(ms, fps int) string {
+	frames := (ms*fps + 500) / 1000
+	f := frames % fps
+	s := frames / fps
+	return fmt.Sprintf("%02d:%02d:%02d:%02d", s/3600, s/60%60, s%60, f)
+}
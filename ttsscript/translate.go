@@ -0,0 +1,91 @@
+package ttsscript
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TranslatorFunc translates text from one language to another.
+// Implementations can call a machine translation service or return
+// placeholder text for human translators.
+type TranslatorFunc func(text, from, to string) (string, error)
+
+// PlaceholderTranslator marks source text for translation instead of
+// translating it, e.g. "TODO(es): Hello world".
+func PlaceholderTranslator(text, from, to string) (string, error) {
+	return fmt.Sprintf("TODO(%s): %s", to, text), nil
+}
+
+// CloneLanguage adds a new language to the script by translating every
+// segment's text in the source language. Voices, title voices, and
+// pronunciations for the source language are copied to the new language
+// where the new language has none. Existing text in the target language is
+// kept. Pauses and other settings are language-independent and unchanged.
+//
+// If translate is nil, PlaceholderTranslator is used. The script is only
+// modified if all translations succeed.
+func (s *Script) CloneLanguage(from, to string, translate TranslatorFunc) error {
+	if from == "" || to == "" {
+		return fmt.Errorf("source and target languages are required")
+	}
+	if strings.EqualFold(from, to) {
+		return fmt.Errorf("source and target languages are the same: %s", from)
+	}
+	if translate == nil {
+		translate = PlaceholderTranslator
+	}
+
+	// Translate first so a failure leaves the script unchanged
+	translations := make(map[[2]int]string)
+	for i, slide := range s.Slides {
+		for j, seg := range slide.Segments {
+			text, ok := seg.Text[from]
+			if !ok {
+				continue
+			}
+			if _, exists := seg.Text[to]; exists {
+				continue
+			}
+			translated, err := translate(text, from, to)
+			if err != nil {
+				return fmt.Errorf("translating slide %d, segment %d: %w", i+1, j+1, err)
+			}
+			translations[[2]int{i, j}] = translated
+		}
+	}
+
+	cloneLanguageEntry(s.DefaultVoices, from, to)
+	for _, langMap := range s.Pronunciations {
+		cloneLanguageEntry(langMap, from, to)
+	}
+
+	for i := range s.Slides {
+		slide := &s.Slides[i]
+		cloneLanguageEntry(slide.TitleVoice, from, to)
+
+		for j := range slide.Segments {
+			seg := &slide.Segments[j]
+			if translated, ok := translations[[2]int{i, j}]; ok {
+				seg.Text[to] = translated
+			}
+			cloneLanguageEntry(seg.Voice, from, to)
+			for _, langMap := range seg.Pronunciations {
+				cloneLanguageEntry(langMap, from, to)
+			}
+		}
+	}
+
+	return nil
+}
+
+// cloneLanguageEntry copies the value for language from to language to,
+// unless to already has a value.
+func cloneLanguageEntry(m map[string]string, from, to string) {
+	value, ok := m[from]
+	if !ok {
+		return
+	}
+	if _, exists := m[to]; !exists {
+		m[to] = value
+	}
+}
@@ -1,6 +1,10 @@
 package ttsscript
 
 import (
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -642,3 +646,1513 @@ func TestBatchConfigGenerateFilename(t *testing.T) {
 		})
 	}
 }
+
+func TestComputeTimings(t *testing.T) {
+	entries := []ManifestEntry{
+		{DurationMs: 1000, PauseAfterMs: 500},
+		{DurationMs: 2000, PauseBeforeMs: 1000},
+		{DurationMs: 500},
+	}
+
+	total := ComputeTimings(entries)
+
+	expectedStarts := []int{0, 2500, 4500}
+	for i, want := range expectedStarts {
+		if entries[i].StartMs != want {
+			t.Errorf("entry %d: expected start %dms, got %dms", i, want, entries[i].StartMs)
+		}
+	}
+	if total != 5000 {
+		t.Errorf("expected total 5000ms, got %dms", total)
+	}
+}
+
+func TestManifestWriter(t *testing.T) {
+	entries := []ManifestEntry{
+		{SlideIndex: 0, SegmentIndex: 0, Text: "Hello, world", VoiceID: "voice-1", Language: "en", OutputFile: "a.mp3", DurationMs: 1000, PauseAfterMs: 200},
+		{SlideIndex: 0, SegmentIndex: 1, Text: "Second", VoiceID: "voice-1", Language: "en", OutputFile: "b.mp3", DurationMs: 800},
+	}
+
+	writer := NewManifestWriter()
+
+	var jsonOut strings.Builder
+	if err := writer.WriteJSON(&jsonOut, entries); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	if !strings.Contains(jsonOut.String(), `"start_ms": 1200`) {
+		t.Errorf("JSON manifest should contain cumulative start time, got:\n%s", jsonOut.String())
+	}
+
+	var csvOut strings.Builder
+	if err := writer.WriteCSV(&csvOut, entries); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 CSV lines, got %d", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "slide_index,segment_index") {
+		t.Errorf("unexpected CSV header: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"Hello, world"`) {
+		t.Errorf("CSV text should be quoted, got: %s", lines[1])
+	}
+
+	// Writers must not modify the input
+	if entries[1].StartMs != 0 {
+		t.Error("WriteJSON/WriteCSV should not modify entries")
+	}
+}
+
+func TestEstimateDurationMs(t *testing.T) {
+	tests := []struct {
+		size     int64
+		format   string
+		expected int
+	}{
+		{16000, "", 1000}, // mp3_44100_128: 16KB per second
+		{16000, "mp3_44100_128", 1000},
+		{24000, "mp3_44100_192", 1000},
+		{32000, "pcm_16000", 1000},
+		{8000, "ulaw_8000", 1000},
+		{1000, "unknown", 0},
+	}
+
+	for _, tt := range tests {
+		result := EstimateDurationMs(tt.size, tt.format)
+		if result != tt.expected {
+			t.Errorf("EstimateDurationMs(%d, %q) = %d, expected %d", tt.size, tt.format, result, tt.expected)
+		}
+	}
+}
+
+// contentHash returns the content hash of seg, failing the test on error.
+func contentHash(t *testing.T, seg ElevenLabsSegment, modelID string) string {
+	t.Helper()
+	hash, err := seg.ContentHash(modelID)
+	if err != nil {
+		t.Fatalf("ContentHash() error = %v", err)
+	}
+	return hash
+}
+
+// generateManifest calls GenerateManifest, failing the test on error.
+func generateManifest(t *testing.T, segments []ElevenLabsSegment, config *BatchConfig, language string) []ManifestEntry {
+	t.Helper()
+	entries, err := GenerateManifest(segments, config, language)
+	if err != nil {
+		t.Fatalf("GenerateManifest() error = %v", err)
+	}
+	return entries
+}
+
+func TestContentHash(t *testing.T) {
+	seg := ElevenLabsSegment{Text: "Hello", VoiceID: "voice-1"}
+
+	base := contentHash(t, seg, "model-1")
+	if base != contentHash(t, seg, "model-1") {
+		t.Error("content hash should be deterministic")
+	}
+	if base == contentHash(t, seg, "model-2") {
+		t.Error("content hash should change with the model")
+	}
+
+	changedVoice := seg
+	changedVoice.VoiceID = "voice-2"
+	if base == contentHash(t, changedVoice, "model-1") {
+		t.Error("content hash should change with the voice")
+	}
+
+	changedText := seg
+	changedText.Text = "Hello!"
+	if base == contentHash(t, changedText, "model-1") {
+		t.Error("content hash should change with the text")
+	}
+
+	speed := 1.2
+	changedSettings := seg
+	changedSettings.Settings = &VoiceSettings{Speed: &speed}
+	if base == contentHash(t, changedSettings, "model-1") {
+		t.Error("content hash should change with the voice settings")
+	}
+
+	nan := math.NaN()
+	invalid := seg
+	invalid.Settings = &VoiceSettings{Speed: &nan}
+	if _, err := invalid.ContentHash("model-1"); err == nil {
+		t.Error("ContentHash() error = nil for NaN settings")
+	}
+	if _, err := GenerateManifest([]ElevenLabsSegment{invalid}, NewBatchConfig("out"), "en"); err == nil {
+		t.Error("GenerateManifest() error = nil for NaN settings")
+	}
+}
+
+func TestManifestIndexUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "slide01_seg01_en.mp3")
+	if err := os.WriteFile(existing, []byte("audio"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := NewManifestIndex([]ManifestEntry{
+		{OutputFile: existing, ContentHash: "abc", DurationMs: 1200},
+		{OutputFile: filepath.Join(dir, "missing.mp3"), ContentHash: "def"},
+	})
+
+	prev, ok := previous.Unchanged(ManifestEntry{OutputFile: existing, ContentHash: "abc"})
+	if !ok {
+		t.Fatal("expected existing file with matching hash to be unchanged")
+	}
+	if prev.DurationMs != 1200 {
+		t.Errorf("expected previous duration 1200ms, got %dms", prev.DurationMs)
+	}
+
+	if _, ok := previous.Unchanged(ManifestEntry{OutputFile: existing, ContentHash: "xyz"}); ok {
+		t.Error("expected changed hash to require regeneration")
+	}
+	if _, ok := previous.Unchanged(ManifestEntry{OutputFile: filepath.Join(dir, "missing.mp3"), ContentHash: "def"}); ok {
+		t.Error("expected missing output file to require regeneration")
+	}
+}
+
+func TestManifestFailedSegmentRerun(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "slide01_seg01_en.mp3")
+	manifestPath := filepath.Join(dir, "manifest_en.json")
+
+	// The first run generated the audio for the old text
+	if err := os.WriteFile(output, []byte("old audio"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	writer := NewManifestWriter()
+	if err := writer.WriteJSONFile(manifestPath, []ManifestEntry{{OutputFile: output, ContentHash: "old", DurationMs: 900}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second run changes the text, and generation fails
+	entry := ManifestEntry{OutputFile: output, ContentHash: "new"}
+	entries, err := LoadManifest(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := NewManifestIndex(entries).Unchanged(entry); ok {
+		t.Fatal("changed segment reported unchanged")
+	}
+	failed := entry
+	failed.MarkFailed()
+	if err := writer.WriteJSONFile(manifestPath, []ManifestEntry{failed}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The third run must not reuse the old audio
+	entries, err = LoadManifest(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := NewManifestIndex(entries).Unchanged(entry); ok {
+		t.Error("segment that failed to generate reported unchanged on rerun")
+	}
+}
+
+func TestCompileVoiceSettings(t *testing.T) {
+	stability, style, speed := 0.3, 0.6, 1.1
+	speakTitle := true
+
+	script := &Script{
+		Title:           "Settings",
+		DefaultLanguage: "en",
+		DefaultVoices:   map[string]string{"en": "voice-en"},
+		VoiceSettings:   &VoiceSettings{Stability: &stability},
+		Slides: []Slide{
+			{
+				Title:         "Slide",
+				SpeakTitle:    &speakTitle,
+				VoiceSettings: &VoiceSettings{Style: &style},
+				Segments: []Segment{
+					{Text: map[string]string{"en": "Inherited"}},
+					{
+						Text:          map[string]string{"en": "Overridden"},
+						VoiceSettings: &VoiceSettings{Speed: &speed},
+					},
+				},
+			},
+		},
+	}
+
+	segments, err := NewCompiler().Compile(script, "en")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if len(segments) != 3 {
+		t.Fatalf("expected 3 segments, got %d", len(segments))
+	}
+
+	for _, seg := range segments {
+		if seg.Settings == nil {
+			t.Fatalf("segment %q has no settings", seg.Text)
+		}
+		if seg.Settings.Stability == nil || *seg.Settings.Stability != stability {
+			t.Errorf("segment %q: expected script stability", seg.Text)
+		}
+		if seg.Settings.Style == nil || *seg.Settings.Style != style {
+			t.Errorf("segment %q: expected slide style", seg.Text)
+		}
+	}
+
+	if segments[1].Settings.Speed != nil {
+		t.Error("inherited segment should not have speed set")
+	}
+	if segments[2].Settings.Speed == nil || *segments[2].Settings.Speed != speed {
+		t.Error("overridden segment should have speed set")
+	}
+	if script.Slides[0].VoiceSettings.Speed != nil {
+		t.Error("merging should not modify the slide settings")
+	}
+}
+
+func TestCompileLanguageDefaults(t *testing.T) {
+	stability, deStability, style := 0.5, 0.8, 0.2
+	script := &Script{
+		DefaultVoices:   map[string]string{"en": "voice-en", "de": "voice-de"},
+		DefaultModels:   map[string]string{"de": "eleven_multilingual_v2"},
+		VoiceSettings:   &VoiceSettings{Stability: &stability, Style: &style},
+		DefaultSettings: map[string]*VoiceSettings{"de": {Stability: &deStability}},
+		Slides: []Slide{
+			{
+				Segments: []Segment{
+					{Text: map[string]string{"en": "Hello", "de": "Hallo"}},
+					{
+						Text:          map[string]string{"en": "Bye", "de": "Tschüss"},
+						VoiceSettings: &VoiceSettings{Stability: &stability},
+					},
+				},
+			},
+		},
+	}
+
+	de, err := NewCompiler().Compile(script, "de")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if *de[0].Settings.Stability != deStability || *de[0].Settings.Style != style {
+		t.Errorf("de settings = %+v, want language stability over script style", de[0].Settings)
+	}
+	if *de[1].Settings.Stability != stability {
+		t.Error("segment settings should override language settings")
+	}
+	if de[0].ModelID != "eleven_multilingual_v2" {
+		t.Errorf("de ModelID = %q", de[0].ModelID)
+	}
+
+	en, err := NewCompiler().Compile(script, "en")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if *en[0].Settings.Stability != stability || en[0].ModelID != "" {
+		t.Errorf("en segment = %+v", en[0])
+	}
+
+	// The language model overrides the generation model
+	jobs := NewElevenLabsFormatter().Format(de)
+	requests := GenerateTTSRequests(jobs, "eleven_flash_v2_5", "de")
+	if requests[0].ModelID != "eleven_multilingual_v2" {
+		t.Errorf("request ModelID = %q", requests[0].ModelID)
+	}
+	if contentHash(t, jobs[0], "eleven_flash_v2_5") != contentHash(t, jobs[0], "other") {
+		t.Error("content hash should use the segment model")
+	}
+	enJobs := NewElevenLabsFormatter().Format(en)
+	if GenerateTTSRequests(enJobs, "eleven_flash_v2_5", "en")[0].ModelID != "eleven_flash_v2_5" {
+		t.Error("segments without a language model should use the generation model")
+	}
+	config := NewBatchConfig("out")
+	config.ModelID = "eleven_flash_v2_5"
+	if m := generateManifest(t, jobs, config, "de"); m[0].ModelID != "eleven_multilingual_v2" {
+		t.Errorf("manifest ModelID = %q", m[0].ModelID)
+	}
+
+	invalid := 1.5
+	script.DefaultSettings["fr"] = &VoiceSettings{Stability: &invalid}
+	if issues := script.Validate(); len(issues) != 1 || !strings.Contains(issues[0], "default settings for fr") {
+		t.Errorf("Validate() = %v", issues)
+	}
+}
+
+func TestVoiceSettingsValidate(t *testing.T) {
+	speed := 5.0
+	script := &Script{
+		Slides: []Slide{
+			{
+				Segments: []Segment{
+					{
+						Text:          map[string]string{"en": "Too fast"},
+						VoiceSettings: &VoiceSettings{Speed: &speed},
+					},
+				},
+			},
+		},
+	}
+
+	issues := script.Validate()
+	found := false
+	for _, issue := range issues {
+		if strings.Contains(issue, "speed") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected speed issue, got %v", issues)
+	}
+
+	for _, v := range []float64{math.NaN(), math.Inf(1)} {
+		settings := &VoiceSettings{Stability: &v}
+		if issues := settings.Validate(); len(issues) != 1 {
+			t.Errorf("Validate() with stability %v = %v, want 1 issue", v, issues)
+		}
+	}
+}
+
+func TestAudioTags(t *testing.T) {
+	script := &Script{
+		DefaultVoices: map[string]string{"en": "voice-en"},
+		Slides: []Slide{
+			{
+				Segments: []Segment{
+					{
+						Text:    map[string]string{"en": "Guess what?"},
+						Emotion: "Excited",
+						Tags:    []string{"[whispers]", "excited", "laughs"},
+					},
+				},
+			},
+		},
+	}
+
+	segments, err := NewCompiler().Compile(script, "en")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	want := []string{"excited", "whispers", "laughs"}
+	if strings.Join(segments[0].AudioTags, ",") != strings.Join(want, ",") {
+		t.Errorf("AudioTags = %v, want %v", segments[0].AudioTags, want)
+	}
+	if segments[0].Text != "Guess what?" {
+		t.Errorf("compiled text should not contain tags, got %q", segments[0].Text)
+	}
+
+	// Tags are off by default, as only Eleven v3 understands them
+	formatter := NewElevenLabsFormatter()
+	if jobs := formatter.Format(segments); jobs[0].Text != "Guess what?" {
+		t.Errorf("formatted text with default formatter = %q", jobs[0].Text)
+	}
+
+	formatter.UseAudioTags = true
+	jobs := formatter.Format(segments)
+	if jobs[0].Text != "[excited] [whispers] [laughs] Guess what?" {
+		t.Errorf("formatted text = %q", jobs[0].Text)
+	}
+
+	formatter.UseAudioTags = false
+	jobs = formatter.Format(segments)
+	if jobs[0].Text != "Guess what?" {
+		t.Errorf("formatted text without tags = %q", jobs[0].Text)
+	}
+}
+
+func TestValidateModelLanguages(t *testing.T) {
+	script := &Script{
+		DefaultModels: map[string]string{"de": "multilingual"},
+		Slides: []Slide{
+			{Segments: []Segment{{Text: map[string]string{"en": "Hello", "de": "Hallo", "ja": "こんにちは"}}}},
+		},
+	}
+	languages := map[string][]string{
+		"english":      {"en"},
+		"multilingual": {"en", "de"},
+	}
+	supports := func(modelID, language string) bool {
+		return slices.Contains(languages[modelID], language)
+	}
+
+	issues := script.ValidateModelLanguages("english", supports)
+	want := []string{"language ja is not supported by model english"}
+	if !slices.Equal(issues, want) {
+		t.Errorf("ValidateModelLanguages() = %v, want %v", issues, want)
+	}
+
+	if got := script.Model("de", "english"); got != "multilingual" {
+		t.Errorf("Model(de) = %q, want multilingual", got)
+	}
+	if got := script.Model("en", "english"); got != "english" {
+		t.Errorf("Model(en) = %q, want english", got)
+	}
+}
+
+func TestValidateAudioTags(t *testing.T) {
+	script := &Script{
+		Slides: []Slide{
+			{
+				Segments: []Segment{
+					{
+						Text: map[string]string{"en": "Hello"},
+						Tags: []string{"whispers", "yodels"},
+					},
+				},
+			},
+		},
+	}
+
+	issues := script.Validate()
+	if len(issues) != 1 || !strings.Contains(issues[0], "yodels") {
+		t.Errorf("expected one unsupported tag issue, got %v", issues)
+	}
+}
+
+func TestParsePhoneme(t *testing.T) {
+	tests := []struct {
+		value    string
+		alphabet string
+		ph       string
+		ok       bool
+	}{
+		{"ipa:təˈmɑːtoʊ", "ipa", "təˈmɑːtoʊ", true},
+		{"cmu: T AH0 M EY1 T OW2", "cmu-arpabet", "T AH0 M EY1 T OW2", true},
+		{"X-SAMPA:t@\"mA:toU", "x-sampa", "t@\"mA:toU", true},
+		{"A P I", "", "", false},
+		{"ratio: 3:1", "", "", false},
+		{"ipa:", "", "", false},
+	}
+
+	for _, tt := range tests {
+		alphabet, ph, ok := ParsePhoneme(tt.value)
+		if alphabet != tt.alphabet || ph != tt.ph || ok != tt.ok {
+			t.Errorf("ParsePhoneme(%q) = (%q, %q, %v), expected (%q, %q, %v)",
+				tt.value, alphabet, ph, ok, tt.alphabet, tt.ph, tt.ok)
+		}
+	}
+}
+
+func TestSSMLFormatterMarkup(t *testing.T) {
+	speed := 1.1
+	script := &Script{
+		Pronunciations: map[string]map[string]string{
+			"tomato": {"en": "ipa:təˈmɑːtoʊ"},
+			"API":    {"en": "A P I"},
+		},
+		Slides: []Slide{
+			{
+				Segments: []Segment{
+					{
+						Text:          map[string]string{"en": "The API sold 1,250 Tomato crates on 2024-03-15."},
+						VoiceSettings: &VoiceSettings{Speed: &speed},
+					},
+				},
+			},
+		},
+	}
+
+	segments, err := NewCompiler().Compile(script, "en")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if segments[0].Text != "The A P I sold 1,250 Tomato crates on 2024-03-15." {
+		t.Errorf("phoneme terms should not be substituted, got %q", segments[0].Text)
+	}
+	if len(segments[0].Phonemes) != 1 {
+		t.Fatalf("expected 1 phoneme, got %d", len(segments[0].Phonemes))
+	}
+
+	formatter := NewSSMLFormatter()
+	formatter.UsePhonemes = true
+	formatter.AutoSayAs = true
+	ssml := formatter.Format(segments, "en")
+	for _, want := range []string{
+		`<prosody rate="110%">`,
+		`<phoneme alphabet="ipa" ph="təˈmɑːtoʊ">Tomato</phoneme>`,
+		`<say-as interpret-as="cardinal">1,250</say-as>`,
+		`<say-as interpret-as="date" format="ymd">2024-03-15</say-as>`,
+	} {
+		if !strings.Contains(ssml, want) {
+			t.Errorf("SSML should contain %s\n%s", want, ssml)
+		}
+	}
+
+	ssml = NewSSMLFormatter().Format(segments, "en")
+	if strings.Contains(ssml, "<phoneme") || strings.Contains(ssml, "<say-as") {
+		t.Errorf("markup should be off by default\n%s", ssml)
+	}
+
+	decimals := []CompiledSegment{{Text: "Pi is 3.14, not 3."}}
+	ssml = formatter.Format(decimals, "en")
+	if !strings.Contains(ssml, "Pi is 3.14, not") || strings.Contains(ssml, ">3.14<") {
+		t.Errorf("decimals should not be wrapped\n%s", ssml)
+	}
+	if !strings.Contains(ssml, `<say-as interpret-as="cardinal">3</say-as>.`) {
+		t.Errorf("whole numbers should be wrapped\n%s", ssml)
+	}
+}
+
+func TestSubtitleFormatter(t *testing.T) {
+	segments := []CompiledSegment{
+		{Text: "Welcome to the A P I course", OriginalText: "Welcome to the API course", PauseAfterMs: 500},
+		{Text: "Let's begin", OriginalText: "Let's begin", PauseBeforeMs: 250},
+	}
+	durations := []int{2000, 1500}
+
+	formatter := NewSubtitleFormatter()
+	srt, err := formatter.FormatSRT(segments, durations)
+	if err != nil {
+		t.Fatalf("FormatSRT() error = %v", err)
+	}
+	expectedSRT := "1\n00:00:00,000 --> 00:00:02,000\nWelcome to the API course\n\n" +
+		"2\n00:00:02,750 --> 00:00:04,250\nLet's begin\n\n"
+	if srt != expectedSRT {
+		t.Errorf("FormatSRT() = %q, expected %q", srt, expectedSRT)
+	}
+
+	vtt, err := formatter.FormatVTT(segments, durations)
+	if err != nil {
+		t.Fatalf("FormatVTT() error = %v", err)
+	}
+	if !strings.HasPrefix(vtt, "WEBVTT\n\n") || !strings.Contains(vtt, "00:00:02.750 --> 00:00:04.250") {
+		t.Errorf("unexpected VTT output: %q", vtt)
+	}
+
+	if _, err := formatter.FormatSRT(segments, durations[:1]); err == nil {
+		t.Error("expected error for mismatched durations")
+	}
+}
+
+func TestSubtitleFormatterSplitsLongCues(t *testing.T) {
+	segments := []CompiledSegment{
+		{OriginalText: "one two three four five six"},
+	}
+
+	formatter := &SubtitleFormatter{MaxCharsPerCue: 10}
+	cues, err := formatter.Cues(segments, []int{2700})
+	if err != nil {
+		t.Fatalf("Cues() error = %v", err)
+	}
+	if len(cues) != 3 {
+		t.Fatalf("expected 3 cues, got %d: %+v", len(cues), cues)
+	}
+	if cues[0].StartMs != 0 || cues[len(cues)-1].EndMs != 2700 {
+		t.Errorf("cues should span the segment: %+v", cues)
+	}
+	for i := 1; i < len(cues); i++ {
+		if cues[i].StartMs != cues[i-1].EndMs {
+			t.Errorf("cue %d should start where cue %d ends", i+1, i)
+		}
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	if got := formatTimestamp(3723456, ","); got != "01:02:03,456" {
+		t.Errorf("formatTimestamp() = %q", got)
+	}
+}
+
+func TestCloneLanguage(t *testing.T) {
+	script := &Script{
+		DefaultVoices:  map[string]string{"en": "voice-en"},
+		Pronunciations: map[string]map[string]string{"API": {"en": "A P I"}},
+		Slides: []Slide{
+			{
+				TitleVoice: map[string]string{"en": "title-voice"},
+				Segments: []Segment{
+					{Text: map[string]string{"en": "Hello"}, Voice: map[string]string{"en": "seg-voice"}, PauseAfter: "500ms"},
+					{Text: map[string]string{"en": "Goodbye", "es": "Adiós"}},
+				},
+			},
+		},
+	}
+
+	translator := func(text, from, to string) (string, error) {
+		return strings.ToUpper(text), nil
+	}
+	if err := script.CloneLanguage("en", "es", translator); err != nil {
+		t.Fatalf("CloneLanguage() error = %v", err)
+	}
+
+	segs := script.Slides[0].Segments
+	if segs[0].Text["es"] != "HELLO" {
+		t.Errorf("expected translated text, got %q", segs[0].Text["es"])
+	}
+	if segs[1].Text["es"] != "Adiós" {
+		t.Errorf("existing translation should be kept, got %q", segs[1].Text["es"])
+	}
+	if segs[0].Voice["es"] != "seg-voice" || script.DefaultVoices["es"] != "voice-en" {
+		t.Error("voices should be copied to the new language")
+	}
+	if script.Slides[0].TitleVoice["es"] != "title-voice" {
+		t.Error("title voice should be copied to the new language")
+	}
+	if script.Pronunciations["API"]["es"] != "A P I" {
+		t.Error("pronunciations should be copied to the new language")
+	}
+	if segs[0].PauseAfter != "500ms" {
+		t.Error("pauses should be preserved")
+	}
+}
+
+func TestCloneLanguagePlaceholderAndErrors(t *testing.T) {
+	script := &Script{
+		Slides: []Slide{
+			{Segments: []Segment{{Text: map[string]string{"en": "Hello"}}}},
+		},
+	}
+
+	if err := script.CloneLanguage("en", "en", nil); err == nil {
+		t.Error("expected error for same language")
+	}
+
+	failing := func(text, from, to string) (string, error) {
+		return "", os.ErrDeadlineExceeded
+	}
+	if err := script.CloneLanguage("en", "fr", failing); err == nil {
+		t.Error("expected translator error")
+	}
+	if _, ok := script.Slides[0].Segments[0].Text["fr"]; ok {
+		t.Error("script should be unchanged after a failed translation")
+	}
+
+	if err := script.CloneLanguage("en", "de", nil); err != nil {
+		t.Fatalf("CloneLanguage() error = %v", err)
+	}
+	if got := script.Slides[0].Segments[0].Text["de"]; got != "TODO(de): Hello" {
+		t.Errorf("expected placeholder text, got %q", got)
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	got := SplitSentences(`First one. "Second?" Third!  Fourth`)
+	want := []string{"First one.", `"Second?"`, "Third!", "Fourth"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("SplitSentences() = %q, expected %q", got, want)
+	}
+}
+
+func TestChunkerSplit(t *testing.T) {
+	chunker := NewChunker(30)
+
+	if got := chunker.Split("Short text."); len(got) != 1 {
+		t.Errorf("short text should not be split, got %q", got)
+	}
+
+	text := "This is the first sentence. This is the second one. And a third."
+	chunks := chunker.Split(text)
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got %d: %q", len(chunks), chunks)
+	}
+	for _, chunk := range chunks {
+		if len(chunk) > 30 {
+			t.Errorf("chunk exceeds limit: %q", chunk)
+		}
+	}
+	if strings.Join(chunks, " ") != text {
+		t.Errorf("chunks should rejoin to the original text, got %q", chunks)
+	}
+
+	// A single long sentence falls back to word boundaries
+	chunks = NewChunker(10).Split("alpha beta gamma delta epsilon")
+	if len(chunks) != 4 || chunks[0] != "alpha beta" {
+		t.Errorf("unexpected word split: %q", chunks)
+	}
+}
+
+func TestCompileChunksLongSegments(t *testing.T) {
+	script := &Script{
+		DefaultVoices:  map[string]string{"en": "voice-en"},
+		Pronunciations: map[string]map[string]string{"API": {"en": "A P I"}},
+		Slides: []Slide{
+			{
+				Segments: []Segment{
+					{
+						Text:        map[string]string{"en": "The API is fast. The API is simple. The API is fun."},
+						PauseBefore: "200ms",
+						PauseAfter:  "500ms",
+					},
+				},
+			},
+		},
+	}
+
+	compiler := NewCompiler()
+	compiler.DefaultPauseAfterSlide = ""
+	compiler.MaxChars = 25
+	segments, err := compiler.Compile(script, "en")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if len(segments) != 3 {
+		t.Fatalf("expected 3 chunks, got %d", len(segments))
+	}
+
+	for i, seg := range segments {
+		if seg.ChunkIndex != i || seg.ChunkCount != 3 {
+			t.Errorf("chunk %d: index/count = %d/%d", i, seg.ChunkIndex, seg.ChunkCount)
+		}
+		if len(seg.Text) > 25 {
+			t.Errorf("chunk %d exceeds limit after pronunciations: %q", i, seg.Text)
+		}
+	}
+	if segments[0].Text != "The A P I is fast." || segments[0].OriginalText != "The API is fast." {
+		t.Errorf("unexpected first chunk: %q / %q", segments[0].Text, segments[0].OriginalText)
+	}
+	if segments[0].PauseBeforeMs != 200 || segments[1].PauseBeforeMs != 0 {
+		t.Error("pause before should only apply to the first chunk")
+	}
+	if segments[2].PauseAfterMs != 500 || segments[0].PauseAfterMs != 0 {
+		t.Error("pause after should only apply to the last chunk")
+	}
+	if segments[0].PreviousText != "" || segments[0].NextText != segments[1].Text {
+		t.Error("first chunk should only have next text")
+	}
+	if segments[1].PreviousText != segments[0].Text || segments[1].NextText != segments[2].Text {
+		t.Error("middle chunk should have previous and next text")
+	}
+
+	jobs := NewElevenLabsFormatter().Format(segments)
+	config := NewBatchConfig("out")
+	if got := config.GenerateFilename(jobs[1], "en"); got != "out/slide01_seg01_part02_en.mp3" {
+		t.Errorf("GenerateFilename() = %q", got)
+	}
+	if jobs[1].PreviousText != segments[0].Text {
+		t.Error("formatter should pass through stitching text")
+	}
+}
+
+func TestCharacterLimit(t *testing.T) {
+	if CharacterLimit("eleven_multilingual_v2") != 10000 {
+		t.Error("unexpected limit for eleven_multilingual_v2")
+	}
+	if CharacterLimit("unknown") != DefaultCharacterLimit {
+		t.Error("unknown models should use the default limit")
+	}
+}
+
+func TestLint(t *testing.T) {
+	script := &Script{
+		DefaultLanguage: "en",
+		DefaultVoices:   map[string]string{"en": "voice-en", "fr": "voice-fr"},
+		Pronunciations: map[string]map[string]string{
+			"API": {"en": "A P I"},
+			"SDK": {"en": "S D K"},
+		},
+		Slides: []Slide{
+			{
+				Segments: []Segment{
+					{Text: map[string]string{"en": "Use the API.", "es": "Usa la API."}, PauseAfter: "10s"},
+				},
+			},
+			{
+				TitlePauseAfter: "soon",
+				Segments: []Segment{
+					{Text: map[string]string{"en": "Use the API.", "es": "Usa la API."}},
+					{Text: map[string]string{"en": "Only English here."}},
+				},
+			},
+		},
+	}
+
+	issues := script.Lint(nil)
+
+	codes := make(map[string]LintIssue)
+	for _, issue := range issues {
+		codes[issue.Code] = issue
+	}
+
+	expected := map[string]LintSeverity{
+		LintMissingTranslation:  SeverityWarning,
+		LintMissingVoice:        SeverityError,
+		LintUnusedPronunciation: SeverityInfo,
+		LintUnusedVoice:         SeverityInfo,
+		LintInvalidPause:        SeverityError,
+		LintPauseOutOfRange:     SeverityWarning,
+	}
+	for code, severity := range expected {
+		issue, ok := codes[code]
+		if !ok {
+			t.Errorf("expected %s issue, got %v", code, issues)
+			continue
+		}
+		if issue.Severity != severity {
+			t.Errorf("%s severity = %s, expected %s", code, issue.Severity, severity)
+		}
+	}
+
+	if issue := codes[LintMissingTranslation]; issue.Slide != 2 || issue.Segment != 2 || issue.Language != "es" {
+		t.Errorf("unexpected missing translation location: %+v", issue)
+	}
+	if issue := codes[LintUnusedPronunciation]; !strings.Contains(issue.Message, "SDK") {
+		t.Errorf("expected unused SDK pronunciation, got %q", issue.Message)
+	}
+	if _, ok := codes[LintDuplicateSlide]; ok {
+		t.Error("slides with different content should not be duplicates")
+	}
+
+	for i := 1; i < len(issues); i++ {
+		if issues[i].Slide < issues[i-1].Slide {
+			t.Error("issues should be ordered by slide")
+		}
+	}
+}
+
+func TestLintDuplicatesAndDuration(t *testing.T) {
+	slide := Slide{Segments: []Segment{{Text: map[string]string{"en": "one two three four five six"}}}}
+	script := &Script{
+		DefaultVoices: map[string]string{"en": "voice-en"},
+		Slides:        []Slide{slide, slide},
+	}
+
+	opts := DefaultLintOptions()
+	opts.WordsPerMinute = 60
+	opts.MaxSlideDurationMs = 5000
+	issues := script.Lint(opts)
+
+	var duplicate, tooLong int
+	for _, issue := range issues {
+		switch issue.Code {
+		case LintDuplicateSlide:
+			duplicate++
+			if issue.Slide != 2 {
+				t.Errorf("duplicate should be reported on slide 2, got %d", issue.Slide)
+			}
+		case LintSlideExceedsDuration:
+			tooLong++
+		}
+	}
+	if duplicate != 1 {
+		t.Errorf("expected 1 duplicate issue, got %d", duplicate)
+	}
+	if tooLong != 2 {
+		t.Errorf("expected 2 duration issues, got %d", tooLong)
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	speakTitle := true
+	script := &Script{
+		Pronunciations: map[string]map[string]string{"API": {"en": "A P I"}},
+		Slides: []Slide{
+			{
+				Title:      "Intro",
+				SpeakTitle: &speakTitle,
+				Segments: []Segment{
+					{Text: map[string]string{"en": "The API is fast"}, PauseAfter: "1s"},
+				},
+			},
+			{
+				Segments: []Segment{
+					{Text: map[string]string{"en": "one two three"}},
+				},
+			},
+		},
+	}
+
+	pricing, err := PricingForTier("Pro", "eleven_multilingual_v2")
+	if err != nil {
+		t.Fatalf("PricingForTier() error = %v", err)
+	}
+
+	est, err := script.Estimate("en", 60, pricing)
+	if err != nil {
+		t.Fatalf("Estimate() error = %v", err)
+	}
+	if len(est.Slides) != 2 {
+		t.Fatalf("expected 2 slides, got %d", len(est.Slides))
+	}
+
+	// "Intro" + "The A P I is fast"
+	if est.Slides[0].Characters != 5+17 {
+		t.Errorf("slide 1 characters = %d", est.Slides[0].Characters)
+	}
+	if est.Slides[0].Words != 1+6 || est.Slides[0].SpeechMs != 7000 {
+		t.Errorf("slide 1 words/speech = %d/%d", est.Slides[0].Words, est.Slides[0].SpeechMs)
+	}
+	if est.Characters != est.Slides[0].Characters+est.Slides[1].Characters {
+		t.Error("total characters should be the sum of slides")
+	}
+	if est.DurationMs != est.SpeechMs+est.PauseMs {
+		t.Error("duration should be speech plus pauses")
+	}
+
+	expectedCost := float64(est.Characters) / 1000 * 0.24
+	if diff := est.CostUSD - expectedCost; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("CostUSD = %f, expected %f", est.CostUSD, expectedCost)
+	}
+
+	flash, _ := PricingForTier("pro", "eleven_flash_v2_5")
+	if flash.Cost(1000) != 0.12 {
+		t.Errorf("flash cost = %f, expected 0.12", flash.Cost(1000))
+	}
+
+	if _, err := PricingForTier("platinum", ""); err == nil {
+		t.Error("expected error for unknown tier")
+	}
+
+	noCost, _ := script.Estimate("en", 0, nil)
+	if noCost.CostUSD != 0 || noCost.WordsPerMinute != 150 {
+		t.Error("nil pricing should skip costs and default wpm")
+	}
+}
+
+func TestCompileVariablesAndConditions(t *testing.T) {
+	boolPtr := func(v bool) *bool { return &v }
+
+	script := &Script{
+		Variables: map[string]string{"product": "Acme", "variant": "basic"},
+		Slides: []Slide{
+			{
+				Title:      "{{product}} Overview",
+				SpeakTitle: boolPtr(true),
+				Segments: []Segment{
+					{Text: map[string]string{"en": "Welcome to {{ product }} {{variant}}."}},
+					{Text: map[string]string{"en": "Pro adds analytics."}, Condition: `variant == "pro"`},
+					{Text: map[string]string{"en": "Upgrade any time."}, Condition: `variant != 'pro' && !trial`},
+				},
+			},
+			{
+				Title:      "Pro Features",
+				SpeakTitle: boolPtr(true),
+				Segments: []Segment{
+					{Text: map[string]string{"en": "Dashboards."}, Condition: `variant == "pro" || variant == "enterprise"`},
+				},
+			},
+		},
+	}
+
+	compiler := NewCompiler()
+	compiler.Variables = map[string]string{"trial": "false"}
+	segments, err := compiler.Compile(script, "en")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	var texts []string
+	for _, seg := range segments {
+		texts = append(texts, seg.Text)
+	}
+	want := "Acme Overview|Welcome to Acme basic.|Upgrade any time."
+	if got := strings.Join(texts, "|"); got != want {
+		t.Errorf("basic texts = %q, want %q", got, want)
+	}
+	// The slide pause follows the last included segment
+	if segments[2].SegmentIndex != 2 || segments[2].PauseAfterMs != 800 {
+		t.Errorf("last segment = %+v", segments[2])
+	}
+
+	compiler.Variables = map[string]string{"variant": "pro", "trial": "false"}
+	segments, err = compiler.Compile(script, "en")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if len(segments) != 5 || segments[1].Text != "Welcome to Acme pro." || segments[2].Text != "Pro adds analytics." || segments[4].Text != "Dashboards." {
+		t.Errorf("pro segments = %+v", segments)
+	}
+	if segments[2].PauseAfterMs != 800 {
+		t.Errorf("pause after last pro segment = %d", segments[2].PauseAfterMs)
+	}
+
+	// trial has no default
+	compiler.Variables = nil
+	if _, err := compiler.Compile(script, "en"); err == nil || !strings.Contains(err.Error(), `"trial"`) {
+		t.Errorf("Compile() with undefined variable error = %v", err)
+	}
+
+	if got := strings.Join(script.VariableNames(), ","); got != "product,trial,variant" {
+		t.Errorf("VariableNames() = %q", got)
+	}
+}
+
+func TestEvaluateCondition(t *testing.T) {
+	values := map[string]string{"variant": "pro", "region": "eu", "beta": "1", "legacy": ""}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"", true},
+		{`variant == "pro"`, true},
+		{`variant == 'basic'`, false},
+		{`variant != "pro" || region == "eu"`, true},
+		{`variant == "pro" && !(region == "eu" || region == "uk")`, false},
+		{"beta", true},
+		{"legacy", false},
+		{"!legacy && beta == 1", true},
+	}
+	for _, tt := range tests {
+		got, err := EvaluateCondition(tt.expr, values)
+		if err != nil || got != tt.want {
+			t.Errorf("EvaluateCondition(%q) = %v, %v; want %v", tt.expr, got, err, tt.want)
+		}
+	}
+
+	for _, expr := range []string{`variant ==`, `variant = "pro"`, `(variant == "pro"`, `variant == "pro`, `a b`} {
+		if _, err := EvaluateCondition(expr, values); err == nil {
+			t.Errorf("EvaluateCondition(%q) should fail", expr)
+		}
+	}
+	if _, err := EvaluateCondition(`tier == "gold"`, values); err == nil {
+		t.Error("EvaluateCondition() with undefined variable should fail")
+	}
+
+	script := &Script{Slides: []Slide{{Segments: []Segment{{Text: map[string]string{"en": "Hi"}, Condition: "variant =="}}}}}
+	if issues := script.Validate(); len(issues) != 1 || !strings.Contains(issues[0], "invalid condition") {
+		t.Errorf("Validate() = %v", issues)
+	}
+}
+
+func TestScriptEditing(t *testing.T) {
+	script := &Script{
+		Slides: []Slide{
+			{Title: "Intro", Segments: []Segment{
+				{Text: map[string]string{"en": "Hello there. Welcome.", "es": "Hola. Bienvenidos."}, PauseBefore: "100ms", PauseAfter: "1s", Rate: "slow"},
+			}},
+			{ID: "outro", Title: "Outro", Segments: []Segment{{ID: "outro", Text: map[string]string{"en": "Bye"}}}},
+		},
+	}
+	script.RenumberIndexes()
+	if script.Slides[1].ID != "outro" || script.Slides[1].Segments[0].ID == "outro" || script.Slides[0].ID == "" {
+		t.Fatalf("RenumberIndexes() did not assign unique IDs: %+v", script.Slides)
+	}
+	if issues := script.Validate(); len(issues) != 0 {
+		t.Errorf("Validate() after RenumberIndexes = %v", issues)
+	}
+	introID := script.Slides[0].ID
+	segID := script.Slides[0].Segments[0].ID
+
+	// Split, then the compiled output still maps back to the segments
+	newSeg, err := script.SplitSegment(segID, map[string]int{"en": len("Hello there."), "es": len("Hola.")})
+	if err != nil {
+		t.Fatalf("SplitSegment() error = %v", err)
+	}
+	segs := script.Slides[0].Segments
+	if len(segs) != 2 || segs[0].Text["en"] != "Hello there." || segs[1].Text["es"] != "Bienvenidos." || segs[1].ID != newSeg {
+		t.Fatalf("segments after split = %+v", segs)
+	}
+	if segs[0].PauseBefore != "100ms" || segs[0].PauseAfter != "" || segs[1].PauseBefore != "" || segs[1].PauseAfter != "1s" || segs[1].Rate != "slow" {
+		t.Errorf("split pauses and prosody = %+v", segs)
+	}
+	compiled, err := NewCompiler().Compile(script, "en")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if compiled[1].SegmentID != newSeg || compiled[1].SlideID != introID {
+		t.Errorf("compiled IDs = %q/%q", compiled[1].SlideID, compiled[1].SegmentID)
+	}
+
+	if err := script.MergeSegments(segID, newSeg); err != nil {
+		t.Fatalf("MergeSegments() error = %v", err)
+	}
+	segs = script.Slides[0].Segments
+	if len(segs) != 1 || segs[0].Text["en"] != "Hello there. Welcome." || segs[0].Text["es"] != "Hola. Bienvenidos." || segs[0].PauseAfter != "1s" {
+		t.Errorf("segments after merge = %+v", segs)
+	}
+
+	id, err := script.InsertSlide(1, Slide{Title: "Middle", Segments: []Segment{{Text: map[string]string{"en": "Middle"}}}})
+	if err != nil || script.Slides[1].ID != id || script.Slides[1].Segments[0].ID == "" {
+		t.Fatalf("InsertSlide() = %q, %v", id, err)
+	}
+	if err := script.MoveSlide(introID, 2); err != nil {
+		t.Fatalf("MoveSlide() error = %v", err)
+	}
+	var titles []string
+	for _, slide := range script.Slides {
+		titles = append(titles, slide.Title)
+	}
+	if got := strings.Join(titles, ","); got != "Middle,Outro,Intro" {
+		t.Errorf("slides = %s", got)
+	}
+	if i, ok := script.FindSlide(introID); !ok || i != 2 {
+		t.Errorf("FindSlide() = %d, %v", i, ok)
+	}
+
+	// Errors
+	if _, err := script.InsertSlide(0, Slide{ID: "outro"}); err == nil {
+		t.Error("InsertSlide() with duplicate ID should fail")
+	}
+	if _, err := script.InsertSlide(9, Slide{}); err == nil {
+		t.Error("InsertSlide() out of range should fail")
+	}
+	if err := script.MoveSlide("missing", 0); err == nil {
+		t.Error("MoveSlide() with unknown ID should fail")
+	}
+	if _, err := script.SplitSegment(segID, map[string]int{"en": 0}); err == nil {
+		t.Error("SplitSegment() at offset 0 should fail")
+	}
+	if err := script.MergeSegments(segID, script.Slides[0].Segments[0].ID); err == nil {
+		t.Error("MergeSegments() across slides should fail")
+	}
+}
+
+func TestDiffScripts(t *testing.T) {
+	stability := 0.4
+	speakTitle := true
+	base := func() *Script {
+		return &Script{
+			DefaultVoices: map[string]string{"en": "voice-en"},
+			Slides: []Slide{
+				{ID: "intro", Title: "Intro", SpeakTitle: &speakTitle, Segments: []Segment{
+					{ID: "hello", Text: map[string]string{"en": "Hello"}},
+					{ID: "agenda", Text: map[string]string{"en": "Today we cover APIs."}},
+				}},
+				{ID: "outro", Segments: []Segment{
+					{ID: "bye", Text: map[string]string{"en": "Bye"}},
+				}},
+			},
+		}
+	}
+
+	oldScript, newScript := base(), base()
+	newScript.Slides[0].Segments[0].Text["en"] = "Hello everyone"
+	newScript.Slides[0].Segments[1].VoiceSettings = &VoiceSettings{Stability: &stability}
+	newScript.Slides[1].Segments = []Segment{{ID: "thanks", Text: map[string]string{"en": "Thanks"}}}
+	if err := newScript.MoveSlide("outro", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := DiffScripts(oldScript, newScript, "en")
+	if err != nil {
+		t.Fatalf("DiffScripts() error = %v", err)
+	}
+
+	var kinds []string
+	for _, c := range diff.Changes {
+		kinds = append(kinds, string(c.Kind)+":"+strings.Join(c.Fields, "+"))
+	}
+	// thanks added; title moved; hello and agenda changed; bye removed
+	want := "added:,moved:,changed:text,changed:settings,removed:"
+	if got := strings.Join(kinds, ","); got != want {
+		t.Errorf("changes = %s, want %s", got, want)
+	}
+	if diff.Unchanged != 0 || !diff.HasChanges() {
+		t.Errorf("Unchanged = %d", diff.Unchanged)
+	}
+
+	var texts []string
+	for _, seg := range diff.NeedsSynthesis() {
+		texts = append(texts, seg.Text)
+	}
+	if got := strings.Join(texts, "|"); got != "Thanks|Hello everyone|Today we cover APIs." {
+		t.Errorf("NeedsSynthesis() = %q", got)
+	}
+
+	report := diff.String()
+	for _, line := range []string{
+		"en: 1 added, 2 changed, 1 removed, 1 moved, 0 unchanged",
+		`+ slide 1, segment 1: "Thanks"`,
+		"> slide 2, title: moved from slide 1, title",
+		`~ slide 2, segment 1 (text): "Hello" -> "Hello everyone"`,
+		"~ slide 2, segment 2 (settings)",
+		`- slide 2, segment 1: "Bye"`,
+	} {
+		if !strings.Contains(report, line) {
+			t.Errorf("report missing %q:\n%s", line, report)
+		}
+	}
+
+	// Without IDs, segments are matched by position
+	same, err := DiffScripts(&Script{Slides: []Slide{{Segments: []Segment{{Text: map[string]string{"en": "A"}}}}}},
+		&Script{Slides: []Slide{{Segments: []Segment{{Text: map[string]string{"en": "A"}}, {Text: map[string]string{"en": "B"}}}}}}, "en")
+	if err != nil {
+		t.Fatalf("DiffScripts() error = %v", err)
+	}
+	if same.Unchanged != 1 || len(same.Changes) != 1 || same.Changes[0].Kind != ChangeAdded {
+		t.Errorf("positional diff = %+v", same)
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	entries := []ManifestEntry{
+		{SlideIndex: 0, SegmentIndex: -1, SlideTitle: "Intro", IsTitleSegment: true, Language: "en", OutputFile: "out/slide01_title_en.mp3", DurationMs: 1000, PauseAfterMs: 500, Text: "Intro"},
+		{SlideIndex: 0, SegmentIndex: 0, SlideTitle: "Intro", Language: "en", OutputFile: "out/slide01_seg01_en.mp3", DurationMs: 2000, PauseAfterMs: 800, Text: "Hello"},
+		{SlideIndex: 1, SegmentIndex: 0, SlideTitle: "It's done", Language: "en", OutputFile: "out/slide02_seg01_en.mp3", PauseBeforeMs: 200, DurationMs: 1500, PauseAfterMs: 800, Text: "Bye"},
+	}
+	tl := NewTimeline(entries)
+	if tl.Language != "en" || tl.DurationMs != 6800 || len(tl.Slides) != 2 {
+		t.Fatalf("timeline = %+v", tl)
+	}
+	// The second slide appears with the pause before its first clip
+	if s := tl.Slides[0]; s.StartMs != 0 || s.EndMs != 4300 || len(s.Clips) != 2 || s.Clips[1].StartMs != 1500 {
+		t.Errorf("slide 1 = %+v", s)
+	}
+	if s := tl.Slides[1]; s.StartMs != 4300 || s.EndMs != 6800 || s.Clips[0].StartMs != 4500 || s.DurationMs() != 2500 {
+		t.Errorf("slide 2 = %+v", s)
+	}
+	if entries[2].StartMs != 0 {
+		t.Error("NewTimeline() should not modify the entries")
+	}
+
+	var edl strings.Builder
+	if err := tl.WriteEDL(&edl, "Course", 25); err != nil {
+		t.Fatalf("WriteEDL() error = %v", err)
+	}
+	for _, want := range []string{
+		"TITLE: Course\nFCM: NON-DROP FRAME\n",
+		"002  AX       AA       C        00:00:00:00 00:00:02:00 00:00:01:13 00:00:03:13\n* FROM CLIP NAME: slide01_seg01_en.mp3\n\n",
+		"003  AX       AA       C        00:00:00:00 00:00:01:13 00:00:04:13 00:00:06:00\n* FROM CLIP NAME: slide02_seg01_en.mp3\n* COMMENT: SLIDE 2 It's done\n",
+	} {
+		if !strings.Contains(edl.String(), want) {
+			t.Errorf("EDL missing %q:\n%s", want, edl.String())
+		}
+	}
+
+	var concat strings.Builder
+	err := tl.WriteFFmpegConcat(&concat, func(i int) string {
+		return []string{"intro.png", "it's.png"}[i]
+	})
+	if err != nil {
+		t.Fatalf("WriteFFmpegConcat() error = %v", err)
+	}
+	want := "ffconcat version 1.0\nfile 'intro.png'\nduration 4.300\nfile 'it'\\''s.png'\nduration 2.500\nfile 'it'\\''s.png'\n"
+	if concat.String() != want {
+		t.Errorf("concat = %q, want %q", concat.String(), want)
+	}
+
+	var js strings.Builder
+	if err := tl.WriteJSON(&js); err != nil || !strings.Contains(js.String(), `"start_ms": 4300`) {
+		t.Errorf("WriteJSON() = %s, %v", js.String(), err)
+	}
+}
+
+func TestAudioSegments(t *testing.T) {
+	script, err := ParseScript([]byte(`{
+		"default_voices": {"en": "voice-en", "es": "voice-es"},
+		"slides": [{
+			"segments": [
+				{"type": "audio", "audio": {"*": {"file": "jingle.mp3"}}},
+				{"text": {"en": "Welcome", "es": "Bienvenidos"}, "emotion": "excited"},
+				{"type": "audio", "text": {"en": "Terms apply."},
+				 "audio": {"en": {"history_item_id": "item-1"}}, "pause_after": "500ms"}
+			]
+		}]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseScript() error = %v", err)
+	}
+	if issues := script.Validate(); len(issues) != 0 {
+		t.Errorf("Validate() = %v", issues)
+	}
+
+	compiler := NewCompiler()
+	compiler.DefaultPauseAfterSlide = ""
+	segments, err := compiler.Compile(script, "en")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if len(segments) != 3 {
+		t.Fatalf("got %d segments, want 3", len(segments))
+	}
+	jingle, disclaimer := segments[0], segments[2]
+	if jingle.Audio == nil || jingle.Audio.File != "jingle.mp3" || jingle.VoiceID != "" || jingle.Text != "" {
+		t.Errorf("jingle = %+v", jingle)
+	}
+	if disclaimer.Audio == nil || disclaimer.Audio.HistoryItemID != "item-1" || disclaimer.Text != "Terms apply." || disclaimer.PauseAfterMs != 500 {
+		t.Errorf("disclaimer = %+v", disclaimer)
+	}
+	if segments[1].Audio != nil {
+		t.Errorf("text segment has audio %+v", segments[1].Audio)
+	}
+
+	// Languages without audio skip the segment
+	es, err := compiler.Compile(script, "es")
+	if err != nil {
+		t.Fatalf("Compile(es) error = %v", err)
+	}
+	if len(es) != 2 || es[0].Audio == nil || es[1].Text != "Bienvenidos" {
+		t.Errorf("Compile(es) = %+v", es)
+	}
+
+	formatter := NewElevenLabsFormatter()
+	formatter.UseAudioTags = true
+	jobs := formatter.Format(segments)
+	if jobs[2].Text != "Terms apply." || jobs[2].Audio == nil || jobs[1].Text != "[excited] Welcome" {
+		t.Errorf("Format() = %q, %q", jobs[1].Text, jobs[2].Text)
+	}
+	if requests := GenerateTTSRequests(jobs, "model-1", "en"); len(requests) != 1 || requests[0].Text != "[excited] Welcome" {
+		t.Errorf("GenerateTTSRequests() = %+v, want only the text segment", requests)
+	}
+	entries := generateManifest(t, jobs, NewBatchConfig("out"), "en")
+	if entries[0].Audio == nil || entries[0].OutputFile != "out/slide01_seg01_en.mp3" {
+		t.Errorf("manifest entry = %+v", entries[0])
+	}
+
+	// The transcript does not affect the audio, the asset does
+	changed := jobs[2]
+	changed.Text = "Conditions apply."
+	if contentHash(t, changed, "model-1") != contentHash(t, jobs[2], "model-2") {
+		t.Error("audio segment hash should ignore transcript and model")
+	}
+	changed.Audio = &AudioAsset{HistoryItemID: "item-2"}
+	if contentHash(t, changed, "model-1") == contentHash(t, jobs[2], "model-1") {
+		t.Error("audio segment hash should change with the asset")
+	}
+
+	ssml := NewSSMLFormatter().Format(segments, "en")
+	if !strings.Contains(ssml, `<audio src="jingle.mp3"/>`) || !strings.Contains(ssml, "Terms apply.") {
+		t.Errorf("SSML missing audio:\n%s", ssml)
+	}
+
+	est, err := script.Estimate("en", 0, nil)
+	if err != nil {
+		t.Fatalf("Estimate() error = %v", err)
+	}
+	if est.Characters != len("Welcome") {
+		t.Errorf("Estimate() characters = %d, want only synthesized text", est.Characters)
+	}
+}
+
+func TestAudioSegmentValidation(t *testing.T) {
+	script := &Script{Slides: []Slide{{Segments: []Segment{
+		{Type: SegmentTypeAudio},
+		{Type: SegmentTypeAudio, Audio: map[string]*AudioAsset{"en": {File: "a.mp3", HistoryItemID: "item-1"}}},
+		{Type: SegmentTypeAudio, Audio: map[string]*AudioAsset{"en": {}}},
+		{Type: "video", Text: map[string]string{"en": "Hi"}},
+	}}}}
+	issues := script.Validate()
+	for _, want := range []string{"segment 1 has no audio", "segment 2 en audio has both", "segment 3 en audio has no file", `segment 4 has unknown type "video"`} {
+		if !slices.ContainsFunc(issues, func(issue string) bool { return strings.Contains(issue, want) }) {
+			t.Errorf("Validate() = %v, missing %q", issues, want)
+		}
+	}
+
+	if _, err := script.SplitSegment("", nil); err == nil {
+		t.Error("SplitSegment() of a missing segment should fail")
+	}
+	script.Slides[0].Segments[1].ID = "seg-1"
+	if _, err := script.SplitSegment("seg-1", nil); err == nil {
+		t.Error("SplitSegment() of an audio segment should fail")
+	}
+
+	asset := AudioAsset{File: "inserts/jingle.mp3"}
+	if got := asset.ResolveFile("/scripts/course.json"); got != filepath.Join("/scripts", "inserts", "jingle.mp3") {
+		t.Errorf("ResolveFile() = %s", got)
+	}
+	asset.File = "/abs/jingle.mp3"
+	if got := asset.ResolveFile("/scripts/course.json"); got != "/abs/jingle.mp3" {
+		t.Errorf("ResolveFile(absolute) = %s", got)
+	}
+}
+
+func TestOutputFormats(t *testing.T) {
+	script, err := ParseScript([]byte(`{
+		"default_voices": {"en": "voice-en"},
+		"slides": [
+			{"title": "Intro", "speak_title": true, "output_format": "pcm_48000", "segments": [
+				{"text": {"en": "Mastered"}},
+				{"text": {"en": "Draft"}, "output_format": "mp3_22050_32"},
+				{"type": "audio", "audio": {"*": {"file": "jingle.wav"}}}
+			]},
+			{"segments": [{"text": {"en": "Default"}}]}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseScript() error = %v", err)
+	}
+	if issues := script.Validate(); len(issues) != 0 {
+		t.Errorf("Validate() = %v", issues)
+	}
+
+	segments, err := NewCompiler().Compile(script, "en")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	jobs := NewElevenLabsFormatter().Format(segments)
+	var formats []string
+	for _, job := range jobs {
+		formats = append(formats, job.OutputFormat)
+	}
+	if want := []string{"pcm_48000", "pcm_48000", "mp3_22050_32", "", ""}; !slices.Equal(formats, want) {
+		t.Fatalf("formats = %q, want %q", formats, want)
+	}
+	if jobs[1].SuggestedFilename != "slide01_seg01.wav" || jobs[3].SuggestedFilename != "slide01_seg03.wav" {
+		t.Errorf("SuggestedFilename = %s, %s", jobs[1].SuggestedFilename, jobs[3].SuggestedFilename)
+	}
+	if requests := GenerateTTSRequests(jobs, "model-1", "en"); requests[2].OutputFormat != "mp3_22050_32" || requests[3].OutputFormat != "" {
+		t.Errorf("GenerateTTSRequests() = %+v", requests)
+	}
+
+	config := NewBatchConfig("out")
+	config.OutputFormat = "opus_48000_64"
+	entries := generateManifest(t, jobs, config, "en")
+	var files []string
+	for _, e := range entries {
+		files = append(files, e.OutputFile)
+	}
+	want := []string{"out/slide01_title_en.wav", "out/slide01_seg01_en.wav", "out/slide01_seg02_en.mp3", "out/slide01_seg03_en.wav", "out/slide02_seg01_en.opus"}
+	if !slices.Equal(files, want) {
+		t.Errorf("output files = %q, want %q", files, want)
+	}
+	if entries[4].OutputFormat != "opus_48000_64" || entries[3].OutputFormat != "" {
+		t.Errorf("manifest formats = %q, %q", entries[4].OutputFormat, entries[3].OutputFormat)
+	}
+	for i, needs := range []bool{true, true, true, true, false} {
+		if got := entries[i].NeedsConversion("opus_48000_64"); got != needs {
+			t.Errorf("entries[%d].NeedsConversion() = %v, want %v", i, got, needs)
+		}
+	}
+	if entries[2].NeedsConversion("mp3_22050_32") || !entries[2].NeedsConversion("") {
+		t.Error("NeedsConversion() of an mp3_22050_32 entry")
+	}
+
+	// The default batch format is recorded as empty, keeping old manifests valid
+	if got := generateManifest(t, jobs, NewBatchConfig("out"), "en"); got[4].OutputFormat != "" || got[4].OutputFile != "out/slide02_seg01_en.mp3" {
+		t.Errorf("default format entry = %+v", got[4])
+	}
+
+	// Changing a segment's format changes its content hash
+	changed := jobs[2]
+	changed.OutputFormat = "pcm_44100"
+	if contentHash(t, changed, "model-1") == contentHash(t, jobs[2], "model-1") {
+		t.Error("ContentHash() ignores the output format")
+	}
+	if fields := changedFields(&jobs[2], &changed); !slices.Equal(fields, []string{FieldFormat}) {
+		t.Errorf("changedFields() = %v", fields)
+	}
+}
+
+func TestOutputFormatHelpers(t *testing.T) {
+	for format, ext := range map[string]string{
+		"": ".mp3", "mp3_44100_128": ".mp3", "pcm_48000": ".wav", "opus_48000_96": ".opus", "ulaw_8000": ".ulaw", "alaw_8000": ".alaw",
+	} {
+		if got := FileExtension(format); got != ext {
+			t.Errorf("FileExtension(%q) = %s, want %s", format, got, ext)
+		}
+	}
+
+	for _, format := range []string{"mp3_44100_128", "pcm_48000", "opus_48000_32", "ulaw_8000"} {
+		if err := ValidateOutputFormat(format); err != nil {
+			t.Errorf("ValidateOutputFormat(%q) error = %v", format, err)
+		}
+	}
+	for _, format := range []string{"", "wav_44100", "mp3_44100", "pcm_fast", "mp3_44100_0"} {
+		if err := ValidateOutputFormat(format); err == nil {
+			t.Errorf("ValidateOutputFormat(%q) should fail", format)
+		}
+	}
+
+	args, err := FFmpegEncodeArgs("mp3_22050_32")
+	if err != nil || strings.Join(args, " ") != "-ar 22050 -ac 1 -c:a libmp3lame -b:a 32k" {
+		t.Errorf("FFmpegEncodeArgs(mp3) = %v, %v", args, err)
+	}
+	args, err = FFmpegEncodeArgs("pcm_48000")
+	if err != nil || strings.Join(args, " ") != "-ar 48000 -ac 1 -c:a pcm_s16le -f wav" {
+		t.Errorf("FFmpegEncodeArgs(pcm) = %v, %v", args, err)
+	}
+	if args, _ := FFmpegEncodeArgs(""); !slices.Contains(args, "128k") {
+		t.Errorf("FFmpegEncodeArgs(\"\") = %v, want the default format", args)
+	}
+	if _, err := FFmpegEncodeArgs("flac_44100"); err == nil {
+		t.Error("FFmpegEncodeArgs(flac) should fail")
+	}
+
+	script := &Script{Slides: []Slide{{OutputFormat: "wav", Segments: []Segment{
+		{Text: map[string]string{"en": "Hi"}, OutputFormat: "pcm_x"},
+	}}}}
+	issues := script.Validate()
+	for _, want := range []string{"slide 1 has invalid output format", "slide 1, segment 1 has invalid output format"} {
+		if !slices.ContainsFunc(issues, func(issue string) bool { return strings.Contains(issue, want) }) {
+			t.Errorf("Validate() = %v, missing %q", issues, want)
+		}
+	}
+}
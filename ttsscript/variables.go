@@ -0,0 +1,308 @@
+package ttsscript
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// variablePattern matches {{name}} placeholders in segment text.
+var variablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*\}\}`)
+
+// SubstituteVariables replaces {{name}} placeholders in text with their
+// values. It returns an error naming the first undefined variable.
+func SubstituteVariables(text string, values map[string]string) (string, error) {
+	var missing string
+	result := variablePattern.ReplaceAllStringFunc(text, func(m string) string {
+		name := variablePattern.FindStringSubmatch(m)[1]
+		v, ok := values[name]
+		if !ok {
+			if missing == "" {
+				missing = name
+			}
+			return m
+		}
+		return v
+	})
+	if missing != "" {
+		return "", fmt.Errorf("undefined variable %q", missing)
+	}
+	return result, nil
+}
+
+// VariableNames returns the names of the variables used in segment and
+// title text and in segment conditions, sorted.
+func (s *Script) VariableNames() []string {
+	names := make(map[string]bool)
+	addText := func(text string) {
+		for _, m := range variablePattern.FindAllStringSubmatch(text, -1) {
+			names[m[1]] = true
+		}
+	}
+	for _, slide := range s.Slides {
+		addText(slide.Title)
+		for _, seg := range slide.Segments {
+			for _, text := range seg.Text {
+				addText(text)
+			}
+			if cond, err := parseCondition(seg.Condition); err == nil {
+				for _, name := range cond.vars {
+					names[name] = true
+				}
+			}
+		}
+	}
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// EvaluateCondition evaluates a segment condition against variable values.
+// Conditions compare variables and quoted strings with == and !=, and
+// combine comparisons with &&, ||, !, and parentheses:
+//
+//	variant == "pro" && region != 'eu'
+//
+// A variable on its own is true unless it is empty, "false", or "0". An
+// empty condition is true. Variables used in the condition must be defined.
+func EvaluateCondition(expr string, values map[string]string) (bool, error) {
+	cond, err := parseCondition(expr)
+	if err != nil {
+		return false, err
+	}
+	return cond.eval(values)
+}
+
+// condition is a parsed segment condition.
+type condition struct {
+	eval func(values map[string]string) (bool, error)
+	vars []string
+}
+
+// condToken is a lexical token of a condition.
+type condToken struct {
+	kind  string // "ident", "string", "op", or "" at the end
+	value string
+}
+
+func tokenizeCondition(expr string) ([]condToken, error) {
+	var tokens []condToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string in condition %q", expr)
+			}
+			tokens = append(tokens, condToken{kind: "string", value: expr[i+1 : i+1+end]})
+			i += end + 2
+		case strings.HasPrefix(expr[i:], "==") || strings.HasPrefix(expr[i:], "!=") ||
+			strings.HasPrefix(expr[i:], "&&") || strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, condToken{kind: "op", value: expr[i : i+2]})
+			i += 2
+		case c == '!' || c == '(' || c == ')':
+			tokens = append(tokens, condToken{kind: "op", value: string(c)})
+			i++
+		case isIdentByte(c):
+			j := i
+			for j < len(expr) && isIdentByte(expr[j]) {
+				j++
+			}
+			tokens = append(tokens, condToken{kind: "ident", value: expr[i:j]})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected %q in condition %q", c, expr)
+		}
+	}
+	return tokens, nil
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '.' || c == '-' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// parseCondition parses a condition. Identifiers that start with a digit
+// are number literals rather than variables.
+func parseCondition(expr string) (*condition, error) {
+	if strings.TrimSpace(expr) == "" {
+		return &condition{eval: func(map[string]string) (bool, error) { return true, nil }}, nil
+	}
+	tokens, err := tokenizeCondition(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &condParser{expr: expr, tokens: tokens}
+	eval, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != "" {
+		return nil, fmt.Errorf("unexpected %q in condition %q", tok.value, expr)
+	}
+	return &condition{eval: eval, vars: p.vars}, nil
+}
+
+type (
+	boolFunc   func(values map[string]string) (bool, error)
+	stringFunc func(values map[string]string) (string, error)
+)
+
+// condParser is a recursive descent parser for conditions.
+type condParser struct {
+	expr   string
+	tokens []condToken
+	pos    int
+	vars   []string
+}
+
+func (p *condParser) peek() condToken {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return condToken{}
+}
+
+func (p *condParser) next() condToken {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *condParser) or() (boolFunc, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == (condToken{kind: "op", value: "||"}) {
+		p.next()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(values map[string]string) (bool, error) {
+			if ok, err := l(values); ok || err != nil {
+				return ok, err
+			}
+			return right(values)
+		}
+	}
+	return left, nil
+}
+
+func (p *condParser) and() (boolFunc, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == (condToken{kind: "op", value: "&&"}) {
+		p.next()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(values map[string]string) (bool, error) {
+			if ok, err := l(values); !ok || err != nil {
+				return ok, err
+			}
+			return right(values)
+		}
+	}
+	return left, nil
+}
+
+func (p *condParser) unary() (boolFunc, error) {
+	if p.peek() == (condToken{kind: "op", value: "!"}) {
+		p.next()
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(values map[string]string) (bool, error) {
+			ok, err := operand(values)
+			return !ok, err
+		}, nil
+	}
+	if p.peek() == (condToken{kind: "op", value: "("}) {
+		p.next()
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok != (condToken{kind: "op", value: ")"}) {
+			return nil, fmt.Errorf("missing ) in condition %q", p.expr)
+		}
+		return inner, nil
+	}
+
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	op := p.peek()
+	if op.kind != "op" || op.value != "==" && op.value != "!=" {
+		return func(values map[string]string) (bool, error) {
+			v, err := left(values)
+			return v != "" && v != "false" && v != "0", err
+		}, nil
+	}
+	p.next()
+	right, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	equal := op.value == "=="
+	return func(values map[string]string) (bool, error) {
+		l, err := left(values)
+		if err != nil {
+			return false, err
+		}
+		r, err := right(values)
+		if err != nil {
+			return false, err
+		}
+		return (l == r) == equal, nil
+	}, nil
+}
+
+func (p *condParser) operand() (stringFunc, error) {
+	tok := p.next()
+	switch {
+	case tok.kind == "string", tok.kind == "ident" && tok.value[0] >= '0' && tok.value[0] <= '9':
+		return func(map[string]string) (string, error) { return tok.value, nil }, nil
+	case tok.kind == "ident":
+		name := tok.value
+		p.vars = append(p.vars, name)
+		return func(values map[string]string) (string, error) {
+			v, ok := values[name]
+			if !ok {
+				return "", fmt.Errorf("undefined variable %q in condition", name)
+			}
+			return v, nil
+		}, nil
+	case tok.kind == "":
+		return nil, fmt.Errorf("unexpected end of condition %q", p.expr)
+	default:
+		return nil, fmt.Errorf("unexpected %q in condition %q", tok.value, p.expr)
+	}
+}
+
+// variableValues merges script defaults with compiler values.
+func variableValues(script, compiler map[string]string) map[string]string {
+	values := make(map[string]string, len(script)+len(compiler))
+	for k, v := range script {
+		values[k] = v
+	}
+	for k, v := range compiler {
+		values[k] = v
+	}
+	return values
+}
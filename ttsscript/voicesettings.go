@@ -0,0 +1,78 @@
+package ttsscript
+
+import "fmt"
+
+// VoiceSettings contains optional voice parameter overrides.
+// Settings can be set at the script, slide, and segment level; nil fields
+// are inherited from the enclosing level.
+type VoiceSettings struct {
+	// Stability determines how stable the voice is (0.0 to 1.0).
+	Stability *float64 `json:"stability,omitempty"`
+
+	// SimilarityBoost determines how closely the voice adheres to the original (0.0 to 1.0).
+	SimilarityBoost *float64 `json:"similarity_boost,omitempty"`
+
+	// Style determines the style exaggeration (0.0 to 1.0).
+	Style *float64 `json:"style,omitempty"`
+
+	// Speed adjusts the speaking speed (0.25 to 4.0, 1.0 is normal).
+	Speed *float64 `json:"speed,omitempty"`
+
+	// UseSpeakerBoost boosts similarity to the original speaker.
+	UseSpeakerBoost *bool `json:"use_speaker_boost,omitempty"`
+}
+
+// Merge returns a new VoiceSettings with the non-nil fields of override
+// applied on top of v. Either side may be nil. Returns nil if both are nil.
+func (v *VoiceSettings) Merge(override *VoiceSettings) *VoiceSettings {
+	if v == nil && override == nil {
+		return nil
+	}
+
+	merged := &VoiceSettings{}
+	if v != nil {
+		*merged = *v
+	}
+	if override == nil {
+		return merged
+	}
+
+	if override.Stability != nil {
+		merged.Stability = override.Stability
+	}
+	if override.SimilarityBoost != nil {
+		merged.SimilarityBoost = override.SimilarityBoost
+	}
+	if override.Style != nil {
+		merged.Style = override.Style
+	}
+	if override.Speed != nil {
+		merged.Speed = override.Speed
+	}
+	if override.UseSpeakerBoost != nil {
+		merged.UseSpeakerBoost = override.UseSpeakerBoost
+	}
+	return merged
+}
+
+// Validate checks that all set values are within range. NaN is never in
+// range.
+func (v *VoiceSettings) Validate() []string {
+	if v == nil {
+		return nil
+	}
+
+	var issues []string
+	checkRange := func(name string, value *float64, minVal, maxVal float64) {
+		if value != nil && !(*value >= minVal && *value <= maxVal) {
+			issues = append(issues, fmt.Sprintf("%s must be between %.2f and %.2f, got %.2f", name, minVal, maxVal, *value))
+		}
+	}
+
+	checkRange("stability", v.Stability, 0, 1)
+	checkRange("similarity_boost", v.SimilarityBoost, 0, 1)
+	checkRange("style", v.Style, 0, 1)
+	checkRange("speed", v.Speed, 0.25, 4.0)
+
+	return issues
+}
@@ -0,0 +1,84 @@
+package elevenlabs
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TTSWord is a word with its timing in the generated audio, merged from
+// character alignments.
+type TTSWord struct {
+	// Text is the word, including attached punctuation.
+	Text string
+
+	// Start is the start time of the first character in seconds.
+	Start float64
+
+	// End is the end time of the last character in seconds.
+	End float64
+}
+
+// Duration returns the time the word is spoken, in seconds.
+func (w TTSWord) Duration() float64 {
+	return w.End - w.Start
+}
+
+// AggregateWords merges character alignments into words, splitting on
+// whitespace. Pass all alignments of a generation in order, since a word
+// can span two alignments.
+func AggregateWords(alignments ...*TTSAlignment) []TTSWord {
+	var agg wordAggregator
+	var words []TTSWord
+	for _, a := range alignments {
+		words = append(words, agg.add(a)...)
+	}
+	return append(words, agg.flush()...)
+}
+
+// wordAggregator merges streamed character alignments into words. A word
+// is complete when whitespace follows it or the stream is flushed.
+type wordAggregator struct {
+	text    strings.Builder
+	start   float64
+	end     float64
+	pending bool
+}
+
+// add adds an alignment and returns the words it completes.
+func (a *wordAggregator) add(alignment *TTSAlignment) []TTSWord {
+	if alignment == nil {
+		return nil
+	}
+	var words []TTSWord
+	for i, char := range alignment.Characters {
+		if strings.TrimFunc(char, unicode.IsSpace) == "" {
+			words = append(words, a.flush()...)
+			continue
+		}
+		var start, end float64
+		if i < len(alignment.CharacterStart) {
+			start = alignment.CharacterStart[i]
+		}
+		if i < len(alignment.CharacterEnd) {
+			end = alignment.CharacterEnd[i]
+		}
+		if !a.pending {
+			a.start = start
+			a.pending = true
+		}
+		a.text.WriteString(char)
+		a.end = max(a.end, end)
+	}
+	return words
+}
+
+// flush returns the word in progress, if any.
+func (a *wordAggregator) flush() []TTSWord {
+	if !a.pending {
+		return nil
+	}
+	word := TTSWord{Text: a.text.String(), Start: a.start, End: a.end}
+	a.text.Reset()
+	a.start, a.end, a.pending = 0, 0, false
+	return []TTSWord{word}
+}
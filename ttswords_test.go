@@ -0,0 +1,174 @@
+package elevenlabs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestAggregateWords(t *testing.T) {
+	// "Hi, there." split mid-word across two alignments
+	first := &TTSAlignment{
+		Characters:     []string{"H", "i", ",", " ", "t", "h"},
+		CharacterStart: []float64{0.0, 0.1, 0.2, 0.3, 0.4, 0.5},
+		CharacterEnd:   []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6},
+	}
+	second := &TTSAlignment{
+		Characters:     []string{"e", "r", "e", "."},
+		CharacterStart: []float64{0.6, 0.7, 0.8, 0.9},
+		CharacterEnd:   []float64{0.7, 0.8, 0.9, 1.0},
+	}
+
+	got := AggregateWords(first, nil, second)
+	want := []TTSWord{
+		{Text: "Hi,", Start: 0.0, End: 0.3},
+		{Text: "there.", Start: 0.4, End: 1.0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AggregateWords() = %+v, want %+v", got, want)
+	}
+
+	if words := AggregateWords(&TTSAlignment{Characters: []string{" ", "\n"}}); len(words) != 0 {
+		t.Errorf("AggregateWords() of whitespace = %+v", words)
+	}
+}
+
+func TestWebSocketTTSWords(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			var msg ttsWSMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg.Flush {
+				_ = conn.WriteJSON(map[string]any{
+					"audio": "AAEC",
+					"normalizedAlignment": map[string]any{
+						"characters":                    []string{"o", "k", " ", "g", "o"},
+						"character_start_times_seconds": []float64{0, 0.1, 0.2, 0.3, 0.4},
+						"character_end_times_seconds":   []float64{0.1, 0.2, 0.3, 0.4, 0.5},
+					},
+				})
+				_ = conn.WriteJSON(map[string]any{"isFinal": true})
+			}
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	opts := DefaultWebSocketTTSOptions()
+	opts.WordAlignments = true
+	conn, err := client.WebSocketTTS().Connect(context.Background(), "voice-id", opts)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.SendText("ok go"); err != nil {
+		t.Fatalf("SendText() error = %v", err)
+	}
+	if err := conn.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	// The last word is sent when the generation is final
+	var got []string
+	for len(got) < 2 {
+		select {
+		case word := <-conn.Words():
+			got = append(got, word.Text)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received words %v, want [ok go]", got)
+		}
+	}
+	if got[0] != "ok" || got[1] != "go" {
+		t.Errorf("words = %v, want [ok go]", got)
+	}
+}
+
+func TestWebSocketTTSWordsClosed(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			var msg ttsWSMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg.Flush {
+				_ = conn.WriteJSON(map[string]any{
+					"audio": "AAEC",
+					"alignment": map[string]any{
+						"characters":                    []string{"H", "i", " ", "y", "o"},
+						"character_start_times_seconds": []float64{0, 0.1, 0.2, 0.3, 0.4},
+						"character_end_times_seconds":   []float64{0.1, 0.2, 0.3, 0.4, 0.5},
+					},
+				})
+				_ = conn.WriteJSON(map[string]any{"isFinal": true})
+				closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+				_ = conn.WriteMessage(websocket.CloseMessage, closeMsg)
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	opts := DefaultWebSocketTTSOptions()
+	opts.WordAlignments = true
+	conn, err := client.WebSocketTTS().Connect(context.Background(), "voice-id", opts)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.SendText("Hi yo"); err != nil {
+		t.Fatalf("SendText() error = %v", err)
+	}
+	if err := conn.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	go func() {
+		for range conn.Audio() {
+		}
+	}()
+
+	// Words is closed once the stream ends
+	done := make(chan []TTSWord)
+	go func() {
+		var words []TTSWord
+		for w := range conn.Words() {
+			words = append(words, w)
+		}
+		done <- words
+	}()
+	select {
+	case words := <-done:
+		if len(words) != 2 || words[0].Text != "Hi" || words[1].Text != "yo" || words[1].End != 0.5 {
+			t.Errorf("Words() = %+v", words)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Words() not closed after the stream ended")
+	}
+}
@@ -16,40 +16,7 @@ type TwilioService struct {
 
 // postJSON is a helper for making JSON POST requests.
 func (s *TwilioService) postJSON(ctx context.Context, path string, req any, result any) error {
-	body, err := json.Marshal(req)
-	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, "POST",
-		s.client.baseURL+path,
-		bytes.NewReader(body))
-	if err != nil {
-		return err
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("xi-api-key", s.client.apiKey)
-
-	resp, err := http.DefaultClient.Do(httpReq)
-	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		respBody, _ := io.ReadAll(resp.Body)
-		return &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(respBody),
-		}
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return nil
+	return s.client.doJSON(ctx, http.MethodPost, path, req, result)
 }
 
 // TwilioRegisterCallRequest is the request to register an incoming Twilio call.
@@ -60,16 +27,28 @@ type TwilioRegisterCallRequest struct {
 	// AgentPhoneNumberID is the ElevenLabs phone number ID (if using imported number).
 	AgentPhoneNumberID string `json:"agent_phone_number_id,omitempty"`
 
+	// InitiationData sets dynamic variables and configuration overrides
+	// for the conversation.
+	InitiationData *ConversationInitiationData `json:"conversation_initiation_client_data,omitempty"`
+
 	// CustomLLMExtraBody is additional data to pass to the LLM.
+	//
+	// Deprecated: Use InitiationData.CustomLLMExtraBody.
 	CustomLLMExtraBody map[string]any `json:"custom_llm_extra_body,omitempty"`
 
 	// DynamicVariables are variables to inject into the agent prompt.
+	//
+	// Deprecated: Use InitiationData.DynamicVariables.
 	DynamicVariables map[string]string `json:"dynamic_variables,omitempty"`
 
 	// FirstMessage overrides the agent's default first message.
+	//
+	// Deprecated: Use InitiationData.FirstMessage.
 	FirstMessage string `json:"first_message,omitempty"`
 
 	// SystemPrompt overrides the agent's system prompt.
+	//
+	// Deprecated: Use InitiationData.Prompt.
 	SystemPrompt string `json:"system_prompt,omitempty"`
 }
 
@@ -93,16 +72,28 @@ type TwilioOutboundCallRequest struct {
 	// ToNumber is the phone number to call (E.164 format).
 	ToNumber string `json:"to_number"`
 
+	// InitiationData sets dynamic variables and configuration overrides
+	// for the conversation.
+	InitiationData *ConversationInitiationData `json:"conversation_initiation_client_data,omitempty"`
+
 	// CustomLLMExtraBody is additional data to pass to the LLM.
+	//
+	// Deprecated: Use InitiationData.CustomLLMExtraBody.
 	CustomLLMExtraBody map[string]any `json:"custom_llm_extra_body,omitempty"`
 
 	// DynamicVariables are variables to inject into the agent prompt.
+	//
+	// Deprecated: Use InitiationData.DynamicVariables.
 	DynamicVariables map[string]string `json:"dynamic_variables,omitempty"`
 
 	// FirstMessage overrides the agent's default first message.
+	//
+	// Deprecated: Use InitiationData.FirstMessage.
 	FirstMessage string `json:"first_message,omitempty"`
 
 	// SystemPrompt overrides the agent's system prompt.
+	//
+	// Deprecated: Use InitiationData.Prompt.
 	SystemPrompt string `json:"system_prompt,omitempty"`
 }
 
@@ -132,16 +123,28 @@ type SIPOutboundCallRequest struct {
 	// FromNumber is the caller ID to display (must be verified).
 	FromNumber string `json:"from_number,omitempty"`
 
+	// InitiationData sets dynamic variables and configuration overrides
+	// for the conversation.
+	InitiationData *ConversationInitiationData `json:"conversation_initiation_client_data,omitempty"`
+
 	// CustomLLMExtraBody is additional data to pass to the LLM.
+	//
+	// Deprecated: Use InitiationData.CustomLLMExtraBody.
 	CustomLLMExtraBody map[string]any `json:"custom_llm_extra_body,omitempty"`
 
 	// DynamicVariables are variables to inject into the agent prompt.
+	//
+	// Deprecated: Use InitiationData.DynamicVariables.
 	DynamicVariables map[string]string `json:"dynamic_variables,omitempty"`
 
 	// FirstMessage overrides the agent's default first message.
+	//
+	// Deprecated: Use InitiationData.FirstMessage.
 	FirstMessage string `json:"first_message,omitempty"`
 
 	// SystemPrompt overrides the agent's system prompt.
+	//
+	// Deprecated: Use InitiationData.Prompt.
 	SystemPrompt string `json:"system_prompt,omitempty"`
 }
 
@@ -161,6 +164,12 @@ func (s *TwilioService) RegisterCall(ctx context.Context, req *TwilioRegisterCal
 		return nil, &APIError{Message: "agent_id is required"}
 	}
 
+	if req.InitiationData != nil {
+		if err := req.InitiationData.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	var result TwilioRegisterCallResponse
 	if err := s.postJSON(ctx, "/v1/convai/twilio/register-call", req, &result); err != nil {
 		return nil, err
@@ -180,6 +189,12 @@ func (s *TwilioService) OutboundCall(ctx context.Context, req *TwilioOutboundCal
 		return nil, &APIError{Message: "to_number is required"}
 	}
 
+	if req.InitiationData != nil {
+		if err := req.InitiationData.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	var result TwilioOutboundCallResponse
 	if err := s.postJSON(ctx, "/v1/convai/twilio/outbound-call", req, &result); err != nil {
 		return nil, err
@@ -199,6 +214,12 @@ func (s *TwilioService) SIPOutboundCall(ctx context.Context, req *SIPOutboundCal
 		return nil, &APIError{Message: "to_number is required"}
 	}
 
+	if req.InitiationData != nil {
+		if err := req.InitiationData.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	var result SIPOutboundCallResponse
 	if err := s.postJSON(ctx, "/v1/convai/sip-trunk/outbound-call", req, &result); err != nil {
 		return nil, err
@@ -236,9 +257,7 @@ func (s *PhoneNumberService) List(ctx context.Context) ([]PhoneNumber, error) {
 		return nil, err
 	}
 
-	httpReq.Header.Set("xi-api-key", s.client.apiKey)
-
-	resp, err := http.DefaultClient.Do(httpReq)
+	resp, err := s.client.httpClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -246,10 +265,9 @@ func (s *PhoneNumberService) List(ctx context.Context) ([]PhoneNumber, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(respBody),
-		}
+		apiErr := newAPIError(resp.StatusCode, respBody)
+		apiErr.setResponse(resp)
+		return nil, apiErr
 	}
 
 	var result ListPhoneNumbersResponse
@@ -273,9 +291,7 @@ func (s *PhoneNumberService) Get(ctx context.Context, phoneNumberID string) (*Ph
 		return nil, err
 	}
 
-	httpReq.Header.Set("xi-api-key", s.client.apiKey)
-
-	resp, err := http.DefaultClient.Do(httpReq)
+	resp, err := s.client.httpClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -283,10 +299,9 @@ func (s *PhoneNumberService) Get(ctx context.Context, phoneNumberID string) (*Ph
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(respBody),
-		}
+		apiErr := newAPIError(resp.StatusCode, respBody)
+		apiErr.setResponse(resp)
+		return nil, apiErr
 	}
 
 	var result PhoneNumber
@@ -325,9 +340,7 @@ func (s *PhoneNumberService) Update(ctx context.Context, phoneNumberID string, r
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("xi-api-key", s.client.apiKey)
-
-	resp, err := http.DefaultClient.Do(httpReq)
+	resp, err := s.client.httpClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -335,10 +348,9 @@ func (s *PhoneNumberService) Update(ctx context.Context, phoneNumberID string, r
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(respBody),
-		}
+		apiErr := newAPIError(resp.StatusCode, respBody)
+		apiErr.setResponse(resp)
+		return nil, apiErr
 	}
 
 	var result PhoneNumber
@@ -362,9 +374,7 @@ func (s *PhoneNumberService) Delete(ctx context.Context, phoneNumberID string) e
 		return err
 	}
 
-	httpReq.Header.Set("xi-api-key", s.client.apiKey)
-
-	resp, err := http.DefaultClient.Do(httpReq)
+	resp, err := s.client.httpClient.Do(httpReq)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
@@ -372,10 +382,9 @@ func (s *PhoneNumberService) Delete(ctx context.Context, phoneNumberID string) e
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
 		respBody, _ := io.ReadAll(resp.Body)
-		return &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(respBody),
-		}
+		apiErr := newAPIError(resp.StatusCode, respBody)
+		apiErr.setResponse(resp)
+		return apiErr
 	}
 
 	return nil
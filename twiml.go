@@ -0,0 +1,240 @@
+package elevenlabs
+
+import (
+	"encoding/xml"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+)
+
+// TwiMLContentType is the content type of TwiML responses.
+const TwiMLContentType = "application/xml"
+
+// TwiMLVerb is an element of a TwiML response, such as TwiMLSay or
+// TwiMLDial.
+type TwiMLVerb interface {
+	twimlVerb()
+}
+
+// TwiMLResponse builds a TwiML document to return from a Twilio webhook,
+// for example before or instead of connecting a call to an agent:
+//
+//	twiml := elevenlabs.NewTwiMLResponse().
+//	    Say("Sorry, no agent is available.").
+//	    Hangup()
+//	twiml.ServeHTTP(w, r)
+type TwiMLResponse struct {
+	XMLName xml.Name    `xml:"Response"`
+	Verbs   []TwiMLVerb `xml:",any"`
+}
+
+// NewTwiMLResponse creates an empty TwiML response.
+func NewTwiMLResponse() *TwiMLResponse {
+	return &TwiMLResponse{}
+}
+
+// Append adds verbs to the response.
+func (r *TwiMLResponse) Append(verbs ...TwiMLVerb) *TwiMLResponse {
+	r.Verbs = append(r.Verbs, verbs...)
+	return r
+}
+
+// Say speaks text to the caller.
+func (r *TwiMLResponse) Say(text string) *TwiMLResponse {
+	return r.Append(&TwiMLSay{Text: text})
+}
+
+// Play plays the audio at url to the caller.
+func (r *TwiMLResponse) Play(url string) *TwiMLResponse {
+	return r.Append(&TwiMLPlay{URL: url})
+}
+
+// Pause waits for seconds.
+func (r *TwiMLResponse) Pause(seconds int) *TwiMLResponse {
+	return r.Append(&TwiMLPause{Length: seconds})
+}
+
+// Dial connects the caller to a phone number.
+func (r *TwiMLResponse) Dial(number string) *TwiMLResponse {
+	return r.Append(&TwiMLDial{Targets: []TwiMLVerb{&TwiMLNumber{Number: number}}})
+}
+
+// ConnectStream connects the call audio to a WebSocket stream, such as an
+// agent's media stream endpoint. Parameters are passed to the stream's
+// start message.
+func (r *TwiMLResponse) ConnectStream(url string, parameters map[string]string) *TwiMLResponse {
+	stream := &TwiMLStream{URL: url}
+	for _, name := range slices.Sorted(maps.Keys(parameters)) {
+		stream.Parameters = append(stream.Parameters, TwiMLParameter{Name: name, Value: parameters[name]})
+	}
+	return r.Append(&TwiMLConnect{Stream: stream})
+}
+
+// Redirect continues the call with the TwiML at url.
+func (r *TwiMLResponse) Redirect(url string) *TwiMLResponse {
+	return r.Append(&TwiMLRedirect{URL: url})
+}
+
+// Hangup ends the call.
+func (r *TwiMLResponse) Hangup() *TwiMLResponse {
+	return r.Append(&TwiMLHangup{})
+}
+
+// Bytes returns the XML document.
+func (r *TwiMLResponse) Bytes() ([]byte, error) {
+	output, err := xml.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("generating TwiML: %w", err)
+	}
+	return append([]byte(xml.Header), output...), nil
+}
+
+// String returns the XML document, or "" if it cannot be encoded.
+func (r *TwiMLResponse) String() string {
+	b, err := r.Bytes()
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// ServeHTTP writes the response as a TwiML document.
+func (r *TwiMLResponse) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	b, err := r.Bytes()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", TwiMLContentType)
+	_, _ = w.Write(b)
+}
+
+// TwiMLSay speaks text to the caller.
+type TwiMLSay struct {
+	XMLName  xml.Name `xml:"Say"`
+	Text     string   `xml:",chardata"`
+	Voice    string   `xml:"voice,attr,omitempty"`
+	Language string   `xml:"language,attr,omitempty"`
+	Loop     int      `xml:"loop,attr,omitempty"`
+}
+
+// TwiMLPlay plays audio to the caller.
+type TwiMLPlay struct {
+	XMLName xml.Name `xml:"Play"`
+	URL     string   `xml:",chardata"`
+	Loop    int      `xml:"loop,attr,omitempty"`
+	Digits  string   `xml:"digits,attr,omitempty"`
+}
+
+// TwiMLPause waits before the next verb.
+type TwiMLPause struct {
+	XMLName xml.Name `xml:"Pause"`
+	Length  int      `xml:"length,attr,omitempty"`
+}
+
+// TwiMLGather collects digits or speech from the caller, optionally while
+// playing prompts, and posts the result to Action.
+type TwiMLGather struct {
+	XMLName       xml.Name `xml:"Gather"`
+	Input         string   `xml:"input,attr,omitempty"` // "dtmf", "speech", or "dtmf speech"
+	Action        string   `xml:"action,attr,omitempty"`
+	Method        string   `xml:"method,attr,omitempty"`
+	Timeout       int      `xml:"timeout,attr,omitempty"`
+	NumDigits     int      `xml:"numDigits,attr,omitempty"`
+	FinishOnKey   string   `xml:"finishOnKey,attr,omitempty"`
+	SpeechTimeout string   `xml:"speechTimeout,attr,omitempty"` // seconds or "auto"
+	Language      string   `xml:"language,attr,omitempty"`
+	Hints         string   `xml:"hints,attr,omitempty"`
+
+	// Prompts are TwiMLSay, TwiMLPlay, or TwiMLPause verbs played while
+	// gathering.
+	Prompts []TwiMLVerb `xml:",any"`
+}
+
+// TwiMLDial connects the caller to another party.
+type TwiMLDial struct {
+	XMLName        xml.Name `xml:"Dial"`
+	CallerID       string   `xml:"callerId,attr,omitempty"`
+	Timeout        int      `xml:"timeout,attr,omitempty"`
+	TimeLimit      int      `xml:"timeLimit,attr,omitempty"`
+	Action         string   `xml:"action,attr,omitempty"`
+	Method         string   `xml:"method,attr,omitempty"`
+	Record         string   `xml:"record,attr,omitempty"` // e.g., "record-from-answer"
+	AnswerOnBridge bool     `xml:"answerOnBridge,attr,omitempty"`
+
+	// Targets are TwiMLNumber, TwiMLSip, or TwiMLConference nouns.
+	Targets []TwiMLVerb `xml:",any"`
+}
+
+// TwiMLNumber is a phone number to dial.
+type TwiMLNumber struct {
+	XMLName    xml.Name `xml:"Number"`
+	Number     string   `xml:",chardata"`
+	SendDigits string   `xml:"sendDigits,attr,omitempty"`
+}
+
+// TwiMLSip is a SIP URI to dial.
+type TwiMLSip struct {
+	XMLName  xml.Name `xml:"Sip"`
+	URI      string   `xml:",chardata"`
+	Username string   `xml:"username,attr,omitempty"`
+	Password string   `xml:"password,attr,omitempty"`
+}
+
+// TwiMLConference joins the caller to a named conference room.
+type TwiMLConference struct {
+	XMLName                xml.Name `xml:"Conference"`
+	Name                   string   `xml:",chardata"`
+	StartConferenceOnEnter *bool    `xml:"startConferenceOnEnter,attr,omitempty"`
+	EndConferenceOnExit    *bool    `xml:"endConferenceOnExit,attr,omitempty"`
+	Muted                  bool     `xml:"muted,attr,omitempty"`
+	Beep                   string   `xml:"beep,attr,omitempty"` // "true", "false", "onEnter", "onExit"
+	WaitURL                string   `xml:"waitUrl,attr,omitempty"`
+	StatusCallback         string   `xml:"statusCallback,attr,omitempty"`
+}
+
+// TwiMLConnect connects the call to a media stream.
+type TwiMLConnect struct {
+	XMLName xml.Name     `xml:"Connect"`
+	Action  string       `xml:"action,attr,omitempty"`
+	Stream  *TwiMLStream `xml:"Stream"`
+}
+
+// TwiMLStream is a bidirectional media stream over WebSocket.
+type TwiMLStream struct {
+	URL        string           `xml:"url,attr"`
+	Name       string           `xml:"name,attr,omitempty"`
+	Track      string           `xml:"track,attr,omitempty"`
+	Parameters []TwiMLParameter `xml:"Parameter"`
+}
+
+// TwiMLParameter is a custom parameter passed to a stream.
+type TwiMLParameter struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// TwiMLRedirect continues the call with TwiML from another URL.
+type TwiMLRedirect struct {
+	XMLName xml.Name `xml:"Redirect"`
+	URL     string   `xml:",chardata"`
+	Method  string   `xml:"method,attr,omitempty"`
+}
+
+// TwiMLHangup ends the call.
+type TwiMLHangup struct {
+	XMLName xml.Name `xml:"Hangup"`
+}
+
+func (*TwiMLSay) twimlVerb()        {}
+func (*TwiMLPlay) twimlVerb()       {}
+func (*TwiMLPause) twimlVerb()      {}
+func (*TwiMLGather) twimlVerb()     {}
+func (*TwiMLDial) twimlVerb()       {}
+func (*TwiMLNumber) twimlVerb()     {}
+func (*TwiMLSip) twimlVerb()        {}
+func (*TwiMLConference) twimlVerb() {}
+func (*TwiMLConnect) twimlVerb()    {}
+func (*TwiMLRedirect) twimlVerb()   {}
+func (*TwiMLHangup) twimlVerb()     {}
@@ -0,0 +1,57 @@
+package elevenlabs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTwiMLResponse(t *testing.T) {
+	twiml := NewTwiMLResponse().
+		Say("Hi & welcome <caller>").
+		Append(&TwiMLGather{
+			Input:     "dtmf speech",
+			Action:    "/gather",
+			NumDigits: 1,
+			Prompts:   []TwiMLVerb{&TwiMLSay{Text: "Press 1 for sales.", Voice: "Polly.Amy"}},
+		}).
+		ConnectStream("wss://example.com/stream", map[string]string{"b": "2", "a": "1"}).
+		Dial("+15551234567").
+		Hangup()
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<Response>` +
+		`<Say>Hi &amp; welcome &lt;caller&gt;</Say>` +
+		`<Gather input="dtmf speech" action="/gather" numDigits="1"><Say voice="Polly.Amy">Press 1 for sales.</Say></Gather>` +
+		`<Connect><Stream url="wss://example.com/stream"><Parameter name="a" value="1"></Parameter><Parameter name="b" value="2"></Parameter></Stream></Connect>` +
+		`<Dial><Number>+15551234567</Number></Dial>` +
+		`<Hangup></Hangup>` +
+		`</Response>`
+	if got := twiml.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTwiMLConference(t *testing.T) {
+	end := true
+	twiml := NewTwiMLResponse().Append(&TwiMLDial{
+		CallerID: "+15550000000",
+		Targets:  []TwiMLVerb{&TwiMLConference{Name: "support-42", EndConferenceOnExit: &end}},
+	})
+	if got := twiml.String(); !strings.Contains(got, `<Dial callerId="+15550000000"><Conference endConferenceOnExit="true">support-42</Conference></Dial>`) {
+		t.Errorf("String() = %s", got)
+	}
+}
+
+func TestTwiMLResponseServeHTTP(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewTwiMLResponse().Say("Goodbye").Hangup().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != TwiMLContentType {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "<Say>Goodbye</Say><Hangup></Hangup>") {
+		t.Errorf("body = %s", rec.Body.String())
+	}
+}
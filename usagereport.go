@@ -0,0 +1,290 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/agentplexus/go-elevenlabs/internal/api"
+)
+
+// Usage report groups.
+const (
+	UsageGroupVoice  = "voice"
+	UsageGroupModel  = "model"
+	UsageGroupAPIKey = "api_key"
+)
+
+// usageBreakdowns maps report groups to usage API breakdown types.
+var usageBreakdowns = []struct {
+	group     string
+	breakdown api.BreakdownTypes
+}{
+	{UsageGroupVoice, api.BreakdownTypesVoice},
+	{UsageGroupModel, api.BreakdownTypesModel},
+	{UsageGroupAPIKey, api.BreakdownTypesAPIKeys},
+}
+
+// UsagePeriod is the time range of a usage report.
+type UsagePeriod struct {
+	// Start is the start of the period, inclusive.
+	Start time.Time
+
+	// End is the end of the period, inclusive.
+	End time.Time
+}
+
+func (p UsagePeriod) validate() error {
+	if p.Start.IsZero() || p.End.IsZero() {
+		return &ValidationError{Field: "period", Message: "start and end are required"}
+	}
+	if p.End.Before(p.Start) {
+		return &ValidationError{Field: "period", Message: "end cannot be before start"}
+	}
+	return nil
+}
+
+// UsageMonth returns the calendar month containing t, in UTC.
+func UsageMonth(t time.Time) UsagePeriod {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return UsagePeriod{Start: start, End: start.AddDate(0, 1, 0).Add(-time.Millisecond)}
+}
+
+// UsageLine is the usage of one voice, model, or API key.
+type UsageLine struct {
+	// Group is UsageGroupVoice, UsageGroupModel, or UsageGroupAPIKey.
+	Group string `json:"group"`
+
+	// Key identifies the voice, model, or API key, as reported by the API.
+	Key string `json:"key"`
+
+	// Name is the voice name, when found in the history.
+	Name string `json:"name,omitempty"`
+
+	// Characters is the number of characters converted.
+	Characters int `json:"characters"`
+
+	// Credits is the number of credits charged, which depends on the
+	// model as well as the characters.
+	Credits int `json:"credits"`
+
+	// EstimatedCost is the cost of the credits, set by EstimateCost.
+	EstimatedCost float64 `json:"estimated_cost,omitempty"`
+}
+
+// UsageReport is the usage over a period, grouped by voice, model, and API
+// key. Lines are sorted by credits, highest first.
+type UsageReport struct {
+	// Period is the reported period.
+	Period UsagePeriod `json:"period"`
+
+	// ByVoice is the usage per voice.
+	ByVoice []UsageLine `json:"by_voice"`
+
+	// ByModel is the usage per model.
+	ByModel []UsageLine `json:"by_model"`
+
+	// ByAPIKey is the usage per API key.
+	ByAPIKey []UsageLine `json:"by_api_key"`
+
+	// TotalCharacters is the number of characters across all models.
+	TotalCharacters int `json:"total_characters"`
+
+	// TotalCredits is the number of credits across all models.
+	TotalCredits int `json:"total_credits"`
+
+	// TotalCost is the cost of TotalCredits, set by EstimateCost.
+	TotalCost float64 `json:"total_cost,omitempty"`
+}
+
+// Lines returns the lines of all groups: voices, then models, then API
+// keys.
+func (r *UsageReport) Lines() []UsageLine {
+	lines := make([]UsageLine, 0, len(r.ByVoice)+len(r.ByModel)+len(r.ByAPIKey))
+	lines = append(lines, r.ByVoice...)
+	lines = append(lines, r.ByModel...)
+	return append(lines, r.ByAPIKey...)
+}
+
+// EstimateCost sets the estimated cost of each line and the total from
+// the price of 1,000 credits, such as the overage rate of your plan.
+func (r *UsageReport) EstimateCost(pricePer1KCredits float64) {
+	for _, lines := range [][]UsageLine{r.ByVoice, r.ByModel, r.ByAPIKey} {
+		for i := range lines {
+			lines[i].EstimatedCost = float64(lines[i].Credits) * pricePer1KCredits / 1000
+		}
+	}
+	r.TotalCost = float64(r.TotalCredits) * pricePer1KCredits / 1000
+}
+
+// WriteCSV writes the report lines as CSV with a header row.
+func (r *UsageReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"group", "key", "name", "characters", "credits", "estimated_cost"}); err != nil {
+		return err
+	}
+	for _, line := range r.Lines() {
+		record := []string{
+			line.Group,
+			line.Key,
+			line.Name,
+			strconv.Itoa(line.Characters),
+			strconv.Itoa(line.Credits),
+			strconv.FormatFloat(line.EstimatedCost, 'f', 2, 64),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *UsageReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// ReportUsage returns the characters and credits used in period, grouped by
+// voice, model, and API key, for attributing costs to products or
+// customers. Voice names are looked up in the history.
+func (s *UserService) ReportUsage(ctx context.Context, period UsagePeriod) (*UsageReport, error) {
+	if err := period.validate(); err != nil {
+		return nil, err
+	}
+
+	report := &UsageReport{Period: period}
+	for _, b := range usageBreakdowns {
+		characters, err := s.usageTotals(ctx, period, b.breakdown, api.MetricTypeTtsCharacters)
+		if err != nil {
+			return nil, err
+		}
+		credits, err := s.usageTotals(ctx, period, b.breakdown, api.MetricTypeCredits)
+		if err != nil {
+			return nil, err
+		}
+		lines := usageLines(b.group, characters, credits)
+
+		switch b.group {
+		case UsageGroupVoice:
+			report.ByVoice = lines
+		case UsageGroupModel:
+			report.ByModel = lines
+			for _, line := range lines {
+				report.TotalCharacters += line.Characters
+				report.TotalCredits += line.Credits
+			}
+		case UsageGroupAPIKey:
+			report.ByAPIKey = lines
+		}
+	}
+
+	names, err := s.historyVoiceNames(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+	for i := range report.ByVoice {
+		report.ByVoice[i].Name = names[report.ByVoice[i].Key]
+	}
+	return report, nil
+}
+
+// usageTotals returns the total of metric over period for each key of the
+// breakdown.
+func (s *UserService) usageTotals(ctx context.Context, period UsagePeriod, breakdown api.BreakdownTypes, metric api.MetricType) (map[string]float64, error) {
+	resp, err := s.client.apiClient.UsageCharacters(ctx, api.UsageCharactersParams{
+		StartUnix:           int(period.Start.UnixMilli()),
+		EndUnix:             int(period.End.UnixMilli()),
+		BreakdownType:       api.NewOptBreakdownTypes(breakdown),
+		AggregationInterval: api.NewOptUsageAggregationInterval(api.UsageAggregationIntervalCumulative),
+		Metric:              api.NewOptMetricType(metric),
+	})
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.UsageCharactersResponseModel:
+		totals := make(map[string]float64, len(r.Usage))
+		for key, values := range r.Usage {
+			for _, v := range values {
+				totals[key] += v
+			}
+		}
+		return totals, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
+	default:
+		return nil, unexpectedResponse(resp)
+	}
+}
+
+// historyVoiceNames returns the names of the voices used in period, by
+// voice ID. History is listed newest first, so listing stops at the first
+// item before the period.
+func (s *UserService) historyVoiceNames(ctx context.Context, period UsagePeriod) (map[string]string, error) {
+	names := make(map[string]string)
+	opts := &HistoryListOptions{PageSize: 100}
+	for {
+		page, err := s.client.History().List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			if item.CreatedAt.Before(period.Start) {
+				return names, nil
+			}
+			if item.VoiceID != "" && item.VoiceName != "" && !item.CreatedAt.After(period.End) {
+				names[item.VoiceID] = item.VoiceName
+			}
+		}
+		if !page.HasMore || page.LastHistoryItemID == "" {
+			return names, nil
+		}
+		opts.StartAfterHistoryItemID = page.LastHistoryItemID
+	}
+}
+
+// usageLines merges character and credit totals into lines sorted by
+// credits, then characters, then key.
+func usageLines(group string, characters, credits map[string]float64) []UsageLine {
+	keys := make(map[string]bool, len(characters))
+	for key := range characters {
+		keys[key] = true
+	}
+	for key := range credits {
+		keys[key] = true
+	}
+
+	lines := make([]UsageLine, 0, len(keys))
+	for key := range keys {
+		line := UsageLine{
+			Group:      group,
+			Key:        key,
+			Characters: int(math.Round(characters[key])),
+			Credits:    int(math.Round(credits[key])),
+		}
+		if line.Characters == 0 && line.Credits == 0 {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].Credits != lines[j].Credits {
+			return lines[i].Credits > lines[j].Credits
+		}
+		if lines[i].Characters != lines[j].Characters {
+			return lines[i].Characters > lines[j].Characters
+		}
+		return lines[i].Key < lines[j].Key
+	})
+	return lines
+}
@@ -0,0 +1,115 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUserReportUsage(t *testing.T) {
+	usage := map[string]string{
+		"voice/tts_characters":    `{"v1": [1200, 300], "v2": [500]}`,
+		"voice/credits":           `{"v1": [1500], "v2": [250]}`,
+		"model/tts_characters":    `{"eleven_multilingual_v2": [1500], "eleven_flash_v2_5": [500]}`,
+		"model/credits":           `{"eleven_multilingual_v2": [1500], "eleven_flash_v2_5": [250]}`,
+		"api_keys/tts_characters": `{"prod": [1800], "staging": [200], "unused": [0]}`,
+		"api_keys/credits":        `{"prod": [1600], "staging": [150]}`,
+	}
+	period := UsageMonth(time.Date(2023, 11, 14, 12, 0, 0, 0, time.UTC))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/v1/usage/character-stats":
+			if q.Get("start_unix") != fmt.Sprint(period.Start.UnixMilli()) || q.Get("aggregation_interval") != "cumulative" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			body, ok := usage[q.Get("breakdown_type")+"/"+q.Get("metric")]
+			if !ok {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			fmt.Fprintf(w, `{"time": [%d], "usage": %s}`, period.Start.Unix(), body)
+		case "/v1/history":
+			// Newest first; the last item is before the period
+			fmt.Fprint(w, `{"has_more": true, "last_history_item_id": "h3", "history": [
+				{"history_item_id": "h1", "date_unix": 1700000000, "character_count_change_from": 0, "character_count_change_to": 5, "content_type": "audio/mpeg", "state": "created", "voice_id": "v1", "voice_name": "Rachel"},
+				{"history_item_id": "h2", "date_unix": 1699500000, "character_count_change_from": 0, "character_count_change_to": 5, "content_type": "audio/mpeg", "state": "created", "voice_id": "v2", "voice_name": "Adam"},
+				{"history_item_id": "h3", "date_unix": 1690000000, "character_count_change_from": 0, "character_count_change_to": 5, "content_type": "audio/mpeg", "state": "created", "voice_id": "v3", "voice_name": "Old"}
+			]}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	report, err := client.User().ReportUsage(context.Background(), period)
+	if err != nil {
+		t.Fatalf("ReportUsage() error = %v", err)
+	}
+
+	wantVoices := []UsageLine{
+		{Group: UsageGroupVoice, Key: "v1", Name: "Rachel", Characters: 1500, Credits: 1500},
+		{Group: UsageGroupVoice, Key: "v2", Name: "Adam", Characters: 500, Credits: 250},
+	}
+	if fmt.Sprint(report.ByVoice) != fmt.Sprint(wantVoices) {
+		t.Errorf("ByVoice = %+v, want %+v", report.ByVoice, wantVoices)
+	}
+	if len(report.ByModel) != 2 || report.ByModel[0].Key != "eleven_multilingual_v2" {
+		t.Errorf("ByModel = %+v", report.ByModel)
+	}
+	if len(report.ByAPIKey) != 2 || report.ByAPIKey[1].Key != "staging" {
+		t.Errorf("ByAPIKey = %+v", report.ByAPIKey)
+	}
+	if report.TotalCharacters != 2000 || report.TotalCredits != 1750 {
+		t.Errorf("totals = %d characters, %d credits", report.TotalCharacters, report.TotalCredits)
+	}
+
+	report.EstimateCost(0.30)
+	if report.TotalCost != 0.525 || report.ByAPIKey[0].EstimatedCost != 0.48 {
+		t.Errorf("TotalCost = %v, prod cost = %v", report.TotalCost, report.ByAPIKey[0].EstimatedCost)
+	}
+
+	var csvOut bytes.Buffer
+	if err := report.WriteCSV(&csvOut); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	if len(lines) != 7 || lines[0] != "group,key,name,characters,credits,estimated_cost" || lines[1] != "voice,v1,Rachel,1500,1500,0.45" {
+		t.Errorf("WriteCSV() =\n%s", csvOut.String())
+	}
+
+	var jsonOut bytes.Buffer
+	if err := report.WriteJSON(&jsonOut); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var decoded UsageReport
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil || decoded.TotalCredits != 1750 || len(decoded.ByVoice) != 2 {
+		t.Errorf("WriteJSON() = %s, %v", jsonOut.String(), err)
+	}
+}
+
+func TestUserReportUsageValidation(t *testing.T) {
+	client, _ := NewClient()
+	ctx := context.Background()
+	var valErr *ValidationError
+
+	if _, err := client.User().ReportUsage(ctx, UsagePeriod{}); !isValidationError(err, &valErr) {
+		t.Errorf("ReportUsage() without period error = %v", err)
+	}
+	now := time.Now()
+	if _, err := client.User().ReportUsage(ctx, UsagePeriod{Start: now, End: now.Add(-time.Hour)}); !isValidationError(err, &valErr) {
+		t.Errorf("ReportUsage() with end before start error = %v", err)
+	}
+}
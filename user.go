@@ -70,7 +70,7 @@ func (s *Subscription) CharactersRemaining() int {
 func (s *UserService) GetInfo(ctx context.Context) (*User, error) {
 	resp, err := s.client.apiClient.GetUserInfo(ctx, api.GetUserInfoParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	// Handle response type
@@ -103,8 +103,10 @@ func (s *UserService) GetInfo(ctx context.Context) (*User, error) {
 		}
 
 		return user, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
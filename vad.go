@@ -0,0 +1,297 @@
+package elevenlabs
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// VADOptions configures voice activity detection. Zero values use the
+// defaults.
+type VADOptions struct {
+	// FrameDuration is the length of the frames audio is classified in.
+	// Defaults to 20ms.
+	FrameDuration time.Duration
+
+	// Threshold is the minimum frame energy of speech in dBFS. It is
+	// raised automatically above the measured background noise. Defaults
+	// to -45.
+	Threshold float64
+
+	// MinSpeech is how long speech must last to start a segment, so
+	// clicks and short noises are ignored. Defaults to 60ms.
+	MinSpeech time.Duration
+
+	// MinSilence is how long silence must last to end a segment, so
+	// pauses between words do not split it. Defaults to 400ms.
+	MinSilence time.Duration
+
+	// Padding is audio kept before and after each segment, so soft word
+	// onsets and endings are not cut off. Defaults to 100ms; set a
+	// negative value for none.
+	Padding time.Duration
+}
+
+// Default voice activity detection settings.
+const (
+	defaultVADFrame      = 20 * time.Millisecond
+	defaultVADThreshold  = -45.0
+	defaultVADMinSpeech  = 60 * time.Millisecond
+	defaultVADMinSilence = 400 * time.Millisecond
+	defaultVADPadding    = 100 * time.Millisecond
+
+	// vadNoiseMargin is how far above the background noise speech must be.
+	vadNoiseMargin = 10.0
+
+	// vadMaxZeroCrossings is the zero-crossing rate above which a frame is
+	// treated as noise unless it is well above the threshold. Voiced
+	// speech crosses zero far less often than hiss.
+	vadMaxZeroCrossings = 0.4
+)
+
+// SpeechSegment is a span of speech in audio, in seconds.
+type SpeechSegment struct {
+	// Start is the start time in seconds.
+	Start float64
+
+	// End is the end time in seconds.
+	End float64
+}
+
+// Duration returns the length of the segment in seconds.
+func (s SpeechSegment) Duration() float64 {
+	return s.End - s.Start
+}
+
+// DetectSpeech returns the spans of speech in 16-bit signed little-endian
+// mono PCM, using frame energy and zero-crossing rate. It is meant for
+// cheap client-side endpointing, not as a replacement for server-side
+// voice activity detection.
+func DetectSpeech(pcm []byte, sampleRate int) ([]SpeechSegment, error) {
+	return DetectSpeechWithOptions(pcm, sampleRate, nil)
+}
+
+// DetectSpeechWithOptions is DetectSpeech with custom detection settings.
+func DetectSpeechWithOptions(pcm []byte, sampleRate int, opts *VADOptions) ([]SpeechSegment, error) {
+	v, err := NewVAD(sampleRate, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(pcm)%2 != 0 {
+		return nil, fmt.Errorf("PCM data has odd length %d", len(pcm))
+	}
+
+	frame := time.Duration(v.frameBytes/2) * time.Second / time.Duration(sampleRate)
+	total := float64(len(pcm)/2) / float64(sampleRate)
+	segment := func(first, last int) SpeechSegment {
+		start := time.Duration(first)*frame - v.opts.Padding
+		end := time.Duration(last+1)*frame + v.opts.Padding
+		return SpeechSegment{Start: max(start.Seconds(), 0), End: min(end.Seconds(), total)}
+	}
+
+	var segments []SpeechSegment
+	for off := 0; off+v.frameBytes <= len(pcm); off += v.frameBytes {
+		if _, closed := v.step(pcm[off : off+v.frameBytes]); closed {
+			segments = append(segments, segment(v.startFrame, v.lastSpeech))
+		}
+	}
+	if v.open {
+		segments = append(segments, segment(v.startFrame, v.lastSpeech))
+	}
+	return segments, nil
+}
+
+// VAD is a streaming voice activity detector for gating audio sent to
+// real-time speech-to-text, so silence is not uploaded or billed:
+//
+//	vad, _ := elevenlabs.NewVAD(16000, nil)
+//	for chunk := range microphone {
+//	    if speech := vad.Filter(chunk); len(speech) > 0 {
+//	        conn.SendAudio(speech)
+//	    }
+//	}
+//
+// A VAD is not safe for concurrent use.
+type VAD struct {
+	opts       VADOptions
+	frameBytes int
+	minSpeech  int
+	minSilence int
+	preRoll    int
+
+	pending    []byte
+	history    [][]byte
+	noiseFloor float64
+	open       bool
+	frame      int
+	speechRun  int
+	silence    int
+	startFrame int
+	lastSpeech int
+}
+
+// NewVAD returns a voice activity detector for 16-bit signed little-endian
+// mono PCM at sampleRate. opts may be nil.
+func NewVAD(sampleRate int, opts *VADOptions) (*VAD, error) {
+	if sampleRate <= 0 {
+		return nil, fmt.Errorf("invalid sample rate: %d", sampleRate)
+	}
+	o := VADOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.FrameDuration <= 0 {
+		o.FrameDuration = defaultVADFrame
+	}
+	if o.Threshold == 0 {
+		o.Threshold = defaultVADThreshold
+	}
+	if o.MinSpeech <= 0 {
+		o.MinSpeech = defaultVADMinSpeech
+	}
+	if o.MinSilence <= 0 {
+		o.MinSilence = defaultVADMinSilence
+	}
+	if o.Padding == 0 {
+		o.Padding = defaultVADPadding
+	} else if o.Padding < 0 {
+		o.Padding = 0
+	}
+
+	samples := int(int64(sampleRate) * int64(o.FrameDuration) / int64(time.Second))
+	if samples == 0 {
+		return nil, fmt.Errorf("frame duration %v is shorter than one sample", o.FrameDuration)
+	}
+	frames := func(d time.Duration) int {
+		return int((d + o.FrameDuration - 1) / o.FrameDuration)
+	}
+	minSpeech := max(frames(o.MinSpeech), 1)
+	return &VAD{
+		opts:       o,
+		frameBytes: samples * 2,
+		minSpeech:  minSpeech,
+		minSilence: max(frames(o.MinSilence), 1),
+		// The speech before the segment started, and the padding
+		preRoll:    minSpeech - 1 + frames(o.Padding),
+		noiseFloor: math.Inf(-1),
+	}, nil
+}
+
+// Speaking reports whether the detector is in a speech segment.
+func (v *VAD) Speaking() bool {
+	return v.open
+}
+
+// Filter processes a chunk of audio and returns the audio to send: speech,
+// including the audio just before it, and the pause after it. It returns
+// nil during silence. Chunks may be of any size; audio that does not fill
+// a frame is held until the next call.
+func (v *VAD) Filter(pcm []byte) []byte {
+	v.pending = append(v.pending, pcm...)
+	var out []byte
+	for len(v.pending) >= v.frameBytes {
+		frame := v.pending[:v.frameBytes:v.frameBytes]
+		v.pending = v.pending[v.frameBytes:]
+
+		wasOpen := v.open
+		opened, closed := v.step(frame)
+		switch {
+		case opened:
+			for _, f := range v.history {
+				out = append(out, f...)
+			}
+			v.history = v.history[:0]
+			out = append(out, frame...)
+		case wasOpen:
+			out = append(out, frame...)
+			if closed {
+				v.history = v.history[:0]
+			}
+		default:
+			v.remember(frame)
+		}
+	}
+	// Keep the remainder from growing the backing array indefinitely
+	v.pending = append([]byte(nil), v.pending...)
+	return out
+}
+
+// remember keeps a frame of silence as pre-roll for the next segment.
+func (v *VAD) remember(frame []byte) {
+	if v.preRoll == 0 {
+		return
+	}
+	if len(v.history) == v.preRoll {
+		copy(v.history, v.history[1:])
+		v.history = v.history[:len(v.history)-1]
+	}
+	v.history = append(v.history, frame)
+}
+
+// step classifies one frame and updates the segment state. It reports
+// whether a segment started or ended with the frame.
+func (v *VAD) step(frame []byte) (opened, closed bool) {
+	idx := v.frame
+	v.frame++
+	speech := v.isSpeech(frame)
+
+	if !v.open {
+		if !speech {
+			v.speechRun = 0
+			return false, false
+		}
+		v.speechRun++
+		if v.speechRun < v.minSpeech {
+			return false, false
+		}
+		v.open = true
+		v.startFrame = idx - v.speechRun + 1
+		v.lastSpeech = idx
+		v.silence = 0
+		return true, false
+	}
+
+	if speech {
+		v.lastSpeech = idx
+		v.silence = 0
+		return false, false
+	}
+	v.silence++
+	if v.silence < v.minSilence {
+		return false, false
+	}
+	v.open = false
+	v.speechRun = 0
+	return false, true
+}
+
+// isSpeech classifies a frame by its energy relative to the threshold and
+// background noise, and its zero-crossing rate. Frames classified as
+// silence update the background noise estimate.
+func (v *VAD) isSpeech(frame []byte) bool {
+	samples, _ := pcmSamples(frame, 1)
+	var energy float64
+	crossings := 0
+	for i, s := range samples {
+		energy += s * s
+		if i > 0 && (s >= 0) != (samples[i-1] >= 0) {
+			crossings++
+		}
+	}
+	db := 10 * math.Log10(energy/float64(len(samples))+1e-12)
+	zcr := 0.0
+	if len(samples) > 1 {
+		zcr = float64(crossings) / float64(len(samples)-1)
+	}
+
+	threshold := max(v.opts.Threshold, v.noiseFloor+vadNoiseMargin)
+	speech := db > threshold && (zcr <= vadMaxZeroCrossings || db > threshold+2*vadNoiseMargin)
+	if !speech {
+		if math.IsInf(v.noiseFloor, -1) {
+			v.noiseFloor = db
+		} else {
+			v.noiseFloor = 0.9*v.noiseFloor + 0.1*db
+		}
+	}
+	return speech
+}
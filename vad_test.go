@@ -0,0 +1,89 @@
+package elevenlabs
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// vadTestAudio returns 16kHz PCM of silence with low noise, then a 220Hz
+// tone from 0.5s to 1.5s, then silence until 2.5s.
+func vadTestAudio() []byte {
+	const rate = 16000
+	rng := rand.New(rand.NewSource(1))
+	pcm := make([]byte, 0, rate*5)
+	for i := 0; i < rate*5/2; i++ {
+		t := float64(i) / rate
+		s := (rng.Float64() - 0.5) * 0.002
+		if t >= 0.5 && t < 1.5 {
+			s += 0.3 * math.Sin(2*math.Pi*220*t)
+		}
+		pcm = binary.LittleEndian.AppendUint16(pcm, uint16(int16(s*32767)))
+	}
+	return pcm
+}
+
+func TestDetectSpeech(t *testing.T) {
+	segments, err := DetectSpeech(vadTestAudio(), 16000)
+	if err != nil {
+		t.Fatalf("DetectSpeech() error = %v", err)
+	}
+	if len(segments) != 1 {
+		t.Fatalf("segments = %+v, want 1", segments)
+	}
+	// Padded by 100ms on each side
+	if s := segments[0]; math.Abs(s.Start-0.4) > 0.03 || math.Abs(s.End-1.6) > 0.03 {
+		t.Errorf("segment = %+v, want about 0.4s - 1.6s", s)
+	}
+
+	if segments, _ := DetectSpeech(make([]byte, 32000), 16000); len(segments) != 0 {
+		t.Errorf("segments of silence = %+v", segments)
+	}
+	if _, err := DetectSpeech([]byte{1}, 16000); err == nil {
+		t.Error("DetectSpeech() with odd length should fail")
+	}
+}
+
+func TestDetectSpeechIgnoresShortNoise(t *testing.T) {
+	pcm := make([]byte, 32000)
+	// A 10ms click
+	for i := 8000; i < 8320; i += 2 {
+		binary.LittleEndian.PutUint16(pcm[i:], uint16(int16(20000)))
+	}
+	segments, err := DetectSpeechWithOptions(pcm, 16000, &VADOptions{Padding: -1})
+	if err != nil {
+		t.Fatalf("DetectSpeechWithOptions() error = %v", err)
+	}
+	if len(segments) != 0 {
+		t.Errorf("segments = %+v, want none for a click", segments)
+	}
+}
+
+func TestVADFilter(t *testing.T) {
+	pcm := vadTestAudio()
+	vad, err := NewVAD(16000, nil)
+	if err != nil {
+		t.Fatalf("NewVAD() error = %v", err)
+	}
+
+	// Feed odd-sized chunks, as a microphone would
+	var sent []byte
+	for off := 0; off < len(pcm); off += 999 {
+		sent = append(sent, vad.Filter(pcm[off:min(off+999, len(pcm))])...)
+	}
+	if vad.Speaking() {
+		t.Error("Speaking() after trailing silence = true")
+	}
+
+	// The tone and its padding are sent, plus the pause before the segment
+	// ended; the leading silence is dropped
+	seconds := float64(len(sent)/2) / 16000
+	if seconds < 1.2 || seconds > 1.6 {
+		t.Errorf("sent %.2fs of audio, want about 1.5s", seconds)
+	}
+	start := int(0.4*16000) * 2
+	if string(sent[:640]) != string(pcm[start:start+640]) {
+		t.Error("sent audio does not start with the pre-roll")
+	}
+}
@@ -0,0 +1,272 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// VoiceReferenceKind is the kind of resource referencing a voice.
+type VoiceReferenceKind string
+
+// Kinds of resources checked by AuditVoiceUsage.
+const (
+	VoiceReferenceAgent       VoiceReferenceKind = "agent"
+	VoiceReferencePhoneNumber VoiceReferenceKind = "phone_number"
+	VoiceReferenceProject     VoiceReferenceKind = "project"
+	VoiceReferenceFile        VoiceReferenceKind = "file"
+)
+
+// VoiceReference is a resource that references a voice.
+type VoiceReference struct {
+	// Kind is the kind of resource.
+	Kind VoiceReferenceKind
+
+	// ID is the agent, phone number, or project ID, or the file path.
+	ID string
+
+	// Name is the agent or project name, or the phone number.
+	Name string
+
+	// Detail describes the reference, such as "default voice", "via agent
+	// Support", or the location in a file ("slides[2].segments[0].voice.en").
+	Detail string
+}
+
+// VoiceAuditOptions configures AuditVoiceUsage.
+type VoiceAuditOptions struct {
+	// Files are ttsscript scripts and generation manifests to check, as
+	// JSON, or CSV manifests with a voice_id column.
+	Files []string
+
+	// SkipAgents skips agents and the phone numbers assigned to them.
+	SkipAgents bool
+
+	// SkipProjects skips Studio projects.
+	SkipProjects bool
+}
+
+// VoiceUsageReport lists the resources referencing a voice.
+type VoiceUsageReport struct {
+	// VoiceID is the audited voice.
+	VoiceID string
+
+	// References are the resources referencing the voice: agents, then
+	// phone numbers, projects, and files.
+	References []VoiceReference
+}
+
+// InUse reports whether anything references the voice.
+func (r *VoiceUsageReport) InUse() bool {
+	return len(r.References) > 0
+}
+
+// ByKind returns the references of one kind.
+func (r *VoiceUsageReport) ByKind(kind VoiceReferenceKind) []VoiceReference {
+	var refs []VoiceReference
+	for _, ref := range r.References {
+		if ref.Kind == kind {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// AuditVoiceUsage lists everything that references a voice, to review
+// before deleting or replacing it:
+//
+//	report, err := client.AuditVoiceUsage(ctx, voiceID, &elevenlabs.VoiceAuditOptions{
+//	    Files: []string{"course/script.json", "course/manifest.json"},
+//	})
+//	for _, ref := range report.References {
+//	    fmt.Printf("%s %s (%s): %s\n", ref.Kind, ref.ID, ref.Name, ref.Detail)
+//	}
+//
+// It checks the default and supported voices of every agent, the phone
+// numbers assigned to those agents, the default voices of Studio projects,
+// and the given files. Agents are read one at a time, so workspaces with
+// many agents take a request per agent.
+func (c *Client) AuditVoiceUsage(ctx context.Context, voiceID string, opts *VoiceAuditOptions) (*VoiceUsageReport, error) {
+	if voiceID == "" {
+		return nil, ErrEmptyVoiceID
+	}
+	if opts == nil {
+		opts = &VoiceAuditOptions{}
+	}
+	report := &VoiceUsageReport{VoiceID: voiceID}
+
+	if !opts.SkipAgents {
+		refs, err := c.agentVoiceReferences(ctx, voiceID)
+		if err != nil {
+			return nil, err
+		}
+		report.References = append(report.References, refs...)
+	}
+
+	if !opts.SkipProjects {
+		projects, err := c.Projects().List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("checking projects: %w", err)
+		}
+		for _, p := range projects {
+			if p.DefaultTitleVoiceID == voiceID {
+				report.References = append(report.References, VoiceReference{
+					Kind: VoiceReferenceProject, ID: p.ProjectID, Name: p.Name, Detail: "default title voice",
+				})
+			}
+			if p.DefaultParagraphVoiceID == voiceID {
+				report.References = append(report.References, VoiceReference{
+					Kind: VoiceReferenceProject, ID: p.ProjectID, Name: p.Name, Detail: "default paragraph voice",
+				})
+			}
+		}
+	}
+
+	for _, path := range opts.Files {
+		locations, err := voiceLocationsInFile(path, voiceID)
+		if err != nil {
+			return nil, err
+		}
+		for _, loc := range locations {
+			report.References = append(report.References, VoiceReference{
+				Kind: VoiceReferenceFile, ID: path, Name: filepath.Base(path), Detail: loc,
+			})
+		}
+	}
+	return report, nil
+}
+
+// agentVoiceReferences returns the agents using a voice and the phone
+// numbers assigned to them.
+func (c *Client) agentVoiceReferences(ctx context.Context, voiceID string) ([]VoiceReference, error) {
+	agents, err := c.Agents().listAgentVoices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("checking agents: %w", err)
+	}
+	var refs []VoiceReference
+	names := make(map[string]string)
+	for _, agent := range agents {
+		for _, id := range agent.voiceIDs() {
+			if id != voiceID {
+				continue
+			}
+			detail := "supported voice"
+			if agent.ConversationConfig.TTS.VoiceID == voiceID {
+				detail = "default voice"
+			}
+			refs = append(refs, VoiceReference{
+				Kind: VoiceReferenceAgent, ID: agent.AgentID, Name: agent.Name, Detail: detail,
+			})
+			names[agent.AgentID] = agent.Name
+		}
+	}
+	if len(names) == 0 {
+		return refs, nil
+	}
+
+	numbers, err := c.PhoneNumbers().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("checking phone numbers: %w", err)
+	}
+	for _, n := range numbers {
+		name, ok := names[n.AgentID]
+		if !ok {
+			continue
+		}
+		if name == "" {
+			name = n.AgentID
+		}
+		refs = append(refs, VoiceReference{
+			Kind: VoiceReferencePhoneNumber, ID: n.ID, Name: n.PhoneNumber, Detail: "via agent " + name,
+		})
+	}
+	return refs, nil
+}
+
+// voiceLocationsInFile returns where a file references a voice ID: JSON
+// paths for JSON files, rows for CSV files.
+func voiceLocationsInFile(path, voiceID string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("checking %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		locations, err := voiceLocationsInCSV(f, voiceID)
+		if err != nil {
+			return nil, fmt.Errorf("checking %s: %w", path, err)
+		}
+		return locations, nil
+	}
+
+	var doc any
+	if err := json.NewDecoder(f).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("checking %s: %w", path, err)
+	}
+	var locations []string
+	findJSONString(doc, "", voiceID, &locations)
+	return locations, nil
+}
+
+// voiceLocationsInCSV returns the rows whose voice_id column is voiceID,
+// as "row N" counting the header as row 1.
+func voiceLocationsInCSV(r io.Reader, voiceID string) ([]string, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	col := -1
+	for i, name := range records[0] {
+		if name == "voice_id" {
+			col = i
+		}
+	}
+	if col < 0 {
+		return nil, fmt.Errorf("no voice_id column")
+	}
+	var locations []string
+	for i, record := range records[1:] {
+		if col < len(record) && record[col] == voiceID {
+			locations = append(locations, fmt.Sprintf("row %d", i+2))
+		}
+	}
+	return locations, nil
+}
+
+// findJSONString appends the paths of the string values equal to s in a
+// decoded JSON value. Object keys are visited in sorted order.
+func findJSONString(v any, path, s string, paths *[]string) {
+	switch v := v.(type) {
+	case string:
+		if v == s {
+			*paths = append(*paths, path)
+		}
+	case []any:
+		for i, elem := range v {
+			findJSONString(elem, fmt.Sprintf("%s[%d]", path, i), s, paths)
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child := k
+			if path != "" {
+				child = path + "." + k
+			}
+			findJSONString(v[k], child, s, paths)
+		}
+	}
+}
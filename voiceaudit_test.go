@@ -0,0 +1,135 @@
+package elevenlabs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newVoiceAuditServer serves a workspace where v-1 is the default voice of
+// agent-1, which has a phone number, a supported voice of agent-2, and the
+// paragraph voice of project p-1.
+func newVoiceAuditServer(t *testing.T) *Client {
+	t.Helper()
+	project := func(id, name, titleVoice, paragraphVoice string) string {
+		return fmt.Sprintf(`{"project_id": %q, "name": %q, "create_date_unix": 1700000000,
+			"default_title_voice_id": %q, "default_paragraph_voice_id": %q, "default_model_id": "eleven_multilingual_v2",
+			"can_be_downloaded": true, "volume_normalization": false, "state": "default", "access_level": "admin",
+			"quality_check_on": false, "quality_check_on_when_bulk_convert": false, "created_by_user_id": null}`,
+			id, name, titleVoice, paragraphVoice)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/convai/agents":
+			agent := func(id string) string {
+				return fmt.Sprintf(`{"agent_id": %q, "name": %q, "tags": [], "created_at_unix_secs": 1700000000,
+					"access_info": {"is_creator": true, "creator_name": "Ada", "creator_email": "ada@example.com", "role": "admin"}}`, id, id)
+			}
+			fmt.Fprintf(w, `{"has_more": false, "agents": [%s, %s, %s]}`, agent("agent-1"), agent("agent-2"), agent("agent-3"))
+		case "/v1/convai/agents/agent-1":
+			fmt.Fprint(w, `{"name": "Support", "conversation_config": {"tts": {"voice_id": "v-1"}}}`)
+		case "/v1/convai/agents/agent-2":
+			fmt.Fprint(w, `{"name": "Sales", "conversation_config": {"tts": {"voice_id": "v-2",
+				"supported_voices": [{"voice_id": "v-1", "label": "Spanish"}]}}}`)
+		case "/v1/convai/agents/agent-3":
+			fmt.Fprint(w, `{"name": "Other", "conversation_config": {"tts": {"voice_id": "v-3"}}}`)
+		case "/v1/convai/phone-numbers":
+			fmt.Fprint(w, `{"phone_numbers": [
+				{"phone_number_id": "pn-1", "phone_number": "+15550001", "agent_id": "agent-1"},
+				{"phone_number_id": "pn-3", "phone_number": "+15550003", "agent_id": "agent-3"}]}`)
+		case "/v1/studio/projects":
+			fmt.Fprintf(w, `{"projects": [%s, %s]}`, project("p-1", "Audiobook", "v-9", "v-1"), project("p-2", "Podcast", "v-2", "v-2"))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestAuditVoiceUsage(t *testing.T) {
+	client := newVoiceAuditServer(t)
+	dir := t.TempDir()
+	script := filepath.Join(dir, "script.json")
+	if err := os.WriteFile(script, []byte(`{"default_voices": {"en": "v-1", "es": "v-2"},
+		"slides": [{"segments": [{"voice": {"en": "v-2"}}, {"voice": {"en": "v-1"}}]}]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	manifest := filepath.Join(dir, "manifest.csv")
+	if err := os.WriteFile(manifest, []byte("slide_index,voice_id,text\n0,v-1,Hello\n1,v-2,Hi\n2,v-1,Bye\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := client.AuditVoiceUsage(context.Background(), "v-1", &VoiceAuditOptions{Files: []string{script, manifest}})
+	if err != nil {
+		t.Fatalf("AuditVoiceUsage() error = %v", err)
+	}
+	want := []VoiceReference{
+		{Kind: VoiceReferenceAgent, ID: "agent-1", Name: "Support", Detail: "default voice"},
+		{Kind: VoiceReferenceAgent, ID: "agent-2", Name: "Sales", Detail: "supported voice"},
+		{Kind: VoiceReferencePhoneNumber, ID: "pn-1", Name: "+15550001", Detail: "via agent Support"},
+		{Kind: VoiceReferenceProject, ID: "p-1", Name: "Audiobook", Detail: "default paragraph voice"},
+		{Kind: VoiceReferenceFile, ID: script, Name: "script.json", Detail: "default_voices.en"},
+		{Kind: VoiceReferenceFile, ID: script, Name: "script.json", Detail: "slides[0].segments[1].voice.en"},
+		{Kind: VoiceReferenceFile, ID: manifest, Name: "manifest.csv", Detail: "row 2"},
+		{Kind: VoiceReferenceFile, ID: manifest, Name: "manifest.csv", Detail: "row 4"},
+	}
+	if !reflect.DeepEqual(report.References, want) {
+		t.Errorf("References =\n%+v\nwant\n%+v", report.References, want)
+	}
+	if !report.InUse() {
+		t.Error("InUse() = false")
+	}
+	if got := report.ByKind(VoiceReferencePhoneNumber); len(got) != 1 || got[0].ID != "pn-1" {
+		t.Errorf("ByKind(phone_number) = %+v", got)
+	}
+}
+
+func TestAuditVoiceUsageUnused(t *testing.T) {
+	client := newVoiceAuditServer(t)
+
+	report, err := client.AuditVoiceUsage(context.Background(), "v-unused", nil)
+	if err != nil {
+		t.Fatalf("AuditVoiceUsage() error = %v", err)
+	}
+	if report.InUse() {
+		t.Errorf("References = %+v, want none", report.References)
+	}
+}
+
+func TestAuditVoiceUsageErrors(t *testing.T) {
+	client := newVoiceAuditServer(t)
+	ctx := context.Background()
+
+	if _, err := client.AuditVoiceUsage(ctx, "", nil); err != ErrEmptyVoiceID {
+		t.Errorf("empty voice ID error = %v, want ErrEmptyVoiceID", err)
+	}
+
+	opts := &VoiceAuditOptions{SkipAgents: true, SkipProjects: true}
+	opts.Files = []string{filepath.Join(t.TempDir(), "missing.json")}
+	if _, err := client.AuditVoiceUsage(ctx, "v-1", opts); err == nil || !strings.Contains(err.Error(), "missing.json") {
+		t.Errorf("missing file error = %v", err)
+	}
+
+	manifest := filepath.Join(t.TempDir(), "manifest.csv")
+	if err := os.WriteFile(manifest, []byte("slide_index,text\n0,Hello\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	opts.Files = []string{manifest}
+	if _, err := client.AuditVoiceUsage(ctx, "v-1", opts); err == nil || !strings.Contains(err.Error(), "voice_id") {
+		t.Errorf("CSV without voice_id error = %v", err)
+	}
+}
@@ -0,0 +1,267 @@
+package elevenlabs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agentplexus/go-elevenlabs/internal/api"
+)
+
+// DefaultVoiceHistoryWindow is how far back DeleteMany looks for
+// generations that make a voice count as in use.
+const DefaultVoiceHistoryWindow = 30 * 24 * time.Hour
+
+// VoiceDeleteOptions configures DeleteMany.
+type VoiceDeleteOptions struct {
+	// Force deletes voices even if they are in use.
+	Force bool
+
+	// DryRun runs the checks and reports what would be deleted without
+	// deleting anything.
+	DryRun bool
+
+	// HistoryWindow is how far back a generation makes a voice count as
+	// in use. Default: DefaultVoiceHistoryWindow. Negative disables the
+	// history check.
+	HistoryWindow time.Duration
+
+	// SkipAgentCheck disables the check for agents using the voices. It
+	// reads every agent's configuration, so skip it in workspaces without
+	// agents to save requests.
+	SkipAgentCheck bool
+}
+
+// VoiceDeleteResult is the outcome of deleting one voice.
+type VoiceDeleteResult struct {
+	// VoiceID is the voice ID.
+	VoiceID string
+
+	// Deleted reports whether the voice was deleted. Always false in a
+	// dry run.
+	Deleted bool
+
+	// LastUsed is when the voice was last used for a generation within
+	// the history window. Zero if it was not.
+	LastUsed time.Time
+
+	// AgentIDs are the agents that speak with the voice.
+	AgentIDs []string
+
+	// Err is why the voice was not deleted: ErrVoiceInUse, a failed
+	// check, or the API error.
+	Err error
+}
+
+// InUse reports whether the voice was used recently or by an agent.
+func (r *VoiceDeleteResult) InUse() bool {
+	return !r.LastUsed.IsZero() || len(r.AgentIDs) > 0
+}
+
+// VoiceDeleteReport is the result of DeleteMany.
+type VoiceDeleteReport struct {
+	// Results are the outcomes in the order of the voice IDs.
+	Results []*VoiceDeleteResult
+}
+
+// Deleted returns the IDs of the deleted voices.
+func (r *VoiceDeleteReport) Deleted() []string {
+	var ids []string
+	for _, res := range r.Results {
+		if res.Deleted {
+			ids = append(ids, res.VoiceID)
+		}
+	}
+	return ids
+}
+
+// Failed returns the voices that were not deleted because of an error,
+// including those in use.
+func (r *VoiceDeleteReport) Failed() []*VoiceDeleteResult {
+	var failed []*VoiceDeleteResult
+	for _, res := range r.Results {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// DeleteMany deletes voices for workspace cleanup. Unless opts.Force is
+// set, it refuses to delete voices that were used for a generation in the
+// history window or are configured on an agent, and reports them with
+// ErrVoiceInUse. Voices are checked and deleted one at a time; a voice
+// that fails is recorded and the rest continue.
+//
+// The returned error is only for failures that stop the run, such as
+// listing agents or a canceled context.
+func (s *VoicesService) DeleteMany(ctx context.Context, voiceIDs []string, opts *VoiceDeleteOptions) (*VoiceDeleteReport, error) {
+	if opts == nil {
+		opts = &VoiceDeleteOptions{}
+	}
+	window := opts.HistoryWindow
+	if window == 0 {
+		window = DefaultVoiceHistoryWindow
+	}
+
+	var agentsByVoice map[string][]string
+	if !opts.SkipAgentCheck && !opts.Force {
+		var err error
+		if agentsByVoice, err = s.client.Agents().agentsByVoice(ctx); err != nil {
+			return nil, fmt.Errorf("checking agents: %w", err)
+		}
+	}
+
+	report := &VoiceDeleteReport{}
+	seen := make(map[string]bool, len(voiceIDs))
+	for _, voiceID := range voiceIDs {
+		if seen[voiceID] {
+			continue
+		}
+		seen[voiceID] = true
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		res := &VoiceDeleteResult{VoiceID: voiceID, AgentIDs: agentsByVoice[voiceID]}
+		report.Results = append(report.Results, res)
+		if voiceID == "" {
+			res.Err = ErrEmptyVoiceID
+			continue
+		}
+
+		if window > 0 && !opts.Force {
+			lastUsed, err := s.client.History().lastUsed(ctx, voiceID)
+			if err != nil {
+				res.Err = fmt.Errorf("checking history: %w", err)
+				continue
+			}
+			if time.Since(lastUsed) <= window {
+				res.LastUsed = lastUsed
+			}
+		}
+		if res.InUse() {
+			res.Err = ErrVoiceInUse
+			continue
+		}
+
+		if opts.DryRun {
+			continue
+		}
+		if err := s.Delete(ctx, voiceID); err != nil {
+			res.Err = err
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return report, err
+			}
+			continue
+		}
+		res.Deleted = true
+	}
+	return report, nil
+}
+
+// lastUsed returns the time of the most recent generation with a voice,
+// or the zero time if there is none.
+func (s *HistoryService) lastUsed(ctx context.Context, voiceID string) (time.Time, error) {
+	page, err := s.List(ctx, &HistoryListOptions{PageSize: 1, VoiceID: voiceID})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(page.Items) == 0 {
+		return time.Time{}, nil
+	}
+	return page.Items[0].CreatedAt, nil
+}
+
+// agentVoices is the agent config fragment holding the agent's voices.
+type agentVoices struct {
+	AgentID            string `json:"-"`
+	Name               string `json:"name"`
+	ConversationConfig struct {
+		TTS struct {
+			VoiceID         string `json:"voice_id"`
+			SupportedVoices []struct {
+				VoiceID string `json:"voice_id"`
+			} `json:"supported_voices"`
+		} `json:"tts"`
+	} `json:"conversation_config"`
+}
+
+// voiceIDs returns the agent's default and supported voices.
+func (a *agentVoices) voiceIDs() []string {
+	tts := a.ConversationConfig.TTS
+	voices := []string{tts.VoiceID}
+	for _, v := range tts.SupportedVoices {
+		voices = append(voices, v.VoiceID)
+	}
+	var ids []string
+	seen := make(map[string]bool, len(voices))
+	for _, voiceID := range voices {
+		if voiceID != "" && !seen[voiceID] {
+			seen[voiceID] = true
+			ids = append(ids, voiceID)
+		}
+	}
+	return ids
+}
+
+// agentsByVoice returns the IDs of the agents using each voice, as their
+// default voice or one of their supported voices.
+func (s *AgentsService) agentsByVoice(ctx context.Context) (map[string][]string, error) {
+	agents, err := s.listAgentVoices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byVoice := make(map[string][]string)
+	for _, agent := range agents {
+		for _, voiceID := range agent.voiceIDs() {
+			byVoice[voiceID] = append(byVoice[voiceID], agent.AgentID)
+		}
+	}
+	return byVoice, nil
+}
+
+// listAgentVoices reads the voices of every agent in the workspace.
+func (s *AgentsService) listAgentVoices(ctx context.Context) ([]*agentVoices, error) {
+	agentIDs, err := s.listAgentIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	agents := make([]*agentVoices, 0, len(agentIDs))
+	for _, agentID := range agentIDs {
+		agent := &agentVoices{}
+		if err := s.client.doJSON(ctx, "GET", agentPath(agentID), nil, agent); err != nil {
+			return nil, fmt.Errorf("reading agent %s: %w", agentID, err)
+		}
+		agent.AgentID = agentID
+		agents = append(agents, agent)
+	}
+	return agents, nil
+}
+
+// listAgentIDs returns the IDs of all agents in the workspace.
+func (s *AgentsService) listAgentIDs(ctx context.Context) ([]string, error) {
+	var ids []string
+	params := api.GetAgentsRouteParams{PageSize: api.NewOptInt(100)}
+	for {
+		resp, err := s.client.apiClient.GetAgentsRoute(ctx, params)
+		if err != nil {
+			return nil, apiError(err)
+		}
+		switch r := resp.(type) {
+		case *api.GetAgentsPageResponseModel:
+			for _, a := range r.Agents {
+				ids = append(ids, a.AgentID)
+			}
+			if !r.HasMore || !r.NextCursor.Set || r.NextCursor.Null || r.NextCursor.Value == "" {
+				return ids, nil
+			}
+			params.Cursor = api.NewOptNilString(r.NextCursor.Value)
+		case *api.HTTPValidationError:
+			return nil, validationAPIError(r)
+		default:
+			return nil, unexpectedResponse(resp)
+		}
+	}
+}
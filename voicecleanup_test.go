@@ -0,0 +1,142 @@
+package elevenlabs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newVoiceCleanupServer serves a workspace where v-agent and v-multi are
+// used by agent-1, v-recent was used an hour ago, and v-old a year ago.
+// It returns the IDs of the voices deleted.
+func newVoiceCleanupServer(t *testing.T) (*Client, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var deleted []string
+	lastUsed := map[string]time.Time{
+		"v-recent": time.Now().Add(-time.Hour),
+		"v-old":    time.Now().Add(-365 * 24 * time.Hour),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/convai/agents":
+			fmt.Fprint(w, `{"has_more": false, "agents": [{"agent_id": "agent-1", "name": "Support", "tags": [],
+				"created_at_unix_secs": 1700000000, "access_info": {"is_creator": true,
+				"creator_name": "Ada", "creator_email": "ada@example.com", "role": "admin"}}]}`)
+		case r.URL.Path == "/v1/convai/agents/agent-1":
+			fmt.Fprint(w, `{"conversation_config": {"tts": {"voice_id": "v-agent",
+				"supported_voices": [{"voice_id": "v-multi", "label": "Spanish"}]}}}`)
+		case r.URL.Path == "/v1/history":
+			voiceID := r.URL.Query().Get("voice_id")
+			if voiceID == "v-broken" {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprint(w, `{"detail": "boom"}`)
+				return
+			}
+			used, ok := lastUsed[voiceID]
+			if !ok {
+				fmt.Fprint(w, `{"has_more": false, "history": []}`)
+				return
+			}
+			fmt.Fprintf(w, `{"has_more": true, "last_history_item_id": "h1", "history": [
+				{"history_item_id": "h1", "date_unix": %d, "character_count_change_from": 0,
+				"character_count_change_to": 5, "content_type": "audio/mpeg", "state": "created", "voice_id": %q}]}`,
+				used.Unix(), voiceID)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v1/voices/"):
+			mu.Lock()
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/v1/voices/"))
+			mu.Unlock()
+			fmt.Fprint(w, `{"status": "ok"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), deleted...)
+	}
+}
+
+func TestVoicesDeleteMany(t *testing.T) {
+	client, deleted := newVoiceCleanupServer(t)
+
+	ids := []string{"v-unused", "v-agent", "v-multi", "v-recent", "v-old", "v-broken", "v-unused"}
+	report, err := client.Voices().DeleteMany(context.Background(), ids, nil)
+	if err != nil {
+		t.Fatalf("DeleteMany() error = %v", err)
+	}
+	if len(report.Results) != 6 {
+		t.Fatalf("Results = %d, want 6 (duplicates skipped)", len(report.Results))
+	}
+	if got := strings.Join(report.Deleted(), ","); got != "v-unused,v-old" {
+		t.Errorf("Deleted() = %s, want v-unused,v-old", got)
+	}
+	if got := strings.Join(deleted(), ","); got != "v-unused,v-old" {
+		t.Errorf("server deleted %s", got)
+	}
+
+	byID := make(map[string]*VoiceDeleteResult)
+	for _, r := range report.Results {
+		byID[r.VoiceID] = r
+	}
+	if r := byID["v-multi"]; !errors.Is(r.Err, ErrVoiceInUse) || len(r.AgentIDs) != 1 || r.AgentIDs[0] != "agent-1" {
+		t.Errorf("v-multi = %+v", r)
+	}
+	if r := byID["v-recent"]; !errors.Is(r.Err, ErrVoiceInUse) || r.LastUsed.IsZero() {
+		t.Errorf("v-recent = %+v", r)
+	}
+	if r := byID["v-old"]; r.InUse() {
+		t.Errorf("v-old = %+v, want not in use", r)
+	}
+	if r := byID["v-broken"]; r.Err == nil || errors.Is(r.Err, ErrVoiceInUse) || r.Deleted {
+		t.Errorf("v-broken = %+v, want check error", r)
+	}
+	if len(report.Failed()) != 4 {
+		t.Errorf("Failed() = %d, want 4", len(report.Failed()))
+	}
+}
+
+func TestVoicesDeleteManyOptions(t *testing.T) {
+	client, deleted := newVoiceCleanupServer(t)
+	ctx := context.Background()
+
+	report, err := client.Voices().DeleteMany(ctx, []string{"v-unused", "v-agent"}, &VoiceDeleteOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("DeleteMany(DryRun) error = %v", err)
+	}
+	if len(report.Deleted()) != 0 || len(deleted()) != 0 || report.Results[0].Err != nil {
+		t.Errorf("dry run deleted %v, results %+v", deleted(), report.Results[0])
+	}
+
+	report, err = client.Voices().DeleteMany(ctx, []string{"v-agent", "v-recent"}, &VoiceDeleteOptions{Force: true})
+	if err != nil {
+		t.Fatalf("DeleteMany(Force) error = %v", err)
+	}
+	if got := strings.Join(report.Deleted(), ","); got != "v-agent,v-recent" {
+		t.Errorf("Deleted() = %s", got)
+	}
+
+	// Without the history check, only agents keep a voice
+	report, err = client.Voices().DeleteMany(ctx, []string{"v-recent"}, &VoiceDeleteOptions{HistoryWindow: -1})
+	if err != nil {
+		t.Fatalf("DeleteMany() error = %v", err)
+	}
+	if !report.Results[0].Deleted {
+		t.Errorf("v-recent = %+v, want deleted", report.Results[0])
+	}
+}
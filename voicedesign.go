@@ -119,7 +119,7 @@ func (s *VoiceDesignService) GeneratePreview(ctx context.Context, req *VoiceDesi
 
 	resp, err := s.client.apiClient.GenerateRandomVoice(ctx, body, api.GenerateRandomVoiceParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
@@ -129,8 +129,10 @@ func (s *VoiceDesignService) GeneratePreview(ctx context.Context, req *VoiceDesi
 			// Note: The generated_voice_id is typically returned in response headers
 			// The ogen client may not expose this directly
 		}, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -156,7 +158,7 @@ func (s *VoiceDesignService) SaveVoice(ctx context.Context, req *SaveVoiceReques
 
 	resp, err := s.client.apiClient.CreateVoiceOld(ctx, body, api.CreateVoiceOldParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	switch r := resp.(type) {
@@ -167,8 +169,10 @@ func (s *VoiceDesignService) SaveVoice(ctx context.Context, req *SaveVoiceReques
 			Description: r.Description.Value,
 			Category:    string(r.Category),
 		}, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -0,0 +1,422 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/cmplx"
+	"sync"
+	"time"
+)
+
+// Voice drift detection defaults.
+const (
+	// DefaultDriftThreshold is the fingerprint distance, in dB, above
+	// which a voice is reported as drifted.
+	DefaultDriftThreshold = 3.0
+
+	// DefaultDriftInterval is how often a VoiceDriftMonitor checks its
+	// canaries.
+	DefaultDriftInterval = 24 * time.Hour
+
+	// driftOutputFormat is the format canaries are generated in when
+	// their request does not ask for PCM.
+	driftOutputFormat = "pcm_16000"
+)
+
+// Fingerprint analysis parameters. Bands span the range where voices
+// differ most, below the Nyquist frequency of 16 kHz audio.
+const (
+	fingerprintBands   = 24
+	fingerprintMinHz   = 80.0
+	fingerprintMaxHz   = 7600.0
+	fingerprintSilence = 1e-3 // RMS of frames skipped as silence
+	fingerprintRange   = 60.0 // dB below the loudest band that bands are clamped to
+)
+
+// VoiceFingerprint is the average spectral envelope of a voice sample,
+// used to detect when the same voice and settings start to sound
+// different. It is independent of loudness.
+type VoiceFingerprint struct {
+	// Bands is the average energy of log-spaced frequency bands from 80
+	// Hz to 7.6 kHz, in dB relative to their mean. Bands more than 60 dB
+	// below the loudest are raised to that level.
+	Bands []float64 `json:"bands"`
+
+	// Hash is a compact summary of the band shape: one bit per band,
+	// set when its energy is higher than the previous band's. Equal
+	// hashes mean the spectra have the same shape; use Distance to
+	// measure how far apart they are.
+	Hash string `json:"hash"`
+
+	// CreatedAt is when the fingerprint was taken.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FingerprintPCM computes the fingerprint of 16-bit signed little-endian
+// mono PCM. The sample rate must be at least 16 kHz.
+func FingerprintPCM(pcm []byte, sampleRate int) (*VoiceFingerprint, error) {
+	if sampleRate < 16000 {
+		return nil, fmt.Errorf("sample rate must be at least 16000, got %d", sampleRate)
+	}
+	samples, err := pcmSamples(pcm, sampleRate)
+	if err != nil {
+		return nil, err
+	}
+
+	frameSize := 1024
+	if sampleRate > 24000 {
+		frameSize = 2048
+	}
+	window := make([]float64, frameSize)
+	for i := range window {
+		window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(frameSize-1))
+	}
+	bandOf := fingerprintBandIndex(frameSize, sampleRate)
+
+	power := make([]float64, fingerprintBands)
+	frame := make([]complex128, frameSize)
+	frames := 0
+	for start := 0; start+frameSize <= len(samples); start += frameSize / 2 {
+		var energy float64
+		for i, s := range samples[start : start+frameSize] {
+			energy += s * s
+			frame[i] = complex(s*window[i], 0)
+		}
+		if math.Sqrt(energy/float64(frameSize)) < fingerprintSilence {
+			continue
+		}
+		fft(frame)
+		for k, band := range bandOf {
+			if band >= 0 {
+				p := cmplx.Abs(frame[k])
+				power[band] += p * p
+			}
+		}
+		frames++
+	}
+	if frames == 0 {
+		return nil, errors.New("sample has no audio above silence")
+	}
+
+	// Bands are clamped below the loudest so that noise in bands without
+	// speech, which does not scale with level, does not count.
+	fp := &VoiceFingerprint{Bands: make([]float64, fingerprintBands), CreatedAt: time.Now()}
+	loudest := math.Inf(-1)
+	for i, p := range power {
+		fp.Bands[i] = 10 * math.Log10(p/float64(frames)+1e-12)
+		loudest = max(loudest, fp.Bands[i])
+	}
+	var mean float64
+	for i := range fp.Bands {
+		fp.Bands[i] = max(fp.Bands[i], loudest-fingerprintRange)
+		mean += fp.Bands[i]
+	}
+	mean /= fingerprintBands
+	for i := range fp.Bands {
+		fp.Bands[i] -= mean
+	}
+	fp.Hash = fingerprintHash(fp.Bands)
+	return fp, nil
+}
+
+// Distance returns the RMS difference between two fingerprints in dB.
+// Samples of the same voice are typically within a dB or two.
+func (f *VoiceFingerprint) Distance(other *VoiceFingerprint) (float64, error) {
+	if other == nil || len(f.Bands) != len(other.Bands) || len(f.Bands) == 0 {
+		return 0, errors.New("fingerprints are not comparable")
+	}
+	var sum float64
+	for i := range f.Bands {
+		d := f.Bands[i] - other.Bands[i]
+		sum += d * d
+	}
+	return math.Sqrt(sum / float64(len(f.Bands))), nil
+}
+
+// fingerprintBandIndex maps FFT bins to bands, or -1 for bins outside
+// the analyzed range.
+func fingerprintBandIndex(frameSize, sampleRate int) []int {
+	bins := make([]int, frameSize/2)
+	ratio := math.Log(fingerprintMaxHz / fingerprintMinHz)
+	for k := range bins {
+		hz := float64(k) * float64(sampleRate) / float64(frameSize)
+		if hz < fingerprintMinHz || hz >= fingerprintMaxHz {
+			bins[k] = -1
+			continue
+		}
+		bins[k] = int(math.Log(hz/fingerprintMinHz) / ratio * fingerprintBands)
+	}
+	return bins
+}
+
+func fingerprintHash(bands []float64) string {
+	bits := make([]byte, (len(bands)+7)/8)
+	for i := 1; i < len(bands); i++ {
+		if bands[i] > bands[i-1] {
+			bits[i/8] |= 1 << (i % 8)
+		}
+	}
+	return hex.EncodeToString(bits)
+}
+
+// fft computes the discrete Fourier transform of x in place. len(x) must
+// be a power of two.
+func fft(x []complex128) {
+	n := len(x)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j |= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Exp(complex(0, -2*math.Pi/float64(size)))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				a, b := x[start+k], x[start+k+size/2]*w
+				x[start+k], x[start+k+size/2] = a+b, a-b
+				w *= step
+			}
+		}
+	}
+}
+
+// VoiceCanary is a phrase generated regularly to check that a voice still
+// sounds the same.
+type VoiceCanary struct {
+	// Name identifies the canary's baseline in the store.
+	Name string
+
+	// Request is the voice, model, settings, and text to generate. Set a
+	// Seed so differences come from the model rather than sampling. If
+	// OutputFormat is not PCM of at least 16 kHz, pcm_16000 is used.
+	Request *TTSRequest
+}
+
+// VoiceDriftResult is the outcome of checking a canary.
+type VoiceDriftResult struct {
+	// Canary is the checked canary.
+	Canary *VoiceCanary
+
+	// Baseline is the stored fingerprint. It is nil when the check
+	// created the baseline.
+	Baseline *VoiceFingerprint
+
+	// Current is the fingerprint of the newly generated sample.
+	Current *VoiceFingerprint
+
+	// Distance is how far Current is from Baseline, in dB.
+	Distance float64
+
+	// Drifted reports whether Distance exceeds the threshold.
+	Drifted bool
+
+	// Err is the error from the check, if any.
+	Err error
+}
+
+// DriftBaselineStore persists baseline fingerprints by canary name.
+// Implementations must be safe for concurrent use.
+type DriftBaselineStore interface {
+	// LoadBaseline returns the baseline for a canary, or nil if there is
+	// none.
+	LoadBaseline(ctx context.Context, name string) (*VoiceFingerprint, error)
+
+	// SaveBaseline creates or replaces the baseline for a canary.
+	SaveBaseline(ctx context.Context, name string, fp *VoiceFingerprint) error
+}
+
+// MemoryBaselineStore is a DriftBaselineStore that keeps baselines in
+// memory.
+type MemoryBaselineStore struct {
+	mu        sync.Mutex
+	baselines map[string]*VoiceFingerprint
+}
+
+// NewMemoryBaselineStore creates an empty in-memory DriftBaselineStore.
+func NewMemoryBaselineStore() *MemoryBaselineStore {
+	return &MemoryBaselineStore{baselines: make(map[string]*VoiceFingerprint)}
+}
+
+// LoadBaseline implements DriftBaselineStore.
+func (m *MemoryBaselineStore) LoadBaseline(_ context.Context, name string) (*VoiceFingerprint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.baselines[name], nil
+}
+
+// SaveBaseline implements DriftBaselineStore.
+func (m *MemoryBaselineStore) SaveBaseline(_ context.Context, name string, fp *VoiceFingerprint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.baselines[name] = fp
+	return nil
+}
+
+// VoiceDriftOption configures a VoiceDriftMonitor.
+type VoiceDriftOption func(*VoiceDriftMonitor)
+
+// WithDriftStore keeps baselines in store. The default is a
+// MemoryBaselineStore, which loses baselines on restart.
+func WithDriftStore(store DriftBaselineStore) VoiceDriftOption {
+	return func(m *VoiceDriftMonitor) {
+		m.store = store
+	}
+}
+
+// WithDriftThreshold sets the fingerprint distance, in dB, above which a
+// voice is reported as drifted. The default is DefaultDriftThreshold.
+func WithDriftThreshold(db float64) VoiceDriftOption {
+	return func(m *VoiceDriftMonitor) {
+		m.threshold = db
+	}
+}
+
+// WithDriftInterval sets how often Run checks the canaries. The default
+// is DefaultDriftInterval.
+func WithDriftInterval(d time.Duration) VoiceDriftOption {
+	return func(m *VoiceDriftMonitor) {
+		m.interval = d
+	}
+}
+
+// WithDriftAlert sets the function Run calls when a canary drifts or its
+// check fails.
+func WithDriftAlert(fn func(*VoiceDriftResult)) VoiceDriftOption {
+	return func(m *VoiceDriftMonitor) {
+		m.onAlert = fn
+	}
+}
+
+// VoiceDriftMonitor regenerates canary phrases and compares their
+// fingerprints with a stored baseline, to catch model updates that change
+// how a voice sounds before customers do:
+//
+//	monitor := client.TextToSpeech().NewVoiceDriftMonitor(
+//	    elevenlabs.WithDriftStore(store),
+//	    elevenlabs.WithDriftAlert(func(r *elevenlabs.VoiceDriftResult) {
+//	        log.Printf("voice %s drifted by %.1f dB (err=%v)", r.Canary.Name, r.Distance, r.Err)
+//	    }),
+//	)
+//	go monitor.Run(ctx, &elevenlabs.VoiceCanary{
+//	    Name:    "support-voice",
+//	    Request: &elevenlabs.TTSRequest{VoiceID: voiceID, Text: "Thanks for calling. How can I help?", Seed: 42},
+//	})
+type VoiceDriftMonitor struct {
+	tts       *TextToSpeechService
+	store     DriftBaselineStore
+	threshold float64
+	interval  time.Duration
+	onAlert   func(*VoiceDriftResult)
+}
+
+// NewVoiceDriftMonitor creates a monitor that generates canaries with this
+// service.
+func (s *TextToSpeechService) NewVoiceDriftMonitor(opts ...VoiceDriftOption) *VoiceDriftMonitor {
+	m := &VoiceDriftMonitor{
+		tts:       s,
+		store:     NewMemoryBaselineStore(),
+		threshold: DefaultDriftThreshold,
+		interval:  DefaultDriftInterval,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Check generates the canary and compares it with its baseline. The first
+// check of a canary stores its fingerprint as the baseline. After an
+// intended change, such as switching models, save result.Current as the
+// new baseline with the store.
+func (m *VoiceDriftMonitor) Check(ctx context.Context, canary *VoiceCanary) (*VoiceDriftResult, error) {
+	if canary == nil || canary.Name == "" {
+		return nil, &ValidationError{Field: "name", Message: "cannot be empty"}
+	}
+	if canary.Request == nil {
+		return nil, &ValidationError{Field: "request", Message: "cannot be nil"}
+	}
+
+	current, err := m.fingerprint(ctx, canary.Request)
+	if err != nil {
+		return nil, err
+	}
+	result := &VoiceDriftResult{Canary: canary, Current: current}
+
+	baseline, err := m.store.LoadBaseline(ctx, canary.Name)
+	if err != nil {
+		return nil, err
+	}
+	if baseline == nil {
+		return result, m.store.SaveBaseline(ctx, canary.Name, current)
+	}
+
+	result.Baseline = baseline
+	result.Distance, err = current.Distance(baseline)
+	if err != nil {
+		return nil, err
+	}
+	result.Drifted = result.Distance > m.threshold
+	return result, nil
+}
+
+// Run checks the canaries now and then at every interval until ctx is
+// done. Drifted canaries and failed checks are reported to the
+// WithDriftAlert function.
+func (m *VoiceDriftMonitor) Run(ctx context.Context, canaries ...*VoiceCanary) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		for _, canary := range canaries {
+			result, err := m.Check(ctx, canary)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != nil {
+				result = &VoiceDriftResult{Canary: canary, Err: err}
+			}
+			if (result.Drifted || result.Err != nil) && m.onAlert != nil {
+				m.onAlert(result)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// fingerprint generates req as PCM and fingerprints the audio.
+func (m *VoiceDriftMonitor) fingerprint(ctx context.Context, req *TTSRequest) (*VoiceFingerprint, error) {
+	r := *req
+	sampleRate, err := ParsePCMSampleRate(r.OutputFormat)
+	if err != nil || sampleRate < 16000 {
+		r.OutputFormat = driftOutputFormat
+		sampleRate, _ = ParsePCMSampleRate(driftOutputFormat)
+	}
+
+	resp, err := m.tts.Generate(ctx, &r)
+	if err != nil {
+		return nil, err
+	}
+	pcm, err := io.ReadAll(resp.Audio)
+	if err != nil {
+		return nil, fmt.Errorf("reading canary audio: %w", err)
+	}
+	fp, err := FingerprintPCM(pcm, sampleRate)
+	if err != nil {
+		return nil, fmt.Errorf("fingerprinting canary audio: %w", err)
+	}
+	return fp, nil
+}
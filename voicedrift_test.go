@@ -0,0 +1,151 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testVoicePCM synthesizes a harmonic tone with the given fundamental,
+// amplitude, and harmonic decay, standing in for a voice.
+func testVoicePCM(sampleRate int, f0, amp, decay float64) []byte {
+	n := sampleRate // 1 second
+	pcm := make([]byte, 2*n)
+	for i := range n {
+		t := float64(i) / float64(sampleRate)
+		var v float64
+		for h := 1; float64(h)*f0 < float64(sampleRate)/2; h++ {
+			v += math.Pow(decay, float64(h-1)) * math.Sin(2*math.Pi*f0*float64(h)*t)
+		}
+		binary.LittleEndian.PutUint16(pcm[2*i:], uint16(int16(amp*v*8000)))
+	}
+	return pcm
+}
+
+func TestFingerprintPCM(t *testing.T) {
+	base, err := FingerprintPCM(testVoicePCM(16000, 140, 1, 0.7), 16000)
+	if err != nil {
+		t.Fatalf("FingerprintPCM() error = %v", err)
+	}
+	if len(base.Bands) != fingerprintBands || len(base.Hash) != 6 {
+		t.Errorf("fingerprint = %+v", base)
+	}
+
+	quieter, _ := FingerprintPCM(testVoicePCM(16000, 140, 0.3, 0.7), 16000)
+	if d, _ := base.Distance(quieter); d > 0.5 {
+		t.Errorf("Distance() to quieter sample = %.2f dB, want about 0", d)
+	}
+	if quieter.Hash != base.Hash {
+		t.Errorf("Hash = %s, want %s", quieter.Hash, base.Hash)
+	}
+
+	brighter, _ := FingerprintPCM(testVoicePCM(16000, 140, 1, 0.95), 16000)
+	if d, _ := base.Distance(brighter); d < DefaultDriftThreshold {
+		t.Errorf("Distance() to brighter sample = %.2f dB, want > %v", d, DefaultDriftThreshold)
+	}
+
+	if _, err := FingerprintPCM(make([]byte, 32000), 16000); err == nil {
+		t.Error("FingerprintPCM() of silence should return error")
+	}
+	if _, err := FingerprintPCM(testVoicePCM(8000, 140, 1, 0.7), 8000); err == nil {
+		t.Error("FingerprintPCM() at 8 kHz should return error")
+	}
+	if _, err := base.Distance(&VoiceFingerprint{}); err == nil {
+		t.Error("Distance() to empty fingerprint should return error")
+	}
+}
+
+func TestVoiceDriftMonitor(t *testing.T) {
+	var decay atomic.Value
+	decay.Store(0.7)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/text-to-speech/voice-1" || r.URL.Query().Get("output_format") != "pcm_16000" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write(testVoicePCM(16000, 140, 1, decay.Load().(float64)))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+	store := NewMemoryBaselineStore()
+	monitor := client.TextToSpeech().NewVoiceDriftMonitor(WithDriftStore(store))
+	canary := &VoiceCanary{Name: "support", Request: &TTSRequest{VoiceID: "voice-1", Text: "Thanks for calling.", Seed: 42}}
+
+	first, err := monitor.Check(ctx, canary)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if first.Baseline != nil || first.Drifted {
+		t.Errorf("first Check() = %+v, want new baseline", first)
+	}
+	if saved, _ := store.LoadBaseline(ctx, "support"); saved != first.Current {
+		t.Error("first Check() did not save the baseline")
+	}
+
+	same, err := monitor.Check(ctx, canary)
+	if err != nil || same.Drifted || same.Baseline == nil {
+		t.Errorf("Check() of unchanged voice = %+v, %v", same, err)
+	}
+
+	decay.Store(0.95)
+	changed, err := monitor.Check(ctx, canary)
+	if err != nil || !changed.Drifted {
+		t.Errorf("Check() of changed voice = %+v, %v", changed, err)
+	}
+
+	var valErr *ValidationError
+	if _, err := monitor.Check(ctx, &VoiceCanary{Name: "x"}); !isValidationError(err, &valErr) {
+		t.Errorf("Check() without request error = %v", err)
+	}
+}
+
+func TestVoiceDriftMonitorRunAlerts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write(testVoicePCM(16000, 220, 1, 0.95))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	store := NewMemoryBaselineStore()
+	baseline, _ := FingerprintPCM(testVoicePCM(16000, 220, 1, 0.6), 16000)
+	_ = store.SaveBaseline(ctx, "support", baseline)
+
+	alerts := make(chan *VoiceDriftResult, 1)
+	monitor := client.TextToSpeech().NewVoiceDriftMonitor(
+		WithDriftStore(store),
+		WithDriftInterval(time.Hour),
+		WithDriftAlert(func(r *VoiceDriftResult) {
+			alerts <- r
+			cancel()
+		}),
+	)
+	err = monitor.Run(ctx, &VoiceCanary{Name: "support", Request: &TTSRequest{VoiceID: "voice-1", Text: "Hi"}})
+	if err != context.Canceled {
+		t.Errorf("Run() error = %v, want context.Canceled", err)
+	}
+	select {
+	case r := <-alerts:
+		if !r.Drifted || r.Err != nil {
+			t.Errorf("alert = %+v", r)
+		}
+	default:
+		t.Error("Run() did not alert")
+	}
+}
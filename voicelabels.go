@@ -0,0 +1,194 @@
+package elevenlabs
+
+import (
+	"sort"
+	"strings"
+)
+
+// Common voice label keys.
+const (
+	VoiceLabelAccent      = "accent"
+	VoiceLabelAge         = "age"
+	VoiceLabelGender      = "gender"
+	VoiceLabelUseCase     = "use_case"
+	VoiceLabelDescriptive = "descriptive"
+	VoiceLabelLanguage    = "language"
+)
+
+// Label returns the value of a voice label, or "" if the voice does not
+// have it. Keys are matched case-insensitively.
+func (v *Voice) Label(key string) string {
+	if value, ok := v.Labels[key]; ok {
+		return value
+	}
+	for k, value := range v.Labels {
+		if strings.EqualFold(k, key) {
+			return value
+		}
+	}
+	return ""
+}
+
+// HasLabel reports whether the voice has a label with the value. Values
+// are compared ignoring case and the difference between spaces,
+// underscores, and hyphens, so "middle aged" matches "middle_aged".
+func (v *Voice) HasLabel(key, value string) bool {
+	label := v.Label(key)
+	return label != "" && normalizeLabel(label) == normalizeLabel(value)
+}
+
+// normalizeLabel lowercases a label value and separates its words with
+// single spaces.
+func normalizeLabel(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-'
+	}), " ")
+}
+
+// FilterVoices returns the voices that have all the labels, in order.
+func FilterVoices(voices []*Voice, labels map[string]string) []*Voice {
+	var matched []*Voice
+	for _, v := range voices {
+		ok := true
+		for key, value := range labels {
+			if !v.HasLabel(key, value) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matched = append(matched, v)
+		}
+	}
+	return matched
+}
+
+// GroupVoicesByLabel groups voices by the value of a label, such as
+// VoiceLabelAccent. Values are normalized as in HasLabel, so "Middle-Aged"
+// and "middle aged" form one group, keyed "middle aged". Voices without
+// the label are grouped under "".
+func GroupVoicesByLabel(voices []*Voice, key string) map[string][]*Voice {
+	groups := make(map[string][]*Voice)
+	for _, v := range voices {
+		value := normalizeLabel(v.Label(key))
+		groups[value] = append(groups[value], v)
+	}
+	return groups
+}
+
+// VoiceCriteria describes the voice wanted by FindVoice. Empty fields
+// match any voice.
+type VoiceCriteria struct {
+	// Gender, Age, Accent, UseCase, and Language are matched against the
+	// voice labels of the same name.
+	Gender   string
+	Age      string
+	Accent   string
+	UseCase  string
+	Language string
+
+	// Labels are other labels to match, such as
+	// {"descriptive": "calm"}.
+	Labels map[string]string
+
+	// Category restricts the voices to a category, such as "premade" or
+	// "professional".
+	Category string
+
+	// Required lists the label keys that must match, such as
+	// VoiceLabelGender. Other criteria are preferences.
+	Required []string
+}
+
+// labels returns the label criteria by key.
+func (c *VoiceCriteria) labels() map[string]string {
+	labels := make(map[string]string, len(c.Labels)+5)
+	for k, v := range c.Labels {
+		labels[k] = v
+	}
+	for k, v := range map[string]string{
+		VoiceLabelGender:   c.Gender,
+		VoiceLabelAge:      c.Age,
+		VoiceLabelAccent:   c.Accent,
+		VoiceLabelUseCase:  c.UseCase,
+		VoiceLabelLanguage: c.Language,
+	} {
+		if v != "" {
+			labels[k] = v
+		}
+	}
+	return labels
+}
+
+// RankVoices returns the voices that satisfy the required criteria and
+// category, best match first. A voice scores a point for each label that
+// matches, and half a point for a label that contains the words of the
+// wanted value, so an accent of "british" partly matches "british-essex".
+// Voices with equal scores keep their order.
+func RankVoices(voices []*Voice, criteria VoiceCriteria) []*Voice {
+	labels := criteria.labels()
+	type scored struct {
+		voice *Voice
+		score float64
+	}
+	var ranked []scored
+	for _, v := range voices {
+		if criteria.Category != "" && !strings.EqualFold(v.Category, criteria.Category) {
+			continue
+		}
+		ok := true
+		for _, key := range criteria.Required {
+			if want, set := labels[key]; set && !v.HasLabel(key, want) {
+				ok = false
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		var score float64
+		for key, want := range labels {
+			label := normalizeLabel(v.Label(key))
+			switch want = normalizeLabel(want); {
+			case label == "":
+			case label == want:
+				score++
+			case containsWords(label, want):
+				score += 0.5
+			}
+		}
+		ranked = append(ranked, scored{v, score})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+
+	result := make([]*Voice, len(ranked))
+	for i, r := range ranked {
+		result[i] = r.voice
+	}
+	return result
+}
+
+// containsWords reports whether the normalized label contains the words
+// of the normalized value in sequence.
+func containsWords(label, value string) bool {
+	return strings.Contains(" "+label+" ", " "+value+" ")
+}
+
+// FindVoice returns the voice that best matches the criteria, or nil if
+// no voice satisfies the required criteria and category:
+//
+//	voices, err := client.Voices().List(ctx)
+//	narrator := elevenlabs.FindVoice(voices, elevenlabs.VoiceCriteria{
+//	    Gender:   "female",
+//	    Accent:   "british",
+//	    UseCase:  "narration",
+//	    Required: []string{elevenlabs.VoiceLabelGender},
+//	})
+func FindVoice(voices []*Voice, criteria VoiceCriteria) *Voice {
+	ranked := RankVoices(voices, criteria)
+	if len(ranked) == 0 {
+		return nil
+	}
+	return ranked[0]
+}
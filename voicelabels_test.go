@@ -0,0 +1,80 @@
+package elevenlabs
+
+import "testing"
+
+func labeledVoices() []*Voice {
+	return []*Voice{
+		{VoiceID: "v1", Category: "premade", Labels: map[string]string{"gender": "male", "age": "middle_aged", "accent": "American", "use_case": "narration"}},
+		{VoiceID: "v2", Category: "premade", Labels: map[string]string{"gender": "female", "age": "young", "accent": "british-essex", "use_case": "conversational"}},
+		{VoiceID: "v3", Category: "cloned", Labels: map[string]string{"Gender": "female", "age": "Middle-Aged", "accent": "british", "use_case": "narration"}},
+		{VoiceID: "v4", Category: "premade"},
+	}
+}
+
+func TestVoiceLabel(t *testing.T) {
+	v := labeledVoices()[2]
+	if v.Label("gender") != "female" || v.Label("missing") != "" {
+		t.Errorf("Label() = %q, %q", v.Label("gender"), v.Label("missing"))
+	}
+	if !v.HasLabel(VoiceLabelAge, "middle aged") || v.HasLabel(VoiceLabelAge, "young") {
+		t.Error("HasLabel() does not normalize values")
+	}
+}
+
+func TestFilterVoices(t *testing.T) {
+	got := FilterVoices(labeledVoices(), map[string]string{"gender": "female", "use_case": "Narration"})
+	if len(got) != 1 || got[0].VoiceID != "v3" {
+		t.Errorf("FilterVoices() = %v", voiceIDs(got))
+	}
+	if got := FilterVoices(labeledVoices(), nil); len(got) != 4 {
+		t.Errorf("FilterVoices(nil) = %v, want all", voiceIDs(got))
+	}
+}
+
+func TestGroupVoicesByLabel(t *testing.T) {
+	groups := GroupVoicesByLabel(labeledVoices(), VoiceLabelAge)
+	if ids := voiceIDs(groups["middle aged"]); len(ids) != 2 || ids[0] != "v1" || ids[1] != "v3" {
+		t.Errorf("groups[middle aged] = %v", ids)
+	}
+	if ids := voiceIDs(groups[""]); len(ids) != 1 || ids[0] != "v4" {
+		t.Errorf("groups[\"\"] = %v", ids)
+	}
+}
+
+func TestFindVoice(t *testing.T) {
+	voices := labeledVoices()
+	tests := []struct {
+		name     string
+		criteria VoiceCriteria
+		want     string
+	}{
+		{"exact", VoiceCriteria{Gender: "female", Accent: "british", UseCase: "narration"}, "v3"},
+		{"partial accent", VoiceCriteria{Accent: "british", Category: "premade"}, "v2"},
+		{"preference only", VoiceCriteria{Gender: "male", UseCase: "conversational"}, "v1"},
+		{"required", VoiceCriteria{Gender: "male", Age: "young", Required: []string{VoiceLabelAge}}, "v2"},
+		{"other labels", VoiceCriteria{Labels: map[string]string{"use_case": "narration"}, Category: "cloned"}, "v3"},
+		{"empty", VoiceCriteria{}, "v1"},
+		{"no match", VoiceCriteria{Gender: "neutral", Required: []string{VoiceLabelGender}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindVoice(voices, tt.criteria)
+			if (got == nil && tt.want != "") || (got != nil && got.VoiceID != tt.want) {
+				t.Errorf("FindVoice() = %v, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Ties keep the input order
+	if ids := voiceIDs(RankVoices(voices, VoiceCriteria{UseCase: "narration"})); ids[0] != "v1" || ids[1] != "v3" {
+		t.Errorf("RankVoices() = %v", ids)
+	}
+}
+
+func voiceIDs(voices []*Voice) []string {
+	ids := make([]string, len(voices))
+	for i, v := range voices {
+		ids[i] = v.VoiceID
+	}
+	return ids
+}
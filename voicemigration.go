@@ -0,0 +1,384 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/agentplexus/go-elevenlabs/internal/api"
+)
+
+// VoiceExport is a voice's metadata, settings, and samples, enough to
+// recreate it as an instant voice clone in another workspace.
+type VoiceExport struct {
+	// VoiceID is the ID of the voice in the source workspace.
+	VoiceID string
+
+	// Name is the display name of the voice.
+	Name string
+
+	// Description is the description of the voice.
+	Description string
+
+	// Category is the category of the voice (e.g., "cloned").
+	Category string
+
+	// Labels are the voice's metadata labels.
+	Labels map[string]string
+
+	// Settings are the voice's saved settings. Nil if not available.
+	Settings *VoiceSettings
+
+	// Samples are the audio recordings the voice was cloned from, in
+	// memory so they can be uploaded again.
+	Samples []VoiceExportSample
+}
+
+// VoiceExportSample is an exported voice sample.
+type VoiceExportSample struct {
+	// Filename is the original name of the audio file.
+	Filename string
+
+	// MimeType is the MIME type of the audio (e.g., "audio/mpeg").
+	MimeType string
+
+	// Audio is the audio content.
+	Audio []byte
+}
+
+// Export downloads a voice's metadata, settings, and samples. Voices
+// without samples, such as premade and designed voices, export with none
+// and cannot be imported.
+func (s *VoicesService) Export(ctx context.Context, voiceID string) (*VoiceExport, error) {
+	if voiceID == "" {
+		return nil, ErrEmptyVoiceID
+	}
+
+	resp, err := s.client.apiClient.GetVoiceByID(ctx, api.GetVoiceByIDParams{
+		VoiceID: voiceID,
+	})
+	if err != nil {
+		return nil, apiError(err)
+	}
+	var r *api.VoiceResponseModel
+	switch v := resp.(type) {
+	case *api.VoiceResponseModel:
+		r = v
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(v)
+	default:
+		return nil, unexpectedResponse(resp)
+	}
+
+	voice := voiceFromAPI(r)
+	export := &VoiceExport{
+		VoiceID:     voice.VoiceID,
+		Name:        voice.Name,
+		Description: voice.Description,
+		Category:    voice.Category,
+		Labels:      voice.Labels,
+	}
+	if settings, err := s.GetSettings(ctx, voiceID); err == nil {
+		export.Settings = settings
+	} else if !IsNotFoundError(err) {
+		return nil, err
+	}
+
+	if r.Samples.Set && !r.Samples.Null {
+		for _, sample := range r.Samples.Value {
+			audio, err := s.sampleAudio(ctx, voiceID, sample.SampleID)
+			if err != nil {
+				return nil, fmt.Errorf("downloading sample %s: %w", sample.SampleID, err)
+			}
+			export.Samples = append(export.Samples, VoiceExportSample{
+				Filename: sample.FileName,
+				MimeType: sample.MimeType,
+				Audio:    audio,
+			})
+		}
+	}
+	return export, nil
+}
+
+// sampleAudio downloads the audio of a voice sample.
+func (s *VoicesService) sampleAudio(ctx context.Context, voiceID, sampleID string) ([]byte, error) {
+	resp, err := s.client.apiClient.GetAudioFromSample(ctx, api.GetAudioFromSampleParams{
+		VoiceID:  voiceID,
+		SampleID: sampleID,
+	})
+	if err != nil {
+		return nil, apiError(err)
+	}
+	switch r := resp.(type) {
+	case *api.GetAudioFromSampleOKHeaders:
+		return io.ReadAll(r.Response.Data)
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
+	default:
+		return nil, unexpectedResponse(resp)
+	}
+}
+
+// Import recreates an exported voice as an instant voice clone and
+// applies its saved settings. It returns the ID of the new voice.
+func (s *VoicesService) Import(ctx context.Context, export *VoiceExport) (*CloneVoiceResponse, error) {
+	if export == nil {
+		return nil, &ValidationError{Field: "export", Message: "cannot be nil"}
+	}
+	req := &CloneVoiceRequest{
+		Name:        export.Name,
+		Description: export.Description,
+		Labels:      export.Labels,
+		Samples:     make([]VoiceSample, len(export.Samples)),
+	}
+	for i, sample := range export.Samples {
+		req.Samples[i] = VoiceSample{
+			Filename: sample.Filename,
+			Audio:    bytes.NewReader(sample.Audio),
+		}
+	}
+	resp, err := s.Clone(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if export.Settings != nil {
+		if err := s.EditSettings(ctx, resp.VoiceID, export.Settings); err != nil {
+			return resp, fmt.Errorf("applying settings to voice %s: %w", resp.VoiceID, err)
+		}
+	}
+	return resp, nil
+}
+
+// EditSettings saves the settings of a voice.
+func (s *VoicesService) EditSettings(ctx context.Context, voiceID string, settings *VoiceSettings) error {
+	if voiceID == "" {
+		return ErrEmptyVoiceID
+	}
+	if settings == nil {
+		return &ValidationError{Field: "settings", Message: "cannot be nil"}
+	}
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	body := &api.VoiceSettingsResponseModel{
+		Stability:       api.NewOptNilFloat64(settings.Stability),
+		SimilarityBoost: api.NewOptNilFloat64(settings.SimilarityBoost),
+		Style:           api.NewOptNilFloat64(settings.Style),
+		UseSpeakerBoost: api.NewOptNilBool(settings.UseSpeakerBoost),
+	}
+	if settings.Speed != 0 {
+		body.Speed = api.NewOptNilFloat64(settings.Speed)
+	}
+	return checkResponse(s.client.apiClient.EditVoiceSettings(ctx, body, api.EditVoiceSettingsParams{
+		VoiceID: voiceID,
+	}))
+}
+
+// DefaultMigrationRetries is how many times MigrateVoices retries a
+// rate-limited request.
+const DefaultMigrationRetries = 5
+
+// VoiceMigrationOptions configures MigrateVoices.
+type VoiceMigrationOptions struct {
+	// VoiceIDs are the voices to migrate. Default: all cloned voices in the
+	// source workspace.
+	VoiceIDs []string
+
+	// MaxRetries is how many times a rate-limited request is retried.
+	// Default: DefaultMigrationRetries. Negative disables retries.
+	MaxRetries int
+
+	// RetryDelay is the wait before the first retry when the API does not
+	// send Retry-After. It doubles on each retry. Default: 1s.
+	RetryDelay time.Duration
+
+	// OnVoice is called after each voice is migrated or fails.
+	OnVoice func(*VoiceMigration)
+}
+
+// VoiceMigration is the outcome of migrating one voice.
+type VoiceMigration struct {
+	// OldVoiceID is the voice ID in the source workspace.
+	OldVoiceID string
+
+	// NewVoiceID is the voice ID in the destination workspace. Empty if the
+	// voice could not be created.
+	NewVoiceID string
+
+	// Name is the voice name.
+	Name string
+
+	// Samples is the number of samples uploaded.
+	Samples int
+
+	// RequiresVerification reports whether the new voice must be verified
+	// before use.
+	RequiresVerification bool
+
+	// Err is the error migrating the voice, if any. A voice can have both
+	// a NewVoiceID and an Err if its settings could not be applied.
+	Err error
+}
+
+// VoiceMigrationReport is the result of MigrateVoices.
+type VoiceMigrationReport struct {
+	// Voices are the outcomes in migration order.
+	Voices []*VoiceMigration
+}
+
+// IDMap returns the mapping from old to new voice IDs of the voices that
+// were created, for rewriting voice references in scripts and agents.
+func (r *VoiceMigrationReport) IDMap() map[string]string {
+	ids := make(map[string]string, len(r.Voices))
+	for _, v := range r.Voices {
+		if v.NewVoiceID != "" {
+			ids[v.OldVoiceID] = v.NewVoiceID
+		}
+	}
+	return ids
+}
+
+// Failed returns the voices that could not be migrated completely.
+func (r *VoiceMigrationReport) Failed() []*VoiceMigration {
+	var failed []*VoiceMigration
+	for _, v := range r.Voices {
+		if v.Err != nil {
+			failed = append(failed, v)
+		}
+	}
+	return failed
+}
+
+// MigrateVoices copies voices from one workspace to another, for example
+// when consolidating accounts. Each voice is exported from the from client
+// and recreated as an instant voice clone with the to client, one at a
+// time; rate-limited requests are retried after the Retry-After delay.
+// Professional voice clones are recreated as instant clones from their
+// samples.
+//
+// A voice that fails is recorded in the report and the migration
+// continues. The returned error is only for failures that stop the
+// migration, such as listing the source voices or a canceled context.
+// Running it again creates duplicates: pass the failed VoiceIDs to retry.
+func MigrateVoices(ctx context.Context, from, to *Client, opts *VoiceMigrationOptions) (*VoiceMigrationReport, error) {
+	if from == nil || to == nil {
+		return nil, &ValidationError{Field: "client", Message: "cannot be nil"}
+	}
+	if opts == nil {
+		opts = &VoiceMigrationOptions{}
+	}
+	retry := &rateLimitRetry{maxRetries: opts.MaxRetries, delay: opts.RetryDelay}
+	if retry.maxRetries == 0 {
+		retry.maxRetries = DefaultMigrationRetries
+	}
+	if retry.delay <= 0 {
+		retry.delay = time.Second
+	}
+
+	voiceIDs := opts.VoiceIDs
+	if len(voiceIDs) == 0 {
+		var voices []*Voice
+		err := retry.do(ctx, func() (err error) {
+			voices, err = from.Voices().List(ctx)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing voices: %w", err)
+		}
+		for _, v := range voices {
+			if v.Category == VoiceCategoryCloned {
+				voiceIDs = append(voiceIDs, v.VoiceID)
+			}
+		}
+	}
+
+	report := &VoiceMigrationReport{}
+	for _, voiceID := range voiceIDs {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		m := migrateVoice(ctx, from, to, voiceID, retry)
+		report.Voices = append(report.Voices, m)
+		if opts.OnVoice != nil {
+			opts.OnVoice(m)
+		}
+		if errors.Is(m.Err, context.Canceled) || errors.Is(m.Err, context.DeadlineExceeded) {
+			return report, m.Err
+		}
+	}
+	return report, nil
+}
+
+// migrateVoice exports one voice and imports it into the destination.
+func migrateVoice(ctx context.Context, from, to *Client, voiceID string, retry *rateLimitRetry) *VoiceMigration {
+	m := &VoiceMigration{OldVoiceID: voiceID}
+
+	var export *VoiceExport
+	if m.Err = retry.do(ctx, func() (err error) {
+		export, err = from.Voices().Export(ctx, voiceID)
+		return err
+	}); m.Err != nil {
+		m.Err = fmt.Errorf("exporting voice %s: %w", voiceID, m.Err)
+		return m
+	}
+	m.Name = export.Name
+	if len(export.Samples) == 0 {
+		m.Err = fmt.Errorf("voice %s has no samples to clone from", voiceID)
+		return m
+	}
+
+	var resp *CloneVoiceResponse
+	m.Err = retry.do(ctx, func() (err error) {
+		if resp != nil {
+			// The voice exists; only the settings are left
+			return to.Voices().EditSettings(ctx, resp.VoiceID, export.Settings)
+		}
+		resp, err = to.Voices().Import(ctx, export)
+		return err
+	})
+	if resp != nil {
+		m.NewVoiceID = resp.VoiceID
+		m.RequiresVerification = resp.RequiresVerification
+		m.Samples = len(export.Samples)
+	}
+	if m.Err != nil {
+		m.Err = fmt.Errorf("importing voice %s: %w", voiceID, m.Err)
+	}
+	return m
+}
+
+// rateLimitRetry retries requests that fail with 429 Too Many Requests.
+type rateLimitRetry struct {
+	maxRetries int
+	delay      time.Duration
+}
+
+// do calls fn until it succeeds, fails with an error other than a rate
+// limit, or runs out of retries. It waits for the Retry-After delay if
+// the API sent one, otherwise for an exponentially growing delay.
+func (r *rateLimitRetry) do(ctx context.Context, fn func() error) error {
+	delay := r.delay
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || !IsRateLimitError(err) || attempt >= r.maxRetries {
+			return err
+		}
+		wait := delay
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
@@ -0,0 +1,205 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// voiceMigrationJSON returns a voice with the given number of samples.
+func voiceMigrationJSON(id, category string, samples int) string {
+	var list []string
+	for i := range samples {
+		list = append(list, fmt.Sprintf(`{"sample_id": "%s-s%d", "file_name": "take%d.mp3", "mime_type": "audio/mpeg", "size_bytes": 5, "hash": "h"}`, id, i+1, i+1))
+	}
+	return fmt.Sprintf(`{"voice_id": %q, "name": "Voice %s", "category": %q, "description": "Narrator",
+		"labels": {"accent": "british"}, "available_for_tiers": [], "high_quality_base_model_ids": [],
+		"samples": [%s]}`, id, id, category, strings.Join(list, ", "))
+}
+
+// newVoiceSourceServer serves a workspace with a cloned voice "a" with two
+// samples, a cloned voice "b" without samples, and a premade voice.
+func newVoiceSourceServer(t *testing.T) *Client {
+	t.Helper()
+	voices := map[string]string{
+		"a":       voiceMigrationJSON("a", "cloned", 2),
+		"b":       voiceMigrationJSON("b", "cloned", 0),
+		"premade": voiceMigrationJSON("premade", "premade", 0),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/voices"), "/")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/voices":
+			fmt.Fprintf(w, `{"voices": [%s, %s, %s]}`, voices["a"], voices["b"], voices["premade"])
+		case len(parts) == 2:
+			fmt.Fprint(w, voices[parts[1]])
+		case len(parts) == 3 && parts[2] == "settings":
+			fmt.Fprint(w, `{"stability": 0.3, "similarity_boost": 0.9, "style": 0.1, "speed": 1.1, "use_speaker_boost": true}`)
+		case len(parts) == 5 && parts[4] == "audio":
+			w.Header().Set("Content-Type", "audio/mpeg")
+			fmt.Fprint(w, "audio-"+parts[3])
+		default:
+			t.Errorf("unexpected source request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("source-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+// voiceDestination records the voices created in a destination workspace.
+type voiceDestination struct {
+	mu       sync.Mutex
+	adds     int
+	samples  []string
+	settings map[string]float64
+}
+
+// newVoiceDestServer serves a workspace that rate limits the first voice
+// creation and records uploaded samples and settings.
+func newVoiceDestServer(t *testing.T) (*Client, *voiceDestination) {
+	t.Helper()
+	dest := &voiceDestination{settings: make(map[string]float64)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dest.mu.Lock()
+		defer dest.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/voices/add":
+			dest.adds++
+			if dest.adds == 1 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				fmt.Fprint(w, `{"detail": {"status": "too_many_concurrent_requests", "message": "slow down"}}`)
+				return
+			}
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("ParseMultipartForm() error = %v", err)
+			}
+			if got := r.FormValue("labels"); got != `{"accent":"british"}` {
+				t.Errorf("labels = %s", got)
+			}
+			for _, fh := range r.MultipartForm.File["files"] {
+				f, _ := fh.Open()
+				data, _ := io.ReadAll(f)
+				f.Close()
+				dest.samples = append(dest.samples, fh.Filename+"="+string(data))
+			}
+			fmt.Fprintf(w, `{"voice_id": "new-%d", "requires_verification": false}`, dest.adds)
+		case strings.HasSuffix(r.URL.Path, "/settings/edit"):
+			var body struct {
+				Stability float64 `json:"stability"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			voiceID := strings.Split(r.URL.Path, "/")[3]
+			dest.settings[voiceID] = body.Stability
+			fmt.Fprint(w, `{"status": "ok"}`)
+		default:
+			t.Errorf("unexpected destination request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("dest-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client, dest
+}
+
+func TestVoicesExport(t *testing.T) {
+	client := newVoiceSourceServer(t)
+
+	export, err := client.Voices().Export(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if export.Name != "Voice a" || export.Description != "Narrator" || export.Labels["accent"] != "british" {
+		t.Errorf("export = %+v", export)
+	}
+	if export.Settings == nil || export.Settings.Stability != 0.3 || !export.Settings.UseSpeakerBoost {
+		t.Errorf("Settings = %+v", export.Settings)
+	}
+	if len(export.Samples) != 2 || string(export.Samples[1].Audio) != "audio-a-s2" || export.Samples[1].Filename != "take2.mp3" {
+		t.Errorf("Samples = %+v", export.Samples)
+	}
+}
+
+func TestMigrateVoices(t *testing.T) {
+	from := newVoiceSourceServer(t)
+	to, dest := newVoiceDestServer(t)
+
+	var reported []string
+	report, err := MigrateVoices(context.Background(), from, to, &VoiceMigrationOptions{
+		RetryDelay: time.Millisecond,
+		OnVoice: func(m *VoiceMigration) {
+			reported = append(reported, m.OldVoiceID)
+		},
+	})
+	if err != nil {
+		t.Fatalf("MigrateVoices() error = %v", err)
+	}
+
+	// Premade voices are not migrated by default
+	if strings.Join(reported, ",") != "a,b" {
+		t.Errorf("reported = %v, want a,b", reported)
+	}
+	if ids := report.IDMap(); len(ids) != 1 || ids["a"] != "new-2" {
+		t.Errorf("IDMap() = %v, want a -> new-2", ids)
+	}
+	failed := report.Failed()
+	if len(failed) != 1 || failed[0].OldVoiceID != "b" || !strings.Contains(failed[0].Err.Error(), "no samples") {
+		t.Errorf("Failed() = %+v", failed)
+	}
+	if report.Voices[0].Samples != 2 {
+		t.Errorf("Samples = %d, want 2", report.Voices[0].Samples)
+	}
+
+	if strings.Join(dest.samples, ",") != "take1.mp3=audio-a-s1,take2.mp3=audio-a-s2" {
+		t.Errorf("uploaded samples = %v", dest.samples)
+	}
+	if dest.settings["new-2"] != 0.3 {
+		t.Errorf("settings = %v", dest.settings)
+	}
+}
+
+func TestMigrateVoicesRateLimitExhausted(t *testing.T) {
+	from := newVoiceSourceServer(t)
+	to, _ := newVoiceDestServer(t)
+
+	report, err := MigrateVoices(context.Background(), from, to, &VoiceMigrationOptions{
+		VoiceIDs:   []string{"a"},
+		MaxRetries: -1,
+	})
+	if err != nil {
+		t.Fatalf("MigrateVoices() error = %v", err)
+	}
+	if m := report.Voices[0]; m.NewVoiceID != "" || !IsRateLimitError(m.Err) {
+		t.Errorf("migration = %+v, want rate limit error", m)
+	}
+}
+
+func TestMigrateVoicesCanceled(t *testing.T) {
+	from := newVoiceSourceServer(t)
+	to, _ := newVoiceDestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := MigrateVoices(ctx, from, to, &VoiceMigrationOptions{VoiceIDs: []string{"a"}}); !errors.Is(err, context.Canceled) {
+		t.Errorf("MigrateVoices() error = %v, want context.Canceled", err)
+	}
+}
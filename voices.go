@@ -1,7 +1,16 @@
 package elevenlabs
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	ht "github.com/ogen-go/ogen/http"
 
 	"github.com/agentplexus/go-elevenlabs/internal/api"
 )
@@ -9,6 +18,9 @@ import (
 // VoicesService handles voice operations.
 type VoicesService struct {
 	client *Client
+
+	previewMu sync.Mutex
+	previews  map[string][]byte
 }
 
 // Voice represents an ElevenLabs voice.
@@ -30,41 +42,95 @@ type Voice struct {
 
 	// Labels contains additional metadata about the voice.
 	Labels map[string]string
+
+	// CreatedAt is when the voice was created. Zero for older voices.
+	CreatedAt time.Time
+
+	// IsOwner reports whether the voice belongs to the current user.
+	IsOwner bool
+
+	// HighQualityBaseModelIDs are the models the voice is optimized for.
+	HighQualityBaseModelIDs []string
+
+	// FineTuning is the fine-tuning status of professional voice clones.
+	// Nil if the API did not report it.
+	FineTuning *VoiceFineTuning
+
+	// SafetyControl is the safety restriction on the voice (e.g.,
+	// VoiceSafetyControlCaptcha). Empty if none.
+	SafetyControl string
+}
+
+// Fine-tuning states of a voice for a model.
+const (
+	FineTuningNotStarted = "not_started"
+	FineTuningQueued     = "queued"
+	FineTuningInProgress = "fine_tuning"
+	FineTuningFineTuned  = "fine_tuned"
+	FineTuningFailed     = "failed"
+	FineTuningDelayed    = "delayed"
+)
+
+// Voice safety controls.
+const (
+	VoiceSafetyControlNone              = "NONE"
+	VoiceSafetyControlBan               = "BAN"
+	VoiceSafetyControlCaptcha           = "CAPTCHA"
+	VoiceSafetyControlEnterpriseBan     = "ENTERPRISE_BAN"
+	VoiceSafetyControlEnterpriseCaptcha = "ENTERPRISE_CAPTCHA"
+)
+
+// VoiceFineTuning is the fine-tuning status of a voice.
+type VoiceFineTuning struct {
+	// IsAllowed reports whether the voice can be fine-tuned.
+	IsAllowed bool
+
+	// Language is the language of the fine-tuning dataset.
+	Language string
+
+	// State is the fine-tuning state (e.g., FineTuningFineTuned) per
+	// model ID.
+	State map[string]string
+
+	// Progress is the fine-tuning progress, from 0 to 1, per model ID.
+	Progress map[string]float64
+
+	// Message is the latest status message per model ID.
+	Message map[string]string
+
+	// VerificationFailures lists why voice verification failed.
+	VerificationFailures []string
+
+	// ManualVerificationRequested reports whether manual verification was
+	// requested.
+	ManualVerificationRequested bool
 }
 
-// List returns all available voices.
+// IsFineTuned reports whether the voice is fine-tuned for modelID.
+func (f *VoiceFineTuning) IsFineTuned(modelID string) bool {
+	return f != nil && f.State[modelID] == FineTuningFineTuned
+}
+
+// List returns all available voices. For accounts with many voices, use
+// Search to fetch them a page at a time.
 func (s *VoicesService) List(ctx context.Context) ([]*Voice, error) {
 	resp, err := s.client.apiClient.GetVoices(ctx, api.GetVoicesParams{})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	// Handle response type
 	switch r := resp.(type) {
 	case *api.GetVoicesResponseModel:
 		voices := make([]*Voice, 0, len(r.Voices))
-		for _, v := range r.Voices {
-			voice := &Voice{
-				VoiceID:  v.VoiceID,
-				Name:     v.Name,
-				Category: string(v.Category),
-				Labels:   make(map[string]string),
-			}
-			if v.Description.Set && !v.Description.Null {
-				voice.Description = v.Description.Value
-			}
-			if v.PreviewURL.Set && !v.PreviewURL.Null {
-				voice.PreviewURL = v.PreviewURL.Value
-			}
-			// Convert labels
-			for k, val := range v.Labels {
-				voice.Labels[k] = val
-			}
-			voices = append(voices, voice)
+		for i := range r.Voices {
+			voices = append(voices, voiceFromAPI(&r.Voices[i]))
 		}
 		return voices, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -78,31 +144,226 @@ func (s *VoicesService) Get(ctx context.Context, voiceID string) (*Voice, error)
 		VoiceID: voiceID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	// Handle response type
 	switch r := resp.(type) {
 	case *api.VoiceResponseModel:
-		voice := &Voice{
-			VoiceID:  r.VoiceID,
-			Name:     r.Name,
-			Category: string(r.Category),
-			Labels:   make(map[string]string),
+		return voiceFromAPI(r), nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
+	default:
+		return nil, unexpectedResponse(resp)
+	}
+}
+
+// voiceFromAPI converts a voice from the generated client.
+func voiceFromAPI(r *api.VoiceResponseModel) *Voice {
+	voice := &Voice{
+		VoiceID:  r.VoiceID,
+		Name:     r.Name,
+		Category: string(r.Category),
+		Labels:   make(map[string]string),
+	}
+	if r.Description.Set && !r.Description.Null {
+		voice.Description = r.Description.Value
+	}
+	if r.PreviewURL.Set && !r.PreviewURL.Null {
+		voice.PreviewURL = r.PreviewURL.Value
+	}
+	// Convert labels
+	for k, val := range r.Labels {
+		voice.Labels[k] = val
+	}
+	if r.CreatedAtUnix.Set && !r.CreatedAtUnix.Null {
+		voice.CreatedAt = time.Unix(int64(r.CreatedAtUnix.Value), 0)
+	}
+	if r.IsOwner.Set && !r.IsOwner.Null {
+		voice.IsOwner = r.IsOwner.Value
+	}
+	voice.HighQualityBaseModelIDs = r.HighQualityBaseModelIds
+	if r.SafetyControl.Set && !r.SafetyControl.Null {
+		voice.SafetyControl = string(r.SafetyControl.Value)
+	}
+	if r.FineTuning.Set {
+		voice.FineTuning = fineTuningFromAPI(&r.FineTuning.Value)
+	}
+	return voice
+}
+
+func fineTuningFromAPI(r *api.FineTuningResponseModel) *VoiceFineTuning {
+	ft := &VoiceFineTuning{
+		IsAllowed:                   r.IsAllowedToFineTune,
+		State:                       make(map[string]string, len(r.State)),
+		VerificationFailures:        r.VerificationFailures,
+		ManualVerificationRequested: r.ManualVerificationRequested,
+	}
+	if r.Language.Set && !r.Language.Null {
+		ft.Language = r.Language.Value
+	}
+	for model, state := range r.State {
+		ft.State[model] = string(state)
+	}
+	if r.Progress.Set && !r.Progress.Null {
+		ft.Progress = r.Progress.Value
+	}
+	if r.Message.Set && !r.Message.Null {
+		ft.Message = r.Message.Value
+	}
+	return ft
+}
+
+// Voice categories.
+const (
+	VoiceCategoryPremade      = "premade"
+	VoiceCategoryCloned       = "cloned"
+	VoiceCategoryGenerated    = "generated"
+	VoiceCategoryProfessional = "professional"
+)
+
+// Voice types for VoiceSearchOptions.
+const (
+	VoiceTypePersonal   = "personal"
+	VoiceTypeCommunity  = "community"
+	VoiceTypeDefault    = "default"
+	VoiceTypeWorkspace  = "workspace"
+	VoiceTypeNonDefault = "non-default" // all but default voices
+)
+
+// Voice sort fields for VoiceSearchOptions.
+const (
+	VoiceSortName      = "name"
+	VoiceSortCreatedAt = "created_at_unix"
+)
+
+// MaxVoiceSearchPageSize is the largest page size accepted by Search.
+const MaxVoiceSearchPageSize = 100
+
+// VoiceSearchOptions contains options for searching voices.
+type VoiceSearchOptions struct {
+	// Search filters by name, description, labels, and category.
+	Search string
+
+	// Category filters by category (e.g., VoiceCategoryCloned).
+	Category string
+
+	// VoiceType filters by type (e.g., VoiceTypePersonal).
+	VoiceType string
+
+	// CollectionID filters by collection.
+	CollectionID string
+
+	// VoiceIDs looks up specific voices (max 100).
+	VoiceIDs []string
+
+	// Sort is the field to sort by (VoiceSortName or VoiceSortCreatedAt).
+	Sort string
+
+	// SortDescending sorts in descending order.
+	SortDescending bool
+
+	// PageSize is the number of voices per page (max 100, default 10).
+	PageSize int
+
+	// NextPageToken fetches the page after a previous response.
+	NextPageToken string
+
+	// IncludeTotalCount requests TotalCount in the response. This is
+	// slower; use HasMore for pagination.
+	IncludeTotalCount bool
+}
+
+// VoiceSearchResponse contains a page of voices.
+type VoiceSearchResponse struct {
+	// Voices is the list of voices on this page.
+	Voices []*Voice
+
+	// HasMore indicates if there are more voices to fetch.
+	HasMore bool
+
+	// NextPageToken is the token for the next page.
+	NextPageToken string
+
+	// TotalCount is the number of matching voices, if requested.
+	TotalCount int
+}
+
+// Search returns a page of voices matching opts, using the v2 voices
+// endpoint. Unlike List, it does not fetch every voice at once. Pass the
+// NextPageToken of a response in opts to fetch the next page.
+func (s *VoicesService) Search(ctx context.Context, opts *VoiceSearchOptions) (*VoiceSearchResponse, error) {
+	params := api.GetUserVoicesV2Params{}
+
+	if opts != nil {
+		if opts.PageSize < 0 || opts.PageSize > MaxVoiceSearchPageSize {
+			return nil, &ValidationError{Field: "page_size", Message: fmt.Sprintf("must be between 1 and %d", MaxVoiceSearchPageSize)}
+		}
+		if len(opts.VoiceIDs) > MaxVoiceSearchPageSize {
+			return nil, &ValidationError{Field: "voice_ids", Message: fmt.Sprintf("at most %d voice IDs", MaxVoiceSearchPageSize)}
+		}
+		switch opts.Sort {
+		case "", VoiceSortName, VoiceSortCreatedAt:
+		default:
+			return nil, &ValidationError{Field: "sort", Message: fmt.Sprintf("unsupported sort field %q", opts.Sort)}
+		}
+
+		if opts.Search != "" {
+			params.Search = api.NewOptNilString(opts.Search)
 		}
-		if r.Description.Set && !r.Description.Null {
-			voice.Description = r.Description.Value
+		if opts.Category != "" {
+			params.Category = api.NewOptNilString(opts.Category)
+		}
+		if opts.VoiceType != "" {
+			params.VoiceType = api.NewOptNilString(opts.VoiceType)
+		}
+		if opts.CollectionID != "" {
+			params.CollectionID = api.NewOptNilString(opts.CollectionID)
+		}
+		if len(opts.VoiceIDs) > 0 {
+			params.VoiceIds = api.NewOptNilStringArray(opts.VoiceIDs)
+		}
+		if opts.Sort != "" {
+			params.Sort = api.NewOptNilString(opts.Sort)
+		}
+		if opts.SortDescending {
+			params.SortDirection = api.NewOptNilString("desc")
+		}
+		if opts.PageSize > 0 {
+			params.PageSize = api.NewOptInt(opts.PageSize)
+		}
+		if opts.NextPageToken != "" {
+			params.NextPageToken = api.NewOptNilString(opts.NextPageToken)
+		}
+		if opts.IncludeTotalCount {
+			params.IncludeTotalCount = api.NewOptBool(true)
+		}
+	}
+
+	resp, err := s.client.apiClient.GetUserVoicesV2(ctx, params)
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	// Handle response type
+	switch r := resp.(type) {
+	case *api.GetVoicesV2ResponseModel:
+		result := &VoiceSearchResponse{
+			Voices:     make([]*Voice, 0, len(r.Voices)),
+			HasMore:    r.HasMore,
+			TotalCount: r.TotalCount,
 		}
-		if r.PreviewURL.Set && !r.PreviewURL.Null {
-			voice.PreviewURL = r.PreviewURL.Value
+		if r.NextPageToken.Set && !r.NextPageToken.Null {
+			result.NextPageToken = r.NextPageToken.Value
 		}
-		// Convert labels
-		for k, val := range r.Labels {
-			voice.Labels[k] = val
+		for i := range r.Voices {
+			result.Voices = append(result.Voices, voiceFromAPI(&r.Voices[i]))
 		}
-		return voice, nil
+		return result, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -116,7 +377,7 @@ func (s *VoicesService) GetSettings(ctx context.Context, voiceID string) (*Voice
 		VoiceID: voiceID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	// Handle response type
@@ -135,9 +396,14 @@ func (s *VoicesService) GetSettings(ctx context.Context, voiceID string) (*Voice
 		if r.Speed.Set && !r.Speed.Null {
 			settings.Speed = r.Speed.Value
 		}
+		if r.UseSpeakerBoost.Set && !r.UseSpeakerBoost.Null {
+			settings.UseSpeakerBoost = r.UseSpeakerBoost.Value
+		}
 		return settings, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
 	default:
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, unexpectedResponse(resp)
 	}
 }
 
@@ -145,7 +411,7 @@ func (s *VoicesService) GetSettings(ctx context.Context, voiceID string) (*Voice
 func (s *VoicesService) GetDefaultSettings(ctx context.Context) (*VoiceSettings, error) {
 	resp, err := s.client.apiClient.GetVoiceSettingsDefault(ctx)
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	settings := &VoiceSettings{}
@@ -170,8 +436,234 @@ func (s *VoicesService) Delete(ctx context.Context, voiceID string) error {
 		return ErrEmptyVoiceID
 	}
 
-	_, err := s.client.apiClient.DeleteVoice(ctx, api.DeleteVoiceParams{
+	return checkResponse(s.client.apiClient.DeleteVoice(ctx, api.DeleteVoiceParams{
 		VoiceID: voiceID,
+	}))
+}
+
+// VoiceSample is an audio recording used for voice cloning.
+type VoiceSample struct {
+	// Filename is the name of the audio file (e.g., "sample1.mp3").
+	Filename string
+
+	// Audio is the audio content.
+	Audio io.Reader
+}
+
+// CloneVoiceRequest contains options for instant voice cloning.
+type CloneVoiceRequest struct {
+	// Name is the display name of the new voice (required).
+	Name string
+
+	// Description is an optional description of the voice.
+	Description string
+
+	// Samples are the audio recordings to clone from (at least one required).
+	Samples []VoiceSample
+
+	// Labels are optional metadata labels (e.g., {"accent": "british"}).
+	Labels map[string]string
+
+	// RemoveBackgroundNoise removes background noise from the samples.
+	// Can reduce quality if the samples are already clean.
+	RemoveBackgroundNoise bool
+}
+
+// CloneVoiceResponse contains the result of voice cloning.
+type CloneVoiceResponse struct {
+	// VoiceID is the ID of the new voice.
+	VoiceID string
+
+	// RequiresVerification indicates the voice must be verified before use.
+	RequiresVerification bool
+}
+
+// Clone creates a new voice from audio samples using instant voice cloning.
+func (s *VoicesService) Clone(ctx context.Context, req *CloneVoiceRequest) (*CloneVoiceResponse, error) {
+	if req.Name == "" {
+		return nil, &ValidationError{Field: "name", Message: "cannot be empty"}
+	}
+	if len(req.Samples) == 0 {
+		return nil, &ValidationError{Field: "samples", Message: "at least one sample is required"}
+	}
+
+	body := &api.BodyAddVoiceV1VoicesAddPostMultipart{
+		Name:  req.Name,
+		Files: make([]ht.MultipartFile, 0, len(req.Samples)),
+	}
+	for _, sample := range req.Samples {
+		if sample.Audio == nil {
+			return nil, &ValidationError{Field: "samples", Message: "audio cannot be nil"}
+		}
+		body.Files = append(body.Files, ht.MultipartFile{
+			Name: sample.Filename,
+			File: sample.Audio,
+		})
+	}
+	if req.Description != "" {
+		body.Description = api.NewOptNilString(req.Description)
+	}
+	if len(req.Labels) > 0 {
+		labels, err := json.Marshal(req.Labels)
+		if err != nil {
+			return nil, err
+		}
+		body.Labels = api.NewOptNilString(string(labels))
+	}
+	if req.RemoveBackgroundNoise {
+		body.RemoveBackgroundNoise = api.NewOptBool(true)
+	}
+
+	resp, err := s.client.apiClient.AddVoice(ctx, body, api.AddVoiceParams{})
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.AddVoiceIVCResponseModel:
+		return &CloneVoiceResponse{
+			VoiceID:              r.VoiceID,
+			RequiresVerification: r.RequiresVerification,
+		}, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
+	default:
+		return nil, unexpectedResponse(resp)
+	}
+}
+
+// DefaultPreviewConcurrency is the number of previews PreviewSettings
+// generates at once.
+const DefaultPreviewConcurrency = 4
+
+// SettingsPreview is a sample generated with one candidate VoiceSettings.
+type SettingsPreview struct {
+	// Label describes the settings, e.g. "#1 stability=0.50 similarity=0.75
+	// style=0.00 speed=1.00 speaker_boost=true".
+	Label string
+
+	// Settings are the candidate settings.
+	Settings *VoiceSettings
+
+	// Audio is the generated sample, fully buffered. Nil if Err is set.
+	Audio io.Reader
+
+	// Err is the error generating this sample, if any.
+	Err error
+}
+
+// PreviewSettings generates a sample of text for each candidate settings
+// concurrently, to compare stability, style, and speed by ear. Keep the
+// text short, as each candidate is billed separately.
+//
+// Previews are returned in the order of candidates. If some samples fail,
+// the previews are still returned with Err set, along with an error
+// joining the failures.
+func (s *VoicesService) PreviewSettings(ctx context.Context, voiceID, text string, candidates []*VoiceSettings) ([]*SettingsPreview, error) {
+	if voiceID == "" {
+		return nil, ErrEmptyVoiceID
+	}
+	if text == "" {
+		return nil, ErrEmptyText
+	}
+	if len(candidates) == 0 {
+		return nil, &ValidationError{Field: "candidates", Message: "at least one voice settings candidate is required"}
+	}
+	for i, vs := range candidates {
+		if vs == nil {
+			return nil, &ValidationError{Field: "candidates", Message: fmt.Sprintf("candidate %d is nil", i)}
+		}
+		if err := vs.Validate(); err != nil {
+			return nil, fmt.Errorf("candidate %d: %w", i, err)
+		}
+	}
+
+	previews := make([]*SettingsPreview, len(candidates))
+	sem := make(chan struct{}, DefaultPreviewConcurrency)
+	var wg sync.WaitGroup
+	for i, vs := range candidates {
+		previews[i] = &SettingsPreview{Label: settingsLabel(i, vs), Settings: vs}
+		wg.Add(1)
+		go func(p *SettingsPreview) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				p.Err = ctx.Err()
+				return
+			}
+			p.Audio, p.Err = s.previewSample(ctx, voiceID, text, p.Settings)
+		}(previews[i])
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, p := range previews {
+		if p.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Label, p.Err))
+		}
+	}
+	return previews, errors.Join(errs...)
+}
+
+func (s *VoicesService) previewSample(ctx context.Context, voiceID, text string, vs *VoiceSettings) (io.Reader, error) {
+	return s.client.tts.generateBuffered(ctx, &TTSRequest{
+		VoiceID:       voiceID,
+		Text:          text,
+		VoiceSettings: vs,
 	})
-	return err
+}
+
+func settingsLabel(i int, vs *VoiceSettings) string {
+	return fmt.Sprintf("#%d stability=%.2f similarity=%.2f style=%.2f speed=%.2f speaker_boost=%t",
+		i+1, vs.Stability, vs.SimilarityBoost, vs.Style, vs.Speed, vs.UseSpeakerBoost)
+}
+
+// DefaultPreviewText is the text spoken by Preview when none is given.
+const DefaultPreviewText = "Hello! This is a preview of my voice."
+
+// Preview returns a short sample of a voice speaking text, or
+// DefaultPreviewText if text is empty, using the voice's own settings.
+// Samples are cached per voice and text, so voice pickers can call Preview
+// freely; use ClearPreviewCache to free them.
+func (s *VoicesService) Preview(ctx context.Context, voiceID, text string) (io.Reader, error) {
+	if voiceID == "" {
+		return nil, ErrEmptyVoiceID
+	}
+	if text == "" {
+		text = DefaultPreviewText
+	}
+	key := voiceID + "\x00" + text
+
+	s.previewMu.Lock()
+	data, ok := s.previews[key]
+	s.previewMu.Unlock()
+	if ok {
+		return bytes.NewReader(data), nil
+	}
+
+	resp, err := s.client.tts.Generate(ctx, &TTSRequest{VoiceID: voiceID, Text: text})
+	if err != nil {
+		return nil, err
+	}
+	data, err = io.ReadAll(resp.Audio)
+	if err != nil {
+		return nil, fmt.Errorf("reading audio: %w", err)
+	}
+
+	s.previewMu.Lock()
+	if s.previews == nil {
+		s.previews = make(map[string][]byte)
+	}
+	s.previews[key] = data
+	s.previewMu.Unlock()
+	return bytes.NewReader(data), nil
+}
+
+// ClearPreviewCache removes all samples cached by Preview.
+func (s *VoicesService) ClearPreviewCache() {
+	s.previewMu.Lock()
+	s.previews = nil
+	s.previewMu.Unlock()
 }
@@ -2,6 +2,14 @@ package elevenlabs
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -121,3 +129,306 @@ func TestVoicesGetValidation(t *testing.T) {
 		t.Errorf("GetSettings('') error = %v, want %v", err, ErrEmptyVoiceID)
 	}
 }
+
+func TestVoicesCloneValidation(t *testing.T) {
+	client, _ := NewClient()
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		req   *CloneVoiceRequest
+		field string
+	}{
+		{"empty name", &CloneVoiceRequest{Samples: []VoiceSample{{Filename: "a.mp3", Audio: strings.NewReader("x")}}}, "name"},
+		{"no samples", &CloneVoiceRequest{Name: "Narrator"}, "samples"},
+		{"nil audio", &CloneVoiceRequest{Name: "Narrator", Samples: []VoiceSample{{Filename: "a.mp3"}}}, "samples"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Voices().Clone(ctx, tt.req)
+			var valErr *ValidationError
+			if !isValidationError(err, &valErr) {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+			if valErr.Field != tt.field {
+				t.Errorf("ValidationError field = %s, want %s", valErr.Field, tt.field)
+			}
+		})
+	}
+}
+
+func TestVoicesPreviewSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			VoiceSettings struct {
+				Stability float64 `json:"stability"`
+			} `json:"voice_settings"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+		if body.VoiceSettings.Stability == 0.9 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		fmt.Fprintf(w, "audio-%.1f", body.VoiceSettings.Stability)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	candidates := []*VoiceSettings{
+		{Stability: 0.3, SimilarityBoost: 0.75},
+		{Stability: 0.5, SimilarityBoost: 0.75},
+		{Stability: 0.9, SimilarityBoost: 0.75},
+	}
+	previews, err := client.Voices().PreviewSettings(context.Background(), "voice-1", "Hello there.", candidates)
+	if err == nil {
+		t.Error("PreviewSettings() should report the failed candidate")
+	}
+	if len(previews) != 3 {
+		t.Fatalf("got %d previews, want 3", len(previews))
+	}
+
+	for i, want := range []string{"audio-0.3", "audio-0.5"} {
+		p := previews[i]
+		if p.Err != nil {
+			t.Fatalf("preview %d error = %v", i, p.Err)
+		}
+		data, _ := io.ReadAll(p.Audio)
+		if string(data) != want {
+			t.Errorf("preview %d audio = %q, want %q", i, data, want)
+		}
+		if p.Settings != candidates[i] {
+			t.Errorf("preview %d settings not preserved", i)
+		}
+	}
+	if !strings.HasPrefix(previews[0].Label, "#1 stability=0.30") {
+		t.Errorf("Label = %q", previews[0].Label)
+	}
+	if previews[2].Err == nil || previews[2].Audio != nil {
+		t.Errorf("preview 2 = %+v, want error", previews[2])
+	}
+}
+
+func TestVoicesPreviewSettingsValidation(t *testing.T) {
+	client, _ := NewClient(WithAPIKey("test-key"))
+	ctx := context.Background()
+
+	if _, err := client.Voices().PreviewSettings(ctx, "", "text", []*VoiceSettings{DefaultVoiceSettings()}); err != ErrEmptyVoiceID {
+		t.Errorf("error = %v, want %v", err, ErrEmptyVoiceID)
+	}
+	var valErr *ValidationError
+	if _, err := client.Voices().PreviewSettings(ctx, "voice-1", "text", nil); !isValidationError(err, &valErr) {
+		t.Errorf("expected ValidationError for no candidates, got %v", err)
+	}
+	if _, err := client.Voices().PreviewSettings(ctx, "voice-1", "text", []*VoiceSettings{{Stability: 2}}); !errors.Is(err, ErrInvalidStability) {
+		t.Errorf("error = %v, want %v", err, ErrInvalidStability)
+	}
+}
+
+func TestVoicesSearch(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/voices" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"voices": [{
+				"voice_id": "voice-1",
+				"name": "Narrator",
+				"category": "cloned",
+				"labels": {"accent": "british"},
+				"description": "Warm narrator",
+				"available_for_tiers": [],
+				"high_quality_base_model_ids": []
+			}],
+			"has_more": true,
+			"next_page_token": "page-2",
+			"total_count": 240
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	resp, err := client.Voices().Search(context.Background(), &VoiceSearchOptions{
+		Search:            "narrator",
+		Category:          VoiceCategoryCloned,
+		VoiceType:         VoiceTypePersonal,
+		Sort:              VoiceSortName,
+		SortDescending:    true,
+		PageSize:          50,
+		NextPageToken:     "page-1",
+		IncludeTotalCount: true,
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	want := map[string]string{
+		"search":              "narrator",
+		"category":            "cloned",
+		"voice_type":          "personal",
+		"sort":                "name",
+		"sort_direction":      "desc",
+		"page_size":           "50",
+		"next_page_token":     "page-1",
+		"include_total_count": "true",
+	}
+	for key, value := range want {
+		if got := query.Get(key); got != value {
+			t.Errorf("query %s = %q, want %q", key, got, value)
+		}
+	}
+
+	if len(resp.Voices) != 1 || resp.Voices[0].Name != "Narrator" || resp.Voices[0].Labels["accent"] != "british" {
+		t.Errorf("Voices = %+v", resp.Voices)
+	}
+	if !resp.HasMore || resp.NextPageToken != "page-2" || resp.TotalCount != 240 {
+		t.Errorf("pagination = %v %q %d", resp.HasMore, resp.NextPageToken, resp.TotalCount)
+	}
+}
+
+func TestVoicesSearchValidation(t *testing.T) {
+	client, _ := NewClient(WithAPIKey("test-key"))
+	ctx := context.Background()
+
+	var valErr *ValidationError
+	if _, err := client.Voices().Search(ctx, &VoiceSearchOptions{PageSize: 101}); !isValidationError(err, &valErr) {
+		t.Errorf("expected ValidationError for page size, got %v", err)
+	}
+	if _, err := client.Voices().Search(ctx, &VoiceSearchOptions{Sort: "popularity"}); !isValidationError(err, &valErr) {
+		t.Errorf("expected ValidationError for sort, got %v", err)
+	}
+}
+
+func TestVoicesGetDetail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/voices/voice-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"voice_id": "voice-1",
+			"name": "Studio Clone",
+			"category": "professional",
+			"description": "Professional clone",
+			"preview_url": "https://example.com/preview.mp3",
+			"labels": {"gender": "female"},
+			"available_for_tiers": [],
+			"high_quality_base_model_ids": ["eleven_multilingual_v2"],
+			"created_at_unix": 1700000000,
+			"is_owner": true,
+			"safety_control": "CAPTCHA",
+			"fine_tuning": {
+				"is_allowed_to_fine_tune": true,
+				"language": "en",
+				"state": {"eleven_multilingual_v2": "fine_tuned", "eleven_turbo_v2_5": "fine_tuning"},
+				"progress": {"eleven_turbo_v2_5": 0.4},
+				"verification_failures": [],
+				"verification_attempts_count": 0,
+				"manual_verification_requested": false
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	voice, err := client.Voices().Get(context.Background(), "voice-1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if voice.Category != VoiceCategoryProfessional || voice.PreviewURL == "" || voice.Labels["gender"] != "female" {
+		t.Errorf("voice = %+v", voice)
+	}
+	if !voice.IsOwner || voice.CreatedAt.Unix() != 1700000000 {
+		t.Errorf("IsOwner, CreatedAt = %v, %v", voice.IsOwner, voice.CreatedAt)
+	}
+	if voice.SafetyControl != VoiceSafetyControlCaptcha {
+		t.Errorf("SafetyControl = %q", voice.SafetyControl)
+	}
+	if len(voice.HighQualityBaseModelIDs) != 1 {
+		t.Errorf("HighQualityBaseModelIDs = %v", voice.HighQualityBaseModelIDs)
+	}
+	ft := voice.FineTuning
+	if ft == nil || !ft.IsAllowed || ft.Language != "en" {
+		t.Fatalf("FineTuning = %+v", ft)
+	}
+	if !ft.IsFineTuned("eleven_multilingual_v2") || ft.IsFineTuned("eleven_turbo_v2_5") {
+		t.Errorf("State = %v", ft.State)
+	}
+	if ft.Progress["eleven_turbo_v2_5"] != 0.4 {
+		t.Errorf("Progress = %v", ft.Progress)
+	}
+}
+
+func TestVoicesPreview(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var body struct {
+			Text string `json:"text"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "audio/mpeg")
+		fmt.Fprintf(w, "%s:%s", strings.TrimPrefix(r.URL.Path, "/v1/text-to-speech/"), body.Text)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	read := func(voiceID, text string) string {
+		t.Helper()
+		audio, err := client.Voices().Preview(ctx, voiceID, text)
+		if err != nil {
+			t.Fatalf("Preview() error = %v", err)
+		}
+		data, _ := io.ReadAll(audio)
+		return string(data)
+	}
+
+	if got := read("voice-1", ""); got != "voice-1:"+DefaultPreviewText {
+		t.Errorf("Preview() = %q", got)
+	}
+	if got := read("voice-1", ""); got != "voice-1:"+DefaultPreviewText {
+		t.Errorf("cached Preview() = %q", got)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 (cached)", calls)
+	}
+
+	read("voice-1", "Custom line.")
+	read("voice-2", "")
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+
+	client.Voices().ClearPreviewCache()
+	read("voice-1", "")
+	if calls != 4 {
+		t.Errorf("calls = %d, want 4 after ClearPreviewCache", calls)
+	}
+
+	if _, err := client.Voices().Preview(ctx, "", ""); err != ErrEmptyVoiceID {
+		t.Errorf("error = %v, want %v", err, ErrEmptyVoiceID)
+	}
+}
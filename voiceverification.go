@@ -0,0 +1,243 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/agentplexus/go-elevenlabs/internal/api"
+	ht "github.com/ogen-go/ogen/http"
+)
+
+// VoiceCaptcha is the verification challenge for a professional voice
+// clone. The voice owner reads the phrase aloud and submits the recording
+// with VerifyCaptcha.
+type VoiceCaptcha struct {
+	// Text is the phrase to read, when the API returns it as text.
+	Text string
+
+	// Image is the phrase rendered as an image, when the API returns it
+	// as one. Empty otherwise.
+	Image []byte
+
+	// ContentType is the content type of the response, such as
+	// "image/png" or "application/json".
+	ContentType string
+}
+
+// VoiceVerificationAttempt is a submitted verification recording.
+type VoiceVerificationAttempt struct {
+	// Text is the phrase that was to be read.
+	Text string
+
+	// Accepted reports whether the attempt verified the voice.
+	Accepted bool
+
+	// Similarity is how closely the recording matches the voice samples.
+	Similarity float64
+
+	// LevenshteinDistance is the edit distance between the phrase and the
+	// transcription of the recording.
+	LevenshteinDistance float64
+
+	// Transcription is the transcription of the recording, if available.
+	Transcription string
+
+	// CreatedAt is when the attempt was made.
+	CreatedAt time.Time
+}
+
+// VoiceVerification is the verification status of a professional voice
+// clone.
+type VoiceVerification struct {
+	// Attempts are the verification attempts made so far.
+	Attempts []*VoiceVerificationAttempt
+
+	// AttemptsCount is the number of attempts counting toward the limit.
+	AttemptsCount int
+
+	// MaxAttempts is the number of attempts allowed before the limit
+	// resets. Zero if not reported.
+	MaxAttempts int
+
+	// AttemptsResetAt is when the attempt limit resets. Zero if not
+	// reported.
+	AttemptsResetAt time.Time
+
+	// Failures lists why verification failed.
+	Failures []string
+
+	// ManualVerificationRequested reports whether manual verification was
+	// requested.
+	ManualVerificationRequested bool
+}
+
+// IsVerified reports whether an attempt was accepted.
+func (v *VoiceVerification) IsVerified() bool {
+	for _, a := range v.Attempts {
+		if a.Accepted {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCaptcha returns the verification phrase for a professional voice
+// clone.
+func (s *VoicesService) GetCaptcha(ctx context.Context, voiceID string) (*VoiceCaptcha, error) {
+	if voiceID == "" {
+		return nil, ErrEmptyVoiceID
+	}
+
+	// The generated client discards the response body, so request it directly
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", s.client.baseURL+pvcCaptchaPath(voiceID), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading captcha: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := newAPIError(resp.StatusCode, body)
+		apiErr.setResponse(resp)
+		return nil, apiErr
+	}
+	return parseCaptcha(resp.Header.Get("Content-Type"), body)
+}
+
+// parseCaptcha decodes a captcha response, which is an image, plain text,
+// or JSON holding the text.
+func parseCaptcha(contentType string, body []byte) (*VoiceCaptcha, error) {
+	captcha := &VoiceCaptcha{ContentType: contentType}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case strings.HasPrefix(mediaType, "image/"):
+		captcha.Image = body
+	case mediaType == "application/json":
+		var text string
+		if err := json.Unmarshal(body, &text); err == nil {
+			captcha.Text = text
+			break
+		}
+		var obj map[string]any
+		if err := json.Unmarshal(body, &obj); err != nil {
+			return nil, fmt.Errorf("failed to decode captcha: %w", err)
+		}
+		for _, key := range []string{"text", "captcha", "phrase"} {
+			if text, ok := obj[key].(string); ok {
+				captcha.Text = text
+				break
+			}
+		}
+	default:
+		captcha.Text = strings.TrimSpace(string(body))
+	}
+	return captcha, nil
+}
+
+// VerifyCaptcha submits a recording of the voice owner reading the
+// captcha phrase. The result of the attempt is reported by Verification.
+func (s *VoicesService) VerifyCaptcha(ctx context.Context, voiceID string, recording io.Reader, filename string) error {
+	if voiceID == "" {
+		return ErrEmptyVoiceID
+	}
+	if recording == nil {
+		return &ValidationError{Field: "recording", Message: "cannot be nil"}
+	}
+	if filename == "" {
+		filename = "recording.mp3"
+	}
+
+	resp, err := s.client.apiClient.VerifyPvcVoiceCaptcha(ctx,
+		&api.BodyVerifyPVCVoiceCaptchaV1VoicesPvcVoiceIDCaptchaPostMultipart{
+			Recording: ht.MultipartFile{Name: filename, File: recording},
+		},
+		api.VerifyPvcVoiceCaptchaParams{VoiceID: voiceID},
+	)
+	if err != nil {
+		return apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.VerifyPVCVoiceCaptchaResponseModel:
+		return nil
+	case *api.HTTPValidationError:
+		return validationAPIError(r)
+	default:
+		return unexpectedResponse(resp)
+	}
+}
+
+// Verification returns the verification status and attempts of a
+// professional voice clone.
+func (s *VoicesService) Verification(ctx context.Context, voiceID string) (*VoiceVerification, error) {
+	if voiceID == "" {
+		return nil, ErrEmptyVoiceID
+	}
+
+	resp, err := s.client.apiClient.GetVoiceByID(ctx, api.GetVoiceByIDParams{
+		VoiceID: voiceID,
+	})
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.VoiceResponseModel:
+		if !r.FineTuning.Set {
+			return &VoiceVerification{}, nil
+		}
+		return verificationFromAPI(&r.FineTuning.Value), nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
+	default:
+		return nil, unexpectedResponse(resp)
+	}
+}
+
+func verificationFromAPI(r *api.FineTuningResponseModel) *VoiceVerification {
+	v := &VoiceVerification{
+		AttemptsCount:               r.VerificationAttemptsCount,
+		Failures:                    r.VerificationFailures,
+		ManualVerificationRequested: r.ManualVerificationRequested,
+	}
+	if r.MaxVerificationAttempts.Set && !r.MaxVerificationAttempts.Null {
+		v.MaxAttempts = r.MaxVerificationAttempts.Value
+	}
+	if r.NextMaxVerificationAttemptsResetUnixMs.Set && !r.NextMaxVerificationAttemptsResetUnixMs.Null {
+		v.AttemptsResetAt = time.UnixMilli(int64(r.NextMaxVerificationAttemptsResetUnixMs.Value))
+	}
+	if r.VerificationAttempts.Set && !r.VerificationAttempts.Null {
+		for _, a := range r.VerificationAttempts.Value {
+			attempt := &VoiceVerificationAttempt{
+				Text:                a.Text,
+				Accepted:            a.Accepted,
+				Similarity:          a.Similarity,
+				LevenshteinDistance: a.LevenshteinDistance,
+				CreatedAt:           time.Unix(int64(a.DateUnix), 0),
+			}
+			if a.Recording.Set {
+				attempt.Transcription = a.Recording.Value.Transcription
+			}
+			v.Attempts = append(v.Attempts, attempt)
+		}
+	}
+	return v
+}
+
+func pvcCaptchaPath(voiceID string) string {
+	return "/v1/voices/pvc/" + url.PathEscape(voiceID) + "/captcha"
+}
@@ -0,0 +1,152 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVoicesGetCaptcha(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantText    string
+		wantImage   bool
+	}{
+		{name: "json object", contentType: "application/json", body: `{"text": "the quick brown fox"}`, wantText: "the quick brown fox"},
+		{name: "json string", contentType: "application/json; charset=utf-8", body: `"the quick brown fox"`, wantText: "the quick brown fox"},
+		{name: "plain text", contentType: "text/plain", body: "the quick brown fox\n", wantText: "the quick brown fox"},
+		{name: "image", contentType: "image/png", body: "\x89PNG", wantImage: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/v1/voices/pvc/voice-1/captcha" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			captcha, err := client.Voices().GetCaptcha(context.Background(), "voice-1")
+			if err != nil {
+				t.Fatalf("GetCaptcha() error = %v", err)
+			}
+			if captcha.Text != tt.wantText {
+				t.Errorf("Text = %q, want %q", captcha.Text, tt.wantText)
+			}
+			if got := len(captcha.Image) > 0; got != tt.wantImage {
+				t.Errorf("Image = %q", captcha.Image)
+			}
+		})
+	}
+}
+
+func TestVoicesVerifyCaptcha(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/voices/pvc/voice-1/captcha" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		file, header, err := r.FormFile("recording")
+		if err != nil {
+			t.Fatalf("FormFile() error = %v", err)
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		if header.Filename != "captcha.wav" || string(data) != "RIFF" {
+			t.Errorf("recording = %s %q", header.Filename, data)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	err = client.Voices().VerifyCaptcha(context.Background(), "voice-1", bytes.NewReader([]byte("RIFF")), "captcha.wav")
+	if err != nil {
+		t.Fatalf("VerifyCaptcha() error = %v", err)
+	}
+}
+
+func TestVoicesVerification(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/voices/voice-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"voice_id": "voice-1",
+			"name": "Studio Clone",
+			"category": "professional",
+			"labels": {},
+			"available_for_tiers": [],
+			"high_quality_base_model_ids": [],
+			"fine_tuning": {
+				"is_allowed_to_fine_tune": true,
+				"state": {},
+				"verification_failures": ["voice mismatch"],
+				"verification_attempts_count": 2,
+				"max_verification_attempts": 5,
+				"next_max_verification_attempts_reset_unix_ms": 1700000000000,
+				"manual_verification_requested": false,
+				"verification_attempts": [
+					{"text": "the quick brown fox", "date_unix": 1700000000, "accepted": false, "similarity": 0.41, "levenshtein_distance": 3},
+					{"text": "the quick brown fox", "date_unix": 1700000100, "accepted": true, "similarity": 0.93, "levenshtein_distance": 0,
+						"recording": {"recording_id": "rec-1", "mime_type": "audio/mpeg", "size_bytes": 1024, "upload_date_unix": 1700000100, "transcription": "the quick brown fox"}}
+				]
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	v, err := client.Voices().Verification(context.Background(), "voice-1")
+	if err != nil {
+		t.Fatalf("Verification() error = %v", err)
+	}
+	if !v.IsVerified() {
+		t.Error("IsVerified() = false, want true")
+	}
+	if len(v.Attempts) != 2 || v.AttemptsCount != 2 || v.MaxAttempts != 5 || v.AttemptsResetAt.UnixMilli() != 1700000000000 {
+		t.Fatalf("verification = %+v", v)
+	}
+	if a := v.Attempts[1]; a.Similarity != 0.93 || a.Transcription != "the quick brown fox" || a.CreatedAt.Unix() != 1700000100 {
+		t.Errorf("Attempts[1] = %+v", a)
+	}
+	if len(v.Failures) != 1 {
+		t.Errorf("Failures = %v", v.Failures)
+	}
+}
+
+func TestVoicesVerificationValidation(t *testing.T) {
+	client, _ := NewClient()
+	ctx := context.Background()
+
+	if _, err := client.Voices().GetCaptcha(ctx, ""); err != ErrEmptyVoiceID {
+		t.Errorf("GetCaptcha('') error = %v, want ErrEmptyVoiceID", err)
+	}
+	if err := client.Voices().VerifyCaptcha(ctx, "voice-1", nil, ""); err == nil {
+		t.Error("VerifyCaptcha(nil) should return error")
+	}
+	if _, err := client.Voices().Verification(ctx, ""); err != ErrEmptyVoiceID {
+		t.Errorf("Verification('') error = %v, want ErrEmptyVoiceID", err)
+	}
+}
@@ -0,0 +1,371 @@
+package elevenlabs
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Post-call webhook event types.
+const (
+	WebhookEventTranscription         = "post_call_transcription"
+	WebhookEventAudio                 = "post_call_audio"
+	WebhookEventCallInitiationFailure = "call_initiation_failure"
+)
+
+// WebhookSignatureHeader is the header holding the signature of a webhook
+// request, in the form "t=<unix time>,v0=<hex HMAC-SHA256>".
+const WebhookSignatureHeader = "ElevenLabs-Signature"
+
+// DefaultWebhookTolerance is how old a webhook's signature timestamp may be
+// before the request is rejected as a replay.
+const DefaultWebhookTolerance = 30 * time.Minute
+
+// DefaultWebhookMaxBodySize is the largest webhook request body a
+// WebhookHandler reads, in bytes. It leaves room for the base64 audio of
+// WebhookEventAudio events from long calls.
+const DefaultWebhookMaxBodySize = 64 << 20
+
+// ErrInvalidWebhookSignature is returned when a webhook request is not
+// signed with the expected secret or its timestamp is out of tolerance.
+var ErrInvalidWebhookSignature = errors.New("elevenlabs: invalid webhook signature")
+
+// WebhookEvent is a webhook sent by ElevenLabs after a conversation.
+type WebhookEvent struct {
+	// Type is the event type, such as WebhookEventTranscription.
+	Type string `json:"type"`
+
+	// EventTimestamp is when the event was sent, in Unix seconds.
+	EventTimestamp int64 `json:"event_timestamp"`
+
+	// Data is the event payload. For post-call transcriptions it has the
+	// shape of a Conversation; decode it with Conversation or Decode.
+	Data json.RawMessage `json:"data"`
+
+	// AgentID and ConversationID identify the conversation the event is
+	// about, taken from Data.
+	AgentID        string `json:"-"`
+	ConversationID string `json:"-"`
+}
+
+// ID returns a key identifying the event across redeliveries: its type and
+// conversation ID, as each conversation sends at most one event of each
+// type. Events without a conversation ID are keyed by a hash of their data.
+func (e *WebhookEvent) ID() string {
+	if e.ConversationID != "" {
+		return e.Type + ":" + e.ConversationID
+	}
+	sum := sha256.Sum256(e.Data)
+	return e.Type + ":" + hex.EncodeToString(sum[:])
+}
+
+// Time returns when the event was sent.
+func (e *WebhookEvent) Time() time.Time {
+	return time.Unix(e.EventTimestamp, 0)
+}
+
+// Decode decodes the event payload into v.
+func (e *WebhookEvent) Decode(v any) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+// VerifyWebhookSignature checks that payload was signed with secret, as
+// given in the WebhookSignatureHeader header. Signatures with timestamps
+// more than tolerance away from now are rejected; a tolerance of zero
+// disables the check. Returns ErrInvalidWebhookSignature on mismatch.
+func VerifyWebhookSignature(payload []byte, header, secret string, tolerance time.Duration) error {
+	var timestamp, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v0":
+			signature = value
+		}
+	}
+	if timestamp == "" || signature == "" {
+		return ErrInvalidWebhookSignature
+	}
+
+	if tolerance > 0 {
+		secs, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return ErrInvalidWebhookSignature
+		}
+		age := time.Since(time.Unix(secs, 0))
+		if age > tolerance || age < -tolerance {
+			return ErrInvalidWebhookSignature
+		}
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	want := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(signature), []byte(want)) {
+		return ErrInvalidWebhookSignature
+	}
+	return nil
+}
+
+// ParseWebhookEvent verifies and decodes a webhook request body. An empty
+// secret skips verification, which should only be done in tests.
+func ParseWebhookEvent(payload []byte, header, secret string) (*WebhookEvent, error) {
+	if secret != "" {
+		if err := VerifyWebhookSignature(payload, header, secret, DefaultWebhookTolerance); err != nil {
+			return nil, err
+		}
+	}
+	return decodeWebhookEvent(payload)
+}
+
+func decodeWebhookEvent(payload []byte) (*WebhookEvent, error) {
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("decoding webhook event: %w", err)
+	}
+	if event.Type == "" {
+		return nil, &ValidationError{Field: "type", Message: "cannot be empty"}
+	}
+	var ids struct {
+		AgentID        string `json:"agent_id"`
+		ConversationID string `json:"conversation_id"`
+	}
+	if len(event.Data) > 0 {
+		if err := json.Unmarshal(event.Data, &ids); err != nil {
+			return nil, fmt.Errorf("decoding webhook event data: %w", err)
+		}
+	}
+	event.AgentID, event.ConversationID = ids.AgentID, ids.ConversationID
+	return &event, nil
+}
+
+// IdempotencyStore records which webhook events have been processed, so
+// events delivered more than once are handled once. Implementations must
+// be safe for concurrent use, and should be shared by all instances
+// receiving webhooks, for example by storing IDs in Redis or a database.
+type IdempotencyStore interface {
+	// Claim records that processing of the event with the given ID has
+	// started. Returns false if it was already claimed.
+	Claim(ctx context.Context, id string) (bool, error)
+
+	// Release forgets an event ID after its handler fails, so a later
+	// delivery is processed again.
+	Release(ctx context.Context, id string) error
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore that keeps event IDs in
+// memory. It only detects duplicates delivered to the same process.
+type MemoryIdempotencyStore struct {
+	ttl time.Duration
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewMemoryIdempotencyStore creates an in-memory IdempotencyStore that
+// forgets event IDs after ttl. A ttl of zero keeps them forever.
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{ttl: ttl, seen: make(map[string]time.Time)}
+}
+
+// Claim implements IdempotencyStore.
+func (m *MemoryIdempotencyStore) Claim(_ context.Context, id string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if m.ttl > 0 {
+		for key, at := range m.seen {
+			if now.Sub(at) > m.ttl {
+				delete(m.seen, key)
+			}
+		}
+	}
+	if _, ok := m.seen[id]; ok {
+		return false, nil
+	}
+	m.seen[id] = now
+	return true, nil
+}
+
+// Release implements IdempotencyStore.
+func (m *MemoryIdempotencyStore) Release(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.seen, id)
+	return nil
+}
+
+// WebhookHandlerFunc handles a webhook event. Returning an error makes the
+// WebhookHandler respond with a server error, so ElevenLabs retries.
+type WebhookHandlerFunc func(ctx context.Context, event *WebhookEvent) error
+
+// WebhookOption configures a WebhookHandler.
+type WebhookOption func(*WebhookHandler)
+
+// WithIdempotencyStore skips events already claimed in store. Without it,
+// every delivery is handled.
+func WithIdempotencyStore(store IdempotencyStore) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.store = store
+	}
+}
+
+// WithWebhookTolerance sets how old a signature timestamp may be. The
+// default is DefaultWebhookTolerance.
+func WithWebhookTolerance(d time.Duration) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.tolerance = d
+	}
+}
+
+// WithWebhookMaxBodySize sets the largest request body, in bytes, read
+// before the signature is checked. Larger requests get 413 Request Entity
+// Too Large. The default is DefaultWebhookMaxBodySize.
+func WithWebhookMaxBodySize(n int64) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.maxBodySize = n
+	}
+}
+
+// WebhookHandler is an http.Handler for post-call webhooks. It verifies
+// signatures and dispatches events to handlers registered by type. With an
+// IdempotencyStore, redelivered events are acknowledged without calling
+// the handler again, so they don't repeat side effects such as CRM
+// updates:
+//
+//	h := elevenlabs.NewWebhookHandler(secret,
+//	    elevenlabs.WithIdempotencyStore(elevenlabs.NewMemoryIdempotencyStore(24*time.Hour)),
+//	)
+//	h.On(elevenlabs.WebhookEventTranscription, func(ctx context.Context, e *elevenlabs.WebhookEvent) error {
+//	    return crm.LogCall(ctx, e.ConversationID, e.Data)
+//	})
+//	http.Handle("/webhooks/elevenlabs", h)
+type WebhookHandler struct {
+	secret      string
+	tolerance   time.Duration
+	store       IdempotencyStore
+	maxBodySize int64
+
+	mu       sync.RWMutex
+	handlers map[string]WebhookHandlerFunc
+}
+
+// NewWebhookHandler creates a handler that verifies requests with the
+// webhook's HMAC secret. An empty secret skips verification, which should
+// only be done in tests.
+func NewWebhookHandler(secret string, opts ...WebhookOption) *WebhookHandler {
+	h := &WebhookHandler{
+		secret:      secret,
+		tolerance:   DefaultWebhookTolerance,
+		maxBodySize: DefaultWebhookMaxBodySize,
+		handlers:    make(map[string]WebhookHandlerFunc),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// On registers fn for events of the given type, replacing any previous
+// handler. Events without a handler are acknowledged and ignored.
+func (h *WebhookHandler) On(eventType string, fn WebhookHandlerFunc) *WebhookHandler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[eventType] = fn
+	return h
+}
+
+// Dispatch calls the handler for event, skipping events already claimed in
+// the IdempotencyStore. If the handler fails, the event is released so it
+// can be retried. Use it to replay stored events, such as from a dead
+// letter queue, without repeating ones already handled.
+func (h *WebhookHandler) Dispatch(ctx context.Context, event *WebhookEvent) error {
+	h.mu.RLock()
+	fn := h.handlers[event.Type]
+	h.mu.RUnlock()
+	if fn == nil {
+		return nil
+	}
+
+	if h.store == nil {
+		return fn(ctx, event)
+	}
+	id := event.ID()
+	claimed, err := h.store.Claim(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+	if err := fn(ctx, event); err != nil {
+		if relErr := h.store.Release(ctx, id); relErr != nil {
+			return errors.Join(err, relErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// ServeHTTP verifies and dispatches a webhook request.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Bound the body, as it is read before the signature is checked
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "reading body", http.StatusBadRequest)
+		return
+	}
+	if h.secret != "" {
+		if err := VerifyWebhookSignature(payload, r.Header.Get(WebhookSignatureHeader), h.secret, h.tolerance); err != nil {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+	}
+	event, err := decodeWebhookEvent(payload)
+	if err != nil {
+		http.Error(w, "invalid event", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Dispatch(r.Context(), event); err != nil {
+		http.Error(w, "handler failed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Conversation decodes the payload of a WebhookEventTranscription event.
+func (e *WebhookEvent) Conversation() (*Conversation, error) {
+	if e.Type != WebhookEventTranscription {
+		return nil, &ValidationError{Field: "type", Message: fmt.Sprintf("%q events do not contain a conversation", e.Type)}
+	}
+	var conv Conversation
+	if err := e.Decode(&conv); err != nil {
+		return nil, fmt.Errorf("decoding conversation: %w", err)
+	}
+	return &conv, nil
+}
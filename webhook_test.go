@@ -0,0 +1,180 @@
+package elevenlabs
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testWebhookSecret = "wsec_test"
+
+const testWebhookPayload = `{
+	"type": "post_call_transcription",
+	"event_timestamp": 1700000000,
+	"data": {"agent_id": "agent-1", "conversation_id": "conv-1", "status": "done"}
+}`
+
+func signWebhook(payload, secret string, at time.Time) string {
+	ts := fmt.Sprint(at.Unix())
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "." + payload))
+	return "t=" + ts + ",v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	payload := []byte(testWebhookPayload)
+	now := time.Now()
+
+	if err := VerifyWebhookSignature(payload, signWebhook(testWebhookPayload, testWebhookSecret, now), testWebhookSecret, time.Minute); err != nil {
+		t.Errorf("VerifyWebhookSignature() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{"wrong secret", signWebhook(testWebhookPayload, "other", now)},
+		{"modified payload", signWebhook(testWebhookPayload+" ", testWebhookSecret, now)},
+		{"expired", signWebhook(testWebhookPayload, testWebhookSecret, now.Add(-time.Hour))},
+		{"malformed", "v0=abc"},
+		{"empty", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyWebhookSignature(payload, tt.header, testWebhookSecret, time.Minute)
+			if !errors.Is(err, ErrInvalidWebhookSignature) {
+				t.Errorf("VerifyWebhookSignature() error = %v, want ErrInvalidWebhookSignature", err)
+			}
+		})
+	}
+}
+
+func TestParseWebhookEvent(t *testing.T) {
+	event, err := ParseWebhookEvent([]byte(testWebhookPayload), signWebhook(testWebhookPayload, testWebhookSecret, time.Now()), testWebhookSecret)
+	if err != nil {
+		t.Fatalf("ParseWebhookEvent() error = %v", err)
+	}
+	if event.Type != WebhookEventTranscription || event.AgentID != "agent-1" || event.ConversationID != "conv-1" {
+		t.Errorf("event = %+v", event)
+	}
+	if event.ID() != "post_call_transcription:conv-1" {
+		t.Errorf("ID() = %q", event.ID())
+	}
+	var data struct {
+		Status string `json:"status"`
+	}
+	if err := event.Decode(&data); err != nil || data.Status != "done" {
+		t.Errorf("Decode() = %+v, %v", data, err)
+	}
+
+	conv, err := event.Conversation()
+	if err != nil || conv.Status != "done" {
+		t.Errorf("Conversation() = %+v, %v", conv, err)
+	}
+
+	noConv, err := ParseWebhookEvent([]byte(`{"type": "call_initiation_failure", "data": {"reason": "busy"}}`), "", "")
+	if err != nil {
+		t.Fatalf("ParseWebhookEvent() error = %v", err)
+	}
+	if !strings.HasPrefix(noConv.ID(), "call_initiation_failure:") || noConv.ID() == "call_initiation_failure:" {
+		t.Errorf("ID() without conversation = %q", noConv.ID())
+	}
+	var valErr *ValidationError
+	if _, err := noConv.Conversation(); !isValidationError(err, &valErr) {
+		t.Errorf("Conversation() on %s error = %v", noConv.Type, err)
+	}
+}
+
+func TestWebhookHandlerIdempotency(t *testing.T) {
+	var calls int
+	fail := true
+	h := NewWebhookHandler(testWebhookSecret, WithIdempotencyStore(NewMemoryIdempotencyStore(time.Hour)))
+	h.On(WebhookEventTranscription, func(_ context.Context, e *WebhookEvent) error {
+		calls++
+		if fail {
+			fail = false
+			return errors.New("crm unavailable")
+		}
+		return nil
+	})
+
+	deliver := func(payload string) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(payload))
+		req.Header.Set(WebhookSignatureHeader, signWebhook(payload, testWebhookSecret, time.Now()))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// A failed handler is released so the retry is processed
+	if code := deliver(testWebhookPayload); code != http.StatusInternalServerError {
+		t.Errorf("first delivery status = %d, want 500", code)
+	}
+	if code := deliver(testWebhookPayload); code != http.StatusOK {
+		t.Errorf("retry status = %d, want 200", code)
+	}
+	// A duplicate of a handled event is acknowledged without the handler
+	if code := deliver(testWebhookPayload); code != http.StatusOK {
+		t.Errorf("duplicate status = %d, want 200", code)
+	}
+	if calls != 2 {
+		t.Errorf("handler calls = %d, want 2", calls)
+	}
+
+	// Unhandled types are acknowledged
+	if code := deliver(`{"type": "post_call_audio", "data": {"conversation_id": "conv-1"}}`); code != http.StatusOK {
+		t.Errorf("unhandled type status = %d, want 200", code)
+	}
+}
+
+func TestWebhookHandlerRejectsRequests(t *testing.T) {
+	h := NewWebhookHandler(testWebhookSecret, WithWebhookMaxBodySize(1024))
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		header string
+		want   int
+	}{
+		{"bad signature", http.MethodPost, testWebhookPayload, signWebhook(testWebhookPayload, "other", time.Now()), http.StatusUnauthorized},
+		{"invalid JSON", http.MethodPost, `{`, signWebhook(`{`, testWebhookSecret, time.Now()), http.StatusBadRequest},
+		{"GET", http.MethodGet, "", "", http.StatusMethodNotAllowed},
+		{"too large", http.MethodPost, strings.Repeat(" ", 1024) + testWebhookPayload, "", http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/webhook", strings.NewReader(tt.body))
+			req.Header.Set(WebhookSignatureHeader, tt.header)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryIdempotencyStoreTTL(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryIdempotencyStore(time.Millisecond)
+
+	if ok, _ := store.Claim(ctx, "a"); !ok {
+		t.Fatal("first Claim() = false")
+	}
+	if ok, _ := store.Claim(ctx, "a"); ok {
+		t.Error("second Claim() = true")
+	}
+	time.Sleep(5 * time.Millisecond)
+	if ok, _ := store.Claim(ctx, "a"); !ok {
+		t.Error("Claim() after TTL = false")
+	}
+}
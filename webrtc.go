@@ -0,0 +1,139 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/agentplexus/go-elevenlabs/internal/api"
+)
+
+// DefaultWebRTCURL is the LiveKit server that WebRTC conversations with
+// agents connect to.
+const DefaultWebRTCURL = "wss://livekit.rtc.elevenlabs.io"
+
+// ConversationTokenOptions configures a conversation token.
+type ConversationTokenOptions struct {
+	// ParticipantName is the name of the user in the session. Defaults to
+	// the user ID of the API key.
+	ParticipantName string
+}
+
+// ConversationToken authorizes a client, such as a browser, to start a
+// WebRTC conversation with an agent without an API key. Its JSON form is
+// what the ElevenLabs client SDKs take to start a session.
+type ConversationToken struct {
+	// Token is the LiveKit access token.
+	Token string `json:"conversation_token"`
+
+	// AgentID is the agent the token is for.
+	AgentID string `json:"agent_id"`
+
+	// ServerURL is the LiveKit server to connect to.
+	ServerURL string `json:"server_url"`
+
+	// Identity is the participant identity and Room the session room,
+	// read from the token. Empty if the token does not include them.
+	Identity string `json:"identity,omitempty"`
+	Room     string `json:"room,omitempty"`
+
+	// ExpiresAt is when the token expires, read from the token. Zero if
+	// the token does not include it.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Expired reports whether the token has expired, or will within margin.
+// Tokens without an expiry never expire.
+func (t *ConversationToken) Expired(margin time.Duration) bool {
+	return !t.ExpiresAt.IsZero() && time.Now().Add(margin).After(t.ExpiresAt)
+}
+
+// ConversationToken returns a token for a WebRTC conversation with an
+// agent. Backends use it to broker sessions: fetch a token with the API
+// key, then pass it to the browser, which connects to the agent directly.
+// The browser's LiveKit client negotiates the connection (SDP and ICE)
+// with the token, so the API key never leaves the server.
+func (s *AgentsService) ConversationToken(ctx context.Context, agentID string, opts *ConversationTokenOptions) (*ConversationToken, error) {
+	if agentID == "" {
+		return nil, &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+
+	params := api.GetLivekitTokenParams{AgentID: agentID}
+	if opts != nil && opts.ParticipantName != "" {
+		params.ParticipantName = api.NewOptNilString(opts.ParticipantName)
+	}
+
+	resp, err := s.client.apiClient.GetLivekitToken(ctx, params)
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.TokenResponseModel:
+		token := &ConversationToken{Token: r.Token, AgentID: agentID, ServerURL: DefaultWebRTCURL}
+		token.readClaims()
+		return token, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
+	default:
+		return nil, unexpectedResponse(resp)
+	}
+}
+
+// readClaims fills in the identity, room, and expiry from the token, a
+// JWT. The signature is not verified; the claims are informational.
+func (t *ConversationToken) readClaims() {
+	parts := strings.Split(t.Token, ".")
+	if len(parts) != 3 {
+		return
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return
+	}
+	var claims struct {
+		Subject   string `json:"sub"`
+		ExpiresAt int64  `json:"exp"`
+		Video     struct {
+			Room string `json:"room"`
+		} `json:"video"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return
+	}
+	t.Identity = claims.Subject
+	t.Room = claims.Video.Room
+	if claims.ExpiresAt > 0 {
+		t.ExpiresAt = time.Unix(claims.ExpiresAt, 0)
+	}
+}
+
+// ConversationTokenHandler returns an http.Handler that responds with a
+// ConversationToken for agentID as JSON, for browsers to fetch before
+// starting a WebRTC session. If participant is not nil, it names the
+// participant of each request, for example from the session user. Failures
+// respond 502 with an error message.
+//
+// Put the handler behind your own authentication: anyone who can reach it
+// can start conversations billed to your account.
+func (s *AgentsService) ConversationTokenHandler(agentID string, participant func(*http.Request) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		opts := &ConversationTokenOptions{}
+		if participant != nil {
+			opts.ParticipantName = participant(r)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		token, err := s.ConversationToken(r.Context(), agentID, opts)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(token)
+	})
+}
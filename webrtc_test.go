@@ -0,0 +1,110 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testJWT returns an unsigned JWT with the given claims.
+func testJWT(claims string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + enc.EncodeToString([]byte(claims)) + ".sig"
+}
+
+func newConversationTokenServer(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/convai/conversation/token" || r.URL.Query().Get("agent_id") != "agent-1" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if got := r.URL.Query().Get("participant_name"); got != "" && got != "alice" {
+			t.Errorf("participant_name = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"token": %q}`, token)
+	}))
+}
+
+func TestAgentsConversationToken(t *testing.T) {
+	exp := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+	jwt := testJWT(fmt.Sprintf(`{"sub": "alice", "exp": %d, "video": {"room": "room-1", "roomJoin": true}}`, exp.Unix()))
+	server := newConversationTokenServer(t, jwt)
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	token, err := client.Agents().ConversationToken(context.Background(), "agent-1", &ConversationTokenOptions{ParticipantName: "alice"})
+	if err != nil {
+		t.Fatalf("ConversationToken() error = %v", err)
+	}
+	if token.Token != jwt || token.AgentID != "agent-1" || token.ServerURL != DefaultWebRTCURL {
+		t.Errorf("token = %+v", token)
+	}
+	if token.Identity != "alice" || token.Room != "room-1" || !token.ExpiresAt.Equal(exp) {
+		t.Errorf("claims = %q, %q, %v", token.Identity, token.Room, token.ExpiresAt)
+	}
+	if token.Expired(time.Minute) || !token.Expired(time.Hour) {
+		t.Errorf("Expired() wrong for expiry in 10 minutes")
+	}
+}
+
+func TestAgentsConversationTokenOpaque(t *testing.T) {
+	server := newConversationTokenServer(t, "opaque-token")
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	token, err := client.Agents().ConversationToken(context.Background(), "agent-1", nil)
+	if err != nil {
+		t.Fatalf("ConversationToken() error = %v", err)
+	}
+	if token.Token != "opaque-token" || token.Identity != "" || !token.ExpiresAt.IsZero() || token.Expired(time.Hour) {
+		t.Errorf("token = %+v", token)
+	}
+}
+
+func TestAgentsConversationTokenValidation(t *testing.T) {
+	client, _ := NewClient(WithAPIKey("test-key"))
+	var valErr *ValidationError
+	_, err := client.Agents().ConversationToken(context.Background(), "", nil)
+	if !isValidationError(err, &valErr) {
+		t.Errorf("error = %v, want ValidationError", err)
+	}
+}
+
+func TestAgentsConversationTokenHandler(t *testing.T) {
+	server := newConversationTokenServer(t, testJWT(`{"sub": "alice"}`))
+	defer server.Close()
+
+	client, _ := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	handler := client.Agents().ConversationTokenHandler("agent-1", func(r *http.Request) string {
+		return r.Header.Get("X-User")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/session", nil)
+	req.Header.Set("X-User", "alice")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Header().Get("Cache-Control") != "no-store" {
+		t.Fatalf("status = %d, headers = %v", rec.Code, rec.Header())
+	}
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if body["conversation_token"] == "" || body["agent_id"] != "agent-1" || body["identity"] != "alice" {
+		t.Errorf("body = %v", body)
+	}
+
+	// API failures are reported as bad gateway
+	server.Close()
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/session", nil))
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("status = %d, want 502", rec.Code)
+	}
+}
@@ -0,0 +1,643 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocketAgentService handles real-time conversations with
+// conversational AI agents via WebSocket.
+type WebSocketAgentService struct {
+	client *Client
+
+	// Open conversations by conversation ID, for live control
+	mu     sync.Mutex
+	active map[string]*WebSocketAgentConnection
+}
+
+// Agent conversation event types.
+const (
+	AgentEventConversationMetadata    = "conversation_initiation_metadata"
+	AgentEventUserTranscript          = "user_transcript"
+	AgentEventAgentResponse           = "agent_response"
+	AgentEventAgentResponseCorrection = "agent_response_correction"
+	AgentEventAudio                   = "audio"
+	AgentEventInterruption            = "interruption"
+	AgentEventClientToolCall          = "client_tool_call"
+)
+
+// DefaultClientToolTimeout is how long a registered client tool may run
+// before an error result is reported to the agent.
+const DefaultClientToolTimeout = 30 * time.Second
+
+// ClientToolFunc handles a client tool call from an agent. The parameters
+// are those defined for the tool in the agent configuration. The result is
+// sent to the agent as is if it is a string, and as JSON otherwise. A
+// returned error is reported to the agent as a failed tool call.
+type ClientToolFunc func(ctx context.Context, params map[string]any) (any, error)
+
+// AgentEvent is an event received from an agent conversation.
+// Fields are populated according to Type; other event types are delivered
+// with only Type and Raw set.
+type AgentEvent struct {
+	// Type is the event type (see the AgentEvent constants).
+	Type string
+
+	// ConversationID is set for conversation_initiation_metadata events.
+	ConversationID string
+
+	// AgentOutputAudioFormat is the format of agent audio, set for
+	// conversation_initiation_metadata events (e.g., "pcm_16000").
+	AgentOutputAudioFormat string
+
+	// UserInputAudioFormat is the expected format of user audio, set for
+	// conversation_initiation_metadata events.
+	UserInputAudioFormat string
+
+	// Text is the transcript for user_transcript events and the response
+	// for agent_response events. For agent_response_correction events it
+	// is the corrected response.
+	Text string
+
+	// Audio is the decoded agent audio for audio events.
+	Audio []byte
+
+	// EventID identifies audio and interruption events.
+	EventID int
+
+	// ToolName, ToolCallID, and ToolParameters are set for client_tool_call
+	// events. Calls to registered client tools are answered automatically and
+	// not delivered as events; answer others with SendClientToolResult.
+	ToolName       string
+	ToolCallID     string
+	ToolParameters map[string]any
+
+	// Raw is the original JSON message.
+	Raw json.RawMessage
+}
+
+// agentWSResponse is a WebSocket message from the agent.
+type agentWSResponse struct {
+	Type string `json:"type"`
+
+	ConversationInitiationMetadataEvent *struct {
+		ConversationID         string `json:"conversation_id"`
+		AgentOutputAudioFormat string `json:"agent_output_audio_format"`
+		UserInputAudioFormat   string `json:"user_input_audio_format"`
+	} `json:"conversation_initiation_metadata_event,omitempty"`
+
+	UserTranscriptionEvent *struct {
+		UserTranscript string `json:"user_transcript"`
+	} `json:"user_transcription_event,omitempty"`
+
+	AgentResponseEvent *struct {
+		AgentResponse string `json:"agent_response"`
+	} `json:"agent_response_event,omitempty"`
+
+	AgentResponseCorrectionEvent *struct {
+		CorrectedAgentResponse string `json:"corrected_agent_response"`
+	} `json:"agent_response_correction_event,omitempty"`
+
+	AudioEvent *struct {
+		AudioBase64 string `json:"audio_base_64"`
+		EventID     int    `json:"event_id"`
+	} `json:"audio_event,omitempty"`
+
+	InterruptionEvent *struct {
+		EventID int `json:"event_id"`
+	} `json:"interruption_event,omitempty"`
+
+	PingEvent *struct {
+		EventID int `json:"event_id"`
+	} `json:"ping_event,omitempty"`
+
+	ClientToolCall *struct {
+		ToolName   string         `json:"tool_name"`
+		ToolCallID string         `json:"tool_call_id"`
+		Parameters map[string]any `json:"parameters"`
+	} `json:"client_tool_call,omitempty"`
+}
+
+// agentWSTextMessage is a user_message or contextual_update message.
+type agentWSTextMessage struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// agentWSAudioMessage is a user audio chunk message.
+type agentWSAudioMessage struct {
+	UserAudioChunk string `json:"user_audio_chunk"` // Base64 encoded audio
+}
+
+// agentWSToolResultMessage answers a client tool call.
+type agentWSToolResultMessage struct {
+	Type       string `json:"type"`
+	ToolCallID string `json:"tool_call_id"`
+	Result     string `json:"result"`
+	IsError    bool   `json:"is_error"`
+}
+
+// agentWSPongMessage answers a server ping.
+type agentWSPongMessage struct {
+	Type    string `json:"type"`
+	EventID int    `json:"event_id"`
+}
+
+// WebSocketAgentConnection represents an active agent conversation.
+//
+// The connection is closed when Close is called, when the context passed to
+// Connect is canceled, or when the server ends the conversation. Done is
+// closed once the connection is closed, and Err reports the reason.
+type WebSocketAgentConnection struct {
+	service        *WebSocketAgentService
+	conn           *websocket.Conn
+	agentID        string
+	mu             sync.Mutex
+	closed         bool
+	closeErr       error
+	conversationID string
+
+	// Client tools
+	ctx         context.Context
+	cancel      context.CancelFunc
+	tools       map[string]ClientToolFunc
+	toolTimeout time.Duration
+
+	// Channels for async operation
+	events    chan *AgentEvent
+	errChan   chan error
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Connect starts a conversation with an agent. The initiation data is
+// optional and sets dynamic variables and overrides for this conversation.
+func (s *WebSocketAgentService) Connect(ctx context.Context, agentID string, data *ConversationInitiationData) (*WebSocketAgentConnection, error) {
+	if agentID == "" {
+		return nil, &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+	if data == nil {
+		data = &ConversationInitiationData{}
+	}
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Build WebSocket URL
+	wsURL, err := s.buildWebSocketURL(agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.client.dryRun {
+		return nil, ErrDryRun
+	}
+
+	conn, err := s.client.dialWebSocket(ctx, wsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	toolCtx, cancel := context.WithCancel(ctx)
+	wsc := &WebSocketAgentConnection{
+		service:     s,
+		conn:        conn,
+		agentID:     agentID,
+		ctx:         toolCtx,
+		cancel:      cancel,
+		tools:       make(map[string]ClientToolFunc),
+		toolTimeout: DefaultClientToolTimeout,
+		events:      make(chan *AgentEvent, 100),
+		errChan:     make(chan error, 1),
+		done:        make(chan struct{}),
+	}
+
+	// Send conversation initiation data
+	if err := wsc.sendJSON(data.wire("conversation_initiation_client_data")); err != nil {
+		cancel()
+		conn.Close()
+		return nil, err
+	}
+
+	// Start reading events
+	go wsc.readLoop()
+
+	// Close the connection when the context is canceled
+	go wsc.watchContext(ctx)
+
+	return wsc, nil
+}
+
+func (s *WebSocketAgentService) buildWebSocketURL(agentID string) (string, error) {
+	baseURL := s.client.baseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	// Convert HTTP URL to WebSocket URL
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme == "https" {
+		u.Scheme = "wss"
+	} else {
+		u.Scheme = "ws"
+	}
+
+	u.Path = "/v1/convai/conversation"
+
+	q := u.Query()
+	q.Set("agent_id", agentID)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+func (wsc *WebSocketAgentConnection) sendJSON(msg any) error {
+	wsc.mu.Lock()
+	defer wsc.mu.Unlock()
+
+	if wsc.closed {
+		return ErrWebSocketClosed
+	}
+
+	return wsc.conn.WriteJSON(msg)
+}
+
+func (wsc *WebSocketAgentConnection) watchContext(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		_ = wsc.shutdown(ctx.Err())
+	case <-wsc.done:
+	}
+}
+
+func (wsc *WebSocketAgentConnection) readLoop() {
+	// Only the read loop sends on the events channel, so it closes it
+	defer close(wsc.events)
+
+	for {
+		_, message, err := wsc.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				_ = wsc.shutdown(ErrWebSocketClosed)
+				return
+			}
+			if wsc.isClosed() {
+				// Read failed because we closed the connection
+				return
+			}
+			wsc.sendError(err)
+			_ = wsc.shutdown(err)
+			return
+		}
+
+		var resp agentWSResponse
+		if err := json.Unmarshal(message, &resp); err != nil {
+			wsc.sendError(fmt.Errorf("failed to parse response: %w", err))
+			continue
+		}
+
+		// Keep the connection alive
+		if resp.Type == "ping" {
+			if resp.PingEvent != nil {
+				_ = wsc.sendJSON(agentWSPongMessage{Type: "pong", EventID: resp.PingEvent.EventID})
+			}
+			continue
+		}
+
+		// Answer calls to registered client tools
+		if call := resp.ClientToolCall; call != nil {
+			if fn := wsc.clientTool(call.ToolName); fn != nil {
+				go wsc.runClientTool(fn, call.ToolName, call.ToolCallID, call.Parameters)
+				continue
+			}
+		}
+
+		event, err := wsc.decodeEvent(&resp, message)
+		if err != nil {
+			wsc.sendError(err)
+			continue
+		}
+
+		select {
+		case wsc.events <- event:
+		case <-wsc.done:
+			return
+		}
+	}
+}
+
+// decodeEvent converts a server message into an AgentEvent.
+func (wsc *WebSocketAgentConnection) decodeEvent(resp *agentWSResponse, raw []byte) (*AgentEvent, error) {
+	event := &AgentEvent{
+		Type: resp.Type,
+		Raw:  json.RawMessage(raw),
+	}
+
+	switch {
+	case resp.ConversationInitiationMetadataEvent != nil:
+		md := resp.ConversationInitiationMetadataEvent
+		event.ConversationID = md.ConversationID
+		event.AgentOutputAudioFormat = md.AgentOutputAudioFormat
+		event.UserInputAudioFormat = md.UserInputAudioFormat
+		wsc.mu.Lock()
+		wsc.conversationID = md.ConversationID
+		wsc.mu.Unlock()
+		wsc.service.track(md.ConversationID, wsc)
+	case resp.UserTranscriptionEvent != nil:
+		event.Text = resp.UserTranscriptionEvent.UserTranscript
+	case resp.AgentResponseEvent != nil:
+		event.Text = resp.AgentResponseEvent.AgentResponse
+	case resp.AgentResponseCorrectionEvent != nil:
+		event.Text = resp.AgentResponseCorrectionEvent.CorrectedAgentResponse
+	case resp.AudioEvent != nil:
+		audio, err := base64.StdEncoding.DecodeString(resp.AudioEvent.AudioBase64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode audio: %w", err)
+		}
+		event.Audio = audio
+		event.EventID = resp.AudioEvent.EventID
+	case resp.InterruptionEvent != nil:
+		event.EventID = resp.InterruptionEvent.EventID
+	case resp.ClientToolCall != nil:
+		event.ToolName = resp.ClientToolCall.ToolName
+		event.ToolCallID = resp.ClientToolCall.ToolCallID
+		event.ToolParameters = resp.ClientToolCall.Parameters
+	}
+
+	return event, nil
+}
+
+func (wsc *WebSocketAgentConnection) sendError(err error) {
+	select {
+	case wsc.errChan <- err:
+	default:
+	}
+}
+
+// shutdown closes the connection once, recording the reason.
+// It sends a close frame before closing the underlying connection.
+func (wsc *WebSocketAgentConnection) shutdown(reason error) error {
+	var err error
+	wsc.closeOnce.Do(func() {
+		wsc.mu.Lock()
+		wsc.closed = true
+		wsc.closeErr = reason
+		conversationID := wsc.conversationID
+		wsc.mu.Unlock()
+		wsc.cancel()
+		wsc.service.untrack(conversationID, wsc)
+
+		closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+		_ = wsc.conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(wsCloseFrameTimeout))
+		err = wsc.conn.Close()
+		close(wsc.done)
+	})
+	return err
+}
+
+func (wsc *WebSocketAgentConnection) isClosed() bool {
+	wsc.mu.Lock()
+	defer wsc.mu.Unlock()
+	return wsc.closed
+}
+
+// RegisterClientTool registers a handler for a client tool defined in the
+// agent configuration. Calls to the tool are run in their own goroutine and
+// answered automatically with the handler's result, or with an error result
+// if the handler fails, panics, or exceeds the client tool timeout.
+// Registering a name again replaces its handler. Register tools before
+// sending user input so early calls are not delivered as events instead.
+func (wsc *WebSocketAgentConnection) RegisterClientTool(name string, fn ClientToolFunc) {
+	wsc.mu.Lock()
+	defer wsc.mu.Unlock()
+	wsc.tools[name] = fn
+}
+
+// SetClientToolTimeout sets how long registered client tools may run
+// (default: DefaultClientToolTimeout).
+func (wsc *WebSocketAgentConnection) SetClientToolTimeout(timeout time.Duration) {
+	wsc.mu.Lock()
+	defer wsc.mu.Unlock()
+	wsc.toolTimeout = timeout
+}
+
+// SendClientToolResult answers a client tool call that was delivered as an
+// event. The result is sent as is if it is a string, and as JSON otherwise.
+func (wsc *WebSocketAgentConnection) SendClientToolResult(toolCallID string, result any, isError bool) error {
+	if toolCallID == "" {
+		return &ValidationError{Field: "tool_call_id", Message: "cannot be empty"}
+	}
+	text, err := clientToolResultText(result)
+	if err != nil {
+		return err
+	}
+	return wsc.sendJSON(agentWSToolResultMessage{
+		Type:       "client_tool_result",
+		ToolCallID: toolCallID,
+		Result:     text,
+		IsError:    isError,
+	})
+}
+
+func (wsc *WebSocketAgentConnection) clientTool(name string) ClientToolFunc {
+	wsc.mu.Lock()
+	defer wsc.mu.Unlock()
+	return wsc.tools[name]
+}
+
+// runClientTool runs a registered client tool and sends its result.
+func (wsc *WebSocketAgentConnection) runClientTool(fn ClientToolFunc, name, toolCallID string, params map[string]any) {
+	wsc.mu.Lock()
+	timeout := wsc.toolTimeout
+	wsc.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(wsc.ctx, timeout)
+	defer cancel()
+
+	type toolResult struct {
+		value any
+		err   error
+	}
+	resultChan := make(chan toolResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				resultChan <- toolResult{err: fmt.Errorf("client tool %s panicked: %v", name, r)}
+			}
+		}()
+		value, err := fn(ctx, params)
+		resultChan <- toolResult{value: value, err: err}
+	}()
+
+	var res toolResult
+	select {
+	case res = <-resultChan:
+	case <-ctx.Done():
+		if wsc.isClosed() {
+			return
+		}
+		res.err = fmt.Errorf("client tool %s timed out after %s", name, timeout)
+	}
+
+	if res.err != nil {
+		wsc.sendError(res.err)
+		_ = wsc.SendClientToolResult(toolCallID, res.err.Error(), true)
+		return
+	}
+	if err := wsc.SendClientToolResult(toolCallID, res.value, false); err != nil {
+		wsc.sendError(fmt.Errorf("client tool %s: %w", name, err))
+		_ = wsc.SendClientToolResult(toolCallID, err.Error(), true)
+	}
+}
+
+// clientToolResultText converts a client tool result to the text sent to the agent.
+func clientToolResultText(result any) (string, error) {
+	switch v := result.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal tool result: %w", err)
+		}
+		return string(data), nil
+	}
+}
+
+// ConversationID returns the conversation ID assigned by the server,
+// or an empty string if the conversation metadata has not been received.
+func (wsc *WebSocketAgentConnection) ConversationID() string {
+	wsc.mu.Lock()
+	defer wsc.mu.Unlock()
+	return wsc.conversationID
+}
+
+// SendAudio sends a chunk of user audio in the agent's user input format.
+func (wsc *WebSocketAgentConnection) SendAudio(audio []byte) error {
+	if len(audio) == 0 {
+		return nil
+	}
+	return wsc.sendJSON(agentWSAudioMessage{
+		UserAudioChunk: base64.StdEncoding.EncodeToString(audio),
+	})
+}
+
+// SendUserMessage sends a text message from the user.
+func (wsc *WebSocketAgentConnection) SendUserMessage(text string) error {
+	if text == "" {
+		return ErrEmptyText
+	}
+	return wsc.sendJSON(agentWSTextMessage{Type: "user_message", Text: text})
+}
+
+// SendContextualUpdate sends background information to the agent without
+// prompting a response.
+func (wsc *WebSocketAgentConnection) SendContextualUpdate(text string) error {
+	if text == "" {
+		return ErrEmptyText
+	}
+	return wsc.sendJSON(agentWSTextMessage{Type: "contextual_update", Text: text})
+}
+
+// Events returns a channel that receives conversation events.
+// The channel is closed when the connection is closed.
+func (wsc *WebSocketAgentConnection) Events() <-chan *AgentEvent {
+	return wsc.events
+}
+
+// Errors returns a channel that receives errors from the connection.
+func (wsc *WebSocketAgentConnection) Errors() <-chan error {
+	return wsc.errChan
+}
+
+// Done returns a channel that is closed when the connection is closed.
+func (wsc *WebSocketAgentConnection) Done() <-chan struct{} {
+	return wsc.done
+}
+
+// Err returns the reason the connection was closed, or nil if it is open.
+func (wsc *WebSocketAgentConnection) Err() error {
+	wsc.mu.Lock()
+	defer wsc.mu.Unlock()
+	return wsc.closeErr
+}
+
+// Close ends the conversation and closes the connection.
+func (wsc *WebSocketAgentConnection) Close() error {
+	return wsc.shutdown(ErrWebSocketClosed)
+}
+
+func (s *WebSocketAgentService) track(conversationID string, wsc *WebSocketAgentConnection) {
+	if conversationID == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active == nil {
+		s.active = make(map[string]*WebSocketAgentConnection)
+	}
+	s.active[conversationID] = wsc
+}
+
+func (s *WebSocketAgentService) untrack(conversationID string, wsc *WebSocketAgentConnection) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active[conversationID] == wsc {
+		delete(s.active, conversationID)
+	}
+}
+
+// Conversation returns the open connection for a conversation started with
+// this client, so supervisors can steer live conversations by ID.
+// ElevenLabs has no API to control conversations held by other processes.
+func (s *WebSocketAgentService) Conversation(conversationID string) (*WebSocketAgentConnection, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wsc, ok := s.active[conversationID]
+	return wsc, ok
+}
+
+// ActiveConversations returns the IDs of open conversations started with
+// this client, in no particular order.
+func (s *WebSocketAgentService) ActiveConversations() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.active))
+	for id := range s.active {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// SendContextualUpdate sends background information to the agent of an
+// open conversation without prompting a response, for example to tell it
+// that a supervisor is listening.
+func (s *WebSocketAgentService) SendContextualUpdate(conversationID, text string) error {
+	wsc, ok := s.Conversation(conversationID)
+	if !ok {
+		return ErrConversationNotFound
+	}
+	return wsc.SendContextualUpdate(text)
+}
+
+// EndConversation ends an open conversation and closes its connection.
+func (s *WebSocketAgentService) EndConversation(conversationID string) error {
+	wsc, ok := s.Conversation(conversationID)
+	if !ok {
+		return ErrConversationNotFound
+	}
+	return wsc.Close()
+}
@@ -0,0 +1,306 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestWebSocketAgentConversation(t *testing.T) {
+	initMsg := make(chan map[string]any, 1)
+	pong := make(chan map[string]any, 1)
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("agent_id"); got != "agent-1" {
+			t.Errorf("agent_id = %q, want %q", got, "agent-1")
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var msg map[string]any
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		initMsg <- msg
+
+		_ = conn.WriteJSON(map[string]any{
+			"type": "conversation_initiation_metadata",
+			"conversation_initiation_metadata_event": map[string]any{
+				"conversation_id":           "conv-1",
+				"agent_output_audio_format": "pcm_16000",
+			},
+		})
+		_ = conn.WriteJSON(map[string]any{
+			"type":       "ping",
+			"ping_event": map[string]any{"event_id": 7},
+		})
+		var reply map[string]any
+		if err := conn.ReadJSON(&reply); err != nil {
+			return
+		}
+		pong <- reply
+
+		_ = conn.WriteJSON(map[string]any{
+			"type":                 "agent_response",
+			"agent_response_event": map[string]any{"agent_response": "Hello Ada"},
+		})
+		_ = conn.WriteJSON(map[string]any{
+			"type":        "audio",
+			"audio_event": map[string]any{"audio_base_64": "AAEC", "event_id": 1},
+		})
+		closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+		_ = conn.WriteMessage(websocket.CloseMessage, closeMsg)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	conn, err := client.WebSocketAgent().Connect(context.Background(), "agent-1", &ConversationInitiationData{
+		DynamicVariables: map[string]any{"name": "Ada"},
+	})
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer conn.Close()
+
+	var events []*AgentEvent
+	for event := range conn.Events() {
+		events = append(events, event)
+	}
+
+	msg := <-initMsg
+	if msg["type"] != "conversation_initiation_client_data" {
+		t.Errorf("init type = %v", msg["type"])
+	}
+	if vars, _ := msg["dynamic_variables"].(map[string]any); vars["name"] != "Ada" {
+		t.Errorf("dynamic_variables = %v", msg["dynamic_variables"])
+	}
+	if got := <-pong; got["type"] != "pong" || got["event_id"] != float64(7) {
+		t.Errorf("pong = %v", got)
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3", len(events))
+	}
+	if events[0].Type != AgentEventConversationMetadata || events[0].ConversationID != "conv-1" {
+		t.Errorf("events[0] = %+v", events[0])
+	}
+	if conn.ConversationID() != "conv-1" {
+		t.Errorf("ConversationID() = %q, want %q", conn.ConversationID(), "conv-1")
+	}
+	if events[1].Type != AgentEventAgentResponse || events[1].Text != "Hello Ada" {
+		t.Errorf("events[1] = %+v", events[1])
+	}
+	if events[2].Type != AgentEventAudio || len(events[2].Audio) != 3 || events[2].EventID != 1 {
+		t.Errorf("events[2] = %+v", events[2])
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(events[2].Raw, &raw); err != nil || raw["type"] != "audio" {
+		t.Errorf("Raw = %s", events[2].Raw)
+	}
+
+	select {
+	case <-conn.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Done() not closed after server close")
+	}
+	if !errors.Is(conn.Err(), ErrWebSocketClosed) {
+		t.Errorf("Err() = %v, want ErrWebSocketClosed", conn.Err())
+	}
+}
+
+func TestWebSocketAgentConnectValidation(t *testing.T) {
+	client, err := NewClient(WithAPIKey("test-key"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var valErr *ValidationError
+	_, err = client.WebSocketAgent().Connect(context.Background(), "", nil)
+	if !isValidationError(err, &valErr) || valErr.Field != "agent_id" {
+		t.Errorf("expected agent_id ValidationError, got %v", err)
+	}
+}
+
+func TestWebSocketAgentClientTools(t *testing.T) {
+	results := make(chan map[string]any, 3)
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var init map[string]any
+		if err := conn.ReadJSON(&init); err != nil {
+			return
+		}
+		// Wait for the client to register its tools
+		var ready map[string]any
+		if err := conn.ReadJSON(&ready); err != nil {
+			return
+		}
+
+		for _, call := range []map[string]any{
+			{"tool_name": "lookup", "tool_call_id": "call-1", "parameters": map[string]any{"id": "42"}},
+			{"tool_name": "fail", "tool_call_id": "call-2"},
+			{"tool_name": "slow", "tool_call_id": "call-3"},
+			{"tool_name": "unknown", "tool_call_id": "call-4"},
+		} {
+			_ = conn.WriteJSON(map[string]any{"type": "client_tool_call", "client_tool_call": call})
+		}
+		for range 3 {
+			var result map[string]any
+			if err := conn.ReadJSON(&result); err != nil {
+				return
+			}
+			results <- result
+		}
+		closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+		_ = conn.WriteMessage(websocket.CloseMessage, closeMsg)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	conn, err := client.WebSocketAgent().Connect(context.Background(), "agent-1", nil)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer conn.Close()
+
+	conn.SetClientToolTimeout(50 * time.Millisecond)
+	conn.RegisterClientTool("lookup", func(ctx context.Context, params map[string]any) (any, error) {
+		return map[string]any{"id": params["id"], "status": "shipped"}, nil
+	})
+	conn.RegisterClientTool("fail", func(ctx context.Context, params map[string]any) (any, error) {
+		return nil, errors.New("database unavailable")
+	})
+	conn.RegisterClientTool("slow", func(ctx context.Context, params map[string]any) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err := conn.SendContextualUpdate("ready"); err != nil {
+		t.Fatalf("SendContextualUpdate() error = %v", err)
+	}
+
+	// Unregistered tools are delivered as events
+	var unhandled *AgentEvent
+	for event := range conn.Events() {
+		if event.Type == AgentEventClientToolCall {
+			unhandled = event
+		}
+	}
+	if unhandled == nil || unhandled.ToolName != "unknown" || unhandled.ToolCallID != "call-4" {
+		t.Errorf("unhandled tool event = %+v", unhandled)
+	}
+
+	got := make(map[string]map[string]any)
+	for range 3 {
+		select {
+		case r := <-results:
+			got[r["tool_call_id"].(string)] = r
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for tool results")
+		}
+	}
+	if r := got["call-1"]; r["type"] != "client_tool_result" || r["result"] != `{"id":"42","status":"shipped"}` || r["is_error"] != false {
+		t.Errorf("lookup result = %v", r)
+	}
+	if r := got["call-2"]; r["result"] != "database unavailable" || r["is_error"] != true {
+		t.Errorf("fail result = %v", r)
+	}
+	if r := got["call-3"]; r["is_error"] != true {
+		t.Errorf("slow result = %v", r)
+	}
+}
+
+func TestWebSocketAgentLiveControl(t *testing.T) {
+	updates := make(chan map[string]any, 1)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var msg map[string]any
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		_ = conn.WriteJSON(map[string]any{
+			"type":                                   "conversation_initiation_metadata",
+			"conversation_initiation_metadata_event": map[string]any{"conversation_id": "conv-live"},
+		})
+		for {
+			var update map[string]any
+			if err := conn.ReadJSON(&update); err != nil {
+				return
+			}
+			updates <- update
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	agents := client.WebSocketAgent()
+
+	conn, err := agents.Connect(context.Background(), "agent-1", nil)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer conn.Close()
+
+	if event := <-conn.Events(); event.ConversationID != "conv-live" {
+		t.Fatalf("first event = %+v", event)
+	}
+	if ids := agents.ActiveConversations(); len(ids) != 1 || ids[0] != "conv-live" {
+		t.Errorf("ActiveConversations() = %v", ids)
+	}
+	if got, ok := agents.Conversation("conv-live"); !ok || got != conn {
+		t.Error("Conversation() did not return the open connection")
+	}
+
+	if err := agents.SendContextualUpdate("conv-live", "A supervisor joined."); err != nil {
+		t.Fatalf("SendContextualUpdate() error = %v", err)
+	}
+	if got := <-updates; got["type"] != "contextual_update" || got["text"] != "A supervisor joined." {
+		t.Errorf("update = %v", got)
+	}
+
+	if err := agents.EndConversation("conv-live"); err != nil {
+		t.Fatalf("EndConversation() error = %v", err)
+	}
+	select {
+	case <-conn.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Done() not closed after EndConversation")
+	}
+	if _, ok := agents.Conversation("conv-live"); ok {
+		t.Error("ended conversation is still tracked")
+	}
+	if err := agents.SendContextualUpdate("conv-live", "hello"); !errors.Is(err, ErrConversationNotFound) {
+		t.Errorf("error = %v, want %v", err, ErrConversationNotFound)
+	}
+}
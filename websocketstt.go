@@ -5,7 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"net/http"
+	"io"
 	"net/url"
 	"sync"
 
@@ -43,8 +43,26 @@ type WebSocketSTTOptions struct {
 
 	// MaxAlternatives is the maximum number of transcription alternatives.
 	MaxAlternatives int
+
+	// EnableDiarization enables speaker diarization. Words and segments
+	// in transcripts are labeled with a SpeakerID.
+	EnableDiarization bool
+
+	// MaxSpeakers is the maximum number of speakers to distinguish when
+	// diarization is enabled (1-32). Zero lets the server decide.
+	MaxSpeakers int
+
+	// AudioSink receives a copy of the audio passed to SendAudio, before
+	// it is sent, so recordings and transcripts share one capture path
+	// (see RotatingFileSink). If a write fails, SendAudio returns the
+	// error without sending the chunk, so nothing is transcribed that was
+	// not recorded. The connection does not close the sink.
+	AudioSink io.Writer
 }
 
+// MaxDiarizationSpeakers is the largest supported WebSocketSTTOptions.MaxSpeakers.
+const MaxDiarizationSpeakers = 32
+
 // DefaultWebSocketSTTOptions returns default options for real-time STT.
 func DefaultWebSocketSTTOptions() *WebSocketSTTOptions {
 	return &WebSocketSTTOptions{
@@ -92,6 +110,13 @@ type STTTranscript struct {
 
 	// EndTime is the end time in seconds.
 	EndTime float64 `json:"end_time,omitempty"`
+
+	// SpeakerID is the speaker of this transcript when diarization is
+	// enabled. If several speakers are present, it is the first one.
+	SpeakerID string `json:"speaker_id,omitempty"`
+
+	// Segments groups consecutive words by speaker when diarization is enabled.
+	Segments []STTSpeakerSegment `json:"segments,omitempty"`
 }
 
 // STTWord represents a single word with timing.
@@ -100,6 +125,24 @@ type STTWord struct {
 	Start      float64 `json:"start"`
 	End        float64 `json:"end"`
 	Confidence float64 `json:"confidence,omitempty"`
+
+	// SpeakerID is the speaker of this word when diarization is enabled.
+	SpeakerID string `json:"speaker_id,omitempty"`
+}
+
+// STTSpeakerSegment is a run of consecutive words from one speaker.
+type STTSpeakerSegment struct {
+	// SpeakerID is the speaker of the segment.
+	SpeakerID string `json:"speaker_id"`
+
+	// Text is the segment text.
+	Text string `json:"text"`
+
+	// StartTime is the start time in seconds.
+	StartTime float64 `json:"start_time"`
+
+	// EndTime is the end time in seconds.
+	EndTime float64 `json:"end_time"`
 }
 
 // sttWSInitMessage is the initial configuration message.
@@ -111,6 +154,8 @@ type sttWSInitMessage struct {
 	EnablePartials       bool   `json:"enable_partials,omitempty"`
 	EnableWordTimestamps bool   `json:"enable_word_timestamps,omitempty"`
 	MaxAlternatives      int    `json:"max_alternatives,omitempty"`
+	Diarize              bool   `json:"diarize,omitempty"`
+	MaxSpeakers          int    `json:"max_speakers,omitempty"`
 }
 
 // sttWSAudioMessage is an audio data message.
@@ -134,6 +179,7 @@ type sttWSResponse struct {
 	LanguageCode string    `json:"language_code,omitempty"`
 	StartTime    float64   `json:"start_time,omitempty"`
 	EndTime      float64   `json:"end_time,omitempty"`
+	SpeakerID    string    `json:"speaker_id,omitempty"`
 	Error        string    `json:"error,omitempty"`
 	Message      string    `json:"message,omitempty"`
 }
@@ -144,25 +190,23 @@ func (s *WebSocketSTTService) Connect(ctx context.Context, opts *WebSocketSTTOpt
 		opts = DefaultWebSocketSTTOptions()
 	}
 
+	if opts.MaxSpeakers < 0 || opts.MaxSpeakers > MaxDiarizationSpeakers {
+		return nil, &ValidationError{Field: "max_speakers", Message: fmt.Sprintf("must be between 1 and %d", MaxDiarizationSpeakers)}
+	}
+
 	// Build WebSocket URL
 	wsURL, err := s.buildWebSocketURL(opts)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create dialer with context
-	dialer := websocket.Dialer{
-		HandshakeTimeout: 0, // Use context timeout
+	if s.client.dryRun {
+		return nil, ErrDryRun
 	}
 
-	// Add headers
-	headers := http.Header{}
-	headers.Set("xi-api-key", s.client.apiKey)
-
-	// Connect
-	conn, _, err := dialer.DialContext(ctx, wsURL, headers)
+	conn, err := s.client.dialWebSocket(ctx, wsURL)
 	if err != nil {
-		return nil, fmt.Errorf("websocket dial failed: %w", err)
+		return nil, err
 	}
 
 	wsc := &WebSocketSTTConnection{
@@ -232,6 +276,11 @@ func (wsc *WebSocketSTTConnection) sendInit() error {
 		msg.MaxAlternatives = wsc.options.MaxAlternatives
 	}
 
+	if wsc.options.EnableDiarization {
+		msg.Diarize = true
+		msg.MaxSpeakers = wsc.options.MaxSpeakers
+	}
+
 	return wsc.sendJSON(msg)
 }
 
@@ -299,6 +348,11 @@ func (wsc *WebSocketSTTConnection) readLoop() {
 				LanguageCode: resp.LanguageCode,
 				StartTime:    resp.StartTime,
 				EndTime:      resp.EndTime,
+				SpeakerID:    resp.SpeakerID,
+				Segments:     speakerSegments(resp.Words),
+			}
+			if transcript.SpeakerID == "" && len(transcript.Segments) > 0 {
+				transcript.SpeakerID = transcript.Segments[0].SpeakerID
 			}
 			select {
 			case wsc.transcriptOut <- transcript:
@@ -309,6 +363,29 @@ func (wsc *WebSocketSTTConnection) readLoop() {
 	}
 }
 
+// speakerSegments groups consecutive words with the same speaker.
+// Returns nil if no word has a speaker.
+func speakerSegments(words []STTWord) []STTSpeakerSegment {
+	var segments []STTSpeakerSegment
+	for _, w := range words {
+		if w.SpeakerID == "" {
+			continue
+		}
+		if n := len(segments); n > 0 && segments[n-1].SpeakerID == w.SpeakerID {
+			segments[n-1].Text += " " + w.Word
+			segments[n-1].EndTime = w.End
+			continue
+		}
+		segments = append(segments, STTSpeakerSegment{
+			SpeakerID: w.SpeakerID,
+			Text:      w.Word,
+			StartTime: w.Start,
+			EndTime:   w.End,
+		})
+	}
+	return segments
+}
+
 func (wsc *WebSocketSTTConnection) closeChannels() {
 	wsc.closeOnce.Do(func() {
 		close(wsc.closeChan)
@@ -323,6 +400,12 @@ func (wsc *WebSocketSTTConnection) SendAudio(audio []byte) error {
 		return nil
 	}
 
+	if sink := wsc.options.AudioSink; sink != nil {
+		if _, err := sink.Write(audio); err != nil {
+			return fmt.Errorf("writing audio to sink: %w", err)
+		}
+	}
+
 	msg := sttWSAudioMessage{
 		Type:  "audio",
 		Audio: base64.StdEncoding.EncodeToString(audio),
@@ -0,0 +1,136 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestSpeakerSegments(t *testing.T) {
+	words := []STTWord{
+		{Word: "Hello", Start: 0.0, End: 0.4, SpeakerID: "speaker_0"},
+		{Word: "there", Start: 0.5, End: 0.8, SpeakerID: "speaker_0"},
+		{Word: "Hi", Start: 1.0, End: 1.2, SpeakerID: "speaker_1"},
+		{Word: "again", Start: 1.5, End: 1.9, SpeakerID: "speaker_0"},
+	}
+
+	segments := speakerSegments(words)
+	want := []STTSpeakerSegment{
+		{SpeakerID: "speaker_0", Text: "Hello there", StartTime: 0.0, EndTime: 0.8},
+		{SpeakerID: "speaker_1", Text: "Hi", StartTime: 1.0, EndTime: 1.2},
+		{SpeakerID: "speaker_0", Text: "again", StartTime: 1.5, EndTime: 1.9},
+	}
+	if len(segments) != len(want) {
+		t.Fatalf("got %d segments, want %d: %+v", len(segments), len(want), segments)
+	}
+	for i := range want {
+		if segments[i] != want[i] {
+			t.Errorf("segment %d = %+v, want %+v", i, segments[i], want[i])
+		}
+	}
+
+	if got := speakerSegments([]STTWord{{Word: "Hello"}}); got != nil {
+		t.Errorf("speakerSegments() without speakers = %+v, want nil", got)
+	}
+}
+
+func TestWebSocketSTTConnectValidation(t *testing.T) {
+	client, err := NewClient(WithAPIKey("test-key"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	opts := DefaultWebSocketSTTOptions()
+	opts.EnableDiarization = true
+	opts.MaxSpeakers = MaxDiarizationSpeakers + 1
+
+	_, err = client.WebSocketSTT().Connect(context.Background(), opts)
+	var valErr *ValidationError
+	if !isValidationError(err, &valErr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if valErr.Field != "max_speakers" {
+		t.Errorf("Field = %q, want %q", valErr.Field, "max_speakers")
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestWebSocketSTTAudioSink(t *testing.T) {
+	var mu sync.Mutex
+	var received []byte
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			var msg sttWSAudioMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg.Type == "audio" {
+				audio, _ := base64.StdEncoding.DecodeString(msg.Audio)
+				mu.Lock()
+				received = append(received, audio...)
+				mu.Unlock()
+			}
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	var recording bytes.Buffer
+	opts := DefaultWebSocketSTTOptions()
+	opts.AudioSink = &recording
+	conn, err := client.WebSocketSTT().Connect(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	for _, chunk := range [][]byte{{1, 2}, {3, 4, 5}} {
+		if err := conn.SendAudio(chunk); err != nil {
+			t.Fatalf("SendAudio() error = %v", err)
+		}
+	}
+
+	// A failed recording stops the chunk from being transcribed
+	conn.options.AudioSink = failingWriter{}
+	if err := conn.SendAudio([]byte{6}); err == nil {
+		t.Error("SendAudio() with a failing sink: expected error")
+	}
+	conn.Close()
+
+	if !bytes.Equal(recording.Bytes(), []byte{1, 2, 3, 4, 5}) {
+		t.Errorf("recording = %v", recording.Bytes())
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		mu.Lock()
+		got := append([]byte(nil), received...)
+		mu.Unlock()
+		if bytes.Equal(got, recording.Bytes()) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("server received %v, want %v", got, recording.Bytes())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
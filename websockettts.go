@@ -5,13 +5,17 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"net/http"
+	"io"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
 
+// wsCloseFrameTimeout bounds how long sending a WebSocket close frame may take.
+const wsCloseFrameTimeout = time.Second
+
 // WebSocketTTSService handles real-time text-to-speech via WebSocket.
 type WebSocketTTSService struct {
 	client *Client
@@ -49,6 +53,11 @@ type WebSocketTTSOptions struct {
 
 	// PronunciationDictionaryIDs is a list of pronunciation dictionary IDs to use.
 	PronunciationDictionaryIDs []string
+
+	// WordAlignments delivers word timestamps on Words, merged from the
+	// character alignments, for captions and lip sync. Words must then be
+	// read concurrently with Audio; see Words.
+	WordAlignments bool
 }
 
 // DefaultWebSocketTTSOptions returns default options optimized for low latency.
@@ -61,22 +70,31 @@ func DefaultWebSocketTTSOptions() *WebSocketTTSOptions {
 }
 
 // WebSocketTTSConnection represents an active WebSocket TTS connection.
+//
+// The connection is closed when Close or CloseWithTimeout is called, when the
+// context passed to Connect is canceled, or when the server closes it. Done
+// is closed once the connection is closed, and Err reports the reason.
 type WebSocketTTSConnection struct {
-	conn    *websocket.Conn
-	voiceID string
-	options *WebSocketTTSOptions
-	mu      sync.Mutex
-	closed  bool
+	conn     *websocket.Conn
+	voiceID  string
+	options  *WebSocketTTSOptions
+	mu       sync.Mutex
+	closed   bool
+	closeErr error
 
 	// Channels for async operation
 	audioOut  chan []byte
 	alignOut  chan *TTSAlignment
+	wordOut   chan TTSWord
+	words     wordAggregator
 	errChan   chan error
-	closeChan chan struct{}
+	done      chan struct{}
+	readDone  chan struct{}
 	closeOnce sync.Once
 }
 
-// TTSAlignment contains word-level timing information.
+// TTSAlignment contains character-level timing information. Use
+// AggregateWords for word timestamps.
 type TTSAlignment struct {
 	Characters     []string  `json:"characters"`
 	CharacterStart []float64 `json:"character_start_times_seconds"`
@@ -134,29 +152,25 @@ func (s *WebSocketTTSService) Connect(ctx context.Context, voiceID string, opts
 		return nil, err
 	}
 
-	// Create dialer with context
-	dialer := websocket.Dialer{
-		HandshakeTimeout: 0, // Use context timeout
+	if s.client.dryRun {
+		return nil, ErrDryRun
 	}
 
-	// Add headers
-	headers := http.Header{}
-	headers.Set("xi-api-key", s.client.apiKey)
-
-	// Connect
-	conn, _, err := dialer.DialContext(ctx, wsURL, headers)
+	conn, err := s.client.dialWebSocket(ctx, wsURL)
 	if err != nil {
-		return nil, fmt.Errorf("websocket dial failed: %w", err)
+		return nil, err
 	}
 
 	wsc := &WebSocketTTSConnection{
-		conn:      conn,
-		voiceID:   voiceID,
-		options:   opts,
-		audioOut:  make(chan []byte, 100),
-		alignOut:  make(chan *TTSAlignment, 100),
-		errChan:   make(chan error, 1),
-		closeChan: make(chan struct{}),
+		conn:     conn,
+		voiceID:  voiceID,
+		options:  opts,
+		audioOut: make(chan []byte, 100),
+		alignOut: make(chan *TTSAlignment, 100),
+		wordOut:  make(chan TTSWord, 100),
+		errChan:  make(chan error, 1),
+		done:     make(chan struct{}),
+		readDone: make(chan struct{}),
 	}
 
 	// Send initial configuration
@@ -168,6 +182,9 @@ func (s *WebSocketTTSService) Connect(ctx context.Context, voiceID string, opts
 	// Start reading responses
 	go wsc.readLoop()
 
+	// Close the connection when the context is canceled
+	go wsc.watchContext(ctx)
+
 	return wsc, nil
 }
 
@@ -248,30 +265,43 @@ func (wsc *WebSocketTTSConnection) sendJSON(msg any) error {
 	defer wsc.mu.Unlock()
 
 	if wsc.closed {
-		return fmt.Errorf("connection closed")
+		return ErrWebSocketClosed
 	}
 
 	return wsc.conn.WriteJSON(msg)
 }
 
+func (wsc *WebSocketTTSConnection) watchContext(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		_ = wsc.shutdown(ctx.Err())
+	case <-wsc.done:
+	}
+}
+
 func (wsc *WebSocketTTSConnection) readLoop() {
-	defer wsc.closeChannels()
+	// Only the read loop sends on the output channels, so it closes them
+	defer close(wsc.readDone)
+	defer close(wsc.wordOut)
+	defer close(wsc.alignOut)
+	defer close(wsc.audioOut)
 
 	for {
-		select {
-		case <-wsc.closeChan:
-			return
-		default:
-		}
-
 		_, message, err := wsc.conn.ReadMessage()
 		if err != nil {
-			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
-				select {
-				case wsc.errChan <- err:
-				default:
-				}
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				_ = wsc.shutdown(ErrWebSocketClosed)
+				return
+			}
+			if wsc.isClosed() {
+				// Read failed because we closed the connection
+				return
 			}
+			select {
+			case wsc.errChan <- err:
+			default:
+			}
+			_ = wsc.shutdown(err)
 			return
 		}
 
@@ -310,33 +340,82 @@ func (wsc *WebSocketTTSConnection) readLoop() {
 			if len(audioBytes) > 0 {
 				select {
 				case wsc.audioOut <- audioBytes:
-				case <-wsc.closeChan:
+				case <-wsc.done:
 					return
 				}
 			}
 		}
 
 		// Send alignment if available
-		if resp.NormalizedAlignment != nil {
+		alignment := resp.NormalizedAlignment
+		if alignment == nil {
+			alignment = resp.Alignment
+		}
+		if alignment != nil {
 			select {
-			case wsc.alignOut <- resp.NormalizedAlignment:
+			case wsc.alignOut <- alignment:
 			default:
 			}
-		} else if resp.Alignment != nil {
-			select {
-			case wsc.alignOut <- resp.Alignment:
-			default:
+		}
+
+		if wsc.options.WordAlignments {
+			words := wsc.words.add(alignment)
+			if resp.IsFinal {
+				words = append(words, wsc.words.flush()...)
+			}
+			for _, word := range words {
+				select {
+				case wsc.wordOut <- word:
+				case <-wsc.done:
+					return
+				}
 			}
 		}
 	}
 }
 
-func (wsc *WebSocketTTSConnection) closeChannels() {
+// audioChanReader reads audio chunks from a channel.
+type audioChanReader struct {
+	ch  <-chan []byte
+	buf []byte
+}
+
+// Read implements io.Reader.
+func (r *audioChanReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, ok := <-r.ch
+		if !ok {
+			return 0, io.EOF
+		}
+		r.buf = chunk
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// shutdown closes the connection once, recording the reason.
+// It sends a close frame before closing the underlying connection.
+func (wsc *WebSocketTTSConnection) shutdown(reason error) error {
+	var err error
 	wsc.closeOnce.Do(func() {
-		close(wsc.closeChan)
-		close(wsc.audioOut)
-		close(wsc.alignOut)
+		wsc.mu.Lock()
+		wsc.closed = true
+		wsc.closeErr = reason
+		wsc.mu.Unlock()
+
+		closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+		_ = wsc.conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(wsCloseFrameTimeout))
+		err = wsc.conn.Close()
+		close(wsc.done)
 	})
+	return err
+}
+
+func (wsc *WebSocketTTSConnection) isClosed() bool {
+	wsc.mu.Lock()
+	defer wsc.mu.Unlock()
+	return wsc.closed
 }
 
 // SendText sends text to be converted to speech.
@@ -391,35 +470,100 @@ func (wsc *WebSocketTTSConnection) Audio() <-chan []byte {
 	return wsc.audioOut
 }
 
-// Alignments returns a channel that receives word alignment information.
+// Reader returns the audio as a stream, ending with io.EOF once the
+// connection is closed. It reads from the same chunks as Audio, so use one
+// or the other.
+func (wsc *WebSocketTTSConnection) Reader() io.Reader {
+	return &audioChanReader{ch: wsc.audioOut}
+}
+
+// Frames returns the audio as frames of frameDuration for WebRTC and
+// telephony. The output format must be PCM, μ-law, or A-law. Like Reader,
+// it consumes the Audio channel.
+func (wsc *WebSocketTTSConnection) Frames(frameDuration time.Duration) (*FrameEmitter, error) {
+	return NewFrameEmitter(wsc.Reader(), wsc.options.OutputFormat, frameDuration)
+}
+
+// Alignments returns a channel that receives character alignment
+// information.
 func (wsc *WebSocketTTSConnection) Alignments() <-chan *TTSAlignment {
 	return wsc.alignOut
 }
 
+// Words returns a channel that receives word timestamps when the
+// WordAlignments option is set. A word is sent once the whitespace after
+// it, or the end of the generation, is received. The channel is closed
+// when the connection is closed.
+//
+// Words are not dropped, so when the channel's buffer is full, receiving
+// audio waits until words are read. Read Words in a separate goroutine
+// from Audio:
+//
+//	go func() {
+//	    for w := range conn.Words() {
+//	        captions.Add(w)
+//	    }
+//	}()
+//	for chunk := range conn.Audio() {
+//	    player.Write(chunk)
+//	}
+func (wsc *WebSocketTTSConnection) Words() <-chan TTSWord {
+	return wsc.wordOut
+}
+
 // Errors returns a channel that receives errors from the connection.
 func (wsc *WebSocketTTSConnection) Errors() <-chan error {
 	return wsc.errChan
 }
 
-// Close closes the WebSocket connection gracefully.
-func (wsc *WebSocketTTSConnection) Close() error {
+// Done returns a channel that is closed when the connection is closed.
+func (wsc *WebSocketTTSConnection) Done() <-chan struct{} {
+	return wsc.done
+}
+
+// Err returns the reason the connection was closed, or nil if it is open.
+// The reason is ErrWebSocketClosed for normal closes, the context error if
+// the context passed to Connect was canceled, or the read error otherwise.
+func (wsc *WebSocketTTSConnection) Err() error {
 	wsc.mu.Lock()
-	if wsc.closed {
-		wsc.mu.Unlock()
+	defer wsc.mu.Unlock()
+	return wsc.closeErr
+}
+
+// Close closes the WebSocket connection immediately. Audio that has not been
+// received yet is discarded; use CloseWithTimeout to wait for it.
+func (wsc *WebSocketTTSConnection) Close() error {
+	if wsc.isClosed() {
 		return nil
 	}
-	wsc.closed = true
-	wsc.mu.Unlock()
 
-	// Send close message
-	msg := ttsWSMessage{
-		CloseConnection: true,
+	// Ask the server to close the connection
+	_ = wsc.sendJSON(ttsWSMessage{CloseConnection: true})
+
+	return wsc.shutdown(ErrWebSocketClosed)
+}
+
+// CloseWithTimeout flushes buffered text and waits up to timeout for the
+// server to send the remaining audio and close the connection, then closes
+// it. Audio continues to be delivered on Audio while waiting, so keep
+// reading from it.
+func (wsc *WebSocketTTSConnection) CloseWithTimeout(timeout time.Duration) error {
+	if wsc.isClosed() {
+		return nil
+	}
+
+	if err := wsc.Flush(); err == nil {
+		_ = wsc.sendJSON(ttsWSMessage{CloseConnection: true})
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case <-wsc.readDone:
+		case <-timer.C:
+		}
 	}
-	_ = wsc.sendJSON(msg)
 
-	// Close the connection
-	wsc.closeChannels()
-	return wsc.conn.Close()
+	return wsc.shutdown(ErrWebSocketClosed)
 }
 
 // StreamText is a convenience method that sends all text from a channel and returns audio.
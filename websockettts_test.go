@@ -0,0 +1,121 @@
+package elevenlabs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newWebSocketTTSTestServer starts a server that reads messages until the
+// client closes the connection, replying with a single audio chunk to each
+// flush request.
+func newWebSocketTTSTestServer(t *testing.T) *Client {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			var msg ttsWSMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if msg.Flush {
+				_ = conn.WriteJSON(map[string]any{"audio": "AAEC"})
+			}
+			if msg.CloseConnection {
+				closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+				_ = conn.WriteMessage(websocket.CloseMessage, closeMsg)
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestWebSocketTTSContextCancel(t *testing.T) {
+	client := newWebSocketTTSTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	conn, err := client.WebSocketTTS().Connect(ctx, "voice-id", nil)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	cancel()
+
+	select {
+	case <-conn.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Done() not closed after context cancellation")
+	}
+	if !errors.Is(conn.Err(), context.Canceled) {
+		t.Errorf("Err() = %v, want context.Canceled", conn.Err())
+	}
+
+	// Audio channel is closed once the read loop exits
+	for range conn.Audio() {
+	}
+
+	if err := conn.SendText("hello"); !errors.Is(err, ErrWebSocketClosed) {
+		t.Errorf("SendText() after close error = %v, want ErrWebSocketClosed", err)
+	}
+	if err := conn.Close(); err != nil {
+		t.Errorf("Close() after cancellation error = %v", err)
+	}
+}
+
+func TestWebSocketTTSCloseWithTimeout(t *testing.T) {
+	client := newWebSocketTTSTestServer(t)
+
+	conn, err := client.WebSocketTTS().Connect(context.Background(), "voice-id", nil)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	if conn.Err() != nil {
+		t.Errorf("Err() on open connection = %v, want nil", conn.Err())
+	}
+
+	if err := conn.SendText("hello"); err != nil {
+		t.Fatalf("SendText() error = %v", err)
+	}
+
+	received := make(chan int)
+	go func() {
+		total := 0
+		for audio := range conn.Audio() {
+			total += len(audio)
+		}
+		received <- total
+	}()
+
+	if err := conn.CloseWithTimeout(5 * time.Second); err != nil {
+		t.Errorf("CloseWithTimeout() error = %v", err)
+	}
+
+	if total := <-received; total != 3 {
+		t.Errorf("received %d audio bytes, want 3", total)
+	}
+	if !errors.Is(conn.Err(), ErrWebSocketClosed) {
+		t.Errorf("Err() = %v, want ErrWebSocketClosed", conn.Err())
+	}
+	select {
+	case <-conn.Done():
+	default:
+		t.Error("Done() not closed after CloseWithTimeout")
+	}
+}
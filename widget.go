@@ -0,0 +1,246 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+
+	ht "github.com/ogen-go/ogen/http"
+
+	"github.com/agentplexus/go-elevenlabs/internal/api"
+)
+
+// WidgetEmbedScriptURL is the script that renders the embeddable agent widget.
+const WidgetEmbedScriptURL = "https://unpkg.com/@elevenlabs/convai-widget-embed"
+
+// Widget variants and placements.
+const (
+	WidgetVariantTiny          = "tiny"
+	WidgetVariantCompact       = "compact"
+	WidgetVariantFull          = "full"
+	WidgetPlacementTopLeft     = "top-left"
+	WidgetPlacementTop         = "top"
+	WidgetPlacementTopRight    = "top-right"
+	WidgetPlacementBottomLeft  = "bottom-left"
+	WidgetPlacementBottom      = "bottom"
+	WidgetPlacementBottomRight = "bottom-right"
+)
+
+// WidgetAvatar is the avatar shown in the widget.
+type WidgetAvatar struct {
+	// Type is "orb", "url", or "image".
+	Type string `json:"type"`
+
+	// Color1 and Color2 are the orb gradient colors (for "orb").
+	Color1 string `json:"color_1,omitempty"`
+	Color2 string `json:"color_2,omitempty"`
+
+	// URL is the avatar image URL (for "url" and "image").
+	URL string `json:"url,omitempty"`
+}
+
+// WidgetConfig is the appearance and behavior of an agent's embeddable
+// widget. When updating, empty fields are left unchanged.
+type WidgetConfig struct {
+	// Layout
+	Variant   string `json:"variant,omitempty"`
+	Placement string `json:"placement,omitempty"`
+
+	// Avatar is the avatar shown in the widget.
+	Avatar *WidgetAvatar `json:"avatar,omitempty"`
+
+	// Colors, as CSS colors (e.g., "#ffffff").
+	BgColor      string `json:"bg_color,omitempty"`
+	TextColor    string `json:"text_color,omitempty"`
+	BtnColor     string `json:"btn_color,omitempty"`
+	BtnTextColor string `json:"btn_text_color,omitempty"`
+	BorderColor  string `json:"border_color,omitempty"`
+	FocusColor   string `json:"focus_color,omitempty"`
+
+	// Corner radii in pixels.
+	BorderRadius *int `json:"border_radius,omitempty"`
+	BtnRadius    *int `json:"btn_radius,omitempty"`
+
+	// Text shown in the widget.
+	ActionText    string `json:"action_text,omitempty"`
+	StartCallText string `json:"start_call_text,omitempty"`
+	EndCallText   string `json:"end_call_text,omitempty"`
+	ExpandText    string `json:"expand_text,omitempty"`
+	ListeningText string `json:"listening_text,omitempty"`
+	SpeakingText  string `json:"speaking_text,omitempty"`
+
+	// Terms users must accept before starting a conversation.
+	// TermsText is markdown; TermsHTML takes precedence when set.
+	TermsText string `json:"terms_text,omitempty"`
+	TermsHTML string `json:"terms_html,omitempty"`
+	TermsKey  string `json:"terms_key,omitempty"`
+
+	// Behavior
+	FeedbackMode      string `json:"feedback_mode,omitempty"` // "none", "during", "end"
+	TranscriptEnabled *bool  `json:"transcript_enabled,omitempty"`
+	TextInputEnabled  *bool  `json:"text_input_enabled,omitempty"`
+	MicMutingEnabled  *bool  `json:"mic_muting_enabled,omitempty"`
+	DefaultExpanded   *bool  `json:"default_expanded,omitempty"`
+	DisableBanner     *bool  `json:"disable_banner,omitempty"`
+}
+
+// agentWidgetUpdate is the agent PATCH body that updates the widget.
+type agentWidgetUpdate struct {
+	PlatformSettings struct {
+		Widget *WidgetConfig `json:"widget"`
+	} `json:"platform_settings"`
+}
+
+// GetWidgetConfig returns the widget configuration of an agent.
+func (s *AgentsService) GetWidgetConfig(ctx context.Context, agentID string) (*WidgetConfig, error) {
+	if agentID == "" {
+		return nil, &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+
+	var result struct {
+		WidgetConfig WidgetConfig `json:"widget_config"`
+	}
+	if err := s.client.doJSON(ctx, "GET", agentPath(agentID)+"/widget", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result.WidgetConfig, nil
+}
+
+// UpdateWidgetConfig updates the widget configuration of an agent and
+// returns the resulting configuration. Empty fields are left unchanged.
+func (s *AgentsService) UpdateWidgetConfig(ctx context.Context, agentID string, config *WidgetConfig) (*WidgetConfig, error) {
+	if agentID == "" {
+		return nil, &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+	if config == nil {
+		return nil, &ValidationError{Field: "widget", Message: "cannot be nil"}
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	var body agentWidgetUpdate
+	body.PlatformSettings.Widget = config
+
+	var result agentWidgetUpdate
+	if err := s.client.doJSON(ctx, "PATCH", agentPath(agentID), &body, &result); err != nil {
+		return nil, err
+	}
+	if result.PlatformSettings.Widget == nil {
+		return config, nil
+	}
+	return result.PlatformSettings.Widget, nil
+}
+
+func (c *WidgetConfig) validate() error {
+	switch c.Variant {
+	case "", WidgetVariantTiny, WidgetVariantCompact, WidgetVariantFull:
+	default:
+		return &ValidationError{Field: "variant", Message: fmt.Sprintf("unsupported variant %q", c.Variant)}
+	}
+	switch c.Placement {
+	case "", WidgetPlacementTopLeft, WidgetPlacementTop, WidgetPlacementTopRight,
+		WidgetPlacementBottomLeft, WidgetPlacementBottom, WidgetPlacementBottomRight:
+	default:
+		return &ValidationError{Field: "placement", Message: fmt.Sprintf("unsupported placement %q", c.Placement)}
+	}
+	switch c.FeedbackMode {
+	case "", "none", "during", "end":
+	default:
+		return &ValidationError{Field: "feedback_mode", Message: fmt.Sprintf("unsupported feedback mode %q", c.FeedbackMode)}
+	}
+	if c.BorderRadius != nil && *c.BorderRadius < 0 {
+		return &ValidationError{Field: "border_radius", Message: "cannot be negative"}
+	}
+	if c.BtnRadius != nil && *c.BtnRadius < 0 {
+		return &ValidationError{Field: "btn_radius", Message: "cannot be negative"}
+	}
+	return nil
+}
+
+// UploadAvatar uploads an image used as the agent's widget avatar and
+// returns its URL.
+func (s *AgentsService) UploadAvatar(ctx context.Context, agentID, filename string, image io.Reader) (string, error) {
+	if agentID == "" {
+		return "", &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+	if image == nil {
+		return "", &ValidationError{Field: "avatar_file", Message: "cannot be nil"}
+	}
+
+	resp, err := s.client.apiClient.PostAgentAvatarRoute(ctx,
+		&api.BodyPostAgentAvatarV1ConvaiAgentsAgentIDAvatarPostMultipart{
+			AvatarFile: ht.MultipartFile{Name: filename, File: image},
+		},
+		api.PostAgentAvatarRouteParams{AgentID: agentID},
+	)
+	if err != nil {
+		return "", apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.PostAgentAvatarResponseModel:
+		if r.AvatarURL.Set && !r.AvatarURL.Null {
+			return r.AvatarURL.Value, nil
+		}
+		return "", nil
+	case *api.HTTPValidationError:
+		return "", validationAPIError(r)
+	default:
+		return "", unexpectedResponse(resp)
+	}
+}
+
+// WidgetEmbedCode returns the HTML snippet that embeds an agent's widget in
+// a web page. The optional initiation data is rendered as widget attributes
+// for dynamic variables and overrides; overrides must be enabled in the
+// agent's security settings.
+func WidgetEmbedCode(agentID string, data *ConversationInitiationData) (string, error) {
+	if agentID == "" {
+		return "", &ValidationError{Field: "agent_id", Message: "cannot be empty"}
+	}
+
+	attrs := [][2]string{{"agent-id", agentID}}
+	if data != nil {
+		if err := data.Validate(); err != nil {
+			return "", err
+		}
+		if len(data.DynamicVariables) > 0 {
+			vars, err := json.Marshal(data.DynamicVariables)
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal dynamic variables: %w", err)
+			}
+			attrs = append(attrs, [2]string{"dynamic-variables", string(vars)})
+		}
+		overrides := map[string]string{
+			"override-prompt":        data.Prompt,
+			"override-first-message": data.FirstMessage,
+			"override-language":      data.Language,
+			"override-voice-id":      data.VoiceID,
+			"user-id":                data.UserID,
+		}
+		names := make([]string, 0, len(overrides))
+		for name, value := range overrides {
+			if value != "" {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			attrs = append(attrs, [2]string{name, overrides[name]})
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("<elevenlabs-convai")
+	for _, attr := range attrs {
+		fmt.Fprintf(&b, " %s=\"%s\"", attr[0], html.EscapeString(attr[1]))
+	}
+	b.WriteString("></elevenlabs-convai>\n")
+	fmt.Fprintf(&b, "<script src=\"%s\" async type=\"text/javascript\"></script>\n", WidgetEmbedScriptURL)
+	return b.String(), nil
+}
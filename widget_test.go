@@ -0,0 +1,100 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAgentsWidgetConfig(t *testing.T) {
+	var patch map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/convai/agents/agent-1/widget":
+			_, _ = w.Write([]byte(`{"agent_id": "agent-1", "widget_config": {"variant": "compact", "bg_color": "#ffffff", "transcript_enabled": true}}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/convai/agents/agent-1":
+			if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+				t.Errorf("decoding body: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"agent_id": "agent-1", "platform_settings": {"widget": {"variant": "full", "bg_color": "#000000"}}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	config, err := client.Agents().GetWidgetConfig(ctx, "agent-1")
+	if err != nil {
+		t.Fatalf("GetWidgetConfig() error = %v", err)
+	}
+	if config.Variant != WidgetVariantCompact || config.BgColor != "#ffffff" || config.TranscriptEnabled == nil || !*config.TranscriptEnabled {
+		t.Errorf("GetWidgetConfig() = %+v", config)
+	}
+
+	updated, err := client.Agents().UpdateWidgetConfig(ctx, "agent-1", &WidgetConfig{
+		Variant: WidgetVariantFull,
+		BgColor: "#000000",
+	})
+	if err != nil {
+		t.Fatalf("UpdateWidgetConfig() error = %v", err)
+	}
+	settings, _ := patch["platform_settings"].(map[string]any)
+	widget, _ := settings["widget"].(map[string]any)
+	if len(widget) != 2 || widget["variant"] != "full" {
+		t.Errorf("PATCH widget = %v", widget)
+	}
+	if updated.Variant != WidgetVariantFull {
+		t.Errorf("UpdateWidgetConfig() = %+v", updated)
+	}
+
+	_, err = client.Agents().UpdateWidgetConfig(ctx, "agent-1", &WidgetConfig{Placement: "middle"})
+	var valErr *ValidationError
+	if !isValidationError(err, &valErr) || valErr.Field != "placement" {
+		t.Errorf("expected placement ValidationError, got %v", err)
+	}
+}
+
+func TestWidgetEmbedCode(t *testing.T) {
+	code, err := WidgetEmbedCode("agent-1", nil)
+	if err != nil {
+		t.Fatalf("WidgetEmbedCode() error = %v", err)
+	}
+	want := `<elevenlabs-convai agent-id="agent-1"></elevenlabs-convai>` + "\n" +
+		`<script src="` + WidgetEmbedScriptURL + `" async type="text/javascript"></script>` + "\n"
+	if code != want {
+		t.Errorf("WidgetEmbedCode() =\n%s\nwant\n%s", code, want)
+	}
+
+	code, err = WidgetEmbedCode("agent-1", &ConversationInitiationData{
+		DynamicVariables: map[string]any{"name": "Ada"},
+		FirstMessage:     `Hi "Ada"`,
+		Language:         "fr",
+	})
+	if err != nil {
+		t.Fatalf("WidgetEmbedCode() error = %v", err)
+	}
+	for _, attr := range []string{
+		`dynamic-variables="{&#34;name&#34;:&#34;Ada&#34;}"`,
+		`override-first-message="Hi &#34;Ada&#34;"`,
+		`override-language="fr"`,
+	} {
+		if !strings.Contains(code, attr) {
+			t.Errorf("WidgetEmbedCode() missing %s:\n%s", attr, code)
+		}
+	}
+
+	if _, err := WidgetEmbedCode("", nil); err == nil {
+		t.Error("WidgetEmbedCode() with empty agent ID should fail")
+	}
+}
@@ -0,0 +1,235 @@
+package elevenlabs
+
+import (
+	"context"
+	"slices"
+
+	"github.com/agentplexus/go-elevenlabs/internal/api"
+)
+
+// WorkspaceService manages workspace resources and who can access them.
+type WorkspaceService struct {
+	client *Client
+}
+
+// Workspace resource types for sharing.
+const (
+	WorkspaceResourceVoice                   = "voice"
+	WorkspaceResourceVoiceCollection         = "voice_collection"
+	WorkspaceResourcePronunciationDictionary = "pronunciation_dictionary"
+	WorkspaceResourceDubbing                 = "dubbing"
+	WorkspaceResourceProject                 = "project"
+	WorkspaceResourceAgent                   = "convai_agents"
+	WorkspaceResourceKnowledgeBaseDocument   = "convai_knowledge_base_documents"
+	WorkspaceResourceTool                    = "convai_tools"
+)
+
+// Workspace resource roles, from most to least access.
+const (
+	WorkspaceRoleAdmin     = "admin"
+	WorkspaceRoleEditor    = "editor"
+	WorkspaceRoleCommenter = "commenter"
+	WorkspaceRoleViewer    = "viewer"
+)
+
+// WorkspaceGroupDefault is the group ID for the access every workspace
+// member has to a resource by default.
+const WorkspaceGroupDefault = "default"
+
+// SharePrincipal identifies who a resource is shared with. Set exactly
+// one field.
+type SharePrincipal struct {
+	// UserEmail is the email of a user or service account.
+	UserEmail string
+
+	// GroupID is the ID of a workspace group, or WorkspaceGroupDefault.
+	GroupID string
+
+	// WorkspaceAPIKeyID is the ID of a workspace API key, as shown in the
+	// workspace settings. This is not the key itself.
+	WorkspaceAPIKeyID string
+}
+
+// ShareWithUser returns a principal for a user or service account.
+func ShareWithUser(email string) SharePrincipal {
+	return SharePrincipal{UserEmail: email}
+}
+
+// ShareWithGroup returns a principal for a workspace group.
+func ShareWithGroup(groupID string) SharePrincipal {
+	return SharePrincipal{GroupID: groupID}
+}
+
+// ShareWithAPIKey returns a principal for a workspace API key.
+func ShareWithAPIKey(keyID string) SharePrincipal {
+	return SharePrincipal{WorkspaceAPIKeyID: keyID}
+}
+
+// Validate checks that exactly one field is set.
+func (p SharePrincipal) Validate() error {
+	set := 0
+	for _, v := range []string{p.UserEmail, p.GroupID, p.WorkspaceAPIKeyID} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return &ValidationError{Field: "principal", Message: "exactly one of user email, group ID, or workspace API key ID must be set"}
+	}
+	return nil
+}
+
+// WorkspaceResource is the sharing state of a resource.
+type WorkspaceResource struct {
+	// ResourceID is the ID of the resource.
+	ResourceID string
+
+	// ResourceType is one of the WorkspaceResource constants.
+	ResourceType string
+
+	// CreatorUserID is the ID of the user who created the resource.
+	CreatorUserID string
+
+	// Roles maps each role to the group IDs that hold it. When the
+	// resource is shared with a user, the group ID is the user's ID.
+	Roles map[string][]string
+}
+
+// HasRole reports whether groupID holds role on the resource.
+func (r *WorkspaceResource) HasRole(groupID, role string) bool {
+	return slices.Contains(r.Roles[role], groupID)
+}
+
+// GetResource returns who has access to a resource.
+func (s *WorkspaceService) GetResource(ctx context.Context, resourceType, resourceID string) (*WorkspaceResource, error) {
+	if err := validateResource(resourceType, resourceID); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.apiClient.GetResourceMetadata(ctx, api.GetResourceMetadataParams{
+		ResourceID:   resourceID,
+		ResourceType: api.WorkspaceResourceType(resourceType),
+	})
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.ResourceMetadataResponseModel:
+		resource := &WorkspaceResource{
+			ResourceID:   r.ResourceID,
+			ResourceType: string(r.ResourceType),
+			Roles:        make(map[string][]string, len(r.RoleToGroupIds)),
+		}
+		if !r.CreatorUserID.Null {
+			resource.CreatorUserID = r.CreatorUserID.Value
+		}
+		for role, groups := range r.RoleToGroupIds {
+			resource.Roles[role] = groups
+		}
+		return resource, nil
+	case *api.HTTPValidationError:
+		return nil, validationAPIError(r)
+	default:
+		return nil, unexpectedResponse(resp)
+	}
+}
+
+// ShareResource grants principal a role on a resource, replacing any role
+// it already has:
+//
+//	err := client.Workspace().ShareResource(ctx, elevenlabs.WorkspaceResourceAgent, agentID,
+//	    elevenlabs.ShareWithUser("dev@example.com"), elevenlabs.WorkspaceRoleEditor)
+func (s *WorkspaceService) ShareResource(ctx context.Context, resourceType, resourceID string, principal SharePrincipal, role string) error {
+	if err := validateResource(resourceType, resourceID); err != nil {
+		return err
+	}
+	if err := principal.Validate(); err != nil {
+		return err
+	}
+	switch role {
+	case WorkspaceRoleAdmin, WorkspaceRoleEditor, WorkspaceRoleCommenter, WorkspaceRoleViewer:
+	default:
+		return &ValidationError{Field: "role", Message: "must be admin, editor, commenter, or viewer"}
+	}
+
+	body := &api.BodyShareWorkspaceResourceV1WorkspaceResourcesResourceIDSharePost{
+		ResourceType: api.WorkspaceResourceType(resourceType),
+		Role:         api.BodyShareWorkspaceResourceV1WorkspaceResourcesResourceIDSharePostRole(role),
+	}
+	if principal.UserEmail != "" {
+		body.UserEmail = api.NewOptNilString(principal.UserEmail)
+	}
+	if principal.GroupID != "" {
+		body.GroupID = api.NewOptNilString(principal.GroupID)
+	}
+	if principal.WorkspaceAPIKeyID != "" {
+		body.WorkspaceAPIKeyID = api.NewOptNilString(principal.WorkspaceAPIKeyID)
+	}
+
+	resp, err := s.client.apiClient.ShareResourceEndpoint(ctx, body, api.ShareResourceEndpointParams{
+		ResourceID: resourceID,
+	})
+	if err != nil {
+		return apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.ShareResourceEndpointOKApplicationJSON:
+		return nil
+	case *api.HTTPValidationError:
+		return validationAPIError(r)
+	default:
+		return unexpectedResponse(resp)
+	}
+}
+
+// UnshareResource removes principal's access to a resource. The creator
+// of a resource always keeps admin access.
+func (s *WorkspaceService) UnshareResource(ctx context.Context, resourceType, resourceID string, principal SharePrincipal) error {
+	if err := validateResource(resourceType, resourceID); err != nil {
+		return err
+	}
+	if err := principal.Validate(); err != nil {
+		return err
+	}
+
+	body := &api.BodyUnshareWorkspaceResourceV1WorkspaceResourcesResourceIDUnsharePost{
+		ResourceType: api.WorkspaceResourceType(resourceType),
+	}
+	if principal.UserEmail != "" {
+		body.UserEmail = api.NewOptNilString(principal.UserEmail)
+	}
+	if principal.GroupID != "" {
+		body.GroupID = api.NewOptNilString(principal.GroupID)
+	}
+	if principal.WorkspaceAPIKeyID != "" {
+		body.WorkspaceAPIKeyID = api.NewOptNilString(principal.WorkspaceAPIKeyID)
+	}
+
+	resp, err := s.client.apiClient.UnshareResourceEndpoint(ctx, body, api.UnshareResourceEndpointParams{
+		ResourceID: resourceID,
+	})
+	if err != nil {
+		return apiError(err)
+	}
+
+	switch r := resp.(type) {
+	case *api.UnshareResourceEndpointOKApplicationJSON:
+		return nil
+	case *api.HTTPValidationError:
+		return validationAPIError(r)
+	default:
+		return unexpectedResponse(resp)
+	}
+}
+
+func validateResource(resourceType, resourceID string) error {
+	if resourceType == "" {
+		return &ValidationError{Field: "resource_type", Message: "cannot be empty"}
+	}
+	if resourceID == "" {
+		return &ValidationError{Field: "resource_id", Message: "cannot be empty"}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newWorkspaceTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestWorkspaceShareResource(t *testing.T) {
+	var got map[string]any
+	client := newWorkspaceTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/workspace/resources/agent-1/share" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	err := client.Workspace().ShareResource(context.Background(), WorkspaceResourceAgent, "agent-1",
+		ShareWithUser("dev@example.com"), WorkspaceRoleEditor)
+	if err != nil {
+		t.Fatalf("ShareResource() error = %v", err)
+	}
+	if got["resource_type"] != "convai_agents" || got["role"] != "editor" || got["user_email"] != "dev@example.com" {
+		t.Errorf("body = %v", got)
+	}
+	if _, ok := got["group_id"]; ok {
+		t.Errorf("body has group_id: %v", got)
+	}
+}
+
+func TestWorkspaceUnshareResource(t *testing.T) {
+	var got map[string]any
+	client := newWorkspaceTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/workspace/resources/voice-1/unshare" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	err := client.Workspace().UnshareResource(context.Background(), WorkspaceResourceVoice, "voice-1", ShareWithGroup("group-1"))
+	if err != nil {
+		t.Fatalf("UnshareResource() error = %v", err)
+	}
+	if got["resource_type"] != "voice" || got["group_id"] != "group-1" {
+		t.Errorf("body = %v", got)
+	}
+}
+
+func TestWorkspaceGetResource(t *testing.T) {
+	client := newWorkspaceTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/workspace/resources/proj-1" || r.URL.Query().Get("resource_type") != "project" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"resource_id": "proj-1",
+			"resource_type": "project",
+			"creator_user_id": "user-1",
+			"anonymous_access_level_override": null,
+			"role_to_group_ids": {"admin": ["user-1"], "viewer": ["default", "group-2"]},
+			"share_options": []
+		}`))
+	})
+
+	resource, err := client.Workspace().GetResource(context.Background(), WorkspaceResourceProject, "proj-1")
+	if err != nil {
+		t.Fatalf("GetResource() error = %v", err)
+	}
+	if resource.CreatorUserID != "user-1" || resource.ResourceType != WorkspaceResourceProject {
+		t.Errorf("resource = %+v", resource)
+	}
+	if !resource.HasRole("group-2", WorkspaceRoleViewer) || resource.HasRole("group-2", WorkspaceRoleAdmin) {
+		t.Errorf("Roles = %v", resource.Roles)
+	}
+}
+
+func TestWorkspaceValidation(t *testing.T) {
+	client, _ := NewClient()
+	ctx := context.Background()
+	ws := client.Workspace()
+	var valErr *ValidationError
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"empty type", ws.ShareResource(ctx, "", "id", ShareWithUser("a@example.com"), WorkspaceRoleViewer)},
+		{"empty id", ws.ShareResource(ctx, WorkspaceResourceVoice, "", ShareWithUser("a@example.com"), WorkspaceRoleViewer)},
+		{"no principal", ws.ShareResource(ctx, WorkspaceResourceVoice, "id", SharePrincipal{}, WorkspaceRoleViewer)},
+		{"two principals", ws.ShareResource(ctx, WorkspaceResourceVoice, "id", SharePrincipal{UserEmail: "a@example.com", GroupID: "g"}, WorkspaceRoleViewer)},
+		{"bad role", ws.ShareResource(ctx, WorkspaceResourceVoice, "id", ShareWithAPIKey("key-1"), "owner")},
+		{"unshare no principal", ws.UnshareResource(ctx, WorkspaceResourceVoice, "id", SharePrincipal{})},
+	}
+	for _, tt := range tests {
+		if !isValidationError(tt.err, &valErr) {
+			t.Errorf("%s: expected ValidationError, got %v", tt.name, tt.err)
+		}
+	}
+}